@@ -0,0 +1,56 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestChallongeSeedingGivesTopSeedsByes(t *testing.T) {
+	parti := make([]*partici, 5)
+	for i := range parti {
+		parti[i] = &partici{id: uuid.New(), seed: i + 1}
+	}
+	res := challongeSeeding(parti, nextPower(len(parti)))
+
+	var seeds []int
+	for _, p := range res {
+		if p == nil {
+			seeds = append(seeds, 0)
+			continue
+		}
+		seeds = append(seeds, p.seed)
+	}
+	if want := []int{1, 4, 2, 5, 3, 0, 0, 0}; !reflect.DeepEqual(seeds, want) {
+		t.Fatalf("expected %v, got %v", want, seeds)
+	}
+}
+
+func TestPositions(t *testing.T) {
+	if got := generateByePos(8, 5); !reflect.DeepEqual(got, []int{0, 2, 4}) {
+		t.Fatalf("generateByePos(8, 5) = %v", got)
+	}
+	if got := generateMatchPos(8, []int{0, 2, 4}); !reflect.DeepEqual(got, []int{1, 3, 5, 6, 7}) {
+		t.Fatalf("generateMatchPos = %v", got)
+	}
+	for n, want := range map[int]int{3: 4, 5: 8, 8: 8, 9: 16} {
+		if got := nextPower(n); got != want {
+			t.Errorf("nextPower(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestSingleElimBracketSplitsByes(t *testing.T) {
+	parti := make([]*partici, 7)
+	for i := range parti {
+		parti[i] = &partici{id: uuid.New(), seed: 7 - i}
+	}
+	byes, remaining := singleElimBracket(parti)
+	if len(byes) != 1 || byes[0].seed != 1 {
+		t.Fatalf("the top seed should have the only bye, got %v", byes)
+	}
+	if len(remaining) != 6 {
+		t.Fatalf("expected six players in round 1, got %d", len(remaining))
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// The prototype's types serialize like the tournament service's matches
+func TestMatchJSON(t *testing.T) {
+	winner := uuid.New()
+	data, err := json.Marshal(&Match{ID: uuid.New(), Round: 2, WinnerID: &winner, Status: MatchCompleted, BracketType: LosersBracket})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	for _, field := range []string{`"round":2`, `"winner_id":"` + winner.String() + `"`, `"status":"COMPLETED"`, `"bracket_type":"LOSERS"`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("expected %s in %s", field, data)
+		}
+	}
+	// Unset optional references are left out
+	if strings.Contains(string(data), "participant1_id") || strings.Contains(string(data), "next_match_id") {
+		t.Fatalf("empty references should be omitted, got %s", data)
+	}
+}
+
+func TestParticipantJSON(t *testing.T) {
+	var p Participant
+	if err := json.Unmarshal([]byte(`{"participant_name":"Ace","seed":3,"status":"WAITLISTED","is_waitlisted":true}`), &p); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if p.ParticipantName != "Ace" || p.Seed != 3 || p.Status != ParticipantWaitlisted || !p.IsWaitlisted || p.UserID != nil {
+		t.Fatalf("unexpected participant %+v", p)
+	}
+}
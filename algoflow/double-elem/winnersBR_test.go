@@ -0,0 +1,134 @@
+package doubleelem
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"algoflow/domain"
+
+	"github.com/google/uuid"
+)
+
+// seeded returns n participants seeded 1..n
+func seeded(n int) []*domain.Participant {
+	participants := make([]*domain.Participant, n)
+	for i := range participants {
+		participants[i] = &domain.Participant{ID: uuid.New(), ParticipantName: fmt.Sprintf("seed%d", i+1), Seed: i + 1}
+	}
+	return participants
+}
+
+// layout renders seeded slots as their seeds, "-" for an empty slot
+func layout(slots []*domain.Participant) string {
+	cells := make([]string, len(slots))
+	for i, p := range slots {
+		cells[i] = "-"
+		if p != nil {
+			cells[i] = fmt.Sprint(p.Seed)
+		}
+	}
+	return strings.Join(cells, " ")
+}
+
+// The prototype's seeding must agree with the tournament service's seeding package
+// (internal/service/bracket/seeding/testdata/challonge.golden)
+func TestApplyChallongeSeedingMatchesTheServiceSeeder(t *testing.T) {
+	for n, want := range map[int]string{
+		3:  "1 2 3 -",
+		5:  "1 4 2 5 3 - - -",
+		6:  "1 3 2 4 5 6 - -",
+		8:  "1 2 3 4 5 6 7 8",
+		11: "1 6 2 7 3 8 4 9 5 10 11 - - - - -",
+	} {
+		if got := layout(applyChallongeSeeding(seeded(n), nextPowerOfTwo(n))); got != want {
+			t.Errorf("%d players: got %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestSingleEliminationBracketShape(t *testing.T) {
+	for n := 3; n <= 16; n++ {
+		matches, rounds, err := NewSingleEliminationGenerator().Generate(context.Background(), uuid.New(), SingleElimination, seeded(n), nil)
+		if err != nil {
+			t.Fatalf("%d players: %v", n, err)
+		}
+		if len(matches) != n-1 {
+			t.Errorf("%d players: expected %d matches, got %d", n, n-1, len(matches))
+		}
+		final := rounds[len(rounds)-1]
+		if len(final) != 1 || final[0].NextMatchID != nil {
+			t.Errorf("%d players: expected a single final, got %d matches", n, len(final))
+		}
+		for _, match := range matches {
+			if match != final[0] && match.NextMatchID == nil {
+				t.Errorf("%d players: match %d does not lead anywhere", n, match.MatchNumber)
+			}
+		}
+		// Everyone without a bye plays in round 1
+		byes := nextPowerOfTwo(n) - n
+		if got := len(rounds[1]); got != (n-byes)/2 {
+			t.Errorf("%d players: expected %d first-round matches, got %d", n, (n-byes)/2, got)
+		}
+	}
+}
+
+func TestGenerateRejectsBadInput(t *testing.T) {
+	g := NewSingleEliminationGenerator()
+	if _, _, err := g.Generate(context.Background(), uuid.New(), SingleElimination, seeded(1), nil); err == nil {
+		t.Fatal("one participant should be rejected")
+	}
+	if _, _, err := g.Generate(context.Background(), uuid.New(), Swiss, seeded(4), nil); err == nil {
+		t.Fatal("an unsupported format should be rejected")
+	}
+}
+
+func TestGrandFinalsWithReset(t *testing.T) {
+	winnersFinal, losersFinal := &domain.Match{ID: uuid.New()}, &domain.Match{ID: uuid.New()}
+	matches, err := NewDoubleEliminationGenerator().generateGrandFinals(context.Background(), uuid.New(), winnersFinal, losersFinal, true)
+	if err != nil {
+		t.Fatalf("generateGrandFinals: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected the grand final and its reset, got %d matches", len(matches))
+	}
+	grandFinal, reset := matches[0], matches[1]
+	if *winnersFinal.NextMatchID != grandFinal.ID || *losersFinal.NextMatchID != grandFinal.ID || *grandFinal.NextMatchID != reset.ID {
+		t.Fatal("both finals should feed the grand final, and the grand final the reset")
+	}
+
+	matches, _ = NewDoubleEliminationGenerator().generateGrandFinals(context.Background(), uuid.New(), winnersFinal, losersFinal, false)
+	if len(matches) != 1 || matches[0].NextMatchID != nil {
+		t.Fatal("without a reset there is a single grand final")
+	}
+}
+
+func TestGenerateLosersPairsDroppedPlayers(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	winnerRounds := [][]*domain.Match{
+		{{LoserID: &a}, {LoserID: &b}, {LoserID: &c}},
+		{{LoserID: &d}},
+	}
+	g := &DoubleElimGenerator{SingleElim: NewSingleEliminationGenerator()}
+
+	losers, err := g.GenerateLosers(context.Background(), uuid.New(), winnerRounds)
+	if err != nil {
+		t.Fatalf("GenerateLosers: %v", err)
+	}
+	if len(losers) != 2 || len(losers[0]) != 1 || len(losers[1]) != 1 {
+		t.Fatalf("expected one match in each of two losers rounds, got %v", losers)
+	}
+	first, second := losers[0][0], losers[1][0]
+	if first.BracketType != domain.LosersBracket || first.MatchNumber != 1000 || second.MatchNumber != 1001 {
+		t.Fatalf("losers matches are numbered from 1000, got %d and %d", first.MatchNumber, second.MatchNumber)
+	}
+	// The odd loser out waits a round and meets the next player to drop
+	if *second.Participant1ID != c || *second.Participant2ID != d {
+		t.Fatal("the waiting loser should meet the winners round 2 loser")
+	}
+
+	if _, err := g.GenerateLosers(context.Background(), uuid.New(), winnerRounds[:1]); err == nil {
+		t.Fatal("a single winners round should be rejected")
+	}
+}
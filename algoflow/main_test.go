@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// The demo simulates an 8-player double elimination bracket to the end
+func TestMainRunsTheDemo(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var out bytes.Buffer
+		io.Copy(&out, r)
+		done <- out.String()
+	}()
+
+	main()
+	w.Close()
+	os.Stdout = stdout
+	out := <-done
+
+	if !strings.Contains(out, "Simulating Winner's Bracket Round 1 results") {
+		t.Fatalf("the demo did not simulate the winners bracket:\n%s", out)
+	}
+}
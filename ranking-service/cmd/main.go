@@ -7,13 +7,17 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	// Adjust import paths as per your project structure
 	"github.com/cliffdoyle/ranking-service/internal/client" // Your new client package
+	"github.com/cliffdoyle/ranking-service/internal/domain"
 	"github.com/cliffdoyle/ranking-service/internal/handler"
 	"github.com/cliffdoyle/ranking-service/internal/repository"
 	"github.com/cliffdoyle/ranking-service/internal/service"
@@ -45,8 +49,11 @@ func main() {
 	}
 	// Add defaults for other DB vars if needed
 
-	dbConnStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=require",
-		dbHost, dbPort, dbUser, dbPass, dbName)
+	// Postgres cancels any statement running longer than RANKING_DB_STATEMENT_TIMEOUT (default 5s)
+	dbStatementTimeout := getDurationEnvOrDefault("RANKING_DB_STATEMENT_TIMEOUT", 5*time.Second)
+
+	dbConnStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=require statement_timeout=%d",
+		dbHost, dbPort, dbUser, dbPass, dbName, dbStatementTimeout.Milliseconds())
 
 	db, err := sql.Open("postgres", dbConnStr)
 	if err != nil {
@@ -58,17 +65,39 @@ func main() {
 	}
 	log.Println("Successfully connected to ranking database")
 
+	// RANKING_GAME_ALIASES adds games to the built-in registry, e.g. "apex-legends=Apex Legends|apex;halo=Halo Infinite"
+	gameAliases, err := parseGameAliases(os.Getenv("RANKING_GAME_ALIASES"))
+	if err != nil {
+		log.Fatalf("Invalid RANKING_GAME_ALIASES: %v", err)
+	}
+	if err := domain.RegisterGameAliases(gameAliases); err != nil {
+		log.Fatalf("Invalid RANKING_GAME_ALIASES: %v", err)
+	}
+
 	// --- Initialize Layers ---
-	rankingRepo := repository.NewRankingRepository(db)
+	// Draws keep a win streak going unless RANKING_DRAWS_BREAK_STREAK is "true"
+	// Leaderboard players level on points share a rank unless LEADERBOARD_TIES=sequential
+	tieRanking := domain.TiesShared
+	if os.Getenv("LEADERBOARD_TIES") == string(domain.TiesSequential) {
+		tieRanking = domain.TiesSequential
+	}
+	rankingRepo := repository.NewRankingRepository(db, os.Getenv("RANKING_DRAWS_BREAK_STREAK") == "true", tieRanking)
 
 	// Instantiate the HTTP User Service Client
 	userServiceURL := os.Getenv("USER_SERVICE_URL") // e.g., "http://localhost:8081" (port of user-service)
-	// interServiceKey := os.Getenv("INTERNAL_SERVICE_KEY") // For securing inter-service calls
+	interServiceKey := os.Getenv("INTERNAL_SERVICE_KEY") // For securing inter-service calls
+	if interServiceKey == "" {
+		log.Println("Warning: INTERNAL_SERVICE_KEY not set. Match results will be accepted from any caller.")
+	}
 
 	if userServiceURL == "" {
 		log.Fatal("USER_SERVICE_URL environment variable is not set. Cannot initialize UserServiceClient.")
 	}
-	userServiceClient,_ := client.NewHTTPUserServiceClient(userServiceURL /*, interServiceKey */)
+	// Name lookups are sent to the user service in chunks of USER_SERVICE_BATCH_SIZE IDs (default 50)
+	userBatchSize, _ := strconv.Atoi(os.Getenv("USER_SERVICE_BATCH_SIZE"))
+	// Each of those requests gives up after USER_SERVICE_TIMEOUT (default 5s)
+	userServiceTimeout := getDurationEnvOrDefault("USER_SERVICE_TIMEOUT", client.DefaultUserServiceTimeout)
+	userServiceClient,_ := client.NewHTTPUserServiceClient(userServiceURL, userBatchSize, userServiceTimeout /*, interServiceKey */)
 	// In ranking-service/cmd/main.go, after creating userServiceClient
 if userServiceClient == nil {
     log.Fatal("FATAL: UserServiceClient is nil after instantiation!")
@@ -76,28 +105,87 @@ if userServiceClient == nil {
     log.Println("UserServiceClient initialized successfully.")
 }
 
-	rankingSvc := service.NewRankingService(rankingRepo, userServiceClient) // Pass the client
+	// Players need LEADERBOARD_MIN_GAMES matches (default 1) before they are listed on leaderboards
+	minGames, err := strconv.Atoi(os.Getenv("LEADERBOARD_MIN_GAMES"))
+	if err != nil || minGames < 1 {
+		minGames = 1
+	}
+	// Elo-rated matches move ratings by at most ELO_K_FACTOR points (default 32)
+	eloKFactor, _ := strconv.Atoi(os.Getenv("ELO_K_FACTOR")) // Unset or invalid falls back to the default
+	rankingSvc := service.NewRankingService(rankingRepo, userServiceClient, minGames, eloKFactor) // Pass the client
 	rankingHandler := handler.NewRankingHandler(rankingSvc)
 
+	// Inactive players lose RANKING_DECAY_PERCENT of their score (default 0, disabled) once every
+	// RANKING_DECAY_INTERVAL after RANKING_DECAY_INACTIVITY without a result
+	decayPercent, err := strconv.Atoi(os.Getenv("RANKING_DECAY_PERCENT"))
+	if err != nil {
+		decayPercent = 0
+	}
+	scoreDecayer := service.NewScoreDecayer(
+		rankingRepo,
+		decayPercent,
+		getDurationEnvOrDefault("RANKING_DECAY_INACTIVITY", 30*24*time.Hour),
+		getDurationEnvOrDefault("RANKING_DECAY_INTERVAL", 24*time.Hour),
+	)
+	decayCtx, stopDecay := context.WithCancel(context.Background())
+	defer stopDecay()
+	go scoreDecayer.Run(decayCtx)
+
 	// --- Setup Gin Router ---
 	router := gin.Default()
 
 	config := cors.DefaultConfig()
 	// Ensure your tournament service (e.g., localhost:8082) and frontend (e.g. localhost:3000) are allowed
-	config.AllowOrigins = []string{"http://localhost:3000", "http://localhost:8082"}
+	origins, err := parseAllowedOrigins(os.Getenv("CORS_ALLOWED_ORIGINS"), []string{"http://localhost:3000", "http://localhost:8082"})
+	if err != nil {
+		log.Fatalf("Invalid CORS_ALLOWED_ORIGINS: %v", err)
+	}
 	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
 	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Internal-Service-Key"} // Add if you use it
-	config.AllowCredentials = true
+	if origins[0] == "*" {
+		// Browsers refuse credentialed responses to a wildcard origin
+		config.AllowAllOrigins = true
+		config.AllowCredentials = false
+	} else {
+		config.AllowOrigins = origins
+		config.AllowCredentials = true
+	}
+	log.Printf("CORS allowed origins: %s", strings.Join(origins, ", "))
 	router.Use(cors.New(config))
 
 	// --- Routes ---
 	rg := router.Group("/rankings")
 	{
-		rg.POST("/match-results", rankingHandler.ProcessMatchResults)
+		rg.POST("/match-results", checkInternalServiceKey(interServiceKey), rankingHandler.ProcessMatchResults)
 		rg.GET("/users/:userId", rankingHandler.GetUserRanking)    // userId here is UUID string
+		rg.GET("/users/:userId/games", rankingHandler.GetUserGameRankings)
+		rg.GET("/users/:userId/history", rankingHandler.GetScoreHistory)
 		rg.GET("/leaderboard", rankingHandler.GetLeaderboard)
+		rg.GET("/leaderboards", rankingHandler.GetLeaderboards)
+		rg.GET("/head-to-head", rankingHandler.GetHeadToHead)
+		rg.GET("/seasons", rankingHandler.ListSeasons)
+		rg.GET("/seasons/:seasonId/leaderboard", rankingHandler.GetSeasonLeaderboard)
+		// Admin routes need the X-Internal-Service-Key header
+		admin := rg.Group("/admin", requireInternalServiceKey())
+		// Runs decay now instead of waiting for the next interval
+		admin.POST("/apply-decay", func(c *gin.Context) {
+			if !scoreDecayer.Enabled() {
+				c.JSON(http.StatusConflict, gin.H{"error": "Score decay is disabled; set RANKING_DECAY_PERCENT"})
+				return
+			}
+			decayed, err := scoreDecayer.Apply(c.Request.Context())
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply score decay: " + err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"decayed": decayed})
+		})
+		admin.POST("/recalculate/:userId", rankingHandler.RecalculateUserScore)
+		admin.POST("/seasons", rankingHandler.StartSeason)
 	}
 	router.GET("/health", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "ranking-service-ok"}) })
+	// Readiness checks that the database answers; /health stays a pure liveness check
+	router.GET("/ready", readyHandler(db))
 
 	// --- Start Server ---
 	srv := &http.Server{
@@ -124,4 +212,117 @@ if userServiceClient == nil {
 		log.Fatalf("Ranking Service forced to shutdown: %v", err)
 	}
 	log.Println("Ranking Service exited properly")
-}
\ No newline at end of file
+}
+
+// readyHandler reports whether the database answers a ping, with how long the ping took.
+// It responds 503 when the database is unreachable so readiness probes take the instance out.
+func readyHandler(db *sql.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+		defer cancel()
+		start := time.Now()
+		err := db.PingContext(ctx)
+		latencyMs := float64(time.Since(start).Microseconds()) / 1000
+		if err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "database": "down", "db_latency_ms": latencyMs, "error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready", "database": "up", "db_latency_ms": latencyMs})
+	}
+}
+
+// getDurationEnvOrDefault reads a duration such as "24h" from the environment
+func getDurationEnvOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// requireInternalServiceKey rejects requests whose X-Internal-Service-Key header does not
+// match INTERNAL_SERVICE_KEY; with no key configured every request is rejected
+func requireInternalServiceKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := os.Getenv("INTERNAL_SERVICE_KEY")
+		if key == "" || c.GetHeader("X-Internal-Service-Key") != key {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "A valid X-Internal-Service-Key header is required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// checkInternalServiceKey rejects requests whose X-Internal-Service-Key header does not match
+// key with 401. An empty key disables the check, for local development.
+func checkInternalServiceKey(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key != "" && c.GetHeader("X-Internal-Service-Key") != key {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid X-Internal-Service-Key header"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// parseGameAliases reads a RANKING_GAME_ALIASES value: games separated by ";", each a
+// canonical ID optionally followed by "=" and its aliases separated by "|"
+func parseGameAliases(raw string) (map[string][]string, error) {
+	games := make(map[string][]string)
+	for _, entry := range strings.Split(raw, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		id, rawAliases, _ := strings.Cut(entry, "=")
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return nil, fmt.Errorf("entry %q has no game ID", entry)
+		}
+		for _, alias := range strings.Split(rawAliases, "|") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				games[id] = append(games[id], alias)
+			}
+		}
+		if _, ok := games[id]; !ok {
+			games[id] = nil
+		}
+	}
+	return games, nil
+}
+
+// parseAllowedOrigins reads a comma-separated CORS_ALLOWED_ORIGINS value such as
+// "https://app.example.com,https://admin.example.com", falling back to defaults when it is empty.
+// A lone "*" allows every origin. Each origin must be an http(s) scheme and host with no path.
+func parseAllowedOrigins(raw string, defaults []string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return defaults, nil
+	}
+	var origins []string
+	for _, origin := range strings.Split(raw, ",") {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		if origin == "" {
+			continue
+		}
+		if origin == "*" {
+			origins = append(origins, origin)
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return nil, fmt.Errorf("invalid CORS origin %q: expected scheme://host[:port]", origin)
+		}
+		origins = append(origins, origin)
+	}
+	if len(origins) == 0 {
+		return defaults, nil
+	}
+	if len(origins) > 1 {
+		for _, origin := range origins {
+			if origin == "*" {
+				return nil, fmt.Errorf("CORS origin \"*\" cannot be combined with other origins")
+			}
+		}
+	}
+	return origins, nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// openConnector is a database that always accepts connections
+type openConnector struct{}
+
+func (openConnector) Connect(context.Context) (driver.Conn, error) { return openConn{}, nil }
+func (openConnector) Driver() driver.Driver                        { return nil }
+
+type openConn struct{}
+
+func (openConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (openConn) Close() error                        { return nil }
+func (openConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+// ready calls readyHandler against db and returns the status and the decoded body
+func ready(t *testing.T, db *sql.DB) (int, map[string]interface{}) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/ready", nil)
+	readyHandler(db)(c)
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	return recorder.Code, body
+}
+
+func TestReadyHandler(t *testing.T) {
+	status, body := ready(t, sql.OpenDB(openConnector{}))
+	if status != http.StatusOK || body["database"] != "up" {
+		t.Fatalf("reachable database: expected 200 and up, got %d %v", status, body)
+	}
+	if _, ok := body["db_latency_ms"].(float64); !ok {
+		t.Fatalf("expected the ping latency in the body, got %v", body)
+	}
+
+	closed := sql.OpenDB(openConnector{})
+	closed.Close()
+	status, body = ready(t, closed)
+	if status != http.StatusServiceUnavailable || body["database"] != "down" {
+		t.Fatalf("closed database: expected 503 and down, got %d %v", status, body)
+	}
+}
+
+func TestParseAllowedOrigins(t *testing.T) {
+	defaults := []string{"http://localhost:3000"}
+	for raw, want := range map[string][]string{
+		"":    defaults,
+		" , ": defaults,
+		"https://app.example.com/, http://localhost:8080": {"https://app.example.com", "http://localhost:8080"},
+		" * ": {"*"},
+	} {
+		origins, err := parseAllowedOrigins(raw, defaults)
+		if err != nil {
+			t.Fatalf("parseAllowedOrigins(%q): %v", raw, err)
+		}
+		if len(origins) != len(want) {
+			t.Fatalf("parseAllowedOrigins(%q) = %v, want %v", raw, origins, want)
+		}
+		for i := range want {
+			if origins[i] != want[i] {
+				t.Fatalf("parseAllowedOrigins(%q) = %v, want %v", raw, origins, want)
+			}
+		}
+	}
+
+	for _, raw := range []string{
+		"app.example.com",
+		"ftp://files.example.com",
+		"https://app.example.com/path",
+		"https://user@app.example.com",
+		"*,https://app.example.com",
+	} {
+		if _, err := parseAllowedOrigins(raw, defaults); err == nil {
+			t.Errorf("parseAllowedOrigins(%q) should be rejected", raw)
+		}
+	}
+}
+
+func TestParseGameAliases(t *testing.T) {
+	games, err := parseGameAliases(" apex-legends = Apex Legends | apex ;; halo=Halo Infinite|; splitgate ")
+	if err != nil {
+		t.Fatalf("parseGameAliases: %v", err)
+	}
+	want := map[string][]string{"apex-legends": {"Apex Legends", "apex"}, "halo": {"Halo Infinite"}, "splitgate": nil}
+	if len(games) != len(want) {
+		t.Fatalf("parseGameAliases = %v, want %v", games, want)
+	}
+	for id, aliases := range want {
+		got, ok := games[id]
+		if !ok || len(got) != len(aliases) {
+			t.Fatalf("%s: got %v, want %v", id, got, aliases)
+		}
+		for i := range aliases {
+			if got[i] != aliases[i] {
+				t.Fatalf("%s: got %v, want %v", id, got, aliases)
+			}
+		}
+	}
+
+	if games, err := parseGameAliases(""); err != nil || len(games) != 0 {
+		t.Fatalf("an unset variable should add no games, got %v, %v", games, err)
+	}
+	if _, err := parseGameAliases("=Apex"); err == nil {
+		t.Fatal("an entry without a game ID should be rejected")
+	}
+}
+
+// guarded sends a request with the given X-Internal-Service-Key (none if empty) through guard
+// and returns the status
+func guarded(guard gin.HandlerFunc, key string) int {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/", guard, func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	request := httptest.NewRequest(http.MethodPost, "/", nil)
+	if key != "" {
+		request.Header.Set("X-Internal-Service-Key", key)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder.Code
+}
+
+func TestAdminRoutesRequireTheServiceKey(t *testing.T) {
+	t.Setenv("INTERNAL_SERVICE_KEY", "")
+	if status := guarded(requireInternalServiceKey(), "anything"); status != http.StatusForbidden {
+		t.Fatalf("without a configured key admin routes stay closed, got %d", status)
+	}
+
+	t.Setenv("INTERNAL_SERVICE_KEY", "s3cret")
+	for key, want := range map[string]int{"": http.StatusForbidden, "wrong": http.StatusForbidden, "s3cret": http.StatusNoContent} {
+		if status := guarded(requireInternalServiceKey(), key); status != want {
+			t.Errorf("key %q: expected %d, got %d", key, want, status)
+		}
+	}
+}
+
+func TestMatchResultsCheckTheServiceKey(t *testing.T) {
+	for key, want := range map[string]int{"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "s3cret": http.StatusNoContent} {
+		if status := guarded(checkInternalServiceKey("s3cret"), key); status != want {
+			t.Errorf("key %q: expected %d, got %d", key, want, status)
+		}
+	}
+	// No key configured leaves ingestion open for local development
+	if status := guarded(checkInternalServiceKey(""), ""); status != http.StatusNoContent {
+		t.Fatalf("an unset key should disable the check, got %d", status)
+	}
+}
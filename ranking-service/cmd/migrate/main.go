@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cliffdoyle/ranking-service/internal/database"
+	"github.com/cliffdoyle/ranking-service/internal/migrations"
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found for ranking-service")
+	}
+	log.Println("Starting ranking database migration...")
+
+	db, err := sql.Open("postgres", connectionString())
+	if err != nil {
+		log.Fatalf("Failed to connect to ranking database: %v", err)
+	}
+	defer db.Close()
+
+	applied, err := database.RunMigrations(context.Background(), db, migrations.FS)
+	if err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+
+	log.Printf("Migration completed successfully (%d applied)", len(applied))
+}
+
+// connectionString builds the database connection string from the same settings as the
+// service itself
+func connectionString() string {
+	dbHost := os.Getenv("RANKING_DB_HOST")
+	if dbHost == "" {
+		dbHost = "localhost"
+	}
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=require",
+		dbHost, os.Getenv("RANKING_DB_PORT"), os.Getenv("RANKING_DB_USER"),
+		os.Getenv("RANKING_DB_PASSWORD"), os.Getenv("RANKING_DB_NAME"))
+}
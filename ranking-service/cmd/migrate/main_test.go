@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestConnectionString(t *testing.T) {
+	t.Setenv("RANKING_DB_HOST", "")
+	t.Setenv("RANKING_DB_PORT", "5433")
+	t.Setenv("RANKING_DB_USER", "ranker")
+	t.Setenv("RANKING_DB_PASSWORD", "secret")
+	t.Setenv("RANKING_DB_NAME", "ranking_db")
+	if got, want := connectionString(), "host=localhost port=5433 user=ranker password=secret dbname=ranking_db sslmode=require"; got != want {
+		t.Fatalf("default host: got %q, want %q", got, want)
+	}
+
+	t.Setenv("RANKING_DB_HOST", "db.internal")
+	if got, want := connectionString(), "host=db.internal port=5433 user=ranker password=secret dbname=ranking_db sslmode=require"; got != want {
+		t.Fatalf("host override: got %q, want %q", got, want)
+	}
+}
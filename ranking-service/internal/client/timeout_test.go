@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBatchRequestsToASlowUserServiceTimeOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices the client hanging up once the body has been read
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	client, _ := NewHTTPUserServiceClient(server.URL, 50, 50*time.Millisecond)
+
+	start := time.Now()
+	_, err := client.GetMultipleUserDetails(context.Background(), newIDs(3))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("took %s despite a 50ms timeout", elapsed)
+	}
+
+	// A caller's cancellation ends the request just the same
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetMultipleUserDetails(ctx, newIDs(3)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancellation to propagate, got %v", err)
+	}
+}
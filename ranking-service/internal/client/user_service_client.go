@@ -9,6 +9,7 @@ import (
 	"log"
 	"net/http"
 	"net/url" // For robust URL joining
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,16 +30,29 @@ type UserServiceClient interface {
 	GetMultipleUserDetails(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]UserDetails, error)
 }
 
+// DefaultUserBatchSize is how many user IDs go in one batch request unless configured otherwise
+const DefaultUserBatchSize = 50
+
+// DefaultUserServiceTimeout bounds each batch request unless configured otherwise
+const DefaultUserServiceTimeout = 5 * time.Second
+
+// maxConcurrentBatches bounds how many batch requests are in flight at once
+const maxConcurrentBatches = 4
+
 // httpUserServiceClient implements UserServiceClient using HTTP.
 type httpUserServiceClient struct {
-	baseURL *url.URL // Store as parsed URL
-	client  *http.Client
+	baseURL   *url.URL // Store as parsed URL
+	client    *http.Client
+	batchSize int // Most user IDs sent in one request to the User Service
+	timeout   time.Duration // Limit for each request; the caller's context can end it sooner
 	// interServiceKey string
 }
 
 // NewHTTPUserServiceClient creates a new HTTP client for the User Service.
-// It now returns an error if the baseURL is invalid.
-func NewHTTPUserServiceClient(baseURLStr string /*, interServiceKey string */) (UserServiceClient, error) {
+// It now returns an error if the baseURL is invalid. Lookups are split into requests of
+// at most batchSize IDs, each given timeout to complete; batchSize <= 0 uses
+// DefaultUserBatchSize and timeout <= 0 DefaultUserServiceTimeout.
+func NewHTTPUserServiceClient(baseURLStr string, batchSize int, timeout time.Duration /*, interServiceKey string */) (UserServiceClient, error) {
 	if baseURLStr == "" {
 		// Return an error instead of just logging, so the calling code knows initialization failed.
 		return nil, fmt.Errorf("USER_SERVICE_URL is not set for HTTPUserServiceClient")
@@ -48,16 +62,25 @@ func NewHTTPUserServiceClient(baseURLStr string /*, interServiceKey string */) (
 		return nil, fmt.Errorf("invalid base URL '%s' for user service client: %w", baseURLStr, err)
 	}
 
+	if batchSize <= 0 {
+		batchSize = DefaultUserBatchSize
+	}
+	if timeout <= 0 {
+		timeout = DefaultUserServiceTimeout
+	}
+
 	return &httpUserServiceClient{
 		baseURL: parsedBaseURL,
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		client:    &http.Client{},
+		batchSize: batchSize,
+		timeout:   timeout,
 		// interServiceKey: interServiceKey,
 	}, nil
 }
 
 // GetMultipleUserDetails fetches details for multiple users from the User Service.
+// IDs are sent in chunks of batchSize, a few chunks at a time, and the results merged.
+// A failed chunk is logged and skipped; an error is returned only if every chunk fails.
 func (c *httpUserServiceClient) GetMultipleUserDetails(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]UserDetails, error) {
 	if c.baseURL == nil { // Check if client was properly initialized
 		return nil, fmt.Errorf("user service client not properly initialized (baseURL is nil)")
@@ -66,6 +89,57 @@ func (c *httpUserServiceClient) GetMultipleUserDetails(ctx context.Context, user
 		return make(map[uuid.UUID]UserDetails), nil
 	}
 
+	var chunks [][]uuid.UUID
+	for start := 0; start < len(userIDs); start += c.batchSize {
+		end := start + c.batchSize
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+		chunks = append(chunks, userIDs[start:end])
+	}
+	if len(chunks) == 1 {
+		return c.fetchUserDetails(ctx, chunks[0])
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		results  = make(map[uuid.UUID]UserDetails, len(userIDs))
+		failures int
+		lastErr  error
+	)
+	sem := make(chan struct{}, maxConcurrentBatches)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk []uuid.UUID) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			details, err := c.fetchUserDetails(ctx, chunk)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("[UserServiceClient] Warning: batch %d of %d (%d userIDs) failed: %v", i+1, len(chunks), len(chunk), err)
+				failures++
+				lastErr = err
+				return
+			}
+			for id, detail := range details {
+				results[id] = detail
+			}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	if failures == len(chunks) {
+		return nil, lastErr
+	}
+	return results, nil
+}
+
+// fetchUserDetails sends one batch request for userIDs to the User Service.
+func (c *httpUserServiceClient) fetchUserDetails(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]UserDetails, error) {
 	// The User Service endpoint expects UUIDs as strings in the JSON payload
 	userIDStrings := make([]string, len(userIDs))
 	for i, id := range userIDs {
@@ -87,6 +161,9 @@ func (c *httpUserServiceClient) GetMultipleUserDetails(ctx context.Context, user
 
 	log.Printf("[UserServiceClient] Sending batch user details request to: %s with %d userIDs", targetURL.String(), len(userIDStrings))
 
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
 	req, err := http.NewRequestWithContext(ctx, "POST", targetURL.String(), bytes.NewBuffer(payloadBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create batch request to user service (%s): %w", targetURL.String(), err)
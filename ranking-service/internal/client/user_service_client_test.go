@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// userService is a fake User Service batch endpoint recording the size of every request
+type userService struct {
+	*httptest.Server
+	mu             sync.Mutex
+	batches        []int
+	inFlight, peak int
+	failFor        map[string]bool // A batch containing one of these IDs fails
+	delay          time.Duration
+}
+
+func newUserService(t *testing.T) *userService {
+	us := &userService{failFor: map[string]bool{}}
+	us.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			UserIDs []string `json:"user_ids"`
+		}
+		if r.URL.Path != "/users/batch" || json.NewDecoder(r.Body).Decode(&request) != nil {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		us.mu.Lock()
+		us.batches = append(us.batches, len(request.UserIDs))
+		if us.inFlight++; us.inFlight > us.peak {
+			us.peak = us.inFlight
+		}
+		us.mu.Unlock()
+		defer func() {
+			us.mu.Lock()
+			us.inFlight--
+			us.mu.Unlock()
+		}()
+		time.Sleep(us.delay)
+
+		users := map[string]UserDetails{}
+		for _, id := range request.UserIDs {
+			if us.failFor[id] {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			users[id] = UserDetails{Username: "user-" + id[:8]}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"users": users})
+	}))
+	t.Cleanup(us.Close)
+	return us
+}
+
+func newIDs(n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	return ids
+}
+
+func TestGetMultipleUserDetailsChunksAndMerges(t *testing.T) {
+	server := newUserService(t)
+	client, err := NewHTTPUserServiceClient(server.URL, 50, time.Second)
+	if err != nil {
+		t.Fatalf("NewHTTPUserServiceClient: %v", err)
+	}
+	ids := newIDs(120)
+
+	details, err := client.GetMultipleUserDetails(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("GetMultipleUserDetails: %v", err)
+	}
+
+	sort.Ints(server.batches)
+	if len(server.batches) != 3 || server.batches[0] != 20 || server.batches[1] != 50 || server.batches[2] != 50 {
+		t.Fatalf("expected batches of 50, 50 and 20, got %v", server.batches)
+	}
+	if len(details) != len(ids) {
+		t.Fatalf("expected %d merged users, got %d", len(ids), len(details))
+	}
+	for _, id := range ids {
+		if details[id].ID != id || details[id].Username != "user-"+id.String()[:8] {
+			t.Fatalf("wrong details for %s: %+v", id, details[id])
+		}
+	}
+}
+
+func TestGetMultipleUserDetailsSkipsAFailedBatch(t *testing.T) {
+	server := newUserService(t)
+	client, _ := NewHTTPUserServiceClient(server.URL, 50, time.Second)
+	ids := newIDs(120)
+	server.failFor[ids[60].String()] = true // In the second batch
+
+	details, err := client.GetMultipleUserDetails(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("one failed batch should not fail the lookup: %v", err)
+	}
+	if len(details) != 70 {
+		t.Fatalf("expected the 70 users of the other batches, got %d", len(details))
+	}
+	if _, ok := details[ids[60]]; ok {
+		t.Fatal("users of the failed batch should be missing")
+	}
+
+	server.failFor[ids[0].String()], server.failFor[ids[100].String()] = true, true
+	if _, err := client.GetMultipleUserDetails(context.Background(), ids); err == nil {
+		t.Fatal("expected an error when every batch fails")
+	}
+}
+
+func TestGetMultipleUserDetailsBoundsConcurrency(t *testing.T) {
+	server := newUserService(t)
+	server.delay = 20 * time.Millisecond
+	client, _ := NewHTTPUserServiceClient(server.URL, 10, time.Second)
+
+	details, err := client.GetMultipleUserDetails(context.Background(), newIDs(100))
+	if err != nil {
+		t.Fatalf("GetMultipleUserDetails: %v", err)
+	}
+	if len(details) != 100 || len(server.batches) != 10 {
+		t.Fatalf("expected 100 users in 10 batches, got %d in %d", len(details), len(server.batches))
+	}
+	if server.peak > maxConcurrentBatches {
+		t.Fatalf("at most %d batches should be in flight, saw %d", maxConcurrentBatches, server.peak)
+	}
+}
+
+func TestNewHTTPUserServiceClientDefaults(t *testing.T) {
+	if _, err := NewHTTPUserServiceClient("", 0, 0); err == nil {
+		t.Fatal("an empty URL should be rejected")
+	}
+	client, err := NewHTTPUserServiceClient("http://users:8081", 0, 0)
+	if err != nil {
+		t.Fatalf("NewHTTPUserServiceClient: %v", err)
+	}
+	c := client.(*httpUserServiceClient)
+	if c.batchSize != DefaultUserBatchSize || c.timeout != DefaultUserServiceTimeout {
+		t.Fatalf("expected the defaults, got batch %d and timeout %s", c.batchSize, c.timeout)
+	}
+}
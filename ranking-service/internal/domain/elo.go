@@ -0,0 +1,29 @@
+package domain
+
+import "math"
+
+// DefaultRating is the Elo rating every player starts at
+const DefaultRating = 1500
+
+// DefaultEloKFactor is the largest rating change one match can cause
+const DefaultEloKFactor = 32
+
+// EloScore is what an outcome is worth in the Elo formula: 1 for a win, 0.5 for a draw, 0 for a loss
+func EloScore(outcome ResultType) float64 {
+	switch outcome {
+	case Win:
+		return 1
+	case Draw:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// EloChange returns how much a player rated rating gains (or, when negative, loses) from scoring
+// score against an opponent rated opponentRating, rounded to a whole point. The opponent's change
+// is the negation, so every match is zero-sum.
+func EloChange(rating, opponentRating int, score float64, kFactor int) int {
+	expected := 1 / (1 + math.Pow(10, float64(opponentRating-rating)/400))
+	return int(math.Round(float64(kFactor) * (score - expected)))
+}
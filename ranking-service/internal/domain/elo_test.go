@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestEloChange(t *testing.T) {
+	for _, c := range []struct {
+		name             string
+		rating, opponent int
+		outcome          ResultType
+		want             int
+	}{
+		{"equal ratings, win", 1500, 1500, Win, 16},
+		{"equal ratings, loss", 1500, 1500, Loss, -16},
+		{"equal ratings, draw", 1500, 1500, Draw, 0},
+		{"underdog win", 1400, 1800, Win, 29},
+		{"favourite win", 1800, 1400, Win, 3},
+		{"favourite draw", 1800, 1400, Draw, -13},
+	} {
+		if got := EloChange(c.rating, c.opponent, EloScore(c.outcome), DefaultEloKFactor); got != c.want {
+			t.Errorf("%s: expected %d, got %d", c.name, c.want, got)
+		}
+	}
+	if got := EloChange(1500, 1500, EloScore(Win), 10); got != 5 {
+		t.Fatalf("the K-factor caps the change: expected 5, got %d", got)
+	}
+}
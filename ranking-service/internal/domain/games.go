@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// gameAliases lists each supported game's canonical ID with the other spellings that map to
+// it. It mirrors SupportedGames in the tournament service's domain package; add new titles
+// to both so leaderboards are not split across spellings of the same game. Deployments can
+// add titles without a release with RegisterGameAliases.
+var gameAliases = map[string][]string{
+	"fifa23":            {"FIFA 23"},
+	"fc24":              {"EA Sports FC 24", "eafc24", "fifa24"},
+	"fc25":              {"EA Sports FC 25", "eafc25", "fifa25"},
+	"valorant":          {"Valorant"},
+	"cs2":               {"Counter-Strike 2", "counterstrike2"},
+	"league-of-legends": {"League of Legends", "lol"},
+	"dota2":             {"Dota 2"},
+	"rocket-league":     {"Rocket League", "rl"},
+	"street-fighter-6":  {"Street Fighter 6", "sf6"},
+	"tekken8":           {"Tekken 8", "t8"},
+	"fortnite":          {"Fortnite"},
+	"chess":             {"Chess"},
+}
+
+var (
+	// gameLookupMu guards gameLookup and unknownGames
+	gameLookupMu sync.RWMutex
+	// gameLookup maps the normalized ID and aliases of every supported game to its ID
+	gameLookup = func() map[string]string {
+		lookup := make(map[string]string)
+		for id, aliases := range gameAliases {
+			addGameAliases(lookup, id, aliases)
+		}
+		return lookup
+	}()
+	// unknownGames holds the unrecognized game IDs already logged, so each is logged once
+	unknownGames = make(map[string]bool)
+)
+
+// addGameAliases maps id and its aliases to id in lookup
+func addGameAliases(lookup map[string]string, id string, aliases []string) {
+	lookup[gameKey(id)] = id
+	for _, alias := range aliases {
+		lookup[gameKey(alias)] = id
+	}
+}
+
+// RegisterGameAliases adds games to the registry, or more aliases to a game already in it.
+// Keys are canonical IDs and must already be slugs ("apex-legends"); an alias already
+// mapped to another game is moved to the new one.
+func RegisterGameAliases(games map[string][]string) error {
+	for id := range games {
+		if id == "" || SlugifyGameID(id) != id {
+			return fmt.Errorf("game ID %q must be lowercase letters, digits and hyphens", id)
+		}
+	}
+	gameLookupMu.Lock()
+	defer gameLookupMu.Unlock()
+	for id, aliases := range games {
+		addGameAliases(gameLookup, id, aliases)
+	}
+	return nil
+}
+
+// lookupGameID returns the canonical ID of a registered game, logging unrecognized names the
+// first time they are seen
+func lookupGameID(gameID string) (string, bool) {
+	key := gameKey(gameID)
+	gameLookupMu.RLock()
+	id, ok := gameLookup[key]
+	logged := unknownGames[key]
+	gameLookupMu.RUnlock()
+	if ok || logged {
+		return id, ok
+	}
+
+	gameLookupMu.Lock()
+	if !unknownGames[key] {
+		unknownGames[key] = true
+		log.Printf("Unrecognized game ID %q, using %q; register it with RANKING_GAME_ALIASES if it is another name for a known game",
+			gameID, SlugifyGameID(gameID))
+	}
+	gameLookupMu.Unlock()
+	return "", false
+}
+
+// SlugifyGameID lowercases a game name and joins its runs of letters and digits with
+// hyphens, so "My Game!" and "my-game" share a leaderboard
+func SlugifyGameID(name string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingHyphen = true
+	}
+	return b.String()
+}
+
+// gameKey reduces a game name to lowercase letters and digits, so "FIFA 23", "fifa-23" and
+// "FIFA23" all compare equal
+func gameKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
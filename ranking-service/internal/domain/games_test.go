@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestResolveGameIDNormalizesAliases(t *testing.T) {
+	for gameID, want := range map[string]string{
+		"":                "global",
+		"Global":          "global",
+		"FIFA23":          "fifa23",
+		"fifa 23":         "fifa23",
+		"FC24":            "fc24",
+		"fifa24":          "fc24",
+		"EA Sports FC 25": "fc25",
+		"lol":             "league-of-legends",
+		"Rocket League":   "rocket-league",
+		"My Custom Game!": "my-custom-game",
+		"my-custom-game":  "my-custom-game",
+	} {
+		if got := ResolveGameID(gameID); got != want {
+			t.Errorf("ResolveGameID(%q) = %q, want %q", gameID, got, want)
+		}
+	}
+}
+
+func TestRegisterGameAliases(t *testing.T) {
+	if err := RegisterGameAliases(map[string][]string{"apex-legends": {"Apex", "apex legends"}, "fc24": {"fut24"}}); err != nil {
+		t.Fatalf("RegisterGameAliases: %v", err)
+	}
+	for gameID, want := range map[string]string{"APEX": "apex-legends", "Apex Legends": "apex-legends", "FUT 24": "fc24", "fifa24": "fc24"} {
+		if got := ResolveGameID(gameID); got != want {
+			t.Errorf("ResolveGameID(%q) = %q, want %q", gameID, got, want)
+		}
+	}
+
+	for _, id := range []string{"", "Apex Legends", "apex_legends"} {
+		if err := RegisterGameAliases(map[string][]string{id: nil}); err == nil {
+			t.Errorf("game ID %q should be rejected", id)
+		}
+	}
+}
+
+func TestSlugifyGameID(t *testing.T) {
+	for name, want := range map[string]string{"My Game!": "my-game", "  --Big  Game 2-- ": "big-game-2", "!!": ""} {
+		if got := SlugifyGameID(name); got != want {
+			t.Errorf("SlugifyGameID(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestUnrecognizedGamesAreLoggedOnce(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	for _, gameID := range []string{"Splitgate Arena", "splitgate-arena", "SPLITGATE ARENA", "Chess"} {
+		ResolveGameID(gameID)
+	}
+	if lines := strings.Count(logged.String(), "Unrecognized game ID"); lines != 1 || !strings.Contains(logged.String(), `"splitgate-arena"`) {
+		t.Fatalf("expected one warning naming the slug, got %q", logged.String())
+	}
+}
+
+func TestUnknownGamesKeepSeparateIDs(t *testing.T) {
+	if apex, halo := ResolveGameID("Apex Mobile"), ResolveGameID("Halo Wars"); apex == halo || apex == defaultGameID || halo == defaultGameID {
+		t.Fatalf("distinct unknown games should not share a leaderboard, got %q and %q", apex, halo)
+	}
+}
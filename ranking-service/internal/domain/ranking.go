@@ -8,17 +8,24 @@ import (
 
 type UserOverallStats struct {
 	UserID            uuid.UUID `json:"userId"`
+	Username          string    `json:"username"`              // From User Service, "Player" if the lookup fails
+	DisplayName       string    `json:"displayName,omitempty"` // From User Service
 	GameID            string    `json:"gameId"` // e.g., "global" or a specific game
 	Level             int       `json:"level"`
 	RankTitle         string    `json:"rankTitle"`  // "Bronze", "Gold", etc.
-	Points            int       `json:"points"`     // Current points from 3-1-0 system
+	Points            int       `json:"points"`     // Current points, 3-1-0 unless a tournament configures its own
+	Rating            int       `json:"rating"`     // Elo rating, moved only by matches reported with elo set
 	GlobalRank        int       `json:"globalRank"` // Numerical position in leaderboard
 	WinRate           float64   `json:"winRate"`    // 0.0 to 1.0
 	TotalGamesPlayed  int       `json:"totalGamesPlayed"`
 	MatchesWon        int       `json:"matchesWon"`
 	MatchesDrawn      int       `json:"matchesDrawn"`
 	MatchesLost       int       `json:"matchesLost"`
+	CurrentStreak     int       `json:"currentStreak"` // Consecutive wins up to the latest result
+	LongestStreak     int       `json:"longestStreak"` // Best run of consecutive wins
 	TournamentsPlayed int       `json:"tournamentsPlayed"` // Count of distinct game_ids they have a score in, or more accurately from User Service
+	Provisional       bool      `json:"provisional"`       // Fewer matches than the leaderboard minimum, so not listed on it yet
+	SeasonID          *uuid.UUID `json:"seasonId,omitempty"` // The current season, which these stats belong to
 	UpdatedAt         time.Time `json:"updatedAt"`
 }
 
@@ -26,9 +33,27 @@ type LeaderboardEntry struct {
 	Rank     int       `json:"rank"`
 	UserID   uuid.UUID `json:"userId"`
 	UserName string    `json:"userName,omitempty"` // Optional, if fetched from User Service
+	DisplayName string `json:"displayName,omitempty"`
 	Score    int       `json:"score"`              // Total points
+	Rating   int       `json:"rating"`             // Elo rating
 }
 
+// LeaderboardSort is what a leaderboard is ordered by
+type LeaderboardSort string
+
+const (
+	SortByPoints LeaderboardSort = "points" // Default
+	SortByRating LeaderboardSort = "rating"
+)
+
+// TieRanking is how leaderboard ranks are numbered for players level on the sorted measure
+type TieRanking string
+
+const (
+	TiesShared     TieRanking = "shared"     // Default; standard competition ranking, 1, 2, 2, 4
+	TiesSequential TieRanking = "sequential" // Every player gets the next rank, 1, 2, 3, 4
+)
+
 type ResultType string
 
 const (
@@ -37,12 +62,65 @@ const (
 	Loss ResultType = "LOSS"
 )
 
+// MatchEventType distinguishes a first result from a correction of an already reported one
+type MatchEventType string
+
+const (
+	MatchEventResult     MatchEventType = "RESULT"     // Default; ignored if the match was already processed
+	MatchEventCorrection MatchEventType = "CORRECTION" // Reverses the previously applied outcome, then applies this one
+	MatchEventReversal   MatchEventType = "REVERSAL"   // Reverses the previously applied outcome; the match can then be reported again
+)
+
 type MatchResultEvent struct {
+	Type         MatchEventType     `json:"type,omitempty"`
 	GameID       string             `json:"gameId,omitempty"`
 	Users        []UserMatchOutcome `json:"users" binding:"required,dive"`
 	MatchID      uuid.UUID          `json:"matchId,omitempty"`
 	TournamentID uuid.UUID          `json:"tournamentId,omitempty"` // ADDED: Useful for tracking tournament participation
 	Timestamp    time.Time          `json:"timestamp"`
+	// Optional per-tournament points; any value left out falls back to DefaultPointsConfig
+	PointsWin  *int `json:"pointsWin,omitempty"`
+	PointsDraw *int `json:"pointsDraw,omitempty"`
+	PointsLoss *int `json:"pointsLoss,omitempty"`
+	// Elo also moves both players' ratings; it only applies to events with exactly two users
+	Elo bool `json:"elo,omitempty"`
+}
+
+// PointsConfig defines how many ranking points each match result is worth
+type PointsConfig struct {
+	Win  int
+	Draw int
+	Loss int
+}
+
+// DefaultPointsConfig is the conventional 3/1/0 scoring
+var DefaultPointsConfig = PointsConfig{Win: 3, Draw: 1, Loss: 0}
+
+// Points resolves the event's points configuration, filling gaps from DefaultPointsConfig
+func (e MatchResultEvent) Points() PointsConfig {
+	points := DefaultPointsConfig
+	if e.PointsWin != nil {
+		points.Win = *e.PointsWin
+	}
+	if e.PointsDraw != nil {
+		points.Draw = *e.PointsDraw
+	}
+	if e.PointsLoss != nil {
+		points.Loss = *e.PointsLoss
+	}
+	return points
+}
+
+// PointsFor returns the points awarded for an outcome; unknown outcomes score as a loss
+func (p PointsConfig) PointsFor(outcome ResultType) int {
+	switch outcome {
+	case Win:
+		return p.Win
+	case Draw:
+		return p.Draw
+	default:
+		return p.Loss
+	}
 }
 type UserMatchOutcome struct {
 	UserID  uuid.UUID  `json:"userId" binding:"required"`
@@ -51,9 +129,48 @@ type UserMatchOutcome struct {
 
 const defaultGameID = "global"
 
+// ResolveGameID maps an empty game ID to the global leaderboard and any spelling of a
+// registered game to its canonical ID. Other game IDs keep their own leaderboard under their
+// slug (SlugifyGameID), and are logged the first time they are seen.
 func ResolveGameID(gameID string) string {
-	if gameID == "" {
+	if key := gameKey(gameID); key == "" || key == defaultGameID {
 		return defaultGameID
 	}
-	return gameID
+	if id, ok := lookupGameID(gameID); ok {
+		return id
+	}
+	return SlugifyGameID(gameID)
+}
+
+// ScoreChange is one step in a player's score history
+type ScoreChange struct {
+	MatchID    *uuid.UUID `json:"matchId,omitempty"` // Absent for inactivity decay
+	Delta      int        `json:"delta"`
+	ScoreAfter int        `json:"scoreAfter"`
+	At         time.Time  `json:"at"`
+}
+
+// ScoreHistory is how a player's score in one game changed over time, oldest first
+type ScoreHistory struct {
+	UserID  uuid.UUID     `json:"userId"`
+	GameID  string        `json:"gameId"`
+	Changes []ScoreChange `json:"changes"`
+}
+
+// HeadToHeadMeeting is one match between two players, seen from the first player's side
+type HeadToHeadMeeting struct {
+	MatchID  uuid.UUID  `json:"matchId"`
+	Outcome  ResultType `json:"outcome"` // The result for userA
+	PlayedAt time.Time  `json:"playedAt"`
+}
+
+// HeadToHead is the record of userA against userB in one game
+type HeadToHead struct {
+	UserA    uuid.UUID           `json:"userA"`
+	UserB    uuid.UUID           `json:"userB"`
+	GameID   string              `json:"gameId"`
+	Wins     int                 `json:"wins"` // Matches userA won
+	Losses   int                 `json:"losses"`
+	Draws    int                 `json:"draws"`
+	Meetings []HeadToHeadMeeting `json:"meetings"` // Most recent first
 }
@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func intPtr(v int) *int { return &v }
+
+func TestMatchResultEventPointsFillsGapsFromDefaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		event MatchResultEvent
+		want  PointsConfig
+	}{
+		{"none configured", MatchResultEvent{}, DefaultPointsConfig},
+		{"win only", MatchResultEvent{PointsWin: intPtr(2)}, PointsConfig{Win: 2, Draw: 1, Loss: 0}},
+		{"explicit zero draw", MatchResultEvent{PointsDraw: intPtr(0)}, PointsConfig{Win: 3, Draw: 0, Loss: 0}},
+		{"all configured", MatchResultEvent{PointsWin: intPtr(5), PointsDraw: intPtr(2), PointsLoss: intPtr(1)}, PointsConfig{Win: 5, Draw: 2, Loss: 1}},
+	}
+	for _, tt := range tests {
+		if got := tt.event.Points(); got != tt.want {
+			t.Errorf("%s: want %+v, got %+v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestPointsForUnknownOutcomeScoresAsLoss(t *testing.T) {
+	points := PointsConfig{Win: 4, Draw: 2, Loss: 1}
+	for outcome, want := range map[ResultType]int{Win: 4, Draw: 2, Loss: 1, ResultType("FORFEIT"): 1} {
+		if got := points.PointsFor(outcome); got != want {
+			t.Errorf("%s: want %d, got %d", outcome, want, got)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Season is a ranking period. Live scores belong to the current season, the one without EndedAt;
+// starting a new season archives them and starts everyone from zero.
+type Season struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	StartedAt time.Time  `json:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+}
+
+// StartSeasonRequest names the season being started
+type StartSeasonRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
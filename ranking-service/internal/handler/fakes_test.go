@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/cliffdoyle/ranking-service/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// stubService is a RankingService whose methods answer through the function fields a test sets;
+// the rest are left to the embedded nil interface and panic if called
+type stubService struct {
+	service.RankingService
+
+	minGames           int
+	userRankingsByGame func(userID uuid.UUID) ([]domain.UserOverallStats, error)
+	leaderboard        func(gameID string, minGames int, sortBy domain.LeaderboardSort, page, pageSize int) ([]domain.LeaderboardEntry, int, error)
+	leaderboards       func(gameIDs []string, limit int) (map[string][]domain.LeaderboardEntry, error)
+	recalculate        func(userID uuid.UUID, gameID string) (*domain.UserOverallStats, error)
+	seasonLeaderboard  func(seasonID uuid.UUID, gameID string, minGames int, sortBy domain.LeaderboardSort, page, pageSize int) ([]domain.LeaderboardEntry, int, error)
+	startSeason        func(name string) (*domain.Season, error)
+	scoreHistory       func(userID uuid.UUID, gameID string, from, to time.Time, limit int) (*domain.ScoreHistory, error)
+}
+
+func (s *stubService) MinGames() int { return s.minGames }
+
+func (s *stubService) GetUserRankingsByGame(ctx context.Context, userID uuid.UUID) ([]domain.UserOverallStats, error) {
+	return s.userRankingsByGame(userID)
+}
+
+func (s *stubService) GetLeaderboard(ctx context.Context, gameID string, minGames int, sortBy domain.LeaderboardSort, page int, pageSize int) ([]domain.LeaderboardEntry, int, error) {
+	return s.leaderboard(gameID, minGames, sortBy, page, pageSize)
+}
+
+func (s *stubService) GetLeaderboards(ctx context.Context, gameIDs []string, limit int) (map[string][]domain.LeaderboardEntry, error) {
+	return s.leaderboards(gameIDs, limit)
+}
+
+func (s *stubService) RecalculateUserScore(ctx context.Context, userID uuid.UUID, gameID string) (*domain.UserOverallStats, error) {
+	return s.recalculate(userID, gameID)
+}
+
+func (s *stubService) GetSeasonLeaderboard(ctx context.Context, seasonID uuid.UUID, gameID string, minGames int, sortBy domain.LeaderboardSort, page int, pageSize int) ([]domain.LeaderboardEntry, int, error) {
+	return s.seasonLeaderboard(seasonID, gameID, minGames, sortBy, page, pageSize)
+}
+
+func (s *stubService) StartSeason(ctx context.Context, name string) (*domain.Season, error) {
+	return s.startSeason(name)
+}
+
+func (s *stubService) GetScoreHistory(ctx context.Context, userID uuid.UUID, gameID string, from, to time.Time, limit int) (*domain.ScoreHistory, error) {
+	return s.scoreHistory(userID, gameID, from, to, limit)
+}
+
+// serve routes a single request to handle and returns the recorded response
+func serve(method, pattern, target string, handle gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, pattern, handle)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+	return recorder
+}
+
+// decode unmarshals a JSON response body into v
+func decode(t *testing.T, recorder *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(recorder.Body.Bytes(), v); err != nil {
+		t.Fatalf("response is not JSON: %v (%s)", err, recorder.Body.String())
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestGetLeaderboardIncludesPagination(t *testing.T) {
+	h := NewRankingHandler(&stubService{
+		minGames: 1,
+		leaderboard: func(gameID string, minGames int, sortBy domain.LeaderboardSort, page, pageSize int) ([]domain.LeaderboardEntry, int, error) {
+			return []domain.LeaderboardEntry{{Rank: 11, UserID: uuid.New()}}, 45, nil
+		},
+	})
+
+	recorder := serve(http.MethodGet, "/rankings/leaderboard", "/rankings/leaderboard?gameId=chess&page=2&pageSize=10", h.GetLeaderboard)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var body struct {
+		TotalPlayers int                   `json:"totalPlayers"`
+		Pagination   domain.PaginationMeta `json:"pagination"`
+	}
+	decode(t, recorder, &body)
+	want := domain.PaginationMeta{Total: 45, Page: 2, PageSize: 10, TotalPages: 5, HasNext: true, HasPrev: true}
+	if body.TotalPlayers != 45 || body.Pagination != want {
+		t.Fatalf("expected pagination %+v, got %+v (total %d)", want, body.Pagination, body.TotalPlayers)
+	}
+}
+
+func TestGetLeaderboardsForSeveralGames(t *testing.T) {
+	var asked []string
+	h := NewRankingHandler(&stubService{
+		leaderboards: func(gameIDs []string, limit int) (map[string][]domain.LeaderboardEntry, error) {
+			asked = gameIDs
+			if limit != 3 {
+				t.Errorf("expected limit 3, got %d", limit)
+			}
+			return map[string][]domain.LeaderboardEntry{"chess": {{Rank: 1}}, "fifa": {}}, nil
+		},
+	})
+
+	recorder := serve(http.MethodGet, "/rankings/leaderboards", "/rankings/leaderboards?game=chess&game=fifa&limit=3", h.GetLeaderboards)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var body struct {
+		Leaderboards map[string][]domain.LeaderboardEntry `json:"leaderboards"`
+	}
+	decode(t, recorder, &body)
+	if len(asked) != 2 || len(body.Leaderboards) != 2 || len(body.Leaderboards["chess"]) != 1 {
+		t.Fatalf("unexpected response for %v: %+v", asked, body)
+	}
+
+	for _, target := range []string{
+		"/rankings/leaderboards",
+		"/rankings/leaderboards?game=chess&limit=0",
+		"/rankings/leaderboards?game=chess&limit=101",
+		"/rankings/leaderboards?" + strings.Repeat("game=chess&", maxLeaderboardGames+1),
+	} {
+		if recorder := serve(http.MethodGet, "/rankings/leaderboards", target, h.GetLeaderboards); recorder.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, recorder.Code)
+		}
+	}
+}
+
+func TestGetLeaderboardSortsByRatingOnRequest(t *testing.T) {
+	var sorted []domain.LeaderboardSort
+	h := NewRankingHandler(&stubService{
+		leaderboard: func(gameID string, minGames int, sortBy domain.LeaderboardSort, page, pageSize int) ([]domain.LeaderboardEntry, int, error) {
+			sorted = append(sorted, sortBy)
+			return []domain.LeaderboardEntry{}, 0, nil
+		},
+	})
+
+	for _, target := range []string{"/rankings/leaderboard?gameId=chess", "/rankings/leaderboard?gameId=chess&sort=rating"} {
+		if recorder := serve(http.MethodGet, "/rankings/leaderboard", target, h.GetLeaderboard); recorder.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", target, recorder.Code)
+		}
+	}
+	if len(sorted) != 2 || sorted[0] != domain.SortByPoints || sorted[1] != domain.SortByRating {
+		t.Fatalf("expected points by default and then rating, got %v", sorted)
+	}
+
+	if recorder := serve(http.MethodGet, "/rankings/leaderboard", "/rankings/leaderboard?gameId=chess&sort=wins", h.GetLeaderboard); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("an unknown sort should be rejected, got %d", recorder.Code)
+	}
+}
@@ -2,14 +2,20 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/cliffdoyle/ranking-service/internal/repository"
 	"github.com/cliffdoyle/ranking-service/internal/service"
 )
 
@@ -21,6 +27,16 @@ func NewRankingHandler(rs service.RankingService) *RankingHandler {
 	return &RankingHandler{rankingService: rs}
 }
 
+// errorStatus is the response status for a failed request: 504 when the database or the
+// User Service ran out of time (a deadline or Postgres' statement_timeout, code 57014), 500 otherwise
+func errorStatus(err error) int {
+	var pqErr *pq.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &pqErr) && pqErr.Code == "57014") {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
 // POST /rankings/match-results
 // Body: domain.MatchResultEvent
 func (h *RankingHandler) ProcessMatchResults(c *gin.Context) {
@@ -40,7 +56,7 @@ func (h *RankingHandler) ProcessMatchResults(c *gin.Context) {
 	err := h.rankingService.ProcessMatchResults(c.Request.Context(), event)
 	if err != nil {
 		log.Printf("Handler: Error from RankingService.ProcessMatchResults: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process match results: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to process match results: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Match results processed successfully"})
@@ -58,15 +74,182 @@ func (h *RankingHandler) GetUserRanking(c *gin.Context) {
 
 	ranking, err := h.rankingService.GetUserRanking(c.Request.Context(), userID, gameID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user ranking: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to retrieve user ranking: " + err.Error()})
 		return
 	}
 	// If GetUserScoreAndRankData (and GetUserRanking) handles the "not found" case by returning a default UserRanking struct with Score=0 and Rank=0 (or calculated last rank)
 	c.JSON(http.StatusOK, ranking)
 }
 
-// GetLeaderboard handler remains the same.
-func (h *RankingHandler) GetLeaderboard(c *gin.Context) {
+// GET /rankings/users/:userId/games
+// Returns the user's stats for each game they have a score in; empty array if none.
+func (h *RankingHandler) GetUserGameRankings(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	rankings, err := h.rankingService.GetUserRankingsByGame(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to retrieve user game rankings: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rankings)
+}
+
+// parseHistoryTime reads a from/to bound given as RFC 3339 or a date. A date used as the upper
+// bound covers the whole day.
+func parseHistoryTime(raw string, endOfDay bool) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		return day.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	return day, nil
+}
+
+// GET /rankings/users/:userId/history?gameId=&from=&to=&limit=500
+// Returns the user's score changes in the game, oldest first: the latest limit (default 500,
+// max 1000) between from and to, each an RFC 3339 time or a date.
+func (h *RankingHandler) GetScoreHistory(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+	from, err := parseHistoryTime(c.Query("from"), false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be an RFC 3339 time or a YYYY-MM-DD date"})
+		return
+	}
+	to, err := parseHistoryTime(c.Query("to"), true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be an RFC 3339 time or a YYYY-MM-DD date"})
+		return
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "500"))
+	if err != nil || limit < 1 || limit > 1000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 1000"})
+		return
+	}
+
+	history, err := h.rankingService.GetScoreHistory(c.Request.Context(), userID, c.Query("gameId"), from, to, limit)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to retrieve score history: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// maxLeaderboardGames caps how many games one GetLeaderboards request may ask for
+const maxLeaderboardGames = 20
+
+// GET /rankings/leaderboards?game=valorant&game=chess&limit=5
+// Returns {"leaderboards": {gameId: [entries]}} with the top limit (default 5, max 100) players of each game.
+func (h *RankingHandler) GetLeaderboards(c *gin.Context) {
+	games := c.QueryArray("game")
+	if len(games) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one game parameter is required"})
+		return
+	}
+	if len(games) > maxLeaderboardGames {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d games can be requested at once", maxLeaderboardGames)})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if err != nil || limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return
+	}
+
+	leaderboards, err := h.rankingService.GetLeaderboards(c.Request.Context(), games, limit)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to retrieve leaderboards: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"leaderboards": leaderboards,
+		"limit":        limit,
+	})
+}
+
+// GET /rankings/head-to-head?userA=&userB=&game=&limit=10
+// Returns userA's wins, losses and draws against userB and their last limit (default 10, max 50) meetings.
+func (h *RankingHandler) GetHeadToHead(c *gin.Context) {
+	userA, err := uuid.Parse(c.Query("userA"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid userA format"})
+		return
+	}
+	userB, err := uuid.Parse(c.Query("userB"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid userB format"})
+		return
+	}
+	if userA == userB {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "userA and userB must be different users"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 50"})
+		return
+	}
+
+	record, err := h.rankingService.GetHeadToHead(c.Request.Context(), userA, userB, c.Query("game"), limit)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to retrieve head-to-head record: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, record)
+}
+
+// POST /rankings/admin/recalculate/:userId?game=
+// Rebuilds the user's score, match counts and streaks from recorded match outcomes.
+func (h *RankingHandler) RecalculateUserScore(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	stats, err := h.rankingService.RecalculateUserScore(c.Request.Context(), userID, c.Query("game"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNoRecordedOutcomes) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No recorded match outcomes for this user and game"})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to recalculate user score: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// leaderboardQuery holds the paging and filtering parameters shared by the leaderboard endpoints
+type leaderboardQuery struct {
+	gameID   string
+	page     int
+	pageSize int
+	minGames int
+	sortBy   domain.LeaderboardSort
+}
+
+// parseLeaderboardQuery reads ?gameId=&page=&pageSize=&minGames=&sort=, responding 400 and
+// returning false when a parameter is invalid
+func (h *RankingHandler) parseLeaderboardQuery(c *gin.Context) (leaderboardQuery, bool) {
 	gameID := c.Query("gameId")
 	pageStr := c.DefaultQuery("page", "1")
 	pageSizeStr := c.DefaultQuery("pageSize", "20")
@@ -83,16 +266,111 @@ func (h *RankingHandler) GetLeaderboard(c *gin.Context) {
 		pageSize = 100
 	}
 
-	entries, totalPlayers, err := h.rankingService.GetLeaderboard(c.Request.Context(), gameID, page, pageSize)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve leaderboard: " + err.Error()})
-		return
+	// ?minGames= overrides the service's minimum matches played for this request
+	minGames := h.rankingService.MinGames()
+	if raw := c.Query("minGames"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "minGames must be a positive integer"})
+			return leaderboardQuery{}, false
+		}
+		minGames = parsed
 	}
-	c.JSON(http.StatusOK, gin.H{
+
+	// ?sort=rating orders by Elo rating instead of points
+	sortBy := domain.LeaderboardSort(c.DefaultQuery("sort", string(domain.SortByPoints)))
+	if sortBy != domain.SortByPoints && sortBy != domain.SortByRating {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be points or rating"})
+		return leaderboardQuery{}, false
+	}
+	return leaderboardQuery{gameID: gameID, page: page, pageSize: pageSize, minGames: minGames, sortBy: sortBy}, true
+}
+
+// leaderboardResponse is the body of the leaderboard endpoints
+func leaderboardResponse(q leaderboardQuery, entries []domain.LeaderboardEntry, totalPlayers int) gin.H {
+	return gin.H{
 		"leaderboard":  entries,
 		"totalPlayers": totalPlayers,
-		"page":         page,
-		"pageSize":     pageSize,
-		"gameId":       domain.ResolveGameID(gameID),
-	})
+		"page":         q.page,
+		"pageSize":     q.pageSize,
+		"gameId":       domain.ResolveGameID(q.gameID),
+		"minGames":     q.minGames,
+		"sort":         q.sortBy,
+		"pagination":   domain.NewPaginationMeta(totalPlayers, q.page, q.pageSize),
+	}
+}
+
+// GET /rankings/leaderboard?gameId=&page=&pageSize=&minGames=&sort=
+func (h *RankingHandler) GetLeaderboard(c *gin.Context) {
+	q, ok := h.parseLeaderboardQuery(c)
+	if !ok {
+		return
+	}
+	entries, totalPlayers, err := h.rankingService.GetLeaderboard(c.Request.Context(), q.gameID, q.minGames, q.sortBy, q.page, q.pageSize)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to retrieve leaderboard: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, leaderboardResponse(q, entries, totalPlayers))
+}
+
+// GET /rankings/seasons
+// Lists every season, newest first; the current one has no endedAt.
+func (h *RankingHandler) ListSeasons(c *gin.Context) {
+	seasons, err := h.rankingService.ListSeasons(c.Request.Context())
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to list seasons: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"seasons": seasons})
+}
+
+// GET /rankings/seasons/:seasonId/leaderboard?gameId=&page=&pageSize=&minGames=&sort=
+// An ended season's final standings; the current season returns the live leaderboard.
+func (h *RankingHandler) GetSeasonLeaderboard(c *gin.Context) {
+	seasonID, err := uuid.Parse(c.Param("seasonId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season ID format"})
+		return
+	}
+	q, ok := h.parseLeaderboardQuery(c)
+	if !ok {
+		return
+	}
+	entries, totalPlayers, err := h.rankingService.GetSeasonLeaderboard(c.Request.Context(), seasonID, q.gameID, q.minGames, q.sortBy, q.page, q.pageSize)
+	if err != nil {
+		if errors.Is(err, repository.ErrSeasonNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Season not found"})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to retrieve season leaderboard: " + err.Error()})
+		return
+	}
+	body := leaderboardResponse(q, entries, totalPlayers)
+	body["seasonId"] = seasonID
+	c.JSON(http.StatusOK, body)
+}
+
+// POST /rankings/admin/seasons
+// Body: {"name": "..."}. Archives the current standings, resets live scores and opens the new season.
+func (h *RankingHandler) StartSeason(c *gin.Context) {
+	var req domain.StartSeasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Season name cannot be blank"})
+		return
+	}
+	season, err := h.rankingService.StartSeason(c.Request.Context(), req.Name)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoCurrentSeason) {
+			c.JSON(http.StatusConflict, gin.H{"error": "No season is open; apply internal/migrations/009_seasons.sql"})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to start season: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, season)
 }
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/cliffdoyle/ranking-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+func TestRecalculateUserScore(t *testing.T) {
+	userID := uuid.New()
+	h := NewRankingHandler(&stubService{
+		recalculate: func(id uuid.UUID, gameID string) (*domain.UserOverallStats, error) {
+			if id != userID {
+				return nil, fmt.Errorf("recalculate: %w", repository.ErrNoRecordedOutcomes)
+			}
+			return &domain.UserOverallStats{UserID: id, GameID: gameID, Points: 9}, nil
+		},
+	})
+	const pattern = "/rankings/admin/recalculate/:userId"
+
+	recorder := serve(http.MethodPost, pattern, "/rankings/admin/recalculate/"+userID.String()+"?game=chess", h.RecalculateUserScore)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var stats domain.UserOverallStats
+	decode(t, recorder, &stats)
+	if stats.Points != 9 || stats.GameID != "chess" {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	if recorder := serve(http.MethodPost, pattern, "/rankings/admin/recalculate/"+uuid.NewString(), h.RecalculateUserScore); recorder.Code != http.StatusNotFound {
+		t.Fatalf("no recorded outcomes: expected 404, got %d", recorder.Code)
+	}
+	if recorder := serve(http.MethodPost, pattern, "/rankings/admin/recalculate/not-a-uuid", h.RecalculateUserScore); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("invalid user ID: expected 400, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestGetScoreHistory(t *testing.T) {
+	userID := uuid.New()
+	var from, to time.Time
+	var limit int
+	h := NewRankingHandler(&stubService{
+		scoreHistory: func(id uuid.UUID, gameID string, f, u time.Time, l int) (*domain.ScoreHistory, error) {
+			from, to, limit = f, u, l
+			return &domain.ScoreHistory{UserID: id, GameID: gameID, Changes: []domain.ScoreChange{{Delta: 3, ScoreAfter: 3}}}, nil
+		},
+	})
+	route := "/rankings/users/:userId/history"
+
+	recorder := serve(http.MethodGet, route, "/rankings/users/"+userID.String()+"/history?gameId=chess&from=2026-05-01&to=2026-05-31", h.GetScoreHistory)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var history domain.ScoreHistory
+	decode(t, recorder, &history)
+	if history.UserID != userID || history.GameID != "chess" || len(history.Changes) != 1 {
+		t.Fatalf("unexpected history %+v", history)
+	}
+	// A date as the upper bound covers the whole day
+	if !from.Equal(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)) || limit != 500 {
+		t.Fatalf("expected all of May with the default limit, got %s to %s limit %d", from, to, limit)
+	}
+
+	recorder = serve(http.MethodGet, route, "/rankings/users/"+userID.String()+"/history?from=2026-05-01T12:00:00Z&limit=10", h.GetScoreHistory)
+	if recorder.Code != http.StatusOK || !from.Equal(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)) || !to.IsZero() || limit != 10 {
+		t.Fatalf("expected an open range from noon with limit 10, got %d: %s to %s limit %d", recorder.Code, from, to, limit)
+	}
+
+	for _, query := range []string{
+		"from=yesterday", "to=31/05/2026", "from=2026-05-02&to=2026-05-01", "limit=0", "limit=1001", "limit=all",
+	} {
+		if recorder := serve(http.MethodGet, route, "/rankings/users/"+userID.String()+"/history?"+query, h.GetScoreHistory); recorder.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, recorder.Code)
+		}
+	}
+	if recorder := serve(http.MethodGet, route, "/rankings/users/me/history", h.GetScoreHistory); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("invalid user ID: expected 400, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/cliffdoyle/ranking-service/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetSeasonLeaderboard(t *testing.T) {
+	seasonID := uuid.New()
+	var asked uuid.UUID
+	var askedSort domain.LeaderboardSort
+	h := NewRankingHandler(&stubService{
+		seasonLeaderboard: func(id uuid.UUID, gameID string, minGames int, sortBy domain.LeaderboardSort, page, pageSize int) ([]domain.LeaderboardEntry, int, error) {
+			asked, askedSort = id, sortBy
+			if id != seasonID {
+				return nil, 0, repository.ErrSeasonNotFound
+			}
+			return []domain.LeaderboardEntry{{Rank: 1, UserID: uuid.New(), Score: 30}}, 1, nil
+		},
+	})
+	route := "/rankings/seasons/:seasonId/leaderboard"
+
+	recorder := serve(http.MethodGet, route, "/rankings/seasons/"+seasonID.String()+"/leaderboard?gameId=chess&sort=rating", h.GetSeasonLeaderboard)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var body struct {
+		SeasonID     uuid.UUID                 `json:"seasonId"`
+		Leaderboard  []domain.LeaderboardEntry `json:"leaderboard"`
+		TotalPlayers int                       `json:"totalPlayers"`
+	}
+	decode(t, recorder, &body)
+	if body.SeasonID != seasonID || len(body.Leaderboard) != 1 || body.Leaderboard[0].Score != 30 || body.TotalPlayers != 1 {
+		t.Fatalf("unexpected season leaderboard %+v", body)
+	}
+	if asked != seasonID || askedSort != domain.SortByRating {
+		t.Fatalf("expected season %s sorted by rating, asked for %s sorted by %s", seasonID, asked, askedSort)
+	}
+
+	if recorder := serve(http.MethodGet, route, "/rankings/seasons/"+uuid.New().String()+"/leaderboard", h.GetSeasonLeaderboard); recorder.Code != http.StatusNotFound {
+		t.Fatalf("unknown season: expected 404, got %d", recorder.Code)
+	}
+	if recorder := serve(http.MethodGet, route, "/rankings/seasons/spring/leaderboard", h.GetSeasonLeaderboard); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("invalid season ID: expected 400, got %d", recorder.Code)
+	}
+}
+
+// postSeason sends a StartSeason request with body
+func postSeason(h *RankingHandler, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/rankings/admin/seasons", h.StartSeason)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/rankings/admin/seasons", strings.NewReader(body)))
+	return recorder
+}
+
+func TestStartSeason(t *testing.T) {
+	var started []string
+	open := true
+	h := NewRankingHandler(&stubService{
+		startSeason: func(name string) (*domain.Season, error) {
+			if !open {
+				return nil, repository.ErrNoCurrentSeason
+			}
+			started = append(started, name)
+			return &domain.Season{ID: uuid.New(), Name: name}, nil
+		},
+	})
+
+	recorder := postSeason(h, `{"name": "Summer"}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var season domain.Season
+	decode(t, recorder, &season)
+	if season.Name != "Summer" || season.EndedAt != nil {
+		t.Fatalf("expected the new open season, got %+v", season)
+	}
+
+	for _, body := range []string{`{}`, `{"name": "   "}`, `not json`} {
+		if recorder := postSeason(h, body); recorder.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, recorder.Code)
+		}
+	}
+	if len(started) != 1 {
+		t.Fatalf("only the valid request should start a season, started %q", started)
+	}
+
+	open = false
+	if recorder := postSeason(h, `{"name": "Autumn"}`); recorder.Code != http.StatusConflict {
+		t.Fatalf("without an open season: expected 409, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/lib/pq"
+)
+
+func TestTimeoutsRespondWithGatewayTimeout(t *testing.T) {
+	for name, c := range map[string]struct {
+		err  error
+		want int
+	}{
+		"deadline exceeded": {fmt.Errorf("failed to fetch leaderboard: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+		"statement timeout": {fmt.Errorf("query failed: %w", &pq.Error{Code: "57014"}), http.StatusGatewayTimeout},
+		"other failure":     {errors.New("connection refused"), http.StatusInternalServerError},
+	} {
+		h := NewRankingHandler(&stubService{
+			leaderboard: func(string, int, domain.LeaderboardSort, int, int) ([]domain.LeaderboardEntry, int, error) {
+				return nil, 0, c.err
+			},
+		})
+		recorder := serve(http.MethodGet, "/rankings/leaderboard", "/rankings/leaderboard?gameId=chess", h.GetLeaderboard)
+		if recorder.Code != c.want {
+			t.Errorf("%s: expected %d, got %d", name, c.want, recorder.Code)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestGetUserGameRankings(t *testing.T) {
+	userID := uuid.New()
+	var asked uuid.UUID
+	h := NewRankingHandler(&stubService{userRankingsByGame: func(id uuid.UUID) ([]domain.UserOverallStats, error) {
+		asked = id
+		return []domain.UserOverallStats{
+			{UserID: id, GameID: "chess", GlobalRank: 2},
+			{UserID: id, GameID: "valorant", GlobalRank: 9},
+		}, nil
+	}})
+
+	recorder := serve(http.MethodGet, "/rankings/users/:userId/games", "/rankings/users/"+userID.String()+"/games", h.GetUserGameRankings)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if asked != userID {
+		t.Fatalf("the service was asked about %s instead of %s", asked, userID)
+	}
+	var rankings []domain.UserOverallStats
+	decode(t, recorder, &rankings)
+	if len(rankings) != 2 || rankings[0].GameID != "chess" || rankings[1].GlobalRank != 9 {
+		t.Fatalf("unexpected body: %+v", rankings)
+	}
+}
+
+func TestGetUserGameRankingsEmptyAndInvalid(t *testing.T) {
+	h := NewRankingHandler(&stubService{userRankingsByGame: func(uuid.UUID) ([]domain.UserOverallStats, error) {
+		return []domain.UserOverallStats{}, nil
+	}})
+
+	recorder := serve(http.MethodGet, "/rankings/users/:userId/games", "/rankings/users/"+uuid.NewString()+"/games", h.GetUserGameRankings)
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "[]" {
+		t.Fatalf("a user without scores should get an empty array, got %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = serve(http.MethodGet, "/rankings/users/:userId/games", "/rankings/users/not-a-uuid/games", h.GetUserGameRankings)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid user ID, got %d", recorder.Code)
+	}
+}
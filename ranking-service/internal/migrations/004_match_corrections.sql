@@ -0,0 +1,12 @@
+-- Timestamp of the latest event applied for a match, so stale or repeated corrections are ignored
+ALTER TABLE processed_match_events ADD COLUMN IF NOT EXISTS event_timestamp TIMESTAMPTZ;
+
+-- The outcome and points applied to each user per match, so a correction can reverse them exactly
+CREATE TABLE IF NOT EXISTS processed_match_outcomes (
+    match_id UUID NOT NULL REFERENCES processed_match_events(match_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    game_id VARCHAR(255) NOT NULL,
+    outcome VARCHAR(10) NOT NULL,
+    points INT NOT NULL,
+    PRIMARY KEY (match_id, user_id)
+);
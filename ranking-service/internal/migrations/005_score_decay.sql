@@ -0,0 +1,2 @@
+-- When a player's score was last reduced for inactivity, so decay is applied at most once per interval
+ALTER TABLE user_scores ADD COLUMN IF NOT EXISTS last_decayed_at TIMESTAMPTZ;
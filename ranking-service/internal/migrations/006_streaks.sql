@@ -0,0 +1,3 @@
+-- Consecutive wins: the run a player is on now and their best run so far
+ALTER TABLE user_scores ADD COLUMN IF NOT EXISTS current_streak INT NOT NULL DEFAULT 0;
+ALTER TABLE user_scores ADD COLUMN IF NOT EXISTS longest_streak INT NOT NULL DEFAULT 0;
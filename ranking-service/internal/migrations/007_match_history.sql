@@ -0,0 +1,11 @@
+-- One row per player and opponent for every processed match, for head-to-head records
+CREATE TABLE IF NOT EXISTS match_history (
+    user_id UUID NOT NULL,
+    opponent_id UUID NOT NULL,
+    game_id VARCHAR(255) NOT NULL,
+    outcome VARCHAR(10) NOT NULL, -- The result for user_id
+    match_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (match_id, user_id, opponent_id)
+);
+CREATE INDEX IF NOT EXISTS idx_match_history_pair ON match_history(user_id, opponent_id, game_id, created_at DESC);
@@ -0,0 +1,5 @@
+-- Elo rating per player and game, alongside the cumulative points
+ALTER TABLE user_scores ADD COLUMN IF NOT EXISTS rating INT NOT NULL DEFAULT 1500;
+-- The rating change each Elo-rated match applied, so corrections can take it back
+ALTER TABLE processed_match_outcomes ADD COLUMN IF NOT EXISTS rating_change INT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_user_scores_game_rating ON user_scores(game_id, rating DESC);
@@ -0,0 +1,29 @@
+-- Ranking seasons. The live scores in user_scores belong to the one season without ended_at.
+CREATE TABLE IF NOT EXISTS seasons (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_seasons_current ON seasons ((true)) WHERE ended_at IS NULL;
+
+-- Scores recorded so far make up the first season
+INSERT INTO seasons (name, started_at)
+SELECT 'Season 1', COALESCE((SELECT MIN(updated_at) FROM user_scores), CURRENT_TIMESTAMP)
+WHERE NOT EXISTS (SELECT 1 FROM seasons);
+
+-- Final standings of each ended season, copied from user_scores when the next one starts
+CREATE TABLE IF NOT EXISTS seasonal_archive (
+    season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    game_id VARCHAR(255) NOT NULL,
+    score INT NOT NULL,
+    rating INT NOT NULL,
+    matches_played INT NOT NULL,
+    matches_won INT NOT NULL,
+    matches_drawn INT NOT NULL,
+    matches_lost INT NOT NULL,
+    longest_streak INT NOT NULL,
+    last_played_at TIMESTAMPTZ,
+    PRIMARY KEY (season_id, game_id, user_id)
+);
@@ -0,0 +1,11 @@
+-- Every change to a player's score, for score-over-time graphs. match_id is NULL for inactivity decay.
+CREATE TABLE IF NOT EXISTS score_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    game_id VARCHAR(255) NOT NULL,
+    score_after INT NOT NULL,
+    delta INT NOT NULL,
+    match_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_score_history_user_game ON score_history(user_id, game_id, created_at);
@@ -0,0 +1,30 @@
+package migrations_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/database"
+	"github.com/cliffdoyle/ranking-service/internal/migrations"
+)
+
+func TestEmbeddedMigrationsCreateEveryTable(t *testing.T) {
+	loaded, err := database.LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("LoadMigrations: %v", err)
+	}
+	var schema strings.Builder
+	for _, migration := range loaded {
+		schema.WriteString(migration.SQL)
+	}
+
+	for _, table := range []string{
+		"user_scores", "user_tournament_participation", "processed_match_events", "processed_match_outcomes",
+		"match_history", "seasons", "score_history",
+	} {
+		if !regexp.MustCompile(`(?i)CREATE TABLE IF NOT EXISTS ` + table + `\s*\(`).MatchString(schema.String()) {
+			t.Errorf("no numbered migration creates %s", table)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestDatabaseAdjustsRatingsInTheMatchTransaction(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewRankingRepository(db, false, domain.TiesShared)
+	userID := uuid.New()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback()
+	if rating, err := repo.GetRating(ctx, tx, userID, "chess"); err != nil || rating != domain.DefaultRating {
+		t.Fatalf("a new player should start at %d, got %d (%v)", domain.DefaultRating, rating, err)
+	}
+	if _, err := repo.ProcessMatchOutcome(ctx, tx, userID, "chess", uuid.New(), uuid.New(), domain.Win, domain.DefaultPointsConfig); err != nil {
+		t.Fatalf("ProcessMatchOutcome: %v", err)
+	}
+	if err := repo.AdjustRating(ctx, tx, userID, "chess", 16); err != nil {
+		t.Fatalf("AdjustRating: %v", err)
+	}
+	if rating, err := repo.GetRating(ctx, tx, userID, "chess"); err != nil || rating != domain.DefaultRating+16 {
+		t.Fatalf("expected %d within the transaction, got %d (%v)", domain.DefaultRating+16, rating, err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	data, err := repo.GetUserScoreData(ctx, userID, "chess", 0)
+	if err != nil {
+		t.Fatalf("GetUserScoreData: %v", err)
+	}
+	if data.Rating != domain.DefaultRating+16 || data.Score != 3 {
+		t.Fatalf("expected the rating and points to be saved together, got %+v", data)
+	}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// recordHistory writes history rows in one transaction
+func recordHistory(t *testing.T, db *sql.DB, repo RankingRepository, entries ...MatchHistoryEntry) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback()
+	for _, entry := range entries {
+		if err := repo.RecordMatchHistory(ctx, tx, entry); err != nil {
+			t.Fatalf("RecordMatchHistory: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+}
+
+func TestHeadToHeadWithMixedOutcomes(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRankingRepository(db, false, domain.TiesShared)
+	a, b, other := uuid.New(), uuid.New(), uuid.New()
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	meeting := func(minutes int, outcome domain.ResultType, opponent uuid.UUID, gameID string) MatchHistoryEntry {
+		return MatchHistoryEntry{
+			MatchID: uuid.New(), UserID: a, OpponentID: opponent, GameID: gameID,
+			Outcome: outcome, PlayedAt: start.Add(time.Duration(minutes) * time.Minute),
+		}
+	}
+	latest := meeting(40, domain.Win, b, "chess")
+	redelivered := meeting(30, domain.Loss, b, "chess")
+	recordHistory(t, db, repo,
+		meeting(10, domain.Win, b, "chess"),
+		meeting(20, domain.Loss, b, "chess"),
+		redelivered,
+		latest,
+		meeting(50, domain.Loss, other, "chess"), // Another opponent
+		meeting(60, domain.Loss, b, "fifa"),      // Another game
+	)
+	// A redelivered result replaces the row it was first recorded as
+	redelivered.Outcome = domain.Draw
+	recordHistory(t, db, repo, redelivered)
+
+	record, err := repo.GetHeadToHead(context.Background(), a, b, "chess", 2)
+	if err != nil {
+		t.Fatalf("GetHeadToHead: %v", err)
+	}
+	if record.Wins != 2 || record.Losses != 1 || record.Draws != 1 {
+		t.Fatalf("expected 2-1-1, got %d-%d-%d", record.Wins, record.Losses, record.Draws)
+	}
+	if len(record.Meetings) != 2 || record.Meetings[0].MatchID != latest.MatchID ||
+		record.Meetings[1].MatchID != redelivered.MatchID || record.Meetings[1].Outcome != domain.Draw {
+		t.Fatalf("expected the last two meetings, newest first, got %+v", record.Meetings)
+	}
+}
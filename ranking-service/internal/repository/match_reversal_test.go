@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestForgetMatchEventDropsItsOutcomes(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewRankingRepository(db, false, domain.TiesShared)
+	matchID, userID := uuid.New(), uuid.New()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback()
+	if err := repo.MarkMatchEventAsProcessed(ctx, tx, matchID, uuid.New(), "chess", time.Now()); err != nil {
+		t.Fatalf("MarkMatchEventAsProcessed: %v", err)
+	}
+	applied := AppliedOutcome{MatchID: matchID, UserID: userID, GameID: "chess", Outcome: domain.Win, Points: 3}
+	if err := repo.RecordMatchOutcome(ctx, tx, applied); err != nil {
+		t.Fatalf("RecordMatchOutcome: %v", err)
+	}
+
+	if err := repo.ForgetMatchEvent(ctx, tx, matchID); err != nil {
+		t.Fatalf("ForgetMatchEvent: %v", err)
+	}
+	if processed, err := repo.IsMatchEventProcessed(ctx, tx, matchID); err != nil || processed {
+		t.Fatalf("the match should no longer be processed, got %v (%v)", processed, err)
+	}
+	var outcomes int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM processed_match_outcomes WHERE match_id = $1`, matchID).Scan(&outcomes); err != nil {
+		t.Fatalf("count outcomes: %v", err)
+	}
+	if outcomes != 0 {
+		t.Fatalf("expected the recorded outcomes to be deleted with the event, %d left", outcomes)
+	}
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/database"
+	"github.com/cliffdoyle/ranking-service/internal/migrations"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+// openTestDB connects to the Postgres database named by TEST_DATABASE_URL, migrated and with
+// every table emptied apart from a fresh current season. Without it the test is skipped.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	if _, err := database.RunMigrations(ctx, db, migrations.FS); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `
+		TRUNCATE user_scores, user_tournament_participation, processed_match_events, processed_match_outcomes,
+			match_history, seasons, seasonal_archive, score_history CASCADE;
+		INSERT INTO seasons (name) VALUES ('Season 1');
+	`); err != nil {
+		t.Fatalf("empty test database: %v", err)
+	}
+	return db
+}
+
+// putScore stores a user_scores row for a player who last played at updatedAt
+func putScore(t *testing.T, db *sql.DB, userID uuid.UUID, gameID string, score, won, lost int, updatedAt time.Time) {
+	t.Helper()
+	_, err := db.Exec(`
+		INSERT INTO user_scores (user_id, game_id, score, matches_played, matches_won, matches_lost, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, userID, gameID, score, won+lost, won, lost, updatedAt)
+	if err != nil {
+		t.Fatalf("store score: %v", err)
+	}
+}
+
+// storedScore reads a player's score back
+func storedScore(t *testing.T, db *sql.DB, userID uuid.UUID, gameID string) int {
+	t.Helper()
+	var score int
+	if err := db.QueryRow(`SELECT score FROM user_scores WHERE user_id = $1 AND game_id = $2`, userID, gameID).Scan(&score); err != nil {
+		t.Fatalf("read score: %v", err)
+	}
+	return score
+}
@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log" // Added for logging
 	"time"
@@ -20,74 +21,206 @@ type UserScoreData struct {
 	MatchesWon        int
 	MatchesDrawn      int
 	MatchesLost       int
+	CurrentStreak     int // Consecutive wins up to the latest result
+	LongestStreak     int // Best run of consecutive wins
+	Rating            int // Elo rating
+	Rank              int // Points rank among listed players, shared on ties; 0 when unranked
 	TournamentsPlayed int
 	UpdatedAt         time.Time // Use sql.NullTime if it can truly be null from DB
 }
 
+// ErrNoRecordedOutcomes is returned when a user has no recorded match outcomes to rebuild a score from
+var ErrNoRecordedOutcomes = errors.New("no recorded match outcomes")
+
+// ErrSeasonNotFound is returned for a season ID that does not exist
+var ErrSeasonNotFound = errors.New("season not found")
+
+// ErrNoCurrentSeason is returned when no season is open, which only happens if 009_seasons.sql was not applied
+var ErrNoCurrentSeason = errors.New("no current season")
+
+// AppliedOutcome is the outcome and points a match event gave one user
+type AppliedOutcome struct {
+	MatchID uuid.UUID
+	UserID  uuid.UUID
+	GameID  string
+	Outcome domain.ResultType
+	Points  int
+	// RatingChange is the Elo rating change applied, 0 for matches not rated with Elo
+	RatingChange int
+}
+
+// MatchHistoryEntry is a user's result against one opponent in a match
+type MatchHistoryEntry struct {
+	MatchID    uuid.UUID
+	UserID     uuid.UUID
+	OpponentID uuid.UUID
+	GameID     string
+	Outcome    domain.ResultType
+	PlayedAt   time.Time
+}
+
 type RankingRepository interface {
-	// ProcessMatchOutcome increments scores and match counts, now within a transaction.
-	ProcessMatchOutcome(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string, tournamentID uuid.UUID, outcome domain.ResultType) (*UserScoreData, error)
-	GetUserScoreData(ctx context.Context, userID uuid.UUID, gameID string) (*UserScoreData, error)
-	GetLeaderboard(ctx context.Context, gameID string, limit int, offset int) ([]domain.LeaderboardEntry, int, error)
+	// ProcessMatchOutcome increments scores and match counts, now within a transaction, and
+	// appends the change to the score history. Points are awarded according to the given configuration.
+	ProcessMatchOutcome(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string, tournamentID uuid.UUID, matchID uuid.UUID, outcome domain.ResultType, pointsConfig domain.PointsConfig) (*UserScoreData, error)
+	// GetUserScoreData and ListUserGames rank the user among players with at least minGames
+	// matches, in the same query as their score
+	GetUserScoreData(ctx context.Context, userID uuid.UUID, gameID string, minGames int) (*UserScoreData, error)
+	// GetRating returns a user's Elo rating in a game, locking it until tx ends; DefaultRating if they have none yet
+	GetRating(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string) (int, error)
+	// AdjustRating adds change to a user's Elo rating in a game; their user_scores row must exist
+	AdjustRating(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string, change int) error
+	ListUserGames(ctx context.Context, userID uuid.UUID, minGames int) ([]UserScoreData, error)
+	// GetLeaderboard lists players with at least minGames matches played, best first by sortBy
+	GetLeaderboard(ctx context.Context, gameID string, minGames int, sortBy domain.LeaderboardSort, limit int, offset int) ([]domain.LeaderboardEntry, int, error)
 	DB() *sql.DB // For direct DB access if needed (e.g., service layer transactions)
 
 	// Methods for Idempotency
 	IsMatchEventProcessed(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) (bool, error)
-	MarkMatchEventAsProcessed(ctx context.Context, tx *sql.Tx, matchID uuid.UUID, tournamentID uuid.UUID, gameID string) error
+	// MarkMatchEventAsProcessed records (or, for a correction, refreshes) the latest event applied for a match.
+	MarkMatchEventAsProcessed(ctx context.Context, tx *sql.Tx, matchID uuid.UUID, tournamentID uuid.UUID, gameID string, eventTime time.Time) error
+	GetProcessedEventTime(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) (time.Time, error)
+	// ForgetMatchEvent removes a match's processed marker so its next result is applied as new.
+	ForgetMatchEvent(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) error
+
+	// Methods for correcting already processed matches
+	RecordMatchOutcome(ctx context.Context, tx *sql.Tx, applied AppliedOutcome) error
+	GetMatchOutcomes(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) ([]AppliedOutcome, error)
+	// ReverseMatchOutcome undoes an applied outcome's points and match counts and forgets it.
+	ReverseMatchOutcome(ctx context.Context, tx *sql.Tx, applied AppliedOutcome) error
+
+	// Methods for head-to-head records
+	RecordMatchHistory(ctx context.Context, tx *sql.Tx, entry MatchHistoryEntry) error
+	ClearMatchHistory(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) error
+	// GetHeadToHead returns userA's record against userB in a game and their last limit meetings.
+	GetHeadToHead(ctx context.Context, userA, userB uuid.UUID, gameID string, limit int) (*domain.HeadToHead, error)
+
+	// GetScoreHistory returns the latest limit score changes of a user in a game between from and
+	// to (either may be zero for no bound), oldest first
+	GetScoreHistory(ctx context.Context, userID uuid.UUID, gameID string, from, to time.Time, limit int) (*domain.ScoreHistory, error)
+
+	// RecalculateUserScore rebuilds a user's score, match counts and streaks in a game from the
+	// recorded outcomes of every processed match, overwriting what user_scores holds.
+	RecalculateUserScore(ctx context.Context, userID uuid.UUID, gameID string) (*UserScoreData, error)
+
+	// ApplyScoreDecay cuts the score of players inactive since inactiveSince by percent, unless
+	// they were already decayed after decayedSince. It returns the number of scores reduced.
+	ApplyScoreDecay(ctx context.Context, percent int, inactiveSince, decayedSince, now time.Time) (int64, error)
+
+	// Methods for seasons
+	GetCurrentSeason(ctx context.Context) (*domain.Season, error)
+	GetSeason(ctx context.Context, seasonID uuid.UUID) (*domain.Season, error)
+	ListSeasons(ctx context.Context) ([]domain.Season, error)
+	// StartSeason ends the current season, archives its scores, resets the live scores and opens
+	// a season called name, all in one transaction. It returns the new season.
+	StartSeason(ctx context.Context, name string, now time.Time) (*domain.Season, error)
+	// GetSeasonLeaderboard is GetLeaderboard over an ended season's archived scores
+	GetSeasonLeaderboard(ctx context.Context, seasonID uuid.UUID, gameID string, minGames int, sortBy domain.LeaderboardSort, limit int, offset int) ([]domain.LeaderboardEntry, int, error)
+}
+
+type rankingRepository struct {
+	db               *sql.DB
+	drawsBreakStreak bool              // Whether a draw ends a win streak; otherwise it leaves the streak as it was
+	tieRanking       domain.TieRanking // How leaderboard ranks are numbered for level players
 }
 
-type rankingRepository struct{ db *sql.DB }
+// NewRankingRepository creates the repository. Leaderboard players level on points (or rating)
+// share a rank unless tieRanking is TiesSequential.
+func NewRankingRepository(db *sql.DB, drawsBreakStreak bool, tieRanking domain.TieRanking) RankingRepository {
+	return &rankingRepository{db: db, drawsBreakStreak: drawsBreakStreak, tieRanking: tieRanking}
+}
 
-func NewRankingRepository(db *sql.DB) RankingRepository { return &rankingRepository{db: db} }
+// leaderboardRank returns the select expression for leaderboard ranks. Players are ordered by
+// sortBy, then by win rate, matches won and most recent activity (lastPlayed), with user_id as
+// the final deterministic fallback. With shared ties, players level on sortBy get the same rank
+// and the tie-breaks only order them. partitionBy, if set, ranks each group of rows separately.
+func (r *rankingRepository) leaderboardRank(sortBy domain.LeaderboardSort, lastPlayed, partitionBy string) (rank, orderBy string) {
+	primary := "score DESC"
+	if sortBy == domain.SortByRating {
+		primary = "rating DESC"
+	}
+	orderBy = primary + `,
+		COALESCE(matches_won, 0)::FLOAT8 / GREATEST(matches_played, 1) DESC,
+		COALESCE(matches_won, 0) DESC,
+		` + lastPlayed + ` DESC NULLS LAST,
+		user_id ASC`
+	window := "OVER ("
+	if partitionBy != "" {
+		window += "PARTITION BY " + partitionBy + " "
+	}
+	if r.tieRanking == domain.TiesSequential {
+		return "ROW_NUMBER() " + window + "ORDER BY " + orderBy + ")", orderBy
+	}
+	return "RANK() " + window + "ORDER BY " + primary + ")", orderBy
+}
 
 // ProcessMatchOutcome now accepts a transaction
-func (r *rankingRepository) ProcessMatchOutcome(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string, tournamentID uuid.UUID, outcome domain.ResultType) (*UserScoreData, error) {
+func (r *rankingRepository) ProcessMatchOutcome(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string, tournamentID uuid.UUID, matchID uuid.UUID, outcome domain.ResultType, pointsConfig domain.PointsConfig) (*UserScoreData, error) {
 	effectiveGameID := domain.ResolveGameID(gameID)
-	points := 0
+	points := pointsConfig.PointsFor(outcome)
 	wonIncrement := 0
 	drawnIncrement := 0
 	lostIncrement := 0
+	resetStreak := false // A win extends the streak; a loss, and a draw if configured, ends it
 
 	switch outcome {
 	case domain.Win:
-		points = 3
 		wonIncrement = 1
 	case domain.Draw:
-		points = 1
 		drawnIncrement = 1
+		resetStreak = r.drawsBreakStreak
 	case domain.Loss:
-		points = 0
 		lostIncrement = 1
+		resetStreak = true
 	default:
 		log.Printf("Warning: Unknown outcome '%s' for user %s in ProcessMatchOutcome. Defaulting to loss.", outcome, userID)
 		lostIncrement = 1 // Or return an error: return nil, fmt.Errorf("unknown outcome: %s", outcome)
+		resetStreak = true
 	}
 
 	scoreUpdateQuery := `
 		INSERT INTO user_scores (
-			user_id, game_id, score, matches_played, matches_won, matches_drawn, matches_lost, updated_at
+			user_id, game_id, score, matches_played, matches_won, matches_drawn, matches_lost, updated_at,
+			current_streak, longest_streak
 		)
-		VALUES ($1, $2, $3, 1, $4, $5, $6, $7)
+		VALUES ($1, $2, $3, 1, $4, $5, $6, $7, $4, $4)
 		ON CONFLICT (user_id, game_id) DO UPDATE SET
 			score = user_scores.score + EXCLUDED.score,
 			matches_played = user_scores.matches_played + 1,
 			matches_won = user_scores.matches_won + EXCLUDED.matches_won,
 			matches_drawn = user_scores.matches_drawn + EXCLUDED.matches_drawn,
 			matches_lost = user_scores.matches_lost + EXCLUDED.matches_lost,
-			updated_at = EXCLUDED.updated_at
-		RETURNING user_id, game_id, score, matches_played, matches_won, matches_drawn, matches_lost, updated_at;
+			updated_at = EXCLUDED.updated_at,
+			current_streak = CASE
+				WHEN EXCLUDED.matches_won = 1 THEN user_scores.current_streak + 1
+				WHEN $8 THEN 0
+				ELSE user_scores.current_streak
+			END,
+			longest_streak = GREATEST(user_scores.longest_streak, user_scores.current_streak + EXCLUDED.matches_won)
+		RETURNING user_id, game_id, score, matches_played, matches_won, matches_drawn, matches_lost, updated_at,
+			current_streak, longest_streak;
 	`
 	var updatedData UserScoreData
 	err := tx.QueryRowContext(ctx, scoreUpdateQuery,
-		userID, effectiveGameID, points, wonIncrement, drawnIncrement, lostIncrement, time.Now(),
+		userID, effectiveGameID, points, wonIncrement, drawnIncrement, lostIncrement, time.Now(), resetStreak,
 	).Scan(
 		&updatedData.UserID, &updatedData.GameID, &updatedData.Score, &updatedData.MatchesPlayed,
 		&updatedData.MatchesWon, &updatedData.MatchesDrawn, &updatedData.MatchesLost, &updatedData.UpdatedAt,
+		&updatedData.CurrentStreak, &updatedData.LongestStreak,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user_scores for user %s, game %s: %w", userID, effectiveGameID, err)
 	}
 
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO score_history (user_id, game_id, score_after, delta, match_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		userID, effectiveGameID, updatedData.Score, points, matchID, updatedData.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record score history for user %s, game %s: %w", userID, effectiveGameID, err)
+	}
+
 	if tournamentID != uuid.Nil {
 		participationQuery := `
 			INSERT INTO user_tournament_participation (user_id, game_id, tournament_id)
@@ -102,15 +235,23 @@ func (r *rankingRepository) ProcessMatchOutcome(ctx context.Context, tx *sql.Tx,
 	return &updatedData, nil // TournamentsPlayed will be fetched by GetUserScoreData
 }
 
-func (r *rankingRepository) GetUserScoreData(ctx context.Context, userID uuid.UUID, gameID string) (*UserScoreData, error) {
+// userRankColumn is the rank selected from a ranked user_scores row, 0 for a player without a result
+const userRankColumn = `CASE WHEN COALESCE(us.matches_played, 0) > 0 OR COALESCE(us.score, 0) > 0 THEN us.rank ELSE 0 END`
+
+// GetUserScoreData reads a user's score in a game. The rank is a window over the listed players
+// plus the user, so a provisional player is still placed among the players the leaderboard shows.
+// It is numbered like the points leaderboard, with the same tie-breaks and tie ranking.
+func (r *rankingRepository) GetUserScoreData(ctx context.Context, userID uuid.UUID, gameID string, minGames int) (*UserScoreData, error) {
 	effectiveGameID := domain.ResolveGameID(gameID)
 	data := UserScoreData{
 		UserID: userID, // Pre-fill in case of no rows
 		GameID: effectiveGameID,
+		Rating: domain.DefaultRating,
 	}
 	var updatedAt sql.NullTime // To handle potential NULL from user_scores
 
 	// Query to get score data and tournament count
+	rank, _ := r.leaderboardRank(domain.SortByPoints, "updated_at", "")
 	query := `
 		SELECT
 			COALESCE(us.score, 0),
@@ -118,20 +259,32 @@ func (r *rankingRepository) GetUserScoreData(ctx context.Context, userID uuid.UU
 			COALESCE(us.matches_won, 0),
 			COALESCE(us.matches_drawn, 0),
 			COALESCE(us.matches_lost, 0),
+			us.current_streak,
+			us.longest_streak,
+			us.rating,
+			` + userRankColumn + `,
 			us.updated_at,
 			(SELECT COUNT(DISTINCT utp.tournament_id)
 			 FROM user_tournament_participation utp
 			 WHERE utp.user_id = $1 AND utp.game_id = $2)
-		FROM user_scores us
-		WHERE us.user_id = $1 AND us.game_id = $2;
+		FROM (
+			SELECT *, ` + rank + ` AS rank
+			FROM user_scores
+			WHERE game_id = $2 AND (matches_played >= $3 OR user_id = $1)
+		) us
+		WHERE us.user_id = $1;
 	`
 	// This query will return sql.ErrNoRows if the user_id/game_id combo doesn't exist in user_scores
-	err := r.db.QueryRowContext(ctx, query, userID, effectiveGameID).Scan(
+	err := r.db.QueryRowContext(ctx, query, userID, effectiveGameID, minGames).Scan(
 		&data.Score,
 		&data.MatchesPlayed,
 		&data.MatchesWon,
 		&data.MatchesDrawn,
 		&data.MatchesLost,
+		&data.CurrentStreak,
+		&data.LongestStreak,
+		&data.Rating,
+		&data.Rank,
 		&updatedAt,
 		&data.TournamentsPlayed,
 	)
@@ -162,13 +315,112 @@ func (r *rankingRepository) GetUserScoreData(ctx context.Context, userID uuid.UU
 	return &data, nil
 }
 
-func (r *rankingRepository) GetLeaderboard(ctx context.Context, gameID string, limit int, offset int) ([]domain.LeaderboardEntry, int, error) {
+// ListUserGames returns the user's score data for every game they have a user_scores row in
+func (r *rankingRepository) ListUserGames(ctx context.Context, userID uuid.UUID, minGames int) ([]UserScoreData, error) {
+	// Ranked within each game like GetUserScoreData
+	rank, _ := r.leaderboardRank(domain.SortByPoints, "updated_at", "game_id")
+	query := `
+		SELECT
+			us.game_id,
+			us.score,
+			us.matches_played,
+			us.matches_won,
+			us.matches_drawn,
+			us.matches_lost,
+			us.current_streak,
+			us.longest_streak,
+			us.rating,
+			` + userRankColumn + `,
+			us.updated_at,
+			(SELECT COUNT(DISTINCT utp.tournament_id)
+			 FROM user_tournament_participation utp
+			 WHERE utp.user_id = us.user_id AND utp.game_id = us.game_id)
+		FROM (
+			SELECT *, ` + rank + ` AS rank
+			FROM user_scores
+			WHERE game_id IN (SELECT game_id FROM user_scores WHERE user_id = $1)
+				AND (matches_played >= $2 OR user_id = $1)
+		) us
+		WHERE us.user_id = $1
+		ORDER BY us.game_id;
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, minGames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list games for user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	games := []UserScoreData{}
+	for rows.Next() {
+		data := UserScoreData{UserID: userID}
+		var updatedAt sql.NullTime
+		if err := rows.Scan(
+			&data.GameID,
+			&data.Score,
+			&data.MatchesPlayed,
+			&data.MatchesWon,
+			&data.MatchesDrawn,
+			&data.MatchesLost,
+			&data.CurrentStreak,
+			&data.LongestStreak,
+			&data.Rating,
+			&data.Rank,
+			&updatedAt,
+			&data.TournamentsPlayed,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan game score for user %s: %w", userID, err)
+		}
+		if updatedAt.Valid {
+			data.UpdatedAt = updatedAt.Time
+		}
+		games = append(games, data)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating game scores for user %s: %w", userID, err)
+	}
+	return games, nil
+}
+
+// GetRating reads a user's Elo rating for update, so a concurrent match between the same players
+// waits for this one
+func (r *rankingRepository) GetRating(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string) (int, error) {
+	effectiveGameID := domain.ResolveGameID(gameID)
+	var rating int
+	err := tx.QueryRowContext(ctx,
+		`SELECT rating FROM user_scores WHERE user_id = $1 AND game_id = $2 FOR UPDATE`, userID, effectiveGameID,
+	).Scan(&rating)
+	if err == sql.ErrNoRows {
+		return domain.DefaultRating, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rating for user %s, game %s: %w", userID, effectiveGameID, err)
+	}
+	return rating, nil
+}
+
+// AdjustRating adds change to a user's Elo rating
+func (r *rankingRepository) AdjustRating(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string, change int) error {
+	effectiveGameID := domain.ResolveGameID(gameID)
+	_, err := tx.ExecContext(ctx,
+		`UPDATE user_scores SET rating = rating + $3 WHERE user_id = $1 AND game_id = $2`,
+		userID, effectiveGameID, change,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to adjust rating for user %s, game %s: %w", userID, effectiveGameID, err)
+	}
+	return nil
+}
+
+func (r *rankingRepository) GetLeaderboard(ctx context.Context, gameID string, minGames int, sortBy domain.LeaderboardSort, limit int, offset int) ([]domain.LeaderboardEntry, int, error) {
 	effectiveGameID := domain.ResolveGameID(gameID)
 	var entries []domain.LeaderboardEntry
 	var totalPlayers int
+	if minGames < 1 {
+		minGames = 1 // Players who have not played are never listed
+	}
 
-	countQuery := `SELECT COUNT(*) FROM user_scores WHERE game_id = $1 AND matches_played > 0` // Only count active players
-	err := r.db.QueryRowContext(ctx, countQuery, effectiveGameID).Scan(&totalPlayers)
+	countQuery := `SELECT COUNT(*) FROM user_scores WHERE game_id = $1 AND matches_played >= $2` // Only count established players
+	err := r.db.QueryRowContext(ctx, countQuery, effectiveGameID, minGames).Scan(&totalPlayers)
 	if err != nil {
 		// No ErrNoRows check here, COUNT always returns a row
 		return nil, 0, fmt.Errorf("failed to count players for leaderboard (game: %s): %w", effectiveGameID, err)
@@ -178,29 +430,27 @@ func (r *rankingRepository) GetLeaderboard(ctx context.Context, gameID string, l
 		return entries, 0, nil
 	}
 
+	rank, orderBy := r.leaderboardRank(sortBy, "updated_at", "")
 	query := `
-        SELECT user_id, score
+        SELECT ` + rank + `, user_id, score, rating
         FROM user_scores
-        WHERE game_id = $1 AND matches_played > 0 -- Only list active players
-        ORDER BY score DESC, updated_at ASC -- Tie-breaking: higher score wins, then earlier update (more stable)
-        LIMIT $2 OFFSET $3;
+        WHERE game_id = $1 AND matches_played >= $2 -- Only list established players
+        ORDER BY ` + orderBy + `
+        LIMIT $3 OFFSET $4;
     `
-	rows, err := r.db.QueryContext(ctx, query, effectiveGameID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, effectiveGameID, minGames, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get leaderboard for game %s: %w", effectiveGameID, err)
 	}
 	defer rows.Close()
 
-	rankCounter := offset + 1
 	for rows.Next() {
 		var entry domain.LeaderboardEntry
-		err := rows.Scan(&entry.UserID, &entry.Score)
+		err := rows.Scan(&entry.Rank, &entry.UserID, &entry.Score, &entry.Rating)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan leaderboard entry: %w", err)
 		}
-		entry.Rank = rankCounter
 		entries = append(entries, entry)
-		rankCounter++
 	}
 	if err = rows.Err(); err != nil {
 		return nil, 0, fmt.Errorf("error iterating leaderboard rows: %w", err)
@@ -230,18 +480,573 @@ func (r *rankingRepository) IsMatchEventProcessed(ctx context.Context, tx *sql.T
 }
 
 // MarkMatchEventAsProcessed records that a match event has been processed.
-func (r *rankingRepository) MarkMatchEventAsProcessed(ctx context.Context, tx *sql.Tx, matchID uuid.UUID, tournamentID uuid.UUID, gameID string) error {
-	query := `INSERT INTO processed_match_events (match_id, tournament_id, game_id, processed_at) VALUES ($1, $2, $3, $4)`
+func (r *rankingRepository) MarkMatchEventAsProcessed(ctx context.Context, tx *sql.Tx, matchID uuid.UUID, tournamentID uuid.UUID, gameID string, eventTime time.Time) error {
+	query := `
+		INSERT INTO processed_match_events (match_id, tournament_id, game_id, processed_at, event_timestamp)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (match_id) DO UPDATE SET
+			processed_at = EXCLUDED.processed_at,
+			event_timestamp = EXCLUDED.event_timestamp`
 	effectiveGameID := domain.ResolveGameID(gameID) // Ensure gameID is resolved
 	var err error
 	if tx != nil {
-		_, err = tx.ExecContext(ctx, query, matchID, tournamentID, effectiveGameID, time.Now())
+		_, err = tx.ExecContext(ctx, query, matchID, tournamentID, effectiveGameID, time.Now(), eventTime)
 	} else {
-		_, err = r.db.ExecContext(ctx, query, matchID, tournamentID, effectiveGameID, time.Now())
+		_, err = r.db.ExecContext(ctx, query, matchID, tournamentID, effectiveGameID, time.Now(), eventTime)
 	}
 
 	if err != nil {
 		return fmt.Errorf("failed to mark match event %s as processed: %w", matchID, err)
 	}
 	return nil
-}
\ No newline at end of file
+}
+// GetProcessedEventTime returns the timestamp of the latest event applied for a match.
+// Matches processed before event timestamps were recorded report the zero time.
+func (r *rankingRepository) GetProcessedEventTime(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) (time.Time, error) {
+	var eventTime sql.NullTime
+	err := tx.QueryRowContext(ctx,
+		`SELECT event_timestamp FROM processed_match_events WHERE match_id = $1`, matchID,
+	).Scan(&eventTime)
+	if err != nil && err != sql.ErrNoRows {
+		return time.Time{}, fmt.Errorf("failed to get event time for match %s: %w", matchID, err)
+	}
+	return eventTime.Time, nil
+}
+
+// ForgetMatchEvent deletes the processed marker of a match; its recorded outcomes go with it.
+func (r *rankingRepository) ForgetMatchEvent(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM processed_match_events WHERE match_id = $1`, matchID); err != nil {
+		return fmt.Errorf("failed to forget processed event for match %s: %w", matchID, err)
+	}
+	return nil
+}
+
+// RecordMatchOutcome remembers the outcome and points applied to a user for a match.
+func (r *rankingRepository) RecordMatchOutcome(ctx context.Context, tx *sql.Tx, applied AppliedOutcome) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO processed_match_outcomes (match_id, user_id, game_id, outcome, points, rating_change)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (match_id, user_id) DO UPDATE SET
+			game_id = EXCLUDED.game_id,
+			outcome = EXCLUDED.outcome,
+			points = EXCLUDED.points,
+			rating_change = EXCLUDED.rating_change`,
+		applied.MatchID, applied.UserID, domain.ResolveGameID(applied.GameID), applied.Outcome, applied.Points,
+		applied.RatingChange,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record outcome of match %s for user %s: %w", applied.MatchID, applied.UserID, err)
+	}
+	return nil
+}
+
+// GetMatchOutcomes lists the outcomes currently applied for a match.
+func (r *rankingRepository) GetMatchOutcomes(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) ([]AppliedOutcome, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT match_id, user_id, game_id, outcome, points, rating_change
+		FROM processed_match_outcomes
+		WHERE match_id = $1`, matchID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied outcomes for match %s: %w", matchID, err)
+	}
+	defer rows.Close()
+
+	var outcomes []AppliedOutcome
+	for rows.Next() {
+		var applied AppliedOutcome
+		if err := rows.Scan(
+			&applied.MatchID, &applied.UserID, &applied.GameID, &applied.Outcome, &applied.Points, &applied.RatingChange,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan applied outcome for match %s: %w", matchID, err)
+		}
+		outcomes = append(outcomes, applied)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating applied outcomes for match %s: %w", matchID, err)
+	}
+	return outcomes, nil
+}
+
+// ReverseMatchOutcome takes back the points, match counts and rating change an applied outcome gave a user.
+// Tournament participation is left in place since the user still played the match. Streaks
+// are not rewound, since the results around the corrected match are not kept.
+func (r *rankingRepository) ReverseMatchOutcome(ctx context.Context, tx *sql.Tx, applied AppliedOutcome) error {
+	wonDecrement, drawnDecrement, lostDecrement := 0, 0, 0
+	switch applied.Outcome {
+	case domain.Win:
+		wonDecrement = 1
+	case domain.Draw:
+		drawnDecrement = 1
+	default:
+		lostDecrement = 1
+	}
+
+	// The reversal is recorded in the score history as a change of its own
+	_, err := tx.ExecContext(ctx, `
+		WITH reversed AS (
+			UPDATE user_scores SET
+				score = score - $3,
+				matches_played = GREATEST(matches_played - 1, 0),
+				matches_won = GREATEST(matches_won - $4, 0),
+				matches_drawn = GREATEST(matches_drawn - $5, 0),
+				matches_lost = GREATEST(matches_lost - $6, 0),
+				updated_at = $7,
+				rating = rating - $8
+			WHERE user_id = $1 AND game_id = $2
+			RETURNING user_id, game_id, score
+		)
+		INSERT INTO score_history (user_id, game_id, score_after, delta, match_id, created_at)
+		SELECT user_id, game_id, score, -$3::INT, $9, $7 FROM reversed`,
+		applied.UserID, domain.ResolveGameID(applied.GameID), applied.Points,
+		wonDecrement, drawnDecrement, lostDecrement, time.Now(), applied.RatingChange, applied.MatchID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reverse outcome of match %s for user %s: %w", applied.MatchID, applied.UserID, err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`DELETE FROM processed_match_outcomes WHERE match_id = $1 AND user_id = $2`,
+		applied.MatchID, applied.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to clear outcome of match %s for user %s: %w", applied.MatchID, applied.UserID, err)
+	}
+	return nil
+}
+
+// RecordMatchHistory stores a user's result against an opponent. A redelivered entry replaces the old one.
+func (r *rankingRepository) RecordMatchHistory(ctx context.Context, tx *sql.Tx, entry MatchHistoryEntry) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO match_history (user_id, opponent_id, game_id, outcome, match_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (match_id, user_id, opponent_id) DO UPDATE SET
+			game_id = EXCLUDED.game_id,
+			outcome = EXCLUDED.outcome,
+			created_at = EXCLUDED.created_at`,
+		entry.UserID, entry.OpponentID, domain.ResolveGameID(entry.GameID), entry.Outcome, entry.MatchID, entry.PlayedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record history of match %s for user %s: %w", entry.MatchID, entry.UserID, err)
+	}
+	return nil
+}
+
+// ClearMatchHistory forgets every history row of a match, before a correction records it again.
+func (r *rankingRepository) ClearMatchHistory(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM match_history WHERE match_id = $1`, matchID); err != nil {
+		return fmt.Errorf("failed to clear history of match %s: %w", matchID, err)
+	}
+	return nil
+}
+
+// GetHeadToHead counts userA's wins, losses and draws against userB and lists their latest meetings.
+func (r *rankingRepository) GetHeadToHead(ctx context.Context, userA, userB uuid.UUID, gameID string, limit int) (*domain.HeadToHead, error) {
+	effectiveGameID := domain.ResolveGameID(gameID)
+	record := &domain.HeadToHead{
+		UserA:    userA,
+		UserB:    userB,
+		GameID:   effectiveGameID,
+		Meetings: []domain.HeadToHeadMeeting{},
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE outcome = $4),
+			COUNT(*) FILTER (WHERE outcome = $5),
+			COUNT(*) FILTER (WHERE outcome = $6)
+		FROM match_history
+		WHERE user_id = $1 AND opponent_id = $2 AND game_id = $3`,
+		userA, userB, effectiveGameID, domain.Win, domain.Loss, domain.Draw,
+	).Scan(&record.Wins, &record.Losses, &record.Draws)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count head-to-head results for %s vs %s: %w", userA, userB, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT match_id, outcome, created_at
+		FROM match_history
+		WHERE user_id = $1 AND opponent_id = $2 AND game_id = $3
+		ORDER BY created_at DESC
+		LIMIT $4`,
+		userA, userB, effectiveGameID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list head-to-head meetings for %s vs %s: %w", userA, userB, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var meeting domain.HeadToHeadMeeting
+		if err := rows.Scan(&meeting.MatchID, &meeting.Outcome, &meeting.PlayedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan head-to-head meeting: %w", err)
+		}
+		record.Meetings = append(record.Meetings, meeting)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating head-to-head meetings: %w", err)
+	}
+	return record, nil
+}
+
+// RecalculateUserScore replays the user's recorded outcomes oldest first in one transaction.
+// Points are the ones applied at the time, and any decay since is discarded. The Elo rating is
+// rebuilt from the rating changes recorded then, since it depended on the opponents' ratings.
+func (r *rankingRepository) RecalculateUserScore(ctx context.Context, userID uuid.UUID, gameID string) (*UserScoreData, error) {
+	effectiveGameID := domain.ResolveGameID(gameID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin recalculation for user %s: %w", userID, err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT o.outcome, o.points, o.rating_change, COALESCE(e.event_timestamp, e.processed_at)
+		FROM processed_match_outcomes o
+		JOIN processed_match_events e ON e.match_id = o.match_id
+		WHERE o.user_id = $1 AND o.game_id = $2
+		ORDER BY COALESCE(e.event_timestamp, e.processed_at), o.match_id`,
+		userID, effectiveGameID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded outcomes for user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	data := UserScoreData{UserID: userID, GameID: effectiveGameID, Rating: domain.DefaultRating}
+	for rows.Next() {
+		var outcome domain.ResultType
+		var points, ratingChange int
+		var playedAt time.Time
+		if err := rows.Scan(&outcome, &points, &ratingChange, &playedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan recorded outcome for user %s: %w", userID, err)
+		}
+		data.Score += points
+		data.Rating += ratingChange
+		data.MatchesPlayed++
+		switch outcome {
+		case domain.Win:
+			data.MatchesWon++
+			data.CurrentStreak++
+			if data.CurrentStreak > data.LongestStreak {
+				data.LongestStreak = data.CurrentStreak
+			}
+		case domain.Draw:
+			data.MatchesDrawn++
+			if r.drawsBreakStreak {
+				data.CurrentStreak = 0
+			}
+		default:
+			data.MatchesLost++
+			data.CurrentStreak = 0
+		}
+		data.UpdatedAt = playedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recorded outcomes for user %s: %w", userID, err)
+	}
+	rows.Close()
+	if data.MatchesPlayed == 0 {
+		return nil, ErrNoRecordedOutcomes
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_scores (
+			user_id, game_id, score, matches_played, matches_won, matches_drawn, matches_lost, updated_at,
+			current_streak, longest_streak, rating
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (user_id, game_id) DO UPDATE SET
+			score = EXCLUDED.score,
+			matches_played = EXCLUDED.matches_played,
+			matches_won = EXCLUDED.matches_won,
+			matches_drawn = EXCLUDED.matches_drawn,
+			matches_lost = EXCLUDED.matches_lost,
+			updated_at = EXCLUDED.updated_at,
+			current_streak = EXCLUDED.current_streak,
+			longest_streak = EXCLUDED.longest_streak,
+			rating = EXCLUDED.rating,
+			last_decayed_at = NULL`,
+		data.UserID, data.GameID, data.Score, data.MatchesPlayed, data.MatchesWon, data.MatchesDrawn,
+		data.MatchesLost, data.UpdatedAt, data.CurrentStreak, data.LongestStreak, data.Rating,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to overwrite score for user %s: %w", userID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit recalculation for user %s: %w", userID, err)
+	}
+	return &data, nil
+}
+
+// ApplyScoreDecay reduces the scores of inactive players by percent, rounded up so small scores
+// still decay, and never below zero. updated_at is left alone so the player stays inactive.
+// Each reduction is recorded in the score history without a match.
+func (r *rankingRepository) ApplyScoreDecay(ctx context.Context, percent int, inactiveSince, decayedSince, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		WITH decayed AS (
+			UPDATE user_scores us
+			SET score = GREATEST(us.score - CEIL(us.score * $1 / 100.0)::INT, 0),
+				last_decayed_at = $2
+			FROM user_scores before -- The row as it was, for the size of the cut
+			WHERE before.user_id = us.user_id AND before.game_id = us.game_id
+				AND us.score > 0
+				AND us.updated_at < $3
+				AND (us.last_decayed_at IS NULL OR us.last_decayed_at < $4)
+			RETURNING us.user_id, us.game_id, us.score, us.score - before.score AS delta
+		)
+		INSERT INTO score_history (user_id, game_id, score_after, delta, created_at)
+		SELECT user_id, game_id, score, delta, $2 FROM decayed
+	`, percent, now, inactiveSince, decayedSince)
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply score decay: %w", err)
+	}
+	decayed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count decayed scores: %w", err)
+	}
+	return decayed, nil
+}
+
+// scanSeason reads a seasons row
+func scanSeason(row interface{ Scan(...interface{}) error }) (*domain.Season, error) {
+	var season domain.Season
+	var endedAt sql.NullTime
+	if err := row.Scan(&season.ID, &season.Name, &season.StartedAt, &endedAt); err != nil {
+		return nil, err
+	}
+	if endedAt.Valid {
+		season.EndedAt = &endedAt.Time
+	}
+	return &season, nil
+}
+
+// GetCurrentSeason returns the open season
+func (r *rankingRepository) GetCurrentSeason(ctx context.Context) (*domain.Season, error) {
+	season, err := scanSeason(r.db.QueryRowContext(ctx,
+		`SELECT id, name, started_at, ended_at FROM seasons WHERE ended_at IS NULL`,
+	))
+	if err == sql.ErrNoRows {
+		return nil, ErrNoCurrentSeason
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current season: %w", err)
+	}
+	return season, nil
+}
+
+// GetSeason returns a season by ID
+func (r *rankingRepository) GetSeason(ctx context.Context, seasonID uuid.UUID) (*domain.Season, error) {
+	season, err := scanSeason(r.db.QueryRowContext(ctx,
+		`SELECT id, name, started_at, ended_at FROM seasons WHERE id = $1`, seasonID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, ErrSeasonNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season %s: %w", seasonID, err)
+	}
+	return season, nil
+}
+
+// ListSeasons returns every season, newest first
+func (r *rankingRepository) ListSeasons(ctx context.Context) ([]domain.Season, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, started_at, ended_at FROM seasons ORDER BY started_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seasons: %w", err)
+	}
+	defer rows.Close()
+
+	seasons := []domain.Season{}
+	for rows.Next() {
+		season, err := scanSeason(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan season: %w", err)
+		}
+		seasons = append(seasons, *season)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating seasons: %w", err)
+	}
+	return seasons, nil
+}
+
+// StartSeason archives every live score with a match played under the current season, then zeroes
+// the scores, match counts and streaks and resets ratings. Recorded outcomes are cleared, so
+// corrections to matches of the ended season no longer change live scores; processed match
+// markers, tournament participation and head-to-head history are kept.
+func (r *rankingRepository) StartSeason(ctx context.Context, name string, now time.Time) (*domain.Season, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin season rollover: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Match results wait for the rollover rather than landing half in each season
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE user_scores IN EXCLUSIVE MODE`); err != nil {
+		return nil, fmt.Errorf("failed to lock scores for season rollover: %w", err)
+	}
+
+	var endedID uuid.UUID
+	err = tx.QueryRowContext(ctx,
+		`UPDATE seasons SET ended_at = $1 WHERE ended_at IS NULL RETURNING id`, now,
+	).Scan(&endedID)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoCurrentSeason
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to end current season: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO seasonal_archive (
+			season_id, user_id, game_id, score, rating, matches_played, matches_won, matches_drawn,
+			matches_lost, longest_streak, last_played_at
+		)
+		SELECT $1, user_id, game_id, COALESCE(score, 0), rating, matches_played, COALESCE(matches_won, 0),
+			COALESCE(matches_drawn, 0), COALESCE(matches_lost, 0), longest_streak, updated_at
+		FROM user_scores
+		WHERE matches_played > 0`, endedID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive season %s: %w", endedID, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO score_history (user_id, game_id, score_after, delta, created_at)
+		SELECT user_id, game_id, 0, -score, $1 FROM user_scores WHERE score <> 0`, now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record season reset in score history: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE user_scores SET
+			score = 0,
+			matches_played = 0,
+			matches_won = 0,
+			matches_drawn = 0,
+			matches_lost = 0,
+			current_streak = 0,
+			longest_streak = 0,
+			rating = $1,
+			last_decayed_at = NULL`, domain.DefaultRating,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset scores: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM processed_match_outcomes`); err != nil {
+		return nil, fmt.Errorf("failed to clear recorded outcomes: %w", err)
+	}
+
+	season, err := scanSeason(tx.QueryRowContext(ctx, `
+		INSERT INTO seasons (name, started_at) VALUES ($1, $2)
+		RETURNING id, name, started_at, ended_at`, name, now,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create season %q: %w", name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit season rollover: %w", err)
+	}
+	return season, nil
+}
+
+// GetSeasonLeaderboard lists a season's archived players with at least minGames matches, ranked
+// the same way as the live leaderboard with their last match standing in for recent activity
+func (r *rankingRepository) GetSeasonLeaderboard(ctx context.Context, seasonID uuid.UUID, gameID string, minGames int, sortBy domain.LeaderboardSort, limit int, offset int) ([]domain.LeaderboardEntry, int, error) {
+	effectiveGameID := domain.ResolveGameID(gameID)
+	entries := []domain.LeaderboardEntry{}
+	if minGames < 1 {
+		minGames = 1
+	}
+
+	var totalPlayers int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM seasonal_archive WHERE season_id = $1 AND game_id = $2 AND matches_played >= $3`,
+		seasonID, effectiveGameID, minGames,
+	).Scan(&totalPlayers)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count players of season %s (game: %s): %w", seasonID, effectiveGameID, err)
+	}
+	if totalPlayers == 0 {
+		return entries, 0, nil
+	}
+
+	rank, orderBy := r.leaderboardRank(sortBy, "last_played_at", "")
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+rank+`, user_id, score, rating
+		FROM seasonal_archive
+		WHERE season_id = $1 AND game_id = $2 AND matches_played >= $3
+		ORDER BY `+orderBy+`
+		LIMIT $4 OFFSET $5`,
+		seasonID, effectiveGameID, minGames, limit, offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get leaderboard of season %s (game: %s): %w", seasonID, effectiveGameID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry domain.LeaderboardEntry
+		if err := rows.Scan(&entry.Rank, &entry.UserID, &entry.Score, &entry.Rating); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan season leaderboard entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating season leaderboard rows: %w", err)
+	}
+	return entries, totalPlayers, nil
+}
+
+// GetScoreHistory reads a user's score changes in a game. The newest limit changes in the range
+// are kept, so a long history shows its most recent part.
+func (r *rankingRepository) GetScoreHistory(ctx context.Context, userID uuid.UUID, gameID string, from, to time.Time, limit int) (*domain.ScoreHistory, error) {
+	effectiveGameID := domain.ResolveGameID(gameID)
+	history := &domain.ScoreHistory{UserID: userID, GameID: effectiveGameID, Changes: []domain.ScoreChange{}}
+
+	var fromBound, toBound sql.NullTime
+	if !from.IsZero() {
+		fromBound = sql.NullTime{Time: from, Valid: true}
+	}
+	if !to.IsZero() {
+		toBound = sql.NullTime{Time: to, Valid: true}
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT match_id, delta, score_after, created_at
+		FROM (
+			SELECT id, match_id, delta, score_after, created_at
+			FROM score_history
+			WHERE user_id = $1 AND game_id = $2
+				AND ($3::TIMESTAMPTZ IS NULL OR created_at >= $3)
+				AND ($4::TIMESTAMPTZ IS NULL OR created_at <= $4)
+			ORDER BY created_at DESC, id DESC
+			LIMIT $5
+		) latest
+		ORDER BY created_at, id`,
+		userID, effectiveGameID, fromBound, toBound, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score history for user %s, game %s: %w", userID, effectiveGameID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change domain.ScoreChange
+		var matchID uuid.NullUUID
+		if err := rows.Scan(&matchID, &change.Delta, &change.ScoreAfter, &change.At); err != nil {
+			return nil, fmt.Errorf("failed to scan score history: %w", err)
+		}
+		if matchID.Valid {
+			change.MatchID = &matchID.UUID
+		}
+		history.Changes = append(history.Changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating score history: %w", err)
+	}
+	return history, nil
+}
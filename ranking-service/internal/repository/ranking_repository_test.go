@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// leaderboardDB answers the leaderboard count with total and the ranked query with the rows of
+// userIDs, recording the arguments of both
+type leaderboardDB struct {
+	scriptedDB
+	total                 int64
+	userIDs               []uuid.UUID
+	countArgs, rankedArgs []driver.NamedValue
+}
+
+func newLeaderboardDB(total int64, userIDs ...uuid.UUID) *leaderboardDB {
+	db := &leaderboardDB{total: total, userIDs: userIDs}
+	db.query = func(query string, args []driver.NamedValue) (driver.Rows, error) {
+		if strings.HasPrefix(query, "SELECT COUNT(*)") {
+			db.countArgs = args
+			return rowsOf([]string{"count"}, []driver.Value{db.total}), nil
+		}
+		db.rankedArgs = args
+		rows := rowsOf([]string{"rank", "user_id", "score", "rating"})
+		for i, id := range db.userIDs {
+			rows.values = append(rows.values, []driver.Value{int64(i + 1), id.String(), int64(10 - i), int64(domain.DefaultRating)})
+		}
+		return rows, nil
+	}
+	return db
+}
+
+func TestGetLeaderboardAppliesTheMinimumGames(t *testing.T) {
+	db := newLeaderboardDB(2, uuid.New(), uuid.New())
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+
+	entries, total, err := repo.GetLeaderboard(context.Background(), "chess", 3, domain.SortByPoints, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+	if total != 2 || len(entries) != 2 || entries[0].UserID != db.userIDs[0] || entries[1].Rank != 2 {
+		t.Fatalf("unexpected leaderboard %+v (total %d)", entries, total)
+	}
+	// The threshold filters both the count and the listed players
+	for _, statement := range []string{db.statements("SELECT COUNT(*)")[0], db.statements("SELECT RANK()")[0]} {
+		if !strings.Contains(statement, "matches_played >= $2") {
+			t.Fatalf("expected the minimum games filter: %s", statement)
+		}
+	}
+	if db.countArgs[1].Value != 3 || db.rankedArgs[1].Value != 3 {
+		t.Fatalf("expected minimum 3 in both queries, got %v and %v", db.countArgs[1].Value, db.rankedArgs[1].Value)
+	}
+}
+
+func TestGetLeaderboardNeverListsPlayersWithoutMatches(t *testing.T) {
+	db := newLeaderboardDB(0)
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+
+	entries, total, err := repo.GetLeaderboard(context.Background(), "chess", 0, domain.SortByPoints, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+	if total != 0 || len(entries) != 0 {
+		t.Fatalf("expected an empty leaderboard, got %+v (total %d)", entries, total)
+	}
+	if db.countArgs[1].Value != 1 {
+		t.Fatalf("a minimum below 1 should be raised to 1, got %v", db.countArgs[1].Value)
+	}
+	if len(db.log) != 1 {
+		t.Fatalf("nobody to list should skip the ranked query, got %v", db.log)
+	}
+}
+
+func TestGetUserScoreDataRanksAProvisionalPlayer(t *testing.T) {
+	userID := uuid.New()
+	var args []driver.NamedValue
+	db := &scriptedDB{query: func(query string, a []driver.NamedValue) (driver.Rows, error) {
+		args = a
+		return rowsOf(
+			[]string{"score", "played", "won", "drawn", "lost", "streak", "longest", "rating", "rank", "updated_at", "tournaments"},
+			[]driver.Value{int64(3), int64(1), int64(1), int64(0), int64(0), int64(1), int64(1), int64(1212), int64(4), nil, int64(1)},
+		), nil
+	}}
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+
+	data, err := repo.GetUserScoreData(context.Background(), userID, "chess", 5)
+	if err != nil {
+		t.Fatalf("GetUserScoreData: %v", err)
+	}
+	if data.Rank != 4 || data.MatchesPlayed != 1 || data.Rating != 1212 {
+		t.Fatalf("unexpected score data %+v", data)
+	}
+	// The user is ranked among the established players even below the minimum
+	if !strings.Contains(db.log[0], "matches_played >= $3 OR user_id = $1") || args[2].Value != 5 {
+		t.Fatalf("expected the established players plus the user, got %s %v", db.log[0], args)
+	}
+}
+
+func TestGetLeaderboardOrdersByTheChosenMeasure(t *testing.T) {
+	for sortBy, order := range map[domain.LeaderboardSort]string{
+		domain.SortByPoints: "RANK() OVER (ORDER BY score DESC)",
+		domain.SortByRating: "RANK() OVER (ORDER BY rating DESC)",
+	} {
+		db := newLeaderboardDB(1, uuid.New())
+		entries, _, err := NewRankingRepository(db.open(), false, domain.TiesShared).GetLeaderboard(context.Background(), "chess", 0, sortBy, 10, 0)
+		if err != nil {
+			t.Fatalf("%s: GetLeaderboard: %v", sortBy, err)
+		}
+		if statement := db.statements("SELECT RANK()")[0]; !strings.Contains(statement, order) {
+			t.Errorf("%s: expected %q in %s", sortBy, order, statement)
+		}
+		if len(entries) != 1 || entries[0].Rating != domain.DefaultRating {
+			t.Errorf("%s: expected the rating on the entry, got %+v", sortBy, entries)
+		}
+	}
+}
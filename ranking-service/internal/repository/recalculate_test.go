@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestRecalculateUserScoreReplaysRecordedOutcomes(t *testing.T) {
+	userID := uuid.New()
+	start := time.Now().Add(-time.Hour)
+	var written []driver.NamedValue
+	db := &scriptedDB{
+		query: func(query string, args []driver.NamedValue) (driver.Rows, error) {
+			return rowsOf([]string{"outcome", "points", "rating_change", "played_at"},
+				[]driver.Value{string(domain.Win), int64(3), int64(16), start},
+				[]driver.Value{string(domain.Win), int64(3), int64(12), start.Add(time.Minute)},
+				[]driver.Value{string(domain.Loss), int64(0), int64(-20), start.Add(2 * time.Minute)},
+				[]driver.Value{string(domain.Draw), int64(1), int64(0), start.Add(3 * time.Minute)},
+			), nil
+		},
+		exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+			written = args
+			return driver.RowsAffected(1), nil
+		},
+	}
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+
+	data, err := repo.RecalculateUserScore(context.Background(), userID, "chess")
+	if err != nil {
+		t.Fatalf("RecalculateUserScore: %v", err)
+	}
+
+	want := UserScoreData{
+		UserID: userID, GameID: domain.ResolveGameID("chess"), Score: 7, MatchesPlayed: 4, MatchesWon: 2,
+		MatchesDrawn: 1, MatchesLost: 1, CurrentStreak: 0, LongestStreak: 2, Rating: domain.DefaultRating + 8,
+	}
+	if !data.UpdatedAt.Equal(start.Add(3 * time.Minute)) {
+		t.Fatalf("the last played time should come from the last outcome, got %s", data.UpdatedAt)
+	}
+	data.UpdatedAt = time.Time{}
+	if *data != want {
+		t.Fatalf("rebuilt score\nwant %+v\ngot  %+v", want, *data)
+	}
+	// The rebuilt score overwrites the stored one and clears the decay marker
+	upsert := db.statements("INSERT INTO user_scores")
+	if len(upsert) != 1 || !strings.Contains(upsert[0], "score = EXCLUDED.score") || !strings.Contains(upsert[0], "last_decayed_at = NULL") {
+		t.Fatalf("expected one overwriting upsert, got %v", db.log)
+	}
+	if written[2].Value != 7 || written[3].Value != 4 || written[10].Value != domain.DefaultRating+8 {
+		t.Fatalf("unexpected values written %v", written)
+	}
+	if len(db.statements("COMMIT")) != 1 {
+		t.Fatalf("expected the recalculation to commit, got %v", db.log)
+	}
+}
+
+func TestRecalculateUserScoreWithoutOutcomes(t *testing.T) {
+	db := &scriptedDB{} // No recorded outcomes
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+
+	if _, err := repo.RecalculateUserScore(context.Background(), uuid.New(), "chess"); !errors.Is(err, ErrNoRecordedOutcomes) {
+		t.Fatalf("expected ErrNoRecordedOutcomes, got %v", err)
+	}
+	if len(db.statements("INSERT")) != 0 || len(db.statements("COMMIT")) != 0 || len(db.statements("ROLLBACK")) != 1 {
+		t.Fatalf("nothing should be written, got %v", db.log)
+	}
+}
+
+func TestRecalculateRestoresACorruptedScore(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRankingRepository(db, false, domain.TiesShared)
+	ctx := context.Background()
+	userID, opponent, tournamentID := uuid.New(), uuid.New(), uuid.New()
+	start := time.Now().Add(-time.Hour)
+
+	for i, outcome := range []domain.ResultType{domain.Win, domain.Loss, domain.Win, domain.Win} {
+		matchID := uuid.New()
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+		if err := repo.MarkMatchEventAsProcessed(ctx, tx, matchID, tournamentID, "chess", start.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("MarkMatchEventAsProcessed: %v", err)
+		}
+		if _, err := repo.ProcessMatchOutcome(ctx, tx, userID, "chess", tournamentID, matchID, outcome, domain.DefaultPointsConfig); err != nil {
+			t.Fatalf("ProcessMatchOutcome: %v", err)
+		}
+		applied := AppliedOutcome{MatchID: matchID, UserID: userID, GameID: "chess", Outcome: outcome, Points: domain.DefaultPointsConfig.PointsFor(outcome)}
+		if err := repo.RecordMatchOutcome(ctx, tx, applied); err != nil {
+			t.Fatalf("RecordMatchOutcome: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+	}
+	putScore(t, db, opponent, "chess", 5, 1, 0, time.Now())
+	before, err := repo.GetUserScoreData(ctx, userID, "chess", 1)
+	if err != nil {
+		t.Fatalf("GetUserScoreData: %v", err)
+	}
+
+	if _, err := db.Exec(`UPDATE user_scores SET score = 999, matches_won = 0, longest_streak = 7 WHERE user_id = $1`, userID); err != nil {
+		t.Fatalf("corrupt score: %v", err)
+	}
+	if _, err := repo.RecalculateUserScore(ctx, userID, "chess"); err != nil {
+		t.Fatalf("RecalculateUserScore: %v", err)
+	}
+
+	after, err := repo.GetUserScoreData(ctx, userID, "chess", 1)
+	if err != nil {
+		t.Fatalf("GetUserScoreData: %v", err)
+	}
+	if after.Score != 9 || after.Score != before.Score || after.MatchesWon != before.MatchesWon ||
+		after.MatchesLost != before.MatchesLost || after.CurrentStreak != 2 || after.LongestStreak != 2 || after.Rank != 1 {
+		t.Fatalf("score not restored\nbefore %+v\nafter  %+v", before, after)
+	}
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestApplyScoreDecayPassesTheWindows(t *testing.T) {
+	var args []driver.NamedValue
+	db := &scriptedDB{exec: func(query string, a []driver.NamedValue) (driver.Result, error) {
+		args = a
+		return driver.RowsAffected(4), nil
+	}}
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+	now := time.Now()
+	inactiveSince, decayedSince := now.Add(-30*24*time.Hour), now.Add(-24*time.Hour)
+
+	decayed, err := repo.ApplyScoreDecay(context.Background(), 10, inactiveSince, decayedSince, now)
+	if err != nil {
+		t.Fatalf("ApplyScoreDecay: %v", err)
+	}
+	if decayed != 4 {
+		t.Fatalf("expected 4 decayed scores, got %d", decayed)
+	}
+	if len(args) != 4 || args[0].Value != 10 || args[1].Value != now || args[2].Value != inactiveSince || args[3].Value != decayedSince {
+		t.Fatalf("unexpected arguments %v", args)
+	}
+}
+
+func TestApplyScoreDecayOnlyTouchesInactivePlayers(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRankingRepository(db, false, domain.TiesShared)
+	ctx := context.Background()
+	now := time.Now()
+	stale, fresh, broke := uuid.New(), uuid.New(), uuid.New()
+	putScore(t, db, stale, "chess", 100, 30, 10, now.Add(-60*24*time.Hour))
+	putScore(t, db, fresh, "chess", 100, 30, 10, now.Add(-time.Hour))
+	putScore(t, db, broke, "chess", 0, 0, 4, now.Add(-60*24*time.Hour))
+
+	inactiveSince, decayedSince := now.Add(-30*24*time.Hour), now.Add(-24*time.Hour)
+	decayed, err := repo.ApplyScoreDecay(ctx, 15, inactiveSince, decayedSince, now)
+	if err != nil {
+		t.Fatalf("ApplyScoreDecay: %v", err)
+	}
+	if decayed != 1 {
+		t.Fatalf("expected only the stale score to decay, got %d", decayed)
+	}
+	if storedScore(t, db, stale, "chess") != 85 || storedScore(t, db, fresh, "chess") != 100 || storedScore(t, db, broke, "chess") != 0 {
+		t.Fatal("decay changed the wrong scores")
+	}
+
+	// Within the window the same score is not decayed again
+	if decayed, err := repo.ApplyScoreDecay(ctx, 15, inactiveSince, decayedSince, now.Add(time.Minute)); err != nil || decayed != 0 {
+		t.Fatalf("expected no second decay within the window, got %d, %v", decayed, err)
+	}
+	var delta int
+	if err := db.QueryRow(`SELECT delta FROM score_history WHERE user_id = $1`, stale).Scan(&delta); err != nil || delta != -15 {
+		t.Fatalf("expected the decay in the score history, got %d, %v", delta, err)
+	}
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestProcessMatchOutcomeRecordsHistoryInTheSameTransaction(t *testing.T) {
+	matchID := uuid.New()
+	var historyArgs []driver.NamedValue
+	db := &scriptedDB{
+		query: func(query string, args []driver.NamedValue) (driver.Rows, error) {
+			return rowsOf(
+				[]string{"user_id", "game_id", "score", "played", "won", "drawn", "lost", "updated_at", "streak", "longest"},
+				[]driver.Value{uuid.NewString(), "chess", int64(12), int64(4), int64(4), int64(0), int64(0), time.Now(), int64(4), int64(4)},
+			), nil
+		},
+		exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+			if strings.HasPrefix(query, "INSERT INTO score_history") {
+				historyArgs = args
+			}
+			return driver.RowsAffected(1), nil
+		},
+	}
+	conn := db.open()
+	repo := NewRankingRepository(conn, false, domain.TiesShared)
+	tx, err := conn.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+
+	if _, err := repo.ProcessMatchOutcome(context.Background(), tx, uuid.New(), "chess", uuid.Nil, matchID, domain.Win, domain.DefaultPointsConfig); err != nil {
+		t.Fatalf("ProcessMatchOutcome: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if len(db.log) != 4 || !strings.HasPrefix(db.log[2], "INSERT INTO score_history") || db.log[3] != "COMMIT" {
+		t.Fatalf("expected the history row between the score update and the commit, got %q", db.log)
+	}
+	// The change is recorded with the score after it and the match it came from
+	if historyArgs[2].Value != 12 || historyArgs[3].Value != 3 || historyArgs[4].Value != matchID {
+		t.Fatalf("expected score 12 after +3 from match %s, got %v", matchID, historyArgs)
+	}
+}
+
+func TestGetScoreHistoryBoundsAreOptional(t *testing.T) {
+	var args []driver.NamedValue
+	decayedAt := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)
+	db := &scriptedDB{query: func(query string, a []driver.NamedValue) (driver.Rows, error) {
+		args = a
+		return rowsOf([]string{"match_id", "delta", "score_after", "created_at"},
+			[]driver.Value{uuid.NewString(), int64(3), int64(3), decayedAt.Add(-time.Hour)},
+			[]driver.Value{nil, int64(-1), int64(2), decayedAt},
+		), nil
+	}}
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	history, err := repo.GetScoreHistory(context.Background(), uuid.New(), "Chess", from, time.Time{}, 50)
+	if err != nil {
+		t.Fatalf("GetScoreHistory: %v", err)
+	}
+	if history.GameID != "chess" || len(history.Changes) != 2 {
+		t.Fatalf("expected two changes in chess, got %+v", history)
+	}
+	if history.Changes[0].MatchID == nil || history.Changes[1].MatchID != nil || history.Changes[1].Delta != -1 {
+		t.Fatalf("a decay step has no match, got %+v", history.Changes)
+	}
+	if args[2].Value != (sql.NullTime{Time: from, Valid: true}) || args[3].Value != (sql.NullTime{}) || args[4].Value != 50 {
+		t.Fatalf("expected from as a bound, no upper bound and limit 50, got %v", args)
+	}
+}
+
+func TestDatabaseScoreHistoryFollowsEveryMatch(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewRankingRepository(db, false, domain.TiesShared)
+	userID := uuid.New()
+	after := playSequence(t, db, repo, userID, domain.Win, domain.Loss, domain.Win, domain.Draw)
+
+	history, err := repo.GetScoreHistory(ctx, userID, "chess", time.Time{}, time.Time{}, 500)
+	if err != nil {
+		t.Fatalf("GetScoreHistory: %v", err)
+	}
+	deltas := []int{3, 0, 3, 1}
+	if len(history.Changes) != len(deltas) {
+		t.Fatalf("expected one change per match, got %+v", history.Changes)
+	}
+	score := 0
+	for i, change := range history.Changes {
+		score += deltas[i]
+		if change.Delta != deltas[i] || change.ScoreAfter != score || change.ScoreAfter != after[i].Score || change.MatchID == nil {
+			t.Errorf("match %d: expected %+d to %d, got %+v", i+1, deltas[i], score, change)
+		}
+	}
+
+	// The limit keeps the latest changes, still oldest first
+	latest, err := repo.GetScoreHistory(ctx, userID, "chess", time.Time{}, time.Time{}, 2)
+	if err != nil {
+		t.Fatalf("GetScoreHistory with a limit: %v", err)
+	}
+	if len(latest.Changes) != 2 || latest.Changes[0].ScoreAfter != 6 || latest.Changes[1].ScoreAfter != 7 {
+		t.Fatalf("expected the last two changes, got %+v", latest.Changes)
+	}
+
+	// A range after the matches is empty
+	later, err := repo.GetScoreHistory(ctx, userID, "chess", time.Now().Add(time.Hour), time.Time{}, 500)
+	if err != nil {
+		t.Fatalf("GetScoreHistory from later: %v", err)
+	}
+	if len(later.Changes) != 0 {
+		t.Fatalf("expected no changes after the matches, got %+v", later.Changes)
+	}
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// newRolloverDB answers StartSeason's statements, ending the season endedID unless it is nil
+func newRolloverDB(endedID *uuid.UUID, name string, now time.Time) *scriptedDB {
+	db := &scriptedDB{}
+	db.query = func(query string, args []driver.NamedValue) (driver.Rows, error) {
+		switch {
+		case strings.HasPrefix(query, "UPDATE seasons"):
+			if endedID == nil {
+				return rowsOf([]string{"id"}), nil
+			}
+			return rowsOf([]string{"id"}, []driver.Value{endedID.String()}), nil
+		case strings.HasPrefix(query, "INSERT INTO seasons"):
+			return rowsOf([]string{"id", "name", "started_at", "ended_at"},
+				[]driver.Value{uuid.New().String(), name, now, nil}), nil
+		}
+		return rowsOf(nil), nil
+	}
+	return db
+}
+
+func TestStartSeasonArchivesAndResetsInOneTransaction(t *testing.T) {
+	endedID := uuid.New()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	db := newRolloverDB(&endedID, "Summer", now)
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+
+	season, err := repo.StartSeason(context.Background(), "Summer", now)
+	if err != nil {
+		t.Fatalf("StartSeason: %v", err)
+	}
+	if season.Name != "Summer" || !season.StartedAt.Equal(now) || season.EndedAt != nil {
+		t.Fatalf("expected the new open season, got %+v", season)
+	}
+
+	// The scores are locked first and the new season is opened last, all before the commit
+	want := []string{
+		"BEGIN", "LOCK TABLE user_scores", "UPDATE seasons SET ended_at", "INSERT INTO seasonal_archive",
+		"INSERT INTO score_history", "UPDATE user_scores SET", "DELETE FROM processed_match_outcomes", "INSERT INTO seasons", "COMMIT",
+	}
+	if len(db.log) != len(want) {
+		t.Fatalf("expected %d statements, got %q", len(want), db.log)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(db.log[i], prefix) {
+			t.Fatalf("statement %d: expected %s, got %s", i, prefix, db.log[i])
+		}
+	}
+	if archive := db.statements("INSERT INTO seasonal_archive")[0]; !strings.Contains(archive, "WHERE matches_played > 0") {
+		t.Fatalf("only players who played should be archived: %s", archive)
+	}
+}
+
+func TestStartSeasonWithoutAnOpenSeason(t *testing.T) {
+	db := newRolloverDB(nil, "Summer", time.Now())
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+
+	if _, err := repo.StartSeason(context.Background(), "Summer", time.Now()); !errors.Is(err, ErrNoCurrentSeason) {
+		t.Fatalf("expected ErrNoCurrentSeason, got %v", err)
+	}
+	if len(db.statements("INSERT")) != 0 || len(db.statements("COMMIT")) != 0 || len(db.statements("ROLLBACK")) != 1 {
+		t.Fatalf("nothing should be archived or reset, got %q", db.log)
+	}
+}
+
+func TestGetSeasonLeaderboardReadsTheArchive(t *testing.T) {
+	seasonID := uuid.New()
+	db := newLeaderboardDB(2, uuid.New(), uuid.New())
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+
+	entries, total, err := repo.GetSeasonLeaderboard(context.Background(), seasonID, "chess", 0, domain.SortByPoints, 10, 0)
+	if err != nil {
+		t.Fatalf("GetSeasonLeaderboard: %v", err)
+	}
+	if total != 2 || len(entries) != 2 || entries[0].UserID != db.userIDs[0] || entries[1].Rank != 2 {
+		t.Fatalf("unexpected leaderboard %+v (total %d)", entries, total)
+	}
+	for _, statement := range []string{db.statements("SELECT COUNT(*)")[0], db.statements("SELECT RANK()")[0]} {
+		if !strings.Contains(statement, "FROM seasonal_archive") || !strings.Contains(statement, "season_id = $1") {
+			t.Fatalf("expected the season's archive to be read: %s", statement)
+		}
+	}
+	// A season's leaderboard never lists players without matches either
+	if db.countArgs[0].Value != seasonID || db.countArgs[2].Value != 1 || db.rankedArgs[2].Value != 1 {
+		t.Fatalf("expected the season and a minimum of 1, got %v and %v", db.countArgs, db.rankedArgs)
+	}
+}
+
+func TestDatabaseSeasonRollover(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewRankingRepository(db, false, domain.TiesShared)
+	first, err := repo.GetCurrentSeason(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentSeason: %v", err)
+	}
+	ace, bo, idle := uuid.New(), uuid.New(), uuid.New()
+	putScore(t, db, ace, "chess", 9, 3, 0, time.Now())
+	putScore(t, db, bo, "chess", 3, 1, 2, time.Now())
+	putScore(t, db, idle, "chess", 0, 0, 0, time.Now())
+
+	second, err := repo.StartSeason(ctx, "Season 2", time.Now())
+	if err != nil {
+		t.Fatalf("StartSeason: %v", err)
+	}
+	if current, err := repo.GetCurrentSeason(ctx); err != nil || current.ID != second.ID {
+		t.Fatalf("expected the new season to be current, got %+v (%v)", current, err)
+	}
+	if ended, err := repo.GetSeason(ctx, first.ID); err != nil || ended.EndedAt == nil {
+		t.Fatalf("expected the first season to be ended, got %+v (%v)", ended, err)
+	}
+	if seasons, err := repo.ListSeasons(ctx); err != nil || len(seasons) != 2 {
+		t.Fatalf("expected both seasons, got %+v (%v)", seasons, err)
+	}
+	if _, err := repo.GetSeason(ctx, uuid.New()); !errors.Is(err, ErrSeasonNotFound) {
+		t.Fatalf("expected ErrSeasonNotFound, got %v", err)
+	}
+
+	// Live scores start again from zero
+	for _, id := range []uuid.UUID{ace, bo} {
+		if score := storedScore(t, db, id, "chess"); score != 0 {
+			t.Fatalf("expected the live score to be reset, got %d", score)
+		}
+	}
+
+	// The ended season keeps its final standings, without the player who never played
+	entries, total, err := repo.GetSeasonLeaderboard(ctx, first.ID, "chess", 1, domain.SortByPoints, 10, 0)
+	if err != nil {
+		t.Fatalf("GetSeasonLeaderboard: %v", err)
+	}
+	if total != 2 || len(entries) != 2 || entries[0].UserID != ace || entries[0].Score != 9 || entries[1].UserID != bo || entries[1].Rank != 2 {
+		t.Fatalf("unexpected archived standings %+v (total %d)", entries, total)
+	}
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// playSequence records outcomes for one player in order, each in its own transaction, and
+// returns the score data after every match
+func playSequence(t *testing.T, db *sql.DB, repo RankingRepository, userID uuid.UUID, outcomes ...domain.ResultType) []*UserScoreData {
+	t.Helper()
+	ctx := context.Background()
+	var after []*UserScoreData
+	for _, outcome := range outcomes {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+		data, err := repo.ProcessMatchOutcome(ctx, tx, userID, "chess", uuid.Nil, uuid.New(), outcome, domain.DefaultPointsConfig)
+		if err != nil {
+			tx.Rollback()
+			t.Fatalf("ProcessMatchOutcome(%s): %v", outcome, err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+		after = append(after, data)
+	}
+	return after
+}
+
+func TestStreaksAcrossWinsAndLosses(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRankingRepository(db, false, domain.TiesShared)
+
+	after := playSequence(t, db, repo, uuid.New(), domain.Win, domain.Win, domain.Loss, domain.Win)
+
+	want := []struct{ current, longest int }{{1, 1}, {2, 2}, {0, 2}, {1, 2}}
+	for i, w := range want {
+		if after[i].CurrentStreak != w.current || after[i].LongestStreak != w.longest {
+			t.Errorf("after match %d: want streak %d (best %d), got %d (best %d)",
+				i+1, w.current, w.longest, after[i].CurrentStreak, after[i].LongestStreak)
+		}
+	}
+}
+
+func TestDrawsBreakStreaksOnlyWhenConfigured(t *testing.T) {
+	db := openTestDB(t)
+
+	for drawsBreak, current := range map[bool]int{false: 2, true: 0} {
+		repo := NewRankingRepository(db, drawsBreak, domain.TiesShared)
+		after := playSequence(t, db, repo, uuid.New(), domain.Win, domain.Win, domain.Draw)
+		if got := after[2]; got.CurrentStreak != current || got.LongestStreak != 2 {
+			t.Errorf("draws break streaks %v: want streak %d (best 2), got %d (best %d)",
+				drawsBreak, current, got.CurrentStreak, got.LongestStreak)
+		}
+	}
+}
+
+func TestProcessMatchOutcomeStreakArguments(t *testing.T) {
+	for _, tc := range []struct {
+		outcome    domain.ResultType
+		drawsBreak bool
+		won, reset bool
+	}{
+		{domain.Win, false, true, false},
+		{domain.Loss, false, false, true},
+		{domain.Draw, false, false, false},
+		{domain.Draw, true, false, true},
+	} {
+		var args []driver.NamedValue
+		db := &scriptedDB{query: func(query string, a []driver.NamedValue) (driver.Rows, error) {
+			args = a
+			return rowsOf(
+				[]string{"user_id", "game_id", "score", "played", "won", "drawn", "lost", "updated_at", "streak", "longest"},
+				[]driver.Value{uuid.NewString(), "chess", int64(0), int64(1), int64(0), int64(0), int64(0), time.Now(), int64(0), int64(0)},
+			), nil
+		}}
+		conn := db.open()
+		repo := NewRankingRepository(conn, tc.drawsBreak, domain.TiesShared)
+		tx, err := conn.Begin()
+		if err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+
+		if _, err := repo.ProcessMatchOutcome(context.Background(), tx, uuid.New(), "chess", uuid.Nil, uuid.New(), tc.outcome, domain.DefaultPointsConfig); err != nil {
+			t.Fatalf("ProcessMatchOutcome(%s): %v", tc.outcome, err)
+		}
+		wonIncrement := 0
+		if tc.won {
+			wonIncrement = 1
+		}
+		if args[3].Value != wonIncrement || args[7].Value != tc.reset {
+			t.Errorf("%s (draws break %v): expected won %d and reset %v, got %v and %v",
+				tc.outcome, tc.drawsBreak, wonIncrement, tc.reset, args[3].Value, args[7].Value)
+		}
+	}
+}
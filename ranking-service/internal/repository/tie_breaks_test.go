@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestLeaderboardTieBreaksComeAfterTheScore(t *testing.T) {
+	repo := NewRankingRepository(nil, false, domain.TiesShared).(*rankingRepository)
+
+	_, orderBy := repo.leaderboardRank(domain.SortByPoints, "updated_at", "")
+	orderBy = strings.Join(strings.Fields(orderBy), " ")
+	want := "score DESC, COALESCE(matches_won, 0)::FLOAT8 / GREATEST(matches_played, 1) DESC, COALESCE(matches_won, 0) DESC, updated_at DESC NULLS LAST, user_id ASC"
+	if orderBy != want {
+		t.Fatalf("expected the ordering\n%s\ngot\n%s", want, orderBy)
+	}
+}
+
+func TestLeaderboardTieNumbering(t *testing.T) {
+	shared, _ := NewRankingRepository(nil, false, domain.TiesShared).(*rankingRepository).leaderboardRank(domain.SortByPoints, "updated_at", "")
+	if shared != "RANK() OVER (ORDER BY score DESC)" {
+		t.Fatalf("shared ties should rank on the score alone, got %s", shared)
+	}
+	sequential, orderBy := NewRankingRepository(nil, false, domain.TiesSequential).(*rankingRepository).leaderboardRank(domain.SortByRating, "last_played_at", "game_id")
+	if !strings.HasPrefix(sequential, "ROW_NUMBER() OVER (PARTITION BY game_id ORDER BY rating DESC,") || !strings.HasSuffix(sequential, orderBy+")") {
+		t.Fatalf("sequential ties should number rows in the full ordering, got %s", sequential)
+	}
+}
+
+func TestDatabaseBreaksLeaderboardTies(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+	unbeaten, busier, recent, older, behind := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	// Everyone but the last player is level on 9 points
+	putScore(t, db, older, "chess", 9, 3, 1, now.Add(-time.Hour))
+	putScore(t, db, recent, "chess", 9, 3, 1, now)
+	putScore(t, db, busier, "chess", 9, 6, 2, now.Add(-2*time.Hour))
+	putScore(t, db, unbeaten, "chess", 9, 3, 0, now.Add(-3*time.Hour))
+	putScore(t, db, behind, "chess", 3, 1, 0, now)
+	order := []uuid.UUID{unbeaten, busier, recent, older, behind}
+
+	for ties, ranks := range map[domain.TieRanking][]int{
+		domain.TiesShared:     {1, 1, 1, 1, 5},
+		domain.TiesSequential: {1, 2, 3, 4, 5},
+	} {
+		entries, total, err := NewRankingRepository(db, false, ties).GetLeaderboard(ctx, "chess", 1, domain.SortByPoints, 10, 0)
+		if err != nil {
+			t.Fatalf("%s: GetLeaderboard: %v", ties, err)
+		}
+		if total != len(order) || len(entries) != len(order) {
+			t.Fatalf("%s: expected %d players, got %d of %d", ties, len(order), len(entries), total)
+		}
+		for i, entry := range entries {
+			if entry.UserID != order[i] || entry.Rank != ranks[i] {
+				t.Errorf("%s: position %d: expected %s at rank %d, got %s at rank %d", ties, i+1, order[i], ranks[i], entry.UserID, entry.Rank)
+			}
+		}
+	}
+}
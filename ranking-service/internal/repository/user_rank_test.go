@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestUserRankIsAWindowOverTheSameQuery(t *testing.T) {
+	for ties, window := range map[domain.TieRanking]string{
+		domain.TiesShared:     "RANK() OVER (ORDER BY score DESC) AS rank",
+		domain.TiesSequential: "ROW_NUMBER() OVER (ORDER BY score DESC,",
+	} {
+		db := &scriptedDB{}
+		repo := NewRankingRepository(db.open(), false, ties)
+
+		if _, err := repo.GetUserScoreData(context.Background(), uuid.New(), "chess", 1); err != nil {
+			t.Fatalf("%s: GetUserScoreData: %v", ties, err)
+		}
+		// No score row falls back to counting tournaments only; the rank needs no query of its own
+		if len(db.log) != 2 || !strings.Contains(db.log[0], window) || strings.Contains(db.log[1], "rank") {
+			t.Fatalf("%s: expected the rank as %q in the score query, got %q", ties, window, db.log)
+		}
+	}
+}
+
+func TestListUserGamesRanksEachGameSeparately(t *testing.T) {
+	userID := uuid.New()
+	db := &scriptedDB{query: func(query string, args []driver.NamedValue) (driver.Rows, error) {
+		return rowsOf(
+			[]string{"game_id", "score", "played", "won", "drawn", "lost", "streak", "longest", "rating", "rank", "updated_at", "tournaments"},
+			[]driver.Value{"chess", int64(9), int64(3), int64(3), int64(0), int64(0), int64(3), int64(3), int64(1230), int64(1), time.Now(), int64(1)},
+			[]driver.Value{"valorant", int64(0), int64(2), int64(0), int64(0), int64(2), int64(0), int64(0), int64(1180), int64(7), time.Now(), int64(1)},
+		), nil
+	}}
+	repo := NewRankingRepository(db.open(), false, domain.TiesShared)
+
+	games, err := repo.ListUserGames(context.Background(), userID, 1)
+	if err != nil {
+		t.Fatalf("ListUserGames: %v", err)
+	}
+	if len(games) != 2 || games[0].Rank != 1 || games[1].Rank != 7 || games[1].GameID != "valorant" {
+		t.Fatalf("expected each game's rank, got %+v", games)
+	}
+	if len(db.log) != 1 || !strings.Contains(db.log[0], "RANK() OVER (PARTITION BY game_id ORDER BY score DESC) AS rank") {
+		t.Fatalf("expected one query ranking within each game, got %q", db.log)
+	}
+}
+
+func TestDatabaseUserRankMatchesTheLeaderboard(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+	first, tiedA, tiedB, last, provisional, idle := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	putScore(t, db, first, "chess", 12, 4, 0, now)
+	putScore(t, db, tiedA, "chess", 9, 3, 1, now)
+	putScore(t, db, tiedB, "chess", 9, 3, 2, now)
+	putScore(t, db, last, "chess", 3, 1, 3, now)
+	// Below a minimum of 3 matches, but still placed among the listed players
+	putScore(t, db, provisional, "chess", 6, 2, 0, now)
+	putScore(t, db, idle, "chess", 0, 0, 0, now)
+	putScore(t, db, tiedB, "go", 3, 1, 0, now)
+
+	for ties, ranks := range map[domain.TieRanking]map[uuid.UUID]int{
+		domain.TiesShared:     {first: 1, tiedA: 2, tiedB: 2, provisional: 4, last: 4, idle: 0},
+		domain.TiesSequential: {first: 1, tiedA: 2, tiedB: 3, provisional: 4, last: 4, idle: 0},
+	} {
+		repo := NewRankingRepository(db, false, ties)
+		for userID, want := range ranks {
+			data, err := repo.GetUserScoreData(ctx, userID, "chess", 3)
+			if err != nil {
+				t.Fatalf("%s: GetUserScoreData: %v", ties, err)
+			}
+			if data.Rank != want {
+				t.Errorf("%s: expected rank %d for a score of %d, got %d", ties, want, data.Score, data.Rank)
+			}
+		}
+
+		// The listed players' ranks are the leaderboard's
+		entries, _, err := repo.GetLeaderboard(ctx, "chess", 3, domain.SortByPoints, 10, 0)
+		if err != nil {
+			t.Fatalf("%s: GetLeaderboard: %v", ties, err)
+		}
+		for _, entry := range entries {
+			if entry.Rank != ranks[entry.UserID] {
+				t.Errorf("%s: the leaderboard ranks %s at %d, the user's rank is %d", ties, entry.UserID, entry.Rank, ranks[entry.UserID])
+			}
+		}
+
+		games, err := repo.ListUserGames(ctx, tiedB, 3)
+		if err != nil {
+			t.Fatalf("%s: ListUserGames: %v", ties, err)
+		}
+		if len(games) != 2 || games[0].Rank != ranks[tiedB] || games[1].GameID != "go" || games[1].Rank != 1 {
+			t.Errorf("%s: expected rank %d in chess and 1 in go, got %+v", ties, ranks[tiedB], games)
+		}
+	}
+}
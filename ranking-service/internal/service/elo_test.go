@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// rating returns the user's Elo rating in chess as the repository holds it
+func (r *fakeRepo) rating(userID uuid.UUID) int {
+	if rating, ok := r.ratings[scoreKey{userID, "chess"}]; ok {
+		return rating
+	}
+	return domain.DefaultRating
+}
+
+func TestEloWinBetweenEquallyRatedPlayersIsZeroSum(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(0)
+	winner, loser := uuid.New(), uuid.New()
+	event := resultEvent(uuid.New(), winner, loser)
+	event.Elo = true
+
+	if err := svc.ProcessMatchResults(ctx, event); err != nil {
+		t.Fatalf("ProcessMatchResults: %v", err)
+	}
+	if got := repo.rating(winner); got != domain.DefaultRating+16 {
+		t.Fatalf("the winner should gain 16, got a rating of %d", got)
+	}
+	if got := repo.rating(loser); got != domain.DefaultRating-16 {
+		t.Fatalf("the loser should lose 16, got a rating of %d", got)
+	}
+	total := 0
+	for _, applied := range repo.outcomes[event.MatchID] {
+		total += applied.RatingChange
+	}
+	if len(repo.outcomes[event.MatchID]) != 2 || total != 0 {
+		t.Fatalf("the recorded rating changes should cancel out, got %+v", repo.outcomes[event.MatchID])
+	}
+
+	// A correction takes the exchange back before applying the new result
+	correction := resultEvent(event.MatchID, loser, winner)
+	correction.Type, correction.Elo = domain.MatchEventCorrection, true
+	correction.Timestamp = event.Timestamp.Add(time.Minute)
+	if err := svc.ProcessMatchResults(ctx, correction); err != nil {
+		t.Fatalf("correction: %v", err)
+	}
+	if repo.rating(loser) != domain.DefaultRating+16 || repo.rating(winner) != domain.DefaultRating-16 {
+		t.Fatalf("expected the exchange to be reversed, got %d and %d", repo.rating(loser), repo.rating(winner))
+	}
+}
+
+func TestEloKFactorAndUnratedEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	svc := NewRankingService(repo, &fakeUsers{}, 0, 10)
+	winner, loser := uuid.New(), uuid.New()
+
+	event := resultEvent(uuid.New(), winner, loser)
+	if err := svc.ProcessMatchResults(ctx, event); err != nil {
+		t.Fatalf("unrated event: %v", err)
+	}
+	if len(repo.ratings) != 0 {
+		t.Fatalf("an event without elo must not move ratings, got %v", repo.ratings)
+	}
+
+	event = resultEvent(uuid.New(), winner, loser)
+	event.Elo = true
+	if err := svc.ProcessMatchResults(ctx, event); err != nil {
+		t.Fatalf("rated event: %v", err)
+	}
+	if got := repo.rating(winner); got != domain.DefaultRating+5 {
+		t.Fatalf("a K-factor of 10 should move equal ratings by 5, got %d", got)
+	}
+
+	// Only two-player matches are rated
+	free := resultEvent(uuid.New(), uuid.New(), uuid.New())
+	free.Users = append(free.Users, domain.UserMatchOutcome{UserID: uuid.New(), Outcome: domain.Loss})
+	free.Elo = true
+	if err := svc.ProcessMatchResults(ctx, free); err != nil {
+		t.Fatalf("three-player event: %v", err)
+	}
+	if len(repo.ratings) != 2 {
+		t.Fatalf("a three-player event must not be rated, got %v", repo.ratings)
+	}
+}
+
+func TestUserRankingReportsTheRating(t *testing.T) {
+	svc, repo, _ := newTestService(0)
+	userID := uuid.New()
+	if err := svc.ProcessMatchResults(context.Background(), resultEvent(uuid.New(), userID, uuid.New())); err != nil {
+		t.Fatalf("ProcessMatchResults: %v", err)
+	}
+	repo.score(userID, "chess").Rating = 1620
+
+	stats, err := svc.GetUserRanking(context.Background(), userID, "chess")
+	if err != nil {
+		t.Fatalf("GetUserRanking: %v", err)
+	}
+	if stats.Rating != 1620 {
+		t.Fatalf("expected the rating in the user's stats, got %d", stats.Rating)
+	}
+}
@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/client"
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/cliffdoyle/ranking-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+// txCounter is a database/sql driver that only supports transactions, counting how they end.
+// The fake repository keeps its state in memory, so ProcessMatchResults only needs a *sql.Tx.
+type txCounter struct {
+	mu                 sync.Mutex
+	commits, rollbacks int
+	// onBegin and onRollback, if set, run as a transaction starts and when it is rolled back
+	onBegin, onRollback func()
+}
+
+func (d *txCounter) Open(string) (driver.Conn, error) { return &txConn{d}, nil }
+func (d *txCounter) Connect(context.Context) (driver.Conn, error) {
+	return &txConn{d}, nil
+}
+func (d *txCounter) Driver() driver.Driver { return d }
+
+func (d *txCounter) counts() (commits, rollbacks int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.commits, d.rollbacks
+}
+
+type txConn struct{ d *txCounter }
+
+func (c *txConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("txCounter does not run statements")
+}
+func (c *txConn) Close() error { return nil }
+func (c *txConn) Begin() (driver.Tx, error) {
+	if c.d.onBegin != nil {
+		c.d.onBegin()
+	}
+	return c, nil
+}
+func (c *txConn) Commit() error {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.commits++
+	return nil
+}
+func (c *txConn) Rollback() error {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.rollbacks++
+	if c.d.onRollback != nil {
+		c.d.onRollback()
+	}
+	return nil
+}
+
+type scoreKey struct {
+	userID uuid.UUID
+	gameID string
+}
+
+// fakeRepo is an in-memory RankingRepository. Methods a test does not need are left to the
+// embedded nil interface and panic if called.
+type fakeRepo struct {
+	repository.RankingRepository
+
+	tx        *txCounter
+	db        *sql.DB
+	scores    map[scoreKey]*repository.UserScoreData
+	ratings   map[scoreKey]int
+	processed map[uuid.UUID]time.Time
+	outcomes  map[uuid.UUID][]repository.AppliedOutcome
+	history   []repository.MatchHistoryEntry
+	changes   map[scoreKey][]domain.ScoreChange
+	seasons   map[uuid.UUID]*domain.Season
+	current   *domain.Season
+	// archives holds the leaderboards of ended seasons per game, copied from leaderboards on rollover
+	archives map[uuid.UUID]map[string][]domain.LeaderboardEntry
+
+	// leaderboards holds what GetLeaderboard returns per game, before paging
+	leaderboards map[string][]domain.LeaderboardEntry
+	// leaderboardCalls records the arguments of every GetLeaderboard call
+	leaderboardCalls []leaderboardCall
+	// failOutcomeFor makes ProcessMatchOutcome fail for that user
+	failOutcomeFor *uuid.UUID
+	// decayCalls records the arguments of every ApplyScoreDecay call
+	decayCalls []decayCall
+}
+
+type decayCall struct {
+	percent                          int
+	inactiveSince, decayedSince, now time.Time
+}
+
+type leaderboardCall struct {
+	gameID        string
+	minGames      int
+	sortBy        domain.LeaderboardSort
+	limit, offset int
+}
+
+func newFakeRepo() *fakeRepo {
+	tx := &txCounter{}
+	season := &domain.Season{ID: uuid.New(), Name: "Season 1", StartedAt: time.Now().Add(-24 * time.Hour)}
+	repo := &fakeRepo{
+		tx:           tx,
+		db:           sql.OpenDB(tx),
+		scores:       make(map[scoreKey]*repository.UserScoreData),
+		ratings:      make(map[scoreKey]int),
+		processed:    make(map[uuid.UUID]time.Time),
+		outcomes:     make(map[uuid.UUID][]repository.AppliedOutcome),
+		changes:      make(map[scoreKey][]domain.ScoreChange),
+		seasons:      map[uuid.UUID]*domain.Season{season.ID: season},
+		current:      season,
+		archives:     make(map[uuid.UUID]map[string][]domain.LeaderboardEntry),
+		leaderboards: make(map[string][]domain.LeaderboardEntry),
+	}
+	// A rolled back event is not left marked as processed, so it can be retried
+	var processed map[uuid.UUID]time.Time
+	tx.onBegin = func() {
+		processed = make(map[uuid.UUID]time.Time, len(repo.processed))
+		for id, at := range repo.processed {
+			processed[id] = at
+		}
+	}
+	tx.onRollback = func() { repo.processed = processed }
+	return repo
+}
+
+func (r *fakeRepo) DB() *sql.DB { return r.db }
+
+// score returns the stored score of a user in a game, or nil if they have none
+func (r *fakeRepo) score(userID uuid.UUID, gameID string) *repository.UserScoreData {
+	return r.scores[scoreKey{userID, domain.ResolveGameID(gameID)}]
+}
+
+func (r *fakeRepo) ProcessMatchOutcome(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string, tournamentID uuid.UUID, matchID uuid.UUID, outcome domain.ResultType, pointsConfig domain.PointsConfig) (*repository.UserScoreData, error) {
+	if r.failOutcomeFor != nil && *r.failOutcomeFor == userID {
+		return nil, errors.New("outcome rejected")
+	}
+	key := scoreKey{userID, domain.ResolveGameID(gameID)}
+	score := r.scores[key]
+	if score == nil {
+		score = &repository.UserScoreData{UserID: userID, GameID: key.gameID, Rating: domain.DefaultRating}
+		r.scores[key] = score
+	}
+	score.Score += pointsConfig.PointsFor(outcome)
+	score.MatchesPlayed++
+	switch outcome {
+	case domain.Win:
+		score.MatchesWon++
+	case domain.Draw:
+		score.MatchesDrawn++
+	default:
+		score.MatchesLost++
+	}
+	score.UpdatedAt = time.Now()
+	r.changes[key] = append(r.changes[key], domain.ScoreChange{
+		MatchID: &matchID, Delta: pointsConfig.PointsFor(outcome), ScoreAfter: score.Score, At: score.UpdatedAt,
+	})
+	return score, nil
+}
+
+func (r *fakeRepo) GetScoreHistory(ctx context.Context, userID uuid.UUID, gameID string, from, to time.Time, limit int) (*domain.ScoreHistory, error) {
+	key := scoreKey{userID, domain.ResolveGameID(gameID)}
+	return &domain.ScoreHistory{UserID: userID, GameID: key.gameID, Changes: r.changes[key]}, nil
+}
+
+func (r *fakeRepo) GetUserScoreData(ctx context.Context, userID uuid.UUID, gameID string, minGames int) (*repository.UserScoreData, error) {
+	if score := r.score(userID, gameID); score != nil {
+		copied := *score
+		return &copied, nil
+	}
+	return &repository.UserScoreData{UserID: userID, GameID: domain.ResolveGameID(gameID), Rating: domain.DefaultRating}, nil
+}
+
+func (r *fakeRepo) ListUserGames(ctx context.Context, userID uuid.UUID, minGames int) ([]repository.UserScoreData, error) {
+	var games []repository.UserScoreData
+	for key, score := range r.scores {
+		if key.userID == userID {
+			games = append(games, *score)
+		}
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].GameID < games[j].GameID })
+	return games, nil
+}
+
+func (r *fakeRepo) GetRating(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string) (int, error) {
+	if rating, ok := r.ratings[scoreKey{userID, domain.ResolveGameID(gameID)}]; ok {
+		return rating, nil
+	}
+	return domain.DefaultRating, nil
+}
+
+func (r *fakeRepo) AdjustRating(ctx context.Context, tx *sql.Tx, userID uuid.UUID, gameID string, change int) error {
+	key := scoreKey{userID, domain.ResolveGameID(gameID)}
+	rating, ok := r.ratings[key]
+	if !ok {
+		rating = domain.DefaultRating
+	}
+	r.ratings[key] = rating + change
+	return nil
+}
+
+func (r *fakeRepo) GetLeaderboard(ctx context.Context, gameID string, minGames int, sortBy domain.LeaderboardSort, limit int, offset int) ([]domain.LeaderboardEntry, int, error) {
+	r.leaderboardCalls = append(r.leaderboardCalls, leaderboardCall{gameID, minGames, sortBy, limit, offset})
+	all := r.leaderboards[domain.ResolveGameID(gameID)]
+	if offset >= len(all) {
+		return []domain.LeaderboardEntry{}, len(all), nil
+	}
+	end := offset + limit
+	if end > len(all) {
+		end = len(all)
+	}
+	page := make([]domain.LeaderboardEntry, end-offset)
+	copy(page, all[offset:end])
+	return page, len(all), nil
+}
+
+func (r *fakeRepo) IsMatchEventProcessed(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) (bool, error) {
+	_, ok := r.processed[matchID]
+	return ok, nil
+}
+
+func (r *fakeRepo) MarkMatchEventAsProcessed(ctx context.Context, tx *sql.Tx, matchID uuid.UUID, tournamentID uuid.UUID, gameID string, eventTime time.Time) error {
+	r.processed[matchID] = eventTime
+	return nil
+}
+
+func (r *fakeRepo) GetProcessedEventTime(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) (time.Time, error) {
+	return r.processed[matchID], nil
+}
+
+func (r *fakeRepo) ForgetMatchEvent(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) error {
+	// Its recorded outcomes go with it, as with the ON DELETE CASCADE
+	delete(r.processed, matchID)
+	delete(r.outcomes, matchID)
+	return nil
+}
+
+func (r *fakeRepo) RecordMatchOutcome(ctx context.Context, tx *sql.Tx, applied repository.AppliedOutcome) error {
+	// Like the foreign key on processed_match_outcomes, an outcome needs its processed event
+	if _, ok := r.processed[applied.MatchID]; !ok {
+		return errors.New("match event not marked as processed")
+	}
+	r.outcomes[applied.MatchID] = append(r.outcomes[applied.MatchID], applied)
+	return nil
+}
+
+func (r *fakeRepo) GetMatchOutcomes(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) ([]repository.AppliedOutcome, error) {
+	return r.outcomes[matchID], nil
+}
+
+func (r *fakeRepo) ReverseMatchOutcome(ctx context.Context, tx *sql.Tx, applied repository.AppliedOutcome) error {
+	score := r.score(applied.UserID, applied.GameID)
+	if score == nil {
+		return errors.New("no score to reverse")
+	}
+	score.Score -= applied.Points
+	score.MatchesPlayed--
+	switch applied.Outcome {
+	case domain.Win:
+		score.MatchesWon--
+	case domain.Draw:
+		score.MatchesDrawn--
+	default:
+		score.MatchesLost--
+	}
+	if applied.RatingChange != 0 {
+		r.ratings[scoreKey{applied.UserID, domain.ResolveGameID(applied.GameID)}] -= applied.RatingChange
+	}
+	kept := r.outcomes[applied.MatchID][:0]
+	for _, o := range r.outcomes[applied.MatchID] {
+		if o.UserID != applied.UserID {
+			kept = append(kept, o)
+		}
+	}
+	r.outcomes[applied.MatchID] = kept
+	return nil
+}
+
+func (r *fakeRepo) RecordMatchHistory(ctx context.Context, tx *sql.Tx, entry repository.MatchHistoryEntry) error {
+	r.history = append(r.history, entry)
+	return nil
+}
+
+func (r *fakeRepo) ClearMatchHistory(ctx context.Context, tx *sql.Tx, matchID uuid.UUID) error {
+	kept := r.history[:0]
+	for _, entry := range r.history {
+		if entry.MatchID != matchID {
+			kept = append(kept, entry)
+		}
+	}
+	r.history = kept
+	return nil
+}
+
+func (r *fakeRepo) ApplyScoreDecay(ctx context.Context, percent int, inactiveSince, decayedSince, now time.Time) (int64, error) {
+	r.decayCalls = append(r.decayCalls, decayCall{percent, inactiveSince, decayedSince, now})
+	return 1, nil
+}
+
+func (r *fakeRepo) GetCurrentSeason(ctx context.Context) (*domain.Season, error) {
+	if r.current == nil {
+		return nil, repository.ErrNoCurrentSeason
+	}
+	return r.current, nil
+}
+
+func (r *fakeRepo) GetSeason(ctx context.Context, seasonID uuid.UUID) (*domain.Season, error) {
+	season, ok := r.seasons[seasonID]
+	if !ok {
+		return nil, repository.ErrSeasonNotFound
+	}
+	return season, nil
+}
+
+func (r *fakeRepo) ListSeasons(ctx context.Context) ([]domain.Season, error) {
+	seasons := []domain.Season{}
+	for _, season := range r.seasons {
+		seasons = append(seasons, *season)
+	}
+	return seasons, nil
+}
+
+// StartSeason archives the live leaderboards and scores under the current season and clears them
+func (r *fakeRepo) StartSeason(ctx context.Context, name string, now time.Time) (*domain.Season, error) {
+	if r.current == nil {
+		return nil, repository.ErrNoCurrentSeason
+	}
+	r.current.EndedAt = &now
+	r.archives[r.current.ID] = r.leaderboards
+	r.leaderboards = make(map[string][]domain.LeaderboardEntry)
+	r.scores = make(map[scoreKey]*repository.UserScoreData)
+	r.ratings = make(map[scoreKey]int)
+	r.outcomes = make(map[uuid.UUID][]repository.AppliedOutcome)
+
+	r.current = &domain.Season{ID: uuid.New(), Name: name, StartedAt: now}
+	r.seasons[r.current.ID] = r.current
+	return r.current, nil
+}
+
+func (r *fakeRepo) GetSeasonLeaderboard(ctx context.Context, seasonID uuid.UUID, gameID string, minGames int, sortBy domain.LeaderboardSort, limit int, offset int) ([]domain.LeaderboardEntry, int, error) {
+	all := r.archives[seasonID][domain.ResolveGameID(gameID)]
+	if offset >= len(all) {
+		return []domain.LeaderboardEntry{}, len(all), nil
+	}
+	end := offset + limit
+	if end > len(all) {
+		end = len(all)
+	}
+	page := make([]domain.LeaderboardEntry, end-offset)
+	copy(page, all[offset:end])
+	return page, len(all), nil
+}
+
+// fakeUsers is a UserServiceClient answering from a fixed set of users
+type fakeUsers struct {
+	details map[uuid.UUID]client.UserDetails
+	err     error
+	calls   [][]uuid.UUID
+}
+
+func (u *fakeUsers) GetMultipleUserDetails(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]client.UserDetails, error) {
+	u.calls = append(u.calls, userIDs)
+	if u.err != nil {
+		return nil, u.err
+	}
+	found := make(map[uuid.UUID]client.UserDetails)
+	for _, id := range userIDs {
+		if details, ok := u.details[id]; ok {
+			found[id] = details
+		}
+	}
+	return found, nil
+}
+
+// newTestService builds a rankingService over a fake repository with a minimum of minGames
+func newTestService(minGames int) (*rankingService, *fakeRepo, *fakeUsers) {
+	repo := newFakeRepo()
+	users := &fakeUsers{details: make(map[uuid.UUID]client.UserDetails)}
+	return NewRankingService(repo, users, minGames, 0).(*rankingService), repo, users
+}
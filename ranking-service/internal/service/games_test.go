@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestResultsOfUnknownGamesStayApart(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(1)
+	winner, loser := uuid.New(), uuid.New()
+
+	for _, gameID := range []string{"Apex Legends Mobile", "Halo Infinite", "Rocket League"} {
+		event := resultEvent(uuid.New(), winner, loser)
+		event.GameID = gameID
+		if err := svc.ProcessMatchResults(ctx, event); err != nil {
+			t.Fatalf("%s: ProcessMatchResults: %v", gameID, err)
+		}
+	}
+	for _, gameID := range []string{"apex-legends-mobile", "halo-infinite", "rocket-league"} {
+		if score := repo.score(winner, gameID); score == nil || score.MatchesPlayed != 1 {
+			t.Errorf("%s: expected one match on its own leaderboard, got %+v", gameID, score)
+		}
+	}
+	if len(repo.scores) != 6 {
+		t.Fatalf("expected a score per player and game, got %d", len(repo.scores))
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestProcessMatchResultsRecordsHeadToHeadHistory(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(0)
+	a, b := uuid.New(), uuid.New()
+	draw := resultEvent(uuid.New(), a, b)
+	draw.Users[0].Outcome, draw.Users[1].Outcome = domain.Draw, domain.Draw
+
+	for _, event := range []domain.MatchResultEvent{resultEvent(uuid.New(), a, b), resultEvent(uuid.New(), b, a), draw} {
+		if err := svc.ProcessMatchResults(ctx, event); err != nil {
+			t.Fatalf("ProcessMatchResults: %v", err)
+		}
+	}
+
+	// One row per player and opponent, written in the same transaction as the scores
+	if len(repo.history) != 6 {
+		t.Fatalf("expected 6 history rows, got %d", len(repo.history))
+	}
+	if commits, rollbacks := repo.tx.counts(); commits != 3 || rollbacks != 0 {
+		t.Fatalf("expected 3 commits, got %d commits and %d rollbacks", commits, rollbacks)
+	}
+	outcomes := map[uuid.UUID][]domain.ResultType{}
+	for _, entry := range repo.history {
+		if entry.UserID == entry.OpponentID || entry.GameID != "chess" || entry.PlayedAt.IsZero() {
+			t.Fatalf("malformed history row %+v", entry)
+		}
+		outcomes[entry.UserID] = append(outcomes[entry.UserID], entry.Outcome)
+	}
+	wantA := []domain.ResultType{domain.Win, domain.Loss, domain.Draw}
+	wantB := []domain.ResultType{domain.Loss, domain.Win, domain.Draw}
+	for i := range wantA {
+		if outcomes[a][i] != wantA[i] || outcomes[b][i] != wantB[i] {
+			t.Fatalf("expected %v and %v, got %v and %v", wantA, wantB, outcomes[a], outcomes[b])
+		}
+	}
+}
+
+func TestCorrectionReplacesHeadToHeadHistory(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(0)
+	a, b := uuid.New(), uuid.New()
+	matchID := uuid.New()
+	result := resultEvent(matchID, a, b)
+	if err := svc.ProcessMatchResults(ctx, result); err != nil {
+		t.Fatalf("result: %v", err)
+	}
+
+	correction := resultEvent(matchID, b, a)
+	correction.Type = domain.MatchEventCorrection
+	correction.Timestamp = result.Timestamp.Add(time.Minute)
+	if err := svc.ProcessMatchResults(ctx, correction); err != nil {
+		t.Fatalf("correction: %v", err)
+	}
+
+	if len(repo.history) != 2 {
+		t.Fatalf("a correction should replace the match's history, got %d rows", len(repo.history))
+	}
+	for _, entry := range repo.history {
+		if entry.UserID == b && entry.Outcome != domain.Win {
+			t.Fatalf("expected the corrected result, got %+v", entry)
+		}
+	}
+}
+
+func TestHeadToHeadNeedsTwoUsers(t *testing.T) {
+	svc, _, _ := newTestService(0)
+	userID := uuid.New()
+
+	if _, err := svc.GetHeadToHead(context.Background(), userID, userID, "chess", 10); err == nil {
+		t.Fatal("a player has no record against themselves")
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/client"
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestGetLeaderboardsResolvesNamesOnce(t *testing.T) {
+	svc, repo, users := newTestService(1)
+	both, chessOnly, fifaOnly, third := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	users.details[both] = client.UserDetails{ID: both, Username: "ace"}
+	users.details[fifaOnly] = client.UserDetails{ID: fifaOnly, Username: "striker"}
+	chess, fifa := domain.ResolveGameID("chess"), domain.ResolveGameID("fifa")
+	repo.leaderboards[chess] = []domain.LeaderboardEntry{
+		{Rank: 1, UserID: both, Score: 30}, {Rank: 2, UserID: chessOnly, Score: 20}, {Rank: 3, UserID: third, Score: 10},
+	}
+	repo.leaderboards[fifa] = []domain.LeaderboardEntry{{Rank: 1, UserID: fifaOnly, Score: 9}, {Rank: 2, UserID: both, Score: 6}}
+
+	leaderboards, err := svc.GetLeaderboards(context.Background(), []string{"chess", "fifa", "chess"}, 2)
+	if err != nil {
+		t.Fatalf("GetLeaderboards: %v", err)
+	}
+
+	if len(leaderboards) != 2 || len(leaderboards[chess]) != 2 || len(leaderboards[fifa]) != 2 {
+		t.Fatalf("expected the top 2 of both games, got %+v", leaderboards)
+	}
+	if len(repo.leaderboardCalls) != 2 {
+		t.Fatalf("a repeated game should be read once, got %d reads", len(repo.leaderboardCalls))
+	}
+	for _, call := range repo.leaderboardCalls {
+		if call.limit != 2 || call.offset != 0 || call.minGames != 1 {
+			t.Fatalf("unexpected leaderboard read %+v", call)
+		}
+	}
+	// The player on both leaderboards is only looked up once, and the third-placed one not at all
+	if len(users.calls) != 1 || len(users.calls[0]) != 3 {
+		t.Fatalf("expected one lookup of 3 players, got %v", users.calls)
+	}
+	if leaderboards[chess][0].UserName != "ace" || leaderboards[fifa][1].UserName != "ace" ||
+		leaderboards[fifa][0].UserName != "striker" || leaderboards[chess][1].UserName != "Player" {
+		t.Fatalf("names not attached: %+v", leaderboards)
+	}
+}
+
+func TestGetLeaderboardsWithoutPlayers(t *testing.T) {
+	svc, _, users := newTestService(1)
+
+	leaderboards, err := svc.GetLeaderboards(context.Background(), []string{"chess"}, 5)
+	if err != nil {
+		t.Fatalf("GetLeaderboards: %v", err)
+	}
+	entries, ok := leaderboards[domain.ResolveGameID("chess")]
+	if !ok || entries == nil || len(entries) != 0 {
+		t.Fatalf("an empty game should map to an empty list, got %#v", leaderboards)
+	}
+	if len(users.calls) != 0 {
+		t.Fatalf("no players means no user lookup, got %v", users.calls)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestProcessMatchResultsSkipsARepeatedResult(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(0)
+	winner, loser := uuid.New(), uuid.New()
+	event := resultEvent(uuid.New(), winner, loser)
+
+	for i := 0; i < 2; i++ {
+		if err := svc.ProcessMatchResults(ctx, event); err != nil {
+			t.Fatalf("delivery %d: %v", i+1, err)
+		}
+	}
+
+	if score := repo.score(winner, "chess"); score.Score != 3 || score.MatchesPlayed != 1 {
+		t.Fatalf("a retried delivery must not count twice, got %d points over %d matches", score.Score, score.MatchesPlayed)
+	}
+	if commits, rollbacks := repo.tx.counts(); commits != 2 || rollbacks != 0 {
+		t.Fatalf("expected both deliveries to commit, got %d commits and %d rollbacks", commits, rollbacks)
+	}
+}
+
+func TestProcessMatchResultsCorrectionReversesThePreviousOutcome(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(0)
+	first, second := uuid.New(), uuid.New()
+	matchID := uuid.New()
+	result := resultEvent(matchID, first, second)
+	if err := svc.ProcessMatchResults(ctx, result); err != nil {
+		t.Fatalf("result: %v", err)
+	}
+
+	correction := resultEvent(matchID, second, first)
+	correction.Type = domain.MatchEventCorrection
+	correction.Timestamp = result.Timestamp.Add(time.Minute)
+	if err := svc.ProcessMatchResults(ctx, correction); err != nil {
+		t.Fatalf("correction: %v", err)
+	}
+
+	was, now := repo.score(first, "chess"), repo.score(second, "chess")
+	if was.Score != 0 || was.MatchesWon != 0 || was.MatchesLost != 1 || was.MatchesPlayed != 1 {
+		t.Fatalf("the original winner should now have one loss and no points: %+v", was)
+	}
+	if now.Score != 3 || now.MatchesWon != 1 || now.MatchesLost != 0 || now.MatchesPlayed != 1 {
+		t.Fatalf("the corrected winner should have one win worth 3 points: %+v", now)
+	}
+	if len(repo.outcomes[matchID]) != 2 {
+		t.Fatalf("only the corrected outcomes should be recorded, got %d", len(repo.outcomes[matchID]))
+	}
+}
+
+func TestProcessMatchResultsIgnoresAStaleCorrection(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(0)
+	winner, loser := uuid.New(), uuid.New()
+	matchID := uuid.New()
+	result := resultEvent(matchID, winner, loser)
+	if err := svc.ProcessMatchResults(ctx, result); err != nil {
+		t.Fatalf("result: %v", err)
+	}
+
+	// A correction that is not newer than the applied result, e.g. one delivered out of order
+	correction := resultEvent(matchID, loser, winner)
+	correction.Type = domain.MatchEventCorrection
+	correction.Timestamp = result.Timestamp
+	if err := svc.ProcessMatchResults(ctx, correction); err != nil {
+		t.Fatalf("correction: %v", err)
+	}
+
+	if repo.score(winner, "chess").Score != 3 || repo.score(loser, "chess").Score != 0 {
+		t.Fatal("a stale correction must not change the scores")
+	}
+}
+
+func TestProcessMatchResultsRollsBackWhenAnOutcomeFails(t *testing.T) {
+	svc, repo, _ := newTestService(0)
+	winner, loser := uuid.New(), uuid.New()
+	repo.failOutcomeFor = &loser
+	event := resultEvent(uuid.New(), winner, loser)
+
+	if err := svc.ProcessMatchResults(context.Background(), event); err == nil {
+		t.Fatal("expected the failed outcome to be reported")
+	}
+	if commits, rollbacks := repo.tx.counts(); commits != 0 || rollbacks != 1 {
+		t.Fatalf("expected a rollback, got %d commits and %d rollbacks", commits, rollbacks)
+	}
+	if _, processed := repo.processed[event.MatchID]; processed {
+		t.Fatal("a failed event must stay unprocessed so it can be retried")
+	}
+}
+
+func TestProcessMatchResultsReversalTakesBackTheOutcome(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(0)
+	first, second := uuid.New(), uuid.New()
+	matchID := uuid.New()
+	result := resultEvent(matchID, first, second)
+	if err := svc.ProcessMatchResults(ctx, result); err != nil {
+		t.Fatalf("result: %v", err)
+	}
+
+	reversal := resultEvent(matchID, first, second)
+	reversal.Type = domain.MatchEventReversal
+	reversal.Timestamp = result.Timestamp.Add(time.Minute)
+	if err := svc.ProcessMatchResults(ctx, reversal); err != nil {
+		t.Fatalf("reversal: %v", err)
+	}
+	for _, userID := range []uuid.UUID{first, second} {
+		if score := repo.score(userID, "chess"); score.Score != 0 || score.MatchesPlayed != 0 || score.MatchesWon != 0 || score.MatchesLost != 0 {
+			t.Fatalf("the reversed match should leave no trace: %+v", score)
+		}
+	}
+	if _, processed := repo.processed[matchID]; processed || len(repo.outcomes[matchID]) != 0 {
+		t.Fatal("the match should be forgotten after a reversal")
+	}
+
+	// The result reported after the reset is applied as a new one
+	replay := resultEvent(matchID, second, first)
+	replay.Timestamp = reversal.Timestamp.Add(time.Minute)
+	if err := svc.ProcessMatchResults(ctx, replay); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if repo.score(second, "chess").Score != 3 || repo.score(first, "chess").MatchesLost != 1 {
+		t.Fatal("the result after the reversal was not applied")
+	}
+}
+
+func TestProcessMatchResultsIgnoresAReversalOfAnUnknownMatch(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(0)
+	reversal := resultEvent(uuid.New(), uuid.New(), uuid.New())
+	reversal.Type = domain.MatchEventReversal
+
+	if err := svc.ProcessMatchResults(ctx, reversal); err != nil {
+		t.Fatalf("reversal: %v", err)
+	}
+	if len(repo.scores) != 0 || len(repo.processed) != 0 {
+		t.Fatal("a reversal of a match never applied must change nothing")
+	}
+}
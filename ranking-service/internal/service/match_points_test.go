@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// resultEvent is a two-player result in which winner beat loser
+func resultEvent(matchID, winner, loser uuid.UUID) domain.MatchResultEvent {
+	return domain.MatchResultEvent{
+		GameID:       "chess",
+		TournamentID: uuid.New(),
+		MatchID:      matchID,
+		Timestamp:    time.Now(),
+		Users: []domain.UserMatchOutcome{
+			{UserID: winner, Outcome: domain.Win},
+			{UserID: loser, Outcome: domain.Loss},
+		},
+	}
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestProcessMatchResultsAppliesTournamentPoints(t *testing.T) {
+	svc, repo, _ := newTestService(0)
+	winner, loser := uuid.New(), uuid.New()
+	event := resultEvent(uuid.New(), winner, loser)
+	event.PointsWin, event.PointsLoss = intPtr(2), intPtr(1)
+
+	if err := svc.ProcessMatchResults(context.Background(), event); err != nil {
+		t.Fatalf("ProcessMatchResults: %v", err)
+	}
+
+	if got := repo.score(winner, "chess").Score; got != 2 {
+		t.Fatalf("winner should get the configured 2 points, got %d", got)
+	}
+	if got := repo.score(loser, "chess").Score; got != 1 {
+		t.Fatalf("loser should get the configured 1 point, got %d", got)
+	}
+	// The applied points are kept so a later correction reverses exactly what was given
+	for _, applied := range repo.outcomes[event.MatchID] {
+		want := map[uuid.UUID]int{winner: 2, loser: 1}[applied.UserID]
+		if applied.Points != want {
+			t.Errorf("user %s: recorded %d points, want %d", applied.UserID, applied.Points, want)
+		}
+	}
+}
+
+func TestProcessMatchResultsDefaultsToThreeOneZero(t *testing.T) {
+	svc, repo, _ := newTestService(0)
+	winner, loser := uuid.New(), uuid.New()
+
+	if err := svc.ProcessMatchResults(context.Background(), resultEvent(uuid.New(), winner, loser)); err != nil {
+		t.Fatalf("ProcessMatchResults: %v", err)
+	}
+	if repo.score(winner, "chess").Score != 3 || repo.score(loser, "chess").Score != 0 {
+		t.Fatalf("expected 3/0, got %d/%d", repo.score(winner, "chess").Score, repo.score(loser, "chess").Score)
+	}
+}
+
+func TestProcessMatchResultsRejectsNegativePoints(t *testing.T) {
+	svc, repo, _ := newTestService(0)
+	event := resultEvent(uuid.New(), uuid.New(), uuid.New())
+	event.PointsLoss = intPtr(-1)
+
+	if err := svc.ProcessMatchResults(context.Background(), event); err == nil {
+		t.Fatal("expected negative points to be rejected")
+	}
+	if commits, rollbacks := repo.tx.counts(); commits+rollbacks != 0 {
+		t.Fatal("the event should be rejected before a transaction is started")
+	}
+	if len(repo.scores) != 0 {
+		t.Fatal("no score should be changed")
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/cliffdoyle/ranking-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+func TestUserRankingIsProvisionalBelowTheMinimum(t *testing.T) {
+	svc, repo, _ := newTestService(3)
+	userID := uuid.New()
+	key := scoreKey{userID, domain.ResolveGameID("chess")}
+
+	for played, provisional := range map[int]bool{0: true, 2: true, 3: false, 7: false} {
+		repo.scores[key] = &repository.UserScoreData{UserID: userID, GameID: key.gameID, MatchesPlayed: played, Rank: 1}
+		stats, err := svc.GetUserRanking(context.Background(), userID, "chess")
+		if err != nil {
+			t.Fatalf("GetUserRanking: %v", err)
+		}
+		if stats.Provisional != provisional {
+			t.Errorf("%d of 3 games played: expected provisional %v", played, provisional)
+		}
+	}
+}
+
+func TestLeaderboardDefaultsToTheServerMinimum(t *testing.T) {
+	svc, repo, _ := newTestService(3)
+
+	for _, minGames := range []int{0, 10} {
+		if _, _, err := svc.GetLeaderboard(context.Background(), "chess", minGames, domain.SortByPoints, 1, 20); err != nil {
+			t.Fatalf("GetLeaderboard: %v", err)
+		}
+	}
+	if len(repo.leaderboardCalls) != 2 || repo.leaderboardCalls[0].minGames != 3 || repo.leaderboardCalls[1].minGames != 10 {
+		t.Fatalf("expected the server minimum unless one is asked for, got %+v", repo.leaderboardCalls)
+	}
+}
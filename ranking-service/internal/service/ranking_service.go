@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"sort" // For sorting user IDs for batch fetching
+	"time"
 
 	"github.com/cliffdoyle/ranking-service/internal/client" // Assuming client package
 	"github.com/cliffdoyle/ranking-service/internal/domain"
@@ -17,22 +18,54 @@ import (
 type RankingService interface {
 	ProcessMatchResults(ctx context.Context, event domain.MatchResultEvent) error
 	GetUserRanking(ctx context.Context, userID uuid.UUID, gameID string) (*domain.UserOverallStats, error)
-	GetLeaderboard(ctx context.Context, gameID string, page int, pageSize int) ([]domain.LeaderboardEntry, int, error)
+	GetUserRankingsByGame(ctx context.Context, userID uuid.UUID) ([]domain.UserOverallStats, error)
+	// GetLeaderboard lists players with at least minGames matches; minGames <= 0 uses the service default
+	GetLeaderboard(ctx context.Context, gameID string, minGames int, sortBy domain.LeaderboardSort, page int, pageSize int) ([]domain.LeaderboardEntry, int, error)
+	MinGames() int
+	GetLeaderboards(ctx context.Context, gameIDs []string, limit int) (map[string][]domain.LeaderboardEntry, error)
+	GetHeadToHead(ctx context.Context, userA, userB uuid.UUID, gameID string, limit int) (*domain.HeadToHead, error)
+	// GetScoreHistory returns up to limit of a user's latest score changes between from and to, oldest first
+	GetScoreHistory(ctx context.Context, userID uuid.UUID, gameID string, from, to time.Time, limit int) (*domain.ScoreHistory, error)
+	// RecalculateUserScore rebuilds a user's stats in a game from recorded match outcomes
+	RecalculateUserScore(ctx context.Context, userID uuid.UUID, gameID string) (*domain.UserOverallStats, error)
+
+	ListSeasons(ctx context.Context) ([]domain.Season, error)
+	// StartSeason archives the current season's standings, resets live scores and opens a new season
+	StartSeason(ctx context.Context, name string) (*domain.Season, error)
+	// GetSeasonLeaderboard is GetLeaderboard for any season; the current one is the live leaderboard
+	GetSeasonLeaderboard(ctx context.Context, seasonID uuid.UUID, gameID string, minGames int, sortBy domain.LeaderboardSort, page int, pageSize int) ([]domain.LeaderboardEntry, int, error)
 }
 
 type rankingService struct {
 	repo              repository.RankingRepository
 	userServiceClient client.UserServiceClient // Added UserServiceClient
+	minGames          int                      // Matches a player needs before appearing on leaderboards
+	eloKFactor        int                      // Largest rating change one Elo-rated match can cause
 }
 
-// NewRankingService updated to accept UserServiceClient
-func NewRankingService(repo repository.RankingRepository, userServiceClient client.UserServiceClient) RankingService {
+// NewRankingService updated to accept UserServiceClient. Players with fewer than minGames
+// matches are left off leaderboards and reported as provisional. Elo-rated matches move
+// ratings by at most eloKFactor points; values below 1 use DefaultEloKFactor.
+func NewRankingService(repo repository.RankingRepository, userServiceClient client.UserServiceClient, minGames int, eloKFactor int) RankingService {
+	if minGames < 1 {
+		minGames = 1
+	}
+	if eloKFactor < 1 {
+		eloKFactor = domain.DefaultEloKFactor
+	}
 	return &rankingService{
 		repo:              repo,
 		userServiceClient: userServiceClient,
+		minGames:          minGames,
+		eloKFactor:        eloKFactor,
 	}
 }
 
+// MinGames returns the default number of matches a player needs to appear on leaderboards
+func (s *rankingService) MinGames() int {
+	return s.minGames
+}
+
 func (s *rankingService) ProcessMatchResults(ctx context.Context, event domain.MatchResultEvent) error {
 	log.Printf("Service: Processing match results for game '%s', tournament '%s', match '%s'",
 		event.GameID, event.TournamentID, event.MatchID)
@@ -43,6 +76,9 @@ func (s *rankingService) ProcessMatchResults(ctx context.Context, event domain.M
 	if len(event.Users) == 0 {
 		return fmt.Errorf("no user outcomes provided in match result event for match %s", event.MatchID)
 	}
+	if points := event.Points(); points.Win < 0 || points.Draw < 0 || points.Loss < 0 {
+		return fmt.Errorf("points configuration for match %s cannot be negative", event.MatchID)
+	}
 
 	// Begin transaction
 	tx, err := s.repo.DB().BeginTx(ctx, nil)
@@ -72,24 +108,82 @@ func (s *rankingService) ProcessMatchResults(ctx context.Context, event domain.M
 		// err will be set, causing rollback by defer
 		return fmt.Errorf("error checking if match event %s was processed: %w", event.MatchID, err)
 	}
+	if event.Type == domain.MatchEventReversal {
+		if !isProcessed {
+			log.Printf("Reversal for match %s ignored; no result was applied for it.", event.MatchID)
+			return nil
+		}
+		var skip bool
+		skip, err = s.reversePreviousOutcome(ctx, tx, event)
+		if err != nil || skip {
+			return err
+		}
+		// Forgetting the match lets the result reported after the reset be applied as a new one
+		err = s.repo.ForgetMatchEvent(ctx, tx, event.MatchID)
+		return err
+	}
 	if isProcessed {
-		log.Printf("Match event %s (tournament %s) already processed. Skipping.", event.MatchID, event.TournamentID)
-		err = nil // Ensure commit of empty transaction
-		return nil // Successfully skipped
+		if event.Type != domain.MatchEventCorrection {
+			log.Printf("Match event %s (tournament %s) already processed. Skipping.", event.MatchID, event.TournamentID)
+			err = nil // Ensure commit of empty transaction
+			return nil // Successfully skipped
+		}
+		var skip bool
+		skip, err = s.reversePreviousOutcome(ctx, tx, event)
+		if err != nil {
+			return err
+		}
+		if skip {
+			return nil
+		}
 	}
 
-	// 2. Process each user's outcome
+	// 2. Mark Event as Processed. The recorded outcomes reference the event, so it comes first;
+	// an error later rolls the marker back with everything else.
+	err = s.repo.MarkMatchEventAsProcessed(ctx, tx, event.MatchID, event.TournamentID, event.GameID, event.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to mark match event %s as processed: %w", event.MatchID, err)
+	}
+
+	// 3. Work out the Elo rating changes from the ratings before this match
+	var ratingChanges map[uuid.UUID]int
+	ratingChanges, err = s.eloChanges(ctx, tx, event)
+	if err != nil {
+		return err
+	}
+
+	// 4. Process each user's outcome
+	pointsConfig := event.Points()
 	var processingErrors []error
 	for _, userOutcome := range event.Users {
-		_, outcomeErr := s.repo.ProcessMatchOutcome(ctx, tx, userOutcome.UserID, event.GameID, event.TournamentID, userOutcome.Outcome)
+		_, outcomeErr := s.repo.ProcessMatchOutcome(ctx, tx, userOutcome.UserID, event.GameID, event.TournamentID, event.MatchID, userOutcome.Outcome, pointsConfig)
 		if outcomeErr != nil {
 			log.Printf("Error processing outcome for user %s in match %s (game '%s', tournament '%s'): %v. Outcome: %s",
 				userOutcome.UserID, event.MatchID, event.GameID, event.TournamentID, outcomeErr, userOutcome.Outcome)
 			processingErrors = append(processingErrors, outcomeErr)
-		} else {
-			log.Printf("Successfully processed outcome %s for user %s (game '%s', tournament '%s') within transaction",
-				userOutcome.Outcome, userOutcome.UserID, domain.ResolveGameID(event.GameID), event.TournamentID)
+			continue
+		}
+		ratingChange := ratingChanges[userOutcome.UserID]
+		if ratingChange != 0 {
+			if outcomeErr = s.repo.AdjustRating(ctx, tx, userOutcome.UserID, event.GameID, ratingChange); outcomeErr != nil {
+				processingErrors = append(processingErrors, outcomeErr)
+				continue
+			}
 		}
+		outcomeErr = s.repo.RecordMatchOutcome(ctx, tx, repository.AppliedOutcome{
+			MatchID:      event.MatchID,
+			UserID:       userOutcome.UserID,
+			GameID:       event.GameID,
+			Outcome:      userOutcome.Outcome,
+			Points:       pointsConfig.PointsFor(userOutcome.Outcome),
+			RatingChange: ratingChange,
+		})
+		if outcomeErr != nil {
+			processingErrors = append(processingErrors, outcomeErr)
+			continue
+		}
+		log.Printf("Successfully processed outcome %s for user %s (game '%s', tournament '%s') within transaction",
+			userOutcome.Outcome, userOutcome.UserID, domain.ResolveGameID(event.GameID), event.TournamentID)
 	}
 
 	if len(processingErrors) > 0 {
@@ -97,38 +191,146 @@ func (s *rankingService) ProcessMatchResults(ctx context.Context, event domain.M
 		return err // This will trigger rollback in defer
 	}
 
-	// 3. Mark Event as Processed
-	err = s.repo.MarkMatchEventAsProcessed(ctx, tx, event.MatchID, event.TournamentID, event.GameID)
+	// 5. Record each player's result against every opponent for head-to-head records
+	err = s.recordMatchHistory(ctx, tx, event)
 	if err != nil {
-		return fmt.Errorf("failed to mark match event %s as processed: %w", event.MatchID, err)
+		return err
 	}
 
 	return nil
 }
 
+// eloChanges returns each player's rating change for an Elo-rated event, or nil when the event
+// is not rated. Only two-player matches are rated; the changes add up to zero.
+func (s *rankingService) eloChanges(ctx context.Context, tx *sql.Tx, event domain.MatchResultEvent) (map[uuid.UUID]int, error) {
+	if !event.Elo {
+		return nil, nil
+	}
+	if len(event.Users) != 2 || event.Users[0].UserID == event.Users[1].UserID {
+		log.Printf("Elo rating skipped for match %s; it needs exactly two different users, got %d outcome(s)",
+			event.MatchID, len(event.Users))
+		return nil, nil
+	}
+	a, b := event.Users[0], event.Users[1]
+	ratingA, err := s.repo.GetRating(ctx, tx, a.UserID, event.GameID)
+	if err != nil {
+		return nil, err
+	}
+	ratingB, err := s.repo.GetRating(ctx, tx, b.UserID, event.GameID)
+	if err != nil {
+		return nil, err
+	}
+	change := domain.EloChange(ratingA, ratingB, domain.EloScore(a.Outcome), s.eloKFactor)
+	return map[uuid.UUID]int{a.UserID: change, b.UserID: -change}, nil
+}
+
+// reversePreviousOutcome undoes what earlier events applied for a match before a correction
+// is applied, or for a reversal. It reports skip when the correction is not newer than the last applied event
+// (e.g. a retried delivery) or when the earlier outcome was never recorded and cannot be reversed.
+func (s *rankingService) reversePreviousOutcome(ctx context.Context, tx *sql.Tx, event domain.MatchResultEvent) (bool, error) {
+	lastApplied, err := s.repo.GetProcessedEventTime(ctx, tx, event.MatchID)
+	if err != nil {
+		return false, err
+	}
+	if !event.Timestamp.After(lastApplied) {
+		log.Printf("Correction for match %s at %s is not newer than the last applied event (%s). Skipping.",
+			event.MatchID, event.Timestamp, lastApplied)
+		return true, nil
+	}
+
+	applied, err := s.repo.GetMatchOutcomes(ctx, tx, event.MatchID)
+	if err != nil {
+		return false, err
+	}
+	if len(applied) == 0 {
+		log.Printf("Warning: correction for match %s ignored; the original outcome was not recorded and cannot be reversed", event.MatchID)
+		return true, nil
+	}
+
+	for _, previous := range applied {
+		if err := s.repo.ReverseMatchOutcome(ctx, tx, previous); err != nil {
+			return false, err
+		}
+	}
+	if err := s.repo.ClearMatchHistory(ctx, tx, event.MatchID); err != nil {
+		return false, err
+	}
+	log.Printf("Reversed %d previously applied outcome(s) for match %s before applying correction", len(applied), event.MatchID)
+	return false, nil
+}
+
+// recordMatchHistory writes one history row per player and opponent of the match, in the
+// same transaction as the score updates.
+func (s *rankingService) recordMatchHistory(ctx context.Context, tx *sql.Tx, event domain.MatchResultEvent) error {
+	playedAt := event.Timestamp
+	if playedAt.IsZero() {
+		playedAt = time.Now()
+	}
+	for _, player := range event.Users {
+		for _, opponent := range event.Users {
+			if opponent.UserID == player.UserID {
+				continue
+			}
+			err := s.repo.RecordMatchHistory(ctx, tx, repository.MatchHistoryEntry{
+				MatchID:    event.MatchID,
+				UserID:     player.UserID,
+				OpponentID: opponent.UserID,
+				GameID:     event.GameID,
+				Outcome:    player.Outcome,
+				PlayedAt:   playedAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (s *rankingService) GetUserRanking(ctx context.Context, userID uuid.UUID, gameID string) (*domain.UserOverallStats, error) {
 	effectiveGameID := domain.ResolveGameID(gameID)
-	scoreData, err := s.repo.GetUserScoreData(ctx, userID, effectiveGameID)
+	scoreData, err := s.repo.GetUserScoreData(ctx, userID, effectiveGameID, s.minGames)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user score data for user %s, game %s: %w", userID, effectiveGameID, err)
 	}
 
-	var calculatedRank int
-	if scoreData.MatchesPlayed > 0 || scoreData.Score > 0 {
-		queryRank := `SELECT COUNT(*) + 1 FROM user_scores WHERE game_id = $1 AND score > $2`
-		dbErr := s.repo.DB().QueryRowContext(ctx, queryRank, effectiveGameID, scoreData.Score).Scan(&calculatedRank)
-		if dbErr != nil {
-			if dbErr == sql.ErrNoRows {
-				calculatedRank = 1
-			} else {
-				log.Printf("Service: Error calculating rank for user %s in game %s (score %d): %v", userID, effectiveGameID, scoreData.Score, dbErr)
-				calculatedRank = 0 // Indicate rank calculation issue or unranked
-			}
-		}
-	} else {
-		calculatedRank = 0
+	stats := s.buildUserStats(scoreData)
+	details := s.lookupUserDetails(ctx, []uuid.UUID{userID})
+	stats.Username = details[userID].Username
+	stats.DisplayName = details[userID].DisplayName
+	stats.SeasonID = s.currentSeasonID(ctx)
+	return stats, nil
+}
+
+// GetUserRankingsByGame returns the user's stats and rank for every game they have played
+func (s *rankingService) GetUserRankingsByGame(ctx context.Context, userID uuid.UUID) ([]domain.UserOverallStats, error) {
+	games, err := s.repo.ListUserGames(ctx, userID, s.minGames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list games for user %s: %w", userID, err)
 	}
 
+	stats := make([]domain.UserOverallStats, 0, len(games))
+	if len(games) == 0 {
+		return stats, nil
+	}
+	details := s.lookupUserDetails(ctx, []uuid.UUID{userID})
+	seasonID := s.currentSeasonID(ctx)
+	for i := range games {
+		gameStats := s.buildUserStats(&games[i])
+		gameStats.Username = details[userID].Username
+		gameStats.DisplayName = details[userID].DisplayName
+		gameStats.SeasonID = seasonID
+		stats = append(stats, *gameStats)
+	}
+	return stats, nil
+}
+
+// buildUserStats derives win rate and rank title from a user's score data in one game
+func (s *rankingService) buildUserStats(scoreData *repository.UserScoreData) *domain.UserOverallStats {
+	effectiveGameID := domain.ResolveGameID(scoreData.GameID)
+
+	calculatedRank := scoreData.Rank
+
 	winRate := 0.0
 	if scoreData.MatchesPlayed > 0 {
 		winRate = (float64(scoreData.MatchesWon) / float64(scoreData.MatchesPlayed))*100.0
@@ -165,22 +367,29 @@ func (s *rankingService) GetUserRanking(ctx context.Context, userID uuid.UUID, g
 		UserID:            scoreData.UserID,
 		GameID:            effectiveGameID,
 		Points:            scoreData.Score, // domain.UserOverallStats uses "Points", maps from scoreData.Score
+		Rating:            scoreData.Rating,
 		GlobalRank:        calculatedRank,
 		WinRate:           winRate,
 		TotalGamesPlayed:  scoreData.MatchesPlayed,
 		MatchesWon:        scoreData.MatchesWon,
 		MatchesDrawn:      scoreData.MatchesDrawn,
 		MatchesLost:       scoreData.MatchesLost,
+		CurrentStreak:     scoreData.CurrentStreak,
+		LongestStreak:     scoreData.LongestStreak,
 		TournamentsPlayed: scoreData.TournamentsPlayed,
 		UpdatedAt:         scoreData.UpdatedAt,
 		Level:             level,
 		RankTitle:         rankTitle,
+		Provisional:       scoreData.MatchesPlayed < s.minGames,
 	}
-	return stats, nil
+	return stats
 }
 
-func (s *rankingService) GetLeaderboard(ctx context.Context, gameID string, page int, pageSize int) ([]domain.LeaderboardEntry, int, error) {
+func (s *rankingService) GetLeaderboard(ctx context.Context, gameID string, minGames int, sortBy domain.LeaderboardSort, page int, pageSize int) ([]domain.LeaderboardEntry, int, error) {
 	log.Printf("Service: Getting leaderboard for game %s, page %d, pageSize %d", gameID, page, pageSize)
+	if minGames <= 0 {
+		minGames = s.minGames
+	}
 	if page < 1 {
 		page = 1
 	}
@@ -191,48 +400,133 @@ func (s *rankingService) GetLeaderboard(ctx context.Context, gameID string, page
 	}
 	offset := (page - 1) * pageSize
 
-	entries, totalPlayers, err := s.repo.GetLeaderboard(ctx, gameID, pageSize, offset)
+	entries, totalPlayers, err := s.repo.GetLeaderboard(ctx, gameID, minGames, sortBy, pageSize, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get leaderboard from repository: %w", err)
 	}
 
-	if s.userServiceClient != nil && len(entries) > 0 {
-		userIDs := make([]uuid.UUID, 0, len(entries))
-		for _, entry := range entries {
-			userIDs = append(userIDs, entry.UserID)
-		}
+	s.fillEntryNames(ctx, entries)
+	return entries, totalPlayers, nil
+}
 
-		sort.Slice(userIDs, func(i, j int) bool {
-			return userIDs[i].String() < userIDs[j].String()
-		})
+// fillEntryNames adds usernames and display names to leaderboard entries
+func (s *rankingService) fillEntryNames(ctx context.Context, entries []domain.LeaderboardEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	userIDs := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		userIDs = append(userIDs, entry.UserID)
+	}
+	details := s.lookupUserDetails(ctx, userIDs)
+	for i := range entries {
+		entries[i].UserName = details[entries[i].UserID].Username
+		entries[i].DisplayName = details[entries[i].UserID].DisplayName
+	}
+}
 
-		userDetailsMap, err := s.userServiceClient.GetMultipleUserDetails(ctx, userIDs)
+// GetLeaderboards returns the top limit entries of each game, keyed by game ID. Names for the
+// players of every game are resolved with a single User Service call.
+func (s *rankingService) GetLeaderboards(ctx context.Context, gameIDs []string, limit int) (map[string][]domain.LeaderboardEntry, error) {
+	if limit < 1 {
+		limit = 5
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	leaderboards := make(map[string][]domain.LeaderboardEntry, len(gameIDs))
+	var userIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, gameID := range gameIDs {
+		gameID = domain.ResolveGameID(gameID)
+		if _, done := leaderboards[gameID]; done {
+			continue
+		}
+		entries, _, err := s.repo.GetLeaderboard(ctx, gameID, s.minGames, domain.SortByPoints, limit, 0)
 		if err != nil {
-			log.Printf("Warning: Failed to get multiple user details for leaderboard: %v. Usernames will be missing/default.", err)
-			for i := range entries {
-				if entries[i].UserName == "" {
-					entries[i].UserName = "Player"
-				}
+			return nil, fmt.Errorf("failed to get leaderboard for game %s: %w", gameID, err)
+		}
+		if entries == nil {
+			entries = []domain.LeaderboardEntry{}
+		}
+		leaderboards[gameID] = entries
+		for _, entry := range entries {
+			if !seen[entry.UserID] {
+				seen[entry.UserID] = true
+				userIDs = append(userIDs, entry.UserID)
 			}
-		} else {
+		}
+	}
+
+	if len(userIDs) > 0 {
+		details := s.lookupUserDetails(ctx, userIDs)
+		for _, entries := range leaderboards {
 			for i := range entries {
-				if details, ok := userDetailsMap[entries[i].UserID]; ok {
-					entries[i].UserName = details.Username
-				} else {
-					log.Printf("Warning: User details not found for UserID %s in batch response.", entries[i].UserID)
-					if entries[i].UserName == "" {
-						entries[i].UserName = "Player"
-					}
-				}
+				entries[i].UserName = details[entries[i].UserID].Username
+				entries[i].DisplayName = details[entries[i].UserID].DisplayName
 			}
 		}
-	} else if len(entries) > 0 {
-		for i := range entries {
-			if entries[i].UserName == "" {
-				entries[i].UserName = "Player"
-			}
+	}
+	return leaderboards, nil
+}
+
+// lookupUserDetails fetches usernames and display names from the User Service.
+// Every requested user gets an entry; the username falls back to "Player" when the lookup fails.
+func (s *rankingService) lookupUserDetails(ctx context.Context, userIDs []uuid.UUID) map[uuid.UUID]client.UserDetails {
+	result := make(map[uuid.UUID]client.UserDetails, len(userIDs))
+	for _, id := range userIDs {
+		result[id] = client.UserDetails{ID: id, Username: "Player"}
+	}
+	if s.userServiceClient == nil || len(userIDs) == 0 {
+		return result
+	}
+
+	sorted := make([]uuid.UUID, len(userIDs))
+	copy(sorted, userIDs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].String() < sorted[j].String()
+	})
+
+	userDetailsMap, err := s.userServiceClient.GetMultipleUserDetails(ctx, sorted)
+	if err != nil {
+		log.Printf("Warning: Failed to get user details for %d user(s): %v. Using default names.", len(userIDs), err)
+		return result
+	}
+	for _, id := range userIDs {
+		details, ok := userDetailsMap[id]
+		if !ok {
+			log.Printf("Warning: User details not found for UserID %s in batch response.", id)
+			continue
+		}
+		if details.Username == "" {
+			details.Username = "Player"
 		}
+		result[id] = details
 	}
+	return result
+}
 
-	return entries, totalPlayers, nil
-}
\ No newline at end of file
+// GetHeadToHead returns userA's record against userB in a game with their last limit meetings
+func (s *rankingService) GetHeadToHead(ctx context.Context, userA, userB uuid.UUID, gameID string, limit int) (*domain.HeadToHead, error) {
+	if userA == userB {
+		return nil, fmt.Errorf("head-to-head needs two different users")
+	}
+	return s.repo.GetHeadToHead(ctx, userA, userB, gameID, limit)
+}
+
+// GetScoreHistory returns how a user's score in a game changed over time
+func (s *rankingService) GetScoreHistory(ctx context.Context, userID uuid.UUID, gameID string, from, to time.Time, limit int) (*domain.ScoreHistory, error) {
+	return s.repo.GetScoreHistory(ctx, userID, gameID, from, to, limit)
+}
+
+// RecalculateUserScore overwrites a user's score in a game with one rebuilt from their recorded
+// match outcomes, then returns the refreshed stats
+func (s *rankingService) RecalculateUserScore(ctx context.Context, userID uuid.UUID, gameID string) (*domain.UserOverallStats, error) {
+	rebuilt, err := s.repo.RecalculateUserScore(ctx, userID, gameID)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Recalculated score for user %s in game '%s': %d points over %d matches",
+		userID, rebuilt.GameID, rebuilt.Score, rebuilt.MatchesPlayed)
+	return s.GetUserRanking(ctx, userID, gameID)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/repository"
+)
+
+// ScoreDecayer periodically reduces the scores of players who have stopped playing, so an
+// inactive player does not hold their rank forever.
+type ScoreDecayer struct {
+	repo       repository.RankingRepository
+	percent    int           // Share of the score removed each time, 0 disables decay
+	inactivity time.Duration // How long without a result before a player's score decays
+	interval   time.Duration // How often decay runs, and the least time between two decays of the same score
+}
+
+// NewScoreDecayer creates a decayer that removes percent of an inactive player's score once per interval
+func NewScoreDecayer(repo repository.RankingRepository, percent int, inactivity, interval time.Duration) *ScoreDecayer {
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+	return &ScoreDecayer{repo: repo, percent: percent, inactivity: inactivity, interval: interval}
+}
+
+// Enabled reports whether decay is configured to remove anything
+func (d *ScoreDecayer) Enabled() bool {
+	return d.percent > 0
+}
+
+// Apply decays every eligible score once and returns how many were reduced. Scores decayed
+// within the last interval are skipped, so calling it again early changes nothing.
+func (d *ScoreDecayer) Apply(ctx context.Context) (int64, error) {
+	if !d.Enabled() {
+		return 0, nil
+	}
+	now := time.Now()
+	// A little slack so a run on the next tick is not skipped by scheduling jitter
+	decayedSince := now.Add(-d.interval + d.interval/20)
+	decayed, err := d.repo.ApplyScoreDecay(ctx, d.percent, now.Add(-d.inactivity), decayedSince, now)
+	if err != nil {
+		return 0, err
+	}
+	if decayed > 0 {
+		log.Printf("ScoreDecayer: reduced %d score(s) by %d%% after %s of inactivity", decayed, d.percent, d.inactivity)
+	}
+	return decayed, nil
+}
+
+// Run applies decay every interval until ctx is cancelled
+func (d *ScoreDecayer) Run(ctx context.Context) {
+	if !d.Enabled() {
+		return
+	}
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.Apply(ctx); err != nil {
+			log.Printf("ScoreDecayer: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
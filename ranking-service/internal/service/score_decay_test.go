@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScoreDecayerWindows(t *testing.T) {
+	repo := newFakeRepo()
+	decayer := NewScoreDecayer(repo, 10, 30*24*time.Hour, 24*time.Hour)
+
+	decayed, err := decayer.Apply(context.Background())
+	if err != nil || decayed != 1 {
+		t.Fatalf("Apply: %d, %v", decayed, err)
+	}
+	if len(repo.decayCalls) != 1 {
+		t.Fatalf("expected one decay, got %d", len(repo.decayCalls))
+	}
+	call := repo.decayCalls[0]
+	if call.percent != 10 {
+		t.Fatalf("expected 10%%, got %d", call.percent)
+	}
+	if inactive := call.now.Sub(call.inactiveSince); inactive != 30*24*time.Hour {
+		t.Fatalf("players should be inactive for 30 days, got %s", inactive)
+	}
+	// Scores decayed within the last interval are skipped, allowing for a little jitter
+	if since := call.now.Sub(call.decayedSince); since >= 24*time.Hour || since < 22*time.Hour {
+		t.Fatalf("expected just under a day since the last decay, got %s", since)
+	}
+}
+
+func TestScoreDecayerPercentBounds(t *testing.T) {
+	repo := newFakeRepo()
+
+	if decayer := NewScoreDecayer(repo, 0, time.Hour, time.Hour); decayer.Enabled() {
+		t.Fatal("0% decay should be disabled")
+	}
+	if decayed, err := NewScoreDecayer(repo, -5, time.Hour, time.Hour).Apply(context.Background()); err != nil || decayed != 0 {
+		t.Fatalf("disabled decay should do nothing, got %d, %v", decayed, err)
+	}
+	if len(repo.decayCalls) != 0 {
+		t.Fatal("disabled decay must not touch the scores")
+	}
+
+	if _, err := NewScoreDecayer(repo, 250, time.Hour, time.Hour).Apply(context.Background()); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if repo.decayCalls[0].percent != 100 {
+		t.Fatalf("decay should be capped at 100%%, got %d", repo.decayCalls[0].percent)
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestScoreHistoryFollowsProcessedMatches(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _ := newTestService(1)
+	ace, bo := uuid.New(), uuid.New()
+	matches := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for i, winner := range []uuid.UUID{ace, bo, ace} {
+		loser := bo
+		if winner == bo {
+			loser = ace
+		}
+		if err := svc.ProcessMatchResults(ctx, resultEvent(matches[i], winner, loser)); err != nil {
+			t.Fatalf("match %d: ProcessMatchResults: %v", i+1, err)
+		}
+	}
+
+	history, err := svc.GetScoreHistory(ctx, ace, "Chess", time.Time{}, time.Time{}, 500)
+	if err != nil {
+		t.Fatalf("GetScoreHistory: %v", err)
+	}
+	want := []domain.ScoreChange{{Delta: 3, ScoreAfter: 3}, {Delta: 0, ScoreAfter: 3}, {Delta: 3, ScoreAfter: 6}}
+	if history.UserID != ace || history.GameID != "chess" || len(history.Changes) != len(want) {
+		t.Fatalf("expected %d changes in chess, got %+v", len(want), history)
+	}
+	for i, change := range history.Changes {
+		if change.Delta != want[i].Delta || change.ScoreAfter != want[i].ScoreAfter || change.MatchID == nil || *change.MatchID != matches[i] {
+			t.Errorf("match %d: expected %+d to %d, got %+v", i+1, want[i].Delta, want[i].ScoreAfter, change)
+		}
+	}
+}
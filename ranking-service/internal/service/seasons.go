@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// currentSeasonID returns the open season's ID, or nil if it cannot be read
+func (s *rankingService) currentSeasonID(ctx context.Context) *uuid.UUID {
+	season, err := s.repo.GetCurrentSeason(ctx)
+	if err != nil {
+		log.Printf("Warning: could not get the current season: %v", err)
+		return nil
+	}
+	return &season.ID
+}
+
+// ListSeasons returns every season, newest first
+func (s *rankingService) ListSeasons(ctx context.Context) ([]domain.Season, error) {
+	return s.repo.ListSeasons(ctx)
+}
+
+// StartSeason ends the current season and opens one called name. The ended season's standings
+// stay available from its leaderboard; live scores start again from zero.
+func (s *rankingService) StartSeason(ctx context.Context, name string) (*domain.Season, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("season name cannot be blank")
+	}
+	season, err := s.repo.StartSeason(ctx, name, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Started ranking season %q (%s); previous standings archived and live scores reset", season.Name, season.ID)
+	return season, nil
+}
+
+// GetSeasonLeaderboard lists a season's leaderboard. Ended seasons are read from their archive
+// and the current season from live scores.
+func (s *rankingService) GetSeasonLeaderboard(ctx context.Context, seasonID uuid.UUID, gameID string, minGames int, sortBy domain.LeaderboardSort, page int, pageSize int) ([]domain.LeaderboardEntry, int, error) {
+	season, err := s.repo.GetSeason(ctx, seasonID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if season.EndedAt == nil {
+		return s.GetLeaderboard(ctx, gameID, minGames, sortBy, page, pageSize)
+	}
+
+	if minGames <= 0 {
+		minGames = s.minGames
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+	entries, totalPlayers, err := s.repo.GetSeasonLeaderboard(ctx, seasonID, gameID, minGames, sortBy, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get leaderboard of season %s: %w", seasonID, err)
+	}
+	s.fillEntryNames(ctx, entries)
+	return entries, totalPlayers, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/cliffdoyle/ranking-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+func TestStartSeasonArchivesStandingsAndResetsLiveScores(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(1)
+	first := repo.current
+	winner, loser := uuid.New(), uuid.New()
+	if err := svc.ProcessMatchResults(ctx, resultEvent(uuid.New(), winner, loser)); err != nil {
+		t.Fatalf("ProcessMatchResults: %v", err)
+	}
+	repo.leaderboards["chess"] = []domain.LeaderboardEntry{{Rank: 1, UserID: winner, Score: 3}, {Rank: 2, UserID: loser}}
+
+	second, err := svc.StartSeason(ctx, "  Season 2 ")
+	if err != nil {
+		t.Fatalf("StartSeason: %v", err)
+	}
+	if second.Name != "Season 2" || second.ID == first.ID || first.EndedAt == nil {
+		t.Fatalf("expected a trimmed new season after the first one ended, got %+v and %+v", second, first)
+	}
+	if repo.score(winner, "chess") != nil {
+		t.Fatal("live scores should start again from zero")
+	}
+
+	// The ended season is read from its archive and the new one from live scores
+	archived, total, err := svc.GetSeasonLeaderboard(ctx, first.ID, "chess", 0, domain.SortByPoints, 1, 20)
+	if err != nil {
+		t.Fatalf("GetSeasonLeaderboard of the ended season: %v", err)
+	}
+	if total != 2 || len(archived) != 2 || archived[0].UserID != winner || archived[0].Score != 3 {
+		t.Fatalf("expected the final standings of the ended season, got %+v (total %d)", archived, total)
+	}
+	live, total, err := svc.GetSeasonLeaderboard(ctx, second.ID, "chess", 0, domain.SortByPoints, 1, 20)
+	if err != nil {
+		t.Fatalf("GetSeasonLeaderboard of the current season: %v", err)
+	}
+	if total != 0 || len(live) != 0 || len(repo.leaderboardCalls) != 1 {
+		t.Fatalf("the current season should be the empty live leaderboard, got %+v (total %d)", live, total)
+	}
+
+	// A player's stats now belong to the new season
+	if err := svc.ProcessMatchResults(ctx, resultEvent(uuid.New(), winner, loser)); err != nil {
+		t.Fatalf("ProcessMatchResults: %v", err)
+	}
+	stats, err := svc.GetUserRanking(ctx, winner, "chess")
+	if err != nil {
+		t.Fatalf("GetUserRanking: %v", err)
+	}
+	if stats.SeasonID == nil || *stats.SeasonID != second.ID || stats.Points != 3 {
+		t.Fatalf("expected 3 points in the new season, got %+v", stats)
+	}
+}
+
+func TestStartSeasonNeedsAName(t *testing.T) {
+	svc, repo, _ := newTestService(1)
+	current := repo.current
+
+	if _, err := svc.StartSeason(context.Background(), "   "); err == nil {
+		t.Fatal("expected a blank name to be rejected")
+	}
+	if repo.current != current || current.EndedAt != nil {
+		t.Fatal("a rejected season ended the current one")
+	}
+}
+
+func TestGetSeasonLeaderboardOfAnUnknownSeason(t *testing.T) {
+	svc, _, _ := newTestService(1)
+
+	if _, _, err := svc.GetSeasonLeaderboard(context.Background(), uuid.New(), "", 0, domain.SortByPoints, 1, 20); !errors.Is(err, repository.ErrSeasonNotFound) {
+		t.Fatalf("expected ErrSeasonNotFound, got %v", err)
+	}
+}
+
+func TestUserStatsWithoutASeasonStillLoad(t *testing.T) {
+	svc, repo, _ := newTestService(1)
+	userID := uuid.New()
+	repo.scores[scoreKey{userID, "chess"}] = &repository.UserScoreData{UserID: userID, GameID: "chess", MatchesPlayed: 1}
+	// 009_seasons.sql not applied
+	repo.current = nil
+
+	stats, err := svc.GetUserRanking(context.Background(), userID, "chess")
+	if err != nil {
+		t.Fatalf("GetUserRanking: %v", err)
+	}
+	if stats.SeasonID != nil {
+		t.Fatalf("expected no season, got %v", stats.SeasonID)
+	}
+}
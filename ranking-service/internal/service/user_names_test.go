@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/client"
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestLeaderboardEntriesCarryUserNames(t *testing.T) {
+	svc, repo, users := newTestService(1)
+	known, nameless, missing := uuid.New(), uuid.New(), uuid.New()
+	users.details[known] = client.UserDetails{ID: known, Username: "ace", DisplayName: "Ace"}
+	users.details[nameless] = client.UserDetails{ID: nameless}
+	repo.leaderboards["chess"] = []domain.LeaderboardEntry{
+		{Rank: 1, UserID: known, Score: 30},
+		{Rank: 2, UserID: nameless, Score: 20},
+		{Rank: 3, UserID: missing, Score: 10},
+	}
+
+	entries, total, err := svc.GetLeaderboard(context.Background(), "chess", 0, domain.SortByPoints, 1, 20)
+	if err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+	if total != 3 || len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d of %d", len(entries), total)
+	}
+	want := []struct{ userName, displayName string }{{"ace", "Ace"}, {"Player", ""}, {"Player", ""}}
+	for i, w := range want {
+		if entries[i].UserName != w.userName || entries[i].DisplayName != w.displayName {
+			t.Errorf("entry %d: want %q/%q, got %q/%q", i, w.userName, w.displayName, entries[i].UserName, entries[i].DisplayName)
+		}
+	}
+	if len(users.calls) != 1 || len(users.calls[0]) != 3 {
+		t.Fatalf("expected one batched lookup for all 3 players, got %v", users.calls)
+	}
+}
+
+func TestUserRankingFallsBackWhenUserServiceFails(t *testing.T) {
+	svc, _, users := newTestService(1)
+	users.err = errors.New("user service unavailable")
+	userID := uuid.New()
+
+	stats, err := svc.GetUserRanking(context.Background(), userID, "chess")
+	if err != nil {
+		t.Fatalf("a failed name lookup must not fail the ranking: %v", err)
+	}
+	if stats.Username != "Player" || stats.DisplayName != "" {
+		t.Fatalf("expected the default name, got %q/%q", stats.Username, stats.DisplayName)
+	}
+}
+
+func TestUserRankingCarriesUserNames(t *testing.T) {
+	svc, _, users := newTestService(1)
+	userID := uuid.New()
+	users.details[userID] = client.UserDetails{ID: userID, Username: "ace", DisplayName: "Ace"}
+
+	stats, err := svc.GetUserRanking(context.Background(), userID, "")
+	if err != nil {
+		t.Fatalf("GetUserRanking: %v", err)
+	}
+	if stats.Username != "ace" || stats.DisplayName != "Ace" || stats.GameID != "global" {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
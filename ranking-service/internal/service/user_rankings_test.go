@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cliffdoyle/ranking-service/internal/client"
+	"github.com/cliffdoyle/ranking-service/internal/domain"
+	"github.com/cliffdoyle/ranking-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+func TestGetUserRankingsByGameListsEveryGame(t *testing.T) {
+	svc, repo, users := newTestService(1)
+	userID := uuid.New()
+	users.details[userID] = client.UserDetails{ID: userID, Username: "ace", DisplayName: "Ace"}
+	repo.scores[scoreKey{userID, "chess"}] = &repository.UserScoreData{
+		UserID: userID, GameID: "chess", Score: 120, MatchesPlayed: 4, MatchesWon: 3, MatchesLost: 1, Rank: 2,
+	}
+	repo.scores[scoreKey{userID, "valorant"}] = &repository.UserScoreData{
+		UserID: userID, GameID: "valorant", Score: 0, MatchesPlayed: 2, MatchesLost: 2, Rank: 9,
+	}
+	// Another player's score must not show up
+	repo.scores[scoreKey{uuid.New(), "chess"}] = &repository.UserScoreData{GameID: "chess", Score: 500}
+
+	stats, err := svc.GetUserRankingsByGame(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("GetUserRankingsByGame: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected one entry per game played, got %d", len(stats))
+	}
+
+	chess, valorant := stats[0], stats[1]
+	if chess.GameID != "chess" || chess.GlobalRank != 2 || chess.Points != 120 || chess.RankTitle != "Gold" || chess.WinRate != 75 {
+		t.Errorf("unexpected chess stats: %+v", chess)
+	}
+	if valorant.GameID != "valorant" || valorant.GlobalRank != 9 || valorant.RankTitle != "Participant" {
+		t.Errorf("unexpected valorant stats: %+v", valorant)
+	}
+	for _, s := range stats {
+		if s.Username != "ace" || s.DisplayName != "Ace" || s.SeasonID == nil || *s.SeasonID != repo.current.ID {
+			t.Errorf("%s: expected the user's names and the current season, got %+v", s.GameID, s)
+		}
+	}
+	if len(users.calls) != 1 {
+		t.Fatalf("expected a single User Service lookup, got %d", len(users.calls))
+	}
+}
+
+func TestGetUserRankingsByGameWithoutScores(t *testing.T) {
+	svc, _, users := newTestService(1)
+
+	stats, err := svc.GetUserRankingsByGame(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("GetUserRankingsByGame: %v", err)
+	}
+	if stats == nil || len(stats) != 0 {
+		t.Fatalf("expected an empty, non-nil list, got %#v", stats)
+	}
+	if len(users.calls) != 0 {
+		t.Fatal("no names should be looked up for a user without scores")
+	}
+}
+
+func TestUserRankingCarriesStreaks(t *testing.T) {
+	svc, repo, _ := newTestService(1)
+	userID := uuid.New()
+	key := scoreKey{userID, domain.ResolveGameID("chess")}
+	repo.scores[key] = &repository.UserScoreData{UserID: userID, GameID: key.gameID, MatchesPlayed: 6, CurrentStreak: 2, LongestStreak: 4}
+
+	stats, err := svc.GetUserRanking(context.Background(), userID, "chess")
+	if err != nil {
+		t.Fatalf("GetUserRanking: %v", err)
+	}
+	if stats.CurrentStreak != 2 || stats.LongestStreak != 4 {
+		t.Fatalf("expected streak 2 (best 4), got %d (best %d)", stats.CurrentStreak, stats.LongestStreak)
+	}
+}
@@ -224,7 +224,7 @@ export default function CreateTournamentPage() {
                 value={formData.prizePool}
                 onChange={handleChange}
                 className="mt-1 block w-full rounded-md border-gray-300 dark:border-slate-600 shadow-sm focus:border-blue-500 focus:ring-blue-500 sm:text-sm text-gray-900 dark:text-slate-100 bg-gray-50 dark:bg-slate-700 placeholder-gray-400 dark:placeholder-slate-500"
-                placeholder='E.g., "$500 + Merch", "Bragging Rights", or JSON: {"currency":"USD", "amount":1000}'
+                placeholder='E.g., "$500 + Merch", "Bragging Rights", or JSON: {"currency":"USD", "amount":1000, "placements":{"1":60, "2":30, "3":10}}'
               />
                <p className="mt-1 text-xs text-gray-500 dark:text-slate-400">
                 Enter a description (e.g., "$1000 total prize pool") or simple structured JSON.
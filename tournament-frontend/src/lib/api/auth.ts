@@ -71,7 +71,7 @@ export const authApi = {
     return response.json();
   },
   
-  updateProfile: async (token: string, profileData: Partial<User>): Promise<{ message: string }> => {
+  updateProfile: async (token: string, profileData: Partial<User>): Promise<{ message: string; token?: string }> => {
     const response = await fetch(`${API_CONFIG.AUTH_URL}/user/profile`, {
       method: 'PUT',
       headers: {
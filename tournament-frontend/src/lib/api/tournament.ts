@@ -276,7 +276,8 @@ export const tournamentApi = {
       }
 
       console.log('Updated match data received from updateMatch:', responseData);
-      return responseData;
+      // The backend returns { match, updated_match_ids }; callers only need the match itself
+      return responseData.match;
     } catch (error) {
       console.error('Error in updateMatch:', error);
       throw error;
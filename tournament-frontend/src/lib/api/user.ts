@@ -6,14 +6,21 @@ import { User } from '@/types/auth'; // Assuming your main User type from auth.t
 export interface UserForLinkingResponse {
   id: string; // UUID string
   username: string;
-  // displayName?: string;
+  display_name?: string;
 }
 
 interface ListUsersResponse { // Matches the structure returned by the new Go handler
     users: UserForLinkingResponse[];
-    // page?: number;
-    // pageSize?: number;
-    // total?: number;
+    page: number;
+    pageSize: number;
+    total: number;
+}
+
+// Optional search and paging for /user/list-for-linking
+export interface ListUsersParams {
+  q?: string;
+  page?: number;
+  pageSize?: number;
 }
 
 
@@ -22,9 +29,14 @@ export const userApi = {
    * Fetches a list of users for linking to tournament participants.
    * Requires authentication token.
    */
-  listUsersForLinking: async (token: string): Promise<ListUsersResponse> => {
+  listUsersForLinking: async (token: string, params: ListUsersParams = {}): Promise<ListUsersResponse> => {
     try {
-      const response = await fetch(`${API_CONFIG.AUTH_URL}${API_CONFIG.ENDPOINTS.USERS_FOR_LINKING}`, {
+      const query = new URLSearchParams();
+      if (params.q) query.set('q', params.q);
+      if (params.page) query.set('page', String(params.page));
+      if (params.pageSize) query.set('pageSize', String(params.pageSize));
+      const queryString = query.toString() ? `?${query.toString()}` : '';
+      const response = await fetch(`${API_CONFIG.AUTH_URL}${API_CONFIG.ENDPOINTS.USERS_FOR_LINKING}${queryString}`, {
         method: 'GET',
         headers: {
           'Content-Type': 'application/json',
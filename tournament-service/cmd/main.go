@@ -1,22 +1,23 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"strconv" // Added for parsing pagination query parameters
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/cliffdoyle/tournament-service/internal/client"
 	"github.com/cliffdoyle/tournament-service/internal/domain"
 	"github.com/cliffdoyle/tournament-service/internal/handlers"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
 	"github.com/cliffdoyle/tournament-service/internal/middleware"
 	"github.com/cliffdoyle/tournament-service/internal/repository"
 	"github.com/cliffdoyle/tournament-service/internal/service"
@@ -29,11 +30,24 @@ import (
 	_ "github.com/lib/pq"
 )
 
+// Page sizes for GET /tournaments/:tournamentId/matches when ?page= or ?pageSize= is given
+const (
+	defaultMatchPageSize = 50
+	maxMatchPageSize     = 200
+)
+
+// Page sizes for GET /rankings/admin/failed-events
+const (
+	defaultOutboxPageSize = 50
+	maxOutboxPageSize     = 500
+)
+
 func main() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		log.Printf("Warning: .env file not found")
 	}
+	logging.Setup(logging.ParseLevel(getEnvOrDefault("LOG_LEVEL", "info")))
 
 	// Database connection
 	dbHost := getEnvOrDefault("DB_HOST", "localhost")
@@ -43,8 +57,11 @@ func main() {
 	dbName := getEnvOrDefault("DB_NAME", "tournament_db")
 	serverPort := getEnvOrDefault("SERVER_PORT", "8082")
 
-	dbConnStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=require",
-		dbHost, dbPort, dbUser, dbPass, dbName)
+	// Postgres cancels any statement running longer than DB_STATEMENT_TIMEOUT (default 5s)
+	dbStatementTimeout := getDurationEnvOrDefault("DB_STATEMENT_TIMEOUT", 5*time.Second)
+
+	dbConnStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=require statement_timeout=%d",
+		dbHost, dbPort, dbUser, dbPass, dbName, dbStatementTimeout.Milliseconds())
 
 	db, err := sql.Open("postgres", dbConnStr)
 	if err != nil {
@@ -58,7 +75,7 @@ func main() {
 	log.Println("Successfully connected to database")
 
 	//---Initialize WebSocket Hub---
-	wsHub:=websocket.NewHub()
+	wsHub := websocket.NewHub()
 	go wsHub.Run()
 
 	// --- Pass Hub's Broadcast channel to services that need to send messages ---
@@ -73,39 +90,69 @@ func main() {
 	// You will need to modify your NewUserActivityService and NewTournamentService signatures
 	// and the structs themselves to hold this `chan domain.WebSocketMessage`
 
-
 	// Initialize router
-	router := gin.Default()
+	// gin.New instead of gin.Default: requests are logged as JSON by RequestLogger
+	router := gin.New()
+	router.Use(gin.Recovery(), middleware.RequestLogger())
 
 	// Add CORS middleware
 	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{"http://localhost:3000"} // Adjust as per your frontend URL
+	origins, err := parseAllowedOrigins(os.Getenv("CORS_ALLOWED_ORIGINS"), []string{"http://localhost:3000"})
+	if err != nil {
+		log.Fatalf("Invalid CORS_ALLOWED_ORIGINS: %v", err)
+	}
 	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
 	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"}
-	config.AllowCredentials = true
-	config.ExposeHeaders = []string{"Content-Length"}
+	if origins[0] == "*" {
+		// Browsers refuse credentialed responses to a wildcard origin
+		config.AllowAllOrigins = true
+		config.AllowCredentials = false
+	} else {
+		config.AllowOrigins = origins
+		config.AllowCredentials = true
+	}
+	log.Printf("CORS allowed origins: %s", strings.Join(origins, ", "))
+	config.ExposeHeaders = []string{"Content-Length", middleware.RequestIDHeader}
 	config.MaxAge = 86400 // 24 hours
 	router.Use(cors.New(config))
 
+	// Calls to the user and ranking services give up after UPSTREAM_TIMEOUT
+	upstreamTimeout := getDurationEnvOrDefault("UPSTREAM_TIMEOUT", client.DefaultTimeout)
+
 	// Initialize services
-	userService := client.NewUserService()
+	userService := client.NewUserService(
+		upstreamTimeout,
+		getDurationEnvOrDefault("USER_TOKEN_CACHE_TTL", 60*time.Second),
+		getIntEnvOrDefault("USER_TOKEN_CACHE_SIZE", 1000),
+	)
 	tournamentRepo := repository.NewTournamentRepository(db)
 	participantRepo := repository.NewParticipantRepository(db)
 	matchRepo := repository.NewMatchRepository(db)
 	messageRepo := repository.NewMessageRepository(db)
+	rankingOutboxRepo := repository.NewRankingOutboxRepository(db)
 	bracketGen := bracket.NewSingleEliminationGenerator()
 
 	//Inititialize UserActivity components
-	activityRepo:=repository.NewUserActivityRepository(db)
-	userActivityService:=service.NewUserActivityService(activityRepo,tournamentRepo,wsHub.Broadcast) // Pass the WebSocket broadcast channel
+	activityRepo := repository.NewUserActivityRepository(db)
+	userActivityService := service.NewUserActivityService(activityRepo, tournamentRepo, wsHub.Broadcast) // Pass the WebSocket broadcast channel
 	// userActivityHandler := handlers.NewUserActivityHandler(userActivityService) // Instantiate the handler
 
-
 	// Initialize UserActivity repository and service
 	// activityRepo := repository.NewUserActivityRepository(db)
 	// // UserActivityService constructor requires tournamentRepo to enrich activity descriptions if needed
 	// userActivityService := service.NewUserActivityService(activityRepo, tournamentRepo)
 
+	// Each user may have TOURNAMENT_LIMIT_PER_USER non-archived tournaments, except those listed in
+	// TOURNAMENT_LIMIT_OVERRIDES with their own limit
+	quotaOverrides, err := parseQuotaOverrides(os.Getenv("TOURNAMENT_LIMIT_OVERRIDES"))
+	if err != nil {
+		log.Fatalf("Invalid TOURNAMENT_LIMIT_OVERRIDES: %v", err)
+	}
+	creationQuota := service.CreationQuota{
+		Limit:     getIntEnvOrDefault("TOURNAMENT_LIMIT_PER_USER", 50),
+		Overrides: quotaOverrides,
+	}
+
 	// Initialize TournamentService
 	// NOTE: The provided tournamentService.go's NewTournamentService constructor signature
 	// does not include userActivityService. The line `userActivityService, // Pass UserActivityService to TournamentService`
@@ -120,16 +167,53 @@ func main() {
 		matchRepo,
 		messageRepo,
 		bracketGen,
-		 userActivityService, // Removed to match the NewTournamentService signature in your provided service.go
-		 wsHub.Broadcast,
+		userActivityService, // Removed to match the NewTournamentService signature in your provided service.go
+		wsHub.Broadcast,
+		userService,
+		client.NewRankingService(upstreamTimeout),
+		creationQuota,
 	)
 
+	// Stale match detection: flag playable matches with no result after STALE_MATCH_TIMEOUT,
+	// optionally auto-forfeiting them in favour of the better seed
+	staleMatchTimeout := getDurationEnvOrDefault("STALE_MATCH_TIMEOUT", 2*time.Hour)
+	staleMatchInterval := getDurationEnvOrDefault("STALE_MATCH_CHECK_INTERVAL", 5*time.Minute)
+	staleMatchAutoForfeit := getEnvOrDefault("STALE_MATCH_AUTO_FORFEIT", "false") == "true"
+	monitorCtx, stopMonitor := context.WithCancel(context.Background())
+	defer stopMonitor()
+	go service.NewStaleMatchMonitor(tournamentService, staleMatchTimeout, staleMatchInterval, staleMatchAutoForfeit).Run(monitorCtx)
+
+	// Ranking notifications are queued in the ranking outbox and delivered in the background,
+	// with failed deliveries retried after RANKING_OUTBOX_RETRY_BASE, doubling up to RANKING_OUTBOX_RETRY_MAX
+	rankingOutboxWorker := service.NewRankingOutboxWorker(
+		rankingOutboxRepo,
+		os.Getenv("RANKING_SERVICE_URL"),
+		os.Getenv("INTERNAL_SERVICE_KEY"),
+		upstreamTimeout,
+		getDurationEnvOrDefault("RANKING_OUTBOX_POLL_INTERVAL", 5*time.Second),
+		getDurationEnvOrDefault("RANKING_OUTBOX_RETRY_BASE", 10*time.Second),
+		getDurationEnvOrDefault("RANKING_OUTBOX_RETRY_MAX", 30*time.Minute),
+	)
+	go rankingOutboxWorker.Run(monitorCtx)
+
+	// Tournaments with custom_fields.auto_start are started at their start time, checked every TOURNAMENT_AUTO_START_INTERVAL
+	go service.NewTournamentAutoStarter(
+		tournamentService, getDurationEnvOrDefault("TOURNAMENT_AUTO_START_INTERVAL", time.Minute),
+	).Run(monitorCtx)
+
+	// Chat flood protection: each user may post CHAT_RATE_LIMIT_MESSAGES per CHAT_RATE_LIMIT_WINDOW in a tournament
+	chatRateLimit := middleware.ChatRateLimitMiddleware(middleware.NewRateLimiter(
+		getIntEnvOrDefault("CHAT_RATE_LIMIT_MESSAGES", 5),
+		getDurationEnvOrDefault("CHAT_RATE_LIMIT_WINDOW", 10*time.Second),
+	))
+
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
+	// Readiness checks that the database answers; /health stays a pure liveness check
+	router.GET("/ready", readyHandler(db))
 
-	
 	// --- Add WebSocket Route ---
 	// It can be public or protected by AuthMiddleware if you want to identify users on connection
 	// If protected, HandleWebSocketConnections needs to access c.Get("userID")
@@ -138,8 +222,10 @@ func main() {
 	})
 
 	// Public routes (existing ones)
-	router.GET("/tournaments", func(c *gin.Context) {
+	router.GET("/tournaments", middleware.OptionalAuthMiddleware(), func(c *gin.Context) {
 		filters := make(map[string]interface{}) // Simplified for brevity, you might parse filters from query
+		// Only public tournaments are listed, plus private ones the caller organizes or plays in
+		filters["visibleTo"] = viewerID(c)
 		pageQuery := c.DefaultQuery("page", "1")
 		pageSizeQuery := c.DefaultQuery("pageSize", "10")
 
@@ -151,10 +237,22 @@ func main() {
 		if pageSize < 1 {
 			pageSize = 10
 		}
+		// Deleted tournaments are archived and hidden unless ?includeArchived=true
+		if c.Query("includeArchived") == "true" {
+			filters["includeArchived"] = true
+		}
+		statuses, err := parseStatusFilter(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(statuses) > 0 {
+			filters["statuses"] = statuses
+		}
 
 		tournaments, total, err := tournamentService.ListTournaments(c.Request.Context(), filters, page, pageSize)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			handlers.RespondError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{
@@ -162,29 +260,90 @@ func main() {
 			"total":       total,
 			"page":        page,
 			"pageSize":    pageSize,
+			"pagination":  domain.NewPaginationMeta(total, page, pageSize),
 		})
 	})
 
-	router.GET("/tournaments/:tournamentId", func(c *gin.Context) {
+	// The games tournaments can be created for, with their canonical IDs
+	router.GET("/games", listGames)
+
+	// Several tournaments in one call: ?ids=uuid1,uuid2,... (at most service.MaxBatchTournamentIDs)
+	router.GET("/tournaments/batch", middleware.OptionalAuthMiddleware(), func(c *gin.Context) {
+		var ids []uuid.UUID
+		for _, raw := range strings.Split(c.Query("ids"), ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid tournament ID: %s", raw)})
+				return
+			}
+			ids = append(ids, id)
+		}
+		if len(ids) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ids query parameter is required"})
+			return
+		}
+
+		tournaments, notFound, err := tournamentService.GetTournamentsByIDs(c.Request.Context(), ids, viewerID(c))
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"tournaments": tournaments, "not_found": notFound})
+	})
+
+	// tournamentAccess runs after OptionalAuthMiddleware on the public per-tournament routes
+	// and stops them from serving private tournaments to callers who may not view them
+	tournamentAccess := func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("tournamentId"))
+		if err != nil {
+			return // the route reports the malformed ID itself
+		}
+		if _, err := tournamentService.ViewTournament(c.Request.Context(), id, viewerID(c)); err != nil {
+			handlers.RespondError(c, err)
+			c.Abort()
+		}
+	}
+
+	// Resolve an invite code to the tournament it joins; the code itself grants access
+	router.GET("/tournaments/code/:code", func(c *gin.Context) {
+		tournament, err := tournamentService.GetTournamentByInviteCode(c.Request.Context(), c.Param("code"))
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, tournament)
+	})
+
+	router.GET("/tournaments/:tournamentId", middleware.OptionalAuthMiddleware(), func(c *gin.Context) {
 		id, err := uuid.Parse(c.Param("tournamentId"))
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
 			return
 		}
 
-		tournament, err := tournamentService.GetTournament(c.Request.Context(), id)
+		viewer := viewerID(c)
+		tournament, err := tournamentService.ViewTournament(c.Request.Context(), id, viewer)
 		if err != nil {
-			if _, ok := err.(*service.ErrTournamentNotFound); ok {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found", "id": id.String()})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			handlers.RespondError(c, err)
 			return
 		}
+		if viewer != uuid.Nil {
+			unread, err := tournamentService.CountUnreadMessages(c.Request.Context(), id, viewer)
+			if err != nil {
+				// The tournament is still worth returning without the chat badge
+				logging.Warnf(c.Request.Context(), "Failed to count unread messages for T-%s: %v", id, err)
+			} else {
+				tournament.UnreadCount = &unread
+			}
+		}
 		c.JSON(http.StatusOK, tournament)
 	})
 
-	router.GET("/tournaments/:tournamentId/participants", func(c *gin.Context) {
+	router.GET("/tournaments/:tournamentId/participants", middleware.OptionalAuthMiddleware(), tournamentAccess, func(c *gin.Context) {
 		id, err := uuid.Parse(c.Param("tournamentId"))
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
@@ -192,16 +351,12 @@ func main() {
 		}
 		_, err = tournamentService.GetTournament(c.Request.Context(), id)
 		if err != nil {
-			if _, ok := err.(*service.ErrTournamentNotFound); ok {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			handlers.RespondError(c, err)
 			return
 		}
 		participants, err := tournamentService.GetParticipants(c.Request.Context(), id)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			handlers.RespondError(c, err)
 			return
 		}
 		if participants == nil {
@@ -210,7 +365,7 @@ func main() {
 		c.JSON(http.StatusOK, participants)
 	})
 
-	router.POST("/tournaments/:tournamentId/participants", func(c *gin.Context) {
+	router.POST("/tournaments/:tournamentId/participants", middleware.OptionalAuthMiddleware(), func(c *gin.Context) {
 		tournamentID, err := uuid.Parse(c.Param("tournamentId"))
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
@@ -219,36 +374,36 @@ func main() {
 
 		//Define expected request body
 		var req struct {
-			ParticipantName string `json:"participant_name" binding:"required"`
-			Seed            *int   `json:"seed,omitempty"`
-			UserID          *string `json:"user_id,omitempty"`          // Optional: UUID string of an existing platform user to link
+			ParticipantName string  `json:"participant_name" binding:"required"`
+			Seed            *int    `json:"seed,omitempty"`
+			UserID          *string `json:"user_id,omitempty"` // Optional: UUID string of an existing platform user to link
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
-			log.Printf("[AddParticipantHandler] Error binding JSON: %v. Request Body: %s", err, getRawBody(c))
+			logging.Debugf(c.Request.Context(), "[AddParticipantHandler] Error binding JSON: %v", err)
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload:" + err.Error()})
 			return
 		}
 
-		log.Printf("[AddParticipantHandler] Received request to add participant: Name='%s', UserID_from_req='%v', Seed=%v",
+		logging.Debugf(c.Request.Context(), "[AddParticipantHandler] Received request to add participant: Name='%s', UserID_from_req='%v', Seed=%v",
 			req.ParticipantName, req.UserID, req.Seed)
 
 		participantReq := &domain.ParticipantRequest{ParticipantName: req.ParticipantName, Seed: req.Seed}
 		if req.UserID != nil && *req.UserID != "" {
 			//If a user_id string is provided in the request payload
-			parsedUserUUID,uuidErr:= uuid.Parse(*req.UserID)
+			parsedUserUUID, uuidErr := uuid.Parse(*req.UserID)
 			if uuidErr != nil {
-				log.Printf("[AddParticipantHandler] Invalid UserID format provided ('%s'). Error: %v. Adding as guest.", *req.UserID, uuidErr)
+				logging.Warnf(c.Request.Context(), "[AddParticipantHandler] Invalid UserID format provided ('%s'). Error: %v. Adding as guest.", *req.UserID, uuidErr)
 				participantReq.UserID = nil // Reset to nil if invalid UUID
-		}else{
-			//Valid UUID string provided, link this participant entry to the system user
-			participantReq.UserID = &parsedUserUUID
-			log.Printf("[AddParticipantHandler] Linking participant '%s' to existing system UserID: %s", req.ParticipantName, parsedUserUUID.String())
-		}
-	}else{
-		// No UserID provided, treat as guest
-		log.Printf("[AddParticipantHandler] No UserID provided, treating participant '%s' as guest.", req.ParticipantName)
-		participantReq.UserID = nil
-	}
+			} else {
+				//Valid UUID string provided, link this participant entry to the system user
+				participantReq.UserID = &parsedUserUUID
+				logging.Debugf(c.Request.Context(), "[AddParticipantHandler] Linking participant '%s' to existing system UserID: %s", req.ParticipantName, parsedUserUUID.String())
+			}
+		} else {
+			// No UserID provided, treat as guest
+			logging.Debugf(c.Request.Context(), "[AddParticipantHandler] No UserID provided, treating participant '%s' as guest.", req.ParticipantName)
+			participantReq.UserID = nil
+		}
 		// token := c.GetHeader("Authorization")
 		// if token != "" && len(token) > 7 {
 		// 	token = token[7:]
@@ -258,36 +413,151 @@ func main() {
 		// 		participantReq.UserID = &userID//Bug overwriting user_id from request payload
 		// 	}
 		// }
-		participant, err := tournamentService.RegisterParticipant(c.Request.Context(), tournamentID, participantReq)
+		// Anonymous callers register as uuid.Nil; a signed-in organizer may register past the deadline
+		participant, err := tournamentService.RegisterParticipant(c.Request.Context(), tournamentID, viewerID(c), participantReq)
 		if err != nil {
-			log.Printf("[AddParticipantHandler] Error calling tournamentService.RegisterParticipant: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register participant"+err.Error()})
+			handlers.RespondError(c, err)
 			return
 		}
-		log.Printf("[AddParticipantHandler] Successfully registered participant: ID=%s, Name='%s', Linked_UserID=%v",
+		logging.Infof(c.Request.Context(), "[AddParticipantHandler] Successfully registered participant: ID=%s, Name='%s', Linked_UserID=%v",
 			participant.ID.String(), participant.ParticipantName, participant.UserID)
 		c.JSON(http.StatusCreated, participant)
 	})
 
-	router.GET("/tournaments/:tournamentId/matches", func(c *gin.Context) {
+	router.GET("/tournaments/:tournamentId/matches", middleware.OptionalAuthMiddleware(), tournamentAccess, func(c *gin.Context) {
 		id, err := uuid.Parse(c.Param("tournamentId"))
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
 			return
 		}
-		matches, err := tournamentService.GetMatches(c.Request.Context(), id)
-		if err != nil {
-			if _, ok := err.(*service.ErrTournamentNotFound); ok {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		// ?bracketType= keeps one bracket; ?page=/?pageSize= switch to a paginated envelope.
+		// Without them every match is returned as a plain list, as before.
+		var filter domain.MatchFilter
+		if raw := c.Query("bracketType"); raw != "" {
+			filter.BracketType = domain.BracketType(strings.ToUpper(raw))
+			if !domain.IsValidBracketType(filter.BracketType) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid bracket type %q", raw)})
 				return
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		paginated := c.Query("page") != "" || c.Query("pageSize") != ""
+		page, pageSize := 1, defaultMatchPageSize
+		if paginated {
+			page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+			pageSize, _ = strconv.Atoi(c.DefaultQuery("pageSize", strconv.Itoa(defaultMatchPageSize)))
+			if page < 1 {
+				page = 1
+			}
+			if pageSize < 1 {
+				pageSize = defaultMatchPageSize
+			}
+			if pageSize > maxMatchPageSize {
+				pageSize = maxMatchPageSize
+			}
+			filter.Limit = pageSize
+			filter.Offset = (page - 1) * pageSize
+		}
+
+		matches, total, err := tournamentService.ListMatches(c.Request.Context(), id, filter)
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
+		}
+		if !paginated {
+			c.JSON(http.StatusOK, matches)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"matches":    matches,
+			"total":      total,
+			"page":       page,
+			"pageSize":   pageSize,
+			"pagination": domain.NewPaginationMeta(total, page, pageSize),
+		})
+	})
+
+	router.GET("/tournaments/:tournamentId/matches/:matchId", middleware.OptionalAuthMiddleware(), tournamentAccess, getMatchHandler(tournamentService))
+
+	router.GET("/tournaments/:tournamentId/schedule", middleware.OptionalAuthMiddleware(), tournamentAccess, func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("tournamentId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+			return
+		}
+		schedule, err := tournamentService.GetSchedule(c.Request.Context(), id)
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, schedule)
+	})
+
+	router.GET("/tournaments/:tournamentId/bracket", middleware.OptionalAuthMiddleware(), tournamentAccess, func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("tournamentId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+			return
+		}
+		tree, err := tournamentService.GetBracketTree(c.Request.Context(), id)
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, tree)
+	})
+
+	router.GET("/tournaments/:tournamentId/standings", middleware.OptionalAuthMiddleware(), tournamentAccess, func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("tournamentId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+			return
+		}
+		// Points per result default to 3/1/0 and can be overridden with ?win=&draw=&loss=
+		points := domain.DefaultPointsConfig
+		for param, target := range map[string]*int{"win": &points.Win, "draw": &points.Draw, "loss": &points.Loss} {
+			if raw := c.Query(param); raw != "" {
+				value, convErr := strconv.Atoi(raw)
+				if convErr != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid %s points value", param)})
+					return
+				}
+				*target = value
+			}
+		}
+		standings, err := tournamentService.GetStandings(c.Request.Context(), id, points)
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, standings)
+	})
+
+	router.GET("/tournaments/:tournamentId/results", middleware.OptionalAuthMiddleware(), tournamentAccess, func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("tournamentId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+			return
+		}
+		results, err := tournamentService.ComputeResults(c.Request.Context(), id)
+		if err != nil {
+			handlers.RespondError(c, err)
 			return
 		}
-		if matches == nil {
-			matches = []*domain.MatchResponse{}
+		c.JSON(http.StatusOK, results)
+	})
+
+	router.GET("/tournaments/:tournamentId/prizes", middleware.OptionalAuthMiddleware(), tournamentAccess, func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("tournamentId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+			return
+		}
+		prizes, err := tournamentService.ComputePrizeDistribution(c.Request.Context(), id)
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
 		}
-		c.JSON(http.StatusOK, matches)
+		c.JSON(http.StatusOK, prizes)
 	})
 
 	router.PUT("/tournaments/:tournamentId/participants/:participantId", func(c *gin.Context) {
@@ -311,13 +581,13 @@ func main() {
 		updateReq := &domain.ParticipantRequest{ParticipantName: req.ParticipantName}
 		participant, err := tournamentService.UpdateParticipant(c.Request.Context(), tournamentID, participantID, updateReq)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			handlers.RespondError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, participant)
 	})
 
-	router.GET("/tournaments/:tournamentId/messages", func(c *gin.Context) {
+	router.GET("/tournaments/:tournamentId/messages", middleware.OptionalAuthMiddleware(), tournamentAccess, func(c *gin.Context) {
 		id, err := uuid.Parse(c.Param("tournamentId"))
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
@@ -327,11 +597,7 @@ func main() {
 		offset := 0 // Add query param parsing for these if needed
 		messages, err := tournamentService.GetMessages(c.Request.Context(), id, limit, offset)
 		if err != nil {
-			if _, ok := err.(*service.ErrTournamentNotFound); ok {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			handlers.RespondError(c, err)
 			return
 		}
 		if messages == nil {
@@ -340,6 +606,36 @@ func main() {
 		c.JSON(http.StatusOK, messages)
 	})
 
+	// Match results as CSV (default) or JSON, named after the tournament
+	router.GET("/tournaments/:tournamentId/export", middleware.OptionalAuthMiddleware(), tournamentAccess, func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("tournamentId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+			return
+		}
+		format := c.DefaultQuery("format", "csv")
+		if format != "csv" && format != "json" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or json"})
+			return
+		}
+		export, err := tournamentService.ExportMatchResults(c.Request.Context(), id)
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
+		}
+		filename := service.ExportFilename(export.TournamentName, id, format)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		if format == "json" {
+			c.JSON(http.StatusOK, export)
+			return
+		}
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := service.WriteMatchResultsCSV(c.Writer, export); err != nil {
+			log.Printf("Error writing results CSV for tournament %s: %v", id, err)
+		}
+	})
+
 	// Protected routes
 	protected := router.Group("")
 	protected.Use(middleware.AuthMiddleware()) // Assuming your middleware sets "userID" in the context
@@ -365,9 +661,9 @@ func main() {
 				pageSize = 10
 			}
 
-			tournaments, total, err := tournamentService.ListActiveTournaments(c.Request.Context(), page, pageSize)
+			tournaments, total, err := tournamentService.ListActiveTournaments(c.Request.Context(), viewerID(c), page, pageSize)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list active tournaments: " + err.Error()})
+				handlers.RespondError(c, err)
 				return
 			}
 
@@ -392,21 +688,25 @@ func main() {
 				}
 				log.Printf("Dashboard - Tournament from DB: ID=%s, Name=%s, PrizePool (json.RawMessage as string): '%s'", t.ID, t.Name, prizePoolStr)
 				tournamentResponses = append(tournamentResponses, &domain.TournamentResponse{
-					ID:                   t.ID,
-					Name:                 t.Name,
-					Description:          t.Description,
-					Game:                 t.Game,
-					Format:               t.Format,
-					Status:               t.Status, // Frontend might need to map this to display strings like "Registrations Open"
-					MaxParticipants:      t.MaxParticipants,
-					CurrentParticipants:  participantCount,
-					RegistrationDeadline: t.RegistrationDeadline,
-					StartTime:            t.StartTime,
-					EndTime:              t.EndTime,
-					CreatedAt:            t.CreatedAt,
-					Rules:                t.Rules,
-					PrizePool:            t.PrizePool, // This is json.RawMessage, frontend handles display
-					CustomFields:         t.CustomFields,
+					ID:                      t.ID,
+					Name:                    t.Name,
+					Description:             t.Description,
+					Game:                    t.Game,
+					Format:                  t.Format,
+					Status:                  t.Status, // Frontend might need to map this to display strings like "Registrations Open"
+					MaxParticipants:         t.MaxParticipants,
+					CurrentParticipants:     participantCount,
+					RegistrationDeadline:    t.RegistrationDeadline,
+					StartTime:               t.StartTime,
+					EndTime:                 t.EndTime,
+					CreatedAt:               t.CreatedAt,
+					Rules:                   t.Rules,
+					PrizePool:               t.PrizePool, // This is json.RawMessage, frontend handles display
+					CustomFields:            t.CustomFields,
+					GrandFinalsAdvantage:    t.GrandFinalsAdvantage,
+					ReportingWindowMinutes:  t.ReportingWindowMinutes,
+					ReportingDeadlinePolicy: t.ReportingDeadlinePolicy,
+					Visibility:              t.Visibility,
 				})
 			}
 
@@ -415,9 +715,62 @@ func main() {
 				"total":       total,
 				"page":        page,
 				"pageSize":    pageSize,
+				"pagination":  domain.NewPaginationMeta(total, page, pageSize),
 			})
 		})
 
+		// GET /users/me/active-matches
+		// Lists the authenticated player's unfinished matches across all tournaments, grouped by tournament.
+		protected.GET("/users/me/active-matches", func(c *gin.Context) {
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+
+			groups, err := tournamentService.GetPlayerActiveMatches(c.Request.Context(), userID)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, groups)
+		})
+
+		// GET /users/me/tournaments
+		// Lists the tournaments the authenticated player is registered in, with their standing.
+		// ?status= limits the list to one or more comma-separated statuses, e.g. IN_PROGRESS.
+		protected.GET("/users/me/tournaments", func(c *gin.Context) {
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+
+			var statuses []domain.TournamentStatus
+			if raw := c.Query("status"); raw != "" {
+				for _, status := range strings.Split(raw, ",") {
+					statuses = append(statuses, domain.TournamentStatus(strings.ToUpper(strings.TrimSpace(status))))
+				}
+			}
+
+			tournaments, err := tournamentService.GetPlayerTournaments(c.Request.Context(), userID, statuses)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, tournaments)
+		})
+
 		// GET /dashboard/activities
 		// Retrieves a paginated list of recent activities for the authenticated user.
 		protected.GET("/dashboard/activities", func(c *gin.Context) {
@@ -448,9 +801,16 @@ func main() {
 				pageSize = 10
 			}
 
-			activities, total, err := userActivityService.GetUserActivities(c.Request.Context(), userID, page, pageSize)
+			// Optional ?type= narrows the feed to one activity type, e.g. MATCH_WON
+			activityType := domain.ActivityType(strings.ToUpper(strings.TrimSpace(c.Query("type"))))
+			if activityType != "" && !domain.IsFilterableActivityType(activityType) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid activity type %q", c.Query("type"))})
+				return
+			}
+
+			activities, total, err := userActivityService.GetUserActivities(c.Request.Context(), userID, activityType, page, pageSize)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user activities: " + err.Error()})
+				handlers.RespondError(c, err)
 				return
 			}
 
@@ -461,6 +821,7 @@ func main() {
 				"total":      total,
 				"page":       page,
 				"pageSize":   pageSize,
+				"pagination": domain.NewPaginationMeta(total, page, pageSize),
 			})
 		})
 
@@ -470,214 +831,975 @@ func main() {
 		protected.POST("/tournaments", func(c *gin.Context) {
 			var req domain.CreateTournamentRequest
 
-			// --- START DEBUGGING BLOCK ---
-			jsonData, err := c.GetRawData()
-			if err != nil {
-				log.Printf("Error getting raw data: %v", err)
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read request body"})
-				return
-			}
-			log.Printf("Received RAW JSON for /tournaments: %s", string(jsonData))
-			// It's crucial to put raw data back for ShouldBindJSON to work after reading it
-			c.Request.Body = io.NopCloser(bytes.NewBuffer(jsonData))
-			// --- END DEBUGGING BLOCK ---
 			if err := c.ShouldBindJSON(&req); err != nil {
-				log.Printf("Error binding JSON for /tournaments: %v. Received body: %s", err, string(jsonData)) // THIS LOG IS KEY
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"+err.Error()})
+				logging.Debugf(c.Request.Context(), "Error binding JSON for /tournaments: %v", err)
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload" + err.Error()})
 				return
 			}
-			token := c.GetHeader("Authorization")
-			if len(token) < 8 {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token format"})
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
 				return
 			}
-			token = token[7:]
-
-			user, err := userService.ValidateToken(token)
-			if err != nil {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user token"})
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
 				return
 			}
-			creatorID := user.GetUserUUID()
-
-			  // Log the bound request struct
-			  log.Printf("Successfully bound CreateTournamentRequest: %+v", req)
-			tournament, err := tournamentService.CreateTournament(c.Request.Context(), &req, creatorID)
+			logging.Debugf(c.Request.Context(), "Successfully bound CreateTournamentRequest: %+v", req)
+			tournament, err := tournamentService.CreateTournament(c.Request.Context(), &req, userID)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				handlers.RespondError(c, err)
 				return
 			}
 			c.JSON(http.StatusCreated, tournament)
 		})
 
-		protected.PUT("/tournaments/:tournamentId", func(c *gin.Context) {
+		// Organizers only, as the archive includes the match threads
+		protected.GET("/tournaments/:tournamentId/archive.json", func(c *gin.Context) {
 			id, err := uuid.Parse(c.Param("tournamentId"))
 			if err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
 				return
 			}
-			var req domain.UpdateTournamentRequest
-			if err := c.ShouldBindJSON(&req); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
 				return
 			}
-			tournament, err := tournamentService.UpdateTournament(c.Request.Context(), id, &req)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
 				return
 			}
-			c.JSON(http.StatusOK, tournament)
-		})
-
-		protected.DELETE("/tournaments/:tournamentId", func(c *gin.Context) {
-			id, err := uuid.Parse(c.Param("tournamentId"))
+			archive, err := tournamentService.ExportTournament(c.Request.Context(), id, userID)
 			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
-				return
-			}
-			if err := tournamentService.DeleteTournament(c.Request.Context(), id); err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				handlers.RespondError(c, err)
 				return
 			}
-			c.Status(http.StatusNoContent)
+			c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=tournament-%s.json", id))
+			c.JSON(http.StatusOK, archive)
 		})
 
-		protected.PUT("/tournaments/:tournamentId/status", func(c *gin.Context) {
-			id, err := uuid.Parse(c.Param("tournamentId"))
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+		protected.POST("/tournaments/import", func(c *gin.Context) {
+			var archive domain.TournamentArchive
+			if err := c.ShouldBindJSON(&archive); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid archive payload: " + err.Error()})
 				return
 			}
-			var req struct {
-				Status domain.TournamentStatus `json:"status"`
-			}
-			if err := c.ShouldBindJSON(&req); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
 				return
 			}
-			if err := tournamentService.UpdateTournamentStatus(c.Request.Context(), id, req.Status); err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
 				return
 			}
-			tournament, err := tournamentService.GetTournament(c.Request.Context(), id)
+			result, err := tournamentService.ImportTournament(c.Request.Context(), &archive, userID)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				handlers.RespondError(c, err)
 				return
 			}
-			c.JSON(http.StatusOK, tournament)
+			c.JSON(http.StatusCreated, result)
 		})
 
-		protected.POST("/tournaments/:tournamentId/bracket", func(c *gin.Context) {
+		// Start a new Draft tournament with the settings of one the caller can see
+		protected.POST("/tournaments/:tournamentId/clone", func(c *gin.Context) {
 			id, err := uuid.Parse(c.Param("tournamentId"))
 			if err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
 				return
 			}
-			log.Printf("Clearing existing matches for tournament %s", id)
-			err = tournamentService.DeleteMatches(c.Request.Context(), id)
-			if err != nil {
-				log.Printf("Error clearing matches: %v", err)
-			}
-			log.Printf("Generating bracket for tournament %s", id)
-			err = tournamentService.GenerateBracket(c.Request.Context(), id)
-			if err != nil {
-				log.Printf("Error generating bracket: %v", err)
-				c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to generate bracket: %v", err)})
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
 				return
 			}
-			log.Printf("Updating tournament %s status to IN_PROGRESS", id)
-			err = tournamentService.UpdateTournamentStatus(c.Request.Context(), id, domain.InProgress)
-			if err != nil {
-				log.Printf("Warning: Failed to update tournament status: %v", err)
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
 			}
-			log.Printf("Fetching matches for tournament %s", id)
-			matches, err := tournamentService.GetMatches(c.Request.Context(), id)
+			tournament, err := tournamentService.CloneTournament(c.Request.Context(), id, userID)
 			if err != nil {
-				log.Printf("Error fetching matches: %v", err)
-				c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to fetch generated matches: %v", err)})
+				handlers.RespondError(c, err)
 				return
 			}
-			c.JSON(http.StatusCreated, matches)
+			c.JSON(http.StatusCreated, tournament)
 		})
 
-		protected.PUT("/tournaments/:tournamentId/matches/:matchId", func(c *gin.Context) {
-			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+		protected.PUT("/tournaments/:tournamentId", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
 			if err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
 				return
 			}
-			matchID, err := uuid.Parse(c.Param("matchId"))
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
-				return
-			}
-			var req domain.ScoreUpdateRequest
+			var req domain.UpdateTournamentRequest
 			if err := c.ShouldBindJSON(&req); err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			}
-			token := c.GetHeader("Authorization")
-			if len(token) < 8 {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token format"})
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			tournament, err := tournamentService.UpdateTournament(c.Request.Context(), id, userID, &req)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, tournament)
+		})
+
+		protected.DELETE("/tournaments/:tournamentId", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			if err := tournamentService.DeleteTournament(c.Request.Context(), id, userID); err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.Status(http.StatusNoContent)
+		})
+
+		protected.DELETE("/tournaments/:tournamentId/purge", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			if err := tournamentService.PurgeTournament(c.Request.Context(), id, userID); err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.Status(http.StatusNoContent)
+		})
+
+		// Join the tournament an invite code belongs to as the authenticated user. The
+		// participant name defaults to the caller's username.
+		protected.POST("/tournaments/code/:code/join", func(c *gin.Context) {
+			var req struct {
+				ParticipantName string `json:"participant_name"`
+			}
+			if c.Request.ContentLength > 0 {
+				if err := c.ShouldBindJSON(&req); err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload:" + err.Error()})
+					return
+				}
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			if req.ParticipantName == "" {
+				req.ParticipantName = c.GetString("username")
+			}
+			if req.ParticipantName == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "participant_name is required"})
+				return
+			}
+			participant, err := tournamentService.JoinByInviteCode(c.Request.Context(), c.Param("code"), userID, req.ParticipantName)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusCreated, participant)
+		})
+
+		// Organizers read, regenerate (POST) or disable (DELETE) a tournament's invite code
+		protected.GET("/tournaments/:tournamentId/invite-code", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			code, err := tournamentService.GetInviteCode(c.Request.Context(), id, userID)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"invite_code": code, "enabled": code != ""})
+		})
+
+		protected.POST("/tournaments/:tournamentId/invite-code", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			code, err := tournamentService.RegenerateInviteCode(c.Request.Context(), id, userID)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"invite_code": code, "enabled": true})
+		})
+
+		protected.DELETE("/tournaments/:tournamentId/invite-code", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			if err := tournamentService.DisableInviteCode(c.Request.Context(), id, userID); err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.Status(http.StatusNoContent)
+		})
+
+		protected.PUT("/tournaments/:tournamentId/status", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			var req struct {
+				Status domain.TournamentStatus `json:"status"`
+			}
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			if err := tournamentService.UpdateTournamentStatus(c.Request.Context(), id, userID, req.Status); err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			tournament, err := tournamentService.GetTournament(c.Request.Context(), id)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, tournament)
+		})
+
+		protected.POST("/tournaments/:tournamentId/check-in", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			if err := tournamentService.CheckInParticipant(c.Request.Context(), id, userID); err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.Status(http.StatusNoContent)
+		})
+
+		protected.POST("/tournaments/:tournamentId/bracket", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			force := c.Query("force") == "true"
+			log.Printf("Generating bracket for tournament %s (force=%t)", id, force)
+			err = tournamentService.GenerateBracket(c.Request.Context(), id, userID, force)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			log.Printf("Updating tournament %s status to IN_PROGRESS", id)
+			err = tournamentService.UpdateTournamentStatus(c.Request.Context(), id, userID, domain.InProgress)
+			if err != nil {
+				log.Printf("Warning: Failed to update tournament status: %v", err)
+			}
+			log.Printf("Fetching matches for tournament %s", id)
+			matches, err := tournamentService.GetMatches(c.Request.Context(), id)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusCreated, matches)
+		})
+
+		protected.POST("/tournaments/:tournamentId/seed-by-ranking", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			participants, err := tournamentService.SeedByRanking(c.Request.Context(), id, userID)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, participants)
+		})
+
+		// Dry run of bracket generation; ?format= and ?seeding= override the tournament's format and saved seeds
+		protected.GET("/tournaments/:tournamentId/bracket/preview", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			format := domain.TournamentFormat(strings.ToUpper(c.Query("format")))
+			preview, err := tournamentService.PreviewBracket(c.Request.Context(), id, userID, format, c.Query("seeding"))
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, preview)
+		})
+
+		protected.POST("/tournaments/:tournamentId/swiss/next-round", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			matches, err := tournamentService.GenerateNextSwissRound(c.Request.Context(), id, userID)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusCreated, matches)
+		})
+
+		protected.GET("/tournaments/:tournamentId/stale-matches", func(c *gin.Context) {
+			id, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			timeout := staleMatchTimeout
+			if raw := c.Query("timeout"); raw != "" {
+				timeout, err = time.ParseDuration(raw)
+				if err != nil || timeout <= 0 {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timeout, expected a duration such as 90m"})
+					return
+				}
+			}
+			stale, err := tournamentService.ListStaleMatches(c.Request.Context(), id, timeout)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, stale)
+		})
+
+		protected.PUT("/tournaments/:tournamentId/matches/:matchId", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			matchID, err := uuid.Parse(c.Param("matchId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+				return
+			}
+			var req domain.ScoreUpdateRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			update, err := tournamentService.UpdateMatchScore(c.Request.Context(), tournamentID, matchID, userID, &req)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			// Only the reported match and the IDs of the downstream matches it changed are returned
+			c.JSON(http.StatusOK, update)
+		})
+
+		protected.POST("/tournaments/:tournamentId/matches/:matchId/forfeit", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			matchID, err := uuid.Parse(c.Param("matchId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+				return
+			}
+			var req domain.ForfeitRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if !req.DoubleForfeit && req.ForfeitingParticipantID == nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "forfeiting_participant_id is required unless double_forfeit is set"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			match, err := tournamentService.ForfeitMatch(c.Request.Context(), tournamentID, matchID, userID, &req)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, match)
+		})
+
+		// POST /tournaments/:tournamentId/participants/:participantId/substitute
+		// Organizers only. Puts a replacement player in the participant's bracket slot.
+		protected.POST("/tournaments/:tournamentId/participants/:participantId/substitute", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			participantID, err := uuid.Parse(c.Param("participantId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid participant ID"})
+				return
+			}
+			var req domain.SubstitutionRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			participant, err := tournamentService.SubstituteParticipant(c.Request.Context(), tournamentID, participantID, userID, &req)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, participant)
+		})
+
+		protected.PUT("/tournaments/:tournamentId/matches/:matchId/schedule", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			matchID, err := uuid.Parse(c.Param("matchId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+				return
+			}
+			var req domain.MatchScheduleRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			match, err := tournamentService.UpdateMatchSchedule(c.Request.Context(), tournamentID, matchID, userID, req.ScheduledTime)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, match)
+		})
+
+		protected.POST("/tournaments/:tournamentId/matches/:matchId/reset", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			matchID, err := uuid.Parse(c.Param("matchId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			update, err := tournamentService.ResetMatch(c.Request.Context(), tournamentID, matchID, userID)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, update)
+		})
+
+		protected.PUT("/tournaments/:tournamentId/matches/:matchId/notes", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			matchID, err := uuid.Parse(c.Param("matchId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+				return
+			}
+			var req domain.MatchNotesRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			match, err := tournamentService.UpdateMatchNotes(c.Request.Context(), tournamentID, matchID, userID, &req)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, match)
+		})
+
+		// Mark the tournament chat read up to now, resetting its unread_count
+		protected.POST("/tournaments/:tournamentId/messages/read", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
 				return
 			}
-			token = token[7:]
-			user, err := userService.ValidateToken(token)
+			state, err := tournamentService.MarkChatRead(c.Request.Context(), tournamentID, userID)
 			if err != nil {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user token"})
+				handlers.RespondError(c, err)
 				return
 			}
-			userID := user.GetUserUUID()
-			err = tournamentService.UpdateMatchScore(c.Request.Context(), tournamentID, matchID, userID, &req)
+			c.JSON(http.StatusOK, state)
+		})
+
+		protected.POST("/tournaments/:tournamentId/messages", chatRateLimit, func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			var req domain.MessageRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
 				return
 			}
-			matches, err := tournamentService.GetMatches(c.Request.Context(), tournamentID) // Re-fetch all matches for simplicity
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			message, err := tournamentService.SendMessage(c.Request.Context(), tournamentID, userID, &req)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get updated match data"})
+				handlers.RespondError(c, err)
 				return
 			}
-			var updatedMatch *domain.MatchResponse
-			for _, m := range matches {
-				if m.ID == matchID {
-					updatedMatch = m
-					break
-				}
+			c.JSON(http.StatusCreated, message)
+		})
+
+		// Match threads are for the two players and the organizers, so reading one needs a token too
+		protected.GET("/tournaments/:tournamentId/matches/:matchId/messages", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			matchID, err := uuid.Parse(c.Param("matchId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
 			}
-			c.JSON(http.StatusOK, updatedMatch) // Return only the updated match or all matches if preferred
+			messages, err := tournamentService.GetMatchMessages(c.Request.Context(), tournamentID, matchID, userID, 50, 0)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, messages)
 		})
 
-		protected.POST("/tournaments/:tournamentId/messages", func(c *gin.Context) {
+		protected.POST("/tournaments/:tournamentId/matches/:matchId/messages", chatRateLimit, func(c *gin.Context) {
 			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
 			if err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
 				return
 			}
+			matchID, err := uuid.Parse(c.Param("matchId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+				return
+			}
 			var req domain.MessageRequest
 			if err := c.ShouldBindJSON(&req); err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			}
-			token := c.GetHeader("Authorization")
-			if len(token) < 8 {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token format"})
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
 				return
 			}
-			token = token[7:]
-			user, err := userService.ValidateToken(token)
-			if err != nil {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user token"})
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
 				return
 			}
-			userID := user.GetUserUUID()
-			message, err := tournamentService.SendMessage(c.Request.Context(), tournamentID, userID, &req)
+			message, err := tournamentService.SendMatchMessage(c.Request.Context(), tournamentID, matchID, userID, &req)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				handlers.RespondError(c, err)
 				return
 			}
 			c.JSON(http.StatusCreated, message)
 		})
+
+		protected.PUT("/tournaments/:tournamentId/messages/:messageId", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			messageID, err := uuid.Parse(c.Param("messageId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+				return
+			}
+			var req domain.MessageRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			message, err := tournamentService.EditMessage(c.Request.Context(), tournamentID, messageID, userID, &req)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, message)
+		})
+
+		protected.POST("/tournaments/:tournamentId/messages/:messageId/reactions", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			messageID, err := uuid.Parse(c.Param("messageId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+				return
+			}
+			var req domain.ReactionRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			emoji := strings.TrimSpace(req.Emoji)
+			if emoji == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "emoji is required"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			message, err := tournamentService.AddReaction(c.Request.Context(), tournamentID, messageID, userID, emoji)
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, message)
+		})
+
+		// The emoji is the last path segment, URL-encoded by the client
+		protected.DELETE("/tournaments/:tournamentId/messages/:messageId/reactions/:emoji", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			messageID, err := uuid.Parse(c.Param("messageId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			message, err := tournamentService.RemoveReaction(c.Request.Context(), tournamentID, messageID, userID, c.Param("emoji"))
+			if err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, message)
+		})
+
+		// POST pins a message to the top of its chat and DELETE unpins it (organizers only)
+		pinMessage := func(pinned bool) gin.HandlerFunc {
+			return func(c *gin.Context) {
+				tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+					return
+				}
+				messageID, err := uuid.Parse(c.Param("messageId"))
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+					return
+				}
+				userIDValue, exists := c.Get("userID")
+				if !exists {
+					c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+					return
+				}
+				userID, ok := userIDValue.(uuid.UUID)
+				if !ok {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+					return
+				}
+				message, err := tournamentService.SetMessagePinned(c.Request.Context(), tournamentID, messageID, userID, pinned)
+				if err != nil {
+					handlers.RespondError(c, err)
+					return
+				}
+				c.JSON(http.StatusOK, message)
+			}
+		}
+		protected.POST("/tournaments/:tournamentId/messages/:messageId/pin", pinMessage(true))
+		protected.DELETE("/tournaments/:tournamentId/messages/:messageId/pin", pinMessage(false))
+
+		protected.DELETE("/tournaments/:tournamentId/messages/:messageId", func(c *gin.Context) {
+			tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+				return
+			}
+			messageID, err := uuid.Parse(c.Param("messageId"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+				return
+			}
+			userIDValue, exists := c.Get("userID")
+			if !exists {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context. Authentication required."})
+				return
+			}
+			userID, ok := userIDValue.(uuid.UUID)
+			if !ok {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID in context is of an invalid type."})
+				return
+			}
+			if err := tournamentService.DeleteMessage(c.Request.Context(), tournamentID, messageID, userID); err != nil {
+				handlers.RespondError(c, err)
+				return
+			}
+			c.Status(http.StatusNoContent)
+		})
+	}
+
+	// Ranking outbox admin routes; need the X-Internal-Service-Key header
+	rankingAdmin := router.Group("/rankings/admin", middleware.RequireInternalServiceKey())
+	{
+		rankingAdmin.GET("/failed-events", failedEventsHandler(rankingOutboxWorker))
+		rankingAdmin.POST("/retry/:eventId", retryEventHandler(rankingOutboxWorker))
 	}
 
 	// Start server
@@ -708,6 +1830,86 @@ func main() {
 	log.Println("Server exited properly")
 }
 
+// listGames responds with the supported game registry
+func listGames(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"games": domain.SupportedGames})
+}
+
+// getMatchHandler responds with one match of the tournament, 404 when it belongs to another one
+func getMatchHandler(tournamentService service.TournamentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tournamentID, err := uuid.Parse(c.Param("tournamentId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+			return
+		}
+		matchID, err := uuid.Parse(c.Param("matchId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+			return
+		}
+		match, err := tournamentService.GetMatch(c.Request.Context(), tournamentID, matchID)
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, match)
+	}
+}
+
+// failedEventsHandler lists the ranking notifications not delivered yet, up to ?limit=
+func failedEventsHandler(worker *service.RankingOutboxWorker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultOutboxPageSize)))
+		if limit < 1 {
+			limit = defaultOutboxPageSize
+		}
+		if limit > maxOutboxPageSize {
+			limit = maxOutboxPageSize
+		}
+		events, err := worker.ListUndelivered(c.Request.Context(), limit)
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"events": events})
+	}
+}
+
+// retryEventHandler delivers one undelivered ranking notification now
+func retryEventHandler(worker *service.RankingOutboxWorker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, err := uuid.Parse(c.Param("eventId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+			return
+		}
+		event, delivered, err := worker.Retry(c.Request.Context(), eventID)
+		if err != nil {
+			handlers.RespondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"delivered": delivered, "event": event})
+	}
+}
+
+// readyHandler reports whether the database answers a ping, with how long the ping took.
+// It responds 503 when the database is unreachable so readiness probes take the instance out.
+func readyHandler(db *sql.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+		defer cancel()
+		start := time.Now()
+		err := db.PingContext(ctx)
+		latencyMs := float64(time.Since(start).Microseconds()) / 1000
+		if err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "database": "down", "db_latency_ms": latencyMs, "error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready", "database": "up", "db_latency_ms": latencyMs})
+	}
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -716,12 +1918,121 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return value
 }
 
-// Helper function to get raw body for logging (optional, but useful for debugging JSON binding)
-func getRawBody(c *gin.Context) string {
-    bodyBytes, err := io.ReadAll(c.Request.Body)
-    if err != nil {
-        return fmt.Sprintf("error reading body: %v", err)
-    }
-    c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes)) // Important: Restore the body for further processing
-    return string(bodyBytes)
+func getIntEnvOrDefault(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+func getDurationEnvOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// viewerID returns the authenticated caller set by AuthMiddleware or OptionalAuthMiddleware,
+// or uuid.Nil for anonymous requests
+func viewerID(c *gin.Context) uuid.UUID {
+	if userIDValue, exists := c.Get("userID"); exists {
+		if userID, ok := userIDValue.(uuid.UUID); ok {
+			return userID
+		}
+	}
+	return uuid.Nil
+}
+
+// parseStatusFilter collects tournament statuses from repeated ?status= params and a
+// comma-separated ?statuses= list, rejecting any value that is not a known status
+func parseStatusFilter(c *gin.Context) ([]domain.TournamentStatus, error) {
+	raw := c.QueryArray("status")
+	if list := c.Query("statuses"); list != "" {
+		raw = append(raw, list)
+	}
+
+	var statuses []domain.TournamentStatus
+	seen := make(map[domain.TournamentStatus]bool)
+	for _, value := range raw {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			status := domain.TournamentStatus(strings.ToUpper(part))
+			if !domain.IsValidStatus(status) {
+				return nil, fmt.Errorf("invalid status %q", part)
+			}
+			if !seen[status] {
+				seen[status] = true
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// parseQuotaOverrides parses TOURNAMENT_LIMIT_OVERRIDES: comma-separated userID=limit pairs,
+// where a limit of 0 lifts the cap for that user
+func parseQuotaOverrides(raw string) (map[uuid.UUID]int, error) {
+	overrides := make(map[uuid.UUID]int)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		userPart, limitPart, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid tournament limit override %q: expected userID=limit", entry)
+		}
+		userID, err := uuid.Parse(strings.TrimSpace(userPart))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tournament limit override %q: %w", entry, err)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(limitPart))
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid tournament limit override %q: limit must be a non-negative integer", entry)
+		}
+		overrides[userID] = limit
+	}
+	return overrides, nil
+}
+
+// parseAllowedOrigins reads a comma-separated CORS_ALLOWED_ORIGINS value such as
+// "https://app.example.com,https://admin.example.com", falling back to defaults when it is empty.
+// A lone "*" allows every origin. Each origin must be an http(s) scheme and host with no path.
+func parseAllowedOrigins(raw string, defaults []string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return defaults, nil
+	}
+	var origins []string
+	for _, origin := range strings.Split(raw, ",") {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		if origin == "" {
+			continue
+		}
+		if origin == "*" {
+			origins = append(origins, origin)
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return nil, fmt.Errorf("invalid CORS origin %q: expected scheme://host[:port]", origin)
+		}
+		origins = append(origins, origin)
+	}
+	if len(origins) == 0 {
+		return defaults, nil
+	}
+	if len(origins) > 1 {
+		for _, origin := range origins {
+			if origin == "*" {
+				return nil, fmt.Errorf("CORS origin \"*\" cannot be combined with other origins")
+			}
+		}
+	}
+	return origins, nil
 }
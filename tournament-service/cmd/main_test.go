@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// queryContext is a gin context for a GET request to target
+func queryContext(target string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c
+}
+
+func TestParseStatusFilter(t *testing.T) {
+	statuses, err := parseStatusFilter(queryContext("/tournaments?status=registration&status=IN_PROGRESS&statuses=completed,%20registration"))
+	if err != nil {
+		t.Fatalf("parseStatusFilter: %v", err)
+	}
+	want := []domain.TournamentStatus{domain.Registration, domain.InProgress, domain.Completed}
+	if len(statuses) != len(want) {
+		t.Fatalf("want %v, got %v", want, statuses)
+	}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Fatalf("want %v, got %v", want, statuses)
+		}
+	}
+
+	if statuses, err := parseStatusFilter(queryContext("/tournaments")); err != nil || len(statuses) != 0 {
+		t.Fatalf("no status parameters should mean no filter, got %v, %v", statuses, err)
+	}
+	if _, err := parseStatusFilter(queryContext("/tournaments?statuses=REGISTRATION,LIVE")); err == nil {
+		t.Fatal("expected an unknown status to be rejected")
+	}
+}
+
+// openConnector is a database that always accepts connections
+type openConnector struct{}
+
+func (openConnector) Connect(context.Context) (driver.Conn, error) { return openConn{}, nil }
+func (openConnector) Driver() driver.Driver                        { return nil }
+
+type openConn struct{}
+
+func (openConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (openConn) Close() error                        { return nil }
+func (openConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+// ready calls readyHandler against db and returns the status and the decoded body
+func ready(t *testing.T, db *sql.DB) (int, map[string]interface{}) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/ready", nil)
+	readyHandler(db)(c)
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	return recorder.Code, body
+}
+
+func TestReadyHandler(t *testing.T) {
+	status, body := ready(t, sql.OpenDB(openConnector{}))
+	if status != http.StatusOK || body["database"] != "up" {
+		t.Fatalf("reachable database: expected 200 and up, got %d %v", status, body)
+	}
+	if _, ok := body["db_latency_ms"].(float64); !ok {
+		t.Fatalf("expected the ping latency in the body, got %v", body)
+	}
+
+	closed := sql.OpenDB(openConnector{})
+	closed.Close()
+	status, body = ready(t, closed)
+	if status != http.StatusServiceUnavailable || body["database"] != "down" {
+		t.Fatalf("closed database: expected 503 and down, got %d %v", status, body)
+	}
+}
+
+func TestParseAllowedOrigins(t *testing.T) {
+	defaults := []string{"http://localhost:3000"}
+	for raw, want := range map[string][]string{
+		"":    defaults,
+		" , ": defaults,
+		"https://app.example.com/, http://localhost:8080": {"https://app.example.com", "http://localhost:8080"},
+		" * ": {"*"},
+	} {
+		origins, err := parseAllowedOrigins(raw, defaults)
+		if err != nil {
+			t.Fatalf("parseAllowedOrigins(%q): %v", raw, err)
+		}
+		if len(origins) != len(want) {
+			t.Fatalf("parseAllowedOrigins(%q) = %v, want %v", raw, origins, want)
+		}
+		for i := range want {
+			if origins[i] != want[i] {
+				t.Fatalf("parseAllowedOrigins(%q) = %v, want %v", raw, origins, want)
+			}
+		}
+	}
+
+	for _, raw := range []string{
+		"app.example.com",
+		"ftp://files.example.com",
+		"https://app.example.com/path",
+		"https://user@app.example.com",
+		"*,https://app.example.com",
+	} {
+		if _, err := parseAllowedOrigins(raw, defaults); err == nil {
+			t.Errorf("parseAllowedOrigins(%q) should be rejected", raw)
+		}
+	}
+}
+
+func TestListGames(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/games", nil)
+	listGames(c)
+
+	var body struct {
+		Games []domain.GameTitle `json:"games"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if recorder.Code != http.StatusOK || len(body.Games) != len(domain.SupportedGames) {
+		t.Fatalf("expected all %d supported games, got %d %+v", len(domain.SupportedGames), recorder.Code, body.Games)
+	}
+	for _, game := range body.Games {
+		if id, ok := domain.CanonicalGameID(game.Name); !ok || id != game.ID {
+			t.Errorf("%s should resolve to %s, got %q", game.Name, game.ID, id)
+		}
+	}
+}
+
+// matchService answers GetMatch from matches, reporting other tournaments' matches as not found
+type matchService struct {
+	service.TournamentService
+	matches map[uuid.UUID]*domain.MatchResponse
+}
+
+func (s matchService) GetMatch(ctx context.Context, tournamentID, matchID uuid.UUID) (*domain.MatchResponse, error) {
+	match, ok := s.matches[matchID]
+	if !ok || match.TournamentID != tournamentID {
+		return nil, service.ErrMatchNotFound
+	}
+	return match, nil
+}
+
+func TestGetMatchHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tournamentID := uuid.New()
+	match := &domain.MatchResponse{ID: uuid.New(), TournamentID: tournamentID, Round: 1, Participant1Name: "Ace"}
+	router := gin.New()
+	router.GET("/tournaments/:tournamentId/matches/:matchId", getMatchHandler(matchService{matches: map[uuid.UUID]*domain.MatchResponse{match.ID: match}}))
+
+	get := func(tournament, match string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/tournaments/"+tournament+"/matches/"+match, nil))
+		return recorder
+	}
+
+	found := get(tournamentID.String(), match.ID.String())
+	var body domain.MatchResponse
+	if err := json.Unmarshal(found.Body.Bytes(), &body); err != nil || found.Code != http.StatusOK {
+		t.Fatalf("expected the match, got %d %s", found.Code, found.Body)
+	}
+	if body.ID != match.ID || body.Participant1Name != "Ace" {
+		t.Fatalf("unexpected match %+v", body)
+	}
+
+	for name, c := range map[string]struct {
+		tournament, match string
+		status            int
+	}{
+		"wrong tournament": {uuid.NewString(), match.ID.String(), http.StatusNotFound},
+		"missing match":    {tournamentID.String(), uuid.NewString(), http.StatusNotFound},
+		"bad match ID":     {tournamentID.String(), "nope", http.StatusBadRequest},
+		"bad tournament":   {"nope", match.ID.String(), http.StatusBadRequest},
+	} {
+		if got := get(c.tournament, c.match); got.Code != c.status {
+			t.Errorf("%s: expected %d, got %d %s", name, c.status, got.Code, got.Body)
+		}
+	}
+}
+
+func TestParseQuotaOverrides(t *testing.T) {
+	vip, tight := uuid.New(), uuid.New()
+	overrides, err := parseQuotaOverrides(" " + vip.String() + "=0, " + tight.String() + " = 3,")
+	if err != nil {
+		t.Fatalf("parseQuotaOverrides: %v", err)
+	}
+	if len(overrides) != 2 || overrides[vip] != 0 || overrides[tight] != 3 {
+		t.Fatalf("unexpected overrides %v", overrides)
+	}
+	if overrides, err := parseQuotaOverrides(""); err != nil || len(overrides) != 0 {
+		t.Fatalf("an empty value should have no overrides, got %v (%v)", overrides, err)
+	}
+
+	for _, raw := range []string{vip.String(), "nope=3", vip.String() + "=-1", vip.String() + "=many"} {
+		if _, err := parseQuotaOverrides(raw); err == nil {
+			t.Errorf("parseQuotaOverrides(%q) should be rejected", raw)
+		}
+	}
+}
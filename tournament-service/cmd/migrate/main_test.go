@@ -0,0 +1,18 @@
+package main
+
+import "testing"
+
+func TestConnectionString(t *testing.T) {
+	for _, key := range []string{"DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME"} {
+		t.Setenv(key, "")
+	}
+	if got, want := connectionString(), "host=localhost port=5432 user=postgres password=postgres dbname=tournament_db sslmode=require"; got != want {
+		t.Fatalf("defaults: got %q, want %q", got, want)
+	}
+
+	t.Setenv("DB_HOST", "db.internal")
+	t.Setenv("DB_NAME", "tournaments")
+	if got, want := connectionString(), "host=db.internal port=5432 user=postgres password=postgres dbname=tournaments sslmode=require"; got != want {
+		t.Fatalf("overrides: got %q, want %q", got, want)
+	}
+}
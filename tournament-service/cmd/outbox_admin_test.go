@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/repository"
+	"github.com/cliffdoyle/tournament-service/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// memoryOutbox is a RankingOutboxRepository over a map; methods the admin routes do not reach
+// are left to the embedded nil interface and panic if called
+type memoryOutbox struct {
+	repository.RankingOutboxRepository
+	entries map[uuid.UUID]*domain.OutboxEntry
+	limits  []int
+}
+
+func (o *memoryOutbox) ListUnsent(ctx context.Context, limit int) ([]*domain.OutboxEntry, error) {
+	o.limits = append(o.limits, limit)
+	entries := []*domain.OutboxEntry{}
+	for _, entry := range o.entries {
+		if entry.SentAt == nil {
+			copied := *entry
+			entries = append(entries, &copied)
+		}
+	}
+	return entries, nil
+}
+
+func (o *memoryOutbox) GetByID(ctx context.Context, id uuid.UUID) (*domain.OutboxEntry, error) {
+	entry, ok := o.entries[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *entry
+	return &copied, nil
+}
+
+func (o *memoryOutbox) MarkSent(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	o.entries[id].SentAt = &now
+	o.entries[id].Attempts++
+	return nil
+}
+
+func (o *memoryOutbox) MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	o.entries[id].Attempts++
+	o.entries[id].NextAttemptAt = nextAttemptAt
+	o.entries[id].LastError = lastError
+	return nil
+}
+
+func TestRankingOutboxAdminRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ranking := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ranking.Close()
+
+	failed := &domain.OutboxEntry{
+		ID: uuid.New(), MatchID: uuid.New(), Payload: []byte(`{}`), Attempts: 4,
+		LastError: "failed to reach ranking service", NextAttemptAt: time.Now().Add(time.Hour),
+	}
+	outbox := &memoryOutbox{entries: map[uuid.UUID]*domain.OutboxEntry{failed.ID: failed}}
+	worker := service.NewRankingOutboxWorker(outbox, ranking.URL, "", time.Second, time.Minute, time.Second, time.Minute)
+	router := gin.New()
+	router.GET("/rankings/admin/failed-events", failedEventsHandler(worker))
+	router.POST("/rankings/admin/retry/:eventId", retryEventHandler(worker))
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+		return recorder
+	}
+
+	listed := serve(http.MethodGet, "/rankings/admin/failed-events?limit=5000")
+	var list struct {
+		Events []domain.OutboxEntry `json:"events"`
+	}
+	if err := json.Unmarshal(listed.Body.Bytes(), &list); err != nil || listed.Code != http.StatusOK {
+		t.Fatalf("expected the failed events, got %d %s", listed.Code, listed.Body)
+	}
+	if len(list.Events) != 1 || list.Events[0].ID != failed.ID || list.Events[0].Attempts != 4 || list.Events[0].LastError == "" {
+		t.Fatalf("expected the seeded event with its attempts and reason, got %+v", list.Events)
+	}
+	serve(http.MethodGet, "/rankings/admin/failed-events?limit=0")
+	if len(outbox.limits) != 2 || outbox.limits[0] != maxOutboxPageSize || outbox.limits[1] != defaultOutboxPageSize {
+		t.Fatalf("expected the limit capped and defaulted, got %v", outbox.limits)
+	}
+
+	retried := serve(http.MethodPost, "/rankings/admin/retry/"+failed.ID.String())
+	var result struct {
+		Delivered bool               `json:"delivered"`
+		Event     domain.OutboxEntry `json:"event"`
+	}
+	if err := json.Unmarshal(retried.Body.Bytes(), &result); err != nil || retried.Code != http.StatusOK {
+		t.Fatalf("expected the retry result, got %d %s", retried.Code, retried.Body)
+	}
+	if !result.Delivered || result.Event.SentAt == nil || result.Event.Attempts != 5 {
+		t.Fatalf("expected the event to be delivered on retry, got %+v", result)
+	}
+
+	for target, status := range map[string]int{
+		"/rankings/admin/retry/" + failed.ID.String():  http.StatusConflict,
+		"/rankings/admin/retry/" + uuid.New().String(): http.StatusNotFound,
+		"/rankings/admin/retry/latest":                 http.StatusBadRequest,
+	} {
+		if got := serve(http.MethodPost, target); got.Code != status {
+			t.Errorf("%s: expected %d, got %d %s", target, status, got.Code, got.Body)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RankingService handles lookups against the Ranking Service.
+type RankingService struct {
+	BaseURL string
+	client  *http.Client
+	timeout time.Duration // Limit for each call; the caller's context can end it sooner
+}
+
+// UserRanking is the part of the Ranking Service's /rankings/users/:userId response used here.
+// Users without any results come back with zero points.
+type UserRanking struct {
+	UserID     uuid.UUID `json:"userId"`
+	GameID     string    `json:"gameId"`
+	Points     int       `json:"points"`
+	GlobalRank int       `json:"globalRank"`
+}
+
+// NewRankingService creates a new client for the Ranking Service at RANKING_SERVICE_URL whose
+// calls give up after timeout.
+func NewRankingService(timeout time.Duration) *RankingService {
+	baseURL := os.Getenv("RANKING_SERVICE_URL")
+	if baseURL == "" {
+		log.Println("Warning: RANKING_SERVICE_URL environment variable is not set. Ranking service client might not function correctly.")
+	}
+	return &RankingService{
+		BaseURL: baseURL,
+		client:  &http.Client{},
+		timeout: timeout,
+	}
+}
+
+// GetUserRanking fetches a user's ranking for a game from the Ranking Service.
+func (s *RankingService) GetUserRanking(ctx context.Context, userID uuid.UUID, gameID string) (*UserRanking, error) {
+	if s.BaseURL == "" {
+		return nil, fmt.Errorf("ranking service BaseURL is not configured")
+	}
+
+	ctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+
+	rankingURL := fmt.Sprintf("%s/rankings/users/%s?gameId=%s", s.BaseURL, userID, url.QueryEscape(gameID))
+	req, err := http.NewRequestWithContext(ctx, "GET", rankingURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", rankingURL, err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", rankingURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		log.Printf("[client.RankingService.GetUserRanking] Error: Ranking service returned status %d. Body: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("ranking lookup for user %s failed with status %d", userID, resp.StatusCode)
+	}
+
+	var ranking UserRanking
+	if err := json.NewDecoder(resp.Body).Decode(&ranking); err != nil {
+		return nil, fmt.Errorf("failed to decode ranking response: %w", err)
+	}
+	return &ranking, nil
+}
@@ -0,0 +1,18 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultTimeout bounds each call to another service when no timeout is configured
+const DefaultTimeout = 5 * time.Second
+
+// withTimeout derives the context for one outbound call. The caller's cancellation still
+// applies; timeout <= 0 uses DefaultTimeout.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// slowServer answers nothing until the client gives up, or after a few seconds at most
+func slowServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices the client hanging up once the body has been read
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCallsToASlowServiceTimeOut(t *testing.T) {
+	server := slowServer(t)
+	t.Setenv("USER_SERVICE_URL", server.URL)
+	t.Setenv("RANKING_SERVICE_URL", server.URL)
+	users := NewUserService(50*time.Millisecond, 0, 0)
+	rankings := NewRankingService(50 * time.Millisecond)
+
+	for name, call := range map[string]func() error{
+		"ValidateToken": func() error {
+			_, err := users.ValidateToken(context.Background(), "token")
+			return err
+		},
+		"GetMultipleUserDetails": func() error {
+			_, err := users.GetMultipleUserDetails(context.Background(), []uuid.UUID{uuid.New()})
+			return err
+		},
+		"GetUserRanking": func() error {
+			_, err := rankings.GetUserRanking(context.Background(), uuid.New(), "chess")
+			return err
+		},
+	} {
+		start := time.Now()
+		err := call()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: expected the deadline to be exceeded, got %v", name, err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%s: took %s despite a 50ms timeout", name, elapsed)
+		}
+	}
+}
+
+func TestCallsStopWhenTheCallerCancels(t *testing.T) {
+	server := slowServer(t)
+	t.Setenv("USER_SERVICE_URL", server.URL)
+	users := NewUserService(time.Minute, 0, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := users.GetMultipleUserDetails(ctx, []uuid.UUID{uuid.New()}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("the caller's deadline should end the call, got %v", err)
+	}
+}
+
+func TestWithTimeoutDefault(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), 0)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > DefaultTimeout || time.Until(deadline) < DefaultTimeout-time.Second {
+		t.Fatalf("expected a deadline about %s away, got %v", DefaultTimeout, time.Until(deadline))
+	}
+}
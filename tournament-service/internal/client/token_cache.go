@@ -0,0 +1,101 @@
+package client
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// cachedToken is a validated token's profile and when it stops being trusted
+type cachedToken struct {
+	user    UserProfileData
+	expires time.Time
+}
+
+// tokenCache remembers recently validated tokens so that repeated requests with the same
+// token skip the round trip to the User Service. Tokens are keyed by their SHA-256 hash so
+// the raw tokens are not kept in memory.
+type tokenCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[[sha256.Size]byte]cachedToken
+	now        func() time.Time
+}
+
+// newTokenCache creates a cache holding up to maxEntries tokens for ttl each.
+// It returns nil, which disables caching, if either is not positive.
+func newTokenCache(ttl time.Duration, maxEntries int) *tokenCache {
+	if ttl <= 0 || maxEntries <= 0 {
+		return nil
+	}
+	return &tokenCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[[sha256.Size]byte]cachedToken),
+		now:        time.Now,
+	}
+}
+
+// get returns the cached profile for token if it has not expired
+func (c *tokenCache) get(token string) (*UserProfileData, bool) {
+	if c == nil {
+		return nil, false
+	}
+	key := sha256.Sum256([]byte(token))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	user := entry.user
+	return &user, true
+}
+
+// put caches the profile a token was validated as, making room first if the cache is full
+func (c *tokenCache) put(token string, user *UserProfileData) {
+	if c == nil {
+		return
+	}
+	key := sha256.Sum256([]byte(token))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		// Drop expired entries, then the one closest to expiring if that was not enough
+		var oldestKey [sha256.Size]byte
+		var oldest time.Time
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+				continue
+			}
+			if oldest.IsZero() || entry.expires.Before(oldest) {
+				oldestKey, oldest = k, entry.expires
+			}
+		}
+		if len(c.entries) >= c.maxEntries {
+			delete(c.entries, oldestKey)
+		}
+	}
+	c.entries[key] = cachedToken{user: *user, expires: now.Add(c.ttl)}
+}
+
+// invalidate forgets token, e.g. after the User Service rejected it
+func (c *tokenCache) invalidate(token string) {
+	if c == nil {
+		return
+	}
+	key := sha256.Sum256([]byte(token))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io" // For io.ReadAll
@@ -17,6 +18,8 @@ import (
 type UserService struct {
 	BaseURL string
 	client  *http.Client
+	timeout time.Duration // Limit for each call; the caller's context can end it sooner
+	tokens  *tokenCache   // Recently validated tokens; nil disables caching
 }
 
 // UserProfileData matches the structure of the "user" object returned by User Service's /user/profile.
@@ -35,8 +38,17 @@ type ValidateTokenResponse struct {
 	User UserProfileData `json:"user"`
 }
 
-// NewUserService creates a new client for the User Service.
-func NewUserService() *UserService {
+// UserDetails is the public profile returned for each user by the User Service's /users/batch endpoint.
+type UserDetails struct {
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name,omitempty"`
+}
+
+// NewUserService creates a new client for the User Service whose calls give up after timeout.
+// Validated tokens are cached for tokenCacheTTL, up to tokenCacheSize of them; a zero TTL or
+// size disables the cache.
+func NewUserService(timeout, tokenCacheTTL time.Duration, tokenCacheSize int) *UserService {
 	baseURL := os.Getenv("USER_SERVICE_URL")
 	if baseURL == "" {
 		log.Println("Warning: USER_SERVICE_URL environment variable is not set. User service client might not function correctly.")
@@ -45,13 +57,30 @@ func NewUserService() *UserService {
 	}
 	return &UserService{
 		BaseURL: baseURL,
-		client:  &http.Client{Timeout: 10 * time.Second}, // Added a timeout
+		client:  &http.Client{},
+		timeout: timeout,
+		tokens:  newTokenCache(tokenCacheTTL, tokenCacheSize),
+	}
+}
+
+// ValidateToken validates a JWT token, answering from the token cache when the same token
+// was validated recently and otherwise asking the User Service.
+// It returns the UserProfileData which includes the correct uuid.UUID.
+func (s *UserService) ValidateToken(ctx context.Context, token string) (*UserProfileData, error) {
+	if user, ok := s.tokens.get(token); ok {
+		return user, nil
 	}
+	user, err := s.fetchTokenProfile(ctx, token)
+	if err != nil {
+		s.tokens.invalidate(token)
+		return nil, err
+	}
+	s.tokens.put(token, user)
+	return user, nil
 }
 
-// ValidateToken validates a JWT token by calling the User Service's /user/profile endpoint.
-// It now returns the UserProfileData which includes the correct uuid.UUID.
-func (s *UserService) ValidateToken(token string) (*UserProfileData, error) {
+// fetchTokenProfile validates a JWT token by calling the User Service's /user/profile endpoint.
+func (s *UserService) fetchTokenProfile(ctx context.Context, token string) (*UserProfileData, error) {
 	if s.BaseURL == "" {
 		return nil, fmt.Errorf("user service BaseURL is not configured")
 	}
@@ -73,8 +102,11 @@ func (s *UserService) ValidateToken(token string) (*UserProfileData, error) {
 		}
 	*/
 
+	ctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+
 	profileURL := fmt.Sprintf("%s/user/profile", s.BaseURL)
-	req, err := http.NewRequest("GET", profileURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", profileURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request to %s: %w", profileURL, err)
 	}
@@ -82,38 +114,89 @@ func (s *UserService) ValidateToken(token string) (*UserProfileData, error) {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("Content-Type", "application/json") // Good practice, though GET might not need it
 
-	log.Printf("[client.UserService.ValidateToken] Sending GET to %s", profileURL)
+	log.Printf("[client.UserService.fetchTokenProfile] Sending GET to %s", profileURL)
 	resp, err := s.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call %s: %w", profileURL, err)
 	}
 	defer resp.Body.Close()
 
-	log.Printf("[client.UserService.ValidateToken] Received status %d from %s", resp.StatusCode, profileURL)
+	log.Printf("[client.UserService.fetchTokenProfile] Received status %d from %s", resp.StatusCode, profileURL)
 
 	bodyBytes, _ := io.ReadAll(resp.Body) // Read body for logging in case of error
 	// Restore body for json.NewDecoder
 	resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 
-
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("[client.UserService.ValidateToken] Error: User service returned status %d. Body: %s", resp.StatusCode, string(bodyBytes))
+		log.Printf("[client.UserService.fetchTokenProfile] Error: User service returned status %d. Body: %s", resp.StatusCode, string(bodyBytes))
 		return nil, fmt.Errorf("user service token validation failed with status %d", resp.StatusCode)
 	}
 
 	var validationResponse ValidateTokenResponse // To decode the {"user": {...}} structure
 	if err := json.NewDecoder(resp.Body).Decode(&validationResponse); err != nil {
-		log.Printf("[client.UserService.ValidateToken] Error decoding response body: %v. Body: %s", err, string(bodyBytes))
+		log.Printf("[client.UserService.fetchTokenProfile] Error decoding response body: %v. Body: %s", err, string(bodyBytes))
 		return nil, fmt.Errorf("failed to decode user profile response: %w", err)
 	}
 
 	// The actual user data is in validationResponse.User
-	log.Printf("[client.UserService.ValidateToken] Successfully validated token, UserID: %s, Username: %s",
+	log.Printf("[client.UserService.fetchTokenProfile] Successfully validated token, UserID: %s, Username: %s",
 		validationResponse.User.ID, validationResponse.User.Username)
 
 	return &validationResponse.User, nil
 }
 
+// GetMultipleUserDetails fetches usernames and display names for several users in one call
+// to the User Service's /users/batch endpoint. Users the service does not know are absent from the map.
+func (s *UserService) GetMultipleUserDetails(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]UserDetails, error) {
+	if s.BaseURL == "" {
+		return nil, fmt.Errorf("user service BaseURL is not configured")
+	}
+	if len(userIDs) == 0 {
+		return make(map[uuid.UUID]UserDetails), nil
+	}
+
+	payloadBytes, err := json.Marshal(struct {
+		UserIDs []uuid.UUID `json:"user_ids"`
+	}{UserIDs: userIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user IDs for batch request: %w", err)
+	}
+
+	ctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+
+	batchURL := fmt.Sprintf("%s/users/batch", s.BaseURL)
+	req, err := http.NewRequestWithContext(ctx, "POST", batchURL, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", batchURL, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", batchURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		log.Printf("[client.UserService.GetMultipleUserDetails] Error: User service returned status %d. Body: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("user service batch lookup failed with status %d", resp.StatusCode)
+	}
+
+	var batchResponse struct {
+		Users map[uuid.UUID]UserDetails `json:"users"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&batchResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode batch user details response: %w", err)
+	}
+	if batchResponse.Users == nil {
+		batchResponse.Users = make(map[uuid.UUID]UserDetails)
+	}
+
+	return batchResponse.Users, nil
+}
+
 // GetUserUUID is now a method of UserProfileData if needed, or just use .ID directly.
 // Since ValidateToken now returns *UserProfileData which contains the uuid.UUID,
 // the old GetUserUUID method on the old UserResponse is no longer directly applicable
@@ -138,4 +221,4 @@ func (s *UserService) GetUserByID(userID uuid.UUID) (*UserProfileData, error) {
     // ... (similar HTTP request logic as ValidateToken) ...
     // ... decode into UserProfileData ...
 }
-*/
\ No newline at end of file
+*/
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// newTestUserService points a UserService at handler
+func newTestUserService(t *testing.T, handler http.HandlerFunc) *UserService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	t.Setenv("USER_SERVICE_URL", server.URL)
+	return NewUserService(time.Second, 0, 0)
+}
+
+func TestGetMultipleUserDetails(t *testing.T) {
+	known, unknown := uuid.New(), uuid.New()
+	users := newTestUserService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/users/batch" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			UserIDs []uuid.UUID `json:"user_ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.UserIDs) != 2 {
+			t.Errorf("expected both user IDs in the body, got %+v (%v)", body, err)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"users": map[uuid.UUID]UserDetails{known: {ID: known, Username: "ace", DisplayName: "Ace"}},
+		})
+	})
+
+	details, err := users.GetMultipleUserDetails(context.Background(), []uuid.UUID{known, unknown})
+	if err != nil {
+		t.Fatalf("GetMultipleUserDetails: %v", err)
+	}
+	if len(details) != 1 || details[known].Username != "ace" || details[known].DisplayName != "Ace" {
+		t.Fatalf("unexpected details: %+v", details)
+	}
+}
+
+func TestGetMultipleUserDetailsErrors(t *testing.T) {
+	users := newTestUserService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	if _, err := users.GetMultipleUserDetails(context.Background(), []uuid.UUID{uuid.New()}); err == nil {
+		t.Fatal("expected an error for a failed batch lookup")
+	}
+
+	details, err := users.GetMultipleUserDetails(context.Background(), nil)
+	if err != nil || len(details) != 0 {
+		t.Fatalf("no IDs should need no call, got %v, %v", details, err)
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	userID := uuid.New()
+	users := newTestUserService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/profile" || r.Header.Get("Authorization") != "Bearer good-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"user": UserProfileData{ID: userID, Username: "ace"},
+		})
+	})
+
+	profile, err := users.ValidateToken(context.Background(), "good-token")
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if profile.GetUserUUID() != userID || profile.Username != "ace" {
+		t.Fatalf("unexpected profile %+v", profile)
+	}
+	if _, err := users.ValidateToken(context.Background(), "bad-token"); err == nil {
+		t.Fatal("expected a rejected token to be an error")
+	}
+}
+
+func TestValidateTokenIsCachedWithinTheTTL(t *testing.T) {
+	calls := 0
+	users := newTestUserService(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "Bearer good-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"user": UserProfileData{ID: uuid.New(), Username: "ace"},
+		})
+	})
+	users.tokens = newTokenCache(time.Minute, 10)
+	now := time.Now()
+	users.tokens.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := users.ValidateToken(context.Background(), "good-token"); err != nil {
+			t.Fatalf("ValidateToken: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the second validation to come from the cache, the user service was called %d times", calls)
+	}
+
+	// Once the TTL has passed the user service is asked again
+	now = now.Add(time.Minute)
+	if _, err := users.ValidateToken(context.Background(), "good-token"); err != nil || calls != 2 {
+		t.Fatalf("expected an expired token to be validated again, got %d calls (%v)", calls, err)
+	}
+
+	// Rejected tokens are never cached
+	for i := 0; i < 2; i++ {
+		if _, err := users.ValidateToken(context.Background(), "bad-token"); err == nil {
+			t.Fatal("expected a rejected token to be an error")
+		}
+	}
+	if calls != 4 {
+		t.Fatalf("expected every rejected validation to reach the user service, got %d calls", calls)
+	}
+}
+
+func TestTokenCacheEvictsWhenFull(t *testing.T) {
+	cache := newTokenCache(time.Minute, 2)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.put("first", &UserProfileData{Username: "first"})
+	now = now.Add(time.Second)
+	cache.put("second", &UserProfileData{Username: "second"})
+	cache.put("third", &UserProfileData{Username: "third"})
+
+	if _, ok := cache.get("first"); ok {
+		t.Fatal("expected the entry closest to expiring to make room")
+	}
+	for _, token := range []string{"second", "third"} {
+		if user, ok := cache.get(token); !ok || user.Username != token {
+			t.Fatalf("expected %s to stay cached, got %+v", token, user)
+		}
+	}
+	if newTokenCache(0, 10) != nil || newTokenCache(time.Minute, 0) != nil {
+		t.Fatal("a zero TTL or size should disable the cache")
+	}
+}
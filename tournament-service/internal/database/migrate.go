@@ -0,0 +1,136 @@
+// Package database applies the service's SQL migrations.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+)
+
+// migrationLockID is the Postgres advisory lock held while migrating, so two instances
+// starting at once do not apply the same file twice
+const migrationLockID = 72_410_001
+
+// migrationFile matches the files RunMigrations applies: a numeric prefix, a name and .sql.
+// Rollback scripts (*.down.sql) and unnumbered files are left alone.
+var migrationFile = regexp.MustCompile(`^\d+_[A-Za-z0-9_]+\.sql$`)
+
+// Migration is one SQL file. Filename is the version recorded in schema_migrations.
+type Migration struct {
+	Filename string
+	SQL      string
+}
+
+// LoadMigrations reads the migrations at the root of fsys, ordered by filename
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	var migrations []Migration
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !migrationFile.MatchString(name) {
+			continue
+		}
+		contents, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+		migrations = append(migrations, Migration{Filename: name, SQL: string(contents)})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Filename < migrations[j].Filename })
+	return migrations, nil
+}
+
+// RunMigrations applies every migration in fsys that schema_migrations does not list yet, each
+// in its own transaction, and returns the filenames it applied. The table has the same shape
+// run-migrations.sh created, so databases migrated by the script carry on where it stopped.
+func RunMigrations(ctx context.Context, db *sql.DB, fsys fs.FS) ([]string, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	// The advisory lock belongs to a session, so every statement below uses the same connection
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a database connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return nil, fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			log.Printf("Warning: failed to release the migration lock: %v", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			filename VARCHAR(255) PRIMARY KEY,
+			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)
+	`); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	done := make(map[string]bool)
+	rows, err := conn.QueryContext(ctx, `SELECT filename FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	for rows.Next() {
+		var filename string
+		if err := rows.Scan(&filename); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		done[filename] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating schema_migrations: %w", err)
+	}
+
+	applied := []string{}
+	for _, migration := range migrations {
+		if done[migration.Filename] {
+			continue
+		}
+		if err := applyMigration(ctx, conn, migration); err != nil {
+			return applied, err
+		}
+		log.Printf("Applied migration %s", migration.Filename)
+		applied = append(applied, migration.Filename)
+	}
+	return applied, nil
+}
+
+// applyMigration runs one migration and records it, all or nothing
+func applyMigration(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %s: %w", migration.Filename, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration.SQL); err != nil {
+		return fmt.Errorf("migration %s failed: %w", migration.Filename, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (filename) VALUES ($1)`, migration.Filename,
+	); err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", migration.Filename, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %s: %w", migration.Filename, err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/migrations"
+	_ "github.com/lib/pq"
+)
+
+// migrationFS is three migrations, out of order, next to files the runner must skip
+func migrationFS() fstest.MapFS {
+	return fstest.MapFS{
+		"002_matches.sql":            {Data: []byte("CREATE TABLE matches ()")},
+		"001_initial.sql":            {Data: []byte("CREATE TABLE tournaments ()")},
+		"010_indexes.sql":            {Data: []byte("CREATE INDEX idx ON matches (id)")},
+		"002_matches.down.sql":       {Data: []byte("DROP TABLE matches")},
+		"user_activities.sql":        {Data: []byte("CREATE TABLE user_activities ()")},
+		"notes.txt":                  {Data: []byte("not a migration")},
+		"archive/003_old_schema.sql": {Data: []byte("DROP SCHEMA public")},
+	}
+}
+
+func TestLoadMigrationsOrdersNumberedFiles(t *testing.T) {
+	migrations, err := LoadMigrations(migrationFS())
+	if err != nil {
+		t.Fatalf("LoadMigrations: %v", err)
+	}
+	var names []string
+	for _, migration := range migrations {
+		names = append(names, migration.Filename)
+	}
+	if strings.Join(names, " ") != "001_initial.sql 002_matches.sql 010_indexes.sql" {
+		t.Fatalf("expected the three numbered migrations in order, got %v", names)
+	}
+	if migrations[0].SQL != "CREATE TABLE tournaments ()" {
+		t.Fatalf("unexpected SQL %q", migrations[0].SQL)
+	}
+}
+
+// appliedMigrations answers the schema_migrations select with the given filenames
+func appliedMigrations(filenames ...string) func(string, []driver.NamedValue) (driver.Rows, error) {
+	return func(query string, args []driver.NamedValue) (driver.Rows, error) {
+		rows := rowsOf([]string{"filename"})
+		for _, filename := range filenames {
+			rows.values = append(rows.values, []driver.Value{filename})
+		}
+		return rows, nil
+	}
+}
+
+func TestRunMigrationsAppliesOnlyNewFiles(t *testing.T) {
+	db := &scriptedDB{query: appliedMigrations("001_initial.sql")}
+
+	applied, err := RunMigrations(context.Background(), db.open(), migrationFS())
+	if err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+	if strings.Join(applied, " ") != "002_matches.sql 010_indexes.sql" {
+		t.Fatalf("expected the two new migrations, got %v", applied)
+	}
+
+	if lock := db.statements("SELECT pg_advisory_lock"); len(lock) != 1 {
+		t.Fatalf("expected the migration lock to be taken, got %v", db.log)
+	}
+	if unlock := db.statements("SELECT pg_advisory_unlock"); len(unlock) != 1 {
+		t.Fatalf("expected the migration lock to be released, got %v", db.log)
+	}
+	if len(db.statements("CREATE TABLE IF NOT EXISTS schema_migrations")) != 1 {
+		t.Fatalf("schema_migrations should be created if missing, got %v", db.log)
+	}
+	if len(db.statements("CREATE TABLE tournaments")) != 0 {
+		t.Fatal("an applied migration ran again")
+	}
+	// Each migration and its record share a transaction
+	if len(db.statements("BEGIN")) != 2 || len(db.statements("COMMIT")) != 2 || len(db.statements("INSERT INTO schema_migrations")) != 2 {
+		t.Fatalf("expected two committed migrations, got %v", db.log)
+	}
+}
+
+func TestRunMigrationsStopsAtAFailure(t *testing.T) {
+	failure := errors.New("syntax error")
+	db := &scriptedDB{
+		query: appliedMigrations(),
+		exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+			if strings.HasPrefix(query, "CREATE TABLE matches") {
+				return nil, failure
+			}
+			return driver.RowsAffected(0), nil
+		},
+	}
+
+	applied, err := RunMigrations(context.Background(), db.open(), migrationFS())
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), "002_matches.sql") {
+		t.Fatalf("expected the failing migration to be named, got %v", err)
+	}
+	if len(applied) != 1 || applied[0] != "001_initial.sql" {
+		t.Fatalf("expected only the first migration applied, got %v", applied)
+	}
+	if len(db.statements("ROLLBACK")) != 1 || len(db.statements("CREATE INDEX")) != 0 {
+		t.Fatalf("expected a rollback and no later migration, got %v", db.log)
+	}
+	if len(db.statements("SELECT pg_advisory_unlock")) != 1 {
+		t.Fatal("the migration lock should be released after a failure")
+	}
+}
+
+// throwawayDB creates an empty database next to the one named by TEST_DATABASE_URL and
+// drops it when the test ends. Without TEST_DATABASE_URL the test is skipped.
+func throwawayDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	name := fmt.Sprintf("migrate_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec(`CREATE DATABASE ` + name); err != nil {
+		t.Fatalf("create throwaway database: %v", err)
+	}
+	target, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("TEST_DATABASE_URL must be a URL: %v", err)
+	}
+	target.Path = "/" + name
+	db, err := sql.Open("postgres", target.String())
+	if err != nil {
+		t.Fatalf("open throwaway database: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		if _, err := admin.Exec(`DROP DATABASE IF EXISTS ` + name); err != nil {
+			t.Logf("drop throwaway database: %v", err)
+		}
+	})
+	return db
+}
+
+func TestRunMigrationsOnAThrowawayDatabase(t *testing.T) {
+	ctx := context.Background()
+	db := throwawayDB(t)
+
+	applied, err := RunMigrations(ctx, db, migrations.FS)
+	if err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+	all, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("LoadMigrations: %v", err)
+	}
+	if len(applied) != len(all) {
+		t.Fatalf("expected all %d migrations applied, got %d", len(all), len(applied))
+	}
+
+	for _, table := range []string{
+		"schema_migrations", "tournaments", "tournament_participants", "matches", "tournament_messages", "user_activities",
+	} {
+		var exists bool
+		if err := db.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, table).Scan(&exists); err != nil {
+			t.Fatalf("look up %s: %v", table, err)
+		}
+		if !exists {
+			t.Errorf("table %s was not created", table)
+		}
+	}
+
+	// A second run finds nothing left to do
+	if again, err := RunMigrations(ctx, db, migrations.FS); err != nil || len(again) != 0 {
+		t.Fatalf("expected nothing applied on the second run, got %v (%v)", again, err)
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "testing"
+
+func TestIsFilterableActivityType(t *testing.T) {
+	for _, activityType := range []ActivityType{
+		ActivityTournamentCreated, ActivityTournamentJoined, ActivityMatchWon, ActivityMatchLost,
+	} {
+		if !IsFilterableActivityType(activityType) {
+			t.Errorf("%s should be filterable", activityType)
+		}
+	}
+	for _, activityType := range []ActivityType{"", "match_won", ActivityBadgeEarned, ActivityGeneralPost} {
+		if IsFilterableActivityType(activityType) {
+			t.Errorf("%q should not be filterable", activityType)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchiveVersion is the current version of the tournament archive format
+const ArchiveVersion = 1
+
+// TournamentArchive is a self-contained snapshot of a tournament used for backup
+// and for moving an event between environments
+type TournamentArchive struct {
+	Version      int            `json:"version"`
+	ExportedAt   time.Time      `json:"exported_at"`
+	Tournament   *Tournament    `json:"tournament"`
+	Participants []*Participant `json:"participants"`
+	Matches      []*Match       `json:"matches"`
+	Messages     []*Message     `json:"messages"`
+}
+
+// ImportResult summarises a tournament recreated from an archive
+type ImportResult struct {
+	Tournament       *Tournament             `json:"tournament"`
+	ParticipantCount int                     `json:"participant_count"`
+	MatchCount       int                     `json:"match_count"`
+	MessageCount     int                     `json:"message_count"`
+	IDMapping        map[uuid.UUID]uuid.UUID `json:"id_mapping"` // Old ID -> new ID for every imported entity
+}
+
+// MatchResultRow is one match in a results export, with participant names resolved
+type MatchResultRow struct {
+	Round         int         `json:"round"`
+	MatchNumber   int         `json:"match_number"`
+	Participant1  string      `json:"participant1"`
+	Participant2  string      `json:"participant2"`
+	Score1        int         `json:"score1"`
+	Score2        int         `json:"score2"`
+	Winner        string      `json:"winner"`
+	Status        MatchStatus `json:"status"`
+	CompletedTime *time.Time  `json:"completed_time"`
+	BracketType   BracketType `json:"bracket_type,omitempty"`
+}
+
+// MatchResultsExport lists every match of a tournament with its result, for spreadsheets and record keeping
+type MatchResultsExport struct {
+	TournamentID   uuid.UUID        `json:"tournament_id"`
+	TournamentName string           `json:"tournament_name"`
+	Matches        []MatchResultRow `json:"matches"`
+}
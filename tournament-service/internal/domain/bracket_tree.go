@@ -0,0 +1,27 @@
+package domain
+
+import "github.com/google/uuid"
+
+// BracketTreeMatch is a match placed in the bracket tree, with participant names resolved
+type BracketTreeMatch struct {
+	*MatchResponse
+	Participant1Name string      `json:"participant1_name,omitempty"`
+	Participant2Name string      `json:"participant2_name,omitempty"`
+	FeederMatchIDs   []uuid.UUID `json:"feeder_match_ids"` // Matches whose winner or loser advances into this one
+}
+
+// BracketTree is a tournament's matches grouped for rendering: each bracket side is a list of
+// rounds in order, and each round lists its matches by match number
+type BracketTree struct {
+	Winners     [][]*BracketTreeMatch `json:"winners"`
+	Losers      [][]*BracketTreeMatch `json:"losers"`
+	GrandFinals []*BracketTreeMatch   `json:"grandFinals"`
+}
+
+// BracketPreview is the bracket a tournament would get, generated without saving any matches
+type BracketPreview struct {
+	TournamentID uuid.UUID        `json:"tournament_id"`
+	Format       TournamentFormat `json:"format"`
+	Seeding      string           `json:"seeding"` // Strategy used to seed the preview, or "current" for the saved seeds
+	Bracket      *BracketTree     `json:"bracket"`
+}
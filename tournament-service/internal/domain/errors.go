@@ -0,0 +1,51 @@
+package domain
+
+import "net/http"
+
+// Error is implemented by errors whose message is safe to show to API clients. HTTPStatus
+// is the status code handlers respond with; anything else is treated as an internal error.
+type Error interface {
+	error
+	HTTPStatus() int
+}
+
+// ErrorDetails is implemented by errors that carry extra fields for the response body,
+// such as the per-field problems of a ValidationError
+type ErrorDetails interface {
+	Details() map[string]interface{}
+}
+
+// ErrorKind is one of the broad classes of client-facing errors below. Every classified
+// error unwraps to its kind, so errors.Is(err, ErrNotFound) works whatever the exact error.
+type ErrorKind struct {
+	name   string
+	status int
+}
+
+func (k *ErrorKind) Error() string   { return k.name }
+func (k *ErrorKind) HTTPStatus() int { return k.status }
+
+var (
+	ErrNotFound        = &ErrorKind{name: "not found", status: http.StatusNotFound}
+	ErrConflict        = &ErrorKind{name: "conflict", status: http.StatusConflict}
+	ErrValidation      = &ErrorKind{name: "invalid request", status: http.StatusBadRequest}
+	ErrForbidden       = &ErrorKind{name: "forbidden", status: http.StatusForbidden}
+	ErrUnauthenticated = &ErrorKind{name: "authentication required", status: http.StatusUnauthorized}
+	ErrUnavailable     = &ErrorKind{name: "service unavailable", status: http.StatusServiceUnavailable}
+	ErrTooManyRequests = &ErrorKind{name: "too many requests", status: http.StatusTooManyRequests}
+)
+
+// kindError is a client-facing message of a given kind
+type kindError struct {
+	kind    *ErrorKind
+	message string
+}
+
+// NewError returns an error of the given kind whose message is shown to clients as-is
+func NewError(kind *ErrorKind, message string) error {
+	return &kindError{kind: kind, message: message}
+}
+
+func (e *kindError) Error() string   { return e.message }
+func (e *kindError) HTTPStatus() int { return e.kind.status }
+func (e *kindError) Unwrap() error   { return e.kind }
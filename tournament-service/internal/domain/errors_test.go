@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestNewErrorUnwrapsToItsKind(t *testing.T) {
+	err := fmt.Errorf("get match: %w", NewError(ErrNotFound, "match not found"))
+
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrConflict) {
+		t.Fatal("a not-found error should match ErrNotFound only")
+	}
+	var classified Error
+	if !errors.As(err, &classified) || classified.HTTPStatus() != http.StatusNotFound || classified.Error() != "match not found" {
+		t.Fatalf("expected a 404 with the given message, got %v", classified)
+	}
+}
+
+func TestErrorKindsAreErrors(t *testing.T) {
+	// A bare kind can be returned and is reported with its generic message
+	var classified Error = ErrUnavailable
+	if classified.HTTPStatus() != http.StatusServiceUnavailable || classified.Error() != "service unavailable" {
+		t.Fatalf("unexpected kind %d %q", classified.HTTPStatus(), classified.Error())
+	}
+}
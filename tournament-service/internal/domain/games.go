@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// GameTitle is a game tournaments can be created for. ID is what tournaments store and what
+// the Ranking Service keys leaderboards by; aliases are other spellings players type.
+type GameTitle struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// SupportedGames is the canonical game registry. The Ranking Service keeps a copy in its
+// domain package to normalize game IDs; add new titles to both.
+var SupportedGames = []GameTitle{
+	{ID: "fifa23", Name: "FIFA 23"},
+	{ID: "fc24", Name: "EA Sports FC 24", Aliases: []string{"eafc24", "fifa24"}},
+	{ID: "fc25", Name: "EA Sports FC 25", Aliases: []string{"eafc25", "fifa25"}},
+	{ID: "valorant", Name: "Valorant"},
+	{ID: "cs2", Name: "Counter-Strike 2", Aliases: []string{"counterstrike2"}},
+	{ID: "league-of-legends", Name: "League of Legends", Aliases: []string{"lol"}},
+	{ID: "dota2", Name: "Dota 2"},
+	{ID: "rocket-league", Name: "Rocket League", Aliases: []string{"rl"}},
+	{ID: "street-fighter-6", Name: "Street Fighter 6", Aliases: []string{"sf6"}},
+	{ID: "tekken8", Name: "Tekken 8", Aliases: []string{"t8"}},
+	{ID: "fortnite", Name: "Fortnite"},
+	{ID: "chess", Name: "Chess"},
+}
+
+// gameLookup maps the normalized ID, name and aliases of every supported game to its ID
+var gameLookup = func() map[string]string {
+	lookup := make(map[string]string)
+	for _, game := range SupportedGames {
+		lookup[gameKey(game.ID)] = game.ID
+		lookup[gameKey(game.Name)] = game.ID
+		for _, alias := range game.Aliases {
+			lookup[gameKey(alias)] = game.ID
+		}
+	}
+	return lookup
+}()
+
+// gameKey reduces a game name to lowercase letters and digits, so "FIFA 23", "fifa-23" and
+// "FIFA23" all compare equal
+func gameKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// CanonicalGameID returns the registry ID for a game's ID, name or alias, and whether the
+// game is supported
+func CanonicalGameID(game string) (string, bool) {
+	id, ok := gameLookup[gameKey(game)]
+	return id, ok
+}
+
+// NormalizeGame returns the registry ID of a supported game, and custom games trimmed but
+// otherwise as given
+func NormalizeGame(game string) string {
+	if id, ok := CanonicalGameID(game); ok {
+		return id
+	}
+	return strings.TrimSpace(game)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "testing"
+
+func TestCanonicalGameIDNormalizesAliases(t *testing.T) {
+	for name, want := range map[string]string{
+		"FIFA23":            "fifa23",
+		"fifa 23":           "fifa23",
+		"FIFA-23":           "fifa23",
+		"FC24":              "fc24",
+		"EA Sports FC 24":   "fc24",
+		"fifa24":            "fc24",
+		"  LoL ":            "league-of-legends",
+		"League of Legends": "league-of-legends",
+		"Counter-Strike 2":  "cs2",
+		"SF6":               "street-fighter-6",
+	} {
+		if id, ok := CanonicalGameID(name); !ok || id != want {
+			t.Errorf("CanonicalGameID(%q) = %q, %v; want %q", name, id, ok, want)
+		}
+	}
+	if id, ok := CanonicalGameID("pong"); ok {
+		t.Fatalf("an unknown game resolved to %q", id)
+	}
+}
+
+func TestNormalizeGame(t *testing.T) {
+	for game, want := range map[string]string{
+		"Fifa 23":     "fifa23",
+		"tekken 8":    "tekken8",
+		"  My Game  ": "My Game",
+		"":            "",
+	} {
+		if got := NormalizeGame(game); got != want {
+			t.Errorf("NormalizeGame(%q) = %q, want %q", game, got, want)
+		}
+	}
+}
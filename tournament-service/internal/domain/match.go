@@ -6,6 +6,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrConcurrentModification is returned when a match changed between being read and written back
+var ErrConcurrentModification = NewError(ErrConflict, "match was modified by someone else; reload it and try again")
+
 // MatchStatus represents the current state of a match
 type MatchStatus string
 
@@ -24,6 +27,20 @@ const (
 	GrandFinals    BracketType = "GRAND_FINALS"
 )
 
+// GameScore is the result of one game within a best-of-N series
+type GameScore struct {
+	Score1 int `json:"score1"`
+	Score2 int `json:"score2"`
+}
+
+// MatchFilter narrows a tournament's match list to one bracket and pages through it.
+// Zero values mean every bracket and no limit.
+type MatchFilter struct {
+	BracketType BracketType
+	Limit       int
+	Offset      int
+}
+
 // PrereqSourceType indicates whether a participant comes from a WIN or LOSS of a prerequisite match
 type PrereqSourceType string
 
@@ -47,41 +64,112 @@ type Match struct {
 	Status            MatchStatus `json:"status"`
 	ScheduledTime     *time.Time  `json:"scheduled_time,omitempty"`
 	CompletedTime     *time.Time  `json:"completed_time,omitempty"`
+	ReportingDeadline *time.Time  `json:"reporting_deadline,omitempty"` // ScheduledTime plus the tournament's reporting window
 	NextMatchID       *uuid.UUID  `json:"next_match_id,omitempty"`
 	LoserNextMatchID  *uuid.UUID  `json:"loser_next_match_id,omitempty"`
 	CreatedAt         time.Time   `json:"created_at"`
 	UpdatedAt         time.Time   `json:"updated_at"`
 	MatchNotes        string      `json:"match_notes,omitempty"`
 	MatchProofs       []string    `json:"match_proofs,omitempty"`
-	BracketType       BracketType `json:"bracket_type"`       // WINNERS, LOSERS, GRAND_FINALS
+	Games             []GameScore `json:"games,omitempty"` // Per-game scores of a series; the scores above count games won
+	BracketType       BracketType `json:"bracket_type"`    // WINNERS, LOSERS, GRAND_FINALS
+	Version           int         `json:"version"`         // Bumped on every update; guards against concurrent writes
+	// Display names joined in by the tournament match listings; empty for TBD slots and other queries
+	Participant1Name string `json:"participant1_name,omitempty"`
+	Participant2Name string `json:"participant2_name,omitempty"`
+	WinnerName       string `json:"winner_name,omitempty"`
 	// PreviousMatchIDs  []uuid.UUID    `json:"previous_match_ids"` // for traceability
 	Participant1PrereqMatchID *uuid.UUID `json:"participant1_prereq_match_id,omitempty"` // New
-    Participant2PrereqMatchID *uuid.UUID `json:"participant2_prereq_match_id,omitempty"` // New
+	Participant2PrereqMatchID *uuid.UUID `json:"participant2_prereq_match_id,omitempty"` // New
 }
 
 // MatchResponse represents the API response for a match
 type MatchResponse struct {
-	ID                uuid.UUID   `json:"id"`
-	TournamentID      uuid.UUID   `json:"tournament_id"`
-	Round             int         `json:"round"`
-	MatchNumber       int         `json:"match_number"`
-	Participant1ID    *uuid.UUID  `json:"participant1_id,omitempty"`
-	Participant2ID    *uuid.UUID  `json:"participant2_id,omitempty"`
-	WinnerID          *uuid.UUID  `json:"winner_id,omitempty"`
-	LoserID           *uuid.UUID  `json:"loser_id,omitempty"`
-	ScoreParticipant1 int         `json:"score_participant1"`
-	ScoreParticipant2 int         `json:"score_participant2"`
-	Status            MatchStatus `json:"status"`
-	ScheduledTime     *time.Time  `json:"scheduled_time,omitempty"`
-	CompletedTime     *time.Time  `json:"completed_time,omitempty"`
-	NextMatchID       *uuid.UUID  `json:"next_match_id,omitempty"`
-	LoserNextMatchID  *uuid.UUID  `json:"loser_next_match_id,omitempty"`
-	CreatedAt         time.Time   `json:"created_at"`
-	MatchNotes        string      `json:"match_notes,omitempty"`
-	MatchProofs       []string    `json:"match_proofs,omitempty"`
-	BracketType       BracketType `json:"bracket_type"` // WINNERS, LOSERS, GRAND_FINALS
-	Participant1PrereqMatchID *uuid.UUID `json:"participant1_prereq_match_id,omitempty"` // New
-    Participant2PrereqMatchID *uuid.UUID `json:"participant2_prereq_match_id,omitempty"` // New
+	ID                        uuid.UUID   `json:"id"`
+	TournamentID              uuid.UUID   `json:"tournament_id"`
+	Round                     int         `json:"round"`
+	MatchNumber               int         `json:"match_number"`
+	Participant1ID            *uuid.UUID  `json:"participant1_id,omitempty"`
+	Participant2ID            *uuid.UUID  `json:"participant2_id,omitempty"`
+	WinnerID                  *uuid.UUID  `json:"winner_id,omitempty"`
+	LoserID                   *uuid.UUID  `json:"loser_id,omitempty"`
+	ScoreParticipant1         int         `json:"score_participant1"`
+	ScoreParticipant2         int         `json:"score_participant2"`
+	Status                    MatchStatus `json:"status"`
+	ScheduledTime             *time.Time  `json:"scheduled_time,omitempty"`
+	CompletedTime             *time.Time  `json:"completed_time,omitempty"`
+	ReportingDeadline         *time.Time  `json:"reporting_deadline,omitempty"` // ScheduledTime plus the tournament's reporting window
+	NextMatchID               *uuid.UUID  `json:"next_match_id,omitempty"`
+	LoserNextMatchID          *uuid.UUID  `json:"loser_next_match_id,omitempty"`
+	CreatedAt                 time.Time   `json:"created_at"`
+	MatchNotes                string      `json:"match_notes,omitempty"`
+	MatchProofs               []string    `json:"match_proofs,omitempty"`
+	Games                     []GameScore `json:"games,omitempty"`
+	BracketType               BracketType `json:"bracket_type"` // WINNERS, LOSERS, GRAND_FINALS
+	Version                   int         `json:"version"`
+	Participant1Name          string      `json:"participant1_name"` // Empty while the slot is TBD
+	Participant2Name          string      `json:"participant2_name"`
+	WinnerName                string      `json:"winner_name"`
+	Participant1PrereqMatchID *uuid.UUID  `json:"participant1_prereq_match_id,omitempty"` // New
+	Participant2PrereqMatchID *uuid.UUID  `json:"participant2_prereq_match_id,omitempty"` // New
+}
+
+// MatchScheduleRequest sets when a match is to be played; a null time clears the schedule
+type MatchScheduleRequest struct {
+	ScheduledTime *time.Time `json:"scheduled_time"`
+}
+
+// MatchNotesRequest replaces a match's notes; an empty string clears them
+type MatchNotesRequest struct {
+	MatchNotes string `json:"match_notes" binding:"max=2000"`
+	Version    *int   `json:"version,omitempty"` // Version the client last saw; a newer one on the server is rejected
+}
+
+// ForfeitRequest awards a match that was not played. With DoubleForfeit set neither participant
+// advances and ForfeitingParticipantID is ignored.
+type ForfeitRequest struct {
+	ForfeitingParticipantID *uuid.UUID `json:"forfeiting_participant_id"`
+	Reason                  string     `json:"reason"`
+	DoubleForfeit           bool       `json:"double_forfeit"`
+}
+
+// MatchScoreUpdate is the result of reporting a score: the updated match plus the IDs of any
+// downstream matches that changed as a consequence (advanced winner, dropped loser, bracket reset)
+type MatchScoreUpdate struct {
+	Match           *MatchResponse `json:"match"`
+	UpdatedMatchIDs []uuid.UUID    `json:"updated_match_ids"`
+}
+
+// StaleMatch is a playable match that has seen no activity for longer than the configured timeout
+type StaleMatch struct {
+	Match        *MatchResponse `json:"match"`
+	LastActivity time.Time      `json:"last_activity"`
+	IdleMinutes  int            `json:"idle_minutes"`
+}
+
+// PlayerMatch is one of a player's upcoming matches, seen from that player's side
+type PlayerMatch struct {
+	Match         *MatchResponse `json:"match"`
+	ParticipantID uuid.UUID      `json:"participant_id"`        // The player's own entry in the match
+	OpponentID    *uuid.UUID     `json:"opponent_id,omitempty"` // Nil while the opponent is still to be decided
+	OpponentName  string         `json:"opponent_name,omitempty"`
+}
+
+// PlayerTournamentMatches groups a player's upcoming matches by tournament
+type PlayerTournamentMatches struct {
+	TournamentID   uuid.UUID      `json:"tournament_id"`
+	TournamentName string         `json:"tournament_name"`
+	Game           string         `json:"game"`
+	Matches        []*PlayerMatch `json:"matches"`
+}
+
+// PlayerTournament is a tournament a player is registered in, with the player's entry and standing
+type PlayerTournament struct {
+	Tournament      *TournamentResponse `json:"tournament"`
+	ParticipantID   uuid.UUID           `json:"participant_id"`
+	ParticipantName string              `json:"participant_name"`
+	Seed            int                 `json:"seed"`
+	Standing        *StandingEntry      `json:"standing,omitempty"` // Nil until the bracket has been generated
 }
 
 // ScoreUpdateRequest represents a request to update match scores
@@ -90,4 +178,8 @@ type ScoreUpdateRequest struct {
 	ScoreParticipant2 int      `json:"score_participant2"`
 	MatchNotes        string   `json:"match_notes,omitempty"`
 	MatchProofs       []string `json:"match_proofs,omitempty"`
+	Version           *int     `json:"version,omitempty"` // Version the client last saw; a newer one on the server is rejected
+	// Games reports a best-of-N series game by game; the scores above are then ignored and
+	// set to the games each participant won
+	Games []GameScore `json:"games,omitempty"`
 }
@@ -2,17 +2,21 @@ package domain
 
 import (
 	"time"
-	
+
 	"github.com/google/uuid"
 )
 
 // Message represents a chat message in a tournament
 type Message struct {
-	ID          uuid.UUID `json:"id"`
-	TournamentID uuid.UUID `json:"tournament_id"`
-	UserID      uuid.UUID `json:"user_id"`
-	Message     string    `json:"message"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID           uuid.UUID  `json:"id"`
+	TournamentID uuid.UUID  `json:"tournament_id"`
+	MatchID      *uuid.UUID `json:"match_id,omitempty"` // Set for match-scoped chat, nil for the tournament chat
+	UserID       uuid.UUID  `json:"user_id"`
+	Message      string     `json:"message"`
+	CreatedAt    time.Time  `json:"created_at"`
+	EditedAt     *time.Time `json:"edited_at,omitempty"`
+	DeletedAt    *time.Time `json:"-"`         // Soft-deleted messages are hidden from chat listings
+	IsPinned     bool       `json:"is_pinned"` // Pinned by an organizer; listed ahead of the rest
 }
 
 // MessageRequest represents data for creating a new message
@@ -22,9 +26,33 @@ type MessageRequest struct {
 
 // MessageResponse represents message data returned to clients
 type MessageResponse struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Username  string    `json:"username"` // This would be populated from user service
-	Message   string    `json:"message"`
-	CreatedAt time.Time `json:"created_at"`
-}
\ No newline at end of file
+	ID          uuid.UUID       `json:"id"`
+	MatchID     *uuid.UUID      `json:"match_id,omitempty"`
+	UserID      uuid.UUID       `json:"user_id"`
+	Username    string          `json:"username"`
+	DisplayName string          `json:"display_name,omitempty"`
+	Message     string          `json:"message"`
+	CreatedAt   time.Time       `json:"created_at"`
+	EditedAt    *time.Time      `json:"edited_at,omitempty"`
+	IsPinned    bool            `json:"is_pinned"`
+	Reactions   []ReactionCount `json:"reactions"` // Most used first; empty when nobody has reacted
+}
+
+// ChatReadState is how far a user has read a tournament's chat
+type ChatReadState struct {
+	TournamentID      uuid.UUID  `json:"tournament_id"`
+	LastReadMessageID *uuid.UUID `json:"last_read_message_id,omitempty"`
+	LastReadAt        time.Time  `json:"last_read_at"`
+	UnreadCount       int        `json:"unread_count"`
+}
+
+// ReactionCount is how many users reacted to a message with one emoji
+type ReactionCount struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
+
+// ReactionRequest adds an emoji reaction to a message
+type ReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required,max=32"`
+}
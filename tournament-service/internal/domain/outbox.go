@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEntry is a ranking service notification stored until it has been delivered
+type OutboxEntry struct {
+	ID            uuid.UUID       `json:"id"`
+	MatchID       uuid.UUID       `json:"match_id"`
+	Payload       json.RawMessage `json:"payload"` // The event body POSTed to the ranking service
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     string          `json:"last_error,omitempty"`
+	SentAt        *time.Time      `json:"sent_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
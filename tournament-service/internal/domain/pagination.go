@@ -0,0 +1,27 @@
+package domain
+
+// PaginationMeta describes where a page sits within a paginated list
+type PaginationMeta struct {
+	Total      int  `json:"total"`
+	Page       int  `json:"page"`
+	PageSize   int  `json:"pageSize"`
+	TotalPages int  `json:"totalPages"`
+	HasNext    bool `json:"hasNext"`
+	HasPrev    bool `json:"hasPrev"`
+}
+
+// NewPaginationMeta derives total pages and next/previous flags from a total count and the requested page
+func NewPaginationMeta(total, page, pageSize int) PaginationMeta {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+	return PaginationMeta{
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestNewPaginationMeta(t *testing.T) {
+	tests := []struct {
+		name                  string
+		total, page, pageSize int
+		want                  PaginationMeta
+	}{
+		{"empty list", 0, 1, 20, PaginationMeta{Total: 0, Page: 1, PageSize: 20}},
+		{"single page", 5, 1, 20, PaginationMeta{Total: 5, Page: 1, PageSize: 20, TotalPages: 1}},
+		{"first of several", 45, 1, 20, PaginationMeta{Total: 45, Page: 1, PageSize: 20, TotalPages: 3, HasNext: true}},
+		{"middle page", 45, 2, 20, PaginationMeta{Total: 45, Page: 2, PageSize: 20, TotalPages: 3, HasNext: true, HasPrev: true}},
+		{"exact last page", 40, 2, 20, PaginationMeta{Total: 40, Page: 2, PageSize: 20, TotalPages: 2, HasPrev: true}},
+		{"past the end", 10, 3, 20, PaginationMeta{Total: 10, Page: 3, PageSize: 20, TotalPages: 1, HasPrev: true}},
+		{"no page size", 10, 1, 0, PaginationMeta{Total: 10, Page: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPaginationMeta(tt.total, tt.page, tt.pageSize); got != tt.want {
+				t.Fatalf("NewPaginationMeta(%d, %d, %d) = %+v, want %+v", tt.total, tt.page, tt.pageSize, got, tt.want)
+			}
+		})
+	}
+}
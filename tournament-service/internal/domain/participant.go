@@ -2,13 +2,12 @@ package domain
 
 import (
 	"time"
-	"errors"
 
 	"github.com/google/uuid"
 )
 
 // internal/domain/errors.go (or similar)
-var ErrAlreadyParticipant = errors.New("user is already a participant in this tournament")
+var ErrAlreadyParticipant = NewError(ErrConflict, "user is already a participant in this tournament")
 
 // ParticipantStatus defines the current state of a participant
 type ParticipantStatus string
@@ -41,6 +40,13 @@ type ParticipantRequest struct {
 	Seed            *int       `json:"seed,omitempty"`
 }
 
+// SubstitutionRequest names the player replacing a participant. UserID links the replacement's
+// account; leaving it out makes the slot unlinked.
+type SubstitutionRequest struct {
+	ParticipantName string     `json:"participant_name" binding:"required,max=100"`
+	UserID          *uuid.UUID `json:"user_id,omitempty"`
+}
+
 // ParticipantResponse represents the data returned to clients
 type ParticipantResponse struct {
 	ID              uuid.UUID         `json:"id"`
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// PrizePool is the structured form of a tournament's prize_pool: a total amount in a currency,
+// split between final places by percentage. Placements maps places ("1", "2", ...) to their
+// share and must add up to 100. A prize pool given as plain text is kept as a description and
+// has no payouts.
+type PrizePool struct {
+	Amount     float64            `json:"amount"`
+	Currency   string             `json:"currency"`
+	Placements map[string]float64 `json:"placements"`
+}
+
+// PrizePayout is what one placed participant wins
+type PrizePayout struct {
+	Place           int        `json:"place"`
+	ParticipantID   uuid.UUID  `json:"participant_id"`
+	ParticipantName string     `json:"participant_name"`
+	UserID          *uuid.UUID `json:"user_id,omitempty"`
+	Percentage      float64    `json:"percentage"`
+	Amount          float64    `json:"amount"`
+}
+
+// PrizeDistribution is a completed tournament's prize pool paid out by final placement
+type PrizeDistribution struct {
+	TournamentID uuid.UUID      `json:"tournament_id"`
+	Currency     string         `json:"currency"`
+	Amount       float64        `json:"amount"`
+	Payouts      []*PrizePayout `json:"payouts"`
+}
+
+// ParsePrizePool reads the structured prize pool from a tournament's prize_pool JSON. It returns
+// nil without an error when the prize pool is absent or not a JSON object (a plain description).
+func ParsePrizePool(raw json.RawMessage) (*PrizePool, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, nil
+	}
+	var pool PrizePool
+	if err := json.Unmarshal(trimmed, &pool); err != nil {
+		return nil, fmt.Errorf("is not a valid prize pool: %v", err)
+	}
+	if pool.Amount <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+	if len(pool.Currency) != 3 {
+		return nil, errors.New("currency must be a 3-letter code such as USD")
+	}
+	if len(pool.Placements) == 0 {
+		return nil, errors.New("placements must give at least one place a share")
+	}
+	total := 0.0
+	for place, share := range pool.Placements {
+		if n, err := strconv.Atoi(place); err != nil || n < 1 {
+			return nil, fmt.Errorf("placements key %q must be a place of 1 or more", place)
+		}
+		if share <= 0 {
+			return nil, fmt.Errorf("placements share for place %s must be greater than 0", place)
+		}
+		total += share
+	}
+	if math.Abs(total-100) > 0.001 {
+		return nil, fmt.Errorf("placements percentages must add up to 100, got %g", total)
+	}
+	return &pool, nil
+}
+
+// Share returns the percentage of the pool for place, 0 when it pays nothing
+func (p *PrizePool) Share(place int) float64 {
+	return p.Placements[strconv.Itoa(place)]
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParsePrizePool(t *testing.T) {
+	pool, err := ParsePrizePool(json.RawMessage(`{"amount": 1000, "currency": "USD", "placements": {"1": 50, "2": 30, "3": 20}}`))
+	if err != nil {
+		t.Fatalf("ParsePrizePool: %v", err)
+	}
+	if pool.Amount != 1000 || pool.Currency != "USD" || pool.Share(1) != 50 || pool.Share(3) != 20 || pool.Share(4) != 0 {
+		t.Fatalf("unexpected pool %+v", pool)
+	}
+
+	// Plain descriptions carry no payouts
+	for _, raw := range []string{``, `"A trophy"`, `  `} {
+		if pool, err := ParsePrizePool(json.RawMessage(raw)); pool != nil || err != nil {
+			t.Errorf("%q: expected no prize pool, got %+v (%v)", raw, pool, err)
+		}
+	}
+
+	for name, raw := range map[string]string{
+		"not adding up to 100": `{"amount": 100, "currency": "USD", "placements": {"1": 60, "2": 30}}`,
+		"over 100":             `{"amount": 100, "currency": "USD", "placements": {"1": 70, "2": 40}}`,
+		"no amount":            `{"currency": "USD", "placements": {"1": 100}}`,
+		"a bad currency":       `{"amount": 100, "currency": "dollars", "placements": {"1": 100}}`,
+		"no placements":        `{"amount": 100, "currency": "USD"}`,
+		"place zero":           `{"amount": 100, "currency": "USD", "placements": {"0": 100}}`,
+		"a named place":        `{"amount": 100, "currency": "USD", "placements": {"first": 100}}`,
+		"a negative share":     `{"amount": 100, "currency": "USD", "placements": {"1": 110, "2": -10}}`,
+		"malformed":            `{"amount": "lots"}`,
+	} {
+		if _, err := ParsePrizePool(json.RawMessage(raw)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "github.com/google/uuid"
+
+// PointsConfig defines how many standings points each match result is worth
+type PointsConfig struct {
+	Win  int `json:"win"`
+	Draw int `json:"draw"`
+	Loss int `json:"loss"`
+}
+
+// DefaultPointsConfig is the conventional 3/1/0 scoring
+var DefaultPointsConfig = PointsConfig{Win: 3, Draw: 1, Loss: 0}
+
+// StandingEntry is a participant's position and record within a tournament
+type StandingEntry struct {
+	Rank            int       `json:"rank"`
+	ParticipantID   uuid.UUID `json:"participant_id"`
+	ParticipantName string    `json:"participant_name"`
+	Played          int       `json:"played"`
+	Wins            int       `json:"wins"`
+	Losses          int       `json:"losses"`
+	Draws           int       `json:"draws"`
+	Points          int       `json:"points"`
+}
+
+// Placement is a participant's final position in a completed tournament.
+// Participants knocked out at the same stage of an elimination bracket share a place.
+type Placement struct {
+	Place           int        `json:"place"`
+	ParticipantID   uuid.UUID  `json:"participant_id"`
+	ParticipantName string     `json:"participant_name"`
+	UserID          *uuid.UUID `json:"user_id,omitempty"`
+}
+
+// TournamentResults lists the final placements of a completed tournament, best first
+type TournamentResults struct {
+	TournamentID uuid.UUID        `json:"tournament_id"`
+	Format       TournamentFormat `json:"format"`
+	Placements   []*Placement     `json:"placements"`
+}
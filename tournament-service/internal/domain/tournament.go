@@ -28,73 +28,114 @@ const (
 	InProgress   TournamentStatus = "IN_PROGRESS"
 	Completed    TournamentStatus = "COMPLETED"
 	Cancelled    TournamentStatus = "CANCELLED"
+	Archived     TournamentStatus = "ARCHIVED" // Deleted by the organizer; kept so matches, chat and rankings stay intact
+)
+
+// TournamentVisibility controls who can find and open a tournament
+type TournamentVisibility string
+
+// Tournament visibilities
+const (
+	VisibilityPublic   TournamentVisibility = "PUBLIC"   // Listed and viewable by everyone
+	VisibilityUnlisted TournamentVisibility = "UNLISTED" // Left out of listings but viewable by anyone with the link
+	VisibilityPrivate  TournamentVisibility = "PRIVATE"  // Only organizers and participants can see it
+)
+
+// DeadlinePolicy decides what happens to a match whose reporting deadline passes without a result
+type DeadlinePolicy string
+
+// Reporting deadline policies
+const (
+	DeadlineFlag          DeadlinePolicy = "FLAG"           // Notify the organizer only (default)
+	DeadlineDoubleForfeit DeadlinePolicy = "DOUBLE_FORFEIT" // Neither participant advances; the match is cancelled
+	DeadlineCoinFlip      DeadlinePolicy = "COIN_FLIP"      // A random participant is awarded the match
 )
 
 // Tournament represents a gaming tournament
 type Tournament struct {
-	ID                   uuid.UUID              `json:"id"`
-	Name                 string                 `json:"name"`
-	Description          string                 `json:"description"`
-	Game                 string                 `json:"game"`
-	Format               TournamentFormat       `json:"format"`
-	Status               TournamentStatus       `json:"status"`
-	MaxParticipants      int                    `json:"maxParticipants"`
-	RegistrationDeadline *time.Time             `json:"registration_deadline"`
-	StartTime            *time.Time             `json:"startTime"`
-	EndTime              *time.Time             `json:"endTime"`
-	CreatedBy            uuid.UUID              `json:"createdBy"`
-	CreatedAt            time.Time              `json:"createdAt"`
-	UpdatedAt            time.Time              `json:"updatedAt"`
-	Rules                string                 `json:"rules"`
-	PrizePool            json.RawMessage `json:"prizePool,omitempty"` // <--- CHANGE THIS
-    CustomFields         json.RawMessage `json:"customFields,omitempty"`// Assuming this is also flexible JSON
+	ID                      uuid.UUID            `json:"id"`
+	Name                    string               `json:"name"`
+	Description             string               `json:"description"`
+	Game                    string               `json:"game"`
+	Format                  TournamentFormat     `json:"format"`
+	Status                  TournamentStatus     `json:"status"`
+	MaxParticipants         int                  `json:"maxParticipants"`
+	RegistrationDeadline    *time.Time           `json:"registration_deadline"`
+	StartTime               *time.Time           `json:"startTime"`
+	EndTime                 *time.Time           `json:"endTime"`
+	CreatedBy               uuid.UUID            `json:"createdBy"`
+	CreatedAt               time.Time            `json:"createdAt"`
+	UpdatedAt               time.Time            `json:"updatedAt"`
+	Rules                   string               `json:"rules"`
+	PrizePool               json.RawMessage      `json:"prizePool,omitempty"`    // <--- CHANGE THIS
+	CustomFields            json.RawMessage      `json:"customFields,omitempty"` // Assuming this is also flexible JSON
+	GrandFinalsAdvantage    int                  `json:"grandFinalsAdvantage"`   // Games the winners finalist starts grand finals with; 0 means a bracket reset instead
+	ReportingWindowMinutes  int                  `json:"reportingWindowMinutes"` // Minutes after ScheduledTime to report a result; 0 disables deadlines
+	ReportingDeadlinePolicy DeadlinePolicy       `json:"reportingDeadlinePolicy"`
+	Visibility              TournamentVisibility `json:"visibility"`
+	InviteCode              string               `json:"inviteCode,omitempty"` // Only filled in for the organizer when the tournament is created
 }
 
-
 // CreateTournamentRequest represents the data needed to create a tournament
 type CreateTournamentRequest struct {
-	Name                string           `json:"name" binding:"required"`
-	Description         string           `json:"description"`
-	Game                string           `json:"game" binding:"required"`
-	Format              TournamentFormat `json:"format"`
-	MaxParticipants     int              `json:"maxParticipants"`
-	RegistrationDeadline *time.Time      `json:"registrationDeadline"`
-	StartTime           *time.Time       `json:"startTime"`
-	Rules               string           `json:"rules"`
-	PrizePool            json.RawMessage `json:"prizePool,omitempty"` // <--- CHANGE THIS
-    CustomFields         json.RawMessage `json:"customFields,omitempty"`// Assuming this is also flexible JSON
+	Name                    string               `json:"name" binding:"required"`
+	Description             string               `json:"description"`
+	Game                    string               `json:"game" binding:"required"`
+	Format                  TournamentFormat     `json:"format"`
+	MaxParticipants         int                  `json:"maxParticipants"`
+	RegistrationDeadline    *time.Time           `json:"registrationDeadline"`
+	StartTime               *time.Time           `json:"startTime"`
+	Rules                   string               `json:"rules"`
+	PrizePool               json.RawMessage      `json:"prizePool,omitempty"`    // <--- CHANGE THIS
+	CustomFields            json.RawMessage      `json:"customFields,omitempty"` // Assuming this is also flexible JSON
+	GrandFinalsAdvantage    int                  `json:"grandFinalsAdvantage,omitempty"`
+	ReportingWindowMinutes  int                  `json:"reportingWindowMinutes,omitempty"`
+	ReportingDeadlinePolicy DeadlinePolicy       `json:"reportingDeadlinePolicy,omitempty"`
+	InitialStatus           TournamentStatus     `json:"initialStatus,omitempty"`   // DRAFT (default) or REGISTRATION
+	AllowCustomGame         bool                 `json:"allowCustomGame,omitempty"` // Accept a game that is not in SupportedGames
+	Visibility              TournamentVisibility `json:"visibility,omitempty"`      // PUBLIC (default), UNLISTED or PRIVATE
 }
 
 // UpdateTournamentRequest represents the data for updating a tournament
 type UpdateTournamentRequest struct {
-	Name                string           `json:"name"`
-	Description         string           `json:"description"`
-	Game                string           `json:"game"`
-	Format              TournamentFormat `json:"format"`
-	MaxParticipants     int              `json:"maxParticipants"`
-	RegistrationDeadline *time.Time      `json:"registrationDeadline"`
-	StartTime           *time.Time       `json:"startTime"`
-	Rules               string           `json:"rules"`
-	PrizePool            json.RawMessage `json:"prizePool,omitempty"` // <--- CHANGE THIS
-    CustomFields         json.RawMessage `json:"customFields,omitempty"`// Assuming this is also flexible JSON
+	Name                    string               `json:"name"`
+	Description             string               `json:"description"`
+	Game                    string               `json:"game"`
+	Format                  TournamentFormat     `json:"format"`
+	MaxParticipants         int                  `json:"maxParticipants"`
+	RegistrationDeadline    *time.Time           `json:"registrationDeadline"`
+	StartTime               *time.Time           `json:"startTime"`
+	Rules                   string               `json:"rules"`
+	PrizePool               json.RawMessage      `json:"prizePool,omitempty"`    // <--- CHANGE THIS
+	CustomFields            json.RawMessage      `json:"customFields,omitempty"` // Assuming this is also flexible JSON
+	GrandFinalsAdvantage    *int                 `json:"grandFinalsAdvantage,omitempty"`
+	ReportingWindowMinutes  *int                 `json:"reportingWindowMinutes,omitempty"`
+	ReportingDeadlinePolicy DeadlinePolicy       `json:"reportingDeadlinePolicy,omitempty"`
+	AllowCustomGame         bool                 `json:"allowCustomGame,omitempty"`
+	Visibility              TournamentVisibility `json:"visibility,omitempty"`
 }
 
 // TournamentResponse represents the data returned to clients
 type TournamentResponse struct {
-	ID                  uuid.UUID        `json:"id"`
-	Name                string           `json:"name"`
-	Description         string           `json:"description"`
-	Game                string           `json:"game"`
-	Format              TournamentFormat `json:"format"`
-	Status              TournamentStatus `json:"status"`
-	MaxParticipants     int              `json:"maxParticipants"`
-	CurrentParticipants int              `json:"currentParticipants"`
-	RegistrationDeadline *time.Time      `json:"registrationDeadline"`
-	StartTime           *time.Time       `json:"startTime"`
-	EndTime             *time.Time       `json:"endTime"`
-	CreatedAt           time.Time        `json:"createdAt"`
-	Rules               string           `json:"rules"`
-    PrizePool            json.RawMessage `json:"prizePool,omitempty"` // <--- CHANGE THIS
-    CustomFields         json.RawMessage `json:"customFields,omitempty"`// Assuming this is also flexible JSON
-	CreatedBy            uuid.UUID       `json:"createdBy"` 
+	ID                      uuid.UUID            `json:"id"`
+	Name                    string               `json:"name"`
+	Description             string               `json:"description"`
+	Game                    string               `json:"game"`
+	Format                  TournamentFormat     `json:"format"`
+	Status                  TournamentStatus     `json:"status"`
+	MaxParticipants         int                  `json:"maxParticipants"`
+	CurrentParticipants     int                  `json:"currentParticipants"`
+	RegistrationDeadline    *time.Time           `json:"registrationDeadline"`
+	StartTime               *time.Time           `json:"startTime"`
+	EndTime                 *time.Time           `json:"endTime"`
+	CreatedAt               time.Time            `json:"createdAt"`
+	Rules                   string               `json:"rules"`
+	PrizePool               json.RawMessage      `json:"prizePool,omitempty"`    // <--- CHANGE THIS
+	CustomFields            json.RawMessage      `json:"customFields,omitempty"` // Assuming this is also flexible JSON
+	GrandFinalsAdvantage    int                  `json:"grandFinalsAdvantage"`
+	ReportingWindowMinutes  int                  `json:"reportingWindowMinutes"`
+	ReportingDeadlinePolicy DeadlinePolicy       `json:"reportingDeadlinePolicy"`
+	CreatedBy               uuid.UUID            `json:"createdBy"`
+	Visibility              TournamentVisibility `json:"visibility"`
+	UnreadCount             *int                 `json:"unread_count,omitempty"` // Unread chat messages; only on GET /tournaments/:id for signed-in callers
 }
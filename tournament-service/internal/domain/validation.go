@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Limits on the size of a tournament
+const (
+	MinTournamentParticipants = 2
+	MaxTournamentParticipants = 1024
+)
+
+// ValidationError reports the request fields that failed validation, keyed by their JSON name
+type ValidationError struct {
+	Fields map[string]string `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	problems := make([]string, len(names))
+	for i, name := range names {
+		problems[i] = fmt.Sprintf("%s: %s", name, e.Fields[name])
+	}
+	return "invalid request: " + strings.Join(problems, "; ")
+}
+
+func (e *ValidationError) HTTPStatus() int { return http.StatusBadRequest }
+func (e *ValidationError) Unwrap() error   { return ErrValidation }
+
+// Details adds the per-field problems to error responses
+func (e *ValidationError) Details() map[string]interface{} {
+	return map[string]interface{}{"fields": e.Fields}
+}
+
+// add records a problem with a field, keeping the first one reported
+func (e *ValidationError) add(field, problem string) {
+	if _, exists := e.Fields[field]; !exists {
+		e.Fields[field] = problem
+	}
+}
+
+// orNil returns e when it holds any problems, and nil otherwise
+func (e *ValidationError) orNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// IsValidFormat reports whether f is a supported tournament format
+func IsValidFormat(f TournamentFormat) bool {
+	switch f {
+	case SingleElimination, DoubleElimination, RoundRobin, Swiss:
+		return true
+	}
+	return false
+}
+
+// IsValidStatus reports whether st is a known tournament status
+func IsValidStatus(st TournamentStatus) bool {
+	switch st {
+	case Draft, Registration, InProgress, Completed, Cancelled, Archived:
+		return true
+	}
+	return false
+}
+
+// IsValidBracketType reports whether bt is a known bracket type
+func IsValidBracketType(bt BracketType) bool {
+	switch bt {
+	case WinnersBracket, LosersBracket, GrandFinals:
+		return true
+	}
+	return false
+}
+
+// checkGame requires a supported game unless the request opts into a custom one
+func checkGame(verr *ValidationError, game string, allowCustom bool) {
+	if strings.TrimSpace(game) == "" {
+		verr.add("game", "is required")
+		return
+	}
+	if _, ok := CanonicalGameID(game); !ok && !allowCustom {
+		verr.add("game", fmt.Sprintf("unknown game %q; see GET /games or set allowCustomGame", game))
+	}
+}
+
+// checkVisibility rejects unknown visibilities; an empty one keeps the default or current value
+func checkVisibility(verr *ValidationError, visibility TournamentVisibility) {
+	switch visibility {
+	case "", VisibilityPublic, VisibilityUnlisted, VisibilityPrivate:
+		return
+	}
+	verr.add("visibility", fmt.Sprintf("unknown visibility %q", visibility))
+}
+
+// ValidateSchedule checks that registration closes before the tournament starts, when both are set
+func ValidateSchedule(registrationDeadline, startTime *time.Time) error {
+	verr := &ValidationError{Fields: map[string]string{}}
+	checkSchedule(verr, registrationDeadline, startTime)
+	return verr.orNil()
+}
+
+func checkSchedule(verr *ValidationError, registrationDeadline, startTime *time.Time) {
+	if registrationDeadline != nil && startTime != nil && !registrationDeadline.Before(*startTime) {
+		verr.add("registrationDeadline", "must be before startTime")
+	}
+}
+
+func checkMaxParticipants(verr *ValidationError, maxParticipants int) {
+	if maxParticipants < MinTournamentParticipants || maxParticipants > MaxTournamentParticipants {
+		verr.add("maxParticipants", fmt.Sprintf(
+			"must be between %d and %d", MinTournamentParticipants, MaxTournamentParticipants,
+		))
+	}
+}
+
+// checkPrizePool validates a structured prize pool; plain-text descriptions are left alone
+func checkPrizePool(verr *ValidationError, prizePool json.RawMessage) {
+	if _, err := ParsePrizePool(prizePool); err != nil {
+		verr.add("prizePool", err.Error())
+	}
+}
+
+// Validate checks a create request; an empty Format is allowed and defaults to single elimination
+func (r *CreateTournamentRequest) Validate() error {
+	verr := &ValidationError{Fields: map[string]string{}}
+	if strings.TrimSpace(r.Name) == "" {
+		verr.add("name", "is required")
+	}
+	checkGame(verr, r.Game, r.AllowCustomGame)
+	checkVisibility(verr, r.Visibility)
+	checkMaxParticipants(verr, r.MaxParticipants)
+	if r.Format != "" && !IsValidFormat(r.Format) {
+		verr.add("format", fmt.Sprintf("unknown format %q", r.Format))
+	}
+	checkSchedule(verr, r.RegistrationDeadline, r.StartTime)
+	checkPrizePool(verr, r.PrizePool)
+	return verr.orNil()
+}
+
+// Validate checks the fields an update request sets; zero values mean "leave unchanged"
+func (r *UpdateTournamentRequest) Validate() error {
+	verr := &ValidationError{Fields: map[string]string{}}
+	if r.Name != "" && strings.TrimSpace(r.Name) == "" {
+		verr.add("name", "cannot be blank")
+	}
+	if r.Game != "" {
+		checkGame(verr, r.Game, r.AllowCustomGame)
+	}
+	checkVisibility(verr, r.Visibility)
+	if r.MaxParticipants != 0 {
+		checkMaxParticipants(verr, r.MaxParticipants)
+	}
+	if r.Format != "" && !IsValidFormat(r.Format) {
+		verr.add("format", fmt.Sprintf("unknown format %q", r.Format))
+	}
+	checkSchedule(verr, r.RegistrationDeadline, r.StartTime)
+	checkPrizePool(verr, r.PrizePool)
+	return verr.orNil()
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCreateTournamentRequestValidate(t *testing.T) {
+	start := time.Now().Add(48 * time.Hour)
+	lateDeadline := start.Add(time.Hour)
+	valid := func() CreateTournamentRequest {
+		return CreateTournamentRequest{Name: "Spring Open", Game: "chess", MaxParticipants: 16}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *CreateTournamentRequest)
+		fields []string
+	}{
+		{"valid", func(r *CreateTournamentRequest) {}, nil},
+		{"blank name", func(r *CreateTournamentRequest) { r.Name = "   " }, []string{"name"}},
+		{"missing game", func(r *CreateTournamentRequest) { r.Game = "" }, []string{"game"}},
+		{"unknown game", func(r *CreateTournamentRequest) { r.Game = "pong" }, []string{"game"}},
+		{"custom game allowed", func(r *CreateTournamentRequest) { r.Game, r.AllowCustomGame = "pong", true }, nil},
+		{"too few players", func(r *CreateTournamentRequest) { r.MaxParticipants = 1 }, []string{"maxParticipants"}},
+		{"too many players", func(r *CreateTournamentRequest) { r.MaxParticipants = MaxTournamentParticipants + 1 }, []string{"maxParticipants"}},
+		{"unknown format", func(r *CreateTournamentRequest) { r.Format = "LADDER" }, []string{"format"}},
+		{"unknown visibility", func(r *CreateTournamentRequest) { r.Visibility = "SECRET" }, []string{"visibility"}},
+		{"prize pool split", func(r *CreateTournamentRequest) {
+			r.PrizePool = json.RawMessage(`{"amount": 500, "currency": "EUR", "placements": {"1": 70, "2": 30}}`)
+		}, nil},
+		{"prize pool described in text", func(r *CreateTournamentRequest) { r.PrizePool = json.RawMessage(`"Medals"`) }, nil},
+		{"prize pool not adding up", func(r *CreateTournamentRequest) {
+			r.PrizePool = json.RawMessage(`{"amount": 500, "currency": "EUR", "placements": {"1": 70, "2": 20}}`)
+		}, []string{"prizePool"}},
+		{"deadline after start", func(r *CreateTournamentRequest) {
+			r.StartTime, r.RegistrationDeadline = &start, &lateDeadline
+		}, []string{"registrationDeadline"}},
+		{"several problems", func(r *CreateTournamentRequest) {
+			r.Name, r.MaxParticipants, r.Format = "", 0, "LADDER"
+		}, []string{"name", "maxParticipants", "format"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := valid()
+			tt.modify(&request)
+			err := request.Validate()
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) || !errors.Is(err, ErrValidation) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+			if len(verr.Fields) != len(tt.fields) {
+				t.Fatalf("expected problems with %v, got %v", tt.fields, verr.Fields)
+			}
+			for _, field := range tt.fields {
+				if verr.Fields[field] == "" {
+					t.Errorf("expected a problem with %s, got %v", field, verr.Fields)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateTournamentRequestValidateLeavesUnsetFieldsAlone(t *testing.T) {
+	if err := (&UpdateTournamentRequest{}).Validate(); err != nil {
+		t.Fatalf("an empty update changes nothing and is valid: %v", err)
+	}
+
+	err := (&UpdateTournamentRequest{Name: " ", MaxParticipants: 1, Game: "pong"}).Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Fields) != 3 {
+		t.Fatalf("expected name, maxParticipants and game problems, got %v", err)
+	}
+}
+
+func TestValidationErrorIsDeterministic(t *testing.T) {
+	verr := &ValidationError{Fields: map[string]string{"name": "is required", "game": "is required"}}
+	if got := verr.Error(); got != "invalid request: game: is required; name: is required" {
+		t.Fatalf("unexpected message %q", got)
+	}
+	encoded, _ := json.Marshal(verr.Details())
+	if string(encoded) != `{"fields":{"game":"is required","name":"is required"}}` {
+		t.Fatalf("unexpected details %s", encoded)
+	}
+	if verr.HTTPStatus() != 400 {
+		t.Fatalf("expected 400, got %d", verr.HTTPStatus())
+	}
+}
+
+func TestIsValidStatus(t *testing.T) {
+	for _, status := range []TournamentStatus{Draft, Registration, InProgress, Completed, Cancelled, Archived} {
+		if !IsValidStatus(status) {
+			t.Errorf("%s should be valid", status)
+		}
+	}
+	if IsValidStatus("registration") || IsValidStatus("") {
+		t.Error("statuses are matched exactly")
+	}
+}
+
+func TestIsValidBracketType(t *testing.T) {
+	for _, bracketType := range []BracketType{WinnersBracket, LosersBracket, GrandFinals} {
+		if !IsValidBracketType(bracketType) {
+			t.Errorf("%s should be valid", bracketType)
+		}
+	}
+	if IsValidBracketType("losers") || IsValidBracketType("") {
+		t.Error("bracket types are matched exactly")
+	}
+}
@@ -1,6 +1,8 @@
 package domain
 
-import (// You'll likely need this for timestamps in payloads
+import ( // You'll likely need this for timestamps in payloads
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -9,10 +11,17 @@ type WebSocketEventType string
 
 // Define constants for different event types
 const (
-	WSEventMatchScoreUpdated    WebSocketEventType = "MATCH_SCORE_UPDATED"
-	WSEventParticipantJoined    WebSocketEventType = "PARTICIPANT_JOINED"
-	WSEventTournamentCreated    WebSocketEventType = "TOURNAMENT_CREATED" // Example
-	WSEventNewUserActivity      WebSocketEventType = "NEW_USER_ACTIVITY"
+	WSEventMatchScoreUpdated   WebSocketEventType = "MATCH_SCORE_UPDATED"
+	WSEventParticipantJoined   WebSocketEventType = "PARTICIPANT_JOINED"
+	WSEventTournamentCreated   WebSocketEventType = "TOURNAMENT_CREATED" // Example
+	WSEventNewUserActivity     WebSocketEventType = "NEW_USER_ACTIVITY"
+	WSEventMatchMessagePosted  WebSocketEventType = "MATCH_MESSAGE_POSTED"
+	WSEventMatchStale          WebSocketEventType = "MATCH_STALE"
+	WSEventMatchDeadlinePassed WebSocketEventType = "MATCH_DEADLINE_PASSED"
+	WSEventMatchScheduled      WebSocketEventType = "MATCH_SCHEDULED"
+	WSEventTournamentCompleted WebSocketEventType = "TOURNAMENT_COMPLETED"
+	WSEventTournamentStarted   WebSocketEventType = "TOURNAMENT_STARTED"
+	WSEventChatMessage         WebSocketEventType = "CHAT_MESSAGE_POSTED"
 	// Add more event types as needed: TOURNAMENT_STATUS_CHANGED, NEW_MESSAGE, etc.
 )
 
@@ -20,6 +29,16 @@ const (
 type WebSocketMessage struct {
 	Type    WebSocketEventType `json:"type"`
 	Payload interface{}        `json:"payload"` // Allows different payload structures
+	// TournamentID, when set, limits delivery to clients subscribed to that tournament;
+	// other events go to every client
+	TournamentID *uuid.UUID `json:"-"`
+}
+
+// WebSocketSubscription is sent by clients to follow or stop following a tournament's
+// scoped events, e.g. {"type": "SUBSCRIBE", "tournament_id": "..."}
+type WebSocketSubscription struct {
+	Type         string    `json:"type"` // SUBSCRIBE or UNSUBSCRIBE
+	TournamentID uuid.UUID `json:"tournament_id"`
 }
 
 // --- Specific Payload Structs ---
@@ -32,8 +51,8 @@ type MatchScoreUpdatedPayload struct {
 	Participant2ID    *uuid.UUID  `json:"participant2_id,omitempty"` // Participant.ID
 	ScoreParticipant1 int         `json:"score_participant1"`
 	ScoreParticipant2 int         `json:"score_participant2"`
-	WinnerID          *uuid.UUID  `json:"winner_id,omitempty"`       // Participant.ID of winner
-	Status            MatchStatus `json:"status"`                    // e.g., COMPLETED
+	WinnerID          *uuid.UUID  `json:"winner_id,omitempty"` // Participant.ID of winner
+	Status            MatchStatus `json:"status"`              // e.g., COMPLETED
 	// Optional: For direct UI update without re-fetching participant details
 	// Participant1Name  string `json:"participant1_name,omitempty"`
 	// Participant2Name  string `json:"participant2_name,omitempty"`
@@ -41,18 +60,70 @@ type MatchScoreUpdatedPayload struct {
 
 // ParticipantJoinedPayload contains data for when a new participant joins
 type ParticipantJoinedPayload struct {
-	TournamentID    uuid.UUID           `json:"tournament_id"`
-	Participant     ParticipantResponse `json:"participant"` // Your existing ParticipantResponse
-	ParticipantCount int                `json:"participant_count"`
+	TournamentID     uuid.UUID           `json:"tournament_id"`
+	Participant      ParticipantResponse `json:"participant"` // Your existing ParticipantResponse
+	ParticipantCount int                 `json:"participant_count"`
 }
 
 // NewUserActivityPayload contains the newly created user activity
 type NewUserActivityPayload struct {
-	Activity UserActivity `json:"activity"` // Your existing domain.UserActivity
-	ForUserID uuid.UUID `json:"for_user_id"` // The UserID this activity is for (so frontend can filter)
+	Activity  UserActivity `json:"activity"`    // Your existing domain.UserActivity
+	ForUserID uuid.UUID    `json:"for_user_id"` // The UserID this activity is for (so frontend can filter)
 }
 
 // TournamentCreatedPayload (Example)
 type TournamentCreatedPayload struct {
 	Tournament TournamentResponse `json:"tournament"` // Your existing domain.TournamentResponse
-}
\ No newline at end of file
+}
+
+// MatchMessagePostedPayload contains a new message in a match's chat thread.
+// Clients filter on MatchID to show it only in that match's thread.
+type MatchMessagePostedPayload struct {
+	TournamentID uuid.UUID       `json:"tournament_id"`
+	MatchID      uuid.UUID       `json:"match_id"`
+	Message      MessageResponse `json:"message"`
+}
+
+// ChatMessagePayload contains a new message in a tournament's chat. It is only delivered to
+// clients subscribed to the tournament; Message carries the author's username and CreatedAt.
+type ChatMessagePayload struct {
+	TournamentID uuid.UUID       `json:"tournament_id"`
+	Message      MessageResponse `json:"message"`
+}
+
+// MatchStalePayload flags a match that has gone unreported for too long
+type MatchStalePayload struct {
+	TournamentID  uuid.UUID `json:"tournament_id"`
+	MatchID       uuid.UUID `json:"match_id"`
+	IdleMinutes   int       `json:"idle_minutes"`
+	AutoForfeited bool      `json:"auto_forfeited"`
+}
+
+// MatchDeadlinePassedPayload reports a match whose result was not reported before its deadline
+type MatchDeadlinePassedPayload struct {
+	TournamentID uuid.UUID      `json:"tournament_id"`
+	MatchID      uuid.UUID      `json:"match_id"`
+	Deadline     time.Time      `json:"deadline"`
+	Policy       DeadlinePolicy `json:"policy"` // FLAG, DOUBLE_FORFEIT or COIN_FLIP
+}
+
+// MatchScheduledPayload announces a match's new scheduled time; a nil ScheduledTime means it was unscheduled
+type MatchScheduledPayload struct {
+	TournamentID  uuid.UUID  `json:"tournament_id"`
+	MatchID       uuid.UUID  `json:"match_id"`
+	ScheduledTime *time.Time `json:"scheduled_time"`
+}
+
+// TournamentStartedPayload announces a tournament whose bracket was generated and play has begun
+type TournamentStartedPayload struct {
+	TournamentID uuid.UUID `json:"tournament_id"`
+	MatchCount   int       `json:"match_count"`
+	AutoStarted  bool      `json:"auto_started"` // Started by the scheduler at its start time rather than by the organizer
+}
+
+// TournamentCompletedPayload announces a finished tournament; Champion is omitted for formats without a final
+type TournamentCompletedPayload struct {
+	TournamentID uuid.UUID            `json:"tournament_id"`
+	Champion     *ParticipantResponse `json:"champion,omitempty"`
+	EndTime      *time.Time           `json:"end_time,omitempty"`
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+const (
+	// internalErrorMessage replaces the message of unclassified errors in release mode
+	internalErrorMessage = "Internal server error"
+	timeoutMessage       = "A dependency did not respond in time"
+	// queryCanceledCode is the Postgres error code for statements cancelled by statement_timeout
+	queryCanceledCode = "57014"
+)
+
+// RespondError writes err as a JSON error response. Errors implementing domain.Error get
+// their own status and message, plus any domain.ErrorDetails fields; everything else is
+// logged and returned as a 500 whose message is hidden when gin runs in release mode
+// (GIN_MODE=release), since it may contain database or network details. Calls to other
+// services or the database that ran out of time return 504.
+func RespondError(c *gin.Context, err error) {
+	var clientErr domain.Error
+	if errors.As(err, &clientErr) {
+		body := gin.H{"error": clientErr.Error()}
+		var detailed domain.ErrorDetails
+		if errors.As(clientErr, &detailed) {
+			for key, value := range detailed.Details() {
+				body[key] = value
+			}
+		}
+		c.JSON(clientErr.HTTPStatus(), body)
+		return
+	}
+
+	if isTimeout(err) {
+		logging.Warnf(c.Request.Context(), "[%s %s] timed out: %v", c.Request.Method, c.FullPath(), err)
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": timeoutMessage})
+		return
+	}
+
+	logging.Errorf(c.Request.Context(), "[%s %s] internal error: %v", c.Request.Method, c.FullPath(), err)
+	if gin.Mode() == gin.ReleaseMode {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": internalErrorMessage})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// isTimeout reports whether err comes from an outbound call or query that hit its deadline
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == queryCanceledCode
+}
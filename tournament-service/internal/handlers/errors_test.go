@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+// respond runs RespondError for err and returns the recorded response
+func respond(t *testing.T, err error) (int, map[string]interface{}) {
+	t.Helper()
+	return respondIn(t, gin.TestMode, err)
+}
+
+// respondIn is respond with gin running in mode
+func respondIn(t *testing.T, mode string, err error) (int, map[string]interface{}) {
+	t.Helper()
+	previous := gin.Mode()
+	gin.SetMode(mode)
+	defer gin.SetMode(previous)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	RespondError(c, err)
+
+	var body map[string]interface{}
+	if decodeErr := json.Unmarshal(recorder.Body.Bytes(), &body); decodeErr != nil {
+		t.Fatalf("response is not JSON: %v", decodeErr)
+	}
+	return recorder.Code, body
+}
+
+func TestRespondErrorMapsRegistrationErrors(t *testing.T) {
+	status, body := respond(t, domain.ErrAlreadyParticipant)
+	if status != http.StatusConflict {
+		t.Fatalf("duplicate registration: expected 409, got %d", status)
+	}
+	if body["error"] != domain.ErrAlreadyParticipant.Error() {
+		t.Fatalf("unexpected message %q", body["error"])
+	}
+
+	// Wrapping keeps the classification
+	if status, _ := respond(t, fmt.Errorf("register: %w", domain.ErrAlreadyParticipant)); status != http.StatusConflict {
+		t.Fatalf("wrapped duplicate registration: expected 409, got %d", status)
+	}
+	if status, _ := respond(t, domain.NewError(domain.ErrNotFound, "match not found")); status != http.StatusNotFound {
+		t.Fatalf("not found: expected 404, got %d", status)
+	}
+}
+
+func TestRespondErrorIncludesValidationFields(t *testing.T) {
+	err := (&domain.CreateTournamentRequest{Game: "chess", MaxParticipants: 16}).Validate()
+
+	status, body := respond(t, fmt.Errorf("create: %w", err))
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", status)
+	}
+	fields, ok := body["fields"].(map[string]interface{})
+	if !ok || fields["name"] != "is required" {
+		t.Fatalf("expected the per-field problems in the body, got %v", body)
+	}
+}
+
+func TestRespondErrorMapsEachKind(t *testing.T) {
+	for kind, want := range map[*domain.ErrorKind]int{
+		domain.ErrNotFound:        http.StatusNotFound,
+		domain.ErrConflict:        http.StatusConflict,
+		domain.ErrValidation:      http.StatusBadRequest,
+		domain.ErrForbidden:       http.StatusForbidden,
+		domain.ErrUnauthenticated: http.StatusUnauthorized,
+		domain.ErrUnavailable:     http.StatusServiceUnavailable,
+		domain.ErrTooManyRequests: http.StatusTooManyRequests,
+	} {
+		// Classified messages are meant for clients, so release mode shows them too
+		status, body := respondIn(t, gin.ReleaseMode, fmt.Errorf("service: %w", domain.NewError(kind, "safe message")))
+		if status != want || body["error"] != "safe message" {
+			t.Errorf("%s: expected %d with the message, got %d and %v", kind, want, status, body)
+		}
+	}
+}
+
+func TestRespondErrorHidesInternalErrorsInRelease(t *testing.T) {
+	internal := errors.New("pq: relation \"tournaments\" does not exist")
+
+	status, body := respondIn(t, gin.ReleaseMode, internal)
+	if status != http.StatusInternalServerError || body["error"] != internalErrorMessage {
+		t.Fatalf("release: expected 500 with a generic message, got %d and %v", status, body)
+	}
+	// Outside release mode the message helps debugging
+	if _, body := respond(t, internal); body["error"] != internal.Error() {
+		t.Fatalf("debug: expected the internal message, got %v", body)
+	}
+}
+
+func TestRespondErrorMapsTimeouts(t *testing.T) {
+	for name, err := range map[string]error{
+		"deadline":          fmt.Errorf("call ranking service: %w", context.DeadlineExceeded),
+		"statement timeout": fmt.Errorf("list matches: %w", &pq.Error{Code: queryCanceledCode, Message: "canceling statement"}),
+	} {
+		status, body := respondIn(t, gin.ReleaseMode, err)
+		if status != http.StatusGatewayTimeout || body["error"] != timeoutMessage {
+			t.Errorf("%s: expected 504 with a generic message, got %d and %v", name, status, body)
+		}
+	}
+}
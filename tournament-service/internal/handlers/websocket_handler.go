@@ -7,6 +7,7 @@ import (
 
 	"github.com/cliffdoyle/tournament-service/internal/websocket" // Your hub package
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	gwebsocket "github.com/gorilla/websocket" // Renamed to avoid conflict with your package
 )
 
@@ -29,8 +30,20 @@ var upgrader = gwebsocket.Upgrader{
 	},
 }
 
-// ServeWs handles websocket requests from the peer.
+// ServeWs handles websocket requests from the peer. Each ?tournamentId= subscribes the
+// connection to that tournament's scoped events, such as its chat; clients can also
+// subscribe later by sending {"type": "SUBSCRIBE", "tournament_id": "..."}.
 func ServeWs(hub *websocket.Hub, c *gin.Context) {
+	var tournamentIDs []uuid.UUID
+	for _, raw := range c.QueryArray("tournamentId") {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+			return
+		}
+		tournamentIDs = append(tournamentIDs, id)
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Println("WebSocket upgrade failed:", err)
@@ -40,11 +53,10 @@ func ServeWs(hub *websocket.Hub, c *gin.Context) {
 	log.Printf("WebSocket connection established from: %s", conn.RemoteAddr())
 
 	// Create a new client
-	client := &websocket.Client{Conn: conn, Send: make(chan []byte, 256)} // Buffered channel
+	client := websocket.NewClient(conn, tournamentIDs...)
 	hub.Register(client) // Register client with the hub
 
 	// Allow collection of memory referenced by the caller by executing them in new goroutines.
 	go client.WritePump()
 	go client.ReadPump(hub) // Pass hub to ReadPump for unregistering
 }
-
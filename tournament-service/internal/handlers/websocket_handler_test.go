@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/websocket"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	gwebsocket "github.com/gorilla/websocket"
+)
+
+// wsServer serves ServeWs for hub
+func wsServer(hub *websocket.Hub) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", func(c *gin.Context) { ServeWs(hub, c) })
+	return httptest.NewServer(router)
+}
+
+func TestServeWsSubscribesToTheRequestedTournaments(t *testing.T) {
+	hub := websocket.NewHub()
+	go hub.Run()
+	server := wsServer(hub)
+	defer server.Close()
+	tournamentID := uuid.New()
+
+	conn, _, err := gwebsocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws?tournamentId="+tournamentID.String(), nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	received := make(chan error, 1)
+	go func() {
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		var message domain.WebSocketMessage
+		err := conn.ReadJSON(&message)
+		if err == nil && message.Type != domain.WSEventChatMessage {
+			err = fmt.Errorf("unexpected event %s", message.Type)
+		}
+		received <- err
+	}()
+	// The client may be registered with the hub only after Dial returns, so keep broadcasting
+	// until it receives the event
+	for {
+		select {
+		case err := <-received:
+			if err != nil {
+				t.Fatalf("expected the tournament's chat event: %v", err)
+			}
+			return
+		case hub.Broadcast <- domain.WebSocketMessage{Type: domain.WSEventChatMessage, TournamentID: &tournamentID}:
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func TestServeWsRejectsBadTournamentIDs(t *testing.T) {
+	server := wsServer(websocket.NewHub())
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/ws?tournamentId=nope")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", response.StatusCode)
+	}
+}
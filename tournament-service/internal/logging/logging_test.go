@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+// capture makes a JSON logger writing to a buffer the default for the duration of the test
+func capture(t *testing.T, level slog.Level) *bytes.Buffer {
+	var out bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(NewHandler(&out, level)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &out
+}
+
+func TestLogsCarryTheRequestID(t *testing.T) {
+	out := capture(t, slog.LevelInfo)
+	ctx := WithRequestID(context.Background(), "req-42")
+
+	Infof(ctx, "reported %d", 3)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("log line is not JSON: %v (%s)", err, out.String())
+	}
+	if record["request_id"] != "req-42" || record["msg"] != "reported 3" || record["level"] != "INFO" {
+		t.Fatalf("unexpected record %v", record)
+	}
+	if RequestID(context.Background()) != "" {
+		t.Fatal("a context without a request ID should report none")
+	}
+}
+
+func TestDebugLogsAreGatedByLevel(t *testing.T) {
+	out := capture(t, slog.LevelInfo)
+	Debugf(context.Background(), "raw body %s", "{}")
+	if out.Len() != 0 {
+		t.Fatalf("debug output leaked at info level: %s", out.String())
+	}
+
+	out = capture(t, ParseLevel("DEBUG"))
+	Debugf(context.Background(), "raw body %s", "{}")
+	if out.Len() == 0 {
+		t.Fatal("expected debug output at debug level")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, want := range map[string]slog.Level{
+		"debug": slog.LevelDebug, " Warn ": slog.LevelWarn, "warning": slog.LevelWarn,
+		"error": slog.LevelError, "": slog.LevelInfo, "verbose": slog.LevelInfo,
+	} {
+		if got := ParseLevel(name); got != want {
+			t.Errorf("ParseLevel(%q) = %s, want %s", name, got, want)
+		}
+	}
+}
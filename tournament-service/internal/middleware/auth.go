@@ -10,6 +10,16 @@ import (
 	"github.com/google/uuid"
 )
 
+// tokenClaims mirrors the claims the user service signs in utils.GenerateToken
+type tokenClaims struct {
+	Username string    `json:"username"`
+	UserID   uuid.UUID `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// AuthMiddleware verifies the platform JWT locally with the shared JWT_SECRET, so handlers
+// do not need to ask the user service. It sets "userID" (uuid.UUID) and "username" in the
+// context and rejects missing, tampered or expired tokens with 401.
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the Authorization header
@@ -20,50 +30,76 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Check if the header has the Bearer prefix
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+		claims, problem := parseToken(authHeader)
+		if problem != "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": problem})
 			c.Abort()
 			return
 		}
 
-		tokenString := parts[1]
+		// Add user info to context
+		c.Set("userID", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Next() // Token is valid, claims extracted, proceed to the next handler
+	}
+}
 
-		// Parse and validate the token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Validate signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
+// OptionalAuthMiddleware sets "userID" and "username" like AuthMiddleware when a valid
+// token is sent, but lets anonymous or badly authenticated requests through untouched.
+// Public routes use it to grant extra rights to callers they can identify.
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+			if claims, problem := parseToken(authHeader); problem == "" {
+				c.Set("userID", claims.UserID)
+				c.Set("username", claims.Username)
 			}
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
-
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
-			return
 		}
+		c.Next()
+	}
+}
 
-		// Extract claims
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			// Add user info to context
-			c.Set("username", claims["username"])
-			if userId, exists := claims["user_id"].(string); exists {
-				parsedUserID,uuidErr:=uuid.Parse(userId)//pass the string
-				  if uuidErr != nil {
-        // Handle error: token has malformed user_id
-        c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user identifier in token"})
-        c.Abort()
-        return
-    }
-				c.Set("userID", parsedUserID)
-			}
-			c.Next()//Token is valid,claims extracted,proceed to the next handler
-		} else {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			c.Abort()
+// parseToken validates a "Bearer <jwt>" header and returns its claims, or the reason
+// the token was rejected
+func parseToken(authHeader string) (*tokenClaims, string) {
+	// Check if the header has the Bearer prefix
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, "Invalid authorization header format"
+	}
+
+	tokenString := parts[1]
+
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		// An empty key would accept tokens signed by anyone who guesses it
+		return nil, "Token verification is not configured"
+	}
+
+	// Parse and validate the token; the user service signs with HS256 and always sets an expiry
+	claims := &tokenClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return nil, "Invalid token"
+	}
+
+	if claims.UserID == uuid.Nil {
+		return nil, "Invalid user identifier in token"
+	}
+	return claims, ""
+}
+
+// RequireInternalServiceKey rejects requests whose X-Internal-Service-Key header does not match
+// INTERNAL_SERVICE_KEY; with no key configured every request is rejected
+func RequireInternalServiceKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := os.Getenv("INTERNAL_SERVICE_KEY")
+		if key == "" || c.GetHeader("X-Internal-Service-Key") != key {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "A valid X-Internal-Service-Key header is required"})
 			return
 		}
+		c.Next()
 	}
 }
@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const testSecret = "test-secret"
+
+// signedToken signs claims the way the user service does
+func signedToken(t *testing.T, method jwt.SigningMethod, secret string, userID uuid.UUID, expires time.Time) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, tokenClaims{
+		Username:         "ace",
+		UserID:           userID,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expires)},
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+// authenticate runs a request with header through handler and returns the status and the
+// user ID the handler saw, if any
+func authenticate(handler gin.HandlerFunc, header string) (int, interface{}) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var seen interface{}
+	router.GET("/", handler, func(c *gin.Context) {
+		seen, _ = c.Get("userID")
+		c.Status(http.StatusOK)
+	})
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		request.Header.Set("Authorization", header)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder.Code, seen
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	t.Setenv("JWT_SECRET", testSecret)
+	userID := uuid.New()
+	valid := signedToken(t, jwt.SigningMethodHS256, testSecret, userID, time.Now().Add(time.Hour))
+
+	status, seen := authenticate(AuthMiddleware(), "Bearer "+valid)
+	if status != http.StatusOK || seen != userID {
+		t.Fatalf("valid token: expected 200 with the user ID set, got %d and %v", status, seen)
+	}
+
+	for name, header := range map[string]string{
+		"missing":      "",
+		"not bearer":   "Token " + valid,
+		"expired":      "Bearer " + signedToken(t, jwt.SigningMethodHS256, testSecret, userID, time.Now().Add(-time.Minute)),
+		"tampered":     "Bearer " + valid[:len(valid)-2] + "xx",
+		"other secret": "Bearer " + signedToken(t, jwt.SigningMethodHS256, "guessed", userID, time.Now().Add(time.Hour)),
+		"other method": "Bearer " + signedToken(t, jwt.SigningMethodHS512, testSecret, userID, time.Now().Add(time.Hour)),
+		"no user":      "Bearer " + signedToken(t, jwt.SigningMethodHS256, testSecret, uuid.Nil, time.Now().Add(time.Hour)),
+	} {
+		if status, _ := authenticate(AuthMiddleware(), header); status != http.StatusUnauthorized {
+			t.Errorf("%s token: expected 401, got %d", name, status)
+		}
+	}
+}
+
+func TestAuthMiddlewareWithoutSecretRejectsEverything(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+	token := signedToken(t, jwt.SigningMethodHS256, "", uuid.New(), time.Now().Add(time.Hour))
+
+	if status, _ := authenticate(AuthMiddleware(), "Bearer "+token); status != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a configured secret, got %d", status)
+	}
+}
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	t.Setenv("JWT_SECRET", testSecret)
+	userID := uuid.New()
+
+	status, seen := authenticate(OptionalAuthMiddleware(), "Bearer "+signedToken(t, jwt.SigningMethodHS256, testSecret, userID, time.Now().Add(time.Hour)))
+	if status != http.StatusOK || seen != userID {
+		t.Fatalf("valid token: expected the user ID to be set, got %d and %v", status, seen)
+	}
+	for _, header := range []string{"", "Bearer garbage"} {
+		if status, seen := authenticate(OptionalAuthMiddleware(), header); status != http.StatusOK || seen != nil {
+			t.Errorf("%q: expected an anonymous 200, got %d and %v", header, status, seen)
+		}
+	}
+}
+
+// withServiceKey runs a request carrying key as X-Internal-Service-Key through
+// RequireInternalServiceKey and returns the status
+func withServiceKey(key string) int {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", RequireInternalServiceKey(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	if key != "" {
+		request.Header.Set("X-Internal-Service-Key", key)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder.Code
+}
+
+func TestRequireInternalServiceKey(t *testing.T) {
+	t.Setenv("INTERNAL_SERVICE_KEY", "internal-key")
+	if status := withServiceKey("internal-key"); status != http.StatusOK {
+		t.Fatalf("matching key: expected 200, got %d", status)
+	}
+	for _, key := range []string{"", "other-key"} {
+		if status := withServiceKey(key); status != http.StatusForbidden {
+			t.Errorf("%q: expected 403, got %d", key, status)
+		}
+	}
+
+	t.Setenv("INTERNAL_SERVICE_KEY", "")
+	if status := withServiceKey(""); status != http.StatusForbidden {
+		t.Fatalf("without a configured key: expected 403, got %d", status)
+	}
+}
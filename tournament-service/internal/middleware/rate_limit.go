@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tokenBucket holds the remaining allowance for one key
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// RateLimiter is a token-bucket limiter keyed by an arbitrary string.
+// Each key may burst up to limit requests and regains limit tokens per window.
+type RateLimiter struct {
+	mu      sync.Mutex
+	limit   float64
+	window  time.Duration
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+// NewRateLimiter creates a limiter allowing limit requests per window for each key
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   float64(limit),
+		window:  window,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token for key if one is available. When it is not, it returns how long
+// until the next token becomes available.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	refillRate := l.limit / l.window.Seconds() // tokens per second
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.limit, lastSeen: now}
+		l.buckets[key] = bucket
+	} else {
+		elapsed := now.Sub(bucket.lastSeen).Seconds()
+		bucket.tokens = math.Min(l.limit, bucket.tokens+elapsed*refillRate)
+		bucket.lastSeen = now
+	}
+
+	// Forget idle keys once they are full again so the map does not grow without bound
+	for k, b := range l.buckets {
+		if k != key && now.Sub(b.lastSeen) > l.window {
+			delete(l.buckets, k)
+		}
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / refillRate * float64(time.Second))
+	return false, wait
+}
+
+// ChatRateLimitMiddleware limits how fast a user can post to a single tournament's chat.
+// It must run after AuthMiddleware so that "userID" is set.
+func ChatRateLimitMiddleware(limiter *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.Next()
+			return
+		}
+		key := fmt.Sprintf("%v:%s", userID, c.Param("tournamentId"))
+
+		allowed, retryAfter := limiter.Allow(key)
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "You are sending messages too quickly. Please wait before sending another."})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
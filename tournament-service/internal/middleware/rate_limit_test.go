@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeClock is a settable time source for the limiter
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestLimiter(limit int, window time.Duration) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter(limit, window)
+	limiter.now = clock.Now
+	return limiter, clock
+}
+
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	limiter, clock := newTestLimiter(3, 30*time.Second)
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := limiter.Allow("user:chat"); !ok {
+			t.Fatalf("request %d within the burst was refused", i+1)
+		}
+	}
+	ok, wait := limiter.Allow("user:chat")
+	if ok {
+		t.Fatal("the request after the burst should be refused")
+	}
+	// 3 tokens per 30s is one every 10s
+	if wait != 10*time.Second {
+		t.Fatalf("expected to wait 10s for the next token, got %s", wait)
+	}
+
+	clock.now = clock.now.Add(10 * time.Second)
+	if ok, _ := limiter.Allow("user:chat"); !ok {
+		t.Fatal("a token should be available again after 10s")
+	}
+	if ok, _ := limiter.Allow("user:chat"); ok {
+		t.Fatal("only one token should have been refilled")
+	}
+}
+
+func TestRateLimiterKeysAreIndependent(t *testing.T) {
+	limiter, _ := newTestLimiter(1, time.Minute)
+
+	if ok, _ := limiter.Allow("alice:t1"); !ok {
+		t.Fatal("first request refused")
+	}
+	if ok, _ := limiter.Allow("alice:t1"); ok {
+		t.Fatal("second request for the same key should be refused")
+	}
+	for _, key := range []string{"bob:t1", "alice:t2"} {
+		if ok, _ := limiter.Allow(key); !ok {
+			t.Fatalf("%s was limited by another key's usage", key)
+		}
+	}
+}
+
+func TestChatRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter, _ := newTestLimiter(1, 20*time.Second)
+	router := gin.New()
+	router.POST("/tournaments/:tournamentId/messages", func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Next()
+	}, ChatRateLimitMiddleware(limiter), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	post := func(tournamentID string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/tournaments/"+tournamentID+"/messages", nil))
+		return recorder
+	}
+
+	if recorder := post("t1"); recorder.Code != http.StatusCreated {
+		t.Fatalf("first message: expected 201, got %d", recorder.Code)
+	}
+	recorder := post("t1")
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Fatalf("second message: expected 429, got %d", recorder.Code)
+	}
+	if got := recorder.Header().Get("Retry-After"); got != "20" {
+		t.Fatalf("expected Retry-After: 20, got %q", got)
+	}
+	if recorder := post("t2"); recorder.Code != http.StatusCreated {
+		t.Fatalf("another tournament's chat should not be limited, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestLoggerTagsResponseAndLogs(t *testing.T) {
+	var out bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(logging.NewHandler(&out, slog.LevelInfo)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestLogger())
+	var seen string
+	router.GET("/tournaments", func(c *gin.Context) {
+		seen = logging.RequestID(c.Request.Context())
+		c.Status(http.StatusTeapot)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/tournaments", nil))
+
+	requestID := recorder.Header().Get(RequestIDHeader)
+	if requestID == "" || seen != requestID {
+		t.Fatalf("expected the handler to see the ID sent back in the header, got %q and %q", seen, requestID)
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("log line is not JSON: %v (%s)", err, out.String())
+	}
+	if record["request_id"] != requestID || record["path"] != "/tournaments" || record["status"] != float64(http.StatusTeapot) {
+		t.Fatalf("unexpected request log %v", record)
+	}
+}
+
+func TestRequestLoggerKeepsTheCallersID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestLogger())
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(RequestIDHeader, "from-gateway")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if got := recorder.Header().Get(RequestIDHeader); got != "from-gateway" {
+		t.Fatalf("expected the caller's request ID to be kept, got %q", got)
+	}
+}
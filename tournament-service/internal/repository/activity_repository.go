@@ -12,7 +12,8 @@ import (
 
 type UserActivityRepository interface {
 	Create(ctx context.Context, activity *domain.UserActivity) error
-	GetByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*domain.UserActivity, int, error)
+	// GetByUserID lists a user's activities, newest first; an empty activityType matches every type
+	GetByUserID(ctx context.Context, userID uuid.UUID, activityType domain.ActivityType, limit int, offset int) ([]*domain.UserActivity, int, error)
 }
 
 type userActivityRepository struct {
@@ -41,12 +42,13 @@ func (r *userActivityRepository) Create(ctx context.Context, activity *domain.Us
 	return nil
 }
 
-func (r *userActivityRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*domain.UserActivity, int, error) {
-	var activities []*domain.UserActivity
+func (r *userActivityRepository) GetByUserID(ctx context.Context, userID uuid.UUID, activityType domain.ActivityType, limit int, offset int) ([]*domain.UserActivity, int, error) {
+	activities := []*domain.UserActivity{}
 	var total int
 
-	countQuery := "SELECT COUNT(*) FROM user_activities WHERE user_id = $1"
-	err := r.db.QueryRowContext(ctx, countQuery, userID).Scan(&total)
+	// $2 is the optional type filter; an empty string leaves it out
+	countQuery := "SELECT COUNT(*) FROM user_activities WHERE user_id = $1 AND ($2 = '' OR activity_type = $2)"
+	err := r.db.QueryRowContext(ctx, countQuery, userID, string(activityType)).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count user activities: %w", err)
 	}
@@ -54,10 +56,10 @@ func (r *userActivityRepository) GetByUserID(ctx context.Context, userID uuid.UU
 	query := `SELECT id, user_id, activity_type, description, 
 	                 related_entity_id, related_entity_type, context_url, created_at 
 	          FROM user_activities
-	          WHERE user_id = $1
+	          WHERE user_id = $1 AND ($2 = '' OR activity_type = $2)
 	          ORDER BY created_at DESC
-	          LIMIT $2 OFFSET $3`
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	          LIMIT $3 OFFSET $4`
+	rows, err := r.db.QueryContext(ctx, query, userID, string(activityType), limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to query user activities: %w", err)
 	}
@@ -99,4 +101,4 @@ func (r *userActivityRepository) GetByUserID(ctx context.Context, userID uuid.UU
 	}
 
 	return activities, total, nil
-}
\ No newline at end of file
+}
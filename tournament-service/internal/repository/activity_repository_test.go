@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestGetActivitiesByUserFiltersByType(t *testing.T) {
+	userID := uuid.New()
+	for _, activityType := range []domain.ActivityType{
+		"", domain.ActivityTournamentCreated, domain.ActivityTournamentJoined, domain.ActivityMatchWon, domain.ActivityMatchLost,
+	} {
+		var countArgs, listArgs []driver.NamedValue
+		db := &scriptedDB{query: func(query string, args []driver.NamedValue) (driver.Rows, error) {
+			if strings.HasPrefix(query, "SELECT COUNT(*)") {
+				countArgs = args
+				return rowsOf([]string{"count"}, []driver.Value{int64(0)}), nil
+			}
+			listArgs = args
+			return &scriptedRows{}, nil
+		}}
+		repo := NewUserActivityRepository(db.open())
+
+		activities, total, err := repo.GetByUserID(context.Background(), userID, activityType, 10, 20)
+		if err != nil {
+			t.Fatalf("%q: GetByUserID: %v", activityType, err)
+		}
+		if activities == nil || len(activities) != 0 || total != 0 {
+			t.Errorf("%q: expected an empty, non-nil page, got %#v (total %d)", activityType, activities, total)
+		}
+		if len(countArgs) != 2 || countArgs[1].Value != string(activityType) {
+			t.Errorf("%q: the count should be filtered by the type, got %+v", activityType, countArgs)
+		}
+		if len(listArgs) != 4 || listArgs[0].Value != userID || listArgs[1].Value != string(activityType) ||
+			listArgs[2].Value != 10 || listArgs[3].Value != 20 {
+			t.Errorf("%q: expected the user, type, limit and offset as arguments, got %+v", activityType, listArgs)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// matchColumns are the columns of the match listings, the joined participant names last
+var matchColumns = []string{
+	"id", "tournament_id", "round", "match_number", "participant1_id", "participant2_id", "winner_id", "loser_id",
+	"score_participant1", "score_participant2", "status", "scheduled_time", "completed_time",
+	"next_match_id", "loser_next_match_id", "created_at", "updated_at",
+	"match_notes", "match_proofs", "bracket_type", "reporting_deadline", "version", "games",
+	"participant1_name", "participant2_name", "winner_name",
+}
+
+// optionalID returns id as a column value, NULL when it is nil
+func optionalID(id *uuid.UUID) driver.Value {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
+
+// matchRow returns a stored match with the given joined names as a row of matchColumns
+func matchRow(match *domain.Match, participant1Name, participant2Name, winnerName string) []driver.Value {
+	return []driver.Value{
+		match.ID.String(), match.TournamentID.String(), int64(match.Round), int64(match.MatchNumber),
+		optionalID(match.Participant1ID), optionalID(match.Participant2ID), optionalID(match.WinnerID), optionalID(match.LoserID),
+		int64(match.ScoreParticipant1), int64(match.ScoreParticipant2), string(match.Status), nil, nil,
+		optionalID(match.NextMatchID), optionalID(match.LoserNextMatchID), time.Now(), time.Now(),
+		match.MatchNotes, nil, string(match.BracketType), nil, int64(match.Version), nil,
+		participant1Name, participant2Name, winnerName,
+	}
+}
+
+// listingDB answers a match listing's count with total and its select with rows, recording both
+type listingDB struct {
+	scriptedDB
+	countArgs, selectArgs []driver.NamedValue
+	selectQuery           string
+}
+
+func newListingDB(total int, rows ...[]driver.Value) *listingDB {
+	db := &listingDB{}
+	db.query = func(query string, args []driver.NamedValue) (driver.Rows, error) {
+		if strings.HasPrefix(query, "SELECT COUNT(*)") {
+			db.countArgs = args
+			return rowsOf([]string{"count"}, []driver.Value{int64(total)}), nil
+		}
+		db.selectQuery, db.selectArgs = query, args
+		return rowsOf(matchColumns, rows...), nil
+	}
+	return db
+}
+
+func TestListByTournamentPagesThroughOneBracket(t *testing.T) {
+	tournamentID := uuid.New()
+	match := &domain.Match{
+		ID: uuid.New(), TournamentID: tournamentID, Round: 2, MatchNumber: 3,
+		Status: domain.MatchPending, BracketType: domain.LosersBracket, Version: 1,
+	}
+	db := newListingDB(45, matchRow(match, "", "", ""))
+	repo := NewMatchRepository(db.open())
+
+	filter := domain.MatchFilter{BracketType: domain.LosersBracket, Limit: 20, Offset: 40}
+	matches, total, err := repo.ListByTournament(context.Background(), tournamentID, filter)
+	if err != nil {
+		t.Fatalf("ListByTournament: %v", err)
+	}
+	if total != 45 || len(matches) != 1 || matches[0].ID != match.ID || matches[0].BracketType != domain.LosersBracket {
+		t.Fatalf("expected the one stored match of 45, got %d of %d", len(matches), total)
+	}
+
+	if len(db.countArgs) != 2 || db.countArgs[0].Value != tournamentID || db.countArgs[1].Value != domain.LosersBracket {
+		t.Fatalf("the count should be filtered by tournament and bracket, got %+v", db.countArgs)
+	}
+	if !strings.Contains(db.selectQuery, "m.bracket_type = $2") || !strings.Contains(db.selectQuery, "LIMIT $3 OFFSET $4") {
+		t.Fatalf("expected the bracket filter and paging in the query, got %s", db.selectQuery)
+	}
+	if len(db.selectArgs) != 4 || db.selectArgs[2].Value != 20 || db.selectArgs[3].Value != 40 {
+		t.Fatalf("expected limit 20 and offset 40, got %+v", db.selectArgs)
+	}
+	if !strings.Contains(db.selectQuery, "m.round, m.match_number, m.id") {
+		t.Fatalf("matches should be ordered by bracket, round and match number, got %s", db.selectQuery)
+	}
+}
+
+func TestListByTournamentWithoutAFilterReturnsEverything(t *testing.T) {
+	db := newListingDB(0)
+	repo := NewMatchRepository(db.open())
+
+	matches, total, err := repo.ListByTournament(context.Background(), uuid.New(), domain.MatchFilter{})
+	if err != nil {
+		t.Fatalf("ListByTournament: %v", err)
+	}
+	if matches == nil || len(matches) != 0 || total != 0 {
+		t.Fatalf("expected an empty, non-nil list, got %#v and %d", matches, total)
+	}
+	if len(db.selectArgs) != 1 || strings.Contains(db.selectQuery, "LIMIT") || strings.Contains(db.selectQuery, "bracket_type =") {
+		t.Fatalf("an empty filter should neither page nor filter, got %s with %+v", db.selectQuery, db.selectArgs)
+	}
+}
+
+func TestMatchListingsJoinParticipantNames(t *testing.T) {
+	tournamentID := uuid.New()
+	ace, bo := uuid.New(), uuid.New()
+	played := &domain.Match{
+		ID: uuid.New(), TournamentID: tournamentID, Round: 1, MatchNumber: 1,
+		Participant1ID: &ace, Participant2ID: &bo, WinnerID: &ace, LoserID: &bo,
+		ScoreParticipant1: 2, Status: domain.MatchCompleted, BracketType: domain.WinnersBracket,
+	}
+	// The final has its winner's slot filled and the other one still TBD
+	final := &domain.Match{
+		ID: uuid.New(), TournamentID: tournamentID, Round: 2, MatchNumber: 1,
+		Participant1ID: &ace, Status: domain.MatchPending, BracketType: domain.WinnersBracket,
+	}
+	var queries int
+	db := newListingDB(2, matchRow(played, "Ace", "Bo", "Ace"), matchRow(final, "Ace", "", ""))
+	query := db.query
+	db.query = func(q string, args []driver.NamedValue) (driver.Rows, error) {
+		queries++
+		return query(q, args)
+	}
+	repo := NewMatchRepository(db.open())
+
+	matches, err := repo.GetByTournamentID(context.Background(), tournamentID)
+	if err != nil {
+		t.Fatalf("GetByTournamentID: %v", err)
+	}
+	if queries != 1 {
+		t.Fatalf("names should be joined in the same query, ran %d", queries)
+	}
+	for _, join := range []string{"p1.id = m.participant1_id", "p2.id = m.participant2_id", "pw.id = m.winner_id"} {
+		if !strings.Contains(db.selectQuery, "LEFT JOIN tournament_participants "+join[:2]+" ON "+join) {
+			t.Fatalf("expected a LEFT JOIN on %s, got %s", join, db.selectQuery)
+		}
+	}
+
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if got := matches[0]; got.Participant1Name != "Ace" || got.Participant2Name != "Bo" || got.WinnerName != "Ace" {
+		t.Fatalf("unexpected names of the played match: %q, %q, %q", got.Participant1Name, got.Participant2Name, got.WinnerName)
+	}
+	if got := matches[1]; got.Participant1Name != "Ace" || got.Participant2ID != nil || got.Participant2Name != "" || got.WinnerName != "" {
+		t.Fatalf("a TBD slot and an undecided winner should have empty names, got %+v", got)
+	}
+}
+
+func TestMatchGamesRoundTrip(t *testing.T) {
+	if data, err := marshalGames(nil); err != nil || string(data) != "[]" {
+		t.Fatalf("no games should be stored as an empty list, got %s (%v)", data, err)
+	}
+	games := []domain.GameScore{{Score1: 2, Score2: 1}, {Score1: 0, Score2: 3}}
+	data, err := marshalGames(games)
+	if err != nil {
+		t.Fatalf("marshalGames: %v", err)
+	}
+
+	match := &domain.Match{ID: uuid.New(), TournamentID: uuid.New(), Round: 1, MatchNumber: 1, Status: domain.MatchCompleted}
+	withGames, withoutGames := matchRow(match, "", "", ""), matchRow(match, "", "", "")
+	// games is the last column before the joined names
+	withGames[22], withoutGames[22] = data, []byte("[]")
+	repo := NewMatchRepository(newListingDB(2, withGames, withoutGames).open())
+
+	matches, err := repo.GetByTournamentID(context.Background(), match.TournamentID)
+	if err != nil {
+		t.Fatalf("GetByTournamentID: %v", err)
+	}
+	if len(matches[0].Games) != 2 || matches[0].Games[1] != games[1] {
+		t.Fatalf("expected the stored games back, got %+v", matches[0].Games)
+	}
+	if matches[1].Games != nil {
+		t.Fatalf("an empty list should read back as no games, got %#v", matches[1].Games)
+	}
+}
@@ -17,10 +17,16 @@ type MatchRepository interface {
 	Create(ctx context.Context, match *domain.Match) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Match, error)
 	GetByTournamentID(ctx context.Context, tournamentID uuid.UUID) ([]*domain.Match, error)
+	ListByTournament(ctx context.Context, tournamentID uuid.UUID, filter domain.MatchFilter) ([]*domain.Match, int, error)
 	GetByRound(ctx context.Context, tournamentID uuid.UUID, round int) ([]*domain.Match, error)
 	GetByParticipant(ctx context.Context, tournamentID, participantID uuid.UUID) ([]*domain.Match, error)
 	Update(ctx context.Context, match *domain.Match) error
+	UpdateWithOutbox(ctx context.Context, match *domain.Match, entry *domain.OutboxEntry) error
+	ReplaceBracket(ctx context.Context, tournamentID uuid.UUID, matches []*domain.Match) error
+	SaveRound(ctx context.Context, created, updated []*domain.Match, removed []uuid.UUID) error
 	Delete(ctx context.Context, tournamentID uuid.UUID) error
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+	GetActiveByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Match, error)
 }
 
 // matchRepository implements MatchRepository interface
@@ -35,16 +41,26 @@ func NewMatchRepository(db *sql.DB) MatchRepository {
 
 // Create inserts a new match into the database
 func (r *matchRepository) Create(ctx context.Context, match *domain.Match) error {
+	return insertMatch(ctx, r.db, match)
+}
+
+// insertMatch inserts a match using db or an open transaction
+func insertMatch(ctx context.Context, db execer, match *domain.Match) error {
 	// Set timestamps
 	now := time.Now()
 	match.CreatedAt = now
 	match.UpdatedAt = now
+	match.Version = 1
 
 	// Convert match proofs to JSON
 	proofsJSON, err := json.Marshal(match.MatchProofs)
 	if err != nil {
 		return err
 	}
+	gamesJSON, err := marshalGames(match.Games)
+	if err != nil {
+		return err
+	}
 
 	// Convert PreviousMatchIDs to an array
 	// var prevMatchIDsArray pq.StringArray
@@ -55,7 +71,7 @@ func (r *matchRepository) Create(ctx context.Context, match *domain.Match) error
 	// }
 
 	// Execute SQL insert
-	_, err = r.db.ExecContext(ctx, `
+	_, err = db.ExecContext(ctx, `
 		INSERT INTO matches (
 			id, tournament_id, round, match_number,
 			participant1_id, participant2_id,
@@ -63,10 +79,10 @@ func (r *matchRepository) Create(ctx context.Context, match *domain.Match) error
 			score_participant1, score_participant2,
 			status, scheduled_time, completed_time,
 			next_match_id, loser_next_match_id, created_at, updated_at,
-			match_notes, match_proofs, bracket_type
+			match_notes, match_proofs, bracket_type, reporting_deadline, version, games
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-			$11, $12, $13, $14, $15, $16, $17, $18, $19, $20
+			$11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
 		)
 	`,
 		match.ID,
@@ -89,6 +105,9 @@ func (r *matchRepository) Create(ctx context.Context, match *domain.Match) error
 		match.MatchNotes,
 		proofsJSON,
 		match.BracketType,
+		match.ReportingDeadline,
+		match.Version,
+		gamesJSON,
 		// prevMatchIDsArray,
 	)
 
@@ -100,20 +119,23 @@ func (r *matchRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ma
 	var (
 		match      domain.Match
 		proofsJSON []byte
+		gamesJSON  []byte
 		// prevMatchIDsArray []string
 	)
 
 	err := r.db.QueryRowContext(ctx, `
-		SELECT 
-			id, tournament_id, round, match_number,
-			participant1_id, participant2_id,
-			winner_id, loser_id,
-			score_participant1, score_participant2,
-			status, scheduled_time, completed_time,
-			next_match_id, loser_next_match_id, created_at, updated_at,
-			match_notes, match_proofs, bracket_type
-		FROM matches
-		WHERE id = $1
+		SELECT
+			m.id, m.tournament_id, m.round, m.match_number,
+			m.participant1_id, m.participant2_id,
+			m.winner_id, m.loser_id,
+			m.score_participant1, m.score_participant2,
+			m.status, m.scheduled_time, m.completed_time,
+			m.next_match_id, m.loser_next_match_id, m.created_at, m.updated_at,
+			m.match_notes, m.match_proofs, m.bracket_type, m.reporting_deadline, m.version, m.games,
+			`+participantNameColumns+`
+		FROM matches m
+		`+participantNameJoins+`
+		WHERE m.id = $1
 	`, id).Scan(
 		&match.ID,
 		&match.TournamentID,
@@ -135,11 +157,17 @@ func (r *matchRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ma
 		&match.MatchNotes,
 		&proofsJSON,
 		&match.BracketType,
+		&match.ReportingDeadline,
+		&match.Version,
+		&gamesJSON,
+		&match.Participant1Name,
+		&match.Participant2Name,
+		&match.WinnerName,
 		// &prevMatchIDsArray,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("match not found: %v", id)
+		return nil, domain.NewError(domain.ErrNotFound, fmt.Sprintf("match not found: %v", id))
 	}
 	if err != nil {
 		return nil, err
@@ -151,6 +179,9 @@ func (r *matchRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ma
 			return nil, err
 		}
 	}
+	if err := unmarshalGames(gamesJSON, &match.Games); err != nil {
+		return nil, err
+	}
 
 	// Convert previous match IDs from array of strings to UUIDs
 	// for _, strID := range prevMatchIDsArray {
@@ -164,20 +195,31 @@ func (r *matchRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ma
 	return &match, nil
 }
 
+// participantNameColumns and participantNameJoins add the display names of a match's slots and
+// winner to a query over "matches m"; TBD slots come back as empty strings
+const participantNameColumns = `COALESCE(p1.participant_name, ''), COALESCE(p2.participant_name, ''), COALESCE(pw.participant_name, '')`
+
+const participantNameJoins = `
+		LEFT JOIN tournament_participants p1 ON p1.id = m.participant1_id
+		LEFT JOIN tournament_participants p2 ON p2.id = m.participant2_id
+		LEFT JOIN tournament_participants pw ON pw.id = m.winner_id`
+
 // GetByTournamentID retrieves all matches for a tournament
 func (r *matchRepository) GetByTournamentID(ctx context.Context, tournamentID uuid.UUID) ([]*domain.Match, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT 
-			id, tournament_id, round, match_number,
-			participant1_id, participant2_id,
-			winner_id, loser_id,
-			score_participant1, score_participant2,
-			status, scheduled_time, completed_time,
-			next_match_id, loser_next_match_id, created_at, updated_at,
-			match_notes, match_proofs, bracket_type
-		FROM matches
-		WHERE tournament_id = $1
-		ORDER BY round, match_number
+		SELECT
+			m.id, m.tournament_id, m.round, m.match_number,
+			m.participant1_id, m.participant2_id,
+			m.winner_id, m.loser_id,
+			m.score_participant1, m.score_participant2,
+			m.status, m.scheduled_time, m.completed_time,
+			m.next_match_id, m.loser_next_match_id, m.created_at, m.updated_at,
+			m.match_notes, m.match_proofs, m.bracket_type, m.reporting_deadline, m.version, m.games,
+			`+participantNameColumns+`
+		FROM matches m
+		`+participantNameJoins+`
+		WHERE m.tournament_id = $1
+		ORDER BY m.round, m.match_number
 	`, tournamentID)
 	if err != nil {
 		return nil, err
@@ -189,6 +231,7 @@ func (r *matchRepository) GetByTournamentID(ctx context.Context, tournamentID uu
 		var (
 			match      domain.Match
 			proofsJSON []byte
+			gamesJSON  []byte
 		)
 
 		err := rows.Scan(
@@ -212,6 +255,12 @@ func (r *matchRepository) GetByTournamentID(ctx context.Context, tournamentID uu
 			&match.MatchNotes,
 			&proofsJSON,
 			&match.BracketType,
+			&match.ReportingDeadline,
+			&match.Version,
+			&gamesJSON,
+			&match.Participant1Name,
+			&match.Participant2Name,
+			&match.WinnerName,
 		)
 		if err != nil {
 			return nil, err
@@ -223,6 +272,9 @@ func (r *matchRepository) GetByTournamentID(ctx context.Context, tournamentID uu
 				return nil, err
 			}
 		}
+		if err := unmarshalGames(gamesJSON, &match.Games); err != nil {
+			return nil, err
+		}
 
 		matches = append(matches, &match)
 	}
@@ -230,6 +282,106 @@ func (r *matchRepository) GetByTournamentID(ctx context.Context, tournamentID uu
 	return matches, nil
 }
 
+// ListByTournament retrieves one page of a tournament's matches, optionally limited to one
+// bracket, along with the total number of matches the filter matches. Matches are ordered
+// winners, losers, then grand finals, and by round and match number within each bracket.
+func (r *matchRepository) ListByTournament(
+	ctx context.Context, tournamentID uuid.UUID, filter domain.MatchFilter,
+) ([]*domain.Match, int, error) {
+	where := "WHERE m.tournament_id = $1"
+	args := []interface{}{tournamentID}
+	if filter.BracketType != "" {
+		args = append(args, filter.BracketType)
+		where += fmt.Sprintf(" AND m.bracket_type = $%d", len(args))
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM matches m "+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT
+			m.id, m.tournament_id, m.round, m.match_number,
+			m.participant1_id, m.participant2_id,
+			m.winner_id, m.loser_id,
+			m.score_participant1, m.score_participant2,
+			m.status, m.scheduled_time, m.completed_time,
+			m.next_match_id, m.loser_next_match_id, m.created_at, m.updated_at,
+			m.match_notes, m.match_proofs, m.bracket_type, m.reporting_deadline, m.version, m.games,
+			` + participantNameColumns + `
+		FROM matches m
+		` + participantNameJoins + `
+		` + where + `
+		ORDER BY
+			CASE m.bracket_type WHEN 'WINNERS' THEN 0 WHEN 'LOSERS' THEN 1 WHEN 'GRAND_FINALS' THEN 2 ELSE 3 END,
+			m.round, m.match_number, m.id`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	matches := []*domain.Match{}
+	for rows.Next() {
+		var (
+			match      domain.Match
+			proofsJSON []byte
+			gamesJSON  []byte
+		)
+
+		err := rows.Scan(
+			&match.ID,
+			&match.TournamentID,
+			&match.Round,
+			&match.MatchNumber,
+			&match.Participant1ID,
+			&match.Participant2ID,
+			&match.WinnerID,
+			&match.LoserID,
+			&match.ScoreParticipant1,
+			&match.ScoreParticipant2,
+			&match.Status,
+			&match.ScheduledTime,
+			&match.CompletedTime,
+			&match.NextMatchID,
+			&match.LoserNextMatchID,
+			&match.CreatedAt,
+			&match.UpdatedAt,
+			&match.MatchNotes,
+			&proofsJSON,
+			&match.BracketType,
+			&match.ReportingDeadline,
+			&match.Version,
+			&gamesJSON,
+			&match.Participant1Name,
+			&match.Participant2Name,
+			&match.WinnerName,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(proofsJSON) > 0 {
+			if err := json.Unmarshal(proofsJSON, &match.MatchProofs); err != nil {
+				return nil, 0, err
+			}
+		}
+		if err := unmarshalGames(gamesJSON, &match.Games); err != nil {
+			return nil, 0, err
+		}
+
+		matches = append(matches, &match)
+	}
+
+	return matches, total, rows.Err()
+}
+
 // GetByRound retrieves matches for a specific round
 func (r *matchRepository) GetByRound(ctx context.Context, tournamentID uuid.UUID, round int) ([]*domain.Match, error) {
 	rows, err := r.db.QueryContext(ctx, `
@@ -240,7 +392,7 @@ func (r *matchRepository) GetByRound(ctx context.Context, tournamentID uuid.UUID
 			score_participant1, score_participant2,
 			status, scheduled_time, completed_time,
 			next_match_id, loser_next_match_id, created_at, updated_at,
-			match_notes, match_proofs, bracket_type
+			match_notes, match_proofs, bracket_type, reporting_deadline, version, games
 		FROM matches
 		WHERE tournament_id = $1 AND round = $2
 		ORDER BY match_number
@@ -255,6 +407,7 @@ func (r *matchRepository) GetByRound(ctx context.Context, tournamentID uuid.UUID
 		var (
 			match      domain.Match
 			proofsJSON []byte
+			gamesJSON  []byte
 		)
 
 		err := rows.Scan(
@@ -278,6 +431,9 @@ func (r *matchRepository) GetByRound(ctx context.Context, tournamentID uuid.UUID
 			&match.MatchNotes,
 			&proofsJSON,
 			&match.BracketType,
+			&match.ReportingDeadline,
+			&match.Version,
+			&gamesJSON,
 		)
 		if err != nil {
 			return nil, err
@@ -289,6 +445,9 @@ func (r *matchRepository) GetByRound(ctx context.Context, tournamentID uuid.UUID
 				return nil, err
 			}
 		}
+		if err := unmarshalGames(gamesJSON, &match.Games); err != nil {
+			return nil, err
+		}
 
 		matches = append(matches, &match)
 	}
@@ -306,7 +465,7 @@ func (r *matchRepository) GetByParticipant(ctx context.Context, tournamentID, pa
 			score_participant1, score_participant2,
 			status, scheduled_time, completed_time,
 			next_match_id, loser_next_match_id, created_at, updated_at,
-			match_notes, match_proofs, bracket_type
+			match_notes, match_proofs, bracket_type, reporting_deadline, version, games
 		FROM matches
 		WHERE tournament_id = $1 
 		AND (participant1_id = $2 OR participant2_id = $2)
@@ -322,6 +481,7 @@ func (r *matchRepository) GetByParticipant(ctx context.Context, tournamentID, pa
 		var (
 			match      domain.Match
 			proofsJSON []byte
+			gamesJSON  []byte
 		)
 
 		err := rows.Scan(
@@ -345,6 +505,9 @@ func (r *matchRepository) GetByParticipant(ctx context.Context, tournamentID, pa
 			&match.MatchNotes,
 			&proofsJSON,
 			&match.BracketType,
+			&match.ReportingDeadline,
+			&match.Version,
+			&gamesJSON,
 		)
 		if err != nil {
 			return nil, err
@@ -356,6 +519,9 @@ func (r *matchRepository) GetByParticipant(ctx context.Context, tournamentID, pa
 				return nil, err
 			}
 		}
+		if err := unmarshalGames(gamesJSON, &match.Games); err != nil {
+			return nil, err
+		}
 
 		matches = append(matches, &match)
 	}
@@ -366,6 +532,129 @@ func (r *matchRepository) GetByParticipant(ctx context.Context, tournamentID, pa
 // Update updates a match in the database
 // Update updates a match in the database
 func (r *matchRepository) Update(ctx context.Context, match *domain.Match) error {
+	return updateMatch(ctx, r.db, match)
+}
+
+// UpdateWithOutbox updates a match and queues a ranking notification in one transaction,
+// so the notification exists if and only if the match update was committed
+func (r *matchRepository) UpdateWithOutbox(ctx context.Context, match *domain.Match, entry *domain.OutboxEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateMatch(ctx, tx, match); err != nil {
+		return err
+	}
+	if err := insertOutboxEntry(ctx, tx, entry); err != nil {
+		return fmt.Errorf("failed to queue ranking notification: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit match update: %w", err)
+	}
+	return nil
+}
+
+// ReplaceBracket swaps a tournament's matches for a newly generated bracket in one transaction:
+// existing matches are deleted, the new ones inserted, then linked to the matches their winners
+// and losers advance to. Any failure rolls back the whole replacement.
+func (r *matchRepository) ReplaceBracket(ctx context.Context, tournamentID uuid.UUID, matches []*domain.Match) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM matches WHERE tournament_id = $1`, tournamentID); err != nil {
+		return fmt.Errorf("failed to clear existing matches: %w", err)
+	}
+
+	// Links point at other new matches, so every match is inserted before any is linked
+	for _, match := range matches {
+		unlinked := *match
+		unlinked.NextMatchID = nil
+		unlinked.LoserNextMatchID = nil
+		if err := insertMatch(ctx, tx, &unlinked); err != nil {
+			return fmt.Errorf("failed to create match %s: %w", match.ID, err)
+		}
+		match.CreatedAt, match.UpdatedAt, match.Version = unlinked.CreatedAt, unlinked.UpdatedAt, unlinked.Version
+	}
+	for _, match := range matches {
+		if match.NextMatchID == nil && match.LoserNextMatchID == nil {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE matches SET next_match_id = $1, loser_next_match_id = $2
+			WHERE id = $3
+		`, match.NextMatchID, match.LoserNextMatchID, match.ID); err != nil {
+			return fmt.Errorf("failed to link match %s: %w", match.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit bracket: %w", err)
+	}
+	return nil
+}
+
+// SaveRound writes a newly paired round in one transaction: created matches are inserted,
+// updated ones written with the usual version check and removed ones deleted. Any failure
+// rolls back the whole round.
+func (r *matchRepository) SaveRound(ctx context.Context, created, updated []*domain.Match, removed []uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, match := range updated {
+		if err := updateMatch(ctx, tx, match); err != nil {
+			return err
+		}
+	}
+	for _, match := range created {
+		if err := insertMatch(ctx, tx, match); err != nil {
+			return fmt.Errorf("failed to create match %s: %w", match.ID, err)
+		}
+	}
+	for _, id := range removed {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM matches WHERE id = $1`, id); err != nil {
+			return fmt.Errorf("failed to remove match %s: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit round: %w", err)
+	}
+	return nil
+}
+
+// marshalGames encodes a match's per-game scores, storing an empty list rather than null
+func marshalGames(games []domain.GameScore) ([]byte, error) {
+	if games == nil {
+		games = []domain.GameScore{}
+	}
+	return json.Marshal(games)
+}
+
+// unmarshalGames decodes the games column into dst, leaving it nil when no games were recorded
+func unmarshalGames(data []byte, dst *[]domain.GameScore) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return err
+	}
+	if len(*dst) == 0 {
+		*dst = nil
+	}
+	return nil
+}
+
+// updateMatch writes a match using db or an open transaction
+func updateMatch(ctx context.Context, db execer, match *domain.Match) error {
 	// Update timestamp
 	match.UpdatedAt = time.Now()
 
@@ -374,9 +663,13 @@ func (r *matchRepository) Update(ctx context.Context, match *domain.Match) error
 	if err != nil {
 		return fmt.Errorf("failed to marshal match proofs for update: %w", err)
 	}
+	gamesJSON, err := marshalGames(match.Games)
+	if err != nil {
+		return fmt.Errorf("failed to marshal match games for update: %w", err)
+	}
 
 	// Execute SQL update
-	result, err := r.db.ExecContext(ctx, `
+	result, err := db.ExecContext(ctx, `
 		UPDATE matches SET
 			participant1_id = $1,
 			participant2_id = $2,
@@ -392,9 +685,12 @@ func (r *matchRepository) Update(ctx context.Context, match *domain.Match) error
 			updated_at = $12,
 			match_notes = $13,
 			match_proofs = $14,
-			bracket_type = $15 
+			bracket_type = $15,
+			reporting_deadline = $16,
+			games = $19,
+			version = version + 1
 			-- If you add previous_match_ids here, adjust placeholders below too
-		WHERE id = $16 -- Corrected placeholder for id
+		WHERE id = $17 AND version = $18 -- Only if nobody else updated the match since it was read
 	`,
 		match.Participant1ID,    // $1
 		match.Participant2ID,    // $2
@@ -411,8 +707,11 @@ func (r *matchRepository) Update(ctx context.Context, match *domain.Match) error
 		match.MatchNotes,        // $13
 		proofsJSON,              // $14
 		match.BracketType,       // $15
-		// prevMatchIDsArray,    // If used, this would be $16, and id would be $17
-		match.ID, // $16 (for WHERE clause)
+		match.ReportingDeadline, // $16
+		// prevMatchIDsArray,    // If used, this would be $17, and id would be $18
+		match.ID,      // $17 (for WHERE clause)
+		match.Version, // $18 (version the caller read)
+		gamesJSON,     // $19
 	)
 	if err != nil {
 		// Check for specific pq error if it helps
@@ -428,12 +727,18 @@ func (r *matchRepository) Update(ctx context.Context, match *domain.Match) error
 	}
 
 	if rowsAffected == 0 {
-		// It's possible that an update affects 0 rows if the data hasn't actually changed
-		// and the DB optimizes it, or if the WHERE condition isn't met.
-		// For an ID based update, this usually means "not found".
+		// Either the match is gone or its version moved on since it was read
+		var exists bool
+		if err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM matches WHERE id = $1)`, match.ID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check match %v after update: %w", match.ID, err)
+		}
+		if exists {
+			return domain.ErrConcurrentModification
+		}
 		return fmt.Errorf("match not found for update (or no changes made): %v", match.ID)
 	}
 
+	match.Version++
 	return nil
 }
 
@@ -445,3 +750,87 @@ func (r *matchRepository) Delete(ctx context.Context, tournamentID uuid.UUID) er
 	`, tournamentID)
 	return err
 }
+
+// DeleteByID removes a single match
+func (r *matchRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM matches
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+// GetActiveByUser retrieves unfinished matches, across all tournaments, in which one of the
+// user's linked participant entries is assigned
+func (r *matchRepository) GetActiveByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Match, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT 
+			m.id, m.tournament_id, m.round, m.match_number,
+			m.participant1_id, m.participant2_id,
+			m.winner_id, m.loser_id,
+			m.score_participant1, m.score_participant2,
+			m.status, m.scheduled_time, m.completed_time,
+			m.next_match_id, m.loser_next_match_id, m.created_at, m.updated_at,
+			m.match_notes, m.match_proofs, m.bracket_type, m.reporting_deadline, m.version, m.games
+		FROM matches m
+		JOIN tournament_participants p
+			ON p.id = m.participant1_id OR p.id = m.participant2_id
+		WHERE p.user_id = $1 AND m.status IN ($2, $3)
+		ORDER BY m.scheduled_time NULLS LAST, m.tournament_id, m.round, m.match_number
+	`, userID, domain.MatchPending, domain.MatchInProgress)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := []*domain.Match{}
+	for rows.Next() {
+		var (
+			match      domain.Match
+			proofsJSON []byte
+			gamesJSON  []byte
+		)
+
+		err := rows.Scan(
+			&match.ID,
+			&match.TournamentID,
+			&match.Round,
+			&match.MatchNumber,
+			&match.Participant1ID,
+			&match.Participant2ID,
+			&match.WinnerID,
+			&match.LoserID,
+			&match.ScoreParticipant1,
+			&match.ScoreParticipant2,
+			&match.Status,
+			&match.ScheduledTime,
+			&match.CompletedTime,
+			&match.NextMatchID,
+			&match.LoserNextMatchID,
+			&match.CreatedAt,
+			&match.UpdatedAt,
+			&match.MatchNotes,
+			&proofsJSON,
+			&match.BracketType,
+			&match.ReportingDeadline,
+			&match.Version,
+			&gamesJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(proofsJSON) > 0 {
+			if err := json.Unmarshal(proofsJSON, &match.MatchProofs); err != nil {
+				return nil, err
+			}
+		}
+		if err := unmarshalGames(gamesJSON, &match.Games); err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, &match)
+	}
+
+	return matches, rows.Err()
+}
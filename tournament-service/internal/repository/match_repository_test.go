@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestGetMatchByIDReportsMissingMatchAsNotFound(t *testing.T) {
+	db := &scriptedDB{} // Every query comes back empty
+	repo := NewMatchRepository(db.open())
+
+	_, err := repo.GetByID(context.Background(), uuid.New())
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected a not-found error, got %v", err)
+	}
+}
+
+func TestGetActiveByUserFiltersUnfinishedMatchesOfTheUser(t *testing.T) {
+	userID := uuid.New()
+	var args []driver.NamedValue
+	db := &scriptedDB{query: func(query string, a []driver.NamedValue) (driver.Rows, error) {
+		args = a
+		return &scriptedRows{}, nil
+	}}
+	repo := NewMatchRepository(db.open())
+
+	matches, err := repo.GetActiveByUser(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("GetActiveByUser: %v", err)
+	}
+	if matches == nil || len(matches) != 0 {
+		t.Fatalf("expected an empty, non-nil list, got %#v", matches)
+	}
+	if len(args) != 3 || args[0].Value != userID ||
+		args[1].Value != domain.MatchPending || args[2].Value != domain.MatchInProgress {
+		t.Fatalf("expected the user and the pending/in-progress statuses as arguments, got %+v", args)
+	}
+}
+
+// versionedMatches answers match updates like Postgres would for a single stored match row:
+// an UPDATE only applies while its version argument matches the stored version.
+func versionedMatches(version *int) *scriptedDB {
+	return &scriptedDB{
+		exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+			if args[17].Value != *version {
+				return driver.RowsAffected(0), nil
+			}
+			*version++
+			return driver.RowsAffected(1), nil
+		},
+		query: func(query string, args []driver.NamedValue) (driver.Rows, error) {
+			return rowsOf([]string{"exists"}, []driver.Value{true}), nil
+		},
+	}
+}
+
+func TestUpdateMatchFromStaleReadIsRejected(t *testing.T) {
+	ctx := context.Background()
+	stored := 1
+	db := versionedMatches(&stored)
+	repo := NewMatchRepository(db.open())
+
+	// Two organizers read version 1 of the same match
+	id := uuid.New()
+	first := &domain.Match{ID: id, Version: 1, ScoreParticipant1: 2}
+	second := &domain.Match{ID: id, Version: 1, ScoreParticipant2: 2}
+
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if first.Version != 2 || stored != 2 {
+		t.Fatalf("expected the update to bump the version to 2, got %d (stored %d)", first.Version, stored)
+	}
+
+	if err := repo.Update(ctx, second); !errors.Is(err, domain.ErrConcurrentModification) {
+		t.Fatalf("expected ErrConcurrentModification, got %v", err)
+	}
+	if second.Version != 1 || stored != 2 {
+		t.Fatalf("a rejected update must not bump the version, got %d (stored %d)", second.Version, stored)
+	}
+	updates := db.statements("UPDATE matches SET")
+	if len(updates) != 2 || !strings.Contains(updates[0], "WHERE id = $17 AND version = $18") {
+		t.Fatalf("expected both updates to be guarded by the version, got %v", updates)
+	}
+}
+
+func TestUpdateMissingMatchIsNotAConflict(t *testing.T) {
+	db := &scriptedDB{
+		exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+			return driver.RowsAffected(0), nil
+		},
+		query: func(query string, args []driver.NamedValue) (driver.Rows, error) {
+			return rowsOf([]string{"exists"}, []driver.Value{false}), nil
+		},
+	}
+	repo := NewMatchRepository(db.open())
+
+	err := repo.Update(context.Background(), &domain.Match{ID: uuid.New(), Version: 1})
+	if err == nil || errors.Is(err, domain.ErrConcurrentModification) {
+		t.Fatalf("expected a plain not-found error for a deleted match, got %v", err)
+	}
+}
+
+// bracketFixture is two semi-finals feeding a final, with the losers meeting for third place
+func bracketFixture() []*domain.Match {
+	tournamentID := uuid.New()
+	final := &domain.Match{ID: uuid.New(), TournamentID: tournamentID, Round: 2, MatchNumber: 1}
+	third := &domain.Match{ID: uuid.New(), TournamentID: tournamentID, Round: 2, MatchNumber: 2}
+	semi := func(number int) *domain.Match {
+		return &domain.Match{
+			ID: uuid.New(), TournamentID: tournamentID, Round: 1, MatchNumber: number,
+			NextMatchID: &final.ID, LoserNextMatchID: &third.ID,
+		}
+	}
+	return []*domain.Match{semi(1), semi(2), final, third}
+}
+
+func TestReplaceBracketInsertsThenLinksInOneTransaction(t *testing.T) {
+	db := &scriptedDB{}
+	repo := NewMatchRepository(db.open())
+	matches := bracketFixture()
+
+	if err := repo.ReplaceBracket(context.Background(), matches[0].TournamentID, matches); err != nil {
+		t.Fatalf("ReplaceBracket: %v", err)
+	}
+
+	want := []string{"BEGIN", "DELETE FROM matches", "INSERT INTO matches", "INSERT INTO matches", "INSERT INTO matches",
+		"INSERT INTO matches", "UPDATE matches SET next_match_id", "UPDATE matches SET next_match_id", "COMMIT"}
+	if len(db.log) != len(want) {
+		t.Fatalf("expected %d statements, got %v", len(want), db.log)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(db.log[i], prefix) {
+			t.Fatalf("statement %d: expected %s, got %s", i, prefix, db.log[i])
+		}
+	}
+	if matches[0].NextMatchID == nil || *matches[0].NextMatchID != matches[2].ID {
+		t.Fatal("ReplaceBracket must not clear the caller's bracket links")
+	}
+}
+
+func TestReplaceBracketRollsBackWhenAnInsertFails(t *testing.T) {
+	insertErr := errors.New("insert failed")
+	for failing := 1; failing <= 4; failing++ {
+		inserts := 0
+		db := &scriptedDB{exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+			if strings.HasPrefix(query, "INSERT INTO matches") {
+				if inserts++; inserts == failing {
+					return nil, insertErr
+				}
+			}
+			return driver.RowsAffected(1), nil
+		}}
+		repo := NewMatchRepository(db.open())
+		matches := bracketFixture()
+
+		err := repo.ReplaceBracket(context.Background(), matches[0].TournamentID, matches)
+		if !errors.Is(err, insertErr) {
+			t.Fatalf("insert %d: expected the insert error, got %v", failing, err)
+		}
+		// Without a commit neither the delete nor the earlier inserts are kept, so the old
+		// bracket is still there and none of the new matches are
+		if len(db.statements("COMMIT")) != 0 || len(db.statements("ROLLBACK")) != 1 {
+			t.Fatalf("insert %d: expected a rollback and no commit, got %v", failing, db.log)
+		}
+		if got := db.statements("INSERT INTO matches"); len(got) != failing {
+			t.Fatalf("insert %d: expected to stop at the failing insert, got %d inserts", failing, len(got))
+		}
+		if got := db.statements("UPDATE matches"); len(got) != 0 {
+			t.Fatalf("insert %d: no match should be linked, got %v", failing, got)
+		}
+	}
+}
+
+func TestReplaceBracketRollsBackWhenALinkFails(t *testing.T) {
+	linkErr := errors.New("link failed")
+	db := &scriptedDB{exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+		if strings.HasPrefix(query, "UPDATE matches SET next_match_id") {
+			return nil, linkErr
+		}
+		return driver.RowsAffected(1), nil
+	}}
+	repo := NewMatchRepository(db.open())
+	matches := bracketFixture()
+
+	if err := repo.ReplaceBracket(context.Background(), matches[0].TournamentID, matches); !errors.Is(err, linkErr) {
+		t.Fatalf("expected the link error, got %v", err)
+	}
+	if len(db.statements("COMMIT")) != 0 || len(db.statements("ROLLBACK")) != 1 {
+		t.Fatalf("expected a rollback and no commit, got %v", db.log)
+	}
+}
+
+func TestSaveRoundWritesTheRoundInOneTransaction(t *testing.T) {
+	db := &scriptedDB{}
+	repo := NewMatchRepository(db.open())
+	tournamentID := uuid.New()
+	paired := &domain.Match{ID: uuid.New(), TournamentID: tournamentID, Round: 2, MatchNumber: 1, Version: 1}
+	added := &domain.Match{ID: uuid.New(), TournamentID: tournamentID, Round: 2, MatchNumber: 3}
+
+	if err := repo.SaveRound(context.Background(), []*domain.Match{added}, []*domain.Match{paired}, []uuid.UUID{uuid.New()}); err != nil {
+		t.Fatalf("SaveRound: %v", err)
+	}
+	want := []string{"BEGIN", "UPDATE matches SET participant1_id", "INSERT INTO matches", "DELETE FROM matches", "COMMIT"}
+	if len(db.log) != len(want) {
+		t.Fatalf("expected %d statements, got %v", len(want), db.log)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(db.log[i], prefix) {
+			t.Fatalf("statement %d: expected %s, got %s", i, prefix, db.log[i])
+		}
+	}
+	if paired.Version != 2 || added.Version != 1 {
+		t.Fatalf("expected versions 2 and 1, got %d and %d", paired.Version, added.Version)
+	}
+}
+
+func TestSaveRoundRollsBackWhenAWriteFails(t *testing.T) {
+	insertErr := errors.New("insert failed")
+	db := &scriptedDB{exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+		if strings.HasPrefix(query, "INSERT INTO matches") {
+			return nil, insertErr
+		}
+		return driver.RowsAffected(1), nil
+	}}
+	repo := NewMatchRepository(db.open())
+	tournamentID := uuid.New()
+	paired := &domain.Match{ID: uuid.New(), TournamentID: tournamentID, Round: 2, MatchNumber: 1, Version: 1}
+	added := &domain.Match{ID: uuid.New(), TournamentID: tournamentID, Round: 2, MatchNumber: 2}
+
+	err := repo.SaveRound(context.Background(), []*domain.Match{added}, []*domain.Match{paired}, []uuid.UUID{uuid.New()})
+	if !errors.Is(err, insertErr) {
+		t.Fatalf("expected the insert error, got %v", err)
+	}
+	// The pairing written before the failure is rolled back with the rest of the round
+	if len(db.statements("COMMIT")) != 0 || len(db.statements("ROLLBACK")) != 1 || len(db.statements("DELETE")) != 0 {
+		t.Fatalf("expected a rollback before any delete and no commit, got %v", db.log)
+	}
+}
@@ -3,19 +3,37 @@ package repository
 import (
 	"context"
 	"database/sql"
-	// "fmt"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/cliffdoyle/tournament-service/internal/domain"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // MessageRepository defines methods for message database operations
 type MessageRepository interface {
 	Create(ctx context.Context, message *domain.Message) error
 	ListByTournament(ctx context.Context, tournamentID uuid.UUID, limit, offset int) ([]*domain.Message, error)
+	ListByMatch(ctx context.Context, matchID uuid.UUID, limit, offset int) ([]*domain.Message, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Message, error)
+	Update(ctx context.Context, message *domain.Message) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	SetPinned(ctx context.Context, id uuid.UUID, pinned bool) error
+	AddReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) error
+	RemoveReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) error
+	ReactionCounts(ctx context.Context, messageIDs []uuid.UUID) (map[uuid.UUID][]domain.ReactionCount, error)
+	MarkRead(ctx context.Context, tournamentID, userID uuid.UUID, readAt time.Time) (*domain.ChatReadState, error)
+	CountUnread(ctx context.Context, tournamentID, userID uuid.UUID) (int, error)
 }
 
+// ErrReactionExists is returned by AddReaction when the user already reacted with that emoji
+var ErrReactionExists = errors.New("reaction already exists")
+
+// ErrReactionNotFound is returned by RemoveReaction when the user has no such reaction
+var ErrReactionNotFound = errors.New("reaction not found")
+
 // messageRepository implements MessageRepository interface
 type messageRepository struct {
 	db *sql.DB
@@ -28,33 +46,42 @@ func NewMessageRepository(db *sql.DB) MessageRepository {
 
 // Create inserts a new message into the database
 func (r *messageRepository) Create(ctx context.Context, message *domain.Message) error {
+	return insertMessage(ctx, r.db, message)
+}
+
+// insertMessage inserts a message using db or an open transaction
+func insertMessage(ctx context.Context, db execer, message *domain.Message) error {
 	// Generate UUID if not provided
 	if message.ID == uuid.Nil {
 		message.ID = uuid.New()
 	}
 
-	// Set timestamp
-	message.CreatedAt = time.Now()
+	// Set timestamp unless the caller is preserving an existing one (e.g. archive import)
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
 
 	// Execute SQL insert
-	_, err := r.db.ExecContext(ctx, `
+	_, err := db.ExecContext(ctx, `
 		INSERT INTO tournament_messages (
-			id, tournament_id, user_id, message, created_at
+			id, tournament_id, match_id, user_id, message, created_at, edited_at
 		) VALUES (
-			$1, $2, $3, $4, $5
+			$1, $2, $3, $4, $5, $6, $7
 		)
 	`,
 		message.ID,
 		message.TournamentID,
+		message.MatchID,
 		message.UserID,
 		message.Message,
 		message.CreatedAt,
+		message.EditedAt,
 	)
 
 	return err
 }
 
-// ListByTournament retrieves messages for a tournament with pagination
+// ListByTournament retrieves tournament-wide chat messages with pagination; match threads are excluded
 func (r *messageRepository) ListByTournament(ctx context.Context, tournamentID uuid.UUID, limit, offset int) ([]*domain.Message, error) {
 	// Use sensible defaults for pagination
 	if limit <= 0 {
@@ -66,10 +93,10 @@ func (r *messageRepository) ListByTournament(ctx context.Context, tournamentID u
 
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT 
-			id, tournament_id, user_id, message, created_at
+			id, tournament_id, match_id, user_id, message, created_at, edited_at, deleted_at, is_pinned
 		FROM tournament_messages
-		WHERE tournament_id = $1
-		ORDER BY created_at DESC
+		WHERE tournament_id = $1 AND match_id IS NULL AND deleted_at IS NULL
+		ORDER BY is_pinned DESC, created_at DESC
 		LIMIT $2 OFFSET $3
 	`, tournamentID, limit, offset)
 
@@ -78,6 +105,266 @@ func (r *messageRepository) ListByTournament(ctx context.Context, tournamentID u
 	}
 	defer rows.Close()
 
+	return scanMessages(rows)
+}
+
+// ListByMatch retrieves the chat thread of a single match with pagination
+func (r *messageRepository) ListByMatch(ctx context.Context, matchID uuid.UUID, limit, offset int) ([]*domain.Message, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT 
+			id, tournament_id, match_id, user_id, message, created_at, edited_at, deleted_at, is_pinned
+		FROM tournament_messages
+		WHERE match_id = $1 AND deleted_at IS NULL
+		ORDER BY is_pinned DESC, created_at DESC
+		LIMIT $2 OFFSET $3
+	`, matchID, limit, offset)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMessages(rows)
+}
+
+// GetByID retrieves a single message, including soft-deleted ones
+func (r *messageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Message, error) {
+	var message domain.Message
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT 
+			id, tournament_id, match_id, user_id, message, created_at, edited_at, deleted_at, is_pinned
+		FROM tournament_messages
+		WHERE id = $1
+	`, id).Scan(
+		&message.ID,
+		&message.TournamentID,
+		&message.MatchID,
+		&message.UserID,
+		&message.Message,
+		&message.CreatedAt,
+		&message.EditedAt,
+		&message.DeletedAt,
+		&message.IsPinned,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("message not found: %v", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// Update saves a message's edited text and stamps edited_at
+func (r *messageRepository) Update(ctx context.Context, message *domain.Message) error {
+	now := time.Now()
+	message.EditedAt = &now
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE tournament_messages
+		SET message = $1, edited_at = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`, message.Message, message.EditedAt, message.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("message not found: %v", message.ID)
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a message by setting deleted_at, keeping the row for chat history
+func (r *messageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE tournament_messages
+		SET deleted_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("message not found: %v", id)
+	}
+
+	return nil
+}
+
+// SetPinned pins or unpins a live message
+func (r *messageRepository) SetPinned(ctx context.Context, id uuid.UUID, pinned bool) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE tournament_messages
+		SET is_pinned = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`, pinned, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("message not found: %v", id)
+	}
+
+	return nil
+}
+
+// AddReaction records a user's emoji reaction to a message
+func (r *messageRepository) AddReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) error {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (message_id, user_id, emoji) DO NOTHING
+	`, messageID, userID, emoji, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrReactionExists
+	}
+
+	return nil
+}
+
+// RemoveReaction takes back a user's emoji reaction to a message
+func (r *messageRepository) RemoveReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM message_reactions
+		WHERE message_id = $1 AND user_id = $2 AND emoji = $3
+	`, messageID, userID, emoji)
+	if err != nil {
+		return fmt.Errorf("failed to remove reaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrReactionNotFound
+	}
+
+	return nil
+}
+
+// ReactionCounts counts the reactions per emoji for each of the messages, most used emoji
+// first. Messages nobody reacted to are absent from the map.
+func (r *messageRepository) ReactionCounts(
+	ctx context.Context, messageIDs []uuid.UUID,
+) (map[uuid.UUID][]domain.ReactionCount, error) {
+	counts := make(map[uuid.UUID][]domain.ReactionCount)
+	if len(messageIDs) == 0 {
+		return counts, nil
+	}
+
+	ids := make([]string, len(messageIDs))
+	for i, id := range messageIDs {
+		ids[i] = id.String()
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT message_id, emoji, COUNT(*)
+		FROM message_reactions
+		WHERE message_id = ANY($1::uuid[])
+		GROUP BY message_id, emoji
+		ORDER BY message_id, COUNT(*) DESC, MIN(created_at)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			messageID uuid.UUID
+			count     domain.ReactionCount
+		)
+		if err := rows.Scan(&messageID, &count.Emoji, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction count: %w", err)
+		}
+		counts[messageID] = append(counts[messageID], count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// MarkRead records that the user has read the tournament chat up to readAt, noting the newest
+// message at that point
+func (r *messageRepository) MarkRead(
+	ctx context.Context, tournamentID, userID uuid.UUID, readAt time.Time,
+) (*domain.ChatReadState, error) {
+	state := &domain.ChatReadState{TournamentID: tournamentID, LastReadAt: readAt}
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO chat_read_state (user_id, tournament_id, last_read_message_id, last_read_at)
+		VALUES ($1, $2, (
+			SELECT id FROM tournament_messages
+			WHERE tournament_id = $2 AND match_id IS NULL AND deleted_at IS NULL AND created_at <= $3
+			ORDER BY created_at DESC
+			LIMIT 1
+		), $3)
+		ON CONFLICT (user_id, tournament_id) DO UPDATE
+		SET last_read_message_id = EXCLUDED.last_read_message_id, last_read_at = EXCLUDED.last_read_at
+		RETURNING last_read_message_id
+	`, userID, tournamentID, readAt).Scan(&state.LastReadMessageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark chat read: %w", err)
+	}
+	return state, nil
+}
+
+// CountUnread counts the tournament chat messages created after the user last marked it read,
+// leaving out their own. A user who never marked it read has every message unread.
+func (r *messageRepository) CountUnread(ctx context.Context, tournamentID, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM tournament_messages m
+		LEFT JOIN chat_read_state s ON s.tournament_id = m.tournament_id AND s.user_id = $2
+		WHERE m.tournament_id = $1 AND m.match_id IS NULL AND m.deleted_at IS NULL
+			AND m.user_id <> $2
+			AND (s.last_read_at IS NULL OR m.created_at > s.last_read_at)
+	`, tournamentID, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+	return count, nil
+}
+
+// scanMessages reads message rows into domain objects
+func scanMessages(rows *sql.Rows) ([]*domain.Message, error) {
 	messages := []*domain.Message{}
 	for rows.Next() {
 		var message domain.Message
@@ -85,9 +372,13 @@ func (r *messageRepository) ListByTournament(ctx context.Context, tournamentID u
 		err := rows.Scan(
 			&message.ID,
 			&message.TournamentID,
+			&message.MatchID,
 			&message.UserID,
 			&message.Message,
 			&message.CreatedAt,
+			&message.EditedAt,
+			&message.DeletedAt,
+			&message.IsPinned,
 		)
 
 		if err != nil {
@@ -97,9 +388,9 @@ func (r *messageRepository) ListByTournament(ctx context.Context, tournamentID u
 		messages = append(messages, &message)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
 	return messages, nil
-}
\ No newline at end of file
+}
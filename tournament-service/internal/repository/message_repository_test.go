@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestMessageEditsOnlyTouchLiveMessages(t *testing.T) {
+	db := &scriptedDB{exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+		return driver.RowsAffected(0), nil // Already deleted, or never existed
+	}}
+	repo := NewMessageRepository(db.open())
+	ctx := context.Background()
+
+	message := &domain.Message{ID: uuid.New(), Message: "edited"}
+	if err := repo.Update(ctx, message); err == nil || !strings.Contains(err.Error(), "message not found") {
+		t.Fatalf("expected message not found from Update, got %v", err)
+	}
+	if err := repo.Delete(ctx, message.ID); err == nil || !strings.Contains(err.Error(), "message not found") {
+		t.Fatalf("expected message not found from Delete, got %v", err)
+	}
+
+	for _, statement := range db.statements("UPDATE tournament_messages") {
+		if !strings.Contains(statement, "deleted_at IS NULL") {
+			t.Errorf("statement can change a deleted message: %s", statement)
+		}
+	}
+	if len(db.statements("DELETE")) != 0 {
+		t.Fatal("messages must be soft-deleted, not removed")
+	}
+}
+
+func TestMessageEditRecordsEditTime(t *testing.T) {
+	db := &scriptedDB{}
+	repo := NewMessageRepository(db.open())
+
+	message := &domain.Message{ID: uuid.New(), Message: "edited"}
+	if err := repo.Update(context.Background(), message); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if message.EditedAt == nil {
+		t.Fatal("Update should set EditedAt")
+	}
+}
+
+func TestReactionsReportDuplicatesAndMissingReactions(t *testing.T) {
+	db := &scriptedDB{exec: func(string, []driver.NamedValue) (driver.Result, error) {
+		return driver.RowsAffected(0), nil
+	}}
+	repo := NewMessageRepository(db.open())
+	ctx := context.Background()
+
+	if err := repo.AddReaction(ctx, uuid.New(), uuid.New(), "🔥"); !errors.Is(err, ErrReactionExists) {
+		t.Fatalf("expected ErrReactionExists, got %v", err)
+	}
+	if statements := db.statements("INSERT INTO message_reactions"); len(statements) != 1 || !strings.Contains(statements[0], "ON CONFLICT (message_id, user_id, emoji) DO NOTHING") {
+		t.Fatalf("a repeated reaction should be ignored by the unique key, got %v", statements)
+	}
+	if err := repo.RemoveReaction(ctx, uuid.New(), uuid.New(), "🔥"); !errors.Is(err, ErrReactionNotFound) {
+		t.Fatalf("expected ErrReactionNotFound, got %v", err)
+	}
+	if err := repo.SetPinned(ctx, uuid.New(), true); err == nil || !strings.Contains(err.Error(), "message not found") {
+		t.Fatalf("pinning a missing message: expected message not found, got %v", err)
+	}
+}
+
+func TestReactionCountsGroupByMessage(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	db := &scriptedDB{query: func(string, []driver.NamedValue) (driver.Rows, error) {
+		return rowsOf([]string{"message_id", "emoji", "count"},
+			[]driver.Value{first.String(), "🔥", int64(2)},
+			[]driver.Value{first.String(), "👍", int64(1)},
+			[]driver.Value{second.String(), "👍", int64(4)},
+		), nil
+	}}
+	repo := NewMessageRepository(db.open())
+
+	counts, err := repo.ReactionCounts(context.Background(), []uuid.UUID{first, second, uuid.New()})
+	if err != nil {
+		t.Fatalf("ReactionCounts: %v", err)
+	}
+	if len(counts) != 2 || len(counts[first]) != 2 || counts[first][0] != (domain.ReactionCount{Emoji: "🔥", Count: 2}) || counts[second][0].Count != 4 {
+		t.Fatalf("unexpected counts %+v", counts)
+	}
+
+	// No messages, no query
+	if counts, err := repo.ReactionCounts(context.Background(), nil); err != nil || len(counts) != 0 {
+		t.Fatalf("expected no counts, got %v (%v)", counts, err)
+	}
+	if queries := db.statements("SELECT message_id"); len(queries) != 1 {
+		t.Fatalf("expected a single count query, ran %d", len(queries))
+	}
+}
+
+func TestDatabaseCountsUnreadMessages(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	tournament := &domain.Tournament{
+		ID: uuid.New(), Name: "Chatty Cup", Game: "chess", Format: domain.SingleElimination,
+		Status: domain.InProgress, MaxParticipants: 8, CreatedBy: uuid.New(),
+	}
+	if err := NewTournamentRepository(db).Create(ctx, tournament); err != nil {
+		t.Fatalf("create tournament: %v", err)
+	}
+	repo := NewMessageRepository(db)
+	reader, writer := uuid.New(), uuid.New()
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	post := func(userID uuid.UUID, at time.Time, matchID *uuid.UUID) {
+		t.Helper()
+		if err := repo.Create(ctx, &domain.Message{TournamentID: tournament.ID, MatchID: matchID, UserID: userID, Message: "hi", CreatedAt: at}); err != nil {
+			t.Fatalf("create message: %v", err)
+		}
+	}
+	unread := func() int {
+		t.Helper()
+		count, err := repo.CountUnread(ctx, tournament.ID, reader)
+		if err != nil {
+			t.Fatalf("CountUnread: %v", err)
+		}
+		return count
+	}
+
+	post(writer, start, nil)
+	post(writer, start.Add(time.Minute), nil)
+	post(reader, start.Add(2*time.Minute), nil)
+	if got := unread(); got != 2 {
+		t.Fatalf("a reader who never marked the chat read has every other message unread, got %d", got)
+	}
+
+	state, err := repo.MarkRead(ctx, tournament.ID, reader, start.Add(90*time.Second))
+	if err != nil {
+		t.Fatalf("MarkRead: %v", err)
+	}
+	if state.LastReadMessageID == nil {
+		t.Fatal("the read state should note the newest message read")
+	}
+	post(writer, start.Add(3*time.Minute), nil)
+	if got := unread(); got != 1 {
+		t.Fatalf("only the message after the read mark is unread, got %d", got)
+	}
+
+	// Marking read again moves the mark rather than adding a second one
+	if _, err := repo.MarkRead(ctx, tournament.ID, reader, start.Add(4*time.Minute)); err != nil {
+		t.Fatalf("MarkRead again: %v", err)
+	}
+	if got := unread(); got != 0 {
+		t.Fatalf("expected nothing unread, got %d", got)
+	}
+}
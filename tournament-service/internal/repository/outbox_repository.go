@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// RankingOutboxRepository defines methods for the ranking notification outbox.
+// Entries are written together with their match by MatchRepository.UpdateWithOutbox.
+type RankingOutboxRepository interface {
+	ListDue(ctx context.Context, limit int) ([]*domain.OutboxEntry, error)
+	MarkSent(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error
+	CountPending(ctx context.Context) (int, error)
+	ListUnsent(ctx context.Context, limit int) ([]*domain.OutboxEntry, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.OutboxEntry, error)
+}
+
+// rankingOutboxRepository implements RankingOutboxRepository interface
+type rankingOutboxRepository struct {
+	db *sql.DB
+}
+
+// NewRankingOutboxRepository creates a new ranking outbox repository
+func NewRankingOutboxRepository(db *sql.DB) RankingOutboxRepository {
+	return &rankingOutboxRepository{db: db}
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// insertOutboxEntry queues an entry for delivery using db or an open transaction
+func insertOutboxEntry(ctx context.Context, db execer, entry *domain.OutboxEntry) error {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	now := time.Now()
+	entry.CreatedAt = now
+	if entry.NextAttemptAt.IsZero() {
+		entry.NextAttemptAt = now
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO ranking_outbox (
+			id, match_id, payload, attempts, next_attempt_at, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+	`,
+		entry.ID,
+		entry.MatchID,
+		[]byte(entry.Payload),
+		entry.Attempts,
+		entry.NextAttemptAt,
+		entry.CreatedAt,
+	)
+	return err
+}
+
+// ListDue retrieves unsent entries whose next attempt is due, oldest first
+func (r *rankingOutboxRepository) ListDue(ctx context.Context, limit int) ([]*domain.OutboxEntry, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+outboxColumns+`
+		FROM ranking_outbox
+		WHERE sent_at IS NULL AND next_attempt_at <= $1
+		ORDER BY created_at
+		LIMIT $2
+	`, time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanOutboxEntries(rows)
+}
+
+// outboxColumns are the columns scanOutboxEntry reads, in order
+const outboxColumns = `id, match_id, payload, attempts, next_attempt_at, last_error, sent_at, created_at`
+
+// scanOutboxEntry is a helper to scan an outbox row
+func scanOutboxEntry(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.OutboxEntry, error) {
+	var entry domain.OutboxEntry
+	var payload []byte
+	err := scanner.Scan(
+		&entry.ID,
+		&entry.MatchID,
+		&payload,
+		&entry.Attempts,
+		&entry.NextAttemptAt,
+		&entry.LastError,
+		&entry.SentAt,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	entry.Payload = payload
+	return &entry, nil
+}
+
+// scanOutboxEntries reads every row of an outbox query and closes it
+func scanOutboxEntries(rows *sql.Rows) ([]*domain.OutboxEntry, error) {
+	defer rows.Close()
+
+	entries := []*domain.OutboxEntry{}
+	for rows.Next() {
+		entry, err := scanOutboxEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// MarkSent records that an entry was delivered
+func (r *rankingOutboxRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE ranking_outbox
+		SET sent_at = $1, attempts = attempts + 1, last_error = ''
+		WHERE id = $2
+	`, time.Now(), id)
+	return err
+}
+
+// MarkFailed records a failed delivery and when to try again
+func (r *rankingOutboxRepository) MarkFailed(
+	ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string,
+) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE ranking_outbox
+		SET attempts = attempts + 1, next_attempt_at = $1, last_error = $2
+		WHERE id = $3
+	`, nextAttemptAt, lastError, id)
+	return err
+}
+
+// CountPending returns the number of entries not yet delivered
+func (r *rankingOutboxRepository) CountPending(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM ranking_outbox WHERE sent_at IS NULL`).Scan(&count)
+	return count, err
+}
+
+// ListUnsent retrieves entries not yet delivered, due or not, oldest first
+func (r *rankingOutboxRepository) ListUnsent(ctx context.Context, limit int) ([]*domain.OutboxEntry, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+outboxColumns+`
+		FROM ranking_outbox
+		WHERE sent_at IS NULL
+		ORDER BY created_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanOutboxEntries(rows)
+}
+
+// GetByID retrieves an entry, or nil when there is none with that ID
+func (r *rankingOutboxRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.OutboxEntry, error) {
+	entry, err := scanOutboxEntry(r.db.QueryRowContext(ctx, `
+		SELECT `+outboxColumns+`
+		FROM ranking_outbox
+		WHERE id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return entry, err
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func outboxFixture() (*domain.Match, *domain.OutboxEntry) {
+	match := &domain.Match{ID: uuid.New(), TournamentID: uuid.New(), Status: domain.MatchCompleted, Version: 1}
+	return match, &domain.OutboxEntry{MatchID: match.ID, Payload: []byte(`{"type":"RESULT"}`)}
+}
+
+func TestUpdateWithOutboxQueuesTheEventWithTheMatch(t *testing.T) {
+	db := &scriptedDB{}
+	repo := NewMatchRepository(db.open())
+	match, entry := outboxFixture()
+
+	if err := repo.UpdateWithOutbox(context.Background(), match, entry); err != nil {
+		t.Fatalf("UpdateWithOutbox: %v", err)
+	}
+
+	want := []string{"BEGIN", "UPDATE matches", "INSERT INTO ranking_outbox", "COMMIT"}
+	if len(db.log) != len(want) {
+		t.Fatalf("expected %v, got %v", want, db.log)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(db.log[i], prefix) {
+			t.Fatalf("statement %d: expected %s, got %s", i+1, prefix, db.log[i])
+		}
+	}
+	if entry.ID == uuid.Nil || entry.NextAttemptAt.IsZero() {
+		t.Fatal("a queued entry should get an ID and be due immediately")
+	}
+	if match.Version != 2 {
+		t.Fatalf("expected the match version to move to 2, got %d", match.Version)
+	}
+}
+
+func TestUpdateWithOutboxRollsBackTheMatchWhenQueueingFails(t *testing.T) {
+	queueErr := errors.New("outbox unavailable")
+	db := &scriptedDB{}
+	db.exec = func(query string, args []driver.NamedValue) (driver.Result, error) {
+		if strings.HasPrefix(query, "INSERT INTO ranking_outbox") {
+			return nil, queueErr
+		}
+		return driver.RowsAffected(1), nil
+	}
+	repo := NewMatchRepository(db.open())
+	match, entry := outboxFixture()
+
+	if err := repo.UpdateWithOutbox(context.Background(), match, entry); !errors.Is(err, queueErr) {
+		t.Fatalf("expected the queueing error, got %v", err)
+	}
+	if got := db.statements("COMMIT"); len(got) != 0 {
+		t.Fatalf("the result must not be saved without its ranking event: %v", db.log)
+	}
+	if got := db.statements("ROLLBACK"); len(got) != 1 {
+		t.Fatalf("expected a rollback, got %v", db.log)
+	}
+}
+
+func TestMarkFailedCountsTheAttemptAndKeepsTheError(t *testing.T) {
+	var args []driver.NamedValue
+	db := &scriptedDB{}
+	db.exec = func(query string, a []driver.NamedValue) (driver.Result, error) {
+		args = a
+		return driver.RowsAffected(1), nil
+	}
+	id, retryAt := uuid.New(), time.Now().Add(time.Minute)
+
+	if err := NewRankingOutboxRepository(db.open()).MarkFailed(context.Background(), id, retryAt, "503"); err != nil {
+		t.Fatalf("MarkFailed: %v", err)
+	}
+	update := db.statements("UPDATE ranking_outbox")
+	if len(update) != 1 || !strings.Contains(update[0], "attempts = attempts + 1") {
+		t.Fatalf("expected the attempt to be counted, got %v", db.log)
+	}
+	if len(args) != 3 || args[0].Value != retryAt || args[1].Value != "503" || args[2].Value != id {
+		t.Fatalf("unexpected arguments %v", args)
+	}
+}
+
+// outboxColumnNames are the columns of outboxColumns
+var outboxColumnNames = []string{"id", "match_id", "payload", "attempts", "next_attempt_at", "last_error", "sent_at", "created_at"}
+
+func TestListUnsentIncludesEntriesNotDueYet(t *testing.T) {
+	failed := uuid.New()
+	var args []driver.NamedValue
+	db := &scriptedDB{query: func(query string, a []driver.NamedValue) (driver.Rows, error) {
+		args = a
+		return rowsOf(outboxColumnNames, []driver.Value{
+			failed.String(), uuid.NewString(), []byte(`{}`), int64(3), time.Now().Add(time.Hour), "503", nil, time.Now(),
+		}), nil
+	}}
+
+	entries, err := NewRankingOutboxRepository(db.open()).ListUnsent(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("ListUnsent: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != failed || entries[0].Attempts != 3 || entries[0].LastError != "503" || entries[0].SentAt != nil {
+		t.Fatalf("expected the failed entry with its attempts and error, got %+v", entries)
+	}
+	query := db.statements("SELECT")[0]
+	if !strings.Contains(query, "WHERE sent_at IS NULL ORDER BY created_at") || strings.Contains(query, "next_attempt_at <=") {
+		t.Fatalf("every undelivered entry should be listed, due or not: %s", query)
+	}
+	if args[0].Value != 50 {
+		t.Fatalf("expected the default limit of 50, got %v", args[0].Value)
+	}
+}
+
+func TestGetByIDOfAMissingEntry(t *testing.T) {
+	entry, err := NewRankingOutboxRepository((&scriptedDB{}).open()).GetByID(context.Background(), uuid.New())
+	if err != nil || entry != nil {
+		t.Fatalf("a missing entry should be nil without an error, got %+v, %v", entry, err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cliffdoyle/tournament-service/internal/domain"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // ParticipantRepository defines methods for participant database operations
@@ -20,8 +21,9 @@ type ParticipantRepository interface {
 	Update(ctx context.Context, participant *domain.Participant) error
 	UpdateSeed(ctx context.Context, id uuid.UUID, seed int) error
 	CheckIn(ctx context.Context, id uuid.UUID) error
+	Substitute(ctx context.Context, participant *domain.Participant) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	 ExistsByTournamentIDAndUserID(ctx context.Context, tournamentID, userID uuid.UUID) (bool, error)
+	ExistsByTournamentIDAndUserID(ctx context.Context, tournamentID, userID uuid.UUID) (bool, error)
 }
 
 // participantRepository implements ParticipantRepository interface
@@ -42,7 +44,6 @@ func NewParticipantRepository(db *sql.DB) ParticipantRepository {
 //     db *sql.DB // This is a standard sql.DB pointer
 // }
 
-
 // In your ExistsByTournamentIDAndUserID implementation:
 // Import necessary packages:
 // import (
@@ -55,50 +56,70 @@ func NewParticipantRepository(db *sql.DB) ParticipantRepository {
 // )
 
 func (r *participantRepository) ExistsByTournamentIDAndUserID(ctx context.Context, tournamentID, userID uuid.UUID) (bool, error) {
-    // Use a COUNT query to efficiently check for existence
-    query := `
+	// Use a COUNT query to efficiently check for existence
+	query := `
         SELECT COUNT(*)
         FROM tournament_participants
         WHERE tournament_id = $1 AND user_id = $2
     ` // Use $1, $2 for PostgreSQL, or ?,? for MySQL/SQLite
 
-    var count int
-    // Use QueryRowContext for queries expected to return at most one row
-    err := r.db.QueryRowContext(ctx, query, tournamentID, userID).Scan(&count)
+	var count int
+	// Use QueryRowContext for queries expected to return at most one row
+	err := r.db.QueryRowContext(ctx, query, tournamentID, userID).Scan(&count)
 
-    if err != nil {
-        // sql.ErrNoRows specifically is NOT an error for COUNT(*),
-        // COUNT(*) always returns a row, even if it's 0.
-        // So any error here is a genuine database error.
-        return false, fmt.Errorf("database query failed: %w", err)
-    }
+	if err != nil {
+		// sql.ErrNoRows specifically is NOT an error for COUNT(*),
+		// COUNT(*) always returns a row, even if it's 0.
+		// So any error here is a genuine database error.
+		return false, fmt.Errorf("database query failed: %w", err)
+	}
 
-    // If count > 0, a record exists
-    return count > 0, nil
+	// If count > 0, a record exists
+	return count > 0, nil
 }
 
-// Create inserts a new participant into the database
+// uniqueParticipantUserIndex stops a user registering twice in a tournament (migration 018)
+const uniqueParticipantUserIndex = "uq_tournament_participants_tournament_user"
+
+// Create inserts a new participant into the database. Registering a user already in the
+// tournament fails with domain.ErrAlreadyParticipant, which also catches two concurrent
+// registrations that both passed ExistsByTournamentIDAndUserID.
 func (r *participantRepository) Create(ctx context.Context, participant *domain.Participant) error {
+	return insertParticipant(ctx, r.db, participant)
+}
+
+// insertParticipant inserts a participant using db or an open transaction
+func insertParticipant(ctx context.Context, db execer, participant *domain.Participant) error {
 	// Set timestamps
 	now := time.Now()
 	participant.CreatedAt = now
 	participant.UpdatedAt = now
 
+	// Set default status if not set
+	if participant.Status == "" {
+		participant.Status = domain.ParticipantRegistered
+	}
+
 	// Execute SQL insert
-	_, err := r.db.ExecContext(ctx, `
+	_, err := db.ExecContext(ctx, `
 		INSERT INTO tournament_participants (
 			id, tournament_id, user_id, participant_name, seed,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			status, is_waitlisted, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`,
 		participant.ID,
 		participant.TournamentID,
 		participant.UserID,
 		participant.ParticipantName,
 		participant.Seed,
+		participant.Status,
+		participant.IsWaitlisted,
 		participant.CreatedAt,
 		participant.UpdatedAt,
 	)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == uniqueParticipantUserIndex {
+		return domain.ErrAlreadyParticipant
+	}
 
 	return err
 }
@@ -110,7 +131,7 @@ func (r *participantRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 	err := r.db.QueryRowContext(ctx, `
 		SELECT 
 			id, tournament_id, user_id, COALESCE(participant_name, ''), seed,
-			created_at, updated_at
+			status, is_waitlisted, created_at, updated_at
 		FROM tournament_participants
 		WHERE id = $1
 	`, id).Scan(
@@ -119,6 +140,8 @@ func (r *participantRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 		&participant.UserID,
 		&participant.ParticipantName,
 		&participant.Seed,
+		&participant.Status,
+		&participant.IsWaitlisted,
 		&participant.CreatedAt,
 		&participant.UpdatedAt,
 	)
@@ -144,7 +167,7 @@ func (r *participantRepository) GetByTournamentAndUser(ctx context.Context, tour
 	err := r.db.QueryRowContext(ctx, `
 		SELECT 
 			id, tournament_id, user_id, COALESCE(participant_name, ''), seed,
-			created_at, updated_at
+			status, is_waitlisted, created_at, updated_at
 		FROM tournament_participants
 		WHERE tournament_id = $1 AND user_id = $2
 	`, tournamentID, userID).Scan(
@@ -153,6 +176,8 @@ func (r *participantRepository) GetByTournamentAndUser(ctx context.Context, tour
 		&participant.UserID,
 		&participant.ParticipantName,
 		&participant.Seed,
+		&participant.Status,
+		&participant.IsWaitlisted,
 		&participant.CreatedAt,
 		&participant.UpdatedAt,
 	)
@@ -177,7 +202,7 @@ func (r *participantRepository) ListByTournament(ctx context.Context, tournament
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT 
 			id, tournament_id, user_id, COALESCE(participant_name, ''), seed,
-			created_at, updated_at
+			status, is_waitlisted, created_at, updated_at
 		FROM tournament_participants
 		WHERE tournament_id = $1
 		ORDER BY seed, created_at
@@ -198,6 +223,8 @@ func (r *participantRepository) ListByTournament(ctx context.Context, tournament
 			&participant.UserID,
 			&participant.ParticipantName,
 			&participant.Seed,
+			&participant.Status,
+			&participant.IsWaitlisted,
 			&participant.CreatedAt,
 			&participant.UpdatedAt,
 		)
@@ -220,12 +247,14 @@ func (r *participantRepository) ListByTournament(ctx context.Context, tournament
 func (r *participantRepository) Update(ctx context.Context, participant *domain.Participant) error {
 	query := `
 		UPDATE tournament_participants 
-		SET participant_name = $1, updated_at = $2
-		WHERE id = $3
+		SET participant_name = $1, status = $2, is_waitlisted = $3, updated_at = $4
+		WHERE id = $5
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
 		participant.ParticipantName,
+		participant.Status,
+		participant.IsWaitlisted,
 		participant.UpdatedAt,
 		participant.ID,
 	)
@@ -245,6 +274,34 @@ func (r *participantRepository) Update(ctx context.Context, participant *domain.
 	return nil
 }
 
+// Substitute saves the participant's name and linked user, leaving its ID, seed and status as they
+// are so matches referencing the participant are untouched. Linking a user who already plays in
+// the tournament fails with domain.ErrAlreadyParticipant.
+func (r *participantRepository) Substitute(ctx context.Context, participant *domain.Participant) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE tournament_participants SET
+			participant_name = $1,
+			user_id = $2,
+			updated_at = $3
+		WHERE id = $4
+	`, participant.ParticipantName, participant.UserID, participant.UpdatedAt, participant.ID)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == uniqueParticipantUserIndex {
+		return domain.ErrAlreadyParticipant
+	}
+	if err != nil {
+		return fmt.Errorf("failed to substitute participant: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("participant not found: %v", participant.ID)
+	}
+	return nil
+}
+
 // UpdateSeed updates a participant's seed
 func (r *participantRepository) UpdateSeed(ctx context.Context, id uuid.UUID, seed int) error {
 	result, err := r.db.ExecContext(ctx, `
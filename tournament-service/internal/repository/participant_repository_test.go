@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func TestParticipantUpdateSavesCheckInAndWaitlist(t *testing.T) {
+	var args []driver.NamedValue
+	db := &scriptedDB{exec: func(query string, a []driver.NamedValue) (driver.Result, error) {
+		args = a
+		return driver.RowsAffected(1), nil
+	}}
+	participant := &domain.Participant{ID: uuid.New(), ParticipantName: "p", Status: domain.ParticipantCheckedIn}
+
+	if err := NewParticipantRepository(db.open()).Update(context.Background(), participant); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if len(args) != 5 || args[1].Value != domain.ParticipantCheckedIn || args[2].Value != false || args[4].Value != participant.ID {
+		t.Fatalf("expected the status and waitlist flag to be saved, got %+v", args)
+	}
+}
+
+func TestParticipantUpdateOfMissingParticipantFails(t *testing.T) {
+	db := &scriptedDB{exec: func(string, []driver.NamedValue) (driver.Result, error) {
+		return driver.RowsAffected(0), nil
+	}}
+	if err := NewParticipantRepository(db.open()).Update(context.Background(), &domain.Participant{ID: uuid.New()}); err == nil {
+		t.Fatal("expected an error when no participant was updated")
+	}
+}
+
+func TestParticipantCreateReportsDuplicateRegistrations(t *testing.T) {
+	db := &scriptedDB{exec: func(string, []driver.NamedValue) (driver.Result, error) {
+		return nil, &pq.Error{Code: "23505", Constraint: uniqueParticipantUserIndex}
+	}}
+	userID := uuid.New()
+	err := NewParticipantRepository(db.open()).Create(context.Background(), &domain.Participant{ID: uuid.New(), UserID: &userID})
+	if !errors.Is(err, domain.ErrAlreadyParticipant) {
+		t.Fatalf("expected ErrAlreadyParticipant, got %v", err)
+	}
+
+	// Other constraint violations are passed on as they are
+	other := &pq.Error{Code: "23503", Constraint: "tournament_participants_tournament_id_fkey"}
+	db.exec = func(string, []driver.NamedValue) (driver.Result, error) { return nil, other }
+	if err := NewParticipantRepository(db.open()).Create(context.Background(), &domain.Participant{ID: uuid.New()}); err != other {
+		t.Fatalf("expected the foreign key error, got %v", err)
+	}
+}
+
+func TestDatabaseRejectsDuplicateRegistrations(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	tournament := &domain.Tournament{
+		ID: uuid.New(), Name: "Duplicate Cup", Game: "chess", Format: domain.SingleElimination,
+		Status: domain.Registration, MaxParticipants: 8, CreatedBy: uuid.New(),
+	}
+	if err := NewTournamentRepository(db).Create(ctx, tournament); err != nil {
+		t.Fatalf("create tournament: %v", err)
+	}
+	participants := NewParticipantRepository(db)
+	userID := uuid.New()
+
+	if err := participants.Create(ctx, &domain.Participant{ID: uuid.New(), TournamentID: tournament.ID, UserID: &userID, ParticipantName: "first"}); err != nil {
+		t.Fatalf("first registration: %v", err)
+	}
+	err := participants.Create(ctx, &domain.Participant{ID: uuid.New(), TournamentID: tournament.ID, UserID: &userID, ParticipantName: "second"})
+	if !errors.Is(err, domain.ErrAlreadyParticipant) {
+		t.Fatalf("the database should reject a second registration, got %v", err)
+	}
+
+	// Guests have no user and may share a tournament freely
+	for _, name := range []string{"guest one", "guest two"} {
+		if err := participants.Create(ctx, &domain.Participant{ID: uuid.New(), TournamentID: tournament.ID, ParticipantName: name}); err != nil {
+			t.Fatalf("guest %s: %v", name, err)
+		}
+	}
+}
+
+func TestParticipantSubstituteChangesOnlyTheName(t *testing.T) {
+	var query string
+	var args []driver.NamedValue
+	db := &scriptedDB{exec: func(q string, a []driver.NamedValue) (driver.Result, error) {
+		query, args = q, a
+		return driver.RowsAffected(1), nil
+	}}
+	userID := uuid.New()
+	participant := &domain.Participant{ID: uuid.New(), ParticipantName: "Stand-in", UserID: &userID, Seed: 3}
+	repo := NewParticipantRepository(db.open())
+
+	if err := repo.Substitute(context.Background(), participant); err != nil {
+		t.Fatalf("Substitute: %v", err)
+	}
+	if strings.Contains(query, "seed") || strings.Contains(query, "status") {
+		t.Fatalf("a substitution must leave seed and status alone, got %s", query)
+	}
+	if len(args) != 4 || args[0].Value != "Stand-in" || args[3].Value != participant.ID {
+		t.Fatalf("unexpected arguments %+v", args)
+	}
+
+	db.exec = func(string, []driver.NamedValue) (driver.Result, error) {
+		return nil, &pq.Error{Code: "23505", Constraint: uniqueParticipantUserIndex}
+	}
+	if err := repo.Substitute(context.Background(), participant); !errors.Is(err, domain.ErrAlreadyParticipant) {
+		t.Fatalf("a replacement already registered: expected ErrAlreadyParticipant, got %v", err)
+	}
+	db.exec = func(string, []driver.NamedValue) (driver.Result, error) { return driver.RowsAffected(0), nil }
+	if err := repo.Substitute(context.Background(), participant); err == nil {
+		t.Fatal("expected an error when no participant was updated")
+	}
+}
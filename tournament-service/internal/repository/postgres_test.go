@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/database"
+	"github.com/cliffdoyle/tournament-service/migrations"
+	_ "github.com/lib/pq"
+)
+
+// openTestDB connects to the Postgres database named by TEST_DATABASE_URL, migrated and with
+// every tournament removed. Without it the test is skipped.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	if _, err := database.RunMigrations(ctx, db, migrations.FS); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `TRUNCATE tournaments, user_activities, ranking_outbox CASCADE`); err != nil {
+		t.Fatalf("empty test database: %v", err)
+	}
+	return db
+}
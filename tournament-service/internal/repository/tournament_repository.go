@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,11 +21,22 @@ type TournamentRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Tournament, error)
 	List(ctx context.Context, filters map[string]interface{}, page, pageSize int) ([]*domain.Tournament, int, error)
 	Update(ctx context.Context, tournament *domain.Tournament) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error // Hard delete; cascades to matches, participants and messages
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Tournament, error)
 	GetParticipantCount(ctx context.Context, id uuid.UUID) (int, error)
-	GetByStatuses(ctx context.Context, statuses []domain.TournamentStatus, limit int, offset int) ([]*domain.Tournament, int, error)
+	CountActiveByCreator(ctx context.Context, userID uuid.UUID) (int, error)
+	GetParticipantCounts(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]int, error)
+	GetByStatuses(ctx context.Context, statuses []domain.TournamentStatus, visibleTo *uuid.UUID, limit int, offset int) ([]*domain.Tournament, int, error)
+	ListByParticipantUser(ctx context.Context, userID uuid.UUID, statuses []domain.TournamentStatus) ([]*domain.Tournament, error)
+	GetInviteCode(ctx context.Context, id uuid.UUID) (string, error)
+	SetInviteCode(ctx context.Context, id uuid.UUID, code string) error
+	GetIDByInviteCode(ctx context.Context, code string) (uuid.UUID, error)
+	Import(ctx context.Context, tournament *domain.Tournament, participants []*domain.Participant, matches []*domain.Match, messages []*domain.Message) error
 }
 
+// ErrInviteCodeTaken is returned by SetInviteCode when another tournament already uses the code
+var ErrInviteCodeTaken = errors.New("invite code already in use")
+
 // tournamentRepository implements TournamentRepository interface
 type tournamentRepository struct {
 	db *sql.DB
@@ -37,6 +49,11 @@ func NewTournamentRepository(db *sql.DB) TournamentRepository {
 
 // Create inserts a new tournament into the database
 func (r *tournamentRepository) Create(ctx context.Context, tournament *domain.Tournament) error {
+	return insertTournament(ctx, r.db, tournament)
+}
+
+// insertTournament inserts a tournament using db or an open transaction
+func insertTournament(ctx context.Context, db execer, tournament *domain.Tournament) error {
 	// Set timestamps
 	now := time.Now()
 	tournament.CreatedAt = now
@@ -48,17 +65,20 @@ func (r *tournamentRepository) Create(ctx context.Context, tournament *domain.To
 	if tournament.CustomFields == nil {
 		tournament.CustomFields = json.RawMessage("null") // Or "{}"
 	}
+	if tournament.Visibility == "" {
+		tournament.Visibility = domain.VisibilityPublic
+	}
 
-
-	_, err := r.db.ExecContext(ctx, `
+	_, err := db.ExecContext(ctx, `
 		INSERT INTO tournaments (
 			id, name, description, game, format, status,
 			max_participants, registration_deadline, start_time,
 			end_time, created_by, created_at, updated_at,
-			rules, prize_pool, custom_fields
+			rules, prize_pool, custom_fields, grand_finals_advantage,
+			reporting_window_minutes, reporting_deadline_policy, visibility
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-			$11, $12, $13, $14, $15, $16
+			$11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 		)
 	`,
 		tournament.ID,
@@ -72,18 +92,20 @@ func (r *tournamentRepository) Create(ctx context.Context, tournament *domain.To
 		tournament.StartTime,            // This is *time.Time
 		tournament.EndTime,              // This is *time.Time
 		tournament.CreatedBy,
-		tournament.CreatedAt,           // This is time.Time (NOT NULL)
-		tournament.UpdatedAt,           // This is time.Time (NOT NULL)
+		tournament.CreatedAt, // This is time.Time (NOT NULL)
+		tournament.UpdatedAt, // This is time.Time (NOT NULL)
 		tournament.Rules,
 		tournament.PrizePool,    // Pass json.RawMessage directly
 		tournament.CustomFields, // Pass json.RawMessage directly
+		tournament.GrandFinalsAdvantage,
+		tournament.ReportingWindowMinutes,
+		tournament.ReportingDeadlinePolicy,
+		tournament.Visibility,
 	)
 
-
 	return err
 }
 
-
 // scanTournament is a helper to scan a tournament row
 func scanTournament(scanner interface {
 	Scan(dest ...interface{}) error
@@ -112,6 +134,10 @@ func scanTournament(scanner interface {
 		&t.Rules,
 		&prizePoolBytes,    // Scan directly into []byte
 		&customFieldsBytes, // Scan directly into []byte
+		&t.GrandFinalsAdvantage,
+		&t.ReportingWindowMinutes,
+		&t.ReportingDeadlinePolicy,
+		&t.Visibility,
 	)
 	if err != nil {
 		return nil, err
@@ -139,7 +165,7 @@ func scanTournament(scanner interface {
 	// If prizePoolBytes or customFieldsBytes are nil from the DB (SQL NULL),
 	// t.PrizePool and t.CustomFields will remain nil (their zero value),
 	// which marshals to JSON `null` if omitempty is not set or is set but field is non-nil.
-    // With omitempty, if they are nil, they are omitted from JSON.
+	// With omitempty, if they are nil, they are omitted from JSON.
 
 	return &t, nil
 }
@@ -157,7 +183,8 @@ func (r *tournamentRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 			id, name, description, game, format, status,
 			max_participants, registration_deadline, start_time,
 			end_time, created_by, created_at, updated_at,
-			rules, prize_pool, custom_fields
+			rules, prize_pool, custom_fields, grand_finals_advantage,
+			reporting_window_minutes, reporting_deadline_policy, visibility
 		FROM tournaments
 		WHERE id = $1
 	`, id).Scan(
@@ -177,10 +204,14 @@ func (r *tournamentRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 		&tournament.Rules,
 		&prizePoolJSON,
 		&customFieldsJSON,
+		&tournament.GrandFinalsAdvantage,
+		&tournament.ReportingWindowMinutes,
+		&tournament.ReportingDeadlinePolicy,
+		&tournament.Visibility,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("tournament not found: %v", id)
+		return nil, domain.NewError(domain.ErrNotFound, fmt.Sprintf("tournament not found: %v", id))
 	}
 	if err != nil {
 		return nil, err
@@ -209,7 +240,8 @@ func (r *tournamentRepository) List(ctx context.Context, filters map[string]inte
 			id, name, description, game, format, status,
 			max_participants, registration_deadline, start_time,
 			end_time, created_by, created_at, updated_at,
-			rules, prize_pool, custom_fields
+			rules, prize_pool, custom_fields, grand_finals_advantage,
+			reporting_window_minutes, reporting_deadline_policy, visibility
 		FROM tournaments
 		WHERE 1=1
 	`
@@ -223,6 +255,12 @@ func (r *tournamentRepository) List(ctx context.Context, filters map[string]inte
 		countQuery += fmt.Sprintf(" AND status = $%d", argNum)
 		args = append(args, status)
 		argNum++
+	} else if includeArchived, _ := filters["includeArchived"].(bool); !includeArchived {
+		// Archived (deleted) tournaments are hidden unless asked for
+		query += fmt.Sprintf(" AND status <> $%d", argNum)
+		countQuery += fmt.Sprintf(" AND status <> $%d", argNum)
+		args = append(args, domain.Archived)
+		argNum++
 	}
 	if game, ok := filters["game"]; ok {
 		query += fmt.Sprintf(" AND game = $%d", argNum)
@@ -230,6 +268,14 @@ func (r *tournamentRepository) List(ctx context.Context, filters map[string]inte
 		args = append(args, game)
 		argNum++
 	}
+	// Listings for a caller (uuid.Nil when anonymous) leave out the unlisted and private
+	// tournaments they have no part in
+	if viewerID, ok := filters["visibleTo"].(uuid.UUID); ok {
+		query += " AND " + visibleToClause(argNum)
+		countQuery += " AND " + visibleToClause(argNum)
+		args = append(args, viewerID.String())
+		argNum++
+	}
 
 	// Add pagination
 	offset := (page - 1) * pageSize
@@ -253,47 +299,14 @@ func (r *tournamentRepository) List(ctx context.Context, filters map[string]inte
 	// Scan results
 	tournaments := []*domain.Tournament{}
 	for rows.Next() {
-		var (
-			tournament       domain.Tournament
-			prizePoolJSON    []byte
-			customFieldsJSON []byte
-		)
-
-		err := rows.Scan(
-			&tournament.ID,
-			&tournament.Name,
-			&tournament.Description,
-			&tournament.Game,
-			&tournament.Format,
-			&tournament.Status,
-			&tournament.MaxParticipants,
-			&tournament.RegistrationDeadline,
-			&tournament.StartTime,
-			&tournament.EndTime,
-			&tournament.CreatedBy,
-			&tournament.CreatedAt,
-			&tournament.UpdatedAt,
-			&tournament.Rules,
-			&prizePoolJSON,
-			&customFieldsJSON,
-		)
+		tournament, err := scanTournament(rows)
 		if err != nil {
 			return nil, 0, err
 		}
-
-		// Parse JSONB fields
-		if len(prizePoolJSON) > 0 {
-			if err := json.Unmarshal(prizePoolJSON, &tournament.PrizePool); err != nil {
-				return nil, 0, err
-			}
-		}
-		if len(customFieldsJSON) > 0 {
-			if err := json.Unmarshal(customFieldsJSON, &tournament.CustomFields); err != nil {
-				return nil, 0, err
-			}
-		}
-
-		tournaments = append(tournaments, &tournament)
+		tournaments = append(tournaments, tournament)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
 	}
 
 	return tournaments, total, nil
@@ -304,13 +317,13 @@ func (r *tournamentRepository) Update(ctx context.Context, tournament *domain.To
 	// Update timestamp
 	tournament.UpdatedAt = time.Now()
 
-	    // Ensure nil json.RawMessage becomes JSON null if necessary for DB, or specific default like "{}"
-		if tournament.PrizePool == nil {
-			tournament.PrizePool = json.RawMessage("null")
-		}
-		if tournament.CustomFields == nil {
-			tournament.CustomFields = json.RawMessage("null")
-		}
+	// Ensure nil json.RawMessage becomes JSON null if necessary for DB, or specific default like "{}"
+	if tournament.PrizePool == nil {
+		tournament.PrizePool = json.RawMessage("null")
+	}
+	if tournament.CustomFields == nil {
+		tournament.CustomFields = json.RawMessage("null")
+	}
 
 	// Execute SQL update
 	result, err := r.db.ExecContext(ctx, `
@@ -327,8 +340,12 @@ func (r *tournamentRepository) Update(ctx context.Context, tournament *domain.To
 			updated_at = $10,
 			rules = $11,
 			prize_pool = $12,
-			custom_fields = $13
-		WHERE id = $14
+			custom_fields = $13,
+			grand_finals_advantage = $14,
+			reporting_window_minutes = $15,
+			reporting_deadline_policy = $16,
+			visibility = $18
+		WHERE id = $17
 	`,
 		tournament.Name,
 		tournament.Description,
@@ -343,7 +360,11 @@ func (r *tournamentRepository) Update(ctx context.Context, tournament *domain.To
 		tournament.Rules,
 		tournament.PrizePool,
 		tournament.CustomFields,
+		tournament.GrandFinalsAdvantage,
+		tournament.ReportingWindowMinutes,
+		tournament.ReportingDeadlinePolicy,
 		tournament.ID,
+		tournament.Visibility,
 	)
 
 	if err != nil {
@@ -381,11 +402,96 @@ func (r *tournamentRepository) GetParticipantCount(ctx context.Context, id uuid.
 	return count, err
 }
 
+// CountActiveByCreator returns the number of tournaments userID created that are not archived
+func (r *tournamentRepository) CountActiveByCreator(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM tournaments
+		WHERE created_by = $1 AND status <> $2
+	`, userID, domain.Archived).Scan(&count)
+	return count, err
+}
+
+// GetParticipantCounts returns the number of participants in each of several tournaments in one
+// query. Tournaments without participants are absent from the map.
+func (r *tournamentRepository) GetParticipantCounts(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(ids))
+	if len(ids) == 0 {
+		return counts, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT tournament_id, COUNT(*) FROM tournament_participants
+		WHERE tournament_id = ANY($1)
+		GROUP BY tournament_id
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count participants: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan participant count: %w", err)
+		}
+		counts[id] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating participant counts: %w", err)
+	}
+	return counts, nil
+}
+
+// GetByIDs retrieves several tournaments in one query. IDs that do not exist are skipped, and
+// the tournaments come back in no particular order.
+func (r *tournamentRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Tournament, error) {
+	tournaments := []*domain.Tournament{}
+	if len(ids) == 0 {
+		return tournaments, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, description, game, format, status, max_participants,
+		       registration_deadline, start_time, end_time, created_by,
+		       created_at, updated_at, rules, prize_pool, custom_fields, grand_finals_advantage,
+		       reporting_window_minutes, reporting_deadline_policy, visibility
+		FROM tournaments
+		WHERE id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tournaments by ID: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		tournament, err := scanTournament(rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, tournament)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tournament rows by ID: %w", err)
+	}
+	return tournaments, nil
+}
+
+// visibleToClause matches the tournaments listed for the viewer whose ID (as text) is parameter
+// argNum: public ones plus those the viewer created, co-organizes or is registered in
+func visibleToClause(argNum int) string {
+	return fmt.Sprintf(`(visibility = '%[2]s' OR created_by::text = $%[1]d
+		OR custom_fields->'co_organizers' ? $%[1]d
+		OR EXISTS (
+			SELECT 1 FROM tournament_participants vp
+			WHERE vp.tournament_id = tournaments.id AND vp.user_id::text = $%[1]d
+		))`, argNum, domain.VisibilityPublic)
+}
 
 // type tournamentRepository struct { db *sql.DB }
 // func NewTournamentRepository(db *sql.DB) TournamentRepository { return &tournamentRepository{db: db} }
-// GetByStatuses retrieves tournaments by specific statuses
-func (r *tournamentRepository) GetByStatuses(ctx context.Context, statuses []domain.TournamentStatus, limit int, offset int) ([]*domain.Tournament, int, error) {
+// GetByStatuses retrieves tournaments by specific statuses. A non-nil visibleTo limits the
+// results to the tournaments listed for that viewer (uuid.Nil when anonymous).
+func (r *tournamentRepository) GetByStatuses(ctx context.Context, statuses []domain.TournamentStatus, visibleTo *uuid.UUID, limit int, offset int) ([]*domain.Tournament, int, error) {
 	var tournaments []*domain.Tournament
 	var total int
 
@@ -393,14 +499,16 @@ func (r *tournamentRepository) GetByStatuses(ctx context.Context, statuses []dom
 	queryBuilder.WriteString(`
 		SELECT id, name, description, game, format, status, max_participants, 
 		       registration_deadline, start_time, end_time, created_by, 
-		       created_at, updated_at, rules, prize_pool, custom_fields 
+		       created_at, updated_at, rules, prize_pool, custom_fields, grand_finals_advantage,
+			reporting_window_minutes, reporting_deadline_policy, visibility
 		FROM tournaments 
 	`)
 	args := []interface{}{}
 	paramIndex := 1
 
+	var conditions []string
 	if len(statuses) > 0 {
-		queryBuilder.WriteString(fmt.Sprintf("WHERE status = ANY($%d) ", paramIndex))
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", paramIndex))
 		statusStrings := make([]string, len(statuses))
 		for i, s := range statuses {
 			statusStrings[i] = string(s)
@@ -408,19 +516,21 @@ func (r *tournamentRepository) GetByStatuses(ctx context.Context, statuses []dom
 		args = append(args, pq.Array(statusStrings))
 		paramIndex++
 	}
+	if visibleTo != nil {
+		conditions = append(conditions, visibleToClause(paramIndex))
+		args = append(args, visibleTo.String())
+		paramIndex++
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ") + " "
+	}
+	queryBuilder.WriteString(where)
 
 	countQueryBuilder := strings.Builder{}
-	countQueryBuilder.WriteString("SELECT COUNT(*) FROM tournaments ")
-	var countArgs []interface{}
-	if len(statuses) > 0 {
-		countQueryBuilder.WriteString("WHERE status = ANY($1)")
-        statusStringsForCount := make([]string, len(statuses)) // Rebuild for countArgs
-		for i, s := range statuses {
-			statusStringsForCount[i] = string(s)
-		}
-		countArgs = append(countArgs, pq.Array(statusStringsForCount))
-	}
-	
+	countQueryBuilder.WriteString("SELECT COUNT(*) FROM tournaments " + where)
+	countArgs := append([]interface{}{}, args...)
+
 	err := r.db.QueryRowContext(ctx, countQueryBuilder.String(), countArgs...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count tournaments by status: %w", err)
@@ -447,4 +557,150 @@ func (r *tournamentRepository) GetByStatuses(ctx context.Context, statuses []dom
 	}
 
 	return tournaments, total, nil
-}
\ No newline at end of file
+}
+
+// ListByParticipantUser retrieves the tournaments a user is registered in, optionally limited to some statuses
+func (r *tournamentRepository) ListByParticipantUser(ctx context.Context, userID uuid.UUID, statuses []domain.TournamentStatus) ([]*domain.Tournament, error) {
+	query := `
+		SELECT t.id, t.name, t.description, t.game, t.format, t.status, t.max_participants,
+		       t.registration_deadline, t.start_time, t.end_time, t.created_by,
+		       t.created_at, t.updated_at, t.rules, t.prize_pool, t.custom_fields, t.grand_finals_advantage,
+		       t.reporting_window_minutes, t.reporting_deadline_policy, t.visibility
+		FROM tournaments t
+		WHERE EXISTS (
+			SELECT 1 FROM tournament_participants p
+			WHERE p.tournament_id = t.id AND p.user_id = $1
+		)
+	`
+	args := []interface{}{userID}
+	if len(statuses) > 0 {
+		statusStrings := make([]string, len(statuses))
+		for i, s := range statuses {
+			statusStrings[i] = string(s)
+		}
+		query += " AND t.status = ANY($2)"
+		args = append(args, pq.Array(statusStrings))
+	}
+	query += " ORDER BY COALESCE(t.start_time, t.created_at) DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tournaments for user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	tournaments := []*domain.Tournament{}
+	for rows.Next() {
+		tournament, err := scanTournament(rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, tournament)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tournaments for user %s: %w", userID, err)
+	}
+
+	return tournaments, nil
+}
+
+// GetInviteCode returns the tournament's invite code, or "" when joining by code is disabled
+func (r *tournamentRepository) GetInviteCode(ctx context.Context, id uuid.UUID) (string, error) {
+	var code sql.NullString
+	err := r.db.QueryRowContext(ctx, `SELECT invite_code FROM tournaments WHERE id = $1`, id).Scan(&code)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("tournament not found: %v", id)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get invite code of tournament %s: %w", id, err)
+	}
+	return code.String, nil
+}
+
+// SetInviteCode replaces the tournament's invite code; an empty code disables joining by code
+func (r *tournamentRepository) SetInviteCode(ctx context.Context, id uuid.UUID, code string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE tournaments SET invite_code = $1, updated_at = $2 WHERE id = $3
+	`, sql.NullString{String: code, Valid: code != ""}, time.Now(), id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrInviteCodeTaken
+		}
+		return fmt.Errorf("failed to set invite code of tournament %s: %w", id, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("tournament not found: %v", id)
+	}
+	return nil
+}
+
+// GetIDByInviteCode returns the ID of the tournament using the invite code, or uuid.Nil when
+// no tournament does
+func (r *tournamentRepository) GetIDByInviteCode(ctx context.Context, code string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM tournaments WHERE invite_code = $1`, code).Scan(&id)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to look up invite code: %w", err)
+	}
+	return id, nil
+}
+
+// Import creates a tournament together with its participants, matches and messages in one
+// transaction, so a failure part way leaves nothing behind. Matches are inserted before any is
+// linked to the matches their winners and losers advance to, as in ReplaceBracket.
+func (r *tournamentRepository) Import(
+	ctx context.Context, tournament *domain.Tournament, participants []*domain.Participant,
+	matches []*domain.Match, messages []*domain.Message,
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertTournament(ctx, tx, tournament); err != nil {
+		return fmt.Errorf("failed to create tournament: %w", err)
+	}
+	for _, participant := range participants {
+		if err := insertParticipant(ctx, tx, participant); err != nil {
+			return fmt.Errorf("failed to create participant %s: %w", participant.ID, err)
+		}
+	}
+	for _, match := range matches {
+		unlinked := *match
+		unlinked.NextMatchID = nil
+		unlinked.LoserNextMatchID = nil
+		if err := insertMatch(ctx, tx, &unlinked); err != nil {
+			return fmt.Errorf("failed to create match %s: %w", match.ID, err)
+		}
+		match.CreatedAt, match.UpdatedAt, match.Version = unlinked.CreatedAt, unlinked.UpdatedAt, unlinked.Version
+	}
+	for _, match := range matches {
+		if match.NextMatchID == nil && match.LoserNextMatchID == nil {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE matches SET next_match_id = $1, loser_next_match_id = $2
+			WHERE id = $3
+		`, match.NextMatchID, match.LoserNextMatchID, match.ID); err != nil {
+			return fmt.Errorf("failed to link match %s: %w", match.ID, err)
+		}
+	}
+	for _, message := range messages {
+		if err := insertMessage(ctx, tx, message); err != nil {
+			return fmt.Errorf("failed to create message %s: %w", message.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import: %w", err)
+	}
+	return nil
+}
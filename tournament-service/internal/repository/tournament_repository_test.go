@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// importFixture is a two-match bracket with one chat message, linked the way an archive is
+func importFixture() (*domain.Tournament, []*domain.Participant, []*domain.Match, []*domain.Message) {
+	tournament := &domain.Tournament{ID: uuid.New(), Name: "Imported", Format: domain.SingleElimination, Status: domain.Completed}
+	participants := []*domain.Participant{
+		{ID: uuid.New(), TournamentID: tournament.ID, ParticipantName: "a"},
+		{ID: uuid.New(), TournamentID: tournament.ID, ParticipantName: "b"},
+	}
+	final := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 2, MatchNumber: 1}
+	semi := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 1, MatchNumber: 1, NextMatchID: &final.ID}
+	messages := []*domain.Message{{ID: uuid.New(), TournamentID: tournament.ID, Message: "gg"}}
+	return tournament, participants, []*domain.Match{semi, final}, messages
+}
+
+func TestImportWritesEverythingInOneTransaction(t *testing.T) {
+	db := &scriptedDB{}
+	repo := NewTournamentRepository(db.open())
+	tournament, participants, matches, messages := importFixture()
+
+	if err := repo.Import(context.Background(), tournament, participants, matches, messages); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	if got := db.statements("BEGIN"); len(got) != 1 {
+		t.Fatalf("expected one transaction, got %d", len(got))
+	}
+	if got := db.statements("COMMIT"); len(got) != 1 {
+		t.Fatalf("expected the import to commit once, got %d", len(got))
+	}
+	if got := db.statements("INSERT INTO matches"); len(got) != 2 {
+		t.Fatalf("expected 2 match inserts, got %d", len(got))
+	}
+	// Only the semi-final links anywhere, and it is linked after both matches exist
+	links := db.statements("UPDATE matches SET next_match_id")
+	if len(links) != 1 {
+		t.Fatalf("expected 1 link update, got %d", len(links))
+	}
+	lastInsert, link := -1, -1
+	for i, entry := range db.log {
+		if strings.HasPrefix(entry, "INSERT INTO matches") {
+			lastInsert = i
+		}
+		if strings.HasPrefix(entry, "UPDATE matches SET next_match_id") {
+			link = i
+		}
+	}
+	if link < lastInsert {
+		t.Fatalf("match linked before every match was inserted: %v", db.log)
+	}
+	if matches[0].NextMatchID == nil || *matches[0].NextMatchID != matches[1].ID {
+		t.Fatal("Import must not clear the caller's bracket links")
+	}
+}
+
+func TestImportRollsBackWhenAnInsertFails(t *testing.T) {
+	insertErr := errors.New("insert failed")
+	db := &scriptedDB{}
+	db.exec = func(query string, args []driver.NamedValue) (driver.Result, error) {
+		if strings.HasPrefix(query, "INSERT INTO tournament_messages") {
+			return nil, insertErr
+		}
+		return driver.RowsAffected(1), nil
+	}
+	repo := NewTournamentRepository(db.open())
+	tournament, participants, matches, messages := importFixture()
+
+	err := repo.Import(context.Background(), tournament, participants, matches, messages)
+	if !errors.Is(err, insertErr) {
+		t.Fatalf("expected the insert error, got %v", err)
+	}
+	if got := db.statements("COMMIT"); len(got) != 0 {
+		t.Fatalf("a failed import must not commit: %v", db.log)
+	}
+	if got := db.statements("ROLLBACK"); len(got) != 1 {
+		t.Fatalf("expected the import to roll back, got %v", db.log)
+	}
+}
+
+func TestListByParticipantUserFiltersByStatus(t *testing.T) {
+	userID := uuid.New()
+	db := &scriptedDB{}
+	repo := NewTournamentRepository(db.open())
+
+	if _, err := repo.ListByParticipantUser(context.Background(), userID, nil); err != nil {
+		t.Fatalf("ListByParticipantUser: %v", err)
+	}
+	if _, err := repo.ListByParticipantUser(context.Background(), userID, []domain.TournamentStatus{domain.InProgress}); err != nil {
+		t.Fatalf("ListByParticipantUser: %v", err)
+	}
+
+	queries := db.statements("SELECT t.id")
+	if len(queries) != 2 {
+		t.Fatalf("expected two queries, got %v", db.log)
+	}
+	if strings.Contains(queries[0], "ANY($2)") {
+		t.Fatalf("no statuses should mean no status filter: %s", queries[0])
+	}
+	if !strings.Contains(queries[1], "t.status = ANY($2)") || !strings.Contains(queries[1], "p.user_id = $1") {
+		t.Fatalf("expected the user and status filters: %s", queries[1])
+	}
+}
+
+func TestListHidesArchivedTournamentsUnlessAsked(t *testing.T) {
+	var countArgs [][]driver.NamedValue
+	db := &scriptedDB{query: func(query string, args []driver.NamedValue) (driver.Rows, error) {
+		if strings.HasPrefix(query, "SELECT COUNT(*)") {
+			countArgs = append(countArgs, args)
+			return rowsOf([]string{"count"}, []driver.Value{int64(0)}), nil
+		}
+		return &scriptedRows{}, nil
+	}}
+	repo := NewTournamentRepository(db.open())
+
+	for _, filters := range []map[string]interface{}{{}, {"includeArchived": true}} {
+		if _, _, err := repo.List(context.Background(), filters, 1, 10); err != nil {
+			t.Fatalf("List(%v): %v", filters, err)
+		}
+	}
+
+	counts := db.statements("SELECT COUNT(*)")
+	if len(counts) != 2 {
+		t.Fatalf("expected two count queries, got %v", db.log)
+	}
+	if !strings.Contains(counts[0], "status <> $1") || countArgs[0][0].Value != domain.Archived {
+		t.Fatalf("archived tournaments should be hidden by default: %s %v", counts[0], countArgs[0])
+	}
+	if strings.Contains(counts[1], "status <>") {
+		t.Fatalf("includeArchived should drop the filter: %s", counts[1])
+	}
+}
+
+func TestGetByIDsUsesOneQuery(t *testing.T) {
+	db := &scriptedDB{}
+	repo := NewTournamentRepository(db.open())
+
+	tournaments, err := repo.GetByIDs(context.Background(), nil)
+	if err != nil || tournaments == nil || len(tournaments) != 0 {
+		t.Fatalf("no IDs should give an empty list, got %v, %v", tournaments, err)
+	}
+	if len(db.log) != 0 {
+		t.Fatalf("no IDs should not query at all, got %v", db.log)
+	}
+
+	if _, err := repo.GetByIDs(context.Background(), []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}); err != nil {
+		t.Fatalf("GetByIDs: %v", err)
+	}
+	if len(db.log) != 1 || !strings.Contains(db.log[0], "WHERE id = ANY($1)") {
+		t.Fatalf("expected a single ANY($1) query, got %v", db.log)
+	}
+}
+
+func TestListingsForAViewerHideOtherTournaments(t *testing.T) {
+	var countArgs [][]driver.NamedValue
+	db := &scriptedDB{query: func(query string, args []driver.NamedValue) (driver.Rows, error) {
+		if strings.HasPrefix(query, "SELECT COUNT(*)") {
+			countArgs = append(countArgs, args)
+			return rowsOf([]string{"count"}, []driver.Value{int64(0)}), nil
+		}
+		return &scriptedRows{}, nil
+	}}
+	repo := NewTournamentRepository(db.open())
+	viewer := uuid.New()
+
+	if _, _, err := repo.List(context.Background(), map[string]interface{}{"visibleTo": uuid.Nil}, 1, 10); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if _, _, err := repo.GetByStatuses(context.Background(), []domain.TournamentStatus{domain.Registration}, &viewer, 10, 0); err != nil {
+		t.Fatalf("GetByStatuses: %v", err)
+	}
+	if _, _, err := repo.GetByStatuses(context.Background(), []domain.TournamentStatus{domain.Registration}, nil, 10, 0); err != nil {
+		t.Fatalf("GetByStatuses: %v", err)
+	}
+
+	counts := db.statements("SELECT COUNT(*)")
+	if len(counts) != 3 {
+		t.Fatalf("expected three count queries, got %v", db.log)
+	}
+	// Anonymous callers are matched as uuid.Nil, which no creator or participant has
+	if !strings.Contains(counts[0], "visibility = 'PUBLIC' OR created_by::text = $2") || countArgs[0][1].Value != uuid.Nil.String() {
+		t.Fatalf("anonymous listing should only show public tournaments: %s %v", counts[0], countArgs[0])
+	}
+	if !strings.Contains(counts[1], "status = ANY($1) AND (visibility = 'PUBLIC'") || countArgs[1][1].Value != viewer.String() {
+		t.Fatalf("expected the status and viewer filters: %s %v", counts[1], countArgs[1])
+	}
+	if !strings.Contains(counts[1], "vp.user_id::text = $2") || !strings.Contains(counts[1], "co_organizers' ? $2") {
+		t.Fatalf("participants and co-organizers should see the tournament: %s", counts[1])
+	}
+	if strings.Contains(counts[2], "visibility") {
+		t.Fatalf("no viewer should mean no visibility filter: %s", counts[2])
+	}
+}
+
+func TestSetInviteCode(t *testing.T) {
+	var stored []driver.Value
+	db := &scriptedDB{exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+		if args[0].Value == (sql.NullString{String: "TAKEN123", Valid: true}) {
+			return nil, &pq.Error{Code: "23505"}
+		}
+		stored = append(stored, args[0].Value)
+		return driver.RowsAffected(1), nil
+	}}
+	repo := NewTournamentRepository(db.open())
+	id := uuid.New()
+
+	if err := repo.SetInviteCode(context.Background(), id, "TAKEN123"); !errors.Is(err, ErrInviteCodeTaken) {
+		t.Fatalf("a unique violation should be ErrInviteCodeTaken, got %v", err)
+	}
+	for _, code := range []string{"ABCD2345", ""} {
+		if err := repo.SetInviteCode(context.Background(), id, code); err != nil {
+			t.Fatalf("SetInviteCode(%q): %v", code, err)
+		}
+	}
+	// Disabling stores NULL, so the unique index allows any number of tournaments without a code
+	if len(stored) != 2 || stored[0] != (sql.NullString{String: "ABCD2345", Valid: true}) || stored[1] != (sql.NullString{}) {
+		t.Fatalf("expected the code and then NULL, got %v", stored)
+	}
+}
+
+func TestCountActiveByCreatorSkipsArchivedTournaments(t *testing.T) {
+	var query string
+	var args []driver.NamedValue
+	db := &scriptedDB{query: func(q string, a []driver.NamedValue) (driver.Rows, error) {
+		query, args = q, a
+		return rowsOf([]string{"count"}, []driver.Value{int64(4)}), nil
+	}}
+	creator := uuid.New()
+
+	count, err := NewTournamentRepository(db.open()).CountActiveByCreator(context.Background(), creator)
+	if err != nil || count != 4 {
+		t.Fatalf("expected 4, got %d (%v)", count, err)
+	}
+	if !strings.Contains(query, "created_by = $1 AND status <> $2") || len(args) != 2 || args[0].Value != creator || args[1].Value != domain.Archived {
+		t.Fatalf("expected the creator's non-archived tournaments to be counted, got %s with %+v", query, args)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time" // For CreatedAt
 
 	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
 	"github.com/cliffdoyle/tournament-service/internal/repository"
 	"github.com/google/uuid"
 	"log" // For logging
@@ -14,21 +15,21 @@ import (
 
 type UserActivityService interface {
 	RecordActivity(ctx context.Context, userID uuid.UUID, activityType domain.ActivityType, description string, relatedEntityID *uuid.UUID, relatedEntityType *domain.RelatedEntityType, contextURL *string) (*domain.UserActivity, error)
-	GetUserActivities(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*domain.UserActivity, int, error)
+	GetUserActivities(ctx context.Context, userID uuid.UUID, activityType domain.ActivityType, page, pageSize int) ([]*domain.UserActivity, int, error)
 }
 
 type userActivityService struct {
 	activityRepo repository.UserActivityRepository
 	// Potentially other repos if needed to enrich activity data, e.g., tournamentRepo to get tournament name
 	tournamentRepo repository.TournamentRepository // Example
-	broadcastChan  chan<- domain.WebSocketMessage // Add this
+	broadcastChan  chan<- domain.WebSocketMessage  // Add this
 }
 
-func NewUserActivityService(activityRepo repository.UserActivityRepository, tournamentRepo repository.TournamentRepository,broadcastChan chan<-domain.WebSocketMessage) UserActivityService {
+func NewUserActivityService(activityRepo repository.UserActivityRepository, tournamentRepo repository.TournamentRepository, broadcastChan chan<- domain.WebSocketMessage) UserActivityService {
 	return &userActivityService{
 		activityRepo:   activityRepo,
 		tournamentRepo: tournamentRepo, // Buffered channel for broadcasting
-		broadcastChan: broadcastChan, // Injected broadcast channel
+		broadcastChan:  broadcastChan,  // Injected broadcast channel
 	}
 }
 
@@ -41,7 +42,7 @@ func (s *userActivityService) RecordActivity(
 	relatedEntityType *domain.RelatedEntityType,
 	contextURL *string,
 ) (*domain.UserActivity, error) {
-	
+
 	if description == "" { // Autofill description if possible based on type and related entity
 		if relatedEntityID != nil && relatedEntityType != nil {
 			switch *relatedEntityType {
@@ -60,30 +61,37 @@ func (s *userActivityService) RecordActivity(
 					} else {
 						// Log warning: could not fetch tournament details for description
 						// description will remain as passed or default for the type
-						if description == "" { description = fmt.Sprintf("%s an item", activityType) }
+						if description == "" {
+							description = fmt.Sprintf("%s an item", activityType)
+						}
 					}
 				} else {
-					if description == "" { description = fmt.Sprintf("%s for entity %s", activityType, relatedEntityID.String())}
+					if description == "" {
+						description = fmt.Sprintf("%s for entity %s", activityType, relatedEntityID.String())
+					}
 				}
 			// Add cases for EntityTypeMatch etc.
 			default:
-				if description == "" { description = fmt.Sprintf("%s an entity", activityType) }
+				if description == "" {
+					description = fmt.Sprintf("%s an entity", activityType)
+				}
 			}
 		} else {
-            if description == "" { description = fmt.Sprintf("Performed action: %s", activityType)}
-        }
+			if description == "" {
+				description = fmt.Sprintf("Performed action: %s", activityType)
+			}
+		}
 	}
 
-
 	activity := &domain.UserActivity{
 		// ID will be generated by repo or DB
-		UserID:              userID,
-		ActivityType:        activityType,
-		Description:         description,
-		RelatedEntityID:     relatedEntityID,
-		RelatedEntityType:   relatedEntityType,
-		ContextURL:          contextURL,
-		CreatedAt:           time.Now(), // Set creation time in service
+		UserID:            userID,
+		ActivityType:      activityType,
+		Description:       description,
+		RelatedEntityID:   relatedEntityID,
+		RelatedEntityType: relatedEntityType,
+		ContextURL:        contextURL,
+		CreatedAt:         time.Now(), // Set creation time in service
 	}
 
 	err := s.activityRepo.Create(ctx, activity)
@@ -91,7 +99,6 @@ func (s *userActivityService) RecordActivity(
 		return nil, fmt.Errorf("failed to record activity: %w", err)
 	}
 
-	
 	if s.broadcastChan != nil {
 		wsPayload := domain.NewUserActivityPayload{
 			Activity:  *activity,
@@ -101,26 +108,33 @@ func (s *userActivityService) RecordActivity(
 			Type:    domain.WSEventNewUserActivity,
 			Payload: wsPayload,
 		}
-        // The hub will Marshal, send the struct directly
+		// The hub will Marshal, send the struct directly
 		s.broadcastChan <- wsMessage
-        log.Printf("Broadcasted WSEventNewUserActivity for U-%s (Activity: %s)", activity.UserID, activity.ID)
+		logging.Infof(ctx, "Broadcasted WSEventNewUserActivity for U-%s (Activity: %s)", activity.UserID, activity.ID)
 	} else {
-        log.Println("Warning: userActivityService.broadcastChan is nil. Cannot broadcast new activity.")
+		log.Println("Warning: userActivityService.broadcastChan is nil. Cannot broadcast new activity.")
 	}
 	return activity, nil
 }
 
-func (s *userActivityService) GetUserActivities(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*domain.UserActivity, int, error) {
-	if page < 1 { page = 1 }
-	if pageSize < 1 { pageSize = 10 }
-    if pageSize > 50 { pageSize = 50 } // Max activities per page for dashboard
+// GetUserActivities pages through a user's activity feed, optionally limited to one activity type
+func (s *userActivityService) GetUserActivities(ctx context.Context, userID uuid.UUID, activityType domain.ActivityType, page, pageSize int) ([]*domain.UserActivity, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 50 {
+		pageSize = 50
+	} // Max activities per page for dashboard
 	offset := (page - 1) * pageSize
 
-	activities, total, err := s.activityRepo.GetByUserID(ctx, userID, pageSize, offset)
+	activities, total, err := s.activityRepo.GetByUserID(ctx, userID, activityType, pageSize, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get user activities: %w", err)
 	}
-	
+
 	// The repository now directly returns domain.UserActivity which has 'date' as json tag for CreatedAt
 	return activities, total, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// archiveMessagePageSize is the page size used when collecting chat history for an archive
+const archiveMessagePageSize = 100
+
+// ExportTournament builds a self-contained archive of a tournament, its participants, matches and
+// messages. The archive includes match threads, which only the match's players and the organizers
+// may read, so only organizers can export.
+func (s *tournamentService) ExportTournament(ctx context.Context, tournamentID, userID uuid.UUID) (*domain.TournamentArchive, error) {
+	tournament, err := s.getManagedTournament(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	if participants == nil {
+		participants = []*domain.Participant{}
+	}
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	// Page through the whole chat history, tournament-wide and per match; the repository caps a single read
+	messages := []*domain.Message{}
+	for offset := 0; ; offset += archiveMessagePageSize {
+		page, err := s.messageRepo.ListByTournament(ctx, tournamentID, archiveMessagePageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get messages: %w", err)
+		}
+		messages = append(messages, page...)
+		if len(page) < archiveMessagePageSize {
+			break
+		}
+	}
+	for _, match := range matches {
+		for offset := 0; ; offset += archiveMessagePageSize {
+			page, err := s.messageRepo.ListByMatch(ctx, match.ID, archiveMessagePageSize, offset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get messages for match %s: %w", match.ID, err)
+			}
+			messages = append(messages, page...)
+			if len(page) < archiveMessagePageSize {
+				break
+			}
+		}
+	}
+
+	return &domain.TournamentArchive{
+		Version:      domain.ArchiveVersion,
+		ExportedAt:   time.Now().UTC(),
+		Tournament:   tournament,
+		Participants: participants,
+		Matches:      matches,
+		Messages:     messages,
+	}, nil
+}
+
+// ImportTournament recreates a tournament from an archive. Every entity receives a new ID
+// and all internal references (participants in matches, bracket links) are rewritten to match.
+func (s *tournamentService) ImportTournament(
+	ctx context.Context, archive *domain.TournamentArchive, importerID uuid.UUID,
+) (*domain.ImportResult, error) {
+	if archive == nil || archive.Tournament == nil {
+		return nil, domain.NewError(domain.ErrValidation, "archive does not contain a tournament")
+	}
+	if archive.Version != domain.ArchiveVersion {
+		return nil, domain.NewError(domain.ErrValidation,
+			fmt.Sprintf("unsupported archive version %d (expected %d)", archive.Version, domain.ArchiveVersion))
+	}
+
+	// The archived settings are checked like a new tournament's. The game was accepted when the
+	// source was created, even if it is a custom one.
+	source := archive.Tournament
+	request := &domain.CreateTournamentRequest{
+		Name:                    source.Name,
+		Description:             source.Description,
+		Game:                    source.Game,
+		Format:                  source.Format,
+		MaxParticipants:         source.MaxParticipants,
+		RegistrationDeadline:    source.RegistrationDeadline,
+		StartTime:               source.StartTime,
+		Rules:                   source.Rules,
+		PrizePool:               source.PrizePool,
+		CustomFields:            source.CustomFields,
+		GrandFinalsAdvantage:    source.GrandFinalsAdvantage,
+		ReportingWindowMinutes:  source.ReportingWindowMinutes,
+		ReportingDeadlinePolicy: source.ReportingDeadlinePolicy,
+		AllowCustomGame:         true,
+		Visibility:              source.Visibility,
+	}
+	if err := checkCreateRequest(request); err != nil {
+		return nil, err
+	}
+	status := source.Status
+	if status == "" {
+		status = domain.Draft
+	}
+	if !domain.IsValidStatus(status) {
+		return nil, domain.NewError(domain.ErrValidation, fmt.Sprintf("unknown tournament status %q", status))
+	}
+	if err := s.checkCreationQuota(ctx, importerID); err != nil {
+		return nil, err
+	}
+
+	idMapping := make(map[uuid.UUID]uuid.UUID)
+	remap := func(id *uuid.UUID) *uuid.UUID {
+		if id == nil {
+			return nil
+		}
+		newID, ok := idMapping[*id]
+		if !ok {
+			return nil
+		}
+		return &newID
+	}
+
+	// Assign new IDs up front so matches can reference participants and each other
+	for _, p := range archive.Participants {
+		idMapping[p.ID] = uuid.New()
+	}
+	for _, m := range archive.Matches {
+		idMapping[m.ID] = uuid.New()
+	}
+
+	tournament := &domain.Tournament{
+		ID:                      uuid.New(),
+		Name:                    request.Name,
+		Description:             request.Description,
+		Game:                    domain.NormalizeGame(request.Game),
+		Format:                  request.Format,
+		Status:                  status,
+		MaxParticipants:         request.MaxParticipants,
+		RegistrationDeadline:    request.RegistrationDeadline,
+		StartTime:               request.StartTime,
+		EndTime:                 source.EndTime,
+		CreatedBy:               importerID,
+		Rules:                   request.Rules,
+		PrizePool:               request.PrizePool,
+		CustomFields:            request.CustomFields,
+		GrandFinalsAdvantage:    request.GrandFinalsAdvantage,
+		ReportingWindowMinutes:  request.ReportingWindowMinutes,
+		ReportingDeadlinePolicy: request.ReportingDeadlinePolicy,
+		Visibility:              request.Visibility,
+	}
+	if tournament.Visibility == "" {
+		tournament.Visibility = domain.VisibilityPublic
+	}
+	idMapping[source.ID] = tournament.ID
+
+	participants := make([]*domain.Participant, len(archive.Participants))
+	for i, p := range archive.Participants {
+		participants[i] = &domain.Participant{
+			ID:              idMapping[p.ID],
+			TournamentID:    tournament.ID,
+			UserID:          p.UserID,
+			ParticipantName: p.ParticipantName,
+			Seed:            p.Seed,
+			Status:          p.Status,
+			IsWaitlisted:    p.IsWaitlisted,
+		}
+	}
+
+	matches := make([]*domain.Match, len(archive.Matches))
+	for i, m := range archive.Matches {
+		matches[i] = &domain.Match{
+			ID:                        idMapping[m.ID],
+			TournamentID:              tournament.ID,
+			Round:                     m.Round,
+			MatchNumber:               m.MatchNumber,
+			Participant1ID:            remap(m.Participant1ID),
+			Participant2ID:            remap(m.Participant2ID),
+			WinnerID:                  remap(m.WinnerID),
+			LoserID:                   remap(m.LoserID),
+			ScoreParticipant1:         m.ScoreParticipant1,
+			ScoreParticipant2:         m.ScoreParticipant2,
+			Status:                    m.Status,
+			ScheduledTime:             m.ScheduledTime,
+			CompletedTime:             m.CompletedTime,
+			ReportingDeadline:         m.ReportingDeadline,
+			MatchNotes:                m.MatchNotes,
+			MatchProofs:               m.MatchProofs,
+			Games:                     m.Games,
+			BracketType:               m.BracketType,
+			NextMatchID:               remap(m.NextMatchID),
+			LoserNextMatchID:          remap(m.LoserNextMatchID),
+			Participant1PrereqMatchID: remap(m.Participant1PrereqMatchID),
+			Participant2PrereqMatchID: remap(m.Participant2PrereqMatchID),
+		}
+	}
+
+	messages := make([]*domain.Message, len(archive.Messages))
+	for i, msg := range archive.Messages {
+		messages[i] = &domain.Message{
+			ID:           uuid.New(),
+			TournamentID: tournament.ID,
+			MatchID:      remap(msg.MatchID),
+			// The importer posts the copies; the original authors did not take part in the import
+			UserID:    importerID,
+			Message:   msg.Message,
+			CreatedAt: msg.CreatedAt,
+			EditedAt:  msg.EditedAt,
+		}
+		idMapping[msg.ID] = messages[i].ID
+	}
+
+	// Everything is written in one transaction, so a failed import leaves no partial tournament
+	if err := s.tournamentRepo.Import(ctx, tournament, participants, matches, messages); err != nil {
+		return nil, fmt.Errorf("failed to import tournament: %w", err)
+	}
+
+	// Like a new tournament, the copy gets its own join code
+	inviteCode, err := s.assignInviteCode(ctx, tournament.ID)
+	if err != nil {
+		logging.Warnf(ctx, "ImportTournament - Failed to assign invite code to T-%s: %v", tournament.ID, err)
+	}
+	tournament.InviteCode = inviteCode
+
+	logging.Infof(ctx, "Imported tournament %s as %s (%d participants, %d matches, %d messages)",
+		source.ID, tournament.ID, len(archive.Participants), len(archive.Matches), len(archive.Messages))
+
+	return &domain.ImportResult{
+		Tournament:       tournament,
+		ParticipantCount: len(archive.Participants),
+		MatchCount:       len(archive.Matches),
+		MessageCount:     len(archive.Messages),
+		IDMapping:        idMapping,
+	}, nil
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.Completed })
+	players := env.players(tournament.ID, 2)
+
+	final := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 2, MatchNumber: 1, Status: domain.MatchPending}
+	semi := &domain.Match{
+		ID: uuid.New(), TournamentID: tournament.ID, Round: 1, MatchNumber: 1,
+		Participant1ID: &players[0].ID, Participant2ID: &players[1].ID, WinnerID: &players[0].ID,
+		Status: domain.MatchCompleted, NextMatchID: &final.ID,
+	}
+	env.store.putMatch(semi)
+	env.store.putMatch(final)
+	for _, message := range []*domain.Message{
+		{TournamentID: tournament.ID, UserID: organizer, Message: "welcome"},
+		{TournamentID: tournament.ID, MatchID: &semi.ID, UserID: organizer, Message: "good luck"},
+	} {
+		if err := env.service.messageRepo.Create(ctx, message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive, err := env.service.ExportTournament(ctx, tournament.ID, organizer)
+	if err != nil {
+		t.Fatalf("ExportTournament: %v", err)
+	}
+	if len(archive.Participants) != 2 || len(archive.Matches) != 2 || len(archive.Messages) != 2 {
+		t.Fatalf("archive is missing data: %d participants, %d matches, %d messages",
+			len(archive.Participants), len(archive.Matches), len(archive.Messages))
+	}
+
+	importer := uuid.New()
+	result, err := env.service.ImportTournament(ctx, archive, importer)
+	if err != nil {
+		t.Fatalf("ImportTournament: %v", err)
+	}
+	if result.Tournament.ID == tournament.ID || result.Tournament.CreatedBy != importer {
+		t.Fatalf("import should create a new tournament owned by the importer, got %+v", result.Tournament)
+	}
+
+	newSemi := env.match(t, result.IDMapping[semi.ID])
+	if newSemi.TournamentID != result.Tournament.ID {
+		t.Fatal("imported match belongs to the wrong tournament")
+	}
+	if *newSemi.Participant1ID != result.IDMapping[players[0].ID] || *newSemi.WinnerID != result.IDMapping[players[0].ID] {
+		t.Fatal("participant references were not rewritten to the imported participants")
+	}
+	if newSemi.NextMatchID == nil || *newSemi.NextMatchID != result.IDMapping[final.ID] {
+		t.Fatal("bracket link was not rewritten to the imported final")
+	}
+	matchMessages, _ := env.service.messageRepo.ListByMatch(ctx, newSemi.ID, 10, 0)
+	if len(matchMessages) != 1 || matchMessages[0].Message != "good luck" {
+		t.Fatalf("match chat was not carried over: %+v", matchMessages)
+	}
+	if matchMessages[0].UserID != importer {
+		t.Fatalf("imported messages should be posted by the importer, got author %s", matchMessages[0].UserID)
+	}
+}
+
+func TestImportKeepsVisibilityAndAssignsAnInviteCode(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) {
+		t.Visibility = domain.VisibilityPrivate
+		t.Game = "FIFA 23"
+	})
+	archive, err := env.service.ExportTournament(ctx, tournament.ID, organizer)
+	if err != nil {
+		t.Fatalf("ExportTournament: %v", err)
+	}
+
+	result, err := env.service.ImportTournament(ctx, archive, organizer)
+	if err != nil {
+		t.Fatalf("ImportTournament: %v", err)
+	}
+	stored := env.store.tournaments[result.Tournament.ID]
+	if stored.Visibility != domain.VisibilityPrivate || stored.Game != "fifa23" {
+		t.Fatalf("expected a private fifa23 tournament, got %s %q", stored.Visibility, stored.Game)
+	}
+	if code := env.store.inviteCodes[stored.ID]; code == "" || result.Tournament.InviteCode != code {
+		t.Fatalf("expected the import to get its own invite code, got %q (stored %q)", result.Tournament.InviteCode, code)
+	}
+}
+
+func TestImportRejectsInvalidSettings(t *testing.T) {
+	env := newTestEnv(t)
+	for name, configure := range map[string]func(*domain.Tournament){
+		"blank name":         func(t *domain.Tournament) { t.Name = "  " },
+		"unknown format":     func(t *domain.Tournament) { t.Format = "KNOCKOUT" },
+		"unknown visibility": func(t *domain.Tournament) { t.Visibility = "SECRET" },
+		"unknown status":     func(t *domain.Tournament) { t.Status = "PAUSED" },
+		"too few players":    func(t *domain.Tournament) { t.MaxParticipants = 1 },
+		"negative window":    func(t *domain.Tournament) { t.ReportingWindowMinutes = -5 },
+	} {
+		source := &domain.Tournament{Name: "Archived Cup", Game: "chess", MaxParticipants: 8}
+		configure(source)
+		archive := &domain.TournamentArchive{Version: domain.ArchiveVersion, Tournament: source}
+		if _, err := env.service.ImportTournament(context.Background(), archive, uuid.New()); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+	if len(env.store.tournaments) != 0 {
+		t.Fatal("a rejected archive must not create a tournament")
+	}
+}
+
+func TestImportRejectsUnknownArchiveVersion(t *testing.T) {
+	env := newTestEnv(t)
+	archive := &domain.TournamentArchive{Version: domain.ArchiveVersion + 1, Tournament: &domain.Tournament{Name: "future"}}
+
+	if _, err := env.service.ImportTournament(context.Background(), archive, uuid.New()); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected an unsupported version error, got %v", err)
+	}
+	if len(env.store.tournaments) != 0 {
+		t.Fatal("a rejected archive must not create a tournament")
+	}
+}
+
+func TestExportUnknownTournament(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.service.ExportTournament(context.Background(), uuid.New(), uuid.New())
+	var notFound *ErrTournamentNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected ErrTournamentNotFound, got %v", err)
+	}
+}
+
+func TestOnlyOrganizersCanExport(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New())
+	player := env.players(tournament.ID, 1)[0]
+
+	// The archive holds match threads a player outside the match may not read
+	for _, userID := range []uuid.UUID{*player.UserID, uuid.New()} {
+		if _, err := env.service.ExportTournament(context.Background(), tournament.ID, userID); !errors.Is(err, domain.ErrForbidden) {
+			t.Fatalf("expected a non-organizer's export to be forbidden, got %v", err)
+		}
+	}
+}
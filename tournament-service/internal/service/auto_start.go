@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// defaultAutoStartMinParticipants is how many confirmed participants an auto-started
+// tournament needs unless it sets custom_fields.auto_start_min_participants
+const defaultAutoStartMinParticipants = 2
+
+// autoStartSettings reads {"auto_start": true, "auto_start_min_participants": 4} from a
+// tournament's custom_fields
+func autoStartSettings(tournament *domain.Tournament) (enabled bool, minParticipants int) {
+	if len(tournament.CustomFields) == 0 {
+		return false, defaultAutoStartMinParticipants
+	}
+	var fields struct {
+		AutoStart       bool `json:"auto_start"`
+		MinParticipants int  `json:"auto_start_min_participants"`
+	}
+	if err := json.Unmarshal(tournament.CustomFields, &fields); err != nil {
+		log.Printf("Warning: could not read auto-start settings of tournament %s: %v", tournament.ID, err)
+		return false, defaultAutoStartMinParticipants
+	}
+	if fields.MinParticipants < defaultAutoStartMinParticipants {
+		fields.MinParticipants = defaultAutoStartMinParticipants
+	}
+	return fields.AutoStart, fields.MinParticipants
+}
+
+// isAutoStartDue reports whether an opted-in tournament still in registration has reached its
+// start time with enough confirmed participants
+func isAutoStartDue(tournament *domain.Tournament, confirmed int, now time.Time) bool {
+	enabled, minParticipants := autoStartSettings(tournament)
+	if !enabled || tournament.Status != domain.Registration || tournament.StartTime == nil {
+		return false
+	}
+	return !now.Before(*tournament.StartTime) && confirmed >= minParticipants
+}
+
+// AutoStartTournament generates the bracket of a tournament that opted into auto_start and whose
+// start time has passed, then moves it to in progress on behalf of its creator. Tournaments
+// that are not due are left alone and reported as not started.
+func (s *tournamentService) AutoStartTournament(ctx context.Context, tournamentID uuid.UUID, now time.Time) (bool, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get participants: %w", err)
+	}
+	if !isAutoStartDue(tournament, len(confirmedParticipants(participants)), now) {
+		return false, nil
+	}
+
+	if err := s.GenerateBracket(ctx, tournamentID, tournament.CreatedBy, false); err != nil {
+		return false, fmt.Errorf("failed to generate bracket: %w", err)
+	}
+	if err := s.updateTournamentStatus(ctx, tournamentID, domain.InProgress); err != nil {
+		return false, fmt.Errorf("failed to start tournament: %w", err)
+	}
+	logging.Infof(ctx, "Tournament %s auto-started at its start time %s", tournamentID, tournament.StartTime.Format(time.RFC3339))
+
+	if s.broadcastChan != nil {
+		matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+		if err != nil {
+			logging.Warnf(ctx, "AutoStartTournament - failed to count matches of tournament %s: %v", tournamentID, err)
+		}
+		s.broadcastChan <- domain.WebSocketMessage{
+			Type: domain.WSEventTournamentStarted,
+			Payload: domain.TournamentStartedPayload{
+				TournamentID: tournamentID,
+				MatchCount:   len(matches),
+				AutoStarted:  true,
+			},
+		}
+		logging.Infof(ctx, "Broadcasted WSEventTournamentStarted for T-%s", tournamentID)
+	}
+	return true, nil
+}
+
+// TournamentAutoStarter periodically starts tournaments in registration that opted into
+// auto_start once their start time has passed
+type TournamentAutoStarter struct {
+	service  TournamentService
+	interval time.Duration
+	now      func() time.Time // Clock, replaceable so checks can run at a chosen time
+}
+
+// NewTournamentAutoStarter creates a starter that checks every interval
+func NewTournamentAutoStarter(service TournamentService, interval time.Duration) *TournamentAutoStarter {
+	return &TournamentAutoStarter{service: service, interval: interval, now: time.Now}
+}
+
+// Run starts due tournaments until ctx is cancelled
+func (a *TournamentAutoStarter) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.check(ctx)
+		}
+	}
+}
+
+func (a *TournamentAutoStarter) check(ctx context.Context) {
+	const pageSize = 50
+	filters := map[string]interface{}{"status": domain.Registration}
+	now := a.now()
+
+	// Collect first: started tournaments leave the registration list and would shift its pages
+	var due []uuid.UUID
+	for page := 1; ; page++ {
+		tournaments, total, err := a.service.ListTournaments(ctx, filters, page, pageSize)
+		if err != nil {
+			logging.Warnf(ctx, "TournamentAutoStarter: failed to list tournaments in registration: %v", err)
+			return
+		}
+		for _, tournament := range tournaments {
+			if tournament.StartTime != nil && !now.Before(*tournament.StartTime) {
+				due = append(due, tournament.ID)
+			}
+		}
+		if page*pageSize >= total {
+			break
+		}
+	}
+
+	for _, id := range due {
+		if _, err := a.service.AutoStartTournament(ctx, id, now); err != nil {
+			logging.Warnf(ctx, "TournamentAutoStarter: failed to start tournament %s: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// autoStart configures a tournament to start at startTime with the given custom fields
+func autoStart(startTime time.Time, customFields string) func(*domain.Tournament) {
+	return func(t *domain.Tournament) {
+		t.StartTime = &startTime
+		if customFields != "" {
+			t.CustomFields = json.RawMessage(customFields)
+		}
+	}
+}
+
+func TestAutoStarterStartsOnlyEligibleTournaments(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	now := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+
+	due := env.tournament(uuid.New(), autoStart(past, `{"auto_start": true}`))
+	notOptedIn := env.tournament(uuid.New(), autoStart(past, ""))
+	notYet := env.tournament(uuid.New(), autoStart(future, `{"auto_start": true}`))
+	tooFew := env.tournament(uuid.New(), autoStart(past, `{"auto_start": true, "auto_start_min_participants": 6}`))
+	draft := env.tournament(uuid.New(), autoStart(past, `{"auto_start": true}`), func(t *domain.Tournament) { t.Status = domain.Draft })
+	for _, tournament := range []*domain.Tournament{due, notOptedIn, notYet, tooFew, draft} {
+		env.players(tournament.ID, 4)
+	}
+	// Waitlisted players do not count towards the minimum
+	for _, p := range env.players(tooFew.ID, 2) {
+		env.store.participants[p.ID].IsWaitlisted = true
+	}
+
+	starter := NewTournamentAutoStarter(env.service, time.Minute)
+	starter.now = func() time.Time { return now }
+	starter.check(ctx)
+
+	if status := env.store.tournaments[due.ID].Status; status != domain.InProgress {
+		t.Fatalf("the due tournament should be in progress, got %s", status)
+	}
+	if matches := env.storedMatches(due.ID); len(matches) != 3 {
+		t.Fatalf("expected the due tournament's bracket of 3 matches, got %d", len(matches))
+	}
+	for name, tournament := range map[string]*domain.Tournament{
+		"not opted in": notOptedIn, "not yet due": notYet, "too few players": tooFew, "draft": draft,
+	} {
+		if status := env.store.tournaments[tournament.ID].Status; status != tournament.Status {
+			t.Errorf("%s: status changed to %s", name, status)
+		}
+		if matches := env.storedMatches(tournament.ID); len(matches) != 0 {
+			t.Errorf("%s: a bracket was generated", name)
+		}
+	}
+
+	var started []domain.TournamentStartedPayload
+	for _, event := range env.drainEvents() {
+		if event.Type == domain.WSEventTournamentStarted {
+			started = append(started, event.Payload.(domain.TournamentStartedPayload))
+		}
+	}
+	if len(started) != 1 || started[0].TournamentID != due.ID || started[0].MatchCount != 3 || !started[0].AutoStarted {
+		t.Fatalf("expected one auto-start event for the due tournament, got %+v", started)
+	}
+
+	// Later checks leave the started tournament alone
+	starter.now = func() time.Time { return future }
+	starter.check(ctx)
+	if status := env.store.tournaments[notYet.ID].Status; status != domain.InProgress {
+		t.Fatalf("the later tournament should start once due, got %s", status)
+	}
+	if matches := env.storedMatches(due.ID); len(matches) != 3 {
+		t.Fatalf("the started tournament's bracket changed to %d matches", len(matches))
+	}
+}
+
+func TestAutoStartSettings(t *testing.T) {
+	for _, c := range []struct {
+		fields  string
+		enabled bool
+		min     int
+	}{
+		{"", false, 2},
+		{`{"auto_start": true}`, true, 2},
+		{`{"auto_start": true, "auto_start_min_participants": 8}`, true, 8},
+		{`{"auto_start": true, "auto_start_min_participants": 1}`, true, 2},
+		{`not json`, false, 2},
+	} {
+		tournament := &domain.Tournament{ID: uuid.New(), CustomFields: json.RawMessage(c.fields)}
+		if enabled, min := autoStartSettings(tournament); enabled != c.enabled || min != c.min {
+			t.Errorf("%q: expected (%v, %d), got (%v, %d)", c.fields, c.enabled, c.min, enabled, min)
+		}
+	}
+}
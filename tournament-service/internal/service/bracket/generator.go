@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/service/bracket/seeding"
 	"github.com/google/uuid"
 )
 
@@ -54,7 +55,17 @@ func (g *SingleEliminationGenerator) Generate(ctx context.Context, tournamentID
 
 	switch format {
 	case SingleElimination:
-		matches, _, err := g.generateSingleElimination(ctx, tournamentID, participants)
+		// "full_bracket" lays out every first-round slot, byes included, instead of the compact form.
+		// The full layout always seeds in standard order, so "bye_strategy" only affects the compact one.
+		if full, ok := options["full_bracket"].(bool); ok && full {
+			return g.generateFullSingleElimination(tournamentID, participants)
+		}
+		strategyName, _ := options["bye_strategy"].(string)
+		strategy, err := seeding.ParseByeStrategy(strategyName)
+		if err != nil {
+			return nil, err
+		}
+		matches, _, err := g.generateSingleElimination(ctx, tournamentID, participants, strategy)
 		return matches, err
 	case DoubleElimination:
 		doubleGenerator := NewDoubleEliminationGenerator()
@@ -79,7 +90,7 @@ func (g *SingleEliminationGenerator) Generate(ctx context.Context, tournamentID
 // generateSingleElimination creates a single elimination bracket
 
 // generateSingleElimination creates a single elimination bracket
-func (g *SingleEliminationGenerator) generateSingleElimination(ctx context.Context, tournamentID uuid.UUID, participants []*domain.Participant) ([]*domain.Match, [][]*domain.Match, error) {
+func (g *SingleEliminationGenerator) generateSingleElimination(ctx context.Context, tournamentID uuid.UUID, participants []*domain.Participant, strategy seeding.ByeStrategy) ([]*domain.Match, [][]*domain.Match, error) {
 	if len(participants) < 2 {
 		return nil, nil, errors.New("at least 2 participants are required for a tournament")
 	}
@@ -96,14 +107,14 @@ func (g *SingleEliminationGenerator) generateSingleElimination(ctx context.Conte
 	// Calculate the number of rounds needed
 	numParticipants := len(participantsCopy)
 	numRounds := int(math.Ceil(math.Log2(float64(numParticipants))))
-	participantsPowerOfTwo := nextPowerOfTwo(numParticipants)
+	participantsPowerOfTwo := seeding.NextPowerOfTwo(numParticipants)
 
 	// Create matches list
 	matches := make([]*domain.Match, 0)
 	matchCounter := 1
 
-	// Apply Challonge-style seeding
-	seededParticipants := applyChallongeSeeding(participantsCopy, participantsPowerOfTwo)
+	// Place the seeds in bracket slots according to the bye strategy
+	seededParticipants := seeding.Seed(participantsCopy, participantsPowerOfTwo, strategy)
 
 	// Initialize arrays to track matches in each round
 	roundMatches := make([][]*domain.Match, numRounds+1)
@@ -118,9 +129,18 @@ func (g *SingleEliminationGenerator) generateSingleElimination(ctx context.Conte
 
 	// Process participants who get byes first (no first round match)
 	byeParticipants := make([]*domain.Participant, 0, byeCount)
-	for i := 0; i < byeCount*2; i += 2 {
-		if i < len(seededParticipants) && seededParticipants[i] != nil {
-			byeParticipants = append(byeParticipants, seededParticipants[i])
+	if strategy == seeding.ByesStandard {
+		// Standard slots pair up, so a player whose opponent slot is empty has the bye
+		for i := 0; i+1 < len(seededParticipants); i += 2 {
+			if p := byeHolder(seededParticipants[i], seededParticipants[i+1]); p != nil {
+				byeParticipants = append(byeParticipants, p)
+			}
+		}
+	} else {
+		for i := 0; i < byeCount*2; i += 2 {
+			if i < len(seededParticipants) && seededParticipants[i] != nil {
+				byeParticipants = append(byeParticipants, seededParticipants[i])
+			}
 		}
 	}
 
@@ -169,14 +189,27 @@ func (g *SingleEliminationGenerator) generateSingleElimination(ctx context.Conte
 	// we put the two different categories in an interface slice
 	var round2Participants []interface{}
 
-	// Add the byes participants to the interface
-	for _, p := range byeParticipants {
-		round2Participants = append(round2Participants, p)
-	}
+	if strategy == seeding.ByesStandard {
+		// Keep bracket order, so each bye holder meets the winner of the neighbouring round 1 match
+		nextMatch := 0
+		for i := 0; i+1 < len(seededParticipants); i += 2 {
+			if p := byeHolder(seededParticipants[i], seededParticipants[i+1]); p != nil {
+				round2Participants = append(round2Participants, p)
+			} else if seededParticipants[i] != nil {
+				round2Participants = append(round2Participants, roundMatches[1][nextMatch])
+				nextMatch++
+			}
+		}
+	} else {
+		// Add the byes participants to the interface
+		for _, p := range byeParticipants {
+			round2Participants = append(round2Participants, p)
+		}
 
-	// now we add round 1 winners
-	for i := range roundMatches[1] {
-		round2Participants = append(round2Participants, roundMatches[1][i])
+		// now we add round 1 winners
+		for i := range roundMatches[1] {
+			round2Participants = append(round2Participants, roundMatches[1][i])
+		}
 	}
 
 	// Resolve actual participants
@@ -189,7 +222,7 @@ func (g *SingleEliminationGenerator) generateSingleElimination(ctx context.Conte
 			Round:        2,
 			MatchNumber:  matchCounter,
 			Status:       domain.MatchPending,
-			BracketType: domain.WinnersBracket,
+			BracketType:  domain.WinnersBracket,
 			// Participants: realparticipants,
 		}
 
@@ -199,7 +232,7 @@ func (g *SingleEliminationGenerator) generateSingleElimination(ctx context.Conte
 			m.Participant1ID = &v.ID
 		case *domain.Match:
 			v.NextMatchID = &m.ID
-			m.Participant1PrereqMatchID=&v.ID
+			m.Participant1PrereqMatchID = &v.ID
 		}
 
 		// getting player 2 now
@@ -209,7 +242,7 @@ func (g *SingleEliminationGenerator) generateSingleElimination(ctx context.Conte
 				m.Participant2ID = &v.ID
 			case *domain.Match:
 				v.NextMatchID = &m.ID
-				m.Participant1PrereqMatchID=&v.ID
+				m.Participant2PrereqMatchID = &v.ID
 			}
 		}
 		roundMatches[2] = append(roundMatches[2], m)
@@ -234,20 +267,20 @@ func (g *SingleEliminationGenerator) generateSingleElimination(ctx context.Conte
 				Round:        round,
 				MatchNumber:  matchCounter,
 				Status:       domain.MatchPending,
-				BracketType: domain.WinnersBracket,
+				BracketType:  domain.WinnersBracket,
 				// Participants: newParticipants,
 			}
 
 			// set forward links in previous matches
 			if i < len(prevRoundMatches) {
 				prevRoundMatches[i].NextMatchID = &match.ID
-				match.Participant1PrereqMatchID=&prevRoundMatches[i].ID
+				match.Participant1PrereqMatchID = &prevRoundMatches[i].ID
 			}
 
 			if i+1 < len(prevRoundMatches) {
 				prevRoundMatches[i+1].NextMatchID = &match.ID
 				prevRoundMatches[i+1].NextMatchID = &match.ID
-				match.Participant2PrereqMatchID=&prevRoundMatches[i+1].ID
+				match.Participant2PrereqMatchID = &prevRoundMatches[i+1].ID
 			}
 
 			currentRound = append(currentRound, match)
@@ -260,80 +293,105 @@ func (g *SingleEliminationGenerator) generateSingleElimination(ctx context.Conte
 	return matches, roundMatches, nil
 }
 
-// applyChallongeSeeding arranges participants using Challonge's seeding algorithm
-// This is crucial for giving byes to the right participants
-func applyChallongeSeeding(participants []*domain.Participant, bracketSize int) []*domain.Participant {
-	result := make([]*domain.Participant, bracketSize)
-
-	// Special handling for very small brackets
-	if len(participants) <= 2 {
-		for i := 0; i < len(participants); i++ {
-			result[i] = participants[i]
-		}
-		return result
+// generateFullSingleElimination creates a complete power-of-two single elimination bracket.
+// Every first-round slot gets a match; where a bye sits, the match holds only the seeded player,
+// is created already completed, and that player is placed straight into their round 2 match.
+func (g *SingleEliminationGenerator) generateFullSingleElimination(tournamentID uuid.UUID, participants []*domain.Participant) ([]*domain.Match, error) {
+	if len(participants) < 2 {
+		return nil, errors.New("at least 2 participants are required for a tournament")
 	}
 
-	// Challonge's algorithm prioritizes giving byes to top seeds
-	// First, determine which positions will get byes
-	byePositions := generateByePositions(bracketSize, len(participants))
-
-	// Place top seeds in bye positions first
-	seedIndex := 0
-	for _, pos := range byePositions {
-		if seedIndex < len(participants) {
-			result[pos] = participants[seedIndex]
-			seedIndex++
-		}
-	}
+	participantsCopy := make([]*domain.Participant, len(participants))
+	copy(participantsCopy, participants)
+	sort.Slice(participantsCopy, func(i, j int) bool {
+		return participantsCopy[i].Seed < participantsCopy[j].Seed
+	})
 
-	// Now fill remaining positions
-	positions := generateMatchPositions(bracketSize, byePositions)
-	for _, pos := range positions {
-		if seedIndex < len(participants) {
-			result[pos] = participants[seedIndex]
-			seedIndex++
-		}
-	}
+	bracketSize := seeding.NextPowerOfTwo(len(participantsCopy))
+	numRounds := bits.Len(uint(bracketSize)) - 1
+	order := seeding.StandardBracketOrder(bracketSize)
 
-	return result
-}
+	matches := make([]*domain.Match, 0, bracketSize-1)
+	matchCounter := 1
+	now := time.Now()
 
-// generateByePositions returns the positions that should get byes
-// This prioritizes giving byes to higher seeds
-func generateByePositions(bracketSize, numParticipants int) []int {
-	byeCount := bracketSize - numParticipants
-	if byeCount <= 0 {
-		return []int{}
+	// Round 1: seeds past the field size are byes, and the highest seeds are the ones who get them
+	prevRound := make([]*domain.Match, 0, bracketSize/2)
+	for i := 0; i < bracketSize; i += 2 {
+		match := &domain.Match{
+			ID:           uuid.New(),
+			TournamentID: tournamentID,
+			Round:        1,
+			MatchNumber:  matchCounter,
+			Status:       domain.MatchPending,
+			BracketType:  domain.WinnersBracket,
+		}
+		if order[i] < len(participantsCopy) {
+			match.Participant1ID = &participantsCopy[order[i]].ID
+		}
+		if order[i+1] < len(participantsCopy) {
+			match.Participant2ID = &participantsCopy[order[i+1]].ID
+		}
+		if match.Participant2ID == nil {
+			match.Status = domain.MatchCompleted
+			match.WinnerID = match.Participant1ID
+			match.CompletedTime = &now
+			match.MatchNotes = "Bye - advanced automatically"
+		} else if match.Participant1ID == nil {
+			match.Status = domain.MatchCompleted
+			match.WinnerID = match.Participant2ID
+			match.CompletedTime = &now
+			match.MatchNotes = "Bye - advanced automatically"
+		}
+		prevRound = append(prevRound, match)
+		matches = append(matches, match)
+		matchCounter++
 	}
 
-	// Challonge's bye distribution pattern
-	byePositions := make([]int, 0, byeCount)
+	for round := 2; round <= numRounds; round++ {
+		currentRound := make([]*domain.Match, 0, len(prevRound)/2)
+		for i := 0; i+1 < len(prevRound); i += 2 {
+			match := &domain.Match{
+				ID:           uuid.New(),
+				TournamentID: tournamentID,
+				Round:        round,
+				MatchNumber:  matchCounter,
+				Status:       domain.MatchPending,
+				BracketType:  domain.WinnersBracket,
+			}
+			prevRound[i].NextMatchID = &match.ID
+			prevRound[i+1].NextMatchID = &match.ID
+			match.Participant1PrereqMatchID = &prevRound[i].ID
+			match.Participant2PrereqMatchID = &prevRound[i+1].ID
+
+			// Bye winners are already known, so they go straight into their slot
+			if prevRound[i].Status == domain.MatchCompleted {
+				match.Participant1ID = prevRound[i].WinnerID
+			}
+			if prevRound[i+1].Status == domain.MatchCompleted {
+				match.Participant2ID = prevRound[i+1].WinnerID
+			}
 
-	// For a standard bracket, byes are given to positions 0, 2, 4, etc. (top seeds)
-	for i := 0; i < byeCount; i++ {
-		byePositions = append(byePositions, i*2)
+			currentRound = append(currentRound, match)
+			matches = append(matches, match)
+			matchCounter++
+		}
+		prevRound = currentRound
 	}
 
-	return byePositions
+	return matches, nil
 }
 
-// generateMatchPositions returns positions that should have first round matches
-func generateMatchPositions(bracketSize int, byePositions []int) []int {
-	positions := make([]int, 0, bracketSize-len(byePositions))
-
-	// Add all positions that aren't bye positions
-	byeMap := make(map[int]bool)
-	for _, pos := range byePositions {
-		byeMap[pos] = true
+// byeHolder returns the player in a round 1 slot pair whose opponent slot is empty, or nil
+// when both or neither slot is filled
+func byeHolder(p1, p2 *domain.Participant) *domain.Participant {
+	if p2 == nil {
+		return p1
 	}
-
-	for i := 0; i < bracketSize; i++ {
-		if !byeMap[i] {
-			positions = append(positions, i)
-		}
+	if p1 == nil {
+		return p2
 	}
-
-	return positions
+	return nil
 }
 
 func isInByes(p *domain.Participant, byes []*domain.Participant) bool {
@@ -431,22 +489,13 @@ func (g *RoundRobinGenerator) Generate(ctx context.Context, tournamentID uuid.UU
 	return matches, nil
 }
 
-
-// --- Assuming these helper functions are defined in your package ---
-// --- If they are not, you need to provide their implementations ---
-// func nextPowerOfTwo(n int) int { /* ... */ }
-// func applyChallongeSeeding(participants []*domain.Participant, bracketSize int) []*domain.Participant { /* ... */ }
-// func isInByes(p *domain.Participant, byes []*domain.Participant) bool { /* ... */ }
-// --- End Helper Function Placeholders ---
-
-
 // This is your provided function, adapted slightly to be a method
 // of DoubleEliminationGenerator and to include BracketType, Timestamps, and return matchCounter.
 // I've named it generateWinnersBracketFromSingleElim to clearly indicate its role.
 func (g *DoubleEliminationGenerator) generateWinnersBracketFromSingleElim(
-    ctx context.Context,
-    tournamentID uuid.UUID,
-    participants []*domain.Participant,
+	ctx context.Context,
+	tournamentID uuid.UUID,
+	participants []*domain.Participant,
 ) ([]*domain.Match, [][]*domain.Match, int, error) { // Added int for matchCounter
 	if len(participants) < 2 {
 		return nil, nil, 0, errors.New("at least 2 participants are required for a tournament")
@@ -463,23 +512,22 @@ func (g *DoubleEliminationGenerator) generateWinnersBracketFromSingleElim(
 
 	// Calculate the number of rounds needed
 	numParticipants := len(participantsCopy)
-    numRounds := 0
+	numRounds := 0
 	if numParticipants > 0 {
 		numRounds = int(math.Ceil(math.Log2(float64(numParticipants))))
 	}
-    if numParticipants <= 1 {
-        numRounds = 0
-    }
-
+	if numParticipants <= 1 {
+		numRounds = 0
+	}
 
-	participantsPowerOfTwo := nextPowerOfTwo(numParticipants)
+	participantsPowerOfTwo := seeding.NextPowerOfTwo(numParticipants)
 
 	// Create matches list
 	matches := make([]*domain.Match, 0)
 	matchCounter := 1 // Start match numbering at 1 for WB
 
 	// Apply Challonge-style seeding
-	seededParticipants := applyChallongeSeeding(participantsCopy, participantsPowerOfTwo)
+	seededParticipants := seeding.ChallongeSeed(participantsCopy, participantsPowerOfTwo)
 
 	// Initialize arrays to track matches in each round
 	// roundMatchesRoster[0] will be empty, roundMatchesRoster[1] is WB Round 1, etc.
@@ -510,87 +558,85 @@ func (g *DoubleEliminationGenerator) generateWinnersBracketFromSingleElim(
 		}
 	}
 
-    if numRounds > 0 { // Only create R1 matches if there are rounds
-        for i := 0; i < len(participantsWithMatches); i += 2 {
-            now := time.Now()
-            match := &domain.Match{
-                ID:           uuid.New(),
-                TournamentID: tournamentID,
-                Round:        1,
-                MatchNumber:  matchCounter,
-                Status:       domain.MatchPending,
-                BracketType:  domain.WinnersBracket,
-                CreatedAt:    now,
-                UpdatedAt:    now,
-            }
-
-            if i < len(participantsWithMatches) {
-                participant1 := participantsWithMatches[i]
-                match.Participant1ID = &participant1.ID
-            }
-
-            if i+1 < len(participantsWithMatches) {
-                participant2 := participantsWithMatches[i+1]
-                match.Participant2ID = &participant2.ID
-            }
-
-            roundMatchesRoster[1] = append(roundMatchesRoster[1], match)
-            matches = append(matches, match)
-            matchCounter++
-        }
-    }
+	if numRounds > 0 { // Only create R1 matches if there are rounds
+		for i := 0; i < len(participantsWithMatches); i += 2 {
+			now := time.Now()
+			match := &domain.Match{
+				ID:           uuid.New(),
+				TournamentID: tournamentID,
+				Round:        1,
+				MatchNumber:  matchCounter,
+				Status:       domain.MatchPending,
+				BracketType:  domain.WinnersBracket,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}
+
+			if i < len(participantsWithMatches) {
+				participant1 := participantsWithMatches[i]
+				match.Participant1ID = &participant1.ID
+			}
+
+			if i+1 < len(participantsWithMatches) {
+				participant2 := participantsWithMatches[i+1]
+				match.Participant2ID = &participant2.ID
+			}
 
+			roundMatchesRoster[1] = append(roundMatchesRoster[1], match)
+			matches = append(matches, match)
+			matchCounter++
+		}
+	}
 
 	// Round 2
 	var round2Participants []interface{}
 	for _, p := range byeParticipants {
 		round2Participants = append(round2Participants, p)
 	}
-	if numRounds >=1 { // Only add R1 winners if R1 existed
+	if numRounds >= 1 { // Only add R1 winners if R1 existed
 		for i := range roundMatchesRoster[1] {
 			round2Participants = append(round2Participants, roundMatchesRoster[1][i])
 		}
 	}
 
-    if numRounds >= 2 { // Only create R2 if there are enough rounds
-        for i := 0; i < len(round2Participants); i += 2 {
-            now := time.Now()
-            m := &domain.Match{
-                ID:           uuid.New(),
-                TournamentID: tournamentID,
-                Round:        2,
-                MatchNumber:  matchCounter,
-                Status:       domain.MatchPending,
-                BracketType:  domain.WinnersBracket,
-                CreatedAt:    now,
-                UpdatedAt:    now,
-            }
-
-            if i < len(round2Participants) {
-                switch v := round2Participants[i].(type) {
-                case *domain.Participant:
-                    m.Participant1ID = &v.ID
-                case *domain.Match:
-                    v.NextMatchID = &m.ID
+	if numRounds >= 2 { // Only create R2 if there are enough rounds
+		for i := 0; i < len(round2Participants); i += 2 {
+			now := time.Now()
+			m := &domain.Match{
+				ID:           uuid.New(),
+				TournamentID: tournamentID,
+				Round:        2,
+				MatchNumber:  matchCounter,
+				Status:       domain.MatchPending,
+				BracketType:  domain.WinnersBracket,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}
+
+			if i < len(round2Participants) {
+				switch v := round2Participants[i].(type) {
+				case *domain.Participant:
+					m.Participant1ID = &v.ID
+				case *domain.Match:
+					v.NextMatchID = &m.ID
 					m.Participant1PrereqMatchID = &v.ID
-                }
-            }
-
-            if i+1 < len(round2Participants) {
-                switch v := round2Participants[i+1].(type) {
-                case *domain.Participant:
-                    m.Participant2ID = &v.ID
-                case *domain.Match:
-                    v.NextMatchID = &m.ID
-					m.Participant2PrereqMatchID = &v.ID
-                }
-            }
-            roundMatchesRoster[2] = append(roundMatchesRoster[2], m)
-            matches = append(matches, m)
-            matchCounter++
-        }
-    }
+				}
+			}
 
+			if i+1 < len(round2Participants) {
+				switch v := round2Participants[i+1].(type) {
+				case *domain.Participant:
+					m.Participant2ID = &v.ID
+				case *domain.Match:
+					v.NextMatchID = &m.ID
+					m.Participant2PrereqMatchID = &v.ID
+				}
+			}
+			roundMatchesRoster[2] = append(roundMatchesRoster[2], m)
+			matches = append(matches, m)
+			matchCounter++
+		}
+	}
 
 	// subsequent matches after round 2
 	for round := 3; round <= numRounds; round++ {
@@ -602,28 +648,28 @@ func (g *DoubleEliminationGenerator) generateWinnersBracketFromSingleElim(
 		currentRound := make([]*domain.Match, 0)
 
 		for i := 0; i < len(prevRoundMatches); i += 2 {
-            now := time.Now()
+			now := time.Now()
 			match := &domain.Match{
 				ID:           uuid.New(),
 				TournamentID: tournamentID,
 				Round:        round,
 				MatchNumber:  matchCounter,
 				Status:       domain.MatchPending,
-                BracketType:  domain.WinnersBracket,
-                CreatedAt:    now,
-                UpdatedAt:    now,
+				BracketType:  domain.WinnersBracket,
+				CreatedAt:    now,
+				UpdatedAt:    now,
 			}
 
 			if i < len(prevRoundMatches) {
 				prevMatch1 := prevRoundMatches[i]
-                prevMatch1.NextMatchID = &match.ID
-                match.Participant1PrereqMatchID = &prevMatch1.ID
+				prevMatch1.NextMatchID = &match.ID
+				match.Participant1PrereqMatchID = &prevMatch1.ID
 			}
 
 			if i+1 < len(prevRoundMatches) {
 				prevMatch2 := prevRoundMatches[i+1]
-                prevMatch2.NextMatchID = &match.ID
-                match.Participant2PrereqMatchID = &prevMatch2.ID 
+				prevMatch2.NextMatchID = &match.ID
+				match.Participant2PrereqMatchID = &prevMatch2.ID
 			}
 
 			currentRound = append(currentRound, match)
@@ -636,7 +682,6 @@ func (g *DoubleEliminationGenerator) generateWinnersBracketFromSingleElim(
 	return matches, roundMatchesRoster, matchCounter, nil // Added matchCounter
 }
 
-
 // DoubleEliminationGenerator implements the Generator interface for double elimination tournaments
 type DoubleEliminationGenerator struct{}
 
@@ -668,7 +713,7 @@ func (g *DoubleEliminationGenerator) Generate(ctx context.Context, tournamentID
 	if err != nil {
 		return nil, err
 	}
-	
+
 	flatLosersMatches := make([]*domain.Match, 0)
 	for _, round := range losersBracketMatchesList {
 		flatLosersMatches = append(flatLosersMatches, round...)
@@ -687,21 +732,20 @@ func (g *DoubleEliminationGenerator) Generate(ctx context.Context, tournamentID
 	return allMatches, nil
 }
 
-
 // --- PASTE THE generateLosersBracket and generateFinalMatches functions here ---
 // --- from the previous correct versions. I'm omitting them for brevity but you need them. ---
 
 // Helper to create a new LB match shell
 func createLBMatchShell(tournamentID uuid.UUID, lbRoundNum int, matchCounter int) *domain.Match {
 	return &domain.Match{
-		ID:               uuid.New(),
-		TournamentID:     tournamentID,
-		Round:            lbRoundNum,
-		MatchNumber:      matchCounter,
-		Status:           domain.MatchPending,
-		BracketType:      domain.LosersBracket,
-		CreatedAt:        time.Now(),
-		UpdatedAt:        time.Now(),
+		ID:           uuid.New(),
+		TournamentID: tournamentID,
+		Round:        lbRoundNum,
+		MatchNumber:  matchCounter,
+		Status:       domain.MatchPending,
+		BracketType:  domain.LosersBracket,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 		// PreviousMatchIDs: make([]uuid.UUID, 0), // Keep if you use this field
 	}
 }
@@ -893,9 +937,10 @@ func (g *DoubleEliminationGenerator) generateFinalMatches(
 		// PreviousMatchIDs: []uuid.UUID{grandFinals.ID}, // If used
 	}
 	finalMatches = append(finalMatches, bracketResetMatch)
-	
+
 	return finalMatches, matchCounter, nil
 }
+
 // max returns the larger of x or y
 func max(x, y int) int {
 	if x > y {
@@ -977,14 +1022,6 @@ func (g *SingleEliminationGenerator) generateSwiss(ctx context.Context, tourname
 
 // Helper functions
 
-// nextPowerOfTwo returns the next power of 2 >= n
-func nextPowerOfTwo(n int) int {
-	if n <= 1 {
-		return 1
-	}
-	return 1 << (bits.Len(uint(n - 1)))
-}
-
 // rotateParticipants rotates all elements except the first one
 // This is used for round robin scheduling
 func rotateParticipants(indices []int) {
@@ -999,102 +1036,3 @@ func rotateParticipants(indices []int) {
 	}
 	indices[1] = last
 }
-
-// applySeeding arranges participants in a tournament bracket according to seed
-// This is similar to how platforms like Challonge seed participants
-func applySeeding(participants []*domain.Participant, bracketSize int) []*domain.Participant {
-	result := make([]*domain.Participant, bracketSize)
-
-	// Special cases for small brackets
-	if bracketSize <= 2 {
-		for i := 0; i < len(participants) && i < bracketSize; i++ {
-			result[i] = participants[i]
-		}
-		return result
-	}
-
-	// Apply standard seeding pattern for power-of-2 brackets
-	// This distributes participants to maximize the distance between top seeds
-	positions := make([]int, bracketSize)
-
-	// Initialize the positions array
-	for i := 0; i < bracketSize; i++ {
-		positions[i] = i
-	}
-
-	// Apply the seeding pattern recursively
-	applySeedingPattern(positions, 0, bracketSize-1, 1)
-
-	// Place participants according to the calculated positions
-	for i := 0; i < len(participants) && i < bracketSize; i++ {
-		result[positions[i]] = participants[i]
-	}
-
-	return result
-}
-
-// applySeedingPattern recursively determines bracket positions based on seeding
-// This creates bracket placements like: 1 vs 16, 8 vs 9, 5 vs 12, 4 vs 13, etc.
-func applySeedingPattern(positions []int, start, end, seed int) {
-	if start > end {
-		return
-	}
-
-	if start == end {
-		positions[start] = seed - 1
-		return
-	}
-
-	mid := (start + end) / 2
-	positions[start] = seed - 1
-	positions[end] = (len(positions) + 1 - seed) - 1
-
-	applySeedingPattern(positions, start+1, mid, seed*2)
-	applySeedingPattern(positions, mid+1, end-1, seed*2-1)
-}
-
-// seedParticipants orders participants according to standard seeding pattern
-func seedParticipants(participants []*domain.Participant) {
-	// Sort participants by their seed value first
-	for i := 0; i < len(participants); i++ {
-		for j := i + 1; j < len(participants); j++ {
-			if participants[i].Seed > participants[j].Seed {
-				participants[i], participants[j] = participants[j], participants[i]
-			}
-		}
-	}
-
-	// Challonge-style bracket seeding placement (1 vs 16, 8 vs 9, 5 vs 12, etc)
-	size := nextPowerOfTwo(len(participants))
-	if size <= 1 {
-		return
-	}
-
-	reordered := make([]*domain.Participant, size)
-
-	// Apply Challonge seeding algorithm
-	placeSeed(participants, reordered, 0, 0, size)
-
-	// Copy back participants that exist
-	for i := 0; i < len(participants); i++ {
-		participants[i] = reordered[i]
-	}
-}
-
-// placeSeed recursively places seeds according to Challonge's algorithm
-func placeSeed(src []*domain.Participant, dst []*domain.Participant, position int, seed int, size int) {
-	if size == 1 {
-		if seed < len(src) {
-			dst[position] = src[seed]
-		}
-		return
-	}
-
-	halfSize := size / 2
-
-	// Place the first seed at the top position
-	placeSeed(src, dst, position, seed, halfSize)
-
-	// Place the second seed at the bottom position
-	placeSeed(src, dst, position+halfSize, seed+halfSize, halfSize)
-}
@@ -0,0 +1,134 @@
+package bracket
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// seededParticipants returns count participants seeded 1..count
+func seededParticipants(count int) []*domain.Participant {
+	participants := make([]*domain.Participant, count)
+	for i := range participants {
+		participants[i] = &domain.Participant{ID: uuid.New(), ParticipantName: fmt.Sprintf("seed%d", i+1), Seed: i + 1}
+	}
+	return participants
+}
+
+func TestFullBracketLaysOutExplicitByes(t *testing.T) {
+	participants := seededParticipants(5)
+	matches, err := NewSingleEliminationGenerator().Generate(context.Background(), uuid.New(), SingleElimination,
+		participants, map[string]interface{}{"full_bracket": true})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(matches) != 7 {
+		t.Fatalf("an 8-slot bracket has 7 matches, got %d", len(matches))
+	}
+
+	byID := map[uuid.UUID]*domain.Match{}
+	var firstRound []*domain.Match
+	for _, m := range matches {
+		byID[m.ID] = m
+		if m.Round == 1 {
+			firstRound = append(firstRound, m)
+		}
+	}
+	if len(firstRound) != 4 {
+		t.Fatalf("expected every first-round slot to get a match, got %d", len(firstRound))
+	}
+
+	byes := map[uuid.UUID]bool{}
+	for _, m := range firstRound {
+		if m.Participant2ID != nil {
+			if m.Status != domain.MatchPending {
+				t.Fatalf("a real first-round match should be pending: %+v", m)
+			}
+			continue
+		}
+		if m.Status != domain.MatchCompleted || m.WinnerID == nil || *m.WinnerID != *m.Participant1ID {
+			t.Fatalf("a bye should be completed with its player as the winner: %+v", m)
+		}
+		byes[*m.WinnerID] = true
+		next := byID[*m.NextMatchID]
+		if (next.Participant1ID == nil || *next.Participant1ID != *m.WinnerID) &&
+			(next.Participant2ID == nil || *next.Participant2ID != *m.WinnerID) {
+			t.Fatal("a bye winner should already sit in their round 2 match")
+		}
+	}
+	for _, seed := range participants[:3] {
+		if !byes[seed.ID] {
+			t.Errorf("%s should get a bye", seed.ParticipantName)
+		}
+	}
+	if len(byes) != 3 {
+		t.Fatalf("expected 3 byes, got %d", len(byes))
+	}
+}
+
+func TestFullBracketNeedsTwoParticipants(t *testing.T) {
+	_, err := NewSingleEliminationGenerator().Generate(context.Background(), uuid.New(), SingleElimination,
+		seededParticipants(1), map[string]interface{}{"full_bracket": true})
+	if err == nil {
+		t.Fatal("expected an error for a single participant")
+	}
+}
+
+// roundTwoSlots maps each player who skipped round 1 to the index of their round 2 match
+func roundTwoSlots(t *testing.T, strategy string, count int) (map[int]int, []*domain.Participant) {
+	t.Helper()
+	participants := seededParticipants(count)
+	matches, err := NewSingleEliminationGenerator().Generate(context.Background(), uuid.New(), SingleElimination,
+		participants, map[string]interface{}{"bye_strategy": strategy})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	playedRoundOne := map[uuid.UUID]bool{}
+	for _, m := range matches {
+		if m.Round == 1 {
+			playedRoundOne[*m.Participant1ID], playedRoundOne[*m.Participant2ID] = true, true
+		}
+	}
+	bySeed := map[uuid.UUID]int{}
+	for _, p := range participants {
+		bySeed[p.ID] = p.Seed
+	}
+	slots := map[int]int{}
+	for _, m := range matches {
+		if m.Round != 2 {
+			continue
+		}
+		for _, id := range []*uuid.UUID{m.Participant1ID, m.Participant2ID} {
+			if id != nil && !playedRoundOne[*id] {
+				slots[bySeed[*id]] = m.MatchNumber
+			}
+		}
+	}
+	return slots, participants
+}
+
+func TestStandardByesGoToTheTopSeedsOnOppositeHalves(t *testing.T) {
+	slots, _ := roundTwoSlots(t, "standard", 6)
+	if len(slots) != 2 {
+		t.Fatalf("expected two byes, got %v", slots)
+	}
+	first, ok1 := slots[1]
+	second, ok2 := slots[2]
+	if !ok1 || !ok2 {
+		t.Fatalf("seeds 1 and 2 should get the byes, got %v", slots)
+	}
+	if first == second {
+		t.Fatal("seeds 1 and 2 should be in opposite halves of the bracket")
+	}
+}
+
+func TestUnknownByeStrategyIsRejected(t *testing.T) {
+	_, err := NewSingleEliminationGenerator().Generate(context.Background(), uuid.New(), SingleElimination,
+		seededParticipants(6), map[string]interface{}{"bye_strategy": "random"})
+	if err == nil {
+		t.Fatal("expected an error for an unknown bye strategy")
+	}
+}
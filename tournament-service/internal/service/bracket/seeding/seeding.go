@@ -0,0 +1,140 @@
+// Package seeding places seeded participants into bracket slots. The bracket generators use
+// it rather than keeping their own copies of the seeding rules.
+package seeding
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// ByeStrategy decides which bracket slots the players who get first-round byes occupy
+type ByeStrategy string
+
+const (
+	// ByesChallonge puts the bye holders in slots 0, 2, 4, ... ahead of everyone else.
+	// It is the default.
+	ByesChallonge ByeStrategy = "challonge"
+	// ByesStandard lays seeds out in StandardBracketOrder, so the byes fall to the top
+	// seeds spread across the bracket: #1 and #2 get theirs in opposite halves.
+	ByesStandard ByeStrategy = "standard"
+)
+
+// ParseByeStrategy validates a strategy name; an empty name selects ByesChallonge
+func ParseByeStrategy(name string) (ByeStrategy, error) {
+	switch ByeStrategy(name) {
+	case "", ByesChallonge:
+		return ByesChallonge, nil
+	case ByesStandard:
+		return ByesStandard, nil
+	}
+	return "", fmt.Errorf("unsupported bye strategy: %q", name)
+}
+
+// Seed arranges participants, already sorted by seed, into bracketSize slots using the
+// given strategy
+func Seed[T any](participants []T, bracketSize int, strategy ByeStrategy) []T {
+	if strategy == ByesStandard {
+		return StandardSeed(participants, bracketSize)
+	}
+	return ChallongeSeed(participants, bracketSize)
+}
+
+// NextPowerOfTwo returns the next power of 2 >= n
+func NextPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << (bits.Len(uint(n - 1)))
+}
+
+// StandardBracketOrder returns the seed index for each slot of a power-of-two bracket,
+// pairing them as 1 vs 8, 4 vs 5, 2 vs 7, 3 vs 6 so top seeds only meet late
+func StandardBracketOrder(bracketSize int) []int {
+	order := []int{0}
+	for size := 2; size <= bracketSize; size *= 2 {
+		next := make([]int, 0, size)
+		for _, seed := range order {
+			next = append(next, seed, size-1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// StandardSeed arranges participants, already sorted by seed, into bracketSize slots in
+// StandardBracketOrder. Slots 2k and 2k+1 meet in round 1; when one of them is empty the
+// other player has a bye. Empty slots hold the zero value of T.
+func StandardSeed[T any](participants []T, bracketSize int) []T {
+	result := make([]T, bracketSize)
+	for slot, seed := range StandardBracketOrder(bracketSize) {
+		if seed < len(participants) {
+			result[slot] = participants[seed]
+		}
+	}
+	return result
+}
+
+// ChallongeSeed arranges participants, already sorted by seed, into bracketSize slots so
+// the top seeds get the byes. Bye holders sit in the even slots 0, 2, 4, ... and everyone
+// else fills the remaining slots in seed order. Empty slots hold the zero value of T.
+func ChallongeSeed[T any](participants []T, bracketSize int) []T {
+	result := make([]T, bracketSize)
+
+	// Special handling for very small brackets
+	if len(participants) <= 2 {
+		copy(result, participants)
+		return result
+	}
+
+	// Place top seeds in bye positions first
+	byePositions := GenerateByePositions(bracketSize, len(participants))
+	seedIndex := 0
+	for _, pos := range byePositions {
+		if seedIndex < len(participants) {
+			result[pos] = participants[seedIndex]
+			seedIndex++
+		}
+	}
+
+	// Now fill remaining positions
+	for _, pos := range GenerateMatchPositions(bracketSize, byePositions) {
+		if seedIndex < len(participants) {
+			result[pos] = participants[seedIndex]
+			seedIndex++
+		}
+	}
+
+	return result
+}
+
+// GenerateByePositions returns the slots whose occupants get a first-round bye. Byes go
+// to the top seeds, in slots 0, 2, 4, etc.
+func GenerateByePositions(bracketSize, numParticipants int) []int {
+	byeCount := bracketSize - numParticipants
+	if byeCount <= 0 {
+		return []int{}
+	}
+
+	byePositions := make([]int, 0, byeCount)
+	for i := 0; i < byeCount; i++ {
+		byePositions = append(byePositions, i*2)
+	}
+	return byePositions
+}
+
+// GenerateMatchPositions returns the slots that play a first-round match, i.e. every slot
+// not in byePositions, in order
+func GenerateMatchPositions(bracketSize int, byePositions []int) []int {
+	byeMap := make(map[int]bool, len(byePositions))
+	for _, pos := range byePositions {
+		byeMap[pos] = true
+	}
+
+	positions := make([]int, 0, bracketSize-len(byePositions))
+	for i := 0; i < bracketSize; i++ {
+		if !byeMap[i] {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
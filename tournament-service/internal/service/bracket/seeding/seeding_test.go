@@ -0,0 +1,142 @@
+package seeding
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// layout renders a seeded bracket as the seed in each slot, "-" for an empty one
+func layout(slots []int) string {
+	cells := make([]string, len(slots))
+	for i, seed := range slots {
+		if seed == 0 {
+			cells[i] = "-"
+		} else {
+			cells[i] = fmt.Sprint(seed)
+		}
+	}
+	return strings.Join(cells, " ")
+}
+
+// seeds returns the seeds 1..n
+func seeds(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i + 1
+	}
+	return s
+}
+
+// checkGolden compares got with testdata/name, or rewrites the file with -update
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if got != string(want) {
+		t.Fatalf("%s does not match:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestChallongeSeedGolden(t *testing.T) {
+	var b strings.Builder
+	for n := 3; n <= 16; n++ {
+		fmt.Fprintf(&b, "%d: %s\n", n, layout(ChallongeSeed(seeds(n), NextPowerOfTwo(n))))
+	}
+	checkGolden(t, "challonge.golden", b.String())
+}
+
+func TestStandardSeedGolden(t *testing.T) {
+	var b strings.Builder
+	for n := 3; n <= 16; n++ {
+		fmt.Fprintf(&b, "%d: %s\n", n, layout(StandardSeed(seeds(n), NextPowerOfTwo(n))))
+	}
+	checkGolden(t, "standard.golden", b.String())
+}
+
+func TestStandardBracketOrder(t *testing.T) {
+	for size, want := range map[int][]int{
+		1:  {0},
+		2:  {0, 1},
+		4:  {0, 3, 1, 2},
+		8:  {0, 7, 3, 4, 1, 6, 2, 5},
+		16: {0, 15, 7, 8, 3, 12, 4, 11, 1, 14, 6, 9, 2, 13, 5, 10},
+	} {
+		if got := StandardBracketOrder(size); !reflect.DeepEqual(got, want) {
+			t.Errorf("StandardBracketOrder(%d) = %v, want %v", size, got, want)
+		}
+	}
+}
+
+func TestChallongeSeedGivesTheTopSeedsByes(t *testing.T) {
+	for n := 3; n <= 16; n++ {
+		size := NextPowerOfTwo(n)
+		slots := ChallongeSeed(seeds(n), size)
+		byes := GenerateByePositions(size, n)
+		if len(byes) != size-n {
+			t.Fatalf("%d players: expected %d byes, got %d", n, size-n, len(byes))
+		}
+		// Seeds 1..byes hold the bye slots, and everyone else plays in seed order
+		for i, pos := range byes {
+			if slots[pos] != i+1 {
+				t.Errorf("%d players: bye slot %d holds seed %d, want %d", n, pos, slots[pos], i+1)
+			}
+		}
+		next := len(byes) + 1
+		for _, pos := range GenerateMatchPositions(size, byes) {
+			if next <= n && slots[pos] != next {
+				t.Errorf("%d players: match slot %d holds seed %d, want %d", n, pos, slots[pos], next)
+			}
+			next++
+		}
+	}
+}
+
+func TestPositions(t *testing.T) {
+	byes := GenerateByePositions(8, 5)
+	if !reflect.DeepEqual(byes, []int{0, 2, 4}) {
+		t.Fatalf("GenerateByePositions(8, 5) = %v", byes)
+	}
+	if got := GenerateMatchPositions(8, byes); !reflect.DeepEqual(got, []int{1, 3, 5, 6, 7}) {
+		t.Fatalf("GenerateMatchPositions(8, %v) = %v", byes, got)
+	}
+	if got := GenerateByePositions(8, 8); len(got) != 0 {
+		t.Fatalf("a full bracket has no byes, got %v", got)
+	}
+}
+
+func TestNextPowerOfTwo(t *testing.T) {
+	for n, want := range map[int]int{0: 1, 1: 1, 2: 2, 3: 4, 5: 8, 8: 8, 9: 16, 16: 16, 17: 32} {
+		if got := NextPowerOfTwo(n); got != want {
+			t.Errorf("NextPowerOfTwo(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestParseByeStrategy(t *testing.T) {
+	for name, want := range map[string]ByeStrategy{"": ByesChallonge, "challonge": ByesChallonge, "standard": ByesStandard} {
+		if got, err := ParseByeStrategy(name); err != nil || got != want {
+			t.Errorf("ParseByeStrategy(%q) = %q, %v", name, got, err)
+		}
+	}
+	if _, err := ParseByeStrategy("random"); err == nil {
+		t.Fatal("an unknown strategy should be rejected")
+	}
+	if got := Seed(seeds(3), 4, ByesStandard); !reflect.DeepEqual(got, []int{1, 0, 2, 3}) {
+		t.Fatalf("Seed with the standard strategy = %v", got)
+	}
+}
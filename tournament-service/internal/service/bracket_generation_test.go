@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// completeFirstMatch records a result for the first playable match of a tournament
+func completeFirstMatch(t *testing.T, env *testEnv, tournamentID uuid.UUID) *domain.Match {
+	t.Helper()
+	for _, match := range env.storedMatches(tournamentID) {
+		if match.Participant1ID != nil && match.Participant2ID != nil && match.Status != domain.MatchCompleted {
+			match.Status = domain.MatchCompleted
+			match.WinnerID = match.Participant1ID
+			match.LoserID = match.Participant2ID
+			match.ScoreParticipant1 = 2
+			env.store.putMatch(match)
+			return match
+		}
+	}
+	t.Fatal("no playable match found")
+	return nil
+}
+
+func TestRegenerateBracketRefusesToDiscardPlayedMatches(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	env.players(tournament.ID, 4)
+
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+	played := completeFirstMatch(t, env, tournament.ID)
+
+	err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false)
+	var started *ErrBracketAlreadyStarted
+	if !errors.As(err, &started) || started.CompletedMatches != 1 {
+		t.Fatalf("expected ErrBracketAlreadyStarted with 1 completed match, got %v", err)
+	}
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Fatal("ErrBracketAlreadyStarted should classify as a conflict")
+	}
+	if got := env.match(t, played.ID); got.Status != domain.MatchCompleted {
+		t.Fatal("the played match was discarded")
+	}
+}
+
+func TestForcedRegenerationReplacesPlayedMatches(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	env.players(tournament.ID, 4)
+
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+	played := completeFirstMatch(t, env, tournament.ID)
+
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, true); err != nil {
+		t.Fatalf("forced GenerateBracket: %v", err)
+	}
+	matches := env.storedMatches(tournament.ID)
+	if len(matches) != 3 {
+		t.Fatalf("expected a fresh 3-match bracket, got %d matches", len(matches))
+	}
+	for _, match := range matches {
+		if match.ID == played.ID || match.Status == domain.MatchCompleted {
+			t.Fatal("forced regeneration kept a played match")
+		}
+	}
+}
+
+func TestGenerateBracketWithoutPlayedMatchesNeedsNoForce(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	env.players(tournament.ID, 4)
+
+	for i := 0; i < 2; i++ {
+		if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+			t.Fatalf("GenerateBracket #%d: %v", i+1, err)
+		}
+	}
+	if got := len(env.storedMatches(tournament.ID)); got != 3 {
+		t.Fatalf("regenerating an unplayed bracket should replace it, got %d matches", got)
+	}
+}
+
+func TestBracketOptionsReadFullBracket(t *testing.T) {
+	for fields, want := range map[string]bool{
+		``:                        false,
+		`{"full_bracket": true}`:  true,
+		`{"full_bracket": false}`: false,
+		`{"full_bracket": "yes"}`: false,
+	} {
+		tournament := &domain.Tournament{ID: uuid.New()}
+		if fields != "" {
+			tournament.CustomFields = json.RawMessage(fields)
+		}
+		if got, _ := bracketOptions(tournament)["full_bracket"].(bool); got != want {
+			t.Errorf("%q: want full_bracket %v, got %v", fields, want, got)
+		}
+	}
+}
+
+func TestBracketOptionsReadByeStrategy(t *testing.T) {
+	for fields, want := range map[string]string{
+		``:                              "",
+		`{"bye_strategy": "standard"}`:  "standard",
+		`{"bye_strategy": "challonge"}`: "challonge",
+		`{"bye_strategy": "random"}`:    "",
+	} {
+		tournament := &domain.Tournament{ID: uuid.New()}
+		if fields != "" {
+			tournament.CustomFields = json.RawMessage(fields)
+		}
+		if got, _ := bracketOptions(tournament)["bye_strategy"].(string); got != want {
+			t.Errorf("%q: want bye_strategy %q, got %q", fields, want, got)
+		}
+	}
+}
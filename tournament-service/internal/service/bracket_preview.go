@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// SeedCurrent previews the bracket with the participants' saved seeds
+const SeedCurrent = "current"
+
+// PreviewBracket generates the bracket the tournament would get, in memory only: no matches are
+// saved and no seeds are changed. An empty format uses the tournament's own format; an empty
+// seeding keeps the saved seeds, falling back to registration order if nobody is seeded, as
+// GenerateBracket does. Byes appear as matches with a single participant, since they are only
+// resolved once the bracket is saved.
+func (s *tournamentService) PreviewBracket(
+	ctx context.Context, tournamentID, userID uuid.UUID, format domain.TournamentFormat, seeding string,
+) (*domain.BracketPreview, error) {
+	tournament, err := s.getManagedTournament(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = tournament.Format
+	}
+	bracketFormat, err := toBracketFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	participants = confirmedParticipants(participants)
+	if len(participants) < 2 {
+		return nil, domain.NewError(domain.ErrConflict, "need at least 2 participants to generate bracket")
+	}
+
+	// Seeds are applied to copies so the stored participants are left alone
+	seeded := make([]*domain.Participant, len(participants))
+	for i, participant := range participants {
+		participantCopy := *participant
+		seeded[i] = &participantCopy
+	}
+	if seeding == "" || seeding == SeedCurrent {
+		seeding = SeedCurrent
+		hasSeeds := false
+		for _, participant := range seeded {
+			if participant.Seed != 0 {
+				hasSeeds = true
+				break
+			}
+		}
+		if !hasSeeds {
+			seeding = SeedByRegistrationOrder
+		}
+	}
+	if seeding != SeedCurrent {
+		ordered, err := seedOrder(seeded, seeding)
+		if err != nil {
+			return nil, err
+		}
+		for i, participant := range ordered {
+			participant.Seed = i + 1
+		}
+		seeded = ordered
+	}
+
+	matches, err := s.bracketGenerator.Generate(ctx, tournamentID, bracketFormat, seeded, bracketOptions(tournament))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate bracket: %w", err)
+	}
+
+	return &domain.BracketPreview{
+		TournamentID: tournamentID,
+		Format:       format,
+		Seeding:      seeding,
+		Bracket:      buildBracketTree(matches, seeded),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// pairings lists the participant names of each match in a bracket round
+func pairings(round []*domain.BracketTreeMatch) []string {
+	pairs := make([]string, len(round))
+	for i, match := range round {
+		pairs[i] = match.Participant1Name + " v " + match.Participant2Name
+	}
+	return pairs
+}
+
+func TestPreviewBracketSavesNothing(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	players := env.players(tournament.ID, 6)
+	unseed(env, players)
+
+	preview, err := env.service.PreviewBracket(ctx, tournament.ID, organizer, "", "")
+	if err != nil {
+		t.Fatalf("PreviewBracket: %v", err)
+	}
+	if preview.Format != domain.SingleElimination || preview.Seeding != SeedByRegistrationOrder {
+		t.Fatalf("expected the tournament's format seeded by registration, got %s/%s", preview.Format, preview.Seeding)
+	}
+	if len(env.store.matches) != 0 {
+		t.Fatalf("a preview must not save matches, found %d", len(env.store.matches))
+	}
+	for _, p := range players {
+		if env.store.participants[p.ID].Seed != 0 {
+			t.Fatal("a preview must not save seeds")
+		}
+	}
+}
+
+func TestPreviewBracketMatchesTheGeneratedBracket(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	env.players(tournament.ID, 8)
+
+	preview, err := env.service.PreviewBracket(ctx, tournament.ID, organizer, "", SeedCurrent)
+	if err != nil {
+		t.Fatalf("PreviewBracket: %v", err)
+	}
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+	generated := buildBracketTree(env.storedMatches(tournament.ID), participantsOf(env, tournament.ID))
+
+	if len(preview.Bracket.Winners) != len(generated.Winners) {
+		t.Fatalf("expected %d rounds, previewed %d", len(generated.Winners), len(preview.Bracket.Winners))
+	}
+	want, got := pairings(generated.Winners[0]), pairings(preview.Bracket.Winners[0])
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("first round differs: previewed %v, generated %v", got, want)
+		}
+	}
+}
+
+func TestPreviewBracketFormatOverride(t *testing.T) {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	env.players(tournament.ID, 4)
+
+	preview, err := env.service.PreviewBracket(context.Background(), tournament.ID, organizer, domain.DoubleElimination, "")
+	if err != nil {
+		t.Fatalf("PreviewBracket: %v", err)
+	}
+	if preview.Format != domain.DoubleElimination || len(preview.Bracket.Losers) == 0 || len(preview.Bracket.GrandFinals) == 0 {
+		t.Fatalf("expected a double elimination preview, got %s with %d losers rounds", preview.Format, len(preview.Bracket.Losers))
+	}
+	if env.store.tournaments[tournament.ID].Format != domain.SingleElimination {
+		t.Fatal("the override must not change the tournament's format")
+	}
+}
+
+// participantsOf lists a tournament's stored participants
+func participantsOf(env *testEnv, tournamentID uuid.UUID) []*domain.Participant {
+	participants, _ := (&fakeParticipantRepo{env.store}).ListByTournament(context.Background(), tournamentID)
+	return participants
+}
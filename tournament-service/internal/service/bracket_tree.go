@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// GetBracketTree groups a tournament's matches by bracket side and round for direct rendering.
+// Matches without a bracket type (single elimination, round robin, Swiss) are placed in the
+// winners side. A tournament without a bracket yields an empty tree.
+func (s *tournamentService) GetBracketTree(ctx context.Context, tournamentID uuid.UUID) (*domain.BracketTree, error) {
+	if _, err := s.tournamentRepo.GetByID(ctx, tournamentID); err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+	if len(matches) == 0 {
+		return buildBracketTree(nil, nil), nil
+	}
+
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	return buildBracketTree(matches, participants), nil
+}
+
+// buildBracketTree lays out matches as a bracket tree, naming participants from the given list.
+// The matches are sorted in place.
+func buildBracketTree(matches []*domain.Match, participants []*domain.Participant) *domain.BracketTree {
+	tree := &domain.BracketTree{
+		Winners:     [][]*domain.BracketTreeMatch{},
+		Losers:      [][]*domain.BracketTreeMatch{},
+		GrandFinals: []*domain.BracketTreeMatch{},
+	}
+
+	names := make(map[uuid.UUID]string, len(participants))
+	for _, p := range participants {
+		names[p.ID] = p.ParticipantName
+	}
+
+	// Feeders are recovered from the forward links, since that is what the bracket stores
+	feeders := make(map[uuid.UUID][]uuid.UUID)
+	for _, match := range matches {
+		if match.NextMatchID != nil {
+			feeders[*match.NextMatchID] = append(feeders[*match.NextMatchID], match.ID)
+		}
+		if match.LoserNextMatchID != nil {
+			feeders[*match.LoserNextMatchID] = append(feeders[*match.LoserNextMatchID], match.ID)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Round != matches[j].Round {
+			return matches[i].Round < matches[j].Round
+		}
+		return matches[i].MatchNumber < matches[j].MatchNumber
+	})
+
+	winnersRounds := make(map[int][]*domain.BracketTreeMatch)
+	losersRounds := make(map[int][]*domain.BracketTreeMatch)
+	for _, match := range matches {
+		node := &domain.BracketTreeMatch{
+			MatchResponse:  toMatchResponse(match),
+			FeederMatchIDs: feeders[match.ID],
+		}
+		if node.FeederMatchIDs == nil {
+			node.FeederMatchIDs = []uuid.UUID{}
+		}
+		if match.Participant1ID != nil {
+			node.Participant1Name = names[*match.Participant1ID]
+		}
+		if match.Participant2ID != nil {
+			node.Participant2Name = names[*match.Participant2ID]
+		}
+
+		switch match.BracketType {
+		case domain.LosersBracket:
+			losersRounds[match.Round] = append(losersRounds[match.Round], node)
+		case domain.GrandFinals:
+			tree.GrandFinals = append(tree.GrandFinals, node)
+		default:
+			winnersRounds[match.Round] = append(winnersRounds[match.Round], node)
+		}
+	}
+
+	tree.Winners = orderedRounds(winnersRounds)
+	tree.Losers = orderedRounds(losersRounds)
+	return tree
+}
+
+// orderedRounds flattens matches keyed by round number into a list of rounds in ascending order
+func orderedRounds(byRound map[int][]*domain.BracketTreeMatch) [][]*domain.BracketTreeMatch {
+	rounds := make([]int, 0, len(byRound))
+	for round := range byRound {
+		rounds = append(rounds, round)
+	}
+	sort.Ints(rounds)
+
+	ordered := make([][]*domain.BracketTreeMatch, 0, len(rounds))
+	for _, round := range rounds {
+		ordered = append(ordered, byRound[round])
+	}
+	return ordered
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestBracketTreeForSingleElimination(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	env.players(tournament.ID, 4)
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+
+	tree, err := env.service.GetBracketTree(ctx, tournament.ID)
+	if err != nil {
+		t.Fatalf("GetBracketTree: %v", err)
+	}
+	if len(tree.Winners) != 2 || len(tree.Winners[0]) != 2 || len(tree.Winners[1]) != 1 {
+		t.Fatalf("expected rounds of 2 and 1 matches, got %d rounds", len(tree.Winners))
+	}
+	if len(tree.Losers) != 0 || len(tree.GrandFinals) != 0 {
+		t.Fatal("a single elimination bracket has no losers side or grand finals")
+	}
+
+	for i, semi := range tree.Winners[0] {
+		if semi.MatchNumber != i+1 {
+			t.Errorf("round 1 is not ordered by match number: position %d holds match %d", i, semi.MatchNumber)
+		}
+		if semi.Participant1Name == "" || semi.Participant2Name == "" {
+			t.Errorf("semi-final %d is missing participant names: %+v", i+1, semi)
+		}
+		if len(semi.FeederMatchIDs) != 0 {
+			t.Errorf("a first round match has no feeders, got %v", semi.FeederMatchIDs)
+		}
+	}
+	final := tree.Winners[1][0]
+	feeders := map[uuid.UUID]bool{}
+	for _, id := range final.FeederMatchIDs {
+		feeders[id] = true
+	}
+	if len(feeders) != 2 || !feeders[tree.Winners[0][0].ID] || !feeders[tree.Winners[0][1].ID] {
+		t.Fatalf("the final should be fed by both semi-finals, got %v", final.FeederMatchIDs)
+	}
+}
+
+func TestBracketTreeForDoubleElimination(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Format = domain.DoubleElimination })
+	env.players(tournament.ID, 4)
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+
+	tree, err := env.service.GetBracketTree(ctx, tournament.ID)
+	if err != nil {
+		t.Fatalf("GetBracketTree: %v", err)
+	}
+	if len(tree.Winners) == 0 || len(tree.Losers) == 0 || len(tree.GrandFinals) == 0 {
+		t.Fatalf("expected all three sides, got %d winners, %d losers rounds and %d grand finals",
+			len(tree.Winners), len(tree.Losers), len(tree.GrandFinals))
+	}
+	for _, round := range tree.Losers {
+		for _, match := range round {
+			if match.BracketType != domain.LosersBracket {
+				t.Errorf("match %s of type %s is on the losers side", match.ID, match.BracketType)
+			}
+		}
+	}
+}
+
+func TestBracketTreeWithoutBracket(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New())
+
+	tree, err := env.service.GetBracketTree(context.Background(), tournament.ID)
+	if err != nil {
+		t.Fatalf("GetBracketTree: %v", err)
+	}
+	// Empty sides are encoded as [] so clients can render them without nil checks
+	encoded, _ := json.Marshal(tree)
+	if string(encoded) != `{"winners":[],"losers":[],"grandFinals":[]}` {
+		t.Fatalf("unexpected empty tree: %s", encoded)
+	}
+
+	_, err = env.service.GetBracketTree(context.Background(), uuid.New())
+	var notFound *ErrTournamentNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected ErrTournamentNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestCompleteByesAdvancesTheLoneParticipant(t *testing.T) {
+	player, opponent, waiting := uuid.New(), uuid.New(), uuid.New()
+	final := &domain.Match{ID: uuid.New(), Round: 2, Participant2ID: &opponent, Status: domain.MatchPending}
+	bye := &domain.Match{ID: uuid.New(), Round: 1, Participant1ID: &player, Status: domain.MatchPending, NextMatchID: &final.ID}
+	// Fed by an earlier match, so its empty slot is waiting on a result rather than a bye
+	semi := &domain.Match{ID: uuid.New(), Round: 1, Participant1ID: &waiting, Status: domain.MatchPending}
+	fed := &domain.Match{ID: uuid.New(), Round: 1, MatchNumber: 2, Participant1ID: &waiting, Status: domain.MatchPending}
+	semi.NextMatchID = &fed.ID
+
+	completeByes(context.Background(), []*domain.Match{bye, semi, fed, final})
+
+	if bye.Status != domain.MatchCompleted || bye.WinnerID == nil || *bye.WinnerID != player || bye.CompletedTime == nil {
+		t.Fatalf("bye was not completed for its participant: %+v", bye)
+	}
+	if final.Participant1ID == nil || *final.Participant1ID != player {
+		t.Fatal("bye winner was not advanced into the next match")
+	}
+	if fed.Status == domain.MatchCompleted {
+		t.Fatal("a one-sided match that is still fed by another match must not be auto-completed")
+	}
+}
+
+func TestGeneratedBracketSavesByesResolved(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) {
+		t.CustomFields = json.RawMessage(`{"full_bracket": true}`)
+	})
+	players := env.players(tournament.ID, 3)
+
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+
+	var byes int
+	for _, match := range env.storedMatches(tournament.ID) {
+		if match.Round != 1 || (match.Participant1ID != nil && match.Participant2ID != nil) {
+			continue
+		}
+		byes++
+		if match.Status != domain.MatchCompleted || match.WinnerID == nil {
+			t.Fatalf("bye match %d was saved unresolved: %+v", match.MatchNumber, match)
+		}
+		if *match.WinnerID != players[0].ID {
+			t.Fatal("the bye should go to the top seed")
+		}
+		next := env.match(t, *match.NextMatchID)
+		if !sameID(next.Participant1ID, *match.WinnerID) && !sameID(next.Participant2ID, *match.WinnerID) {
+			t.Fatal("bye winner is missing from the next match")
+		}
+	}
+	if byes != 1 {
+		t.Fatalf("expected 1 bye for 3 players, got %d", byes)
+	}
+}
+
+func TestFivePlayerByesAdvanceWithoutRankingEvents(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) {
+		t.CustomFields = json.RawMessage(`{"full_bracket": true}`)
+	})
+	players := env.players(tournament.ID, 5)
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+	env.store.tournaments[tournament.ID].Status = domain.InProgress
+
+	var byes []*domain.Match
+	var ready, awaiting *domain.Match
+	for _, match := range env.storedMatches(tournament.ID) {
+		switch {
+		case match.Round == 1 && match.Participant2ID == nil:
+			byes = append(byes, match)
+		case match.Round == 2 && match.Participant1ID != nil && match.Participant2ID != nil:
+			ready = match
+		case match.Round == 2:
+			awaiting = match
+		}
+	}
+	if len(byes) != 3 {
+		t.Fatalf("expected the top 3 seeds to get byes, got %d", len(byes))
+	}
+	for i, bye := range byes {
+		if bye.Status != domain.MatchCompleted || bye.WinnerID == nil || *bye.WinnerID != players[i].ID {
+			t.Fatalf("bye %d was not completed for seed %d: %+v", i, i+1, bye)
+		}
+	}
+	// Seeds 2 and 3 both had byes, so their round 2 match can be played at once
+	if ready == nil || awaiting == nil {
+		t.Fatal("expected one playable round 2 match and one waiting on 4 v 5")
+	}
+	if len(env.store.outbox) != 0 {
+		t.Fatalf("byes must not reach the ranking service, queued %d events", len(env.store.outbox))
+	}
+
+	score := &domain.ScoreUpdateRequest{ScoreParticipant1: 2}
+	if _, err := env.service.UpdateMatchScore(ctx, tournament.ID, byes[0].ID, organizer, score); !errors.Is(err, ErrByeMatch) || !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("scoring a bye: expected ErrByeMatch, got %v", err)
+	}
+	if _, err := env.service.UpdateMatchScore(ctx, tournament.ID, awaiting.ID, organizer, score); !errors.Is(err, ErrMatchAwaitingParticipants) || !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("scoring a waiting match: expected ErrMatchAwaitingParticipants, got %v", err)
+	}
+	if _, err := env.service.UpdateMatchScore(ctx, tournament.ID, ready.ID, organizer, score); err != nil {
+		t.Fatalf("scoring the playable match: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/client"
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestChatMessagesCarryAuthorNames(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 2)
+	alice, bob := *players[0].UserID, *players[1].UserID
+	env.users.details[alice] = client.UserDetails{ID: alice, Username: "alice", DisplayName: "Alice A."}
+
+	for _, author := range []uuid.UUID{alice, bob, alice} {
+		if _, err := env.service.SendMessage(ctx, tournament.ID, author, &domain.MessageRequest{Message: "gl hf"}); err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+	}
+	env.users.calls = nil
+
+	messages, err := env.service.GetMessages(ctx, tournament.ID, 50, 0)
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(messages))
+	}
+	for _, m := range messages {
+		switch m.UserID {
+		case alice:
+			if m.Username != "alice" || m.DisplayName != "Alice A." {
+				t.Errorf("expected alice's names, got %q/%q", m.Username, m.DisplayName)
+			}
+		case bob:
+			// Unknown to the user service, so the placeholder is kept
+			if m.Username != "User-"+bob.String()[:8] || m.DisplayName != "" {
+				t.Errorf("expected the placeholder for bob, got %q/%q", m.Username, m.DisplayName)
+			}
+		}
+	}
+	if len(env.users.calls) != 1 || len(env.users.calls[0]) != 2 {
+		t.Fatalf("expected one lookup of the 2 distinct authors, got %v", env.users.calls)
+	}
+}
+
+func TestChatMessagesSurviveUserServiceFailure(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.InProgress })
+	author := *env.players(tournament.ID, 1)[0].UserID
+	if _, err := env.service.SendMessage(ctx, tournament.ID, author, &domain.MessageRequest{Message: "hello"}); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	env.users.err = errors.New("user service unavailable")
+
+	messages, err := env.service.GetMessages(ctx, tournament.ID, 50, 0)
+	if err != nil {
+		t.Fatalf("a failed name lookup must not fail the chat: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Username != "User-"+author.String()[:8] {
+		t.Fatalf("expected the placeholder name, got %+v", messages)
+	}
+}
+
+func TestSendMessagePushesOneChatEvent(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New())
+	author := *env.players(tournament.ID, 1)[0].UserID
+	env.users.details[author] = client.UserDetails{ID: author, Username: "alice"}
+	env.drainEvents()
+
+	message, err := env.service.SendMessage(ctx, tournament.ID, author, &domain.MessageRequest{Message: "glhf"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	events := env.drainEvents()
+	if len(events) != 1 || events[0].Type != domain.WSEventChatMessage {
+		t.Fatalf("expected exactly one chat event, got %+v", events)
+	}
+	if events[0].TournamentID == nil || *events[0].TournamentID != tournament.ID {
+		t.Fatal("the chat event should only go to the tournament's subscribers")
+	}
+	payload := events[0].Payload.(domain.ChatMessagePayload)
+	if payload.TournamentID != tournament.ID || payload.Message.ID != message.ID || payload.Message.Username != "alice" || payload.Message.CreatedAt.IsZero() {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+
+	// Subscriptions are not authenticated, so private chat is never pushed
+	env.store.tournaments[tournament.ID].Visibility = domain.VisibilityPrivate
+	if _, err := env.service.SendMessage(ctx, tournament.ID, author, &domain.MessageRequest{Message: "secret"}); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	if events := env.drainEvents(); len(events) != 0 {
+		t.Fatalf("a private tournament's chat was pushed: %+v", events)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// viewableTournament loads a tournament userID may see, reporting hidden private tournaments
+// as not found
+func (s *tournamentService) viewableTournament(
+	ctx context.Context, tournamentID, userID uuid.UUID,
+) (*domain.Tournament, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	visible, err := s.canView(ctx, tournament, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, &ErrTournamentNotFound{ID: tournamentID}
+	}
+	return tournament, nil
+}
+
+// MarkChatRead marks the tournament chat read for userID up to now. Match chats are not tracked.
+func (s *tournamentService) MarkChatRead(
+	ctx context.Context, tournamentID, userID uuid.UUID,
+) (*domain.ChatReadState, error) {
+	if _, err := s.viewableTournament(ctx, tournamentID, userID); err != nil {
+		return nil, err
+	}
+	return s.messageRepo.MarkRead(ctx, tournamentID, userID, time.Now())
+}
+
+// CountUnreadMessages counts the tournament chat messages userID has not read, not counting
+// their own. The caller is expected to have checked that userID can see the tournament.
+func (s *tournamentService) CountUnreadMessages(ctx context.Context, tournamentID, userID uuid.UUID) (int, error) {
+	return s.messageRepo.CountUnread(ctx, tournamentID, userID)
+}
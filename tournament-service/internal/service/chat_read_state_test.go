@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// unread counts the chat messages userID has not read yet
+func (f *chatFixture) unread(t *testing.T, userID uuid.UUID) int {
+	t.Helper()
+	count, err := f.env.service.CountUnreadMessages(context.Background(), f.tournament.ID, userID)
+	if err != nil {
+		t.Fatalf("CountUnreadMessages: %v", err)
+	}
+	return count
+}
+
+func TestMarkingTheChatReadResetsTheUnreadCount(t *testing.T) {
+	ctx := context.Background()
+	f := newChatFixture(t)
+
+	if got := f.unread(t, f.other); got != 1 {
+		t.Fatalf("expected the author's message to be unread, got %d", got)
+	}
+	if got := f.unread(t, f.author); got != 0 {
+		t.Fatalf("authors have read their own messages, got %d", got)
+	}
+
+	state, err := f.env.service.MarkChatRead(ctx, f.tournament.ID, f.other)
+	if err != nil {
+		t.Fatalf("MarkChatRead: %v", err)
+	}
+	if state.TournamentID != f.tournament.ID || state.LastReadAt.IsZero() {
+		t.Fatalf("unexpected read state %+v", state)
+	}
+	if got := f.unread(t, f.other); got != 0 {
+		t.Fatalf("expected nothing unread after marking the chat read, got %d", got)
+	}
+
+	// Only newer tournament chat messages count again, not match threads
+	if _, err := f.env.service.SendMessage(ctx, f.tournament.ID, f.author, &domain.MessageRequest{Message: "rematch?"}); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	matchID := uuid.New()
+	f.env.store.messages[uuid.New()] = &domain.Message{TournamentID: f.tournament.ID, MatchID: &matchID, UserID: f.author}
+	if got := f.unread(t, f.other); got != 1 {
+		t.Fatalf("expected the one new message to be unread, got %d", got)
+	}
+}
+
+func TestMarkingAHiddenChatReadIsNotFound(t *testing.T) {
+	f := newChatFixture(t)
+	f.env.store.tournaments[f.tournament.ID].Visibility = domain.VisibilityPrivate
+
+	var notFound *ErrTournamentNotFound
+	if _, err := f.env.service.MarkChatRead(context.Background(), f.tournament.ID, uuid.New()); !errors.As(err, &notFound) {
+		t.Fatalf("a stranger marking a private chat read: expected ErrTournamentNotFound, got %v", err)
+	}
+}
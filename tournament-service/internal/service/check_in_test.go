@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestCheckInMarksTheParticipant(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New())
+	player := env.players(tournament.ID, 1)[0]
+
+	if err := env.service.CheckInParticipant(ctx, tournament.ID, *player.UserID); err != nil {
+		t.Fatalf("CheckInParticipant: %v", err)
+	}
+	if env.store.participants[player.ID].Status != domain.ParticipantCheckedIn {
+		t.Fatal("the participant should be checked in")
+	}
+	if err := env.service.CheckInParticipant(ctx, tournament.ID, *player.UserID); !errors.Is(err, ErrAlreadyCheckedIn) {
+		t.Fatalf("expected ErrAlreadyCheckedIn, got %v", err)
+	}
+	if err := env.service.CheckInParticipant(ctx, tournament.ID, uuid.New()); !errors.Is(err, ErrNotRegistered) {
+		t.Fatalf("expected ErrNotRegistered, got %v", err)
+	}
+}
+
+func TestCheckInIsClosedOutsideRegistration(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	started := time.Now().Add(-time.Minute)
+	running := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.InProgress })
+	late := env.tournament(uuid.New(), func(t *domain.Tournament) { t.StartTime = &started })
+
+	for name, tournament := range map[string]*domain.Tournament{"in progress": running, "past start time": late} {
+		player := env.players(tournament.ID, 1)[0]
+		if err := env.service.CheckInParticipant(ctx, tournament.ID, *player.UserID); !errors.Is(err, ErrCheckInClosed) {
+			t.Errorf("%s: expected ErrCheckInClosed, got %v", name, err)
+		}
+	}
+
+	var notFound *ErrTournamentNotFound
+	if err := env.service.CheckInParticipant(ctx, uuid.New(), uuid.New()); !errors.As(err, &notFound) {
+		t.Fatalf("expected ErrTournamentNotFound, got %v", err)
+	}
+}
+
+func TestCheckInWaitsForTheConfiguredWindow(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	start := time.Now().Add(2 * time.Hour)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) {
+		t.StartTime = &start
+		t.CustomFields = json.RawMessage(`{"check_in_window_minutes": 60}`)
+	})
+	player := env.players(tournament.ID, 1)[0]
+
+	var notOpen *ErrCheckInNotOpen
+	err := env.service.CheckInParticipant(ctx, tournament.ID, *player.UserID)
+	if !errors.As(err, &notOpen) || !notOpen.OpensAt.Equal(start.Add(-time.Hour)) {
+		t.Fatalf("expected check-in to open an hour before the start, got %v", err)
+	}
+
+	// Once inside the window the participant can check in
+	env.store.tournaments[tournament.ID].CustomFields = json.RawMessage(`{"check_in_window_minutes": 180}`)
+	if err := env.service.CheckInParticipant(ctx, tournament.ID, *player.UserID); err != nil {
+		t.Fatalf("CheckInParticipant inside the window: %v", err)
+	}
+}
+
+func TestCheckInWindowDefaults(t *testing.T) {
+	for fields, want := range map[string]time.Duration{
+		``:                                    0,
+		`{"check_in_window_minutes": 30}`:     30 * time.Minute,
+		`{"check_in_window_minutes": -5}`:     0,
+		`{"check_in_window_minutes": "soon"}`: 0,
+	} {
+		tournament := &domain.Tournament{ID: uuid.New()}
+		if fields != "" {
+			tournament.CustomFields = json.RawMessage(fields)
+		}
+		if got := checkInWindow(tournament); got != want {
+			t.Errorf("%q: want %s, got %s", fields, want, got)
+		}
+	}
+}
+
+func TestWaitlistedCheckInNeedsAFreeSlot(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.MaxParticipants = 2 })
+	players := env.players(tournament.ID, 3)
+	waitlisted := env.store.participants[players[2].ID]
+	waitlisted.IsWaitlisted = true
+
+	if err := env.service.CheckInParticipant(ctx, tournament.ID, *players[2].UserID); !errors.Is(err, ErrTournamentFull) {
+		t.Fatalf("expected ErrTournamentFull, got %v", err)
+	}
+
+	// Moving a confirmed player to the waitlist frees a slot; the waitlist itself never counts
+	env.store.participants[players[1].ID].IsWaitlisted = true
+	if err := env.service.CheckInParticipant(ctx, tournament.ID, *players[2].UserID); err != nil {
+		t.Fatalf("CheckInParticipant: %v", err)
+	}
+	if stored := env.store.participants[players[2].ID]; stored.IsWaitlisted || stored.Status != domain.ParticipantCheckedIn {
+		t.Fatalf("expected the participant off the waitlist and checked in: %+v", stored)
+	}
+}
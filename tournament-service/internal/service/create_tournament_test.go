@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// validCreateRequest is a create request that passes validation
+func validCreateRequest() *domain.CreateTournamentRequest {
+	return &domain.CreateTournamentRequest{Name: "Spring Open", Game: "chess", MaxParticipants: 16}
+}
+
+func TestCreateTournamentInitialStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial domain.TournamentStatus
+		want    domain.TournamentStatus
+		wantErr error
+	}{
+		{name: "defaults to draft", want: domain.Draft},
+		{name: "explicit draft", initial: domain.Draft, want: domain.Draft},
+		{name: "opens registration", initial: domain.Registration, want: domain.Registration},
+		{name: "rejects in progress", initial: domain.InProgress, wantErr: ErrInvalidInitialStatus},
+		{name: "rejects completed", initial: domain.Completed, wantErr: ErrInvalidInitialStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			request := validCreateRequest()
+			request.InitialStatus = tt.initial
+
+			tournament, err := env.service.CreateTournament(context.Background(), request, uuid.New())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				if len(env.store.tournaments) != 0 {
+					t.Fatal("a rejected request must not create a tournament")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateTournament: %v", err)
+			}
+			if tournament.Status != tt.want {
+				t.Fatalf("expected status %s, got %s", tt.want, tournament.Status)
+			}
+		})
+	}
+}
+
+func TestCreateTournamentRecordsRegistrationOpening(t *testing.T) {
+	env := newTestEnv(t)
+	creator := uuid.New()
+	request := validCreateRequest()
+	request.InitialStatus = domain.Registration
+
+	tournament, err := env.service.CreateTournament(context.Background(), request, creator)
+	if err != nil {
+		t.Fatalf("CreateTournament: %v", err)
+	}
+
+	created := env.activities.ofType(domain.ActivityTournamentCreated)
+	if len(created) != 1 {
+		t.Fatalf("expected one creation activity, got %d", len(created))
+	}
+	if created[0].UserID != creator || *created[0].RelatedEntityID != tournament.ID {
+		t.Fatalf("activity not attributed to the creator and tournament: %+v", created[0])
+	}
+	if created[0].Description == "" {
+		t.Fatal("opening registration at creation should be mentioned in the activity")
+	}
+}
+
+func TestCreateTournamentStoresTheCanonicalGame(t *testing.T) {
+	env := newTestEnv(t)
+	for game, want := range map[string]string{"FIFA 23": "fifa23", "EA Sports FC 24": "fc24", " Backyard Cricket ": "Backyard Cricket"} {
+		request := validCreateRequest()
+		request.Game, request.AllowCustomGame = game, true
+		tournament, err := env.service.CreateTournament(context.Background(), request, uuid.New())
+		if err != nil {
+			t.Fatalf("CreateTournament(%q): %v", game, err)
+		}
+		if stored := env.store.tournaments[tournament.ID].Game; stored != want {
+			t.Errorf("%q: expected %q to be stored, got %q", game, want, stored)
+		}
+	}
+
+	request := validCreateRequest()
+	request.Game = "Backyard Cricket"
+	if _, err := env.service.CreateTournament(context.Background(), request, uuid.New()); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("an unknown game without allowCustomGame should be rejected, got %v", err)
+	}
+}
+
+func TestCreateTournamentAnnouncesOnlyPublicTournaments(t *testing.T) {
+	env := newTestEnv(t)
+	for visibility, announced := range map[domain.TournamentVisibility]bool{
+		"":                        true,
+		domain.VisibilityPublic:   true,
+		domain.VisibilityUnlisted: false,
+		domain.VisibilityPrivate:  false,
+	} {
+		request := validCreateRequest()
+		request.Visibility = visibility
+		if _, err := env.service.CreateTournament(context.Background(), request, uuid.New()); err != nil {
+			t.Fatalf("CreateTournament(%q): %v", visibility, err)
+		}
+		var created int
+		for _, event := range env.drainEvents() {
+			if event.Type == domain.WSEventTournamentCreated {
+				created++
+			}
+		}
+		if announced && created != 1 || !announced && created != 0 {
+			t.Errorf("%q: expected announced=%v, got %d creation events", visibility, announced, created)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// CreationQuota caps how many tournaments one user may have at once, so a single account
+// cannot flood the service. Archived tournaments do not count towards it.
+type CreationQuota struct {
+	// Limit applies to every user without an override; 0 means unlimited
+	Limit int
+	// Overrides replaces Limit for particular users, again with 0 meaning unlimited
+	Overrides map[uuid.UUID]int
+}
+
+// LimitFor returns userID's tournament limit, 0 when they have none
+func (q CreationQuota) LimitFor(userID uuid.UUID) int {
+	if limit, ok := q.Overrides[userID]; ok {
+		return limit
+	}
+	return q.Limit
+}
+
+// ErrQuotaExceeded is returned when a user who already has Limit tournaments tries to create another
+type ErrQuotaExceeded struct {
+	Limit int
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("you have reached the limit of %d tournaments; archive one to create another", e.Limit)
+}
+
+func (e *ErrQuotaExceeded) HTTPStatus() int { return http.StatusTooManyRequests }
+func (e *ErrQuotaExceeded) Unwrap() error   { return domain.ErrTooManyRequests }
+
+func (e *ErrQuotaExceeded) Details() map[string]interface{} {
+	return map[string]interface{}{"limit": e.Limit}
+}
+
+// checkCreationQuota returns ErrQuotaExceeded when userID may not create another tournament
+func (s *tournamentService) checkCreationQuota(ctx context.Context, userID uuid.UUID) error {
+	limit := s.creationQuota.LimitFor(userID)
+	if limit <= 0 {
+		return nil
+	}
+	count, err := s.tournamentRepo.CountActiveByCreator(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to count tournaments: %w", err)
+	}
+	if count >= limit {
+		return &ErrQuotaExceeded{Limit: limit}
+	}
+	return nil
+}
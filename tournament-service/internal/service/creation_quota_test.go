@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestCreationQuotaCapsActiveTournaments(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	env.service.creationQuota = CreationQuota{Limit: 2}
+	creator := uuid.New()
+
+	var created []*domain.Tournament
+	for i := 0; i < 2; i++ {
+		tournament, err := env.service.CreateTournament(ctx, validCreateRequest(), creator)
+		if err != nil {
+			t.Fatalf("tournament %d under the limit: %v", i+1, err)
+		}
+		created = append(created, tournament)
+	}
+
+	_, err := env.service.CreateTournament(ctx, validCreateRequest(), creator)
+	var exceeded *ErrQuotaExceeded
+	if !errors.As(err, &exceeded) || exceeded.Limit != 2 || !errors.Is(err, domain.ErrTooManyRequests) || exceeded.HTTPStatus() != http.StatusTooManyRequests {
+		t.Fatalf("expected ErrQuotaExceeded with limit 2, got %v", err)
+	}
+	// Other users have their own allowance
+	if _, err := env.service.CreateTournament(ctx, validCreateRequest(), uuid.New()); err != nil {
+		t.Fatalf("another user: %v", err)
+	}
+
+	// Archiving a tournament frees its place
+	env.store.tournaments[created[0].ID].Status = domain.Archived
+	if _, err := env.service.CreateTournament(ctx, validCreateRequest(), creator); err != nil {
+		t.Fatalf("after archiving: %v", err)
+	}
+}
+
+func TestCreationQuotaOverrides(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	unlimited, tight := uuid.New(), uuid.New()
+	env.service.creationQuota = CreationQuota{Limit: 1, Overrides: map[uuid.UUID]int{unlimited: 0, tight: 1}}
+
+	for i := 0; i < 3; i++ {
+		if _, err := env.service.CreateTournament(ctx, validCreateRequest(), unlimited); err != nil {
+			t.Fatalf("an override of 0 should lift the cap: %v", err)
+		}
+	}
+	if _, err := env.service.CreateTournament(ctx, validCreateRequest(), tight); err != nil {
+		t.Fatalf("first tournament: %v", err)
+	}
+	var exceeded *ErrQuotaExceeded
+	if _, err := env.service.CreateTournament(ctx, validCreateRequest(), tight); !errors.As(err, &exceeded) {
+		t.Fatalf("expected ErrQuotaExceeded, got %v", err)
+	}
+
+	for userID, want := range map[uuid.UUID]int{unlimited: 0, tight: 1, uuid.New(): 1} {
+		if got := env.service.creationQuota.LimitFor(userID); got != want {
+			t.Errorf("LimitFor: expected %d, got %d", want, got)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestClientErrorsAreClassified(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	running := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+	open := env.tournament(organizer)
+	other := env.tournament(organizer)
+	elsewhere := &domain.Match{ID: uuid.New(), TournamentID: other.ID, Round: 1, MatchNumber: 1, Status: domain.MatchPending}
+	env.store.putMatch(elsewhere)
+	negative := -1
+
+	for name, tc := range map[string]struct {
+		kind error
+		run  func() error
+	}{
+		"negative reporting window on create": {domain.ErrValidation, func() error {
+			request := validCreateRequest()
+			request.ReportingWindowMinutes = -5
+			_, err := env.service.CreateTournament(ctx, request, organizer)
+			return err
+		}},
+		"unknown deadline policy on create": {domain.ErrValidation, func() error {
+			request := validCreateRequest()
+			request.ReportingDeadlinePolicy = "IGNORE"
+			_, err := env.service.CreateTournament(ctx, request, organizer)
+			return err
+		}},
+		"negative grand finals advantage on update": {domain.ErrValidation, func() error {
+			_, err := env.service.UpdateTournament(ctx, open.ID, organizer, &domain.UpdateTournamentRequest{GrandFinalsAdvantage: &negative})
+			return err
+		}},
+		"update after the start": {domain.ErrConflict, func() error {
+			_, err := env.service.UpdateTournament(ctx, running.ID, organizer, &domain.UpdateTournamentRequest{Name: "Renamed"})
+			return err
+		}},
+		"delete while in progress": {domain.ErrConflict, func() error {
+			return env.service.DeleteTournament(ctx, running.ID, organizer)
+		}},
+		"invalid status transition": {domain.ErrConflict, func() error {
+			return env.service.UpdateTournamentStatus(ctx, running.ID, organizer, domain.Draft)
+		}},
+		"registration without a user": {domain.ErrValidation, func() error {
+			_, err := env.service.RegisterParticipant(ctx, open.ID, organizer, &domain.ParticipantRequest{ParticipantName: "anon"})
+			return err
+		}},
+		"unregister after the start": {domain.ErrConflict, func() error {
+			return env.service.UnregisterParticipant(ctx, running.ID, organizer)
+		}},
+		"bracket without enough players": {domain.ErrConflict, func() error {
+			return env.service.GenerateBracket(ctx, open.ID, organizer, false)
+		}},
+		"match of another tournament": {domain.ErrNotFound, func() error {
+			_, err := env.service.GetMatch(ctx, open.ID, elsewhere.ID)
+			return err
+		}},
+		"score for a match of another tournament": {domain.ErrNotFound, func() error {
+			_, err := env.service.UpdateMatchScore(ctx, running.ID, elsewhere.ID, organizer, &domain.ScoreUpdateRequest{})
+			return err
+		}},
+		"bracket preview without enough players": {domain.ErrConflict, func() error {
+			_, err := env.service.PreviewBracket(ctx, open.ID, organizer, "", "")
+			return err
+		}},
+		"forfeit without a forfeiting player": {domain.ErrValidation, func() error {
+			players := env.players(running.ID, 2)
+			match := &domain.Match{ID: uuid.New(), TournamentID: running.ID, Round: 1, MatchNumber: 1, Status: domain.MatchPending,
+				Participant1ID: &players[0].ID, Participant2ID: &players[1].ID}
+			env.store.putMatch(match)
+			_, err := env.service.ForfeitMatch(ctx, running.ID, match.ID, organizer, &domain.ForfeitRequest{})
+			return err
+		}},
+		"unknown tournament": {domain.ErrNotFound, func() error {
+			_, err := env.service.GetTournament(ctx, uuid.New())
+			return err
+		}},
+	} {
+		if err := tc.run(); !errors.Is(err, tc.kind) {
+			t.Errorf("%s: expected %v, got %v", name, tc.kind, err)
+		}
+	}
+}
@@ -0,0 +1,1088 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/client"
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/repository"
+	"github.com/cliffdoyle/tournament-service/internal/service/bracket"
+	"github.com/google/uuid"
+)
+
+// memStore is an in-memory stand-in for the Postgres tables the service reads and writes.
+// Reads hand out copies, so a caller only changes the store through the repositories.
+type memStore struct {
+	mu           sync.Mutex
+	tournaments  map[uuid.UUID]*domain.Tournament
+	inviteCodes  map[uuid.UUID]string
+	participants map[uuid.UUID]*domain.Participant
+	matches      map[uuid.UUID]*domain.Match
+	messages     map[uuid.UUID]*domain.Message
+	reactions    map[uuid.UUID]map[string]map[uuid.UUID]bool // message -> emoji -> users
+	readStates   map[[2]uuid.UUID]time.Time                  // (tournament, user) -> last read
+	outbox       []*domain.OutboxEntry
+	created      int // participants created so far, for registration order
+	order        map[uuid.UUID]int
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		tournaments:  map[uuid.UUID]*domain.Tournament{},
+		inviteCodes:  map[uuid.UUID]string{},
+		participants: map[uuid.UUID]*domain.Participant{},
+		matches:      map[uuid.UUID]*domain.Match{},
+		messages:     map[uuid.UUID]*domain.Message{},
+		reactions:    map[uuid.UUID]map[string]map[uuid.UUID]bool{},
+		readStates:   map[[2]uuid.UUID]time.Time{},
+		order:        map[uuid.UUID]int{},
+	}
+}
+
+// testEnv is a tournament service wired to a memStore and recording fakes
+type testEnv struct {
+	store      *memStore
+	service    *tournamentService
+	activities *fakeActivities
+	users      *fakeUsers
+	rankings   *fakeRankings
+	events     chan domain.WebSocketMessage
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := &testEnv{
+		store:      newMemStore(),
+		activities: &fakeActivities{},
+		users:      &fakeUsers{details: map[uuid.UUID]client.UserDetails{}},
+		rankings:   &fakeRankings{points: map[uuid.UUID]int{}},
+		events:     make(chan domain.WebSocketMessage, 256),
+	}
+	env.service = NewTournamentService(
+		&fakeTournamentRepo{env.store},
+		&fakeParticipantRepo{env.store},
+		&fakeMatchRepo{store: env.store},
+		&fakeMessageRepo{env.store},
+		bracket.NewSingleEliminationGenerator(),
+		env.activities,
+		env.events,
+		env.users,
+		env.rankings,
+		CreationQuota{},
+	).(*tournamentService)
+	return env
+}
+
+// tournament stores a tournament run by organizer and returns it
+func (e *testEnv) tournament(organizer uuid.UUID, configure ...func(*domain.Tournament)) *domain.Tournament {
+	tournament := &domain.Tournament{
+		ID:              uuid.New(),
+		Name:            "Test Cup",
+		Game:            "chess",
+		Format:          domain.SingleElimination,
+		Status:          domain.Registration,
+		MaxParticipants: 64,
+		CreatedBy:       organizer,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		Visibility:      domain.VisibilityPublic,
+	}
+	for _, fn := range configure {
+		fn(tournament)
+	}
+	e.store.putTournament(tournament)
+	return tournament
+}
+
+// players registers count linked participants seeded 1..count and returns them in seed order
+func (e *testEnv) players(tournamentID uuid.UUID, count int) []*domain.Participant {
+	participants := make([]*domain.Participant, count)
+	for i := range participants {
+		userID := uuid.New()
+		participants[i] = &domain.Participant{
+			ID:              uuid.New(),
+			TournamentID:    tournamentID,
+			UserID:          &userID,
+			ParticipantName: fmt.Sprintf("player%d", i+1),
+			Seed:            i + 1,
+			Status:          domain.ParticipantRegistered,
+		}
+		if err := (&fakeParticipantRepo{e.store}).Create(context.Background(), participants[i]); err != nil {
+			panic(err)
+		}
+	}
+	return participants
+}
+
+// match fetches the stored copy of a match
+func (e *testEnv) match(t *testing.T, id uuid.UUID) *domain.Match {
+	t.Helper()
+	match, err := (&fakeMatchRepo{store: e.store}).GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("match %s: %v", id, err)
+	}
+	return match
+}
+
+// storedMatches lists a tournament's stored matches by round and match number
+func (e *testEnv) storedMatches(tournamentID uuid.UUID) []*domain.Match {
+	matches, _ := (&fakeMatchRepo{store: e.store}).GetByTournamentID(context.Background(), tournamentID)
+	return matches
+}
+
+// drainEvents returns the websocket events broadcast so far
+func (e *testEnv) drainEvents() []domain.WebSocketMessage {
+	var events []domain.WebSocketMessage
+	for {
+		select {
+		case event := <-e.events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func (s *memStore) putTournament(tournament *domain.Tournament) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *tournament
+	s.tournaments[tournament.ID] = &copied
+}
+
+func (s *memStore) putMatch(match *domain.Match) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *match
+	if copied.Version == 0 {
+		copied.Version = 1
+	}
+	s.matches[match.ID] = &copied
+}
+
+// fakeTournamentRepo implements repository.TournamentRepository over a memStore
+type fakeTournamentRepo struct{ s *memStore }
+
+var _ repository.TournamentRepository = (*fakeTournamentRepo)(nil)
+
+func (r *fakeTournamentRepo) Create(ctx context.Context, tournament *domain.Tournament) error {
+	if tournament.ID == uuid.Nil {
+		tournament.ID = uuid.New()
+	}
+	tournament.CreatedAt = time.Now()
+	tournament.UpdatedAt = tournament.CreatedAt
+	r.s.putTournament(tournament)
+	if tournament.InviteCode != "" {
+		r.s.mu.Lock()
+		r.s.inviteCodes[tournament.ID] = tournament.InviteCode
+		r.s.mu.Unlock()
+	}
+	return nil
+}
+
+func (r *fakeTournamentRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Tournament, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	tournament, ok := r.s.tournaments[id]
+	if !ok {
+		return nil, domain.NewError(domain.ErrNotFound, fmt.Sprintf("tournament not found: %v", id))
+	}
+	copied := *tournament
+	return &copied, nil
+}
+
+func (r *fakeTournamentRepo) List(
+	ctx context.Context, filters map[string]interface{}, page, pageSize int,
+) ([]*domain.Tournament, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var all []*domain.Tournament
+	for _, tournament := range r.s.tournaments {
+		if status, ok := filters["status"]; ok {
+			if fmt.Sprint(status) != string(tournament.Status) {
+				continue
+			}
+		} else if includeArchived, _ := filters["includeArchived"].(bool); !includeArchived && tournament.Status == domain.Archived {
+			continue
+		}
+		if game, ok := filters["game"]; ok && fmt.Sprint(game) != tournament.Game {
+			continue
+		}
+		if viewerID, ok := filters["visibleTo"].(uuid.UUID); ok && !r.s.visibleTo(tournament, viewerID) {
+			continue
+		}
+		copied := *tournament
+		all = append(all, &copied)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.After(all[j].CreatedAt) })
+	return paginate(all, (page-1)*pageSize, pageSize), len(all), nil
+}
+
+func (r *fakeTournamentRepo) Update(ctx context.Context, tournament *domain.Tournament) error {
+	r.s.mu.Lock()
+	_, ok := r.s.tournaments[tournament.ID]
+	r.s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("tournament not found: %v", tournament.ID)
+	}
+	tournament.UpdatedAt = time.Now()
+	r.s.putTournament(tournament)
+	return nil
+}
+
+func (r *fakeTournamentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.tournaments[id]; !ok {
+		return fmt.Errorf("tournament not found: %v", id)
+	}
+	delete(r.s.tournaments, id)
+	for matchID, match := range r.s.matches {
+		if match.TournamentID == id {
+			delete(r.s.matches, matchID)
+		}
+	}
+	for participantID, participant := range r.s.participants {
+		if participant.TournamentID == id {
+			delete(r.s.participants, participantID)
+		}
+	}
+	for messageID, message := range r.s.messages {
+		if message.TournamentID == id {
+			delete(r.s.messages, messageID)
+		}
+	}
+	return nil
+}
+
+func (r *fakeTournamentRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Tournament, error) {
+	var tournaments []*domain.Tournament
+	for _, id := range ids {
+		if tournament, err := r.GetByID(ctx, id); err == nil {
+			tournaments = append(tournaments, tournament)
+		}
+	}
+	return tournaments, nil
+}
+
+func (r *fakeTournamentRepo) GetParticipantCount(ctx context.Context, id uuid.UUID) (int, error) {
+	counts, err := r.GetParticipantCounts(ctx, []uuid.UUID{id})
+	return counts[id], err
+}
+
+func (r *fakeTournamentRepo) CountActiveByCreator(ctx context.Context, userID uuid.UUID) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	count := 0
+	for _, tournament := range r.s.tournaments {
+		if tournament.CreatedBy == userID && tournament.Status != domain.Archived {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeTournamentRepo) GetParticipantCounts(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	counts := map[uuid.UUID]int{}
+	for _, id := range ids {
+		for _, participant := range r.s.participants {
+			if participant.TournamentID == id {
+				counts[id]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+func (r *fakeTournamentRepo) GetByStatuses(
+	ctx context.Context, statuses []domain.TournamentStatus, visibleTo *uuid.UUID, limit int, offset int,
+) ([]*domain.Tournament, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var matched []*domain.Tournament
+	for _, tournament := range r.s.tournaments {
+		if len(statuses) > 0 && !containsStatus(statuses, tournament.Status) {
+			continue
+		}
+		if visibleTo != nil && !r.s.visibleTo(tournament, *visibleTo) {
+			continue
+		}
+		copied := *tournament
+		matched = append(matched, &copied)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+	return paginate(matched, offset, limit), len(matched), nil
+}
+
+func (r *fakeTournamentRepo) ListByParticipantUser(
+	ctx context.Context, userID uuid.UUID, statuses []domain.TournamentStatus,
+) ([]*domain.Tournament, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var tournaments []*domain.Tournament
+	for _, tournament := range r.s.tournaments {
+		if len(statuses) > 0 && !containsStatus(statuses, tournament.Status) {
+			continue
+		}
+		if r.s.participantOf(tournament.ID, userID) {
+			copied := *tournament
+			tournaments = append(tournaments, &copied)
+		}
+	}
+	return tournaments, nil
+}
+
+func (r *fakeTournamentRepo) GetInviteCode(ctx context.Context, id uuid.UUID) (string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.tournaments[id]; !ok {
+		return "", fmt.Errorf("tournament not found: %v", id)
+	}
+	return r.s.inviteCodes[id], nil
+}
+
+func (r *fakeTournamentRepo) SetInviteCode(ctx context.Context, id uuid.UUID, code string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.inviteCodes[id] = code
+	return nil
+}
+
+func (r *fakeTournamentRepo) GetIDByInviteCode(ctx context.Context, code string) (uuid.UUID, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for id, stored := range r.s.inviteCodes {
+		if code != "" && stored == code {
+			return id, nil
+		}
+	}
+	return uuid.Nil, nil
+}
+
+func (r *fakeTournamentRepo) Import(
+	ctx context.Context, tournament *domain.Tournament, participants []*domain.Participant,
+	matches []*domain.Match, messages []*domain.Message,
+) error {
+	if err := r.Create(ctx, tournament); err != nil {
+		return err
+	}
+	for _, participant := range participants {
+		if err := (&fakeParticipantRepo{r.s}).Create(ctx, participant); err != nil {
+			return err
+		}
+	}
+	for _, match := range matches {
+		r.s.putMatch(match)
+	}
+	for _, message := range messages {
+		if err := (&fakeMessageRepo{r.s}).Create(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memStore) visibleTo(tournament *domain.Tournament, viewerID uuid.UUID) bool {
+	if tournament.Visibility == "" || tournament.Visibility == domain.VisibilityPublic {
+		return true
+	}
+	return tournament.CreatedBy == viewerID || s.participantOf(tournament.ID, viewerID)
+}
+
+func (s *memStore) participantOf(tournamentID, userID uuid.UUID) bool {
+	for _, participant := range s.participants {
+		if participant.TournamentID == tournamentID && participant.UserID != nil && *participant.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatus(statuses []domain.TournamentStatus, status domain.TournamentStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func paginate[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// fakeParticipantRepo implements repository.ParticipantRepository over a memStore
+type fakeParticipantRepo struct{ s *memStore }
+
+var _ repository.ParticipantRepository = (*fakeParticipantRepo)(nil)
+
+func (r *fakeParticipantRepo) Create(ctx context.Context, participant *domain.Participant) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if participant.UserID != nil && r.s.participantOf(participant.TournamentID, *participant.UserID) {
+		return domain.ErrAlreadyParticipant
+	}
+	if participant.ID == uuid.Nil {
+		participant.ID = uuid.New()
+	}
+	if participant.CreatedAt.IsZero() {
+		participant.CreatedAt = time.Now()
+	}
+	participant.UpdatedAt = participant.CreatedAt
+	copied := *participant
+	r.s.participants[participant.ID] = &copied
+	r.s.created++
+	r.s.order[participant.ID] = r.s.created
+	return nil
+}
+
+func (r *fakeParticipantRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Participant, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	participant, ok := r.s.participants[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *participant
+	return &copied, nil
+}
+
+func (r *fakeParticipantRepo) GetByTournamentAndUser(
+	ctx context.Context, tournamentID, userID uuid.UUID,
+) (*domain.Participant, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, participant := range r.s.participants {
+		if participant.TournamentID == tournamentID && participant.UserID != nil && *participant.UserID == userID {
+			copied := *participant
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeParticipantRepo) ListByTournament(ctx context.Context, tournamentID uuid.UUID) ([]*domain.Participant, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	participants := []*domain.Participant{}
+	for _, participant := range r.s.participants {
+		if participant.TournamentID == tournamentID {
+			copied := *participant
+			participants = append(participants, &copied)
+		}
+	}
+	sort.Slice(participants, func(i, j int) bool {
+		if participants[i].Seed != participants[j].Seed {
+			return participants[i].Seed < participants[j].Seed
+		}
+		return r.s.order[participants[i].ID] < r.s.order[participants[j].ID]
+	})
+	return participants, nil
+}
+
+func (r *fakeParticipantRepo) Update(ctx context.Context, participant *domain.Participant) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.participants[participant.ID]; !ok {
+		return fmt.Errorf("participant not found: %v", participant.ID)
+	}
+	copied := *participant
+	r.s.participants[participant.ID] = &copied
+	return nil
+}
+
+func (r *fakeParticipantRepo) UpdateSeed(ctx context.Context, id uuid.UUID, seed int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	participant, ok := r.s.participants[id]
+	if !ok {
+		return fmt.Errorf("participant not found: %v", id)
+	}
+	participant.Seed = seed
+	return nil
+}
+
+func (r *fakeParticipantRepo) CheckIn(ctx context.Context, id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	participant, ok := r.s.participants[id]
+	if !ok {
+		return fmt.Errorf("participant not found: %v", id)
+	}
+	participant.Status = domain.ParticipantCheckedIn
+	return nil
+}
+
+func (r *fakeParticipantRepo) Substitute(ctx context.Context, participant *domain.Participant) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored, ok := r.s.participants[participant.ID]
+	if !ok {
+		return fmt.Errorf("participant not found: %v", participant.ID)
+	}
+	if participant.UserID != nil {
+		for _, other := range r.s.participants {
+			if other.ID != participant.ID && other.TournamentID == participant.TournamentID &&
+				other.UserID != nil && *other.UserID == *participant.UserID {
+				return domain.ErrAlreadyParticipant
+			}
+		}
+	}
+	stored.ParticipantName = participant.ParticipantName
+	stored.UserID = participant.UserID
+	stored.UpdatedAt = participant.UpdatedAt
+	return nil
+}
+
+func (r *fakeParticipantRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.participants[id]; !ok {
+		return fmt.Errorf("participant not found: %v", id)
+	}
+	delete(r.s.participants, id)
+	return nil
+}
+
+func (r *fakeParticipantRepo) ExistsByTournamentIDAndUserID(ctx context.Context, tournamentID, userID uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.participantOf(tournamentID, userID), nil
+}
+
+// fakeMatchRepo implements repository.MatchRepository over a memStore. Updates check the
+// version like the real repository does, and failUpdate lets a test fail chosen writes.
+type fakeMatchRepo struct {
+	store      *memStore
+	failUpdate func(match *domain.Match) error
+}
+
+var _ repository.MatchRepository = (*fakeMatchRepo)(nil)
+
+func (r *fakeMatchRepo) Create(ctx context.Context, match *domain.Match) error {
+	match.CreatedAt = time.Now()
+	match.UpdatedAt = match.CreatedAt
+	match.Version = 1
+	r.store.putMatch(match)
+	return nil
+}
+
+func (r *fakeMatchRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Match, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	match, ok := r.store.matches[id]
+	if !ok {
+		return nil, domain.NewError(domain.ErrNotFound, fmt.Sprintf("match not found: %v", id))
+	}
+	copied := *match
+	return &copied, nil
+}
+
+func (r *fakeMatchRepo) GetByTournamentID(ctx context.Context, tournamentID uuid.UUID) ([]*domain.Match, error) {
+	return r.list(func(m *domain.Match) bool { return m.TournamentID == tournamentID }), nil
+}
+
+func (r *fakeMatchRepo) ListByTournament(
+	ctx context.Context, tournamentID uuid.UUID, filter domain.MatchFilter,
+) ([]*domain.Match, int, error) {
+	matches := r.list(func(m *domain.Match) bool {
+		return m.TournamentID == tournamentID && (filter.BracketType == "" || m.BracketType == filter.BracketType)
+	})
+	return paginate(matches, filter.Offset, filter.Limit), len(matches), nil
+}
+
+func (r *fakeMatchRepo) GetByRound(ctx context.Context, tournamentID uuid.UUID, round int) ([]*domain.Match, error) {
+	return r.list(func(m *domain.Match) bool { return m.TournamentID == tournamentID && m.Round == round }), nil
+}
+
+func (r *fakeMatchRepo) GetByParticipant(ctx context.Context, tournamentID, participantID uuid.UUID) ([]*domain.Match, error) {
+	return r.list(func(m *domain.Match) bool {
+		return m.TournamentID == tournamentID && (sameID(m.Participant1ID, participantID) || sameID(m.Participant2ID, participantID))
+	}), nil
+}
+
+func (r *fakeMatchRepo) Update(ctx context.Context, match *domain.Match) error {
+	if r.failUpdate != nil {
+		if err := r.failUpdate(match); err != nil {
+			return err
+		}
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored, ok := r.store.matches[match.ID]
+	if !ok {
+		return fmt.Errorf("match not found for update (or no changes made): %v", match.ID)
+	}
+	if stored.Version != match.Version {
+		return domain.ErrConcurrentModification
+	}
+	match.UpdatedAt = time.Now()
+	match.Version++
+	copied := *match
+	r.store.matches[match.ID] = &copied
+	return nil
+}
+
+func (r *fakeMatchRepo) UpdateWithOutbox(ctx context.Context, match *domain.Match, entry *domain.OutboxEntry) error {
+	if err := r.Update(ctx, match); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.outbox = append(r.store.outbox, entry)
+	return nil
+}
+
+func (r *fakeMatchRepo) ReplaceBracket(ctx context.Context, tournamentID uuid.UUID, matches []*domain.Match) error {
+	if err := r.Delete(ctx, tournamentID); err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if err := r.Create(ctx, match); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveRound applies the round like the real transaction does: if any write fails, the stored
+// matches are put back as they were
+func (r *fakeMatchRepo) SaveRound(ctx context.Context, created, updated []*domain.Match, removed []uuid.UUID) error {
+	r.store.mu.Lock()
+	before := make(map[uuid.UUID]*domain.Match, len(r.store.matches))
+	for id, match := range r.store.matches {
+		before[id] = match
+	}
+	r.store.mu.Unlock()
+
+	err := func() error {
+		for _, match := range updated {
+			if err := r.Update(ctx, match); err != nil {
+				return err
+			}
+		}
+		for _, match := range created {
+			if err := r.Create(ctx, match); err != nil {
+				return err
+			}
+		}
+		for _, id := range removed {
+			if err := r.DeleteByID(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}()
+	if err != nil {
+		r.store.mu.Lock()
+		r.store.matches = before
+		r.store.mu.Unlock()
+	}
+	return err
+}
+
+func (r *fakeMatchRepo) Delete(ctx context.Context, tournamentID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for id, match := range r.store.matches {
+		if match.TournamentID == tournamentID {
+			delete(r.store.matches, id)
+		}
+	}
+	return nil
+}
+
+func (r *fakeMatchRepo) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	delete(r.store.matches, id)
+	return nil
+}
+
+func (r *fakeMatchRepo) GetActiveByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Match, error) {
+	r.store.mu.Lock()
+	participantIDs := map[uuid.UUID]bool{}
+	for _, participant := range r.store.participants {
+		if participant.UserID != nil && *participant.UserID == userID {
+			participantIDs[participant.ID] = true
+		}
+	}
+	r.store.mu.Unlock()
+	return r.list(func(m *domain.Match) bool {
+		if m.Status != domain.MatchPending && m.Status != domain.MatchInProgress {
+			return false
+		}
+		return (m.Participant1ID != nil && participantIDs[*m.Participant1ID]) ||
+			(m.Participant2ID != nil && participantIDs[*m.Participant2ID])
+	}), nil
+}
+
+func (r *fakeMatchRepo) list(keep func(*domain.Match) bool) []*domain.Match {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	matches := []*domain.Match{}
+	for _, match := range r.store.matches {
+		if keep(match) {
+			copied := *match
+			matches = append(matches, &copied)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Round != matches[j].Round {
+			return matches[i].Round < matches[j].Round
+		}
+		return matches[i].MatchNumber < matches[j].MatchNumber
+	})
+	return matches
+}
+
+func sameID(id *uuid.UUID, other uuid.UUID) bool {
+	return id != nil && *id == other
+}
+
+// fakeMessageRepo implements repository.MessageRepository over a memStore
+type fakeMessageRepo struct{ s *memStore }
+
+var _ repository.MessageRepository = (*fakeMessageRepo)(nil)
+
+func (r *fakeMessageRepo) Create(ctx context.Context, message *domain.Message) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if message.ID == uuid.Nil {
+		message.ID = uuid.New()
+	}
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
+	copied := *message
+	r.s.messages[message.ID] = &copied
+	return nil
+}
+
+func (r *fakeMessageRepo) ListByTournament(ctx context.Context, tournamentID uuid.UUID, limit, offset int) ([]*domain.Message, error) {
+	return r.list(func(m *domain.Message) bool { return m.TournamentID == tournamentID && m.MatchID == nil }, limit, offset), nil
+}
+
+func (r *fakeMessageRepo) ListByMatch(ctx context.Context, matchID uuid.UUID, limit, offset int) ([]*domain.Message, error) {
+	return r.list(func(m *domain.Message) bool { return sameID(m.MatchID, matchID) }, limit, offset), nil
+}
+
+func (r *fakeMessageRepo) list(keep func(*domain.Message) bool, limit, offset int) []*domain.Message {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	messages := []*domain.Message{}
+	for _, message := range r.s.messages {
+		if message.DeletedAt == nil && keep(message) {
+			copied := *message
+			messages = append(messages, &copied)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		if messages[i].IsPinned != messages[j].IsPinned {
+			return messages[i].IsPinned
+		}
+		return messages[i].CreatedAt.After(messages[j].CreatedAt)
+	})
+	return paginate(messages, offset, limit)
+}
+
+func (r *fakeMessageRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Message, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	message, ok := r.s.messages[id]
+	if !ok {
+		return nil, fmt.Errorf("message not found: %v", id)
+	}
+	copied := *message
+	return &copied, nil
+}
+
+func (r *fakeMessageRepo) Update(ctx context.Context, message *domain.Message) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored, ok := r.s.messages[message.ID]
+	if !ok || stored.DeletedAt != nil {
+		return fmt.Errorf("message not found: %v", message.ID)
+	}
+	now := time.Now()
+	stored.Message = message.Message
+	stored.EditedAt = &now
+	message.EditedAt = &now
+	return nil
+}
+
+func (r *fakeMessageRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored, ok := r.s.messages[id]
+	if !ok || stored.DeletedAt != nil {
+		return fmt.Errorf("message not found: %v", id)
+	}
+	now := time.Now()
+	stored.DeletedAt = &now
+	return nil
+}
+
+func (r *fakeMessageRepo) SetPinned(ctx context.Context, id uuid.UUID, pinned bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored, ok := r.s.messages[id]
+	if !ok || stored.DeletedAt != nil {
+		return fmt.Errorf("message not found: %v", id)
+	}
+	stored.IsPinned = pinned
+	return nil
+}
+
+func (r *fakeMessageRepo) AddReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if r.s.reactions[messageID] == nil {
+		r.s.reactions[messageID] = map[string]map[uuid.UUID]bool{}
+	}
+	if r.s.reactions[messageID][emoji] == nil {
+		r.s.reactions[messageID][emoji] = map[uuid.UUID]bool{}
+	}
+	if r.s.reactions[messageID][emoji][userID] {
+		return repository.ErrReactionExists
+	}
+	r.s.reactions[messageID][emoji][userID] = true
+	return nil
+}
+
+func (r *fakeMessageRepo) RemoveReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if !r.s.reactions[messageID][emoji][userID] {
+		return repository.ErrReactionNotFound
+	}
+	delete(r.s.reactions[messageID][emoji], userID)
+	return nil
+}
+
+func (r *fakeMessageRepo) ReactionCounts(
+	ctx context.Context, messageIDs []uuid.UUID,
+) (map[uuid.UUID][]domain.ReactionCount, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	counts := map[uuid.UUID][]domain.ReactionCount{}
+	for _, id := range messageIDs {
+		for emoji, users := range r.s.reactions[id] {
+			if len(users) > 0 {
+				counts[id] = append(counts[id], domain.ReactionCount{Emoji: emoji, Count: len(users)})
+			}
+		}
+		sort.Slice(counts[id], func(i, j int) bool { return counts[id][i].Count > counts[id][j].Count })
+	}
+	return counts, nil
+}
+
+func (r *fakeMessageRepo) MarkRead(
+	ctx context.Context, tournamentID, userID uuid.UUID, readAt time.Time,
+) (*domain.ChatReadState, error) {
+	r.s.mu.Lock()
+	r.s.readStates[[2]uuid.UUID{tournamentID, userID}] = readAt
+	r.s.mu.Unlock()
+	return &domain.ChatReadState{TournamentID: tournamentID, LastReadAt: readAt}, nil
+}
+
+func (r *fakeMessageRepo) CountUnread(ctx context.Context, tournamentID, userID uuid.UUID) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	readAt := r.s.readStates[[2]uuid.UUID{tournamentID, userID}]
+	count := 0
+	for _, message := range r.s.messages {
+		if message.TournamentID == tournamentID && message.MatchID == nil && message.DeletedAt == nil &&
+			message.UserID != userID && message.CreatedAt.After(readAt) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// fakeActivities records the activities the service reports
+type fakeActivities struct {
+	mu       sync.Mutex
+	recorded []*domain.UserActivity
+}
+
+func (f *fakeActivities) RecordActivity(
+	ctx context.Context, userID uuid.UUID, activityType domain.ActivityType, description string,
+	relatedEntityID *uuid.UUID, relatedEntityType *domain.RelatedEntityType, contextURL *string,
+) (*domain.UserActivity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	activity := &domain.UserActivity{
+		ID:                uuid.New(),
+		UserID:            userID,
+		ActivityType:      activityType,
+		Description:       description,
+		RelatedEntityID:   relatedEntityID,
+		RelatedEntityType: relatedEntityType,
+		ContextURL:        contextURL,
+		CreatedAt:         time.Now(),
+	}
+	f.recorded = append(f.recorded, activity)
+	return activity, nil
+}
+
+func (f *fakeActivities) GetUserActivities(
+	ctx context.Context, userID uuid.UUID, activityType domain.ActivityType, page, pageSize int,
+) ([]*domain.UserActivity, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var activities []*domain.UserActivity
+	for _, activity := range f.recorded {
+		if activity.UserID == userID && (activityType == "" || activity.ActivityType == activityType) {
+			activities = append(activities, activity)
+		}
+	}
+	return activities, len(activities), nil
+}
+
+// ofType returns the recorded activities of one type
+func (f *fakeActivities) ofType(activityType domain.ActivityType) []*domain.UserActivity {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var all []*domain.UserActivity
+	for _, activity := range f.recorded {
+		if activity.ActivityType == activityType {
+			all = append(all, activity)
+		}
+	}
+	return all
+}
+
+// fakeUsers answers user lookups from a fixed set of accounts
+type fakeUsers struct {
+	details map[uuid.UUID]client.UserDetails
+	err     error
+	calls   [][]uuid.UUID
+}
+
+func (f *fakeUsers) GetMultipleUserDetails(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]client.UserDetails, error) {
+	f.calls = append(f.calls, userIDs)
+	if f.err != nil {
+		return nil, f.err
+	}
+	found := map[uuid.UUID]client.UserDetails{}
+	for _, id := range userIDs {
+		if details, ok := f.details[id]; ok {
+			found[id] = details
+		}
+	}
+	return found, nil
+}
+
+// fakeRankings answers ranking lookups with fixed points per user
+type fakeRankings struct {
+	points map[uuid.UUID]int
+	err    error
+}
+
+func (f *fakeRankings) GetUserRanking(ctx context.Context, userID uuid.UUID, gameID string) (*client.UserRanking, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &client.UserRanking{UserID: userID, GameID: gameID, Points: f.points[userID]}, nil
+}
+
+// fakeOutbox is an in-memory RankingOutboxRepository
+type fakeOutbox struct {
+	mu      sync.Mutex
+	entries []*domain.OutboxEntry
+}
+
+// queue stores an undelivered entry for matchID that is due now
+func (o *fakeOutbox) queue(matchID uuid.UUID, payload string) *domain.OutboxEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	entry := &domain.OutboxEntry{
+		ID: uuid.New(), MatchID: matchID, Payload: []byte(payload),
+		NextAttemptAt: time.Now().Add(-time.Second), CreatedAt: time.Now(),
+	}
+	o.entries = append(o.entries, entry)
+	return entry
+}
+
+// get returns a copy of the stored entry
+func (o *fakeOutbox) get(id uuid.UUID) *domain.OutboxEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, entry := range o.entries {
+		if entry.ID == id {
+			copied := *entry
+			return &copied
+		}
+	}
+	return nil
+}
+
+func (o *fakeOutbox) list(limit int, include func(*domain.OutboxEntry) bool) []*domain.OutboxEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var entries []*domain.OutboxEntry
+	for _, entry := range o.entries {
+		if entry.SentAt == nil && include(entry) && len(entries) < limit {
+			copied := *entry
+			entries = append(entries, &copied)
+		}
+	}
+	return entries
+}
+
+func (o *fakeOutbox) ListDue(ctx context.Context, limit int) ([]*domain.OutboxEntry, error) {
+	now := time.Now()
+	return o.list(limit, func(e *domain.OutboxEntry) bool { return !e.NextAttemptAt.After(now) }), nil
+}
+
+func (o *fakeOutbox) ListUnsent(ctx context.Context, limit int) ([]*domain.OutboxEntry, error) {
+	return o.list(limit, func(*domain.OutboxEntry) bool { return true }), nil
+}
+
+func (o *fakeOutbox) CountPending(ctx context.Context) (int, error) {
+	return len(o.list(len(o.entries), func(*domain.OutboxEntry) bool { return true })), nil
+}
+
+func (o *fakeOutbox) GetByID(ctx context.Context, id uuid.UUID) (*domain.OutboxEntry, error) {
+	return o.get(id), nil
+}
+
+func (o *fakeOutbox) update(id uuid.UUID, fn func(*domain.OutboxEntry)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, entry := range o.entries {
+		if entry.ID == id {
+			fn(entry)
+		}
+	}
+}
+
+func (o *fakeOutbox) MarkSent(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	o.update(id, func(e *domain.OutboxEntry) {
+		e.SentAt, e.Attempts, e.LastError = &now, e.Attempts+1, ""
+	})
+	return nil
+}
+
+func (o *fakeOutbox) MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	o.update(id, func(e *domain.OutboxEntry) {
+		e.Attempts, e.NextAttemptAt, e.LastError = e.Attempts+1, nextAttemptAt, lastError
+	})
+	return nil
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// defaultWalkoverScore is the score credited to the winner of a forfeited match unless the
+// tournament sets custom_fields.walkover_score; the forfeiting side always gets 0
+const defaultWalkoverScore = 1
+
+// ErrNotInMatch is returned when the forfeiting participant is not playing in the match
+var ErrNotInMatch = domain.NewError(domain.ErrValidation, "participant is not playing in this match")
+
+// ErrMatchNotPlayable is returned when forfeiting a match that is finished or still waiting for an opponent
+var ErrMatchNotPlayable = domain.NewError(domain.ErrConflict, "only a pending or in-progress match with both participants can be forfeited")
+
+// walkoverScore reads the score a forfeit awards the winner from custom_fields.walkover_score,
+// e.g. {"walkover_score": 2} for a best-of-three
+func walkoverScore(tournament *domain.Tournament) int {
+	if len(tournament.CustomFields) == 0 {
+		return defaultWalkoverScore
+	}
+	var fields struct {
+		WalkoverScore *int `json:"walkover_score"`
+	}
+	if err := json.Unmarshal(tournament.CustomFields, &fields); err != nil {
+		log.Printf("Warning: could not read walkover score of tournament %s: %v", tournament.ID, err)
+		return defaultWalkoverScore
+	}
+	if fields.WalkoverScore == nil || *fields.WalkoverScore <= 0 {
+		return defaultWalkoverScore
+	}
+	return *fields.WalkoverScore
+}
+
+// ForfeitMatch completes a match that one or both participants did not play. A single forfeit
+// awards the match to the opponent with the walkover score and reports it to the Ranking Service
+// like a played result; a double forfeit cancels the match so that neither participant advances.
+func (s *tournamentService) ForfeitMatch(
+	ctx context.Context, tournamentID, matchID, userID uuid.UUID, request *domain.ForfeitRequest,
+) (*domain.MatchResponse, error) {
+	tournament, err := s.getManagedTournament(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get match %s: %w", matchID, err)
+	}
+	if match.TournamentID != tournamentID {
+		return nil, ErrMatchNotFound
+	}
+	if match.Status != domain.MatchPending && match.Status != domain.MatchInProgress {
+		return nil, ErrMatchNotPlayable
+	}
+	if match.Participant1ID == nil || match.Participant2ID == nil {
+		return nil, ErrMatchNotPlayable
+	}
+
+	if request.DoubleForfeit {
+		if err := s.doubleForfeitMatch(ctx, match, request.Reason); err != nil {
+			return nil, err
+		}
+	} else {
+		if request.ForfeitingParticipantID == nil {
+			return nil, domain.NewError(domain.ErrValidation, "forfeiting_participant_id is required unless double_forfeit is set")
+		}
+		if err := s.singleForfeitMatch(ctx, tournament, match, *request.ForfeitingParticipantID, request.Reason); err != nil {
+			return nil, err
+		}
+	}
+
+	completed, err := s.checkTournamentCompletion(ctx, tournamentID)
+	if err != nil {
+		logging.Warnf(ctx, "Warning (TID: %s): Failed to check tournament completion after forfeit of match %s: %v", tournamentID, matchID, err)
+	} else if completed {
+		if err := s.updateTournamentStatus(ctx, tournamentID, domain.Completed); err != nil {
+			logging.Warnf(ctx, "Warning (TID: %s): Failed to update tournament status to COMPLETED: %v", tournamentID, err)
+		}
+	}
+
+	if s.broadcastChan != nil {
+		s.broadcastChan <- domain.WebSocketMessage{
+			Type: domain.WSEventMatchScoreUpdated,
+			Payload: domain.MatchScoreUpdatedPayload{
+				TournamentID:      tournamentID,
+				MatchID:           match.ID,
+				Participant1ID:    match.Participant1ID,
+				Participant2ID:    match.Participant2ID,
+				ScoreParticipant1: match.ScoreParticipant1,
+				ScoreParticipant2: match.ScoreParticipant2,
+				WinnerID:          match.WinnerID,
+				Status:            match.Status,
+			},
+		}
+		logging.Infof(ctx, "Broadcasted WSEventMatchScoreUpdated for forfeit of M-%s", match.ID)
+	}
+
+	return toMatchResponse(match), nil
+}
+
+// singleForfeitMatch awards the match to the opponent of the forfeiting participant
+func (s *tournamentService) singleForfeitMatch(
+	ctx context.Context, tournament *domain.Tournament, match *domain.Match, forfeitingID uuid.UUID, reason string,
+) error {
+	var winnerID uuid.UUID
+	score := walkoverScore(tournament)
+	switch forfeitingID {
+	case *match.Participant1ID:
+		winnerID = *match.Participant2ID
+		match.ScoreParticipant1, match.ScoreParticipant2 = 0, score
+	case *match.Participant2ID:
+		winnerID = *match.Participant1ID
+		match.ScoreParticipant1, match.ScoreParticipant2 = score, 0
+	default:
+		return ErrNotInMatch
+	}
+	match.Games = nil
+
+	p1, err := s.participantRepo.GetByID(ctx, *match.Participant1ID)
+	if err != nil || p1 == nil {
+		return fmt.Errorf("failed to get participant %s: %w", *match.Participant1ID, err)
+	}
+	p2, err := s.participantRepo.GetByID(ctx, *match.Participant2ID)
+	if err != nil || p2 == nil {
+		return fmt.Errorf("failed to get participant %s: %w", *match.Participant2ID, err)
+	}
+	p1Outcome, p2Outcome := RS_Win, RS_Loss
+	if winnerID == p2.ID {
+		p1Outcome, p2Outcome = RS_Loss, RS_Win
+	}
+	outboxEntry, err := rankingOutboxEntry(tournament, match, p1, p2, p1Outcome, p2Outcome, RS_Result, time.Now())
+	if err != nil {
+		return err
+	}
+
+	note := "Forfeit"
+	if reason != "" {
+		note = "Forfeit: " + reason
+	}
+	if err := s.awardMatch(ctx, match, winnerID, forfeitingID, note, outboxEntry); err != nil {
+		return err
+	}
+
+	// A forfeited grand finals still decides whether the bracket reset is played
+	if tournament.Format == domain.DoubleElimination && match.BracketType == domain.GrandFinals {
+		winnersFinalist, err := s.grandFinalsWinnersFinalist(ctx, match)
+		if err != nil {
+			return fmt.Errorf("failed to resolve grand finals participants: %w", err)
+		}
+		if winnersFinalist != nil {
+			if _, err := s.resolveBracketReset(ctx, tournament, match, *winnersFinalist); err != nil {
+				logging.Warnf(ctx, "ForfeitMatch - Failed to resolve bracket reset after grand finals %s: %v", match.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// doubleForfeitMatch cancels a match neither participant played. Both are eliminated, and the
+// matches they would have fed are resolved as walkovers once their other side is known.
+func (s *tournamentService) doubleForfeitMatch(ctx context.Context, match *domain.Match, reason string) error {
+	note := "Double forfeit"
+	if reason != "" {
+		note = "Double forfeit: " + reason
+	}
+	now := time.Now()
+	match.Status = domain.MatchCancelled
+	match.WinnerID = nil
+	match.LoserID = nil
+	match.CompletedTime = &now
+	match.MatchNotes = note
+	if err := s.matchRepo.Update(ctx, match); err != nil {
+		return fmt.Errorf("failed to apply double forfeit to match %s: %w", match.ID, err)
+	}
+	logging.Infof(ctx, "Double forfeit in match %s (%s)", match.ID, note)
+
+	for _, nextMatchID := range []*uuid.UUID{match.NextMatchID, match.LoserNextMatchID} {
+		if nextMatchID == nil {
+			continue
+		}
+		if err := s.resolveWalkover(ctx, *nextMatchID); err != nil {
+			logging.Warnf(ctx, "ForfeitMatch - failed to resolve walkover in match %s: %v", *nextMatchID, err)
+		}
+	}
+	return nil
+}
+
+// resolveWalkoverAfterAdvance resolves a match that just received a participant but still has
+// an empty slot, in case that slot's feeder match was double-forfeited
+func (s *tournamentService) resolveWalkoverAfterAdvance(ctx context.Context, match *domain.Match) {
+	if match.Participant1ID != nil && match.Participant2ID != nil {
+		return
+	}
+	if err := s.resolveWalkover(ctx, match.ID); err != nil {
+		logging.Warnf(ctx, "failed to resolve walkover in match %s: %v", match.ID, err)
+	}
+}
+
+// resolveWalkover settles a match whose feeder matches are all finished but which is still
+// missing participants because a feeder was double-forfeited. A lone participant advances by
+// walkover; a match left with nobody is cancelled and the matches it feeds are resolved in turn.
+func (s *tournamentService) resolveWalkover(ctx context.Context, matchID uuid.UUID) error {
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match %s: %w", matchID, err)
+	}
+	if match.Status == domain.MatchCompleted || match.Status == domain.MatchCancelled {
+		return nil
+	}
+	if match.Participant1ID != nil && match.Participant2ID != nil {
+		return nil
+	}
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, match.TournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to get matches: %w", err)
+	}
+	for _, feeder := range matches {
+		feeds := (feeder.NextMatchID != nil && *feeder.NextMatchID == matchID) ||
+			(feeder.LoserNextMatchID != nil && *feeder.LoserNextMatchID == matchID)
+		if feeds && feeder.Status != domain.MatchCompleted && feeder.Status != domain.MatchCancelled {
+			return nil // Still waiting on a result
+		}
+	}
+
+	now := time.Now()
+	if match.Participant1ID == nil && match.Participant2ID == nil {
+		match.Status = domain.MatchCancelled
+		match.CompletedTime = &now
+		match.MatchNotes = "Cancelled: both feeder matches were forfeited"
+		if err := s.matchRepo.Update(ctx, match); err != nil {
+			return fmt.Errorf("failed to cancel match %s: %w", matchID, err)
+		}
+		logging.Infof(ctx, "Cancelled match %s: no participants left after forfeits", matchID)
+		for _, nextMatchID := range []*uuid.UUID{match.NextMatchID, match.LoserNextMatchID} {
+			if nextMatchID == nil {
+				continue
+			}
+			if err := s.resolveWalkover(ctx, *nextMatchID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	winnerID := match.Participant1ID
+	if winnerID == nil {
+		winnerID = match.Participant2ID
+	}
+	match.Status = domain.MatchCompleted
+	match.WinnerID = winnerID
+	match.CompletedTime = &now
+	match.MatchNotes = "Walkover - opponent forfeited an earlier match"
+	if err := s.matchRepo.Update(ctx, match); err != nil {
+		return fmt.Errorf("failed to complete walkover match %s: %w", matchID, err)
+	}
+	logging.Infof(ctx, "Resolved walkover in match %s: P-%s advances", matchID, *winnerID)
+
+	if match.NextMatchID == nil {
+		return nil
+	}
+	nextMatch, err := s.matchRepo.GetByID(ctx, *match.NextMatchID)
+	if err != nil {
+		return fmt.Errorf("failed to get next match %s for walkover: %w", *match.NextMatchID, err)
+	}
+	if nextMatch.Participant1ID == nil {
+		nextMatch.Participant1ID = winnerID
+	} else if nextMatch.Participant2ID == nil {
+		nextMatch.Participant2ID = winnerID
+	} else {
+		logging.Warnf(ctx, "resolveWalkover - next match %s already has both participants assigned.", nextMatch.ID)
+		return nil
+	}
+	if err := s.matchRepo.Update(ctx, nextMatch); err != nil {
+		return fmt.Errorf("failed to advance walkover winner into match %s: %w", nextMatch.ID, err)
+	}
+	s.resolveWalkoverAfterAdvance(ctx, nextMatch)
+	return nil
+}
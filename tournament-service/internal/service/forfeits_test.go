@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (f *correctionFixture) forfeit(match *domain.Match, request *domain.ForfeitRequest) (*domain.MatchResponse, error) {
+	return f.env.service.ForfeitMatch(context.Background(), f.tournament.ID, match.ID, f.organizer, request)
+}
+
+func TestForfeitAwardsTheMatchToTheOpponent(t *testing.T) {
+	f := newCorrectionFixture(t)
+	f.env.store.tournaments[f.tournament.ID].CustomFields = json.RawMessage(`{"walkover_score": 2}`)
+	semi := f.semis[0]
+
+	response, err := f.forfeit(semi, &domain.ForfeitRequest{ForfeitingParticipantID: semi.Participant1ID, Reason: "no-show"})
+	if err != nil {
+		t.Fatalf("ForfeitMatch: %v", err)
+	}
+	if response.Status != domain.MatchCompleted || !sameID(response.WinnerID, *semi.Participant2ID) {
+		t.Fatalf("expected participant 2 to win by forfeit: %+v", response)
+	}
+	if response.ScoreParticipant1 != 0 || response.ScoreParticipant2 != 2 || response.MatchNotes != "Forfeit: no-show" {
+		t.Fatalf("expected a 0-2 walkover noted as a forfeit: %+v", response)
+	}
+	final := f.env.match(t, f.final.ID)
+	if !sameID(final.Participant1ID, *semi.Participant2ID) && !sameID(final.Participant2ID, *semi.Participant2ID) {
+		t.Fatal("the walkover winner did not advance")
+	}
+
+	// A forfeit counts as a result for the rankings
+	events := f.rankingEvents(t)
+	if len(events) != 1 || events[0].Type != RS_Result {
+		t.Fatalf("expected one ranking result, got %+v", events)
+	}
+}
+
+func TestDoubleForfeitCancelsAndTheOtherSideWalksOver(t *testing.T) {
+	f := newCorrectionFixture(t)
+
+	response, err := f.forfeit(f.semis[0], &domain.ForfeitRequest{DoubleForfeit: true})
+	if err != nil {
+		t.Fatalf("ForfeitMatch: %v", err)
+	}
+	if response.Status != domain.MatchCancelled || response.WinnerID != nil {
+		t.Fatalf("a double forfeit should cancel the match with no winner: %+v", response)
+	}
+	if len(f.env.store.outbox) != 0 {
+		t.Fatal("a double forfeit has no result to rank")
+	}
+
+	if err := f.report(f.semis[1], 2, 1); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	final := f.env.match(t, f.final.ID)
+	if final.Status != domain.MatchCompleted || !sameID(final.WinnerID, *f.semis[1].Participant1ID) {
+		t.Fatalf("the other semi-final winner should take the final by walkover: %+v", final)
+	}
+}
+
+func TestForfeitRefusals(t *testing.T) {
+	f := newCorrectionFixture(t)
+	semi, other := f.semis[0], f.semis[1]
+
+	if _, err := f.forfeit(semi, &domain.ForfeitRequest{ForfeitingParticipantID: other.Participant1ID}); !errors.Is(err, ErrNotInMatch) {
+		t.Errorf("expected ErrNotInMatch, got %v", err)
+	}
+	if _, err := f.forfeit(semi, &domain.ForfeitRequest{}); err == nil {
+		t.Error("a single forfeit needs the forfeiting participant")
+	}
+	if _, err := f.forfeit(f.final, &domain.ForfeitRequest{DoubleForfeit: true}); !errors.Is(err, ErrMatchNotPlayable) {
+		t.Errorf("a match still waiting for its players cannot be forfeited, got %v", err)
+	}
+	if err := f.report(other, 2, 0); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if _, err := f.forfeit(other, &domain.ForfeitRequest{DoubleForfeit: true}); !errors.Is(err, ErrMatchNotPlayable) {
+		t.Errorf("a completed match cannot be forfeited, got %v", err)
+	}
+
+	var notAuthorized *ErrNotAuthorized
+	_, err := f.env.service.ForfeitMatch(context.Background(), f.tournament.ID, semi.ID, uuid.New(),
+		&domain.ForfeitRequest{DoubleForfeit: true})
+	if !errors.As(err, &notAuthorized) {
+		t.Errorf("only organizers may record forfeits, got %v", err)
+	}
+
+	_, err = f.env.service.ForfeitMatch(context.Background(), f.tournament.ID, uuid.New(), f.organizer,
+		&domain.ForfeitRequest{DoubleForfeit: true})
+	if !errors.Is(err, ErrMatchNotFound) {
+		t.Errorf("unknown match: expected ErrMatchNotFound, got %v", err)
+	}
+}
+
+func TestWalkoverScoreDefaults(t *testing.T) {
+	for fields, want := range map[string]int{
+		``:                       defaultWalkoverScore,
+		`{"walkover_score": 3}`:  3,
+		`{"walkover_score": 0}`:  defaultWalkoverScore,
+		`{"walkover_score": -2}`: defaultWalkoverScore,
+		`not json`:               defaultWalkoverScore,
+	} {
+		tournament := &domain.Tournament{ID: uuid.New()}
+		if fields != "" {
+			tournament.CustomFields = json.RawMessage(fields)
+		}
+		if got := walkoverScore(tournament); got != want {
+			t.Errorf("%q: want %d, got %d", fields, want, got)
+		}
+	}
+}
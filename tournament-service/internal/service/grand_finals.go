@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// Double elimination grand finals.
+//
+// By default (GrandFinalsAdvantage == 0) the losers-bracket finalist has to beat the
+// winners-bracket finalist twice: if they win the grand finals, the bracket reset match
+// is played; otherwise it is cancelled.
+//
+// With an advantage of N, the winners-bracket finalist starts the grand finals N games up
+// and the grand finals are decisive, so the reset match is always cancelled. Reported grand
+// finals scores are totals and must include the advantage.
+
+// ErrBracketResetNotNeeded is returned when reporting a score for a bracket reset that was
+// cancelled because the winners finalist won the grand finals
+var ErrBracketResetNotNeeded = domain.NewError(domain.ErrConflict, "bracket reset was not needed and cannot be played")
+
+// ErrBracketResetPlayed is returned when correcting the grand finals after the bracket reset has been played
+var ErrBracketResetPlayed = domain.NewError(domain.ErrConflict, "grand finals cannot be changed after the bracket reset has been played")
+
+// bracketReset returns the reset match that follows the given grand finals, or nil if there is none
+func bracketReset(matches []*domain.Match, grandFinals *domain.Match) *domain.Match {
+	for _, m := range matches {
+		if m.BracketType == domain.GrandFinals && m.Round > grandFinals.Round {
+			return m
+		}
+	}
+	return nil
+}
+
+// validateGrandFinalsUpdate rejects scores for a cancelled bracket reset and grand finals
+// corrections that would leave an already played bracket reset without a valid outcome.
+func (s *tournamentService) validateGrandFinalsUpdate(ctx context.Context, match *domain.Match) error {
+	if match.BracketType != domain.GrandFinals {
+		return nil
+	}
+	matches, err := s.matchRepo.GetByTournamentID(ctx, match.TournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to get matches: %w", err)
+	}
+	reset := bracketReset(matches, match)
+	if reset == nil {
+		// This is the reset itself
+		if match.Status == domain.MatchCancelled {
+			return ErrBracketResetNotNeeded
+		}
+		return nil
+	}
+	if match.Status == domain.MatchCompleted && reset.Status == domain.MatchCompleted {
+		return ErrBracketResetPlayed
+	}
+	return nil
+}
+
+// grandFinalsWinnersFinalist returns the participant who reached the given match as winner of
+// the winners bracket, or nil if the match is not fed by the winners bracket final.
+func (s *tournamentService) grandFinalsWinnersFinalist(ctx context.Context, match *domain.Match) (*uuid.UUID, error) {
+	if match.BracketType != domain.GrandFinals {
+		return nil, nil
+	}
+	matches, err := s.matchRepo.GetByTournamentID(ctx, match.TournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+	for _, m := range matches {
+		if m.BracketType == domain.WinnersBracket && m.NextMatchID != nil && *m.NextMatchID == match.ID {
+			return m.WinnerID, nil
+		}
+	}
+	return nil, nil
+}
+
+// validateGrandFinalsScore rejects grand finals scores that ignore the winners finalist's starting advantage
+func validateGrandFinalsScore(match *domain.Match, winnersFinalist *uuid.UUID, advantage int) error {
+	if winnersFinalist == nil || advantage <= 0 {
+		return nil
+	}
+	score := match.ScoreParticipant1
+	if match.Participant2ID != nil && *match.Participant2ID == *winnersFinalist {
+		score = match.ScoreParticipant2
+	}
+	if score < advantage {
+		return domain.NewError(domain.ErrValidation, fmt.Sprintf(
+			"grand finals score for the winners finalist (%d) cannot be below their %d game advantage", score, advantage))
+	}
+	return nil
+}
+
+// applyGrandFinalsAdvantage records the starting advantage when the winners finalist is placed in the grand finals
+func applyGrandFinalsAdvantage(grandFinals *domain.Match, winnersFinalist uuid.UUID, advantage int) {
+	if advantage <= 0 {
+		return
+	}
+	if grandFinals.Participant1ID != nil && *grandFinals.Participant1ID == winnersFinalist {
+		grandFinals.ScoreParticipant1 = advantage
+	} else if grandFinals.Participant2ID != nil && *grandFinals.Participant2ID == winnersFinalist {
+		grandFinals.ScoreParticipant2 = advantage
+	}
+}
+
+// resolveBracketReset fills or cancels the reset match once the grand finals are decided.
+// It returns the reset match it changed, or nil if the bracket has none.
+func (s *tournamentService) resolveBracketReset(
+	ctx context.Context, tournament *domain.Tournament, grandFinals *domain.Match, winnersFinalist uuid.UUID,
+) (*domain.Match, error) {
+	matches, err := s.matchRepo.GetByTournamentID(ctx, grandFinals.TournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+	reset := bracketReset(matches, grandFinals)
+	if reset == nil {
+		return nil, nil
+	}
+
+	if tournament.GrandFinalsAdvantage > 0 || (grandFinals.WinnerID != nil && *grandFinals.WinnerID == winnersFinalist) {
+		// A correction may cancel a reset that was already waiting to be played
+		reset.Status = domain.MatchCancelled
+		reset.Participant1ID, reset.Participant2ID = nil, nil
+		logging.Infof(ctx, "Bracket reset %s not needed for tournament %s; cancelling", reset.ID, tournament.ID)
+	} else {
+		reset.Participant1ID = &winnersFinalist
+		reset.Participant2ID = grandFinals.WinnerID
+		// A correction may revive a reset that was cancelled when the grand finals were first reported
+		reset.Status = domain.MatchPending
+		logging.Infof(ctx, "Losers finalist won grand finals %s; bracket reset %s will be played", grandFinals.ID, reset.ID)
+	}
+	if err := s.matchRepo.Update(ctx, reset); err != nil {
+		return nil, err
+	}
+	return reset, nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// grandFinalsFixture is the end of a double elimination bracket: the winners final feeds the
+// grand finals, whose losers-bracket slot is already filled, followed by the bracket reset
+type grandFinalsFixture struct {
+	env                              *testEnv
+	tournament                       *domain.Tournament
+	organizer                        uuid.UUID
+	winnersFinalist, losersFinalist  *domain.Participant
+	winnersFinal, grandFinals, reset *domain.Match
+}
+
+func newGrandFinalsFixture(t *testing.T, advantage int) *grandFinalsFixture {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) {
+		t.Format = domain.DoubleElimination
+		t.Status = domain.InProgress
+		t.GrandFinalsAdvantage = advantage
+	})
+	players := env.players(tournament.ID, 3)
+	f := &grandFinalsFixture{
+		env: env, tournament: tournament, organizer: organizer,
+		winnersFinalist: players[0], losersFinalist: players[2],
+	}
+	f.grandFinals = &domain.Match{
+		ID: uuid.New(), TournamentID: tournament.ID, Round: 4, MatchNumber: 1, BracketType: domain.GrandFinals,
+		Participant1ID: &players[2].ID, Status: domain.MatchPending,
+	}
+	f.reset = &domain.Match{
+		ID: uuid.New(), TournamentID: tournament.ID, Round: 5, MatchNumber: 1, BracketType: domain.GrandFinals,
+		Status: domain.MatchPending,
+	}
+	f.winnersFinal = &domain.Match{
+		ID: uuid.New(), TournamentID: tournament.ID, Round: 3, MatchNumber: 1, BracketType: domain.WinnersBracket,
+		Participant1ID: &players[0].ID, Participant2ID: &players[1].ID, NextMatchID: &f.grandFinals.ID,
+		Status: domain.MatchPending,
+	}
+	for _, m := range []*domain.Match{f.winnersFinal, f.grandFinals, f.reset} {
+		env.store.putMatch(m)
+	}
+	return f
+}
+
+// report records a score as the organizer
+func (f *grandFinalsFixture) report(matchID uuid.UUID, score1, score2 int) error {
+	_, err := f.env.service.UpdateMatchScore(context.Background(), f.tournament.ID, matchID, f.organizer,
+		&domain.ScoreUpdateRequest{ScoreParticipant1: score1, ScoreParticipant2: score2})
+	return err
+}
+
+func TestWinnersFinalistStartsGrandFinalsWithAdvantage(t *testing.T) {
+	f := newGrandFinalsFixture(t, 1)
+
+	if err := f.report(f.winnersFinal.ID, 2, 0); err != nil {
+		t.Fatalf("winners final: %v", err)
+	}
+
+	grandFinals := f.env.match(t, f.grandFinals.ID)
+	if grandFinals.Participant2ID == nil || *grandFinals.Participant2ID != f.winnersFinalist.ID {
+		t.Fatal("the winners finalist was not placed in the grand finals")
+	}
+	if grandFinals.ScoreParticipant2 != 1 || grandFinals.ScoreParticipant1 != 0 {
+		t.Fatalf("expected the grand finals to start 0-1, got %d-%d", grandFinals.ScoreParticipant1, grandFinals.ScoreParticipant2)
+	}
+}
+
+func TestGrandFinalsScoreMustIncludeAdvantage(t *testing.T) {
+	f := newGrandFinalsFixture(t, 1)
+	if err := f.report(f.winnersFinal.ID, 2, 0); err != nil {
+		t.Fatalf("winners final: %v", err)
+	}
+
+	// The losers finalist (participant 1) wins 2-0, which would erase the winners finalist's game
+	if err := f.report(f.grandFinals.ID, 2, 0); err == nil {
+		t.Fatal("a grand finals score below the advantage should be rejected")
+	}
+	if got := f.env.match(t, f.grandFinals.ID); got.Status == domain.MatchCompleted {
+		t.Fatal("the rejected score was stored")
+	}
+}
+
+func TestGrandFinalsAdvantageCancelsBracketReset(t *testing.T) {
+	f := newGrandFinalsFixture(t, 1)
+	if err := f.report(f.winnersFinal.ID, 2, 0); err != nil {
+		t.Fatalf("winners final: %v", err)
+	}
+
+	// Even when the losers finalist wins, the advantage makes the grand finals decisive
+	if err := f.report(f.grandFinals.ID, 3, 1); err != nil {
+		t.Fatalf("grand finals: %v", err)
+	}
+	reset := f.env.match(t, f.reset.ID)
+	if reset.Status != domain.MatchCancelled || reset.Participant1ID != nil || reset.Participant2ID != nil {
+		t.Fatalf("expected an empty cancelled reset, got %+v", reset)
+	}
+}
+
+func TestBracketResetWithoutAdvantage(t *testing.T) {
+	f := newGrandFinalsFixture(t, 0)
+	if err := f.report(f.winnersFinal.ID, 2, 0); err != nil {
+		t.Fatalf("winners final: %v", err)
+	}
+	if got := f.env.match(t, f.grandFinals.ID); got.ScoreParticipant1 != 0 || got.ScoreParticipant2 != 0 {
+		t.Fatalf("no advantage should leave the grand finals at 0-0, got %d-%d", got.ScoreParticipant1, got.ScoreParticipant2)
+	}
+
+	// The losers finalist wins, so the reset is played between both finalists
+	if err := f.report(f.grandFinals.ID, 2, 1); err != nil {
+		t.Fatalf("grand finals: %v", err)
+	}
+	reset := f.env.match(t, f.reset.ID)
+	if reset.Status != domain.MatchPending || reset.Participant1ID == nil || reset.Participant2ID == nil ||
+		*reset.Participant1ID != f.winnersFinalist.ID || *reset.Participant2ID != f.losersFinalist.ID {
+		t.Fatalf("expected a pending reset between both finalists, got %+v", reset)
+	}
+
+	// Correcting the grand finals in the winners finalist's favour cancels it again
+	if err := f.report(f.grandFinals.ID, 1, 2); err != nil {
+		t.Fatalf("grand finals correction: %v", err)
+	}
+	if reset := f.env.match(t, f.reset.ID); reset.Status != domain.MatchCancelled {
+		t.Fatalf("expected the reset to be cancelled after the correction, got %s", reset.Status)
+	}
+}
+
+func TestValidateGrandFinalsScore(t *testing.T) {
+	winner, other := uuid.New(), uuid.New()
+	match := &domain.Match{Participant1ID: &other, Participant2ID: &winner, ScoreParticipant1: 3, ScoreParticipant2: 1}
+
+	if err := validateGrandFinalsScore(match, &winner, 2); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected a score below the advantage to be rejected as invalid, got %v", err)
+	}
+	if err := validateGrandFinalsScore(match, &winner, 1); err != nil {
+		t.Fatalf("a score matching the advantage is valid: %v", err)
+	}
+	if err := validateGrandFinalsScore(match, &winner, 0); err != nil {
+		t.Fatalf("no advantage accepts any score: %v", err)
+	}
+	if err := validateGrandFinalsScore(match, nil, 2); err != nil {
+		t.Fatalf("a grand finals without a winners finalist is not checked: %v", err)
+	}
+}
+
+func TestCancelledBracketResetCompletesTheTournament(t *testing.T) {
+	f := newGrandFinalsFixture(t, 0)
+	if err := f.report(f.winnersFinal.ID, 2, 0); err != nil {
+		t.Fatalf("winners final: %v", err)
+	}
+	// The winners finalist takes the grand finals, so the reset is void
+	if err := f.report(f.grandFinals.ID, 1, 2); err != nil {
+		t.Fatalf("grand finals: %v", err)
+	}
+
+	if got := f.env.store.tournaments[f.tournament.ID].Status; got != domain.Completed {
+		t.Fatalf("a cancelled reset should not hold the tournament open, got %s", got)
+	}
+	if err := f.report(f.reset.ID, 2, 0); !errors.Is(err, ErrBracketResetNotNeeded) {
+		t.Fatalf("expected ErrBracketResetNotNeeded for the void reset, got %v", err)
+	}
+}
+
+func TestGrandFinalsAreLockedOnceTheResetIsPlayed(t *testing.T) {
+	f := newGrandFinalsFixture(t, 0)
+	if err := f.report(f.winnersFinal.ID, 2, 0); err != nil {
+		t.Fatalf("winners final: %v", err)
+	}
+	if err := f.report(f.grandFinals.ID, 2, 1); err != nil {
+		t.Fatalf("grand finals: %v", err)
+	}
+	if err := f.report(f.reset.ID, 2, 0); err != nil {
+		t.Fatalf("bracket reset: %v", err)
+	}
+	if got := f.env.store.tournaments[f.tournament.ID].Status; got != domain.Completed {
+		t.Fatalf("the played reset decides the tournament, got %s", got)
+	}
+
+	if err := f.report(f.grandFinals.ID, 1, 2); !errors.Is(err, ErrBracketResetPlayed) {
+		t.Fatalf("expected ErrBracketResetPlayed, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	// inviteCodeAlphabet leaves out 0/O and 1/I so codes survive being read aloud
+	inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	inviteCodeLength   = 8
+	// inviteCodeAttempts bounds retries when a generated code is already taken
+	inviteCodeAttempts = 5
+)
+
+// ErrInviteCodeNotFound is returned for codes that no tournament uses, including disabled ones
+var ErrInviteCodeNotFound = domain.NewError(domain.ErrNotFound, "invite code not found")
+
+// generateInviteCode returns a random invite code
+func generateInviteCode() (string, error) {
+	max := big.NewInt(int64(len(inviteCodeAlphabet)))
+	code := make([]byte, inviteCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate invite code: %w", err)
+		}
+		code[i] = inviteCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// normalizeInviteCode makes code lookups case-insensitive and tolerant of surrounding spaces
+func normalizeInviteCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// assignInviteCode gives the tournament a fresh invite code, replacing any previous one
+func (s *tournamentService) assignInviteCode(ctx context.Context, tournamentID uuid.UUID) (string, error) {
+	for attempt := 0; attempt < inviteCodeAttempts; attempt++ {
+		code, err := generateInviteCode()
+		if err != nil {
+			return "", err
+		}
+		err = s.tournamentRepo.SetInviteCode(ctx, tournamentID, code)
+		if errors.Is(err, repository.ErrInviteCodeTaken) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return code, nil
+	}
+	return "", fmt.Errorf("failed to find an unused invite code for tournament %s", tournamentID)
+}
+
+// GetInviteCode returns the tournament's invite code for an organizer, "" when it is disabled
+func (s *tournamentService) GetInviteCode(ctx context.Context, tournamentID, userID uuid.UUID) (string, error) {
+	if _, err := s.getManagedTournament(ctx, tournamentID, userID); err != nil {
+		return "", err
+	}
+	return s.tournamentRepo.GetInviteCode(ctx, tournamentID)
+}
+
+// RegenerateInviteCode replaces the tournament's invite code, re-enabling joining by code if
+// it was disabled. The old code stops working immediately.
+func (s *tournamentService) RegenerateInviteCode(ctx context.Context, tournamentID, userID uuid.UUID) (string, error) {
+	if _, err := s.getManagedTournament(ctx, tournamentID, userID); err != nil {
+		return "", err
+	}
+	return s.assignInviteCode(ctx, tournamentID)
+}
+
+// DisableInviteCode removes the tournament's invite code until the organizer regenerates one
+func (s *tournamentService) DisableInviteCode(ctx context.Context, tournamentID, userID uuid.UUID) error {
+	if _, err := s.getManagedTournament(ctx, tournamentID, userID); err != nil {
+		return err
+	}
+	return s.tournamentRepo.SetInviteCode(ctx, tournamentID, "")
+}
+
+// tournamentByInviteCode resolves an invite code to its tournament
+func (s *tournamentService) tournamentByInviteCode(ctx context.Context, code string) (*domain.Tournament, error) {
+	code = normalizeInviteCode(code)
+	if code == "" {
+		return nil, ErrInviteCodeNotFound
+	}
+	id, err := s.tournamentRepo.GetIDByInviteCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if id == uuid.Nil {
+		return nil, ErrInviteCodeNotFound
+	}
+	tournament, err := s.tournamentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	return tournament, nil
+}
+
+// GetTournamentByInviteCode resolves an invite code to the tournament it joins. Holding the
+// code is enough to see the tournament, private or not.
+func (s *tournamentService) GetTournamentByInviteCode(ctx context.Context, code string) (*domain.TournamentResponse, error) {
+	tournament, err := s.tournamentByInviteCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	participantCount, err := s.tournamentRepo.GetParticipantCount(ctx, tournament.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant count: %w", err)
+	}
+	return toTournamentResponse(tournament, participantCount), nil
+}
+
+// JoinByInviteCode registers userID in the tournament the code belongs to under
+// participantName. The usual registration rules apply: the tournament must still be open and
+// before its deadline, and players past the participant cap are waitlisted. Private
+// tournaments can be joined this way.
+func (s *tournamentService) JoinByInviteCode(
+	ctx context.Context, code string, userID uuid.UUID, participantName string,
+) (*domain.Participant, error) {
+	tournament, err := s.tournamentByInviteCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRegistrationOpen(tournament, userID, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.addParticipant(ctx, tournament, &domain.ParticipantRequest{
+		ParticipantName: participantName,
+		UserID:          &userID,
+	})
+}
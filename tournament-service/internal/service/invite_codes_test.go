@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+// createWithCode creates a tournament open for registration and returns it with its invite code
+func createWithCode(t *testing.T, env *testEnv, organizer uuid.UUID, configure func(*domain.CreateTournamentRequest)) (*domain.Tournament, string) {
+	t.Helper()
+	request := validCreateRequest()
+	request.InitialStatus = domain.Registration
+	if configure != nil {
+		configure(request)
+	}
+	tournament, err := env.service.CreateTournament(context.Background(), request, organizer)
+	if err != nil {
+		t.Fatalf("CreateTournament: %v", err)
+	}
+	code, err := env.service.GetInviteCode(context.Background(), tournament.ID, organizer)
+	if err != nil {
+		t.Fatalf("GetInviteCode: %v", err)
+	}
+	return tournament, code
+}
+
+func TestJoinByValidInviteCode(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament, code := createWithCode(t, env, uuid.New(), func(r *domain.CreateTournamentRequest) {
+		r.MaxParticipants = 2
+		r.Visibility = domain.VisibilityPrivate
+	})
+	if len(code) != inviteCodeLength || strings.Trim(code, inviteCodeAlphabet) != "" {
+		t.Fatalf("unexpected invite code %q", code)
+	}
+
+	// Codes are matched ignoring case and surrounding spaces, and open private tournaments
+	resolved, err := env.service.GetTournamentByInviteCode(ctx, " "+strings.ToLower(code)+" ")
+	if err != nil || resolved.ID != tournament.ID {
+		t.Fatalf("expected the code to resolve to the tournament, got %v", err)
+	}
+
+	var joined []*domain.Participant
+	for i := 0; i < 3; i++ {
+		participant, err := env.service.JoinByInviteCode(ctx, code, uuid.New(), "player")
+		if err != nil {
+			t.Fatalf("join %d: %v", i, err)
+		}
+		joined = append(joined, participant)
+	}
+	if joined[0].IsWaitlisted || joined[1].IsWaitlisted || !joined[2].IsWaitlisted {
+		t.Fatal("players past the cap should be waitlisted")
+	}
+	if joined[0].TournamentID != tournament.ID || joined[0].UserID == nil {
+		t.Fatalf("the participant should be linked to the caller, got %+v", joined[0])
+	}
+
+	// Joining twice is still a conflict
+	if _, err := env.service.JoinByInviteCode(ctx, code, *joined[0].UserID, "again"); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("expected a conflict for a second join, got %v", err)
+	}
+}
+
+func TestDisabledAndRegeneratedInviteCodes(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament, code := createWithCode(t, env, organizer, nil)
+
+	if err := env.service.DisableInviteCode(ctx, tournament.ID, uuid.New()); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("only organizers may disable the code, got %v", err)
+	}
+	if err := env.service.DisableInviteCode(ctx, tournament.ID, organizer); err != nil {
+		t.Fatalf("DisableInviteCode: %v", err)
+	}
+	if _, err := env.service.JoinByInviteCode(ctx, code, uuid.New(), "player"); !errors.Is(err, ErrInviteCodeNotFound) {
+		t.Fatalf("a disabled code should not be found, got %v", err)
+	}
+	if _, err := env.service.GetTournamentByInviteCode(ctx, code); !errors.Is(err, ErrInviteCodeNotFound) {
+		t.Fatalf("a disabled code should not resolve, got %v", err)
+	}
+	if current, err := env.service.GetInviteCode(ctx, tournament.ID, organizer); err != nil || current != "" {
+		t.Fatalf("expected no code while disabled, got %q (%v)", current, err)
+	}
+
+	fresh, err := env.service.RegenerateInviteCode(ctx, tournament.ID, organizer)
+	if err != nil {
+		t.Fatalf("RegenerateInviteCode: %v", err)
+	}
+	if _, err := env.service.JoinByInviteCode(ctx, fresh, uuid.New(), "player"); err != nil {
+		t.Fatalf("the regenerated code should work: %v", err)
+	}
+	if _, err := env.service.JoinByInviteCode(ctx, "", uuid.New(), "player"); !errors.Is(err, ErrInviteCodeNotFound) {
+		t.Fatalf("an empty code should not be found, got %v", err)
+	}
+}
+
+func TestJoinByInviteCodeHonorsTheDeadline(t *testing.T) {
+	env := newTestEnv(t)
+	tournament, code := createWithCode(t, env, uuid.New(), nil)
+	past := time.Now().Add(-time.Hour)
+	env.store.tournaments[tournament.ID].RegistrationDeadline = &past
+
+	if _, err := env.service.JoinByInviteCode(context.Background(), code, uuid.New(), "late"); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("joining after the deadline should be refused, got %v", err)
+	}
+}
+
+// takenCodes reports the first few invite codes it is given as already in use
+type takenCodes struct {
+	repository.TournamentRepository
+	taken int
+}
+
+func (r *takenCodes) SetInviteCode(ctx context.Context, id uuid.UUID, code string) error {
+	if r.taken > 0 {
+		r.taken--
+		return repository.ErrInviteCodeTaken
+	}
+	return r.TournamentRepository.SetInviteCode(ctx, id, code)
+}
+
+func TestInviteCodeCollisionsAreRetried(t *testing.T) {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	repo := &takenCodes{TournamentRepository: env.service.tournamentRepo, taken: inviteCodeAttempts - 1}
+	env.service.tournamentRepo = repo
+
+	code, err := env.service.RegenerateInviteCode(context.Background(), tournament.ID, organizer)
+	if err != nil || code == "" {
+		t.Fatalf("expected a code on the last attempt, got %q (%v)", code, err)
+	}
+
+	repo.taken = inviteCodeAttempts
+	if _, err := env.service.RegenerateInviteCode(context.Background(), tournament.ID, organizer); err == nil {
+		t.Fatal("expected an error once every attempt collides")
+	}
+}
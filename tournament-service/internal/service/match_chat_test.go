@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/client"
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// matchChatFixture is a tournament with one match between two linked players
+type matchChatFixture struct {
+	env        *testEnv
+	tournament *domain.Tournament
+	organizer  uuid.UUID
+	players    []*domain.Participant
+	match      *domain.Match
+}
+
+func newMatchChatFixture(t *testing.T) *matchChatFixture {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 3)
+	match := &domain.Match{
+		ID: uuid.New(), TournamentID: tournament.ID, Round: 1, MatchNumber: 1,
+		Participant1ID: &players[0].ID, Participant2ID: &players[1].ID, Status: domain.MatchPending,
+	}
+	env.store.putMatch(match)
+	return &matchChatFixture{env: env, tournament: tournament, organizer: organizer, players: players, match: match}
+}
+
+func TestMatchChatIsLimitedToPlayersAndOrganizers(t *testing.T) {
+	ctx := context.Background()
+	f := newMatchChatFixture(t)
+	request := &domain.MessageRequest{Message: "ready?"}
+
+	for _, userID := range []uuid.UUID{*f.players[0].UserID, *f.players[1].UserID, f.organizer} {
+		if _, err := f.env.service.SendMatchMessage(ctx, f.tournament.ID, f.match.ID, userID, request); err != nil {
+			t.Fatalf("member %s could not post: %v", userID, err)
+		}
+	}
+
+	outsider := *f.players[2].UserID
+	if _, err := f.env.service.SendMatchMessage(ctx, f.tournament.ID, f.match.ID, outsider, request); !errors.Is(err, ErrNotMatchMember) {
+		t.Fatalf("expected ErrNotMatchMember for another participant, got %v", err)
+	}
+	if _, err := f.env.service.GetMatchMessages(ctx, f.tournament.ID, f.match.ID, outsider, 50, 0); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("outsiders must not read the thread, got %v", err)
+	}
+
+	messages, err := f.env.service.GetMatchMessages(ctx, f.tournament.ID, f.match.ID, f.organizer, 50, 0)
+	if err != nil {
+		t.Fatalf("GetMatchMessages: %v", err)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 thread messages, got %d", len(messages))
+	}
+	for _, message := range messages {
+		if message.MatchID == nil || *message.MatchID != f.match.ID {
+			t.Fatal("thread message is not scoped to the match")
+		}
+	}
+}
+
+func TestMatchChatStaysOutOfTournamentChat(t *testing.T) {
+	ctx := context.Background()
+	f := newMatchChatFixture(t)
+
+	if _, err := f.env.service.SendMatchMessage(ctx, f.tournament.ID, f.match.ID, f.organizer, &domain.MessageRequest{Message: "match only"}); err != nil {
+		t.Fatalf("SendMatchMessage: %v", err)
+	}
+
+	tournamentChat, err := f.env.service.GetMessages(ctx, f.tournament.ID, 50, 0)
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+	if len(tournamentChat) != 0 {
+		t.Fatalf("match messages leaked into the tournament chat: %+v", tournamentChat)
+	}
+
+	var posted int
+	for _, event := range f.env.drainEvents() {
+		if event.Type == domain.WSEventMatchMessagePosted {
+			posted++
+		}
+	}
+	if posted != 1 {
+		t.Fatalf("expected one %s event, got %d", domain.WSEventMatchMessagePosted, posted)
+	}
+}
+
+func TestMatchChatUnknownMatchIsNotFound(t *testing.T) {
+	ctx := context.Background()
+	f := newMatchChatFixture(t)
+	other := newMatchChatFixture(t)
+	f.env.store.putMatch(other.match)
+
+	for name, matchID := range map[string]uuid.UUID{
+		"missing match":        uuid.New(),
+		"another tournament's": other.match.ID,
+	} {
+		_, err := f.env.service.GetMatchMessages(ctx, f.tournament.ID, matchID, f.organizer, 50, 0)
+		if !errors.Is(err, ErrMatchNotFound) || !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("%s: expected a not-found error, got %v", name, err)
+		}
+	}
+}
+
+func TestMatchChatIsReadableByPlayersAndCoOrganizers(t *testing.T) {
+	ctx := context.Background()
+	f := newMatchChatFixture(t)
+	coOrganizer := uuid.New()
+	f.env.store.tournaments[f.tournament.ID].CustomFields = json.RawMessage(`{"co_organizers": ["` + coOrganizer.String() + `"]}`)
+	player := *f.players[0].UserID
+	f.env.users.details[player] = client.UserDetails{ID: player, Username: "ace"}
+
+	if _, err := f.env.service.SendMatchMessage(ctx, f.tournament.ID, f.match.ID, player, &domain.MessageRequest{Message: "gg"}); err != nil {
+		t.Fatalf("SendMatchMessage: %v", err)
+	}
+	for name, userID := range map[string]uuid.UUID{"the opponent": *f.players[1].UserID, "a co-organizer": coOrganizer} {
+		messages, err := f.env.service.GetMatchMessages(ctx, f.tournament.ID, f.match.ID, userID, 50, 0)
+		if err != nil {
+			t.Fatalf("%s: GetMatchMessages: %v", name, err)
+		}
+		if len(messages) != 1 || messages[0].Username != "ace" {
+			t.Fatalf("%s: expected the player's message with their username, got %+v", name, messages)
+		}
+	}
+}
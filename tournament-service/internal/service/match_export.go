@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// matchResultsCSVHeader is the header row of a results CSV, in column order
+var matchResultsCSVHeader = []string{
+	"round", "match_number", "participant1", "participant2", "score1", "score2",
+	"winner", "status", "completed_time", "bracket_type",
+}
+
+// bracketExportOrder lists the bracket sides in the order their matches are exported
+var bracketExportOrder = map[domain.BracketType]int{
+	domain.WinnersBracket: 0,
+	domain.LosersBracket:  1,
+	domain.GrandFinals:    2,
+}
+
+// ExportMatchResults lists a tournament's matches with participant names resolved, ordered by
+// bracket side, round and match number
+func (s *tournamentService) ExportMatchResults(ctx context.Context, tournamentID uuid.UUID) (*domain.MatchResultsExport, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	names := make(map[uuid.UUID]string, len(participants))
+	for _, p := range participants {
+		names[p.ID] = p.ParticipantName
+	}
+	nameOf := func(id *uuid.UUID) string {
+		if id == nil {
+			return ""
+		}
+		return names[*id]
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if bracketExportOrder[a.BracketType] != bracketExportOrder[b.BracketType] {
+			return bracketExportOrder[a.BracketType] < bracketExportOrder[b.BracketType]
+		}
+		if a.Round != b.Round {
+			return a.Round < b.Round
+		}
+		return a.MatchNumber < b.MatchNumber
+	})
+
+	export := &domain.MatchResultsExport{
+		TournamentID:   tournament.ID,
+		TournamentName: tournament.Name,
+		Matches:        make([]domain.MatchResultRow, 0, len(matches)),
+	}
+	for _, match := range matches {
+		export.Matches = append(export.Matches, domain.MatchResultRow{
+			Round:         match.Round,
+			MatchNumber:   match.MatchNumber,
+			Participant1:  nameOf(match.Participant1ID),
+			Participant2:  nameOf(match.Participant2ID),
+			Score1:        match.ScoreParticipant1,
+			Score2:        match.ScoreParticipant2,
+			Winner:        nameOf(match.WinnerID),
+			Status:        match.Status,
+			CompletedTime: match.CompletedTime,
+			BracketType:   match.BracketType,
+		})
+	}
+	return export, nil
+}
+
+// WriteMatchResultsCSV writes an export as CSV with a header row. Completion times are RFC 3339
+// in UTC and left empty for matches that have not finished.
+func WriteMatchResultsCSV(w io.Writer, export *domain.MatchResultsExport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(matchResultsCSVHeader); err != nil {
+		return err
+	}
+	for _, row := range export.Matches {
+		completed := ""
+		if row.CompletedTime != nil {
+			completed = row.CompletedTime.UTC().Format(time.RFC3339)
+		}
+		record := []string{
+			strconv.Itoa(row.Round),
+			strconv.Itoa(row.MatchNumber),
+			row.Participant1,
+			row.Participant2,
+			strconv.Itoa(row.Score1),
+			strconv.Itoa(row.Score2),
+			row.Winner,
+			string(row.Status),
+			completed,
+			string(row.BracketType),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportFilename turns a tournament name into a download filename with the given extension,
+// e.g. "Spring Cup 2024" -> "spring-cup-2024-results.csv"
+func ExportFilename(name string, tournamentID uuid.UUID, extension string) string {
+	slug := make([]rune, 0, len(name))
+	dash := false
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			slug = append(slug, r)
+			dash = false
+		case r >= 'A' && r <= 'Z':
+			slug = append(slug, r+('a'-'A'))
+			dash = false
+		case len(slug) > 0 && !dash:
+			slug = append(slug, '-')
+			dash = true
+		}
+	}
+	if dash {
+		slug = slug[:len(slug)-1]
+	}
+	if len(slug) == 0 {
+		return fmt.Sprintf("tournament-%s-results.%s", tournamentID, extension)
+	}
+	return fmt.Sprintf("%s-results.%s", string(slug), extension)
+}
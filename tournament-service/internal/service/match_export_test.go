@@ -0,0 +1,86 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestMatchResultsCSV(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New())
+	players := env.players(tournament.ID, 4)
+	completed := time.Date(2024, 3, 9, 18, 30, 0, 0, time.FixedZone("EAT", 3*60*60))
+	env.store.putMatch(&domain.Match{
+		ID: uuid.New(), TournamentID: tournament.ID, Round: 2, MatchNumber: 3, BracketType: domain.WinnersBracket,
+		Participant1ID: &players[0].ID, Status: domain.MatchPending,
+	})
+	env.store.putMatch(&domain.Match{
+		ID: uuid.New(), TournamentID: tournament.ID, Round: 1, MatchNumber: 1, BracketType: domain.WinnersBracket,
+		Participant1ID: &players[0].ID, Participant2ID: &players[3].ID, WinnerID: &players[0].ID,
+		ScoreParticipant1: 3, ScoreParticipant2: 1, Status: domain.MatchCompleted, CompletedTime: &completed,
+	})
+
+	export, err := env.service.ExportMatchResults(ctx, tournament.ID)
+	if err != nil {
+		t.Fatalf("ExportMatchResults: %v", err)
+	}
+	var out bytes.Buffer
+	if err := WriteMatchResultsCSV(&out, export); err != nil {
+		t.Fatalf("WriteMatchResultsCSV: %v", err)
+	}
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected a header and 2 matches, got %d rows", len(records))
+	}
+
+	header := "round,match_number,participant1,participant2,score1,score2,winner,status,completed_time,bracket_type"
+	if got := strings.Join(records[0], ","); got != header {
+		t.Fatalf("header row\nwant %s\ngot  %s", header, got)
+	}
+	// Ordered by round, with names resolved and the completion time in UTC
+	played := strings.Join([]string{
+		"1", "1", players[0].ParticipantName, players[3].ParticipantName, "3", "1",
+		players[0].ParticipantName, string(domain.MatchCompleted), "2024-03-09T15:30:00Z", string(domain.WinnersBracket),
+	}, ",")
+	if got := strings.Join(records[1], ","); got != played {
+		t.Fatalf("completed match row\nwant %s\ngot  %s", played, got)
+	}
+	if records[2][3] != "" || records[2][6] != "" || records[2][8] != "" {
+		t.Fatalf("an unplayed match should leave the opponent, winner and completion time empty, got %v", records[2])
+	}
+}
+
+func TestExportMatchResultsMissingTournament(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.service.ExportMatchResults(context.Background(), uuid.New())
+	var notFound *ErrTournamentNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected ErrTournamentNotFound, got %v", err)
+	}
+}
+
+func TestExportFilename(t *testing.T) {
+	id := uuid.New()
+	for name, want := range map[string]string{
+		"Spring Cup 2024":   "spring-cup-2024-results.csv",
+		"  FIFA / Weekly! ": "fifa-weekly-results.csv",
+		"???":               "tournament-" + id.String() + "-results.csv",
+	} {
+		if got := ExportFilename(name, id, "csv"); got != want {
+			t.Errorf("ExportFilename(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestListMatchesPagesAndFilters(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	env.players(tournament.ID, 8)
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+	all := env.storedMatches(tournament.ID)
+
+	page, total, err := env.service.ListMatches(ctx, tournament.ID, domain.MatchFilter{Limit: 3, Offset: 3})
+	if err != nil {
+		t.Fatalf("ListMatches: %v", err)
+	}
+	if total != 7 || len(page) != 3 {
+		t.Fatalf("expected 3 of 7 matches, got %d of %d", len(page), total)
+	}
+	for i, match := range page {
+		if match.ID != all[3+i].ID {
+			t.Fatalf("page item %d is out of order: round %d match %d", i, match.Round, match.MatchNumber)
+		}
+	}
+
+	winners, total, err := env.service.ListMatches(ctx, tournament.ID, domain.MatchFilter{BracketType: domain.WinnersBracket})
+	if err != nil || total != 7 || len(winners) != 7 {
+		t.Fatalf("a single-elimination bracket is all winners matches, got %d of %d (%v)", len(winners), total, err)
+	}
+	losers, total, err := env.service.ListMatches(ctx, tournament.ID, domain.MatchFilter{BracketType: domain.LosersBracket})
+	if err != nil || total != 0 || losers == nil || len(losers) != 0 {
+		t.Fatalf("expected an empty losers bracket, got %v of %d (%v)", losers, total, err)
+	}
+}
+
+func TestMatchResponsesCarryParticipantNames(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New())
+	players := env.players(tournament.ID, 2)
+	env.store.putMatch(&domain.Match{
+		ID: uuid.New(), TournamentID: tournament.ID, Round: 1, MatchNumber: 1,
+		Participant1ID: &players[0].ID, Status: domain.MatchPending,
+		Participant1Name: players[0].ParticipantName,
+	})
+
+	matches, err := env.service.GetMatches(context.Background(), tournament.ID)
+	if err != nil {
+		t.Fatalf("GetMatches: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Participant1Name != "player1" || matches[0].Participant2Name != "" || matches[0].WinnerName != "" {
+		t.Fatalf("expected player1 against a TBD slot, got %+v", matches)
+	}
+}
+
+func TestGetMatchOnlyFindsMatchesOfTheTournament(t *testing.T) {
+	ctx := context.Background()
+	f := newMatchChatFixture(t)
+
+	match, err := f.env.service.GetMatch(ctx, f.tournament.ID, f.match.ID)
+	if err != nil {
+		t.Fatalf("GetMatch: %v", err)
+	}
+	if match.ID != f.match.ID || match.Participant1ID == nil || *match.Participant1ID != *f.match.Participant1ID {
+		t.Fatalf("unexpected match %+v", match)
+	}
+
+	other := f.env.tournament(uuid.New())
+	for name, ids := range map[string][2]uuid.UUID{
+		"another tournament": {other.ID, f.match.ID},
+		"a missing match":    {f.tournament.ID, uuid.New()},
+	} {
+		if _, err := f.env.service.GetMatch(ctx, ids[0], ids[1]); !errors.Is(err, ErrMatchNotFound) || !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("%s: expected ErrMatchNotFound, got %v", name, err)
+		}
+	}
+}
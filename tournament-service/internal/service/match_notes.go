@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ErrNotMatchAnnotator is returned when someone other than the match's players or the organizers edits its notes
+var ErrNotMatchAnnotator = domain.NewError(domain.ErrForbidden, "only the match participants and the tournament organizers can edit match notes")
+
+// UpdateMatchNotes replaces a match's notes, e.g. "replay due to disconnect", leaving its
+// scores and status as they are. The users behind the two match slots and the tournament's
+// organizers may edit them.
+func (s *tournamentService) UpdateMatchNotes(
+	ctx context.Context, tournamentID, matchID, userID uuid.UUID, request *domain.MatchNotesRequest,
+) (*domain.MatchResponse, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get match %s: %w", matchID, err)
+	}
+	if match.TournamentID != tournamentID {
+		return nil, ErrMatchNotFound
+	}
+	if request.Version != nil && *request.Version != match.Version {
+		return nil, domain.ErrConcurrentModification
+	}
+
+	allowed := isOrganizer(tournament, userID)
+	for _, participantID := range []*uuid.UUID{match.Participant1ID, match.Participant2ID} {
+		if allowed || participantID == nil {
+			continue
+		}
+		participant, err := s.participantRepo.GetByID(ctx, *participantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get participant %s: %w", *participantID, err)
+		}
+		if participant != nil && participant.UserID != nil && *participant.UserID == userID {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, ErrNotMatchAnnotator
+	}
+
+	match.MatchNotes = request.MatchNotes
+	if err := s.matchRepo.Update(ctx, match); err != nil {
+		return nil, fmt.Errorf("failed to update notes of match %s: %w", matchID, err)
+	}
+
+	return toMatchResponse(match), nil
+}
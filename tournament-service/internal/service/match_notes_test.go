@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestUpdateMatchNotesLeavesTheResultAlone(t *testing.T) {
+	ctx := context.Background()
+	f := newMatchChatFixture(t)
+	stored := f.env.store.matches[f.match.ID]
+	stored.ScoreParticipant1, stored.ScoreParticipant2 = 2, 1
+	stored.Status = domain.MatchInProgress
+
+	response, err := f.env.service.UpdateMatchNotes(ctx, f.tournament.ID, f.match.ID, *f.players[0].UserID,
+		&domain.MatchNotesRequest{MatchNotes: "replay due to disconnect"})
+	if err != nil {
+		t.Fatalf("UpdateMatchNotes: %v", err)
+	}
+	if response.MatchNotes != "replay due to disconnect" || response.ScoreParticipant1 != 2 || response.ScoreParticipant2 != 1 {
+		t.Fatalf("unexpected response %+v", response)
+	}
+
+	updated := f.env.store.matches[f.match.ID]
+	if updated.MatchNotes != "replay due to disconnect" {
+		t.Fatalf("notes were not saved, got %q", updated.MatchNotes)
+	}
+	if updated.ScoreParticipant1 != 2 || updated.ScoreParticipant2 != 1 || updated.Status != domain.MatchInProgress || updated.WinnerID != nil {
+		t.Fatalf("notes-only update changed the result: %+v", updated)
+	}
+
+	// The organizer can edit them too, and an empty string clears them
+	if _, err := f.env.service.UpdateMatchNotes(ctx, f.tournament.ID, f.match.ID, f.organizer, &domain.MatchNotesRequest{}); err != nil {
+		t.Fatalf("organizer UpdateMatchNotes: %v", err)
+	}
+	if notes := f.env.store.matches[f.match.ID].MatchNotes; notes != "" {
+		t.Fatalf("expected the notes to be cleared, got %q", notes)
+	}
+}
+
+func TestUpdateMatchNotesAuthorization(t *testing.T) {
+	ctx := context.Background()
+	f := newMatchChatFixture(t)
+	request := &domain.MatchNotesRequest{MatchNotes: "gg"}
+
+	for name, userID := range map[string]uuid.UUID{
+		"another participant": *f.players[2].UserID,
+		"a stranger":          uuid.New(),
+	} {
+		if _, err := f.env.service.UpdateMatchNotes(ctx, f.tournament.ID, f.match.ID, userID, request); !errors.Is(err, ErrNotMatchAnnotator) || !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("%s: expected ErrNotMatchAnnotator, got %v", name, err)
+		}
+	}
+	if notes := f.env.store.matches[f.match.ID].MatchNotes; notes != "" {
+		t.Fatalf("a refused edit saved notes %q", notes)
+	}
+}
+
+func TestUpdateMatchNotesErrors(t *testing.T) {
+	ctx := context.Background()
+	f := newMatchChatFixture(t)
+	player := *f.players[0].UserID
+
+	if _, err := f.env.service.UpdateMatchNotes(ctx, f.tournament.ID, uuid.New(), player, &domain.MatchNotesRequest{}); !errors.Is(err, ErrMatchNotFound) {
+		t.Fatalf("missing match: expected ErrMatchNotFound, got %v", err)
+	}
+	var notFound *ErrTournamentNotFound
+	if _, err := f.env.service.UpdateMatchNotes(ctx, uuid.New(), f.match.ID, player, &domain.MatchNotesRequest{}); !errors.As(err, &notFound) {
+		t.Fatalf("missing tournament: expected ErrTournamentNotFound, got %v", err)
+	}
+
+	stale := f.env.store.matches[f.match.ID].Version - 1
+	if _, err := f.env.service.UpdateMatchNotes(ctx, f.tournament.ID, f.match.ID, player, &domain.MatchNotesRequest{Version: &stale}); !errors.Is(err, domain.ErrConcurrentModification) {
+		t.Fatalf("stale version: expected ErrConcurrentModification, got %v", err)
+	}
+}
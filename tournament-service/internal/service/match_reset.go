@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// ErrMatchNotCompleted is returned when resetting a match that has no played result to undo,
+// including byes and double forfeits
+var ErrMatchNotCompleted = domain.NewError(domain.ErrConflict, "only a completed match between two participants can be reset")
+
+// ErrDownstreamMatchPlayed is returned when resetting a match whose winner or loser has
+// already played the match they advanced to
+var ErrDownstreamMatchPlayed = domain.NewError(domain.ErrConflict, "match cannot be reset: a match its participants advanced to has already been completed")
+
+// ErrTournamentFinished is returned when resetting a match of a completed tournament
+var ErrTournamentFinished = domain.NewError(domain.ErrConflict, "matches of a completed tournament cannot be reset")
+
+// ResetMatch undoes a reported result: the match goes back to pending with its scores cleared,
+// the participants it advanced are taken out of the following matches, and the Ranking Service
+// is told to reverse the outcome. It is refused once a following match has been played.
+func (s *tournamentService) ResetMatch(
+	ctx context.Context, tournamentID, matchID, userID uuid.UUID,
+) (*domain.MatchScoreUpdate, error) {
+	tournament, err := s.getManagedTournament(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Status == domain.Completed {
+		return nil, ErrTournamentFinished
+	}
+
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get match %s: %w", matchID, err)
+	}
+	if match.TournamentID != tournamentID {
+		return nil, ErrMatchNotFound
+	}
+	if match.Status != domain.MatchCompleted || match.WinnerID == nil ||
+		match.Participant1ID == nil || match.Participant2ID == nil {
+		return nil, ErrMatchNotCompleted
+	}
+
+	// Find every following match the result put someone into, refusing before anything is changed
+	downstream, err := s.resetDownstreamMatches(ctx, tournament, match, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Queue the ranking reversal with the match update; results without linked users were never sent
+	outboxEntry, err := s.rankingReversalEntry(ctx, tournament, match)
+	if err != nil {
+		return nil, err
+	}
+
+	match.Status = domain.MatchPending
+	match.ScoreParticipant1, match.ScoreParticipant2 = 0, 0
+	match.Games = nil
+	match.WinnerID, match.LoserID = nil, nil
+	match.CompletedTime = nil
+	if outboxEntry != nil {
+		err = s.matchRepo.UpdateWithOutbox(ctx, match, outboxEntry)
+	} else {
+		err = s.matchRepo.Update(ctx, match)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset match %s: %w", matchID, err)
+	}
+	logging.Infof(ctx, "Match %s of tournament %s reset by %s", matchID, tournamentID, userID)
+
+	updatedMatchIDs := []uuid.UUID{}
+	for _, next := range downstream {
+		if err := s.matchRepo.Update(ctx, next); err != nil {
+			logging.Warnf(ctx, "ResetMatch - Failed to remove advanced participant from match %s: %v", next.ID, err)
+			continue
+		}
+		updatedMatchIDs = append(updatedMatchIDs, next.ID)
+	}
+
+	if s.broadcastChan != nil {
+		s.broadcastChan <- domain.WebSocketMessage{
+			Type: domain.WSEventMatchScoreUpdated,
+			Payload: domain.MatchScoreUpdatedPayload{
+				TournamentID:      tournamentID,
+				MatchID:           match.ID,
+				Participant1ID:    match.Participant1ID,
+				Participant2ID:    match.Participant2ID,
+				ScoreParticipant1: match.ScoreParticipant1,
+				ScoreParticipant2: match.ScoreParticipant2,
+				WinnerID:          match.WinnerID,
+				Status:            match.Status,
+			},
+		}
+		logging.Infof(ctx, "Broadcasted WSEventMatchScoreUpdated for reset M-%s", match.ID)
+	}
+
+	return &domain.MatchScoreUpdate{
+		Match:           toMatchResponse(match),
+		UpdatedMatchIDs: updatedMatchIDs,
+	}, nil
+}
+
+// ErrDownstreamMatchStarted is returned when correcting a result whose winner or loser is
+// already playing, or has played, the match they advanced to
+var ErrDownstreamMatchStarted = domain.NewError(domain.ErrConflict, "result cannot be corrected: a match its participants advanced to has already started")
+
+// resetDownstreamMatches returns the following matches with the participants the result advanced
+// removed, ready to be saved. It fails with ErrDownstreamMatchPlayed if any of them was completed,
+// and with ErrDownstreamMatchStarted if refuseStarted is set and any of them is in progress.
+func (s *tournamentService) resetDownstreamMatches(
+	ctx context.Context, tournament *domain.Tournament, match *domain.Match, refuseStarted bool,
+) ([]*domain.Match, error) {
+	var downstream []*domain.Match
+
+	release := func(nextID *uuid.UUID, advanced *uuid.UUID) error {
+		if nextID == nil || advanced == nil {
+			return nil
+		}
+		next, err := s.matchRepo.GetByID(ctx, *nextID)
+		if err != nil {
+			return fmt.Errorf("failed to get following match %s: %w", *nextID, err)
+		}
+		inP1 := next.Participant1ID != nil && *next.Participant1ID == *advanced
+		inP2 := next.Participant2ID != nil && *next.Participant2ID == *advanced
+		if !inP1 && !inP2 {
+			return nil
+		}
+		if refuseStarted && (next.Status == domain.MatchCompleted || next.Status == domain.MatchInProgress) {
+			return ErrDownstreamMatchStarted
+		}
+		if next.Status == domain.MatchCompleted {
+			return ErrDownstreamMatchPlayed
+		}
+		if inP1 {
+			next.Participant1ID = nil
+		} else {
+			next.Participant2ID = nil
+		}
+		// Scores set before the match was played, such as a grand finals advantage, go with the participant
+		next.ScoreParticipant1, next.ScoreParticipant2 = 0, 0
+		next.Games = nil
+		next.Status = domain.MatchPending
+		downstream = append(downstream, next)
+		return nil
+	}
+
+	if err := release(match.NextMatchID, match.WinnerID); err != nil {
+		return nil, err
+	}
+	if tournament.Format == domain.DoubleElimination {
+		if err := release(match.LoserNextMatchID, match.LoserID); err != nil {
+			return nil, err
+		}
+
+		// The grand finals also decided whether the bracket reset is played
+		if match.BracketType == domain.GrandFinals {
+			matches, err := s.matchRepo.GetByTournamentID(ctx, tournament.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get matches: %w", err)
+			}
+			if reset := bracketReset(matches, match); reset != nil {
+				if refuseStarted && (reset.Status == domain.MatchCompleted || reset.Status == domain.MatchInProgress) {
+					return nil, ErrDownstreamMatchStarted
+				}
+				if reset.Status == domain.MatchCompleted {
+					return nil, ErrDownstreamMatchPlayed
+				}
+				reset.Participant1ID, reset.Participant2ID = nil, nil
+				reset.ScoreParticipant1, reset.ScoreParticipant2 = 0, 0
+				reset.Games = nil
+				reset.Status = domain.MatchPending
+				downstream = append(downstream, reset)
+			}
+		}
+	}
+	return downstream, nil
+}
+
+// rankingReversalEntry builds the outbox entry that takes back the match's result at the
+// Ranking Service, or nil if the result was never sent because a participant has no linked user
+func (s *tournamentService) rankingReversalEntry(
+	ctx context.Context, tournament *domain.Tournament, match *domain.Match,
+) (*domain.OutboxEntry, error) {
+	if match.Participant1ID == nil || match.Participant2ID == nil {
+		return nil, nil
+	}
+	p1, err := s.participantRepo.GetByID(ctx, *match.Participant1ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant %s: %w", *match.Participant1ID, err)
+	}
+	p2, err := s.participantRepo.GetByID(ctx, *match.Participant2ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant %s: %w", *match.Participant2ID, err)
+	}
+
+	// The outcomes being taken back, for the record; the Ranking Service reverses what it applied
+	p1Outcome, p2Outcome := RS_Loss, RS_Win
+	if *match.WinnerID == p1.ID {
+		p1Outcome, p2Outcome = RS_Win, RS_Loss
+	}
+	return rankingOutboxEntry(tournament, match, p1, p2, p1Outcome, p2Outcome, RS_Reversal, time.Now())
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestResetMatchUndoesTheResult(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	semi := f.semis[0]
+	if err := f.report(semi, 2, 1); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	winner := *f.env.match(t, semi.ID).WinnerID
+
+	update, err := f.env.service.ResetMatch(ctx, f.tournament.ID, semi.ID, f.organizer)
+	if err != nil {
+		t.Fatalf("ResetMatch: %v", err)
+	}
+	if len(update.UpdatedMatchIDs) != 1 || update.UpdatedMatchIDs[0] != f.final.ID {
+		t.Fatalf("expected the final to be updated, got %v", update.UpdatedMatchIDs)
+	}
+
+	reset := f.env.match(t, semi.ID)
+	if reset.Status != domain.MatchPending || reset.WinnerID != nil || reset.LoserID != nil || reset.CompletedTime != nil ||
+		reset.ScoreParticipant1 != 0 || reset.ScoreParticipant2 != 0 {
+		t.Fatalf("match was not reset: %+v", reset)
+	}
+	final := f.env.match(t, f.final.ID)
+	if (final.Participant1ID != nil && *final.Participant1ID == winner) || (final.Participant2ID != nil && *final.Participant2ID == winner) {
+		t.Fatal("the winner is still in the final")
+	}
+
+	events := f.rankingEvents(t)
+	if len(events) != 2 || events[0].Type != RS_Result || events[1].Type != RS_Reversal || events[1].MatchID != semi.ID {
+		t.Fatalf("expected the result and then its reversal, got %+v", events)
+	}
+
+	// The match can be reported again, and advances its new winner
+	if err := f.report(semi, 0, 2); err != nil {
+		t.Fatalf("report after reset: %v", err)
+	}
+	if got := f.env.match(t, semi.ID); got.WinnerID == nil || *got.WinnerID == winner {
+		t.Fatalf("expected the other player to win the replay, got %v", got.WinnerID)
+	}
+}
+
+func TestResetMatchRefusesOnceTheNextMatchIsPlayed(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	for _, semi := range f.semis {
+		if err := f.report(semi, 2, 0); err != nil {
+			t.Fatalf("report semi: %v", err)
+		}
+	}
+	if err := f.report(f.final, 2, 0); err != nil {
+		t.Fatalf("report final: %v", err)
+	}
+	// Finishing the final completes the tournament; reopen it so only the played final blocks the reset
+	f.env.store.tournaments[f.tournament.ID].Status = domain.InProgress
+	queued := len(f.env.store.outbox)
+
+	_, err := f.env.service.ResetMatch(ctx, f.tournament.ID, f.semis[0].ID, f.organizer)
+	if !errors.Is(err, ErrDownstreamMatchPlayed) {
+		t.Fatalf("expected ErrDownstreamMatchPlayed, got %v", err)
+	}
+	if got := f.env.match(t, f.semis[0].ID); got.Status != domain.MatchCompleted || got.WinnerID == nil {
+		t.Fatal("a refused reset changed the match")
+	}
+	if len(f.env.store.outbox) != queued {
+		t.Fatal("a refused reset queued a ranking reversal")
+	}
+}
+
+func TestResetMatchNeedsACompletedMatch(t *testing.T) {
+	f := newCorrectionFixture(t)
+
+	if _, err := f.env.service.ResetMatch(context.Background(), f.tournament.ID, f.semis[0].ID, f.organizer); !errors.Is(err, ErrMatchNotCompleted) {
+		t.Fatalf("pending match: expected ErrMatchNotCompleted, got %v", err)
+	}
+}
+
+func TestResetUnknownMatch(t *testing.T) {
+	f := newCorrectionFixture(t)
+
+	if _, err := f.env.service.ResetMatch(context.Background(), f.tournament.ID, uuid.New(), f.organizer); !errors.Is(err, ErrMatchNotFound) {
+		t.Fatalf("expected ErrMatchNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// ErrMatchNotFound is returned when a match does not exist or belongs to another tournament
+var ErrMatchNotFound = domain.NewError(domain.ErrNotFound, "match not found")
+
+// ErrMatchAlreadyCompleted is returned when rescheduling a match that has already been played
+var ErrMatchAlreadyCompleted = domain.NewError(domain.ErrConflict, "match is already completed and cannot be rescheduled")
+
+// UpdateMatchSchedule sets or clears a match's scheduled time. Only organizers may schedule
+// matches, and the match's reporting deadline follows its new time.
+func (s *tournamentService) UpdateMatchSchedule(
+	ctx context.Context, tournamentID, matchID, userID uuid.UUID, scheduledTime *time.Time,
+) (*domain.MatchResponse, error) {
+	tournament, err := s.getManagedTournament(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get match %s: %w", matchID, err)
+	}
+	if match.TournamentID != tournamentID {
+		return nil, ErrMatchNotFound
+	}
+	if match.Status == domain.MatchCompleted {
+		return nil, ErrMatchAlreadyCompleted
+	}
+
+	if scheduledTime != nil {
+		utc := scheduledTime.UTC()
+		scheduledTime = &utc
+	}
+	match.ScheduledTime = scheduledTime
+	applyReportingDeadline(match, tournament)
+	if err := s.matchRepo.Update(ctx, match); err != nil {
+		return nil, fmt.Errorf("failed to update schedule of match %s: %w", matchID, err)
+	}
+
+	if s.broadcastChan != nil {
+		s.broadcastChan <- domain.WebSocketMessage{
+			Type: domain.WSEventMatchScheduled,
+			Payload: domain.MatchScheduledPayload{
+				TournamentID:  tournamentID,
+				MatchID:       matchID,
+				ScheduledTime: match.ScheduledTime,
+			},
+		}
+		logging.Infof(ctx, "Broadcasted WSEventMatchScheduled for M-%s", matchID)
+	}
+
+	return toMatchResponse(match), nil
+}
+
+// GetSchedule returns the tournament's scheduled matches in the order they are to be played
+func (s *tournamentService) GetSchedule(ctx context.Context, tournamentID uuid.UUID) ([]*domain.MatchResponse, error) {
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	scheduled := make([]*domain.Match, 0, len(matches))
+	for _, match := range matches {
+		if match.ScheduledTime != nil {
+			scheduled = append(scheduled, match)
+		}
+	}
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		if !scheduled[i].ScheduledTime.Equal(*scheduled[j].ScheduledTime) {
+			return scheduled[i].ScheduledTime.Before(*scheduled[j].ScheduledTime)
+		}
+		if scheduled[i].Round != scheduled[j].Round {
+			return scheduled[i].Round < scheduled[j].Round
+		}
+		return scheduled[i].MatchNumber < scheduled[j].MatchNumber
+	})
+
+	responses := make([]*domain.MatchResponse, len(scheduled))
+	for i, match := range scheduled {
+		responses[i] = toMatchResponse(match)
+	}
+	return responses, nil
+}
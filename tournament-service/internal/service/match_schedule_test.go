@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestUpdateMatchScheduleStoresUTCAndBroadcasts(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 2)
+	match := idleMatch(env, tournament.ID, players[0], players[1], 0)
+	nairobi := time.FixedZone("EAT", 3*60*60)
+	when := time.Date(2026, 11, 1, 18, 0, 0, 0, nairobi)
+
+	response, err := env.service.UpdateMatchSchedule(ctx, tournament.ID, match.ID, organizer, &when)
+	if err != nil {
+		t.Fatalf("UpdateMatchSchedule: %v", err)
+	}
+	if response.ScheduledTime == nil || !response.ScheduledTime.Equal(when) || response.ScheduledTime.Location() != time.UTC {
+		t.Fatalf("expected %s stored in UTC, got %v", when, response.ScheduledTime)
+	}
+
+	var scheduled []domain.MatchScheduledPayload
+	for _, event := range env.drainEvents() {
+		if event.Type == domain.WSEventMatchScheduled {
+			scheduled = append(scheduled, event.Payload.(domain.MatchScheduledPayload))
+		}
+	}
+	if len(scheduled) != 1 || scheduled[0].MatchID != match.ID {
+		t.Fatalf("expected one %s event for the match, got %+v", domain.WSEventMatchScheduled, scheduled)
+	}
+
+	// A nil time clears the schedule
+	response, err = env.service.UpdateMatchSchedule(ctx, tournament.ID, match.ID, organizer, nil)
+	if err != nil || response.ScheduledTime != nil {
+		t.Fatalf("expected the schedule to be cleared, got %v, %v", response, err)
+	}
+}
+
+func TestUpdateMatchScheduleRefusals(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+	other := env.tournament(organizer)
+	players := env.players(tournament.ID, 4)
+	pending := idleMatch(env, tournament.ID, players[0], players[1], 0)
+	played := idleMatch(env, tournament.ID, players[2], players[3], 0)
+	env.store.matches[played.ID].Status = domain.MatchCompleted
+	when := time.Now().Add(time.Hour)
+
+	var notAuthorized *ErrNotAuthorized
+	if _, err := env.service.UpdateMatchSchedule(ctx, tournament.ID, pending.ID, *players[0].UserID, &when); !errors.As(err, &notAuthorized) {
+		t.Errorf("players may not schedule matches, got %v", err)
+	}
+	if _, err := env.service.UpdateMatchSchedule(ctx, tournament.ID, played.ID, organizer, &when); !errors.Is(err, ErrMatchAlreadyCompleted) {
+		t.Errorf("expected ErrMatchAlreadyCompleted, got %v", err)
+	}
+	if _, err := env.service.UpdateMatchSchedule(ctx, other.ID, pending.ID, organizer, &when); !errors.Is(err, ErrMatchNotFound) {
+		t.Errorf("a match of another tournament should not be found, got %v", err)
+	}
+	if _, err := env.service.UpdateMatchSchedule(ctx, tournament.ID, uuid.New(), organizer, &when); !errors.Is(err, ErrMatchNotFound) {
+		t.Errorf("expected ErrMatchNotFound, got %v", err)
+	}
+}
+
+func TestGetScheduleOrdersScheduledMatches(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 8)
+	at := time.Now().Add(time.Hour)
+	later, sameTimeRound2, sameTimeRound1, unscheduled :=
+		idleMatch(env, tournament.ID, players[0], players[1], 0),
+		idleMatch(env, tournament.ID, players[2], players[3], 0),
+		idleMatch(env, tournament.ID, players[4], players[5], 0),
+		idleMatch(env, tournament.ID, players[6], players[7], 0)
+	laterTime := at.Add(time.Hour)
+	env.store.matches[later.ID].ScheduledTime = &laterTime
+	env.store.matches[sameTimeRound2.ID].ScheduledTime = &at
+	env.store.matches[sameTimeRound2.ID].Round = 2
+	env.store.matches[sameTimeRound1.ID].ScheduledTime = &at
+
+	schedule, err := env.service.GetSchedule(context.Background(), tournament.ID)
+	if err != nil {
+		t.Fatalf("GetSchedule: %v", err)
+	}
+	want := []uuid.UUID{sameTimeRound1.ID, sameTimeRound2.ID, later.ID}
+	if len(schedule) != len(want) {
+		t.Fatalf("expected %d scheduled matches, got %d", len(want), len(schedule))
+	}
+	for i, id := range want {
+		if schedule[i].ID != id {
+			t.Fatalf("position %d: want %s, got %s", i+1, id, schedule[i].ID)
+		}
+		if schedule[i].ID == unscheduled.ID {
+			t.Fatal("unscheduled matches do not belong in the schedule")
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// chatFixture is an in-progress tournament with one message posted by its first player
+type chatFixture struct {
+	env        *testEnv
+	tournament *domain.Tournament
+	organizer  uuid.UUID
+	author     uuid.UUID
+	other      uuid.UUID
+	message    *domain.Message
+}
+
+func newChatFixture(t *testing.T) *chatFixture {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 2)
+	author := *players[0].UserID
+	message, err := env.service.SendMessage(context.Background(), tournament.ID, author, &domain.MessageRequest{Message: "gg"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	return &chatFixture{env: env, tournament: tournament, organizer: organizer, author: author, other: *players[1].UserID, message: message}
+}
+
+func TestEditMessageByAuthorOrOrganizer(t *testing.T) {
+	ctx := context.Background()
+	f := newChatFixture(t)
+
+	edited, err := f.env.service.EditMessage(ctx, f.tournament.ID, f.message.ID, f.author, &domain.MessageRequest{Message: "gg wp"})
+	if err != nil {
+		t.Fatalf("author edit: %v", err)
+	}
+	if edited.Message != "gg wp" || edited.EditedAt == nil {
+		t.Fatalf("expected the edited text with an edit time, got %+v", edited)
+	}
+	if _, err := f.env.service.EditMessage(ctx, f.tournament.ID, f.message.ID, f.organizer, &domain.MessageRequest{Message: "[moderated]"}); err != nil {
+		t.Fatalf("organizer edit: %v", err)
+	}
+	if _, err := f.env.service.EditMessage(ctx, f.tournament.ID, f.message.ID, f.other, &domain.MessageRequest{Message: "hijacked"}); !errors.Is(err, ErrNotMessageAuthor) {
+		t.Fatalf("expected ErrNotMessageAuthor for another player, got %v", err)
+	}
+	if got := f.env.store.messages[f.message.ID].Message; got != "[moderated]" {
+		t.Fatalf("expected the organizer's edit to be stored, got %q", got)
+	}
+}
+
+func TestDeleteMessageHidesItFromChat(t *testing.T) {
+	ctx := context.Background()
+	f := newChatFixture(t)
+
+	if err := f.env.service.DeleteMessage(ctx, f.tournament.ID, f.message.ID, f.other); !errors.Is(err, ErrNotMessageAuthor) {
+		t.Fatalf("expected ErrNotMessageAuthor for another player, got %v", err)
+	}
+	if err := f.env.service.DeleteMessage(ctx, f.tournament.ID, f.message.ID, f.author); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if f.env.store.messages[f.message.ID].DeletedAt == nil {
+		t.Fatal("the message should be kept and marked deleted")
+	}
+
+	messages, err := f.env.service.GetMessages(ctx, f.tournament.ID, 50, 0)
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+	if len(messages) != 0 {
+		t.Fatalf("a deleted message must not be listed, got %+v", messages)
+	}
+	// A deleted message can be neither edited nor deleted again
+	if _, err := f.env.service.EditMessage(ctx, f.tournament.ID, f.message.ID, f.author, &domain.MessageRequest{Message: "back"}); !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected ErrMessageNotFound when editing a deleted message, got %v", err)
+	}
+	if err := f.env.service.DeleteMessage(ctx, f.tournament.ID, f.message.ID, f.author); !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected ErrMessageNotFound when deleting twice, got %v", err)
+	}
+}
+
+func TestMessageFromAnotherTournamentIsNotFound(t *testing.T) {
+	f := newChatFixture(t)
+	other := f.env.tournament(f.organizer)
+
+	err := f.env.service.DeleteMessage(context.Background(), other.ID, f.message.ID, f.organizer)
+	if !errors.Is(err, ErrMessageNotFound) || !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected a not-found error, got %v", err)
+	}
+	if err := f.env.service.DeleteMessage(context.Background(), f.tournament.ID, uuid.New(), f.organizer); !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected ErrMessageNotFound for a missing message, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrReactionExists is returned when a user reacts to a message with an emoji they already used on it
+var ErrReactionExists = domain.NewError(domain.ErrConflict, "you already reacted with this emoji")
+
+// ErrReactionNotFound is returned when removing a reaction the user has not made
+var ErrReactionNotFound = domain.NewError(domain.ErrNotFound, "reaction not found")
+
+// messageResponses maps a page of messages to their API representation with author names
+// and reaction counts
+func (s *tournamentService) messageResponses(
+	ctx context.Context, messages []*domain.Message,
+) ([]*domain.MessageResponse, error) {
+	ids := make([]uuid.UUID, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+	reactions, err := s.messageRepo.ReactionCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	authors := s.lookupMessageAuthors(ctx, messages)
+	responses := make([]*domain.MessageResponse, len(messages))
+	for i, message := range messages {
+		responses[i] = toMessageResponse(message, authors)
+		if counts, ok := reactions[message.ID]; ok {
+			responses[i].Reactions = counts
+		}
+	}
+	return responses, nil
+}
+
+// messageResponse maps a single message like messageResponses
+func (s *tournamentService) messageResponse(ctx context.Context, message *domain.Message) (*domain.MessageResponse, error) {
+	responses, err := s.messageResponses(ctx, []*domain.Message{message})
+	if err != nil {
+		return nil, err
+	}
+	return responses[0], nil
+}
+
+// getChatMessage loads a live message of the tournament
+func (s *tournamentService) getChatMessage(
+	ctx context.Context, tournamentID, messageID uuid.UUID,
+) (*domain.Message, error) {
+	message, err := s.messageRepo.GetByID(ctx, messageID)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("message not found: %v", messageID) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if message.TournamentID != tournamentID || message.DeletedAt != nil {
+		return nil, ErrMessageNotFound
+	}
+	return message, nil
+}
+
+// getReactableMessage loads a message userID may react to: anything in the tournament chat of
+// a tournament they can view, and match thread messages only for that match's players and the
+// organizers
+func (s *tournamentService) getReactableMessage(
+	ctx context.Context, tournamentID, messageID, userID uuid.UUID,
+) (*domain.Message, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	visible, err := s.canView(ctx, tournament, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, &ErrTournamentNotFound{ID: tournamentID}
+	}
+
+	message, err := s.getChatMessage(ctx, tournamentID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.MatchID != nil {
+		if _, err := s.getMatchThread(ctx, tournamentID, *message.MatchID, userID); err != nil {
+			return nil, err
+		}
+	}
+	return message, nil
+}
+
+// AddReaction reacts to a chat message with an emoji. Each user can use each emoji once per message.
+func (s *tournamentService) AddReaction(
+	ctx context.Context, tournamentID, messageID, userID uuid.UUID, emoji string,
+) (*domain.MessageResponse, error) {
+	message, err := s.getReactableMessage(ctx, tournamentID, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.messageRepo.AddReaction(ctx, messageID, userID, emoji); err != nil {
+		if errors.Is(err, repository.ErrReactionExists) {
+			return nil, ErrReactionExists
+		}
+		return nil, err
+	}
+	return s.messageResponse(ctx, message)
+}
+
+// RemoveReaction takes back the user's emoji reaction to a chat message
+func (s *tournamentService) RemoveReaction(
+	ctx context.Context, tournamentID, messageID, userID uuid.UUID, emoji string,
+) (*domain.MessageResponse, error) {
+	message, err := s.getReactableMessage(ctx, tournamentID, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.messageRepo.RemoveReaction(ctx, messageID, userID, emoji); err != nil {
+		if errors.Is(err, repository.ErrReactionNotFound) {
+			return nil, ErrReactionNotFound
+		}
+		return nil, err
+	}
+	return s.messageResponse(ctx, message)
+}
+
+// SetMessagePinned pins a chat message to the top of its chat, or unpins it. Only organizers may pin.
+func (s *tournamentService) SetMessagePinned(
+	ctx context.Context, tournamentID, messageID, userID uuid.UUID, pinned bool,
+) (*domain.MessageResponse, error) {
+	if _, err := s.getManagedTournament(ctx, tournamentID, userID); err != nil {
+		return nil, err
+	}
+	message, err := s.getChatMessage(ctx, tournamentID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.messageRepo.SetPinned(ctx, messageID, pinned); err != nil {
+		return nil, fmt.Errorf("failed to pin message: %w", err)
+	}
+	message.IsPinned = pinned
+	return s.messageResponse(ctx, message)
+}
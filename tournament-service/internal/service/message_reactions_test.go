@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestReactionsAreCountedPerEmoji(t *testing.T) {
+	ctx := context.Background()
+	f := newChatFixture(t)
+
+	for _, reaction := range []struct {
+		userID uuid.UUID
+		emoji  string
+	}{{f.author, "🔥"}, {f.other, "🔥"}, {f.other, "👍"}} {
+		if _, err := f.env.service.AddReaction(ctx, f.tournament.ID, f.message.ID, reaction.userID, reaction.emoji); err != nil {
+			t.Fatalf("AddReaction %s: %v", reaction.emoji, err)
+		}
+	}
+	// The same emoji twice from one user is refused
+	if _, err := f.env.service.AddReaction(ctx, f.tournament.ID, f.message.ID, f.other, "🔥"); !errors.Is(err, ErrReactionExists) || !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("expected ErrReactionExists, got %v", err)
+	}
+
+	response, err := f.env.service.RemoveReaction(ctx, f.tournament.ID, f.message.ID, f.other, "👍")
+	if err != nil {
+		t.Fatalf("RemoveReaction: %v", err)
+	}
+	if len(response.Reactions) != 1 || response.Reactions[0] != (domain.ReactionCount{Emoji: "🔥", Count: 2}) {
+		t.Fatalf("expected two fire reactions left, got %+v", response.Reactions)
+	}
+	if _, err := f.env.service.RemoveReaction(ctx, f.tournament.ID, f.message.ID, f.other, "👍"); !errors.Is(err, ErrReactionNotFound) {
+		t.Fatalf("removing a missing reaction: expected ErrReactionNotFound, got %v", err)
+	}
+
+	messages, err := f.env.service.GetMessages(ctx, f.tournament.ID, 50, 0)
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+	if len(messages) != 1 || len(messages[0].Reactions) != 1 || messages[0].Reactions[0].Count != 2 {
+		t.Fatalf("the chat should show the reaction counts, got %+v", messages)
+	}
+}
+
+func TestReactionsToADeletedMessageAreNotFound(t *testing.T) {
+	ctx := context.Background()
+	f := newChatFixture(t)
+	if err := f.env.service.DeleteMessage(ctx, f.tournament.ID, f.message.ID, f.author); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if _, err := f.env.service.AddReaction(ctx, f.tournament.ID, f.message.ID, f.other, "👍"); !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+func TestOnlyOrganizersPinMessages(t *testing.T) {
+	ctx := context.Background()
+	f := newChatFixture(t)
+
+	var notAuthorized *ErrNotAuthorized
+	if _, err := f.env.service.SetMessagePinned(ctx, f.tournament.ID, f.message.ID, f.author, true); !errors.As(err, &notAuthorized) {
+		t.Fatalf("a player pinning: expected ErrNotAuthorized, got %v", err)
+	}
+
+	response, err := f.env.service.SetMessagePinned(ctx, f.tournament.ID, f.message.ID, f.organizer, true)
+	if err != nil {
+		t.Fatalf("SetMessagePinned: %v", err)
+	}
+	if !response.IsPinned || !f.env.store.messages[f.message.ID].IsPinned {
+		t.Fatal("the message was not pinned")
+	}
+	if _, err := f.env.service.SetMessagePinned(ctx, f.tournament.ID, f.message.ID, f.organizer, false); err != nil {
+		t.Fatalf("unpin: %v", err)
+	}
+	if f.env.store.messages[f.message.ID].IsPinned {
+		t.Fatal("the message is still pinned")
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+)
+
+func TestStaleScoreUpdateIsRejected(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	semi := f.semis[0]
+	// Both organizers loaded the match before either saved
+	seen := f.env.store.matches[semi.ID].Version
+
+	first := &domain.ScoreUpdateRequest{ScoreParticipant1: 2, ScoreParticipant2: 0, Version: &seen}
+	if _, err := f.env.service.UpdateMatchScore(ctx, f.tournament.ID, semi.ID, f.organizer, first); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if got := f.env.store.matches[semi.ID].Version; got != seen+1 {
+		t.Fatalf("expected the version to move from %d to %d, got %d", seen, seen+1, got)
+	}
+
+	second := &domain.ScoreUpdateRequest{ScoreParticipant1: 0, ScoreParticipant2: 2, Version: &seen}
+	_, err := f.env.service.UpdateMatchScore(ctx, f.tournament.ID, semi.ID, f.organizer, second)
+	if !errors.Is(err, domain.ErrConcurrentModification) {
+		t.Fatalf("expected ErrConcurrentModification, got %v", err)
+	}
+	var classified domain.Error
+	if !errors.As(err, &classified) || classified.HTTPStatus() != http.StatusConflict {
+		t.Fatalf("a stale update should map to 409, got %v", err)
+	}
+	if stored := f.env.store.matches[semi.ID]; stored.ScoreParticipant1 != 2 || stored.ScoreParticipant2 != 0 {
+		t.Fatalf("the stale update overwrote the first result: %d-%d", stored.ScoreParticipant1, stored.ScoreParticipant2)
+	}
+}
+
+func TestScoreUpdateRacingAnotherWriteIsRejected(t *testing.T) {
+	f := newCorrectionFixture(t)
+	semi := f.semis[0]
+	// Another organizer saves between this update reading the match and writing it back
+	f.env.service.matchRepo.(*fakeMatchRepo).failUpdate = func(match *domain.Match) error {
+		if match.ID == semi.ID {
+			f.env.store.matches[semi.ID].Version++
+		}
+		return nil
+	}
+
+	if err := f.report(semi, 2, 1); !errors.Is(err, domain.ErrConcurrentModification) {
+		t.Fatalf("expected ErrConcurrentModification, got %v", err)
+	}
+	if len(f.env.store.outbox) != 0 {
+		t.Fatalf("a rejected update must not queue a ranking event, got %d", len(f.env.store.outbox))
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ErrNotAuthorized is returned when a user who is neither the creator nor a co-organizer
+// tries to manage a tournament
+type ErrNotAuthorized struct {
+	TournamentID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (e *ErrNotAuthorized) Error() string {
+	return fmt.Sprintf("user %v is not an organizer of tournament %v", e.UserID, e.TournamentID)
+}
+
+func (e *ErrNotAuthorized) HTTPStatus() int { return http.StatusForbidden }
+func (e *ErrNotAuthorized) Unwrap() error   { return domain.ErrForbidden }
+
+// organizerFields is the part of a tournament's custom_fields that lists extra organizers
+type organizerFields struct {
+	CoOrganizers []uuid.UUID `json:"co_organizers"`
+}
+
+// isOrganizer reports whether userID created the tournament or is listed under
+// custom_fields.co_organizers
+func isOrganizer(tournament *domain.Tournament, userID uuid.UUID) bool {
+	if tournament.CreatedBy == userID {
+		return true
+	}
+	if len(tournament.CustomFields) == 0 {
+		return false
+	}
+
+	var fields organizerFields
+	if err := json.Unmarshal(tournament.CustomFields, &fields); err != nil {
+		log.Printf("Warning: could not read co-organizers of tournament %s: %v", tournament.ID, err)
+		return false
+	}
+	for _, coOrganizer := range fields.CoOrganizers {
+		if coOrganizer == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// getManagedTournament loads a tournament and checks that userID may manage it
+func (s *tournamentService) getManagedTournament(ctx context.Context, id, userID uuid.UUID) (*domain.Tournament, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, id)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", id) {
+			return nil, &ErrTournamentNotFound{ID: id}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	if !isOrganizer(tournament, userID) {
+		return nil, &ErrNotAuthorized{TournamentID: id, UserID: userID}
+	}
+	return tournament, nil
+}
+
+// canReportMatch reports whether userID may report the result of a match between p1 and p2.
+// Organizers always may; the players themselves only while the match has no result yet.
+func canReportMatch(tournament *domain.Tournament, userID uuid.UUID, completed bool, p1, p2 *domain.Participant) bool {
+	if isOrganizer(tournament, userID) {
+		return true
+	}
+	if completed {
+		return false
+	}
+	for _, participant := range []*domain.Participant{p1, p2} {
+		if participant.UserID != nil && *participant.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
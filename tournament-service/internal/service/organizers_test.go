@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestTournamentManagementIsLimitedToOrganizers(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	creator, coOrganizer, stranger := uuid.New(), uuid.New(), uuid.New()
+	fields, _ := json.Marshal(map[string]interface{}{"co_organizers": []uuid.UUID{coOrganizer}})
+	tournament := env.tournament(creator, func(t *domain.Tournament) { t.CustomFields, t.Status = fields, domain.Draft })
+
+	mutations := map[string]func(userID uuid.UUID) error{
+		"update": func(userID uuid.UUID) error {
+			_, err := env.service.UpdateTournament(ctx, tournament.ID, userID, &domain.UpdateTournamentRequest{Description: "updated"})
+			return err
+		},
+		"status": func(userID uuid.UUID) error {
+			return env.service.UpdateTournamentStatus(ctx, tournament.ID, userID, domain.Registration)
+		},
+		"generate bracket": func(userID uuid.UUID) error {
+			return env.service.GenerateBracket(ctx, tournament.ID, userID, true)
+		},
+		"delete": func(userID uuid.UUID) error {
+			return env.service.DeleteTournament(ctx, tournament.ID, userID)
+		},
+	}
+	env.players(tournament.ID, 4)
+
+	for _, name := range []string{"update", "status", "generate bracket", "delete"} {
+		err := mutations[name](stranger)
+		var notAuthorized *ErrNotAuthorized
+		if !errors.As(err, &notAuthorized) || notAuthorized.HTTPStatus() != http.StatusForbidden || !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("%s by a stranger: expected ErrNotAuthorized (403), got %v", name, err)
+		}
+	}
+	for _, name := range []string{"update", "status", "generate bracket"} {
+		if err := mutations[name](coOrganizer); err != nil {
+			t.Errorf("%s by a co-organizer: %v", name, err)
+		}
+	}
+	if err := mutations["delete"](creator); err != nil {
+		t.Errorf("delete by the creator: %v", err)
+	}
+}
+
+func TestIsOrganizer(t *testing.T) {
+	creator, coOrganizer := uuid.New(), uuid.New()
+	fields, _ := json.Marshal(map[string]interface{}{"co_organizers": []uuid.UUID{coOrganizer}})
+
+	tests := []struct {
+		name      string
+		fields    json.RawMessage
+		userID    uuid.UUID
+		organizer bool
+	}{
+		{"creator", nil, creator, true},
+		{"co-organizer", fields, coOrganizer, true},
+		{"stranger", fields, uuid.New(), false},
+		{"unreadable custom fields", json.RawMessage(`{"co_organizers": "everyone"}`), coOrganizer, false},
+	}
+	for _, tt := range tests {
+		tournament := &domain.Tournament{ID: uuid.New(), CreatedBy: creator, CustomFields: tt.fields}
+		if got := isOrganizer(tournament, tt.userID); got != tt.organizer {
+			t.Errorf("%s: isOrganizer = %v, want %v", tt.name, got, tt.organizer)
+		}
+	}
+}
+
+func TestMatchPlayersReportOnlyTheirOwnUnplayedMatch(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 3)
+	match := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 1, MatchNumber: 1,
+		Participant1ID: &players[0].ID, Participant2ID: &players[1].ID, Status: domain.MatchPending}
+	env.store.putMatch(match)
+	report := func(userID uuid.UUID, score1, score2 int) error {
+		_, err := env.service.UpdateMatchScore(ctx, tournament.ID, match.ID, userID,
+			&domain.ScoreUpdateRequest{ScoreParticipant1: score1, ScoreParticipant2: score2})
+		return err
+	}
+
+	var notAuthorized *ErrNotAuthorized
+	if err := report(*players[2].UserID, 2, 0); !errors.As(err, &notAuthorized) {
+		t.Fatalf("a player outside the match must not report it, got %v", err)
+	}
+	if err := report(*players[1].UserID, 0, 2); err != nil {
+		t.Fatalf("a player of the match should be able to report it: %v", err)
+	}
+	if err := report(*players[0].UserID, 2, 0); !errors.As(err, &notAuthorized) {
+		t.Fatalf("players must not correct a reported result, got %v", err)
+	}
+	if err := report(organizer, 2, 0); err != nil {
+		t.Fatalf("the organizer should be able to correct the result: %v", err)
+	}
+	if winner := env.match(t, match.ID).WinnerID; winner == nil || *winner != players[0].ID {
+		t.Fatal("the organizer's correction was not applied")
+	}
+}
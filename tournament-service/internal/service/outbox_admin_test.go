@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// seedFailedEvent queues an entry that has already failed twice and is not due for an hour
+func seedFailedEvent(outbox *fakeOutbox) *domain.OutboxEntry {
+	entry := outbox.queue(uuid.New(), `{"matchId":"failed"}`)
+	outbox.update(entry.ID, func(e *domain.OutboxEntry) {
+		e.Attempts = 2
+		e.LastError = "ranking service returned status 503: unavailable"
+		e.NextAttemptAt = time.Now().Add(time.Hour)
+	})
+	return outbox.get(entry.ID)
+}
+
+func TestRetryDeliversAFailedEventBeforeItIsDue(t *testing.T) {
+	ctx := context.Background()
+	server := newRankingServer(t)
+	outbox := &fakeOutbox{}
+	failed := seedFailedEvent(outbox)
+	delivered := outbox.queue(uuid.New(), `{}`)
+	outbox.update(delivered.ID, func(e *domain.OutboxEntry) { now := time.Now(); e.SentAt = &now })
+	worker := newTestOutboxWorker(outbox, server.URL)
+
+	listed, err := worker.ListUndelivered(ctx, 50)
+	if err != nil {
+		t.Fatalf("ListUndelivered: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != failed.ID || listed[0].Attempts != 2 || !strings.Contains(listed[0].LastError, "503") {
+		t.Fatalf("expected the failed event with its attempts and reason, got %+v", listed)
+	}
+
+	entry, ok, err := worker.Retry(ctx, failed.ID)
+	if err != nil {
+		t.Fatalf("Retry: %v", err)
+	}
+	if !ok || entry.SentAt == nil || entry.Attempts != 3 {
+		t.Fatalf("expected the retried event to be delivered, got %v %+v", ok, entry)
+	}
+	if got := server.received(); len(got) != 1 || got[0] != `{"matchId":"failed"}` {
+		t.Fatalf("expected the event to be posted once, got %v", got)
+	}
+	if listed, _ := worker.ListUndelivered(ctx, 50); len(listed) != 0 {
+		t.Fatalf("nothing should be left undelivered, got %+v", listed)
+	}
+
+	if _, _, err := worker.Retry(ctx, failed.ID); !errors.Is(err, ErrOutboxEntryDelivered) || !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("retrying a delivered event: expected ErrOutboxEntryDelivered, got %v", err)
+	}
+}
+
+func TestRetryThatFailsAgainCountsTheAttempt(t *testing.T) {
+	server := newRankingServer(t)
+	server.respondWith(http.StatusBadGateway)
+	outbox := &fakeOutbox{}
+	failed := seedFailedEvent(outbox)
+
+	entry, ok, err := newTestOutboxWorker(outbox, server.URL).Retry(context.Background(), failed.ID)
+	if err != nil {
+		t.Fatalf("a failed delivery is a result, not an error: %v", err)
+	}
+	if ok || entry.SentAt != nil || entry.Attempts != 3 || !strings.Contains(entry.LastError, "502") {
+		t.Fatalf("expected a third failed attempt with the new reason, got %v %+v", ok, entry)
+	}
+	// The next automatic attempt backs off like one after the second failure
+	if wait := time.Until(entry.NextAttemptAt); wait < 3*time.Second || wait > 4*time.Second {
+		t.Fatalf("expected the next attempt after 4s, got %s", wait)
+	}
+}
+
+func TestRetryErrors(t *testing.T) {
+	ctx := context.Background()
+	outbox := &fakeOutbox{}
+	failed := seedFailedEvent(outbox)
+
+	if _, _, err := newTestOutboxWorker(outbox, newRankingServer(t).URL).Retry(ctx, uuid.New()); !errors.Is(err, ErrOutboxEntryNotFound) || !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("unknown event: expected ErrOutboxEntryNotFound, got %v", err)
+	}
+	if _, _, err := newTestOutboxWorker(outbox, "").Retry(ctx, failed.ID); !errors.Is(err, ErrRankingServiceNotConfigured) {
+		t.Fatalf("no ranking service: expected ErrRankingServiceNotConfigured, got %v", err)
+	}
+	if stored := outbox.get(failed.ID); stored.Attempts != 2 {
+		t.Fatalf("a refused retry counted an attempt: %+v", stored)
+	}
+}
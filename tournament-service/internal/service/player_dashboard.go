@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// GetPlayerActiveMatches returns every unfinished match the user is assigned to, across all
+// tournaments, grouped by tournament in order of the earliest scheduled match
+func (s *tournamentService) GetPlayerActiveMatches(
+	ctx context.Context, userID uuid.UUID,
+) ([]*domain.PlayerTournamentMatches, error) {
+	matches, err := s.matchRepo.GetActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active matches for user %s: %w", userID, err)
+	}
+
+	groups := []*domain.PlayerTournamentMatches{}
+	byTournament := make(map[uuid.UUID]*domain.PlayerTournamentMatches)
+	participants := make(map[uuid.UUID]*domain.Participant)
+	getParticipant := func(id uuid.UUID) *domain.Participant {
+		if p, ok := participants[id]; ok {
+			return p
+		}
+		p, err := s.participantRepo.GetByID(ctx, id)
+		if err != nil {
+			logging.Warnf(ctx, "GetPlayerActiveMatches - failed to get participant %s: %v", id, err)
+		}
+		participants[id] = p
+		return p
+	}
+
+	for _, match := range matches {
+		group, ok := byTournament[match.TournamentID]
+		if !ok {
+			tournament, err := s.tournamentRepo.GetByID(ctx, match.TournamentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tournament %s: %w", match.TournamentID, err)
+			}
+			group = &domain.PlayerTournamentMatches{
+				TournamentID:   tournament.ID,
+				TournamentName: tournament.Name,
+				Game:           tournament.Game,
+				Matches:        []*domain.PlayerMatch{},
+			}
+			byTournament[match.TournamentID] = group
+			groups = append(groups, group)
+		}
+
+		// Work out which side of the match is the player's
+		var own, opponent *uuid.UUID
+		if p := match.Participant1ID; p != nil {
+			if participant := getParticipant(*p); participant != nil && participant.UserID != nil && *participant.UserID == userID {
+				own, opponent = match.Participant1ID, match.Participant2ID
+			}
+		}
+		if own == nil {
+			own, opponent = match.Participant2ID, match.Participant1ID
+		}
+
+		entry := &domain.PlayerMatch{
+			Match:         toMatchResponse(match),
+			ParticipantID: *own,
+			OpponentID:    opponent,
+		}
+		if opponent != nil {
+			if participant := getParticipant(*opponent); participant != nil {
+				entry.OpponentName = participant.ParticipantName
+			}
+		}
+		group.Matches = append(group.Matches, entry)
+	}
+
+	return groups, nil
+}
+
+// GetPlayerTournaments returns the tournaments the user is registered in, optionally limited to
+// some statuses, with the user's entry and, once matches exist, their current standing
+func (s *tournamentService) GetPlayerTournaments(
+	ctx context.Context, userID uuid.UUID, statuses []domain.TournamentStatus,
+) ([]*domain.PlayerTournament, error) {
+	tournaments, err := s.tournamentRepo.ListByParticipantUser(ctx, userID, statuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournaments for user %s: %w", userID, err)
+	}
+
+	results := make([]*domain.PlayerTournament, 0, len(tournaments))
+	for _, tournament := range tournaments {
+		participants, err := s.participantRepo.ListByTournament(ctx, tournament.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get participants of tournament %s: %w", tournament.ID, err)
+		}
+
+		var own *domain.Participant
+		for _, p := range participants {
+			if p.UserID != nil && *p.UserID == userID {
+				own = p
+				break
+			}
+		}
+		if own == nil {
+			continue
+		}
+
+		entry := &domain.PlayerTournament{
+			Tournament:      toTournamentResponse(tournament, len(participants)),
+			ParticipantID:   own.ID,
+			ParticipantName: own.ParticipantName,
+			Seed:            own.Seed,
+		}
+
+		if tournament.Status == domain.InProgress || tournament.Status == domain.Completed {
+			standings, err := s.GetStandings(ctx, tournament.ID, domain.DefaultPointsConfig)
+			if err != nil {
+				logging.Warnf(ctx, "GetPlayerTournaments - failed to get standings of tournament %s: %v", tournament.ID, err)
+			}
+			for _, standing := range standings {
+				if standing.ParticipantID == own.ID {
+					entry.Standing = standing
+					break
+				}
+			}
+		}
+
+		results = append(results, entry)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestGetPlayerActiveMatchesGroupsByTournament(t *testing.T) {
+	env := newTestEnv(t)
+	first := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Name, t.Status = "First Cup", domain.InProgress })
+	second := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Name, t.Status = "Second Cup", domain.InProgress })
+	firstPlayers := env.players(first.ID, 3)
+	secondPlayers := env.players(second.ID, 2)
+	// The same user plays in both tournaments
+	userID := *firstPlayers[0].UserID
+	env.store.participants[secondPlayers[1].ID].UserID = &userID
+	env.store.participants[firstPlayers[1].ID].ParticipantName = "rival"
+
+	ready := &domain.Match{ID: uuid.New(), TournamentID: first.ID, Round: 1, MatchNumber: 1,
+		Participant1ID: &firstPlayers[0].ID, Participant2ID: &firstPlayers[1].ID, Status: domain.MatchPending}
+	done := &domain.Match{ID: uuid.New(), TournamentID: first.ID, Round: 1, MatchNumber: 2,
+		Participant1ID: &firstPlayers[0].ID, Participant2ID: &firstPlayers[2].ID, Status: domain.MatchCompleted}
+	waiting := &domain.Match{ID: uuid.New(), TournamentID: second.ID, Round: 2, MatchNumber: 1,
+		Participant2ID: &secondPlayers[1].ID, Status: domain.MatchPending}
+	for _, m := range []*domain.Match{ready, done, waiting} {
+		env.store.putMatch(m)
+	}
+
+	groups, err := env.service.GetPlayerActiveMatches(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("GetPlayerActiveMatches: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected matches from 2 tournaments, got %d", len(groups))
+	}
+
+	byName := map[string]*domain.PlayerTournamentMatches{}
+	for _, g := range groups {
+		byName[g.TournamentName] = g
+	}
+	firstGroup, secondGroup := byName["First Cup"], byName["Second Cup"]
+	if firstGroup == nil || len(firstGroup.Matches) != 1 || firstGroup.Matches[0].Match.ID != ready.ID {
+		t.Fatalf("expected only the unfinished match of the first tournament, got %+v", firstGroup)
+	}
+	if m := firstGroup.Matches[0]; m.ParticipantID != firstPlayers[0].ID || m.OpponentID == nil ||
+		*m.OpponentID != firstPlayers[1].ID || m.OpponentName != "rival" {
+		t.Fatalf("the match should be seen from the player's side, got %+v", m)
+	}
+	if secondGroup == nil || len(secondGroup.Matches) != 1 {
+		t.Fatalf("expected the waiting match of the second tournament, got %+v", secondGroup)
+	}
+	if m := secondGroup.Matches[0]; m.ParticipantID != secondPlayers[1].ID || m.OpponentID != nil || m.OpponentName != "" {
+		t.Fatalf("a match awaiting its opponent has none, got %+v", m)
+	}
+}
+
+func TestGetPlayerActiveMatchesWithoutMatches(t *testing.T) {
+	env := newTestEnv(t)
+
+	groups, err := env.service.GetPlayerActiveMatches(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("GetPlayerActiveMatches: %v", err)
+	}
+	if groups == nil || len(groups) != 0 {
+		t.Fatalf("expected an empty, non-nil list, got %#v", groups)
+	}
+}
+
+func TestGetPlayerTournamentsListsTheUsersEntries(t *testing.T) {
+	env := newTestEnv(t)
+	running := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Format, t.Status = domain.RoundRobin, domain.InProgress })
+	upcoming := env.tournament(uuid.New())
+	other := env.tournament(uuid.New())
+	runningPlayers := env.players(running.ID, 2)
+	upcomingPlayers := env.players(upcoming.ID, 2)
+	env.players(other.ID, 2)
+	userID := *runningPlayers[0].UserID
+	env.store.participants[upcomingPlayers[1].ID].UserID = &userID
+	playedMatch(env, running.ID, runningPlayers[0], runningPlayers[1], runningPlayers[0])
+
+	entries, err := env.service.GetPlayerTournaments(context.Background(), userID, nil)
+	if err != nil {
+		t.Fatalf("GetPlayerTournaments: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the two tournaments the user entered, got %d", len(entries))
+	}
+	byTournament := map[uuid.UUID]*domain.PlayerTournament{}
+	for _, entry := range entries {
+		byTournament[entry.Tournament.ID] = entry
+	}
+
+	started := byTournament[running.ID]
+	if started == nil || started.ParticipantID != runningPlayers[0].ID || started.Seed != 1 {
+		t.Fatalf("unexpected entry for the running tournament: %+v", started)
+	}
+	if started.Standing == nil || started.Standing.Rank != 1 || started.Standing.Wins != 1 {
+		t.Fatalf("expected the user's standing in the running tournament, got %+v", started.Standing)
+	}
+	notStarted := byTournament[upcoming.ID]
+	if notStarted == nil || notStarted.ParticipantID != upcomingPlayers[1].ID || notStarted.Standing != nil {
+		t.Fatalf("a tournament that has not started has no standing: %+v", notStarted)
+	}
+}
+
+func TestGetPlayerTournamentsFiltersByStatus(t *testing.T) {
+	env := newTestEnv(t)
+	running := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.InProgress })
+	upcoming := env.tournament(uuid.New())
+	players := env.players(running.ID, 1)
+	userID := *players[0].UserID
+	env.store.participants[env.players(upcoming.ID, 1)[0].ID].UserID = &userID
+
+	entries, err := env.service.GetPlayerTournaments(context.Background(), userID, []domain.TournamentStatus{domain.Registration})
+	if err != nil {
+		t.Fatalf("GetPlayerTournaments: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Tournament.ID != upcoming.ID {
+		t.Fatalf("expected only the tournament open for registration, got %+v", entries)
+	}
+
+	none, err := env.service.GetPlayerTournaments(context.Background(), uuid.New(), nil)
+	if err != nil || none == nil || len(none) != 0 {
+		t.Fatalf("expected an empty, non-nil list for a user with no entries, got %v, %v", none, err)
+	}
+}
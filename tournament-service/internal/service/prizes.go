@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ErrNoPrizePool is returned when prizes are requested for a tournament without a structured prize pool
+var ErrNoPrizePool = domain.NewError(domain.ErrNotFound, "tournament has no prize pool with placements")
+
+// ComputePrizeDistribution pays out a completed tournament's prize pool by final placement.
+// Participants sharing a place split the shares of the places they cover between them, so two
+// joint 3rd places each get half of the 3rd and 4th place shares. Amounts are rounded to cents.
+func (s *tournamentService) ComputePrizeDistribution(
+	ctx context.Context, tournamentID uuid.UUID,
+) (*domain.PrizeDistribution, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	pool, err := domain.ParsePrizePool(tournament.PrizePool)
+	if err != nil {
+		return nil, fmt.Errorf("tournament %s has an invalid prize pool: %w", tournamentID, err)
+	}
+	if pool == nil {
+		return nil, ErrNoPrizePool
+	}
+
+	results, err := s.ComputeResults(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PrizeDistribution{
+		TournamentID: tournamentID,
+		Currency:     pool.Currency,
+		Amount:       pool.Amount,
+		Payouts:      prizePayouts(pool, results.Placements),
+	}, nil
+}
+
+// prizePayouts splits pool between placements, which are ordered best first. Placements whose
+// share comes to nothing are left out.
+func prizePayouts(pool *domain.PrizePool, placements []*domain.Placement) []*domain.PrizePayout {
+	payouts := []*domain.PrizePayout{}
+	for start := 0; start < len(placements); {
+		end := start + 1
+		for end < len(placements) && placements[end].Place == placements[start].Place {
+			end++
+		}
+		tied := end - start
+		place := placements[start].Place
+		share := 0.0
+		for p := place; p < place+tied; p++ {
+			share += pool.Share(p)
+		}
+		share /= float64(tied)
+		if share > 0 {
+			amount := math.Round(pool.Amount*share) / 100 // share is a percentage; round to cents
+			for _, placement := range placements[start:end] {
+				payouts = append(payouts, &domain.PrizePayout{
+					Place:           placement.Place,
+					ParticipantID:   placement.ParticipantID,
+					ParticipantName: placement.ParticipantName,
+					UserID:          placement.UserID,
+					Percentage:      share,
+					Amount:          amount,
+				})
+			}
+		}
+		start = end
+	}
+	return payouts
+}
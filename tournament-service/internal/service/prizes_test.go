@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestPrizePayoutsSplitByPlace(t *testing.T) {
+	pool := &domain.PrizePool{Amount: 1000, Currency: "USD", Placements: map[string]float64{"1": 50, "2": 30, "3": 20}}
+	placements := []*domain.Placement{{Place: 1}, {Place: 2}, {Place: 3}, {Place: 4}}
+	for i, placement := range placements {
+		placement.ParticipantID = uuid.New()
+		placement.ParticipantName = string(rune('a' + i))
+	}
+
+	payouts := prizePayouts(pool, placements)
+	if len(payouts) != 3 {
+		t.Fatalf("only the three paid places should get a payout, got %d", len(payouts))
+	}
+	total := 0.0
+	for i, want := range []float64{500, 300, 200} {
+		if payouts[i].Place != i+1 || payouts[i].Amount != want || payouts[i].ParticipantID != placements[i].ParticipantID {
+			t.Errorf("place %d: expected %.2f, got %+v", i+1, want, payouts[i])
+		}
+		total += payouts[i].Amount
+	}
+	if total != pool.Amount {
+		t.Fatalf("the payouts should add up to the pool, got %.2f", total)
+	}
+}
+
+func TestJointPlacesShareTheirPrizes(t *testing.T) {
+	pool := &domain.PrizePool{Amount: 100, Currency: "USD", Placements: map[string]float64{"1": 60, "2": 25, "3": 10, "4": 5}}
+	payouts := prizePayouts(pool, []*domain.Placement{{Place: 1}, {Place: 2}, {Place: 3}, {Place: 3}})
+	if len(payouts) != 4 || payouts[2].Amount != 7.5 || payouts[3].Amount != 7.5 || payouts[2].Percentage != 7.5 {
+		t.Fatalf("joint 3rd places should split the 3rd and 4th place shares, got %+v %+v", payouts[2], payouts[3])
+	}
+}
+
+func TestComputePrizeDistributionOfACompletedTournament(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	f.env.store.tournaments[f.tournament.ID].PrizePool = json.RawMessage(`{"amount": 1000, "currency": "USD", "placements": {"1": 50, "2": 30, "3": 20}}`)
+	for _, match := range append(f.semis, f.final) {
+		if err := f.report(match, 2, 0); err != nil {
+			t.Fatalf("report: %v", err)
+		}
+	}
+
+	prizes, err := f.env.service.ComputePrizeDistribution(ctx, f.tournament.ID)
+	if err != nil {
+		t.Fatalf("ComputePrizeDistribution: %v", err)
+	}
+	if prizes.Currency != "USD" || prizes.Amount != 1000 || len(prizes.Payouts) != 4 {
+		t.Fatalf("expected winner, runner-up and two joint 3rd places, got %+v", prizes)
+	}
+	champion := f.env.match(t, f.final.ID).WinnerID
+	if payout := prizes.Payouts[0]; payout.Place != 1 || payout.Amount != 500 || payout.ParticipantID != *champion {
+		t.Fatalf("the champion should win 500, got %+v", payout)
+	}
+	total := 0.0
+	for _, payout := range prizes.Payouts {
+		total += payout.Amount
+	}
+	if prizes.Payouts[1].Amount != 300 || prizes.Payouts[2].Amount != 100 || total != 1000 {
+		t.Fatalf("unexpected payouts %+v adding up to %.2f", prizes.Payouts, total)
+	}
+}
+
+func TestComputePrizeDistributionWithoutAPrizePool(t *testing.T) {
+	f := newCorrectionFixture(t)
+	for _, prizePool := range []string{``, `"A trophy"`} {
+		f.env.store.tournaments[f.tournament.ID].PrizePool = json.RawMessage(prizePool)
+		if _, err := f.env.service.ComputePrizeDistribution(context.Background(), f.tournament.ID); !errors.Is(err, ErrNoPrizePool) {
+			t.Errorf("%q: expected ErrNoPrizePool, got %v", prizePool, err)
+		}
+	}
+}
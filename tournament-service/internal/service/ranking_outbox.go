@@ -0,0 +1,194 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/cliffdoyle/tournament-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrOutboxEntryNotFound is returned when retrying a notification that does not exist
+	ErrOutboxEntryNotFound = domain.NewError(domain.ErrNotFound, "ranking event not found")
+	// ErrOutboxEntryDelivered is returned when retrying a notification that was already delivered
+	ErrOutboxEntryDelivered = domain.NewError(domain.ErrConflict, "ranking event was already delivered")
+	// ErrRankingServiceNotConfigured is returned when RANKING_SERVICE_URL is not set
+	ErrRankingServiceNotConfigured = domain.NewError(domain.ErrUnavailable, "ranking service URL is not configured")
+)
+
+// RankingOutboxWorker delivers queued match results to the Ranking Service, retrying failed
+// deliveries with exponential backoff so results survive restarts and ranking outages
+type RankingOutboxWorker struct {
+	outboxRepo  repository.RankingOutboxRepository
+	rankingURL  string
+	serviceKey  string // Sent as X-Internal-Service-Key when set
+	client      *http.Client
+	interval    time.Duration
+	batchSize   int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+}
+
+// NewRankingOutboxWorker creates a worker that polls the outbox every interval and POSTs
+// due entries to rankingURL, authenticated with serviceKey if set, giving each delivery
+// timeout to complete; failures are retried after baseBackoff, doubling up to maxBackoff
+func NewRankingOutboxWorker(
+	outboxRepo repository.RankingOutboxRepository, rankingURL, serviceKey string,
+	timeout, interval, baseBackoff, maxBackoff time.Duration,
+) *RankingOutboxWorker {
+	return &RankingOutboxWorker{
+		outboxRepo:  outboxRepo,
+		rankingURL:  rankingURL,
+		serviceKey:  serviceKey,
+		client:      &http.Client{Timeout: timeout},
+		interval:    interval,
+		batchSize:   50,
+		baseBackoff: baseBackoff,
+		maxBackoff:  maxBackoff,
+	}
+}
+
+// Run delivers outbox entries until ctx is cancelled
+func (w *RankingOutboxWorker) Run(ctx context.Context) {
+	if w.rankingURL == "" {
+		log.Println("Warning: RANKING_SERVICE_URL not set. Ranking outbox entries will accumulate until it is configured.")
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.deliver(ctx)
+		}
+	}
+}
+
+func (w *RankingOutboxWorker) deliver(ctx context.Context) {
+	pending, err := w.outboxRepo.CountPending(ctx)
+	if err != nil {
+		logging.Warnf(ctx, "RankingOutboxWorker: failed to count pending entries: %v", err)
+		return
+	}
+	if pending == 0 {
+		return
+	}
+	logging.Infof(ctx, "RankingOutboxWorker: %d ranking notification(s) pending", pending)
+
+	entries, err := w.outboxRepo.ListDue(ctx, w.batchSize)
+	if err != nil {
+		logging.Warnf(ctx, "RankingOutboxWorker: failed to list due entries: %v", err)
+		return
+	}
+
+	for _, entry := range entries {
+		w.attempt(ctx, entry)
+	}
+}
+
+// attempt delivers one entry and records the outcome, scheduling the next try on failure.
+// It returns the delivery error, if any.
+func (w *RankingOutboxWorker) attempt(ctx context.Context, entry *domain.OutboxEntry) error {
+	if err := w.send(ctx, entry); err != nil {
+		retryAt := time.Now().Add(w.backoff(entry.Attempts))
+		logging.Warnf(ctx, "RankingOutboxWorker: delivery of match %s failed (attempt %d), retrying at %s: %v",
+			entry.MatchID, entry.Attempts+1, retryAt.Format(time.RFC3339), err)
+		if err := w.outboxRepo.MarkFailed(ctx, entry.ID, retryAt, err.Error()); err != nil {
+			logging.Warnf(ctx, "RankingOutboxWorker: failed to record failed delivery of %s: %v", entry.ID, err)
+		}
+		return err
+	}
+	if err := w.outboxRepo.MarkSent(ctx, entry.ID); err != nil {
+		// The ranking service ignores repeated events for a match, so a resend is harmless
+		logging.Warnf(ctx, "RankingOutboxWorker: failed to mark %s as sent: %v", entry.ID, err)
+		return nil
+	}
+	logging.Infof(ctx, "RankingOutboxWorker: delivered ranking notification for match %s", entry.MatchID)
+	return nil
+}
+
+// ListUndelivered returns up to limit notifications not delivered yet, oldest first. Entries
+// that have failed carry their attempt count and the last error.
+func (w *RankingOutboxWorker) ListUndelivered(ctx context.Context, limit int) ([]*domain.OutboxEntry, error) {
+	entries, err := w.outboxRepo.ListUnsent(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox entries: %w", err)
+	}
+	return entries, nil
+}
+
+// Retry delivers an undelivered notification now instead of waiting for its next attempt.
+// It returns the entry as stored afterwards and whether the delivery succeeded; a failed
+// attempt counts towards the entry's backoff like any other.
+func (w *RankingOutboxWorker) Retry(ctx context.Context, id uuid.UUID) (*domain.OutboxEntry, bool, error) {
+	if w.rankingURL == "" {
+		return nil, false, ErrRankingServiceNotConfigured
+	}
+	entry, err := w.outboxRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get outbox entry: %w", err)
+	}
+	if entry == nil {
+		return nil, false, ErrOutboxEntryNotFound
+	}
+	if entry.SentAt != nil {
+		return nil, false, ErrOutboxEntryDelivered
+	}
+
+	deliveryErr := w.attempt(ctx, entry)
+	updated, err := w.outboxRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get outbox entry: %w", err)
+	}
+	return updated, deliveryErr == nil, nil
+}
+
+// backoff returns the delay before retrying an entry that has failed attempts times already
+func (w *RankingOutboxWorker) backoff(attempts int) time.Duration {
+	delay := w.baseBackoff
+	for i := 0; i < attempts && delay < w.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > w.maxBackoff {
+		delay = w.maxBackoff
+	}
+	return delay
+}
+
+// send POSTs a single entry to the Ranking Service
+func (w *RankingOutboxWorker) send(ctx context.Context, entry *domain.OutboxEntry) error {
+	req, err := http.NewRequestWithContext(
+		ctx, "POST", w.rankingURL+"/rankings/match-results", bytes.NewReader(entry.Payload),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.serviceKey != "" {
+		req.Header.Set("X-Internal-Service-Key", w.serviceKey)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach ranking service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("ranking service returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// rankingServer is a stand-in Ranking Service that answers with status and records what it receives
+type rankingServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	payloads []string
+	keys     []string
+}
+
+func newRankingServer(t *testing.T) *rankingServer {
+	rs := &rankingServer{status: http.StatusOK}
+	rs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+		if r.Method != http.MethodPost || r.URL.Path != "/rankings/match-results" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		rs.payloads = append(rs.payloads, string(body))
+		rs.keys = append(rs.keys, r.Header.Get("X-Internal-Service-Key"))
+		w.WriteHeader(rs.status)
+	}))
+	t.Cleanup(rs.Close)
+	return rs
+}
+
+func (rs *rankingServer) respondWith(status int) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.status = status
+}
+
+func (rs *rankingServer) received() []string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return append([]string(nil), rs.payloads...)
+}
+
+func newTestOutboxWorker(outbox *fakeOutbox, url string) *RankingOutboxWorker {
+	return NewRankingOutboxWorker(outbox, url, "internal-key", time.Second, time.Minute, time.Second, 8*time.Second)
+}
+
+func TestOutboxWorkerDeliversDueEntries(t *testing.T) {
+	server := newRankingServer(t)
+	outbox := &fakeOutbox{}
+	due := outbox.queue(uuid.New(), `{"matchId":"due"}`)
+	later := outbox.queue(uuid.New(), `{"matchId":"later"}`)
+	outbox.update(later.ID, func(e *domain.OutboxEntry) { e.NextAttemptAt = time.Now().Add(time.Hour) })
+
+	newTestOutboxWorker(outbox, server.URL).deliver(context.Background())
+
+	if got := server.received(); len(got) != 1 || got[0] != `{"matchId":"due"}` {
+		t.Fatalf("expected only the due entry to be posted, got %v", got)
+	}
+	if server.keys[0] != "internal-key" {
+		t.Fatalf("expected the internal service key, got %q", server.keys[0])
+	}
+	if stored := outbox.get(due.ID); stored.SentAt == nil || stored.Attempts != 1 {
+		t.Fatalf("the delivered entry should be marked sent: %+v", stored)
+	}
+	if stored := outbox.get(later.ID); stored.SentAt != nil || stored.Attempts != 0 {
+		t.Fatalf("an entry that is not due yet must be left alone: %+v", stored)
+	}
+}
+
+func TestOutboxWorkerSchedulesARetryWhenDeliveryFails(t *testing.T) {
+	server := newRankingServer(t)
+	server.respondWith(http.StatusServiceUnavailable)
+	outbox := &fakeOutbox{}
+	entry := outbox.queue(uuid.New(), `{}`)
+	worker := newTestOutboxWorker(outbox, server.URL)
+
+	before := time.Now()
+	worker.deliver(context.Background())
+
+	stored := outbox.get(entry.ID)
+	if stored.SentAt != nil || stored.Attempts != 1 {
+		t.Fatalf("a failed delivery should count an attempt and stay unsent: %+v", stored)
+	}
+	if !strings.Contains(stored.LastError, "503") {
+		t.Fatalf("expected the status in the last error, got %q", stored.LastError)
+	}
+	if wait := stored.NextAttemptAt.Sub(before); wait < time.Second || wait > 2*time.Second {
+		t.Fatalf("expected the first retry after the base backoff, got %s", wait)
+	}
+
+	// Not due again yet, so the next poll leaves it alone
+	worker.deliver(context.Background())
+	if len(server.received()) != 1 {
+		t.Fatal("the entry was retried before its backoff elapsed")
+	}
+}
+
+func TestOutboxWorkerBackoffDoublesUpToTheMaximum(t *testing.T) {
+	worker := newTestOutboxWorker(&fakeOutbox{}, "")
+	for attempts, want := range map[int]time.Duration{
+		0: time.Second, 1: 2 * time.Second, 2: 4 * time.Second, 3: 8 * time.Second, 10: 8 * time.Second,
+	} {
+		if got := worker.backoff(attempts); got != want {
+			t.Errorf("after %d failures: want %s, got %s", attempts, want, got)
+		}
+	}
+}
+
+func TestUnlinkedParticipantsQueueNoRankingEvent(t *testing.T) {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 2)
+	env.store.participants[players[1].ID].UserID = nil
+	match := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 1, MatchNumber: 1,
+		Participant1ID: &players[0].ID, Participant2ID: &players[1].ID, Status: domain.MatchPending}
+	env.store.putMatch(match)
+
+	if _, err := env.service.UpdateMatchScore(context.Background(), tournament.ID, match.ID, organizer,
+		&domain.ScoreUpdateRequest{ScoreParticipant1: 1, ScoreParticipant2: 0}); err != nil {
+		t.Fatalf("UpdateMatchScore: %v", err)
+	}
+	if len(env.store.outbox) != 0 {
+		t.Fatalf("a guest participant has no ranking, yet %d event(s) were queued", len(env.store.outbox))
+	}
+	if env.match(t, match.ID).Status != domain.MatchCompleted {
+		t.Fatal("the result should still be saved")
+	}
+}
+
+func TestOutboxWorkerOmitsAnUnsetServiceKey(t *testing.T) {
+	server := newRankingServer(t)
+	outbox := &fakeOutbox{}
+	entry := outbox.queue(uuid.New(), `{"matchId":"open"}`)
+
+	NewRankingOutboxWorker(outbox, server.URL, "", time.Second, time.Minute, time.Second, 8*time.Second).deliver(context.Background())
+
+	if len(server.keys) != 1 || server.keys[0] != "" {
+		t.Fatalf("expected one delivery without a key header, got %q", server.keys)
+	}
+	if stored := outbox.get(entry.ID); stored.SentAt == nil {
+		t.Fatalf("the entry should be delivered: %+v", stored)
+	}
+}
+
+func TestOutboxWorkerRetriesARejectedServiceKey(t *testing.T) {
+	server := newRankingServer(t)
+	server.respondWith(http.StatusUnauthorized)
+	outbox := &fakeOutbox{}
+	entry := outbox.queue(uuid.New(), `{"matchId":"rejected"}`)
+
+	newTestOutboxWorker(outbox, server.URL).deliver(context.Background())
+
+	if stored := outbox.get(entry.ID); stored.SentAt != nil || stored.Attempts != 1 || !stored.NextAttemptAt.After(time.Now()) {
+		t.Fatalf("a 401 should leave the entry queued for a later attempt: %+v", stored)
+	}
+}
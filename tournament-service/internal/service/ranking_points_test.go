@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// reportedEvent reports a 2-0 win for the first player of a fresh match and decodes the
+// ranking event queued in the outbox
+func reportedEvent(t *testing.T, customFields string) RS_MatchResultEvent {
+	t.Helper()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) {
+		t.Status = domain.InProgress
+		if customFields != "" {
+			t.CustomFields = json.RawMessage(customFields)
+		}
+	})
+	players := env.players(tournament.ID, 2)
+	match := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 1, MatchNumber: 1,
+		Participant1ID: &players[0].ID, Participant2ID: &players[1].ID, Status: domain.MatchPending}
+	env.store.putMatch(match)
+
+	if _, err := env.service.UpdateMatchScore(context.Background(), tournament.ID, match.ID, organizer,
+		&domain.ScoreUpdateRequest{ScoreParticipant1: 2, ScoreParticipant2: 0}); err != nil {
+		t.Fatalf("UpdateMatchScore: %v", err)
+	}
+	if len(env.store.outbox) != 1 {
+		t.Fatalf("expected one ranking event in the outbox, got %d", len(env.store.outbox))
+	}
+	var event RS_MatchResultEvent
+	if err := json.Unmarshal(env.store.outbox[0].Payload, &event); err != nil {
+		t.Fatalf("outbox payload is not a ranking event: %v", err)
+	}
+	return event
+}
+
+func TestRankingEventCarriesConfiguredPoints(t *testing.T) {
+	event := reportedEvent(t, `{"ranking_points": {"win": 2, "loss": 1}}`)
+
+	if event.PointsWin == nil || *event.PointsWin != 2 {
+		t.Fatalf("expected pointsWin 2, got %v", event.PointsWin)
+	}
+	if event.PointsLoss == nil || *event.PointsLoss != 1 {
+		t.Fatalf("expected pointsLoss 1, got %v", event.PointsLoss)
+	}
+	// Left out, so the Ranking Service applies its default
+	if event.PointsDraw != nil {
+		t.Fatalf("expected no pointsDraw, got %d", *event.PointsDraw)
+	}
+}
+
+func TestRankingEventLeavesPointsToTheRankingService(t *testing.T) {
+	for name, customFields := range map[string]string{
+		"no custom fields":    "",
+		"unrelated fields":    `{"stream": "twitch"}`,
+		"unreadable settings": `{"ranking_points": "lots"}`,
+	} {
+		event := reportedEvent(t, customFields)
+		if event.PointsWin != nil || event.PointsDraw != nil || event.PointsLoss != nil {
+			t.Errorf("%s: expected no points in the event, got %+v", name, event)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ErrSeedingClosed is returned when reseeding a tournament that has already started
+var ErrSeedingClosed = domain.NewError(domain.ErrConflict, "cannot update seeds after tournament has started")
+
+// ErrRankingUnavailable is returned when the Ranking Service cannot provide a participant's ranking
+var ErrRankingUnavailable = domain.NewError(domain.ErrUnavailable, "ranking service is unavailable")
+
+// SeedByRanking seeds the tournament's participants 1..N by their Ranking Service points for the
+// tournament's game, highest first. Guests without a linked user seed last; ties and guests keep
+// registration order. Nothing is changed unless every ranking lookup succeeds.
+func (s *tournamentService) SeedByRanking(
+	ctx context.Context, tournamentID, userID uuid.UUID,
+) ([]*domain.ParticipantResponse, error) {
+	tournament, err := s.getManagedTournament(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Status != domain.Draft && tournament.Status != domain.Registration {
+		return nil, ErrSeedingClosed
+	}
+	if s.rankingDirectory == nil {
+		return nil, ErrRankingUnavailable
+	}
+
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	ordered, err := seedOrder(participants, SeedByRegistrationOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make(map[uuid.UUID]int, len(ordered))
+	for _, participant := range ordered {
+		if participant.UserID == nil {
+			continue
+		}
+		ranking, err := s.rankingDirectory.GetUserRanking(ctx, *participant.UserID, tournament.Game)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrRankingUnavailable, err)
+		}
+		points[participant.ID] = ranking.Points
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		iRanked := ordered[i].UserID != nil
+		jRanked := ordered[j].UserID != nil
+		if iRanked != jRanked {
+			return iRanked
+		}
+		return points[ordered[i].ID] > points[ordered[j].ID]
+	})
+
+	for i, participant := range ordered {
+		if err := s.participantRepo.UpdateSeed(ctx, participant.ID, i+1); err != nil {
+			return nil, fmt.Errorf("failed to update seed for participant %s: %w", participant.ID, err)
+		}
+		participant.Seed = i + 1
+	}
+
+	responses := make([]*domain.ParticipantResponse, len(ordered))
+	for i, participant := range ordered {
+		responses[i] = &domain.ParticipantResponse{
+			ID:              participant.ID,
+			TournamentID:    participant.TournamentID,
+			UserID:          participant.UserID,
+			ParticipantName: participant.ParticipantName,
+			Seed:            participant.Seed,
+			Status:          participant.Status,
+			IsWaitlisted:    participant.IsWaitlisted,
+			CreatedAt:       participant.CreatedAt,
+		}
+	}
+	return responses, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestSeedByRankingOrdersByPoints(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	players := env.players(tournament.ID, 4)
+	guest := &domain.Participant{TournamentID: tournament.ID, ParticipantName: "guest", Status: domain.ParticipantRegistered}
+	if err := (&fakeParticipantRepo{env.store}).Create(ctx, guest); err != nil {
+		t.Fatalf("create guest: %v", err)
+	}
+	// The guest registered first, but has no ranking to seed by
+	unseed(env, []*domain.Participant{guest, players[0], players[1], players[2], players[3]})
+	env.rankings.points[*players[0].UserID] = 10
+	env.rankings.points[*players[1].UserID] = 40
+	env.rankings.points[*players[2].UserID] = 25
+	env.rankings.points[*players[3].UserID] = 40
+
+	seeded, err := env.service.SeedByRanking(ctx, tournament.ID, organizer)
+	if err != nil {
+		t.Fatalf("SeedByRanking: %v", err)
+	}
+
+	// players[1] and players[3] tie on 40 and keep their registration order
+	want := []uuid.UUID{players[1].ID, players[3].ID, players[2].ID, players[0].ID, guest.ID}
+	if len(seeded) != len(want) {
+		t.Fatalf("expected %d participants, got %d", len(want), len(seeded))
+	}
+	for i, id := range want {
+		if seeded[i].ID != id || seeded[i].Seed != i+1 || env.store.participants[id].Seed != i+1 {
+			t.Errorf("seed %d: want %s, got %s (seed %d)", i+1, env.store.participants[id].ParticipantName, seeded[i].ParticipantName, seeded[i].Seed)
+		}
+	}
+}
+
+func TestSeedByRankingLeavesSeedsAloneOnFailure(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	players := env.players(tournament.ID, 3)
+	env.rankings.err = errors.New("connection refused")
+
+	if _, err := env.service.SeedByRanking(ctx, tournament.ID, organizer); !errors.Is(err, ErrRankingUnavailable) {
+		t.Fatalf("expected ErrRankingUnavailable, got %v", err)
+	}
+	for i, p := range players {
+		if env.store.participants[p.ID].Seed != i+1 {
+			t.Fatal("a failed ranking lookup must not change any seed")
+		}
+	}
+}
+
+func TestSeedByRankingOnlyBeforeTheStart(t *testing.T) {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+	env.players(tournament.ID, 2)
+
+	if _, err := env.service.SeedByRanking(context.Background(), tournament.ID, organizer); !errors.Is(err, ErrSeedingClosed) {
+		t.Fatalf("expected ErrSeedingClosed, got %v", err)
+	}
+}
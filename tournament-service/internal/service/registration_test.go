@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// register signs userID up for a tournament as themselves
+func register(env *testEnv, tournamentID, userID uuid.UUID) (*domain.Participant, error) {
+	return env.service.RegisterParticipant(context.Background(), tournamentID, userID, &domain.ParticipantRequest{
+		UserID: &userID, ParticipantName: "player-" + userID.String()[:8],
+	})
+}
+
+func TestRegisteringTwiceIsAConflict(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New())
+	userID := uuid.New()
+
+	if _, err := register(env, tournament.ID, userID); err != nil {
+		t.Fatalf("first registration: %v", err)
+	}
+	_, err := register(env, tournament.ID, userID)
+	if !errors.Is(err, domain.ErrAlreadyParticipant) {
+		t.Fatalf("expected ErrAlreadyParticipant, got %v", err)
+	}
+	var classified domain.Error
+	if !errors.As(err, &classified) || classified.HTTPStatus() != http.StatusConflict {
+		t.Fatalf("a duplicate registration should map to 409, got %v", err)
+	}
+}
+
+func TestRegisteringForMissingTournamentIsNotFound(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := register(env, uuid.New(), uuid.New())
+	var notFound *ErrTournamentNotFound
+	if !errors.As(err, &notFound) || notFound.HTTPStatus() != http.StatusNotFound {
+		t.Fatalf("expected ErrTournamentNotFound (404), got %v", err)
+	}
+}
+
+// registerAs signs userID up for a tournament with callerID making the request
+func registerAs(env *testEnv, tournamentID, callerID, userID uuid.UUID) error {
+	_, err := env.service.RegisterParticipant(context.Background(), tournamentID, callerID, &domain.ParticipantRequest{
+		UserID: &userID, ParticipantName: "player-" + userID.String()[:8],
+	})
+	return err
+}
+
+func withDeadline(deadline time.Time) func(*domain.Tournament) {
+	return func(t *domain.Tournament) { t.RegistrationDeadline = &deadline }
+}
+
+func TestRegistrationBeforeTheDeadline(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), withDeadline(time.Now().Add(time.Hour)))
+
+	if _, err := register(env, tournament.ID, uuid.New()); err != nil {
+		t.Fatalf("registration before the deadline: %v", err)
+	}
+	// A full tournament still waitlists registrations made in time
+	env.store.tournaments[tournament.ID].MaxParticipants = 1
+	participant, err := register(env, tournament.ID, uuid.New())
+	if err != nil || !participant.IsWaitlisted {
+		t.Fatalf("expected a waitlisted registration, got %+v (%v)", participant, err)
+	}
+}
+
+func TestRegistrationAfterTheDeadlineIsClosed(t *testing.T) {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, withDeadline(time.Now().Add(-time.Minute)))
+	player := uuid.New()
+
+	for name, callerID := range map[string]uuid.UUID{"the player": player, "an anonymous caller": uuid.Nil} {
+		err := registerAs(env, tournament.ID, callerID, player)
+		var closed *ErrRegistrationClosed
+		if !errors.As(err, &closed) || closed.HTTPStatus() != http.StatusForbidden || !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("%s: expected ErrRegistrationClosed (403), got %v", name, err)
+		}
+	}
+	if len(env.store.participants) != 0 {
+		t.Fatal("a late registration was saved")
+	}
+
+	// The organizer may still add players after the deadline
+	if err := registerAs(env, tournament.ID, organizer, player); err != nil {
+		t.Fatalf("organizer override: %v", err)
+	}
+}
+
+func TestRegistrationClosesOnceTheTournamentStarts(t *testing.T) {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+
+	// The status check applies to organizers too
+	for name, callerID := range map[string]uuid.UUID{"a player": uuid.New(), "the organizer": organizer} {
+		var closed *ErrRegistrationClosed
+		if err := registerAs(env, tournament.ID, callerID, uuid.New()); !errors.As(err, &closed) {
+			t.Errorf("%s: expected ErrRegistrationClosed, got %v", name, err)
+		}
+	}
+}
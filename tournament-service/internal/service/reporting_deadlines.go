@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// isValidDeadlinePolicy reports whether policy is one of the supported reporting deadline policies
+func isValidDeadlinePolicy(policy domain.DeadlinePolicy) bool {
+	switch policy {
+	case domain.DeadlineFlag, domain.DeadlineDoubleForfeit, domain.DeadlineCoinFlip:
+		return true
+	}
+	return false
+}
+
+// applyReportingDeadline sets a match's reporting deadline from its scheduled time and the
+// tournament's reporting window, clearing it when either is missing
+func applyReportingDeadline(match *domain.Match, tournament *domain.Tournament) {
+	if match.ScheduledTime == nil || tournament.ReportingWindowMinutes <= 0 {
+		match.ReportingDeadline = nil
+		return
+	}
+	deadline := match.ScheduledTime.Add(time.Duration(tournament.ReportingWindowMinutes) * time.Minute)
+	match.ReportingDeadline = &deadline
+}
+
+// refreshReportingDeadlines recomputes the deadline of every unfinished match after the reporting window changes
+func (s *tournamentService) refreshReportingDeadlines(ctx context.Context, tournament *domain.Tournament) error {
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournament.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get matches: %w", err)
+	}
+	for _, match := range matches {
+		if match.Status == domain.MatchCompleted || match.Status == domain.MatchCancelled {
+			continue
+		}
+		previous := match.ReportingDeadline
+		applyReportingDeadline(match, tournament)
+		if previous == nil && match.ReportingDeadline == nil {
+			continue
+		}
+		if err := s.matchRepo.Update(ctx, match); err != nil {
+			return fmt.Errorf("failed to update reporting deadline for match %s: %w", match.ID, err)
+		}
+	}
+	return nil
+}
+
+// isOverdue reports whether a match's reporting deadline has passed without a result
+func isOverdue(match *domain.Match, now time.Time) bool {
+	if match.ReportingDeadline == nil {
+		return false
+	}
+	if match.Status != domain.MatchPending && match.Status != domain.MatchInProgress {
+		return false
+	}
+	return now.After(*match.ReportingDeadline)
+}
+
+// ListOverdueMatches returns the tournament's unfinished matches whose reporting deadline has passed
+func (s *tournamentService) ListOverdueMatches(ctx context.Context, tournamentID uuid.UUID) ([]*domain.MatchResponse, error) {
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	now := time.Now()
+	overdue := []*domain.MatchResponse{}
+	for _, match := range matches {
+		if isOverdue(match, now) {
+			overdue = append(overdue, toMatchResponse(match))
+		}
+	}
+	return overdue, nil
+}
+
+// HandleOverdueMatch notifies the organizer of a missed reporting deadline and applies the
+// tournament's deadline policy to the match
+func (s *tournamentService) HandleOverdueMatch(ctx context.Context, tournamentID, matchID uuid.UUID) error {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to get tournament: %w", err)
+	}
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match %s: %w", matchID, err)
+	}
+	if match.TournamentID != tournamentID || !isOverdue(match, time.Now()) {
+		return nil
+	}
+
+	outcome := "flagged for the organizer"
+	switch tournament.ReportingDeadlinePolicy {
+	case domain.DeadlineDoubleForfeit:
+		if err := s.doubleForfeitMatch(ctx, match, "no result reported before the deadline"); err != nil {
+			return err
+		}
+		outcome = "double-forfeited"
+	case domain.DeadlineCoinFlip:
+		if match.Participant1ID == nil || match.Participant2ID == nil {
+			break
+		}
+		winnerID, loserID := *match.Participant1ID, *match.Participant2ID
+		if rand.Intn(2) == 1 {
+			winnerID, loserID = loserID, winnerID
+		}
+		if err := s.awardMatch(ctx, match, winnerID, loserID, "Coin flip: no result reported before the deadline", nil); err != nil {
+			return err
+		}
+		outcome = "decided by coin flip"
+	}
+
+	if s.userActivityService != nil {
+		entityType := domain.EntityTypeMatch
+		contextURL := fmt.Sprintf("/tournaments/%s/matches/%s", tournamentID, matchID)
+		description := fmt.Sprintf("Match %d in round %d of %s missed its reporting deadline and was %s",
+			match.MatchNumber, match.Round, tournament.Name, outcome)
+		if _, err := s.userActivityService.RecordActivity(
+			ctx, tournament.CreatedBy, domain.ActivityMatchStale, description, &matchID, &entityType, &contextURL,
+		); err != nil {
+			logging.Warnf(ctx, "HandleOverdueMatch - Failed to record MATCH_STALE for organizer U-%s: %v", tournament.CreatedBy, err)
+		}
+	}
+
+	if s.broadcastChan != nil {
+		s.broadcastChan <- domain.WebSocketMessage{
+			Type: domain.WSEventMatchDeadlinePassed,
+			Payload: domain.MatchDeadlinePassedPayload{
+				TournamentID: tournamentID,
+				MatchID:      matchID,
+				Deadline:     *match.ReportingDeadline,
+				Policy:       tournament.ReportingDeadlinePolicy,
+			},
+		}
+		logging.Infof(ctx, "Broadcasted WSEventMatchDeadlinePassed for M-%s", matchID)
+	}
+
+	return nil
+}
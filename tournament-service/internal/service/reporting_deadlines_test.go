@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// overdueMatch stores a pending match between p1 and p2 whose reporting deadline passed an hour ago
+func overdueMatch(env *testEnv, tournamentID uuid.UUID, p1, p2 *domain.Participant) *domain.Match {
+	scheduled := time.Now().Add(-2 * time.Hour)
+	deadline := scheduled.Add(time.Hour)
+	match := &domain.Match{
+		ID: uuid.New(), TournamentID: tournamentID, Round: 1, MatchNumber: 1,
+		Participant1ID: &p1.ID, Participant2ID: &p2.ID, Status: domain.MatchPending,
+		ScheduledTime: &scheduled, ReportingDeadline: &deadline,
+	}
+	env.store.putMatch(match)
+	return match
+}
+
+func TestCreateTournamentReportingDeadlinePolicy(t *testing.T) {
+	env := newTestEnv(t)
+
+	request := validCreateRequest()
+	request.ReportingWindowMinutes = 30
+	created, err := env.service.CreateTournament(context.Background(), request, uuid.New())
+	if err != nil {
+		t.Fatalf("CreateTournament: %v", err)
+	}
+	if created.ReportingDeadlinePolicy != domain.DeadlineFlag || created.ReportingWindowMinutes != 30 {
+		t.Fatalf("expected a 30 minute window with the FLAG policy, got %d/%s", created.ReportingWindowMinutes, created.ReportingDeadlinePolicy)
+	}
+
+	request = validCreateRequest()
+	request.ReportingDeadlinePolicy = "REPLAY"
+	if _, err := env.service.CreateTournament(context.Background(), request, uuid.New()); err == nil {
+		t.Fatal("an unknown deadline policy should be rejected")
+	}
+	request = validCreateRequest()
+	request.ReportingWindowMinutes = -5
+	if _, err := env.service.CreateTournament(context.Background(), request, uuid.New()); err == nil {
+		t.Fatal("a negative reporting window should be rejected")
+	}
+}
+
+func TestUpdatingReportingWindowRefreshesDeadlines(t *testing.T) {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	players := env.players(tournament.ID, 2)
+	scheduled := time.Now().Add(time.Hour).Truncate(time.Second)
+	pending := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 1, Participant1ID: &players[0].ID,
+		Participant2ID: &players[1].ID, Status: domain.MatchPending, ScheduledTime: &scheduled}
+	unscheduled := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 2, Status: domain.MatchPending}
+	env.store.putMatch(pending)
+	env.store.putMatch(unscheduled)
+
+	window := 45
+	if _, err := env.service.UpdateTournament(context.Background(), tournament.ID, organizer,
+		&domain.UpdateTournamentRequest{ReportingWindowMinutes: &window}); err != nil {
+		t.Fatalf("UpdateTournament: %v", err)
+	}
+
+	deadline := env.match(t, pending.ID).ReportingDeadline
+	if deadline == nil || !deadline.Equal(scheduled.Add(45*time.Minute)) {
+		t.Fatalf("expected the deadline 45 minutes after the scheduled time, got %v", deadline)
+	}
+	if env.match(t, unscheduled.ID).ReportingDeadline != nil {
+		t.Fatal("a match without a scheduled time has no deadline")
+	}
+}
+
+func TestListOverdueMatches(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 4)
+	overdue := overdueMatch(env, tournament.ID, players[0], players[1])
+	reported := overdueMatch(env, tournament.ID, players[2], players[3])
+	env.store.matches[reported.ID].Status = domain.MatchCompleted
+
+	matches, err := env.service.ListOverdueMatches(context.Background(), tournament.ID)
+	if err != nil {
+		t.Fatalf("ListOverdueMatches: %v", err)
+	}
+	if len(matches) != 1 || matches[0].ID != overdue.ID {
+		t.Fatalf("expected only the unreported match to be overdue, got %+v", matches)
+	}
+}
+
+func TestHandleOverdueMatchPolicies(t *testing.T) {
+	for _, tt := range []struct {
+		policy domain.DeadlinePolicy
+		check  func(t *testing.T, match *domain.Match, players []*domain.Participant)
+	}{
+		{domain.DeadlineFlag, func(t *testing.T, match *domain.Match, _ []*domain.Participant) {
+			if match.Status != domain.MatchPending {
+				t.Fatalf("FLAG should leave the match pending, got %s", match.Status)
+			}
+		}},
+		{domain.DeadlineDoubleForfeit, func(t *testing.T, match *domain.Match, _ []*domain.Participant) {
+			if match.Status != domain.MatchCancelled || match.WinnerID != nil {
+				t.Fatalf("DOUBLE_FORFEIT should cancel the match without a winner, got %s/%v", match.Status, match.WinnerID)
+			}
+		}},
+		{domain.DeadlineCoinFlip, func(t *testing.T, match *domain.Match, players []*domain.Participant) {
+			if match.Status != domain.MatchCompleted || match.WinnerID == nil ||
+				(*match.WinnerID != players[0].ID && *match.WinnerID != players[1].ID) {
+				t.Fatalf("COIN_FLIP should award the match to one of its players, got %s/%v", match.Status, match.WinnerID)
+			}
+		}},
+	} {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			env := newTestEnv(t)
+			organizer := uuid.New()
+			tournament := env.tournament(organizer, func(t *domain.Tournament) {
+				t.Status = domain.InProgress
+				t.ReportingDeadlinePolicy = tt.policy
+			})
+			players := env.players(tournament.ID, 2)
+			match := overdueMatch(env, tournament.ID, players[0], players[1])
+
+			if err := env.service.HandleOverdueMatch(context.Background(), tournament.ID, match.ID); err != nil {
+				t.Fatalf("HandleOverdueMatch: %v", err)
+			}
+			tt.check(t, env.match(t, match.ID), players)
+
+			if notices := env.activities.ofType(domain.ActivityMatchStale); len(notices) != 1 || notices[0].UserID != organizer {
+				t.Fatalf("expected the organizer to be notified once, got %+v", notices)
+			}
+			var passed int
+			for _, event := range env.drainEvents() {
+				if event.Type == domain.WSEventMatchDeadlinePassed {
+					passed++
+				}
+			}
+			if passed != 1 {
+				t.Fatalf("expected one %s event, got %d", domain.WSEventMatchDeadlinePassed, passed)
+			}
+		})
+	}
+}
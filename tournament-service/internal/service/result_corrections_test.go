@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// correctionFixture is a running four-player single-elimination bracket
+type correctionFixture struct {
+	env        *testEnv
+	tournament *domain.Tournament
+	organizer  uuid.UUID
+	semis      []*domain.Match
+	final      *domain.Match
+}
+
+func newCorrectionFixture(t *testing.T) *correctionFixture {
+	t.Helper()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	env.players(tournament.ID, 4)
+	if err := env.service.GenerateBracket(context.Background(), tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+	env.store.tournaments[tournament.ID].Status = domain.InProgress
+
+	f := &correctionFixture{env: env, tournament: tournament, organizer: organizer}
+	for _, match := range env.storedMatches(tournament.ID) {
+		if match.Round == 1 {
+			f.semis = append(f.semis, match)
+		} else {
+			f.final = match
+		}
+	}
+	if len(f.semis) != 2 || f.final == nil {
+		t.Fatalf("expected two semi-finals and a final, got %d and %v", len(f.semis), f.final)
+	}
+	return f
+}
+
+func (f *correctionFixture) report(match *domain.Match, score1, score2 int) error {
+	_, err := f.env.service.UpdateMatchScore(context.Background(), f.tournament.ID, match.ID, f.organizer,
+		&domain.ScoreUpdateRequest{ScoreParticipant1: score1, ScoreParticipant2: score2})
+	return err
+}
+
+// rankingEvents decodes the ranking events queued in the outbox, oldest first
+func (f *correctionFixture) rankingEvents(t *testing.T) []RS_MatchResultEvent {
+	t.Helper()
+	events := make([]RS_MatchResultEvent, len(f.env.store.outbox))
+	for i, entry := range f.env.store.outbox {
+		if err := json.Unmarshal(entry.Payload, &events[i]); err != nil {
+			t.Fatalf("outbox entry %d: %v", i, err)
+		}
+	}
+	return events
+}
+
+func TestCorrectingAResultSendsACorrection(t *testing.T) {
+	f := newCorrectionFixture(t)
+	semi := f.semis[0]
+
+	if err := f.report(semi, 2, 0); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if err := f.report(semi, 0, 2); err != nil {
+		t.Fatalf("correction: %v", err)
+	}
+
+	events := f.rankingEvents(t)
+	if len(events) != 2 {
+		t.Fatalf("expected a result and a correction, got %d events", len(events))
+	}
+	if events[0].Type != RS_Result || events[1].Type != RS_Correction {
+		t.Fatalf("expected RESULT then CORRECTION, got %s then %s", events[0].Type, events[1].Type)
+	}
+	corrected := events[1]
+	p2 := f.env.store.participants[*semi.Participant2ID]
+	for _, user := range corrected.Users {
+		want := RS_Loss
+		if user.UserID == *p2.UserID {
+			want = RS_Win
+		}
+		if user.Outcome != want {
+			t.Errorf("user %s: expected %s in the correction, got %s", user.UserID, want, user.Outcome)
+		}
+	}
+}
+
+func TestCorrectingAResultReplacesTheAdvancedWinner(t *testing.T) {
+	f := newCorrectionFixture(t)
+	semi := f.semis[0]
+
+	if err := f.report(semi, 2, 0); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if err := f.report(semi, 0, 2); err != nil {
+		t.Fatalf("correction: %v", err)
+	}
+
+	final := f.env.match(t, f.final.ID)
+	if sameID(final.Participant1ID, *semi.Participant1ID) || sameID(final.Participant2ID, *semi.Participant1ID) {
+		t.Fatal("the previous winner is still in the final")
+	}
+	if !sameID(final.Participant1ID, *semi.Participant2ID) && !sameID(final.Participant2ID, *semi.Participant2ID) {
+		t.Fatal("the corrected winner did not advance to the final")
+	}
+}
+
+func TestCorrectionRefusedOnceTheNextMatchStarted(t *testing.T) {
+	f := newCorrectionFixture(t)
+	for _, semi := range f.semis {
+		if err := f.report(semi, 2, 0); err != nil {
+			t.Fatalf("report: %v", err)
+		}
+	}
+	f.env.store.matches[f.final.ID].Status = domain.MatchInProgress
+
+	if err := f.report(f.semis[0], 0, 2); !errors.Is(err, ErrDownstreamMatchStarted) {
+		t.Fatalf("expected ErrDownstreamMatchStarted, got %v", err)
+	}
+	if got := f.env.match(t, f.semis[0].ID); !sameID(got.WinnerID, *f.semis[0].Participant1ID) {
+		t.Fatal("a refused correction must leave the result alone")
+	}
+	if len(f.env.store.outbox) != 2 {
+		t.Fatalf("a refused correction must not notify the Ranking Service, got %d events", len(f.env.store.outbox))
+	}
+}
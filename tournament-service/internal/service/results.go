@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ErrTournamentNotCompleted is returned when results are requested before a tournament has finished
+var ErrTournamentNotCompleted = domain.NewError(domain.ErrConflict, "tournament is not completed yet")
+
+// ComputeResults returns the final placements of a completed tournament. Elimination formats
+// are placed by how deep into the bracket each participant got before being knocked out, so the
+// champion is the only participant left standing and the runner-up lost the deciding match (the
+// bracket reset, if it was played). Round robin and Swiss tournaments are placed by their standings.
+func (s *tournamentService) ComputeResults(ctx context.Context, tournamentID uuid.UUID) (*domain.TournamentResults, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	if tournament.Status != domain.Completed {
+		return nil, ErrTournamentNotCompleted
+	}
+
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	participants = confirmedParticipants(participants)
+
+	results := &domain.TournamentResults{
+		TournamentID: tournament.ID,
+		Format:       tournament.Format,
+		Placements:   make([]*domain.Placement, 0, len(participants)),
+	}
+
+	switch tournament.Format {
+	case domain.SingleElimination, domain.DoubleElimination:
+		matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get matches: %w", err)
+		}
+		results.Placements = eliminationPlacements(tournament.Format, participants, matches)
+	default:
+		standings, err := s.GetStandings(ctx, tournamentID, domain.DefaultPointsConfig)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[uuid.UUID]*domain.Participant, len(participants))
+		for _, p := range participants {
+			byID[p.ID] = p
+		}
+		for _, standing := range standings {
+			p, ok := byID[standing.ParticipantID]
+			if !ok {
+				continue
+			}
+			results.Placements = append(results.Placements, &domain.Placement{
+				Place:           standing.Rank,
+				ParticipantID:   p.ID,
+				ParticipantName: p.ParticipantName,
+				UserID:          p.UserID,
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// eliminationLives is how many losses knock a participant out of an elimination format
+func eliminationLives(format domain.TournamentFormat) int {
+	if format == domain.DoubleElimination {
+		return 2
+	}
+	return 1
+}
+
+// participantLosses groups the matches each participant lost by participant ID
+func participantLosses(matches []*domain.Match) map[uuid.UUID][]*domain.Match {
+	losses := make(map[uuid.UUID][]*domain.Match)
+	for _, match := range matches {
+		if match.Participant1ID == nil || match.Participant2ID == nil {
+			continue
+		}
+		switch {
+		case match.Status == domain.MatchCompleted && match.WinnerID != nil:
+			loser := *match.Participant1ID
+			if loser == *match.WinnerID {
+				loser = *match.Participant2ID
+			}
+			losses[loser] = append(losses[loser], match)
+		case match.Status == domain.MatchCancelled:
+			// A double forfeit counts as a loss for both players
+			losses[*match.Participant1ID] = append(losses[*match.Participant1ID], match)
+			losses[*match.Participant2ID] = append(losses[*match.Participant2ID], match)
+		}
+	}
+	return losses
+}
+
+// eliminationPlacements places participants by the match that knocked them out: their first loss
+// in single elimination, their second in double elimination. Later stages (losers bracket, then
+// grand finals) and later rounds place higher; participants knocked out at the same point share a place.
+func eliminationPlacements(
+	format domain.TournamentFormat, participants []*domain.Participant, matches []*domain.Match,
+) []*domain.Placement {
+	livesLeft := eliminationLives(format)
+	losses := participantLosses(matches)
+
+	eliminatedBy := make(map[uuid.UUID]*domain.Match, len(participants))
+	for _, p := range participants {
+		if len(losses[p.ID]) < livesLeft {
+			continue
+		}
+		var last *domain.Match
+		for _, match := range losses[p.ID] {
+			if last == nil || laterBracketStage(match, last) {
+				last = match
+			}
+		}
+		eliminatedBy[p.ID] = last
+	}
+
+	// sameStage reports whether a and b went out at the same point; nil means still standing
+	sameStage := func(a, b *domain.Match) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return !laterBracketStage(a, b) && !laterBracketStage(b, a)
+	}
+
+	ordered := make([]*domain.Participant, len(participants))
+	copy(ordered, participants)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := eliminatedBy[ordered[i].ID], eliminatedBy[ordered[j].ID]
+		if !sameStage(a, b) {
+			return a == nil || (b != nil && laterBracketStage(a, b))
+		}
+		return strings.ToLower(ordered[i].ParticipantName) < strings.ToLower(ordered[j].ParticipantName)
+	})
+
+	placements := make([]*domain.Placement, len(ordered))
+	for i, p := range ordered {
+		place := i + 1
+		if i > 0 && sameStage(eliminatedBy[p.ID], eliminatedBy[ordered[i-1].ID]) {
+			place = placements[i-1].Place
+		}
+		placements[i] = &domain.Placement{
+			Place:           place,
+			ParticipantID:   p.ID,
+			ParticipantName: p.ParticipantName,
+			UserID:          p.UserID,
+		}
+	}
+	return placements
+}
+
+// laterBracketStage reports whether match a comes after match b: grand finals after the losers
+// bracket after the winners bracket, then by round
+func laterBracketStage(a, b *domain.Match) bool {
+	stage := func(m *domain.Match) int {
+		switch m.BracketType {
+		case domain.GrandFinals:
+			return 2
+		case domain.LosersBracket:
+			return 1
+		}
+		return 0
+	}
+	if stage(a) != stage(b) {
+		return stage(a) > stage(b)
+	}
+	return a.Round > b.Round
+}
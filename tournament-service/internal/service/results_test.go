@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// decided stores a completed match of the given bracket stage won by winner
+func decided(env *testEnv, tournamentID uuid.UUID, stage domain.BracketType, round int, winner, loser *domain.Participant) {
+	env.store.putMatch(&domain.Match{
+		ID: uuid.New(), TournamentID: tournamentID, Round: round, MatchNumber: len(env.store.matches) + 1,
+		BracketType: stage, Participant1ID: &winner.ID, Participant2ID: &loser.ID, WinnerID: &winner.ID,
+		Status: domain.MatchCompleted,
+	})
+}
+
+// assertPlacements checks the placements name by name, best first
+func assertPlacements(t *testing.T, results *domain.TournamentResults, want []*domain.Participant, places []int) {
+	t.Helper()
+	if len(results.Placements) != len(want) {
+		t.Fatalf("expected %d placements, got %d", len(want), len(results.Placements))
+	}
+	for i, placement := range results.Placements {
+		if placement.ParticipantID != want[i].ID || placement.Place != places[i] {
+			t.Errorf("position %d: want %s in place %d, got %s in place %d",
+				i+1, want[i].ParticipantName, places[i], placement.ParticipantName, placement.Place)
+		}
+	}
+}
+
+func TestSingleEliminationResults(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	if _, err := f.env.service.ComputeResults(ctx, f.tournament.ID); !errors.Is(err, ErrTournamentNotCompleted) {
+		t.Fatalf("expected ErrTournamentNotCompleted while the bracket is running, got %v", err)
+	}
+
+	for _, semi := range f.semis {
+		if err := f.report(semi, 2, 0); err != nil {
+			t.Fatalf("report semi-final: %v", err)
+		}
+	}
+	final := f.env.match(t, f.final.ID)
+	if err := f.report(final, 0, 2); err != nil {
+		t.Fatalf("report final: %v", err)
+	}
+
+	results, err := f.env.service.ComputeResults(ctx, f.tournament.ID)
+	if err != nil {
+		t.Fatalf("ComputeResults: %v", err)
+	}
+	participants := f.env.store.participants
+	semiLosers := []*domain.Participant{participants[*f.semis[0].Participant2ID], participants[*f.semis[1].Participant2ID]}
+	if semiLosers[1].ParticipantName < semiLosers[0].ParticipantName {
+		semiLosers[0], semiLosers[1] = semiLosers[1], semiLosers[0]
+	}
+	assertPlacements(t, results,
+		[]*domain.Participant{participants[*final.Participant2ID], participants[*final.Participant1ID], semiLosers[0], semiLosers[1]},
+		[]int{1, 2, 3, 3})
+}
+
+func TestDoubleEliminationResults(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		reset bool
+	}{
+		{name: "grand finals decides"},
+		{name: "bracket reset decides", reset: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			tournament := env.tournament(uuid.New(), func(t *domain.Tournament) {
+				t.Format = domain.DoubleElimination
+				t.Status = domain.Completed
+			})
+			players := env.players(tournament.ID, 4)
+			a, b, c, d := players[0], players[1], players[2], players[3]
+
+			decided(env, tournament.ID, domain.WinnersBracket, 1, a, d)
+			decided(env, tournament.ID, domain.WinnersBracket, 1, b, c)
+			decided(env, tournament.ID, domain.WinnersBracket, 2, a, b)
+			decided(env, tournament.ID, domain.LosersBracket, 1, c, d)
+			decided(env, tournament.ID, domain.LosersBracket, 2, b, c)
+			want := []*domain.Participant{a, b, c, d}
+			if tc.reset {
+				// b takes the grand finals, forcing the reset, and wins that too
+				decided(env, tournament.ID, domain.GrandFinals, 3, b, a)
+				decided(env, tournament.ID, domain.GrandFinals, 4, b, a)
+				want = []*domain.Participant{b, a, c, d}
+			} else {
+				decided(env, tournament.ID, domain.GrandFinals, 3, a, b)
+			}
+
+			results, err := env.service.ComputeResults(context.Background(), tournament.ID)
+			if err != nil {
+				t.Fatalf("ComputeResults: %v", err)
+			}
+			assertPlacements(t, results, want, []int{1, 2, 3, 4})
+		})
+	}
+}
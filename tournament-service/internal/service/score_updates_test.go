@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestScoreUpdateReturnsTheMatchAndWhereTheWinnerWent(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	semi := f.semis[0]
+
+	update, err := f.env.service.UpdateMatchScore(ctx, f.tournament.ID, semi.ID, f.organizer,
+		&domain.ScoreUpdateRequest{ScoreParticipant1: 3, ScoreParticipant2: 1})
+	if err != nil {
+		t.Fatalf("UpdateMatchScore: %v", err)
+	}
+	if update.Match.ID != semi.ID || update.Match.ScoreParticipant1 != 3 || update.Match.Status != domain.MatchCompleted {
+		t.Fatalf("expected the updated match in the response, got %+v", update.Match)
+	}
+	if len(update.UpdatedMatchIDs) != 1 || update.UpdatedMatchIDs[0] != f.final.ID {
+		t.Fatalf("expected the final to be reported as changed, got %v", update.UpdatedMatchIDs)
+	}
+}
+
+func TestScoreUpdateOfTheLastMatchChangesNothingElse(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	for _, semi := range f.semis {
+		if err := f.report(semi, 2, 0); err != nil {
+			t.Fatalf("report: %v", err)
+		}
+	}
+
+	update, err := f.env.service.UpdateMatchScore(ctx, f.tournament.ID, f.final.ID, f.organizer,
+		&domain.ScoreUpdateRequest{ScoreParticipant1: 2, ScoreParticipant2: 1})
+	if err != nil {
+		t.Fatalf("UpdateMatchScore: %v", err)
+	}
+	if update.UpdatedMatchIDs == nil || len(update.UpdatedMatchIDs) != 0 {
+		t.Fatalf("expected an empty, non-nil list, got %#v", update.UpdatedMatchIDs)
+	}
+}
+
+func TestScoreUpdateListsWinnerAndLoserMatches(t *testing.T) {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) {
+		t.Format, t.Status = domain.DoubleElimination, domain.InProgress
+	})
+	players := env.players(tournament.ID, 2)
+	winnersNext := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 2, MatchNumber: 1,
+		BracketType: domain.WinnersBracket, Status: domain.MatchPending}
+	losersNext := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 1, MatchNumber: 1,
+		BracketType: domain.LosersBracket, Status: domain.MatchPending}
+	match := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 1, MatchNumber: 1,
+		BracketType: domain.WinnersBracket, Participant1ID: &players[0].ID, Participant2ID: &players[1].ID,
+		NextMatchID: &winnersNext.ID, LoserNextMatchID: &losersNext.ID, Status: domain.MatchPending}
+	for _, m := range []*domain.Match{winnersNext, losersNext, match} {
+		env.store.putMatch(m)
+	}
+
+	update, err := env.service.UpdateMatchScore(context.Background(), tournament.ID, match.ID, organizer,
+		&domain.ScoreUpdateRequest{ScoreParticipant1: 0, ScoreParticipant2: 2})
+	if err != nil {
+		t.Fatalf("UpdateMatchScore: %v", err)
+	}
+	changed := map[uuid.UUID]bool{}
+	for _, id := range update.UpdatedMatchIDs {
+		changed[id] = true
+	}
+	if len(update.UpdatedMatchIDs) != 2 || !changed[winnersNext.ID] || !changed[losersNext.ID] {
+		t.Fatalf("expected the winners and losers bracket matches, got %v", update.UpdatedMatchIDs)
+	}
+	if got := env.match(t, losersNext.ID); !sameID(got.Participant1ID, players[0].ID) && !sameID(got.Participant2ID, players[0].ID) {
+		t.Fatal("the loser did not drop to the losers bracket")
+	}
+}
+
+func TestScoreUpdateListsTheBracketReset(t *testing.T) {
+	ctx := context.Background()
+	f := newGrandFinalsFixture(t, 0)
+	if err := f.report(f.winnersFinal.ID, 2, 0); err != nil {
+		t.Fatalf("winners final: %v", err)
+	}
+
+	// The losers finalist sits in slot 1 and wins, so the reset is played
+	update, err := f.env.service.UpdateMatchScore(ctx, f.tournament.ID, f.grandFinals.ID, f.organizer,
+		&domain.ScoreUpdateRequest{ScoreParticipant1: 3, ScoreParticipant2: 1})
+	if err != nil {
+		t.Fatalf("grand finals: %v", err)
+	}
+	if len(update.UpdatedMatchIDs) != 1 || update.UpdatedMatchIDs[0] != f.reset.ID {
+		t.Fatalf("expected the reset to be reported as changed, got %v", update.UpdatedMatchIDs)
+	}
+}
+
+func TestUniqueMatchIDsKeepsFirstOccurrences(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	got := uniqueMatchIDs([]uuid.UUID{a, b, a, c, b})
+	if len(got) != 3 || got[0] != a || got[1] != b || got[2] != c {
+		t.Fatalf("expected [a b c], got %v", got)
+	}
+}
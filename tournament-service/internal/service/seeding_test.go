@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// unseed clears the players' seeds and backdates their registrations to the given order
+func unseed(env *testEnv, registrationOrder []*domain.Participant) {
+	start := time.Now().Add(-time.Hour)
+	for i, p := range registrationOrder {
+		stored := env.store.participants[p.ID]
+		stored.Seed = 0
+		stored.CreatedAt = start.Add(time.Duration(i) * time.Minute)
+	}
+}
+
+func TestGenerateBracketSeedsUnseededPlayersByRegistration(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	players := env.players(tournament.ID, 4)
+	// Registered in the order 3, 1, 4, 2
+	registered := []*domain.Participant{players[2], players[0], players[3], players[1]}
+	unseed(env, registered)
+
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+
+	for i, p := range registered {
+		if got := env.store.participants[p.ID].Seed; got != i+1 {
+			t.Errorf("%s registered #%d but got seed %d", p.ParticipantName, i+1, got)
+		}
+	}
+}
+
+func TestGenerateBracketKeepsExplicitSeeds(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+	players := env.players(tournament.ID, 4)
+	env.store.participants[players[0].ID].Seed = 4
+	env.store.participants[players[3].ID].Seed = 1
+
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+
+	if env.store.participants[players[0].ID].Seed != 4 || env.store.participants[players[3].ID].Seed != 1 {
+		t.Fatal("explicit seeds were overwritten")
+	}
+}
+
+func TestAssignSeedsStrategies(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New())
+	players := env.players(tournament.ID, 6)
+	unseed(env, players)
+
+	if err := env.service.AssignSeeds(ctx, tournament.ID, SeedRandom); err != nil {
+		t.Fatalf("AssignSeeds(random): %v", err)
+	}
+	seen := map[int]bool{}
+	for _, p := range players {
+		seen[env.store.participants[p.ID].Seed] = true
+	}
+	for seed := 1; seed <= len(players); seed++ {
+		if !seen[seed] {
+			t.Fatalf("random seeding should hand out seeds 1..%d, missing %d", len(players), seed)
+		}
+	}
+
+	if err := env.service.AssignSeeds(ctx, tournament.ID, "alphabetical"); !errors.Is(err, ErrUnsupportedSeeding) {
+		t.Fatalf("expected ErrUnsupportedSeeding, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+)
+
+// seriesLength reads the number of games in a series from custom_fields.best_of, e.g.
+// {"best_of": 3}. It returns 0 when the tournament does not set one, in which case reported
+// games only need to produce a winner.
+func seriesLength(tournament *domain.Tournament) int {
+	if len(tournament.CustomFields) == 0 {
+		return 0
+	}
+	var fields struct {
+		BestOf int `json:"best_of"`
+	}
+	if err := json.Unmarshal(tournament.CustomFields, &fields); err != nil {
+		log.Printf("Warning: could not read series length of tournament %s: %v", tournament.ID, err)
+		return 0
+	}
+	if fields.BestOf < 0 {
+		return 0
+	}
+	return fields.BestOf
+}
+
+// seriesScore counts the games each participant won and checks that they decide a best-of-N
+// series: no game is tied, the series ends with the game that gives one side the wins it needs,
+// and no games follow it. bestOf 0 only requires one side to have won more games.
+func seriesScore(games []domain.GameScore, bestOf int) (wins1, wins2 int, err error) {
+	if bestOf > 0 && len(games) > bestOf {
+		return 0, 0, domain.NewError(domain.ErrValidation,
+			fmt.Sprintf("a best-of-%d series cannot have %d games", bestOf, len(games)))
+	}
+	required := bestOf/2 + 1
+	for i, game := range games {
+		if game.Score1 < 0 || game.Score2 < 0 {
+			return 0, 0, domain.NewError(domain.ErrValidation, fmt.Sprintf("game %d has a negative score", i+1))
+		}
+		if game.Score1 == game.Score2 {
+			return 0, 0, domain.NewError(domain.ErrValidation, fmt.Sprintf("game %d is tied", i+1))
+		}
+		if bestOf > 0 && (wins1 >= required || wins2 >= required) {
+			return 0, 0, domain.NewError(domain.ErrValidation,
+				fmt.Sprintf("game %d was played after the series was already decided", i+1))
+		}
+		if game.Score1 > game.Score2 {
+			wins1++
+		} else {
+			wins2++
+		}
+	}
+
+	if bestOf > 0 && wins1 < required && wins2 < required {
+		return 0, 0, domain.NewError(domain.ErrValidation,
+			fmt.Sprintf("neither participant has won the %d games needed to take a best-of-%d series", required, bestOf))
+	}
+	if wins1 == wins2 {
+		return 0, 0, domain.NewError(domain.ErrValidation, "the reported games do not decide a winner")
+	}
+	return wins1, wins2, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+)
+
+// reportGames reports a match of the fixture game by game
+func (f *correctionFixture) reportGames(match *domain.Match, games ...domain.GameScore) error {
+	_, err := f.env.service.UpdateMatchScore(context.Background(), f.tournament.ID, match.ID, f.organizer,
+		&domain.ScoreUpdateRequest{Games: games})
+	return err
+}
+
+func newBestOfThreeFixture(t *testing.T) *correctionFixture {
+	f := newCorrectionFixture(t)
+	f.env.store.tournaments[f.tournament.ID].CustomFields = json.RawMessage(`{"best_of": 3}`)
+	return f
+}
+
+func TestBestOfThreeSweep(t *testing.T) {
+	f := newBestOfThreeFixture(t)
+	semi := f.semis[0]
+
+	if err := f.reportGames(semi, domain.GameScore{Score1: 3, Score2: 1}, domain.GameScore{Score1: 2, Score2: 0}); err != nil {
+		t.Fatalf("report 2-0: %v", err)
+	}
+	got := f.env.match(t, semi.ID)
+	if got.ScoreParticipant1 != 2 || got.ScoreParticipant2 != 0 || got.WinnerID == nil || *got.WinnerID != *semi.Participant1ID {
+		t.Fatalf("expected participant 1 to win 2-0, got %d-%d won by %v", got.ScoreParticipant1, got.ScoreParticipant2, got.WinnerID)
+	}
+	if len(got.Games) != 2 || got.Games[0].Score1 != 3 {
+		t.Fatalf("the individual games were not kept: %+v", got.Games)
+	}
+}
+
+func TestBestOfThreeDecidedInTheThirdGame(t *testing.T) {
+	f := newBestOfThreeFixture(t)
+	semi := f.semis[0]
+
+	games := []domain.GameScore{{Score1: 1, Score2: 0}, {Score1: 0, Score2: 2}, {Score1: 1, Score2: 4}}
+	if err := f.reportGames(semi, games...); err != nil {
+		t.Fatalf("report 1-2: %v", err)
+	}
+	got := f.env.match(t, semi.ID)
+	if got.ScoreParticipant1 != 1 || got.ScoreParticipant2 != 2 || got.WinnerID == nil || *got.WinnerID != *semi.Participant2ID {
+		t.Fatalf("expected participant 2 to win 2-1, got %d-%d won by %v", got.ScoreParticipant1, got.ScoreParticipant2, got.WinnerID)
+	}
+	if len(got.Games) != 3 {
+		t.Fatalf("expected 3 recorded games, got %+v", got.Games)
+	}
+
+	// A later plain score correction drops the games
+	if err := f.report(semi, 2, 0); err != nil {
+		t.Fatalf("plain correction: %v", err)
+	}
+	if got := f.env.match(t, semi.ID); got.Games != nil {
+		t.Fatalf("a plain score should clear the games, got %+v", got.Games)
+	}
+}
+
+func TestSeriesThatDoNotDecideAWinnerAreRejected(t *testing.T) {
+	f := newBestOfThreeFixture(t)
+	semi := f.semis[0]
+
+	for name, games := range map[string][]domain.GameScore{
+		"one game":         {{Score1: 1, Score2: 0}},
+		"a tied game":      {{Score1: 1, Score2: 0}, {Score1: 2, Score2: 2}, {Score1: 1, Score2: 0}},
+		"played on":        {{Score1: 1, Score2: 0}, {Score1: 1, Score2: 0}, {Score1: 0, Score2: 1}},
+		"too many games":   {{Score1: 1, Score2: 0}, {Score1: 0, Score2: 1}, {Score1: 1, Score2: 0}, {Score1: 1, Score2: 0}},
+		"a negative score": {{Score1: -1, Score2: 0}, {Score1: 1, Score2: 0}},
+		"a one-one split":  {{Score1: 1, Score2: 0}, {Score1: 0, Score2: 1}},
+	} {
+		if err := f.reportGames(semi, games...); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+	if got := f.env.match(t, semi.ID); got.Status == domain.MatchCompleted {
+		t.Fatal("a rejected series completed the match")
+	}
+}
+
+func TestSeriesScoreWithoutALength(t *testing.T) {
+	// Without best_of, any games with a clear leader decide the match
+	wins1, wins2, err := seriesScore([]domain.GameScore{{Score1: 0, Score2: 1}, {Score1: 3, Score2: 2}, {Score1: 0, Score2: 5}, {Score1: 1, Score2: 4}}, 0)
+	if err != nil || wins1 != 1 || wins2 != 3 {
+		t.Fatalf("expected 1-3, got %d-%d (%v)", wins1, wins2, err)
+	}
+	if _, _, err := seriesScore([]domain.GameScore{{Score1: 1, Score2: 0}, {Score1: 0, Score2: 1}}, 0); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("an even split should be rejected, got %v", err)
+	}
+}
+
+func TestSeriesLength(t *testing.T) {
+	for fields, want := range map[string]int{"": 0, `{"best_of": 5}`: 5, `{"best_of": -3}`: 0, `{"other": 1}`: 0, "not json": 0} {
+		if got := seriesLength(&domain.Tournament{CustomFields: json.RawMessage(fields)}); got != want {
+			t.Errorf("%q: expected %d, got %d", fields, want, got)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// matchLastActivity returns when a match last moved: the later of its last update
+// (e.g. the second participant being placed) and its scheduled start
+func matchLastActivity(match *domain.Match) time.Time {
+	last := match.UpdatedAt
+	if match.ScheduledTime != nil && match.ScheduledTime.After(last) {
+		last = *match.ScheduledTime
+	}
+	return last
+}
+
+// isStale reports whether a match is ready to be played but has had no activity within timeout
+func isStale(match *domain.Match, timeout time.Duration, now time.Time) bool {
+	if match.Status != domain.MatchPending && match.Status != domain.MatchInProgress {
+		return false
+	}
+	if match.Participant1ID == nil || match.Participant2ID == nil {
+		return false
+	}
+	return now.Sub(matchLastActivity(match)) > timeout
+}
+
+// ListStaleMatches returns the tournament's playable matches that have gone unreported for longer than timeout
+func (s *tournamentService) ListStaleMatches(
+	ctx context.Context, tournamentID uuid.UUID, timeout time.Duration,
+) ([]*domain.StaleMatch, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	stale := []*domain.StaleMatch{}
+	if tournament.Status != domain.InProgress {
+		return stale, nil
+	}
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	now := time.Now()
+	for _, match := range matches {
+		if !isStale(match, timeout, now) {
+			continue
+		}
+		last := matchLastActivity(match)
+		stale = append(stale, &domain.StaleMatch{
+			Match:        toMatchResponse(match),
+			LastActivity: last,
+			IdleMinutes:  int(now.Sub(last).Minutes()),
+		})
+	}
+
+	return stale, nil
+}
+
+// HandleStaleMatch notifies the organizer that a match has stalled and, if autoForfeit is set,
+// awards it to the better-seeded participant so the bracket can move on
+func (s *tournamentService) HandleStaleMatch(
+	ctx context.Context, tournamentID, matchID uuid.UUID, timeout time.Duration, autoForfeit bool,
+) error {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to get tournament: %w", err)
+	}
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match %s: %w", matchID, err)
+	}
+	now := time.Now()
+	if match.TournamentID != tournamentID || !isStale(match, timeout, now) {
+		return nil
+	}
+	idleMinutes := int(now.Sub(matchLastActivity(match)).Minutes())
+
+	if autoForfeit {
+		if err := s.forfeitStaleMatch(ctx, match, timeout); err != nil {
+			return err
+		}
+	}
+
+	if s.userActivityService != nil {
+		entityType := domain.EntityTypeMatch
+		contextURL := fmt.Sprintf("/tournaments/%s/matches/%s", tournamentID, matchID)
+		description := fmt.Sprintf("Match %d in round %d of %s has had no result for %d minutes",
+			match.MatchNumber, match.Round, tournament.Name, idleMinutes)
+		if autoForfeit {
+			description += " and was auto-forfeited"
+		}
+		if _, err := s.userActivityService.RecordActivity(
+			ctx, tournament.CreatedBy, domain.ActivityMatchStale, description, &matchID, &entityType, &contextURL,
+		); err != nil {
+			logging.Warnf(ctx, "HandleStaleMatch - Failed to record MATCH_STALE for organizer U-%s: %v", tournament.CreatedBy, err)
+		}
+	}
+
+	if s.broadcastChan != nil {
+		s.broadcastChan <- domain.WebSocketMessage{
+			Type: domain.WSEventMatchStale,
+			Payload: domain.MatchStalePayload{
+				TournamentID:  tournamentID,
+				MatchID:       matchID,
+				IdleMinutes:   idleMinutes,
+				AutoForfeited: autoForfeit,
+			},
+		}
+		logging.Infof(ctx, "Broadcasted WSEventMatchStale for M-%s", matchID)
+	}
+
+	return nil
+}
+
+// forfeitStaleMatch completes an unreported match in favour of the better seed
+func (s *tournamentService) forfeitStaleMatch(ctx context.Context, match *domain.Match, timeout time.Duration) error {
+	p1, err := s.participantRepo.GetByID(ctx, *match.Participant1ID)
+	if err != nil || p1 == nil {
+		return fmt.Errorf("failed to get participant %s: %w", *match.Participant1ID, err)
+	}
+	p2, err := s.participantRepo.GetByID(ctx, *match.Participant2ID)
+	if err != nil || p2 == nil {
+		return fmt.Errorf("failed to get participant %s: %w", *match.Participant2ID, err)
+	}
+
+	// Unseeded participants (seed 0) rank below seeded ones
+	winner, loser := p1, p2
+	if p1.Seed == 0 || (p2.Seed != 0 && p2.Seed < p1.Seed) {
+		winner, loser = p2, p1
+	}
+
+	note := fmt.Sprintf("Auto-forfeit: no result reported within %s", timeout)
+	return s.awardMatch(ctx, match, winner.ID, loser.ID, note, nil)
+}
+
+// awardMatch completes a match without a played result and advances the winner, and in
+// double elimination the loser, to their next matches. A non-nil outboxEntry is queued for
+// the Ranking Service in the same transaction as the match update.
+func (s *tournamentService) awardMatch(
+	ctx context.Context, match *domain.Match, winnerID, loserID uuid.UUID, note string, outboxEntry *domain.OutboxEntry,
+) error {
+	now := time.Now()
+	match.Status = domain.MatchCompleted
+	match.WinnerID = &winnerID
+	match.LoserID = &loserID
+	match.CompletedTime = &now
+	match.MatchNotes = note
+	var err error
+	if outboxEntry != nil {
+		err = s.matchRepo.UpdateWithOutbox(ctx, match, outboxEntry)
+	} else {
+		err = s.matchRepo.Update(ctx, match)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to award match %s: %w", match.ID, err)
+	}
+	logging.Infof(ctx, "Awarded match %s to P-%s over P-%s (%s)", match.ID, winnerID, loserID, note)
+
+	for _, advance := range []struct {
+		nextMatchID   *uuid.UUID
+		participantID uuid.UUID
+	}{
+		{match.NextMatchID, winnerID},
+		{match.LoserNextMatchID, loserID},
+	} {
+		if advance.nextMatchID == nil {
+			continue
+		}
+		nextMatch, err := s.matchRepo.GetByID(ctx, *advance.nextMatchID)
+		if err != nil {
+			return fmt.Errorf("failed to get next match %s: %w", *advance.nextMatchID, err)
+		}
+		participantID := advance.participantID
+		if nextMatch.Participant1ID == nil {
+			nextMatch.Participant1ID = &participantID
+		} else if nextMatch.Participant2ID == nil {
+			nextMatch.Participant2ID = &participantID
+		} else {
+			logging.Warnf(ctx, "awardMatch - next match %s already has both participants assigned.", nextMatch.ID)
+			continue
+		}
+		if err := s.matchRepo.Update(ctx, nextMatch); err != nil {
+			return fmt.Errorf("failed to advance P-%s into match %s: %w", participantID, nextMatch.ID, err)
+		}
+		s.resolveWalkoverAfterAdvance(ctx, nextMatch)
+	}
+
+	return nil
+}
+
+// StaleMatchMonitor periodically flags stale matches in every in-progress tournament
+type StaleMatchMonitor struct {
+	service     TournamentService
+	timeout     time.Duration
+	interval    time.Duration
+	autoForfeit bool
+	flagged     map[uuid.UUID]time.Time // Match ID -> last activity already reported, to avoid repeat notices
+	overdue     map[uuid.UUID]time.Time // Match ID -> reporting deadline already handled
+}
+
+// NewStaleMatchMonitor creates a monitor that checks every interval for matches idle longer than timeout
+func NewStaleMatchMonitor(
+	service TournamentService, timeout, interval time.Duration, autoForfeit bool,
+) *StaleMatchMonitor {
+	return &StaleMatchMonitor{
+		service:     service,
+		timeout:     timeout,
+		interval:    interval,
+		autoForfeit: autoForfeit,
+		flagged:     make(map[uuid.UUID]time.Time),
+		overdue:     make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Run checks for stale matches until ctx is cancelled
+func (m *StaleMatchMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+func (m *StaleMatchMonitor) check(ctx context.Context) {
+	const pageSize = 50
+	filters := map[string]interface{}{"status": domain.InProgress}
+
+	for page := 1; ; page++ {
+		tournaments, total, err := m.service.ListTournaments(ctx, filters, page, pageSize)
+		if err != nil {
+			logging.Warnf(ctx, "StaleMatchMonitor: failed to list in-progress tournaments: %v", err)
+			return
+		}
+
+		for _, tournament := range tournaments {
+			stale, err := m.service.ListStaleMatches(ctx, tournament.ID, m.timeout)
+			if err != nil {
+				logging.Warnf(ctx, "StaleMatchMonitor: failed to check tournament %s: %v", tournament.ID, err)
+				continue
+			}
+			m.enforceDeadlines(ctx, tournament.ID)
+			for _, sm := range stale {
+				if reported, ok := m.flagged[sm.Match.ID]; ok && reported.Equal(sm.LastActivity) {
+					continue
+				}
+				if err := m.service.HandleStaleMatch(ctx, tournament.ID, sm.Match.ID, m.timeout, m.autoForfeit); err != nil {
+					logging.Warnf(ctx, "StaleMatchMonitor: failed to handle stale match %s: %v", sm.Match.ID, err)
+					continue
+				}
+				m.flagged[sm.Match.ID] = sm.LastActivity
+			}
+		}
+
+		if page*pageSize >= total {
+			return
+		}
+	}
+}
+
+// enforceDeadlines applies the tournament's deadline policy to matches whose reporting deadline has passed
+func (m *StaleMatchMonitor) enforceDeadlines(ctx context.Context, tournamentID uuid.UUID) {
+	overdue, err := m.service.ListOverdueMatches(ctx, tournamentID)
+	if err != nil {
+		logging.Warnf(ctx, "StaleMatchMonitor: failed to check reporting deadlines for tournament %s: %v", tournamentID, err)
+		return
+	}
+	for _, match := range overdue {
+		if handled, ok := m.overdue[match.ID]; ok && handled.Equal(*match.ReportingDeadline) {
+			continue
+		}
+		if err := m.service.HandleOverdueMatch(ctx, tournamentID, match.ID); err != nil {
+			logging.Warnf(ctx, "StaleMatchMonitor: failed to handle overdue match %s: %v", match.ID, err)
+			continue
+		}
+		m.overdue[match.ID] = *match.ReportingDeadline
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// idleMatch stores a pending match between p1 and p2 (either may be nil) last touched idle ago
+func idleMatch(env *testEnv, tournamentID uuid.UUID, p1, p2 *domain.Participant, idle time.Duration) *domain.Match {
+	match := &domain.Match{
+		ID: uuid.New(), TournamentID: tournamentID, Round: 1, MatchNumber: len(env.store.matches) + 1,
+		Status: domain.MatchPending, UpdatedAt: time.Now().Add(-idle),
+	}
+	if p1 != nil {
+		match.Participant1ID = &p1.ID
+	}
+	if p2 != nil {
+		match.Participant2ID = &p2.ID
+	}
+	env.store.putMatch(match)
+	return match
+}
+
+func TestListStaleMatches(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 8)
+
+	stale := idleMatch(env, tournament.ID, players[0], players[1], 2*time.Hour)
+	idleMatch(env, tournament.ID, players[2], nil, 2*time.Hour)           // Still waiting for an opponent
+	idleMatch(env, tournament.ID, players[3], players[4], 10*time.Minute) // Recently active
+	completed := idleMatch(env, tournament.ID, players[5], players[6], 3*time.Hour)
+	env.store.matches[completed.ID].Status = domain.MatchCompleted
+	scheduled := idleMatch(env, tournament.ID, players[7], players[0], 3*time.Hour)
+	later := time.Now().Add(time.Hour)
+	env.store.matches[scheduled.ID].ScheduledTime = &later
+
+	matches, err := env.service.ListStaleMatches(context.Background(), tournament.ID, time.Hour)
+	if err != nil {
+		t.Fatalf("ListStaleMatches: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Match.ID != stale.ID {
+		t.Fatalf("expected only match %s to be stale, got %+v", stale.ID, matches)
+	}
+	if matches[0].IdleMinutes < 119 || matches[0].IdleMinutes > 120 {
+		t.Fatalf("expected about 120 idle minutes, got %d", matches[0].IdleMinutes)
+	}
+}
+
+func TestListStaleMatchesOnlyForInProgressTournaments(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New())
+	players := env.players(tournament.ID, 2)
+	idleMatch(env, tournament.ID, players[0], players[1], 2*time.Hour)
+
+	matches, err := env.service.ListStaleMatches(context.Background(), tournament.ID, time.Hour)
+	if err != nil {
+		t.Fatalf("ListStaleMatches: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Fatalf("a tournament still in registration has no stale matches, got %d", len(matches))
+	}
+}
+
+func TestHandleStaleMatchAutoForfeitsToBetterSeed(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 2)
+	final := &domain.Match{ID: uuid.New(), TournamentID: tournament.ID, Round: 2, MatchNumber: 1, Status: domain.MatchPending}
+	env.store.putMatch(final)
+	// Seed 2 is participant 1, so the forfeit has to look at seeds rather than slots
+	stale := idleMatch(env, tournament.ID, players[1], players[0], 2*time.Hour)
+	env.store.matches[stale.ID].NextMatchID = &final.ID
+
+	if err := env.service.HandleStaleMatch(ctx, tournament.ID, stale.ID, time.Hour, true); err != nil {
+		t.Fatalf("HandleStaleMatch: %v", err)
+	}
+
+	forfeited := env.match(t, stale.ID)
+	if forfeited.Status != domain.MatchCompleted || forfeited.WinnerID == nil || *forfeited.WinnerID != players[0].ID {
+		t.Fatalf("expected the top seed to win by forfeit, got %+v", forfeited)
+	}
+	if next := env.match(t, final.ID); next.Participant1ID == nil || *next.Participant1ID != players[0].ID {
+		t.Fatal("the forfeit winner was not advanced")
+	}
+
+	notices := env.activities.ofType(domain.ActivityMatchStale)
+	if len(notices) != 1 || notices[0].UserID != organizer {
+		t.Fatalf("expected one MATCH_STALE activity for the organizer, got %+v", notices)
+	}
+	var flagged *domain.MatchStalePayload
+	for _, event := range env.drainEvents() {
+		if payload, ok := event.Payload.(domain.MatchStalePayload); ok && event.Type == domain.WSEventMatchStale {
+			flagged = &payload
+		}
+	}
+	if flagged == nil || !flagged.AutoForfeited || flagged.MatchID != stale.ID {
+		t.Fatalf("expected a %s event for the forfeited match, got %+v", domain.WSEventMatchStale, flagged)
+	}
+}
+
+func TestHandleStaleMatchIgnoresActiveMatch(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 2)
+	active := idleMatch(env, tournament.ID, players[0], players[1], time.Minute)
+
+	if err := env.service.HandleStaleMatch(context.Background(), tournament.ID, active.ID, time.Hour, true); err != nil {
+		t.Fatalf("HandleStaleMatch: %v", err)
+	}
+	if env.match(t, active.ID).Status != domain.MatchPending || len(env.activities.recorded) != 0 {
+		t.Fatal("a match that is not stale must be left alone")
+	}
+}
+
+func TestStaleMatchMonitorNotifiesOncePerIdlePeriod(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.InProgress })
+	players := env.players(tournament.ID, 2)
+	idleMatch(env, tournament.ID, players[0], players[1], 2*time.Hour)
+
+	monitor := NewStaleMatchMonitor(env.service, time.Hour, time.Minute, false)
+	monitor.check(ctx)
+	monitor.check(ctx)
+
+	if notices := env.activities.ofType(domain.ActivityMatchStale); len(notices) != 1 {
+		t.Fatalf("expected a single notice for an unchanged stale match, got %d", len(notices))
+	}
+}
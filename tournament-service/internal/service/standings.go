@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// GetStandings tallies completed matches into a sorted table for the tournament.
+// Matches without two participants (byes) are not counted. Participants level on points
+// are separated by the points they took from each other, then by name.
+func (s *tournamentService) GetStandings(
+	ctx context.Context, tournamentID uuid.UUID, points domain.PointsConfig,
+) ([]*domain.StandingEntry, error) {
+	if _, err := s.tournamentRepo.GetByID(ctx, tournamentID); err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	entries := make(map[uuid.UUID]*domain.StandingEntry, len(participants))
+	standings := make([]*domain.StandingEntry, 0, len(participants))
+	for _, p := range participants {
+		entry := &domain.StandingEntry{ParticipantID: p.ID, ParticipantName: p.ParticipantName}
+		entries[p.ID] = entry
+		standings = append(standings, entry)
+	}
+
+	// headToHead[a][b] is the number of points a has taken from matches against b
+	headToHead := make(map[uuid.UUID]map[uuid.UUID]int)
+	award := func(winner, loser uuid.UUID, winnerPoints int) {
+		if headToHead[winner] == nil {
+			headToHead[winner] = make(map[uuid.UUID]int)
+		}
+		headToHead[winner][loser] += winnerPoints
+	}
+
+	for _, match := range matches {
+		if match.Status != domain.MatchCompleted || match.Participant1ID == nil || match.Participant2ID == nil {
+			continue
+		}
+		p1, ok1 := entries[*match.Participant1ID]
+		p2, ok2 := entries[*match.Participant2ID]
+		if !ok1 || !ok2 {
+			continue
+		}
+		p1.Played++
+		p2.Played++
+
+		switch {
+		case match.WinnerID == nil:
+			p1.Draws++
+			p2.Draws++
+			p1.Points += points.Draw
+			p2.Points += points.Draw
+			award(p1.ParticipantID, p2.ParticipantID, points.Draw)
+			award(p2.ParticipantID, p1.ParticipantID, points.Draw)
+		case *match.WinnerID == p1.ParticipantID:
+			p1.Wins++
+			p2.Losses++
+			p1.Points += points.Win
+			p2.Points += points.Loss
+			award(p1.ParticipantID, p2.ParticipantID, points.Win)
+			award(p2.ParticipantID, p1.ParticipantID, points.Loss)
+		default:
+			p2.Wins++
+			p1.Losses++
+			p2.Points += points.Win
+			p1.Points += points.Loss
+			award(p2.ParticipantID, p1.ParticipantID, points.Win)
+			award(p1.ParticipantID, p2.ParticipantID, points.Loss)
+		}
+	}
+
+	// Head-to-head is measured within each group of participants level on points
+	tiedGroups := make(map[int][]uuid.UUID)
+	for _, entry := range standings {
+		tiedGroups[entry.Points] = append(tiedGroups[entry.Points], entry.ParticipantID)
+	}
+	headToHeadPoints := make(map[uuid.UUID]int, len(standings))
+	for _, group := range tiedGroups {
+		for _, a := range group {
+			for _, b := range group {
+				headToHeadPoints[a] += headToHead[a][b]
+			}
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if headToHeadPoints[a.ParticipantID] != headToHeadPoints[b.ParticipantID] {
+			return headToHeadPoints[a.ParticipantID] > headToHeadPoints[b.ParticipantID]
+		}
+		return strings.ToLower(a.ParticipantName) < strings.ToLower(b.ParticipantName)
+	})
+	for i, entry := range standings {
+		entry.Rank = i + 1
+	}
+
+	return standings, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// playedMatch stores a completed match; a nil winner records a draw
+func playedMatch(env *testEnv, tournamentID uuid.UUID, p1, p2 *domain.Participant, winner *domain.Participant) {
+	match := &domain.Match{
+		ID: uuid.New(), TournamentID: tournamentID, Round: 1, MatchNumber: len(env.store.matches) + 1,
+		Participant1ID: &p1.ID, Participant2ID: &p2.ID, Status: domain.MatchCompleted,
+	}
+	if winner != nil {
+		match.WinnerID = &winner.ID
+	}
+	env.store.putMatch(match)
+}
+
+func TestStandingsForCompletedRoundRobin(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Format = domain.RoundRobin })
+	players := env.players(tournament.ID, 4)
+	alice, bob, carol, dave := players[0], players[1], players[2], players[3]
+	alice.ParticipantName, bob.ParticipantName, carol.ParticipantName, dave.ParticipantName = "alice", "bob", "carol", "dave"
+	for _, p := range players {
+		env.store.participants[p.ID].ParticipantName = p.ParticipantName
+	}
+
+	playedMatch(env, tournament.ID, alice, bob, alice)
+	playedMatch(env, tournament.ID, alice, carol, alice)
+	playedMatch(env, tournament.ID, dave, alice, dave)
+	playedMatch(env, tournament.ID, bob, carol, bob)
+	playedMatch(env, tournament.ID, bob, dave, bob)
+	playedMatch(env, tournament.ID, carol, dave, carol)
+	// A round-robin bye has a single participant and must not count
+	env.store.putMatch(&domain.Match{
+		ID: uuid.New(), TournamentID: tournament.ID, Round: 4, Participant1ID: &dave.ID,
+		WinnerID: &dave.ID, Status: domain.MatchCompleted,
+	})
+
+	standings, err := env.service.GetStandings(context.Background(), tournament.ID, domain.DefaultPointsConfig)
+	if err != nil {
+		t.Fatalf("GetStandings: %v", err)
+	}
+
+	want := []struct {
+		name                 string
+		points, wins, losses int
+	}{
+		// alice and bob are level on 6; alice won their match. carol beat dave for third.
+		{"alice", 6, 2, 1},
+		{"bob", 6, 2, 1},
+		{"carol", 3, 1, 2},
+		{"dave", 3, 1, 2},
+	}
+	if len(standings) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(standings))
+	}
+	for i, w := range want {
+		got := standings[i]
+		if got.Rank != i+1 || got.ParticipantName != w.name || got.Points != w.points ||
+			got.Wins != w.wins || got.Losses != w.losses || got.Played != 3 {
+			t.Errorf("rank %d: want %s %d pts %d-%d over 3, got %+v", i+1, w.name, w.points, w.wins, w.losses, got)
+		}
+	}
+}
+
+func TestStandingsUseConfiguredPointsAndNameTieBreak(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Format = domain.RoundRobin })
+	players := env.players(tournament.ID, 3)
+	names := []string{"zed", "amy", "kim"}
+	for i, p := range players {
+		env.store.participants[p.ID].ParticipantName = names[i]
+	}
+	playedMatch(env, tournament.ID, players[0], players[1], nil)
+
+	standings, err := env.service.GetStandings(context.Background(), tournament.ID, domain.PointsConfig{Win: 2, Draw: 1})
+	if err != nil {
+		t.Fatalf("GetStandings: %v", err)
+	}
+
+	// amy and zed drew (1 point each, level head-to-head), so the name decides; kim has not played
+	order := []string{"amy", "zed", "kim"}
+	for i, name := range order {
+		if standings[i].ParticipantName != name {
+			t.Fatalf("position %d: want %s, got %s", i+1, name, standings[i].ParticipantName)
+		}
+	}
+	if standings[0].Draws != 1 || standings[0].Points != 1 {
+		t.Fatalf("a draw should be worth the configured draw points: %+v", standings[0])
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// ErrParticipantNotFound is returned when a participant does not exist or belongs to another tournament
+var ErrParticipantNotFound = domain.NewError(domain.ErrNotFound, "participant not found")
+
+// ErrParticipantEliminated is returned when substituting a knocked-out participant in a
+// tournament that sets custom_fields.reject_eliminated_substitution
+var ErrParticipantEliminated = domain.NewError(domain.ErrConflict, "participant is already eliminated and cannot be substituted")
+
+// ErrTournamentOver is returned when substituting a participant of a finished tournament
+var ErrTournamentOver = domain.NewError(domain.ErrConflict, "participants of a completed or cancelled tournament cannot be substituted")
+
+// rejectsEliminatedSubstitution reads custom_fields.reject_eliminated_substitution, which stops
+// organizers from substituting participants an elimination bracket has already knocked out
+func rejectsEliminatedSubstitution(tournament *domain.Tournament) bool {
+	if len(tournament.CustomFields) == 0 {
+		return false
+	}
+	var fields struct {
+		RejectEliminatedSubstitution bool `json:"reject_eliminated_substitution"`
+	}
+	if err := json.Unmarshal(tournament.CustomFields, &fields); err != nil {
+		log.Printf("Warning: could not read substitution settings of tournament %s: %v", tournament.ID, err)
+		return false
+	}
+	return fields.RejectEliminatedSubstitution
+}
+
+// isEliminated reports whether an elimination bracket has knocked the participant out
+func (s *tournamentService) isEliminated(
+	ctx context.Context, tournament *domain.Tournament, participantID uuid.UUID,
+) (bool, error) {
+	if tournament.Format != domain.SingleElimination && tournament.Format != domain.DoubleElimination {
+		return false, nil
+	}
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournament.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get matches: %w", err)
+	}
+	return len(participantLosses(matches)[participantID]) >= eliminationLives(tournament.Format), nil
+}
+
+// SubstituteParticipant replaces the player behind a participant, for example when someone
+// drops out mid-tournament. The participant keeps its ID, seed and status, so every match it
+// is in, played or not, now shows the replacement. Only organizers may substitute.
+func (s *tournamentService) SubstituteParticipant(
+	ctx context.Context, tournamentID, participantID, userID uuid.UUID, request *domain.SubstitutionRequest,
+) (*domain.Participant, error) {
+	tournament, err := s.getManagedTournament(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Status == domain.Completed || tournament.Status == domain.Cancelled || tournament.Status == domain.Archived {
+		return nil, ErrTournamentOver
+	}
+
+	participant, err := s.participantRepo.GetByID(ctx, participantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant: %w", err)
+	}
+	if participant == nil || participant.TournamentID != tournamentID {
+		return nil, ErrParticipantNotFound
+	}
+
+	if rejectsEliminatedSubstitution(tournament) {
+		eliminated, err := s.isEliminated(ctx, tournament, participantID)
+		if err != nil {
+			return nil, err
+		}
+		if eliminated {
+			return nil, ErrParticipantEliminated
+		}
+	}
+
+	// The replacement's account may not already hold another slot in the tournament
+	if request.UserID != nil && (participant.UserID == nil || *participant.UserID != *request.UserID) {
+		exists, err := s.participantRepo.ExistsByTournamentIDAndUserID(ctx, tournamentID, *request.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check participant: %w", err)
+		}
+		if exists {
+			return nil, domain.ErrAlreadyParticipant
+		}
+	}
+
+	previousName := participant.ParticipantName
+	participant.ParticipantName = request.ParticipantName
+	participant.UserID = request.UserID
+	participant.UpdatedAt = time.Now()
+	if err := s.participantRepo.Substitute(ctx, participant); err != nil {
+		return nil, err
+	}
+	logging.Infof(ctx, "Participant %s of tournament %s substituted: %q replaced by %q", participantID, tournamentID, previousName, request.ParticipantName)
+
+	if s.userActivityService != nil {
+		entityType := domain.EntityTypeTournament
+		contextURL := fmt.Sprintf("/tournaments/%s", tournamentID)
+		description := fmt.Sprintf("Substituted %s for %s in %s", request.ParticipantName, previousName, tournament.Name)
+		if _, err := s.userActivityService.RecordActivity(
+			ctx, userID, domain.ActivityParticipantSubstituted, description, &tournament.ID, &entityType, &contextURL,
+		); err != nil {
+			logging.Warnf(ctx, "Failed to record '%s' activity for tournament %s by user %s: %v", domain.ActivityParticipantSubstituted, tournamentID, userID, err)
+		}
+	}
+
+	return participant, nil
+}
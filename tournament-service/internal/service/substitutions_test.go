@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestSubstitutionKeepsTheBracketSlot(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	semi := f.semis[0]
+	if err := f.report(semi, 2, 0); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	winnerID := *semi.Participant1ID
+	seed := f.env.store.participants[winnerID].Seed
+	replacement := uuid.New()
+
+	substituted, err := f.env.service.SubstituteParticipant(ctx, f.tournament.ID, winnerID, f.organizer,
+		&domain.SubstitutionRequest{ParticipantName: "Stand-in", UserID: &replacement})
+	if err != nil {
+		t.Fatalf("SubstituteParticipant: %v", err)
+	}
+	if substituted.ID != winnerID || substituted.Seed != seed || substituted.UserID == nil || *substituted.UserID != replacement {
+		t.Fatalf("unexpected substitute %+v", substituted)
+	}
+	stored := f.env.store.participants[winnerID]
+	if stored.ParticipantName != "Stand-in" || stored.Seed != seed {
+		t.Fatalf("the participant row was not updated in place: %+v", stored)
+	}
+
+	// Both the played semi and the final still point at the same participant
+	if got := f.env.match(t, semi.ID); !sameID(got.WinnerID, winnerID) || !sameID(got.Participant1ID, winnerID) {
+		t.Fatalf("the semi lost its reference to the participant: %+v", got)
+	}
+	final := f.env.match(t, f.final.ID)
+	if !sameID(final.Participant1ID, winnerID) && !sameID(final.Participant2ID, winnerID) {
+		t.Fatal("the final lost its reference to the participant")
+	}
+
+	if activities := f.env.activities.ofType(domain.ActivityParticipantSubstituted); len(activities) != 1 || activities[0].UserID != f.organizer {
+		t.Fatalf("expected one substitution activity by the organizer, got %+v", activities)
+	}
+}
+
+func TestSubstitutingAnEliminatedParticipant(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	if err := f.report(f.semis[0], 2, 0); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	loser := *f.semis[0].Participant2ID
+	request := &domain.SubstitutionRequest{ParticipantName: "Late sub"}
+
+	f.env.store.tournaments[f.tournament.ID].CustomFields = json.RawMessage(`{"reject_eliminated_substitution": true}`)
+	if _, err := f.env.service.SubstituteParticipant(ctx, f.tournament.ID, loser, f.organizer, request); !errors.Is(err, ErrParticipantEliminated) {
+		t.Fatalf("expected ErrParticipantEliminated, got %v", err)
+	}
+	// Players still in the bracket can be substituted
+	if _, err := f.env.service.SubstituteParticipant(ctx, f.tournament.ID, *f.semis[1].Participant1ID, f.organizer, request); err != nil {
+		t.Fatalf("substituting an active player: %v", err)
+	}
+
+	f.env.store.tournaments[f.tournament.ID].CustomFields = nil
+	if _, err := f.env.service.SubstituteParticipant(ctx, f.tournament.ID, loser, f.organizer, request); err != nil {
+		t.Fatalf("eliminated players may be substituted unless configured otherwise: %v", err)
+	}
+}
+
+func TestSubstitutionErrors(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	participantID := *f.semis[0].Participant1ID
+	request := &domain.SubstitutionRequest{ParticipantName: "Stand-in"}
+
+	var notAuthorized *ErrNotAuthorized
+	if _, err := f.env.service.SubstituteParticipant(ctx, f.tournament.ID, participantID, uuid.New(), request); !errors.As(err, &notAuthorized) {
+		t.Fatalf("a non-organizer: expected ErrNotAuthorized, got %v", err)
+	}
+	if _, err := f.env.service.SubstituteParticipant(ctx, f.tournament.ID, uuid.New(), f.organizer, request); !errors.Is(err, ErrParticipantNotFound) {
+		t.Fatalf("a missing participant: expected ErrParticipantNotFound, got %v", err)
+	}
+	other := f.env.tournament(f.organizer)
+	if _, err := f.env.service.SubstituteParticipant(ctx, other.ID, participantID, f.organizer, request); !errors.Is(err, ErrParticipantNotFound) {
+		t.Fatalf("another tournament's participant: expected ErrParticipantNotFound, got %v", err)
+	}
+
+	// The replacement may not already hold another slot
+	taken := *f.env.store.participants[*f.semis[1].Participant1ID].UserID
+	if _, err := f.env.service.SubstituteParticipant(ctx, f.tournament.ID, participantID, f.organizer,
+		&domain.SubstitutionRequest{ParticipantName: "Twice", UserID: &taken}); !errors.Is(err, domain.ErrAlreadyParticipant) {
+		t.Fatalf("a registered replacement: expected ErrAlreadyParticipant, got %v", err)
+	}
+
+	f.env.store.tournaments[f.tournament.ID].Status = domain.Completed
+	if _, err := f.env.service.SubstituteParticipant(ctx, f.tournament.ID, participantID, f.organizer, request); !errors.Is(err, ErrTournamentOver) {
+		t.Fatalf("a completed tournament: expected ErrTournamentOver, got %v", err)
+	}
+	if name := f.env.store.participants[participantID].ParticipantName; name == "Stand-in" || name == "Twice" {
+		t.Fatalf("a refused substitution renamed the participant to %q", name)
+	}
+}
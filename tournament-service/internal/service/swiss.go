@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+	"github.com/google/uuid"
+)
+
+// swissPairingBudget caps how many partial pairings pairSwissPlayers tries before giving up on
+// avoiding rematches, since the backtracking search is exponential in the worst case
+const swissPairingBudget = 10000
+
+// swissStanding tracks a participant's record while pairing a Swiss round
+type swissStanding struct {
+	participant *domain.Participant
+	wins        int
+	hadBye      bool
+	opponents   map[uuid.UUID]bool
+}
+
+// GenerateNextSwissRound pairs the next Swiss round from the current standings.
+// Players are grouped by wins, paired within their group where possible without rematches,
+// and the lowest-ranked player who has not yet had a bye receives one if the count is odd.
+// Only the tournament's organizers can pair a round.
+func (s *tournamentService) GenerateNextSwissRound(
+	ctx context.Context, tournamentID, userID uuid.UUID,
+) ([]*domain.MatchResponse, error) {
+	tournament, err := s.getManagedTournament(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Format != domain.Swiss {
+		return nil, domain.NewError(domain.ErrValidation,
+			fmt.Sprintf("tournament format is %s, not %s", tournament.Format, domain.Swiss))
+	}
+
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	participants = confirmedParticipants(participants)
+	if len(participants) < 2 {
+		return nil, domain.NewError(domain.ErrConflict, "need at least 2 participants to pair a Swiss round")
+	}
+
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil, domain.NewError(domain.ErrConflict, "bracket has not been generated yet")
+	}
+
+	// The next round is the first one made only of empty placeholders; otherwise a new round is appended
+	nextRound := 0
+	maxRound := 0
+	maxMatchNumber := 0
+	roundHasPlayers := make(map[int]bool)
+	for _, match := range matches {
+		if match.Round > maxRound {
+			maxRound = match.Round
+		}
+		if match.MatchNumber > maxMatchNumber {
+			maxMatchNumber = match.MatchNumber
+		}
+		if match.Participant1ID != nil || match.Participant2ID != nil {
+			roundHasPlayers[match.Round] = true
+		}
+	}
+	for round := 1; round <= maxRound; round++ {
+		if !roundHasPlayers[round] {
+			nextRound = round
+			break
+		}
+	}
+	if nextRound == 0 {
+		nextRound = maxRound + 1
+	}
+
+	// Build standings from completed matches, refusing to pair while earlier rounds are still open
+	standings := make(map[uuid.UUID]*swissStanding, len(participants))
+	for _, p := range participants {
+		standings[p.ID] = &swissStanding{participant: p, opponents: make(map[uuid.UUID]bool)}
+	}
+	var placeholders []*domain.Match
+	for _, match := range matches {
+		if match.Round == nextRound {
+			placeholders = append(placeholders, match)
+			continue
+		}
+		if match.Round > nextRound {
+			continue
+		}
+		if match.Status != domain.MatchCompleted {
+			return nil, domain.NewError(domain.ErrConflict, fmt.Sprintf("round %d still has unfinished matches", match.Round))
+		}
+		if match.Participant1ID != nil && match.Participant2ID != nil {
+			if st, ok := standings[*match.Participant1ID]; ok {
+				st.opponents[*match.Participant2ID] = true
+			}
+			if st, ok := standings[*match.Participant2ID]; ok {
+				st.opponents[*match.Participant1ID] = true
+			}
+		} else if match.WinnerID != nil {
+			if st, ok := standings[*match.WinnerID]; ok {
+				st.hadBye = true
+			}
+		}
+		if match.WinnerID != nil {
+			if st, ok := standings[*match.WinnerID]; ok {
+				st.wins++
+			}
+		}
+	}
+
+	ranked := make([]*swissStanding, 0, len(standings))
+	for _, p := range participants {
+		ranked = append(ranked, standings[p.ID])
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].wins != ranked[j].wins {
+			return ranked[i].wins > ranked[j].wins
+		}
+		return ranked[i].participant.Seed < ranked[j].participant.Seed
+	})
+
+	// Give the bye to the lowest-ranked player who has not had one yet
+	var byePlayer *swissStanding
+	if len(ranked)%2 != 0 {
+		byeIndex := len(ranked) - 1
+		for i := len(ranked) - 1; i >= 0; i-- {
+			if !ranked[i].hadBye {
+				byeIndex = i
+				break
+			}
+		}
+		byePlayer = ranked[byeIndex]
+		ranked = append(ranked[:byeIndex], ranked[byeIndex+1:]...)
+	}
+
+	budget := swissPairingBudget
+	pairs, ok := pairSwissPlayers(ranked, false, &budget)
+	if !ok {
+		logging.Warnf(ctx, "Swiss round %d for tournament %s cannot avoid rematches; allowing them", nextRound, tournamentID)
+		// With rematches allowed every player takes the next one in order, so the budget is never reached
+		budget = swissPairingBudget
+		pairs, _ = pairSwissPlayers(ranked, true, &budget)
+	}
+
+	sort.Slice(placeholders, func(i, j int) bool {
+		return placeholders[i].MatchNumber < placeholders[j].MatchNumber
+	})
+	nextSlot := func() (*domain.Match, bool) {
+		if len(placeholders) > 0 {
+			match := placeholders[0]
+			placeholders = placeholders[1:]
+			return match, true
+		}
+		maxMatchNumber++
+		return &domain.Match{
+			ID:           uuid.New(),
+			TournamentID: tournamentID,
+			Round:        nextRound,
+			MatchNumber:  maxMatchNumber,
+			Status:       domain.MatchPending,
+		}, false
+	}
+	// The whole round is written at once so a failure part way leaves no half-paired round behind
+	var created, updated []*domain.Match
+	save := func(match *domain.Match, existing bool) {
+		if existing {
+			updated = append(updated, match)
+		} else {
+			created = append(created, match)
+		}
+	}
+
+	for _, pair := range pairs {
+		match, existing := nextSlot()
+		match.Participant1ID = &pair[0].participant.ID
+		match.Participant2ID = &pair[1].participant.ID
+		save(match, existing)
+	}
+	if byePlayer != nil {
+		match, existing := nextSlot()
+		match.Participant1ID = &byePlayer.participant.ID
+		completeByes(ctx, []*domain.Match{match})
+		save(match, existing)
+	}
+
+	// Drop any placeholders left over, e.g. after participants were removed
+	removed := make([]uuid.UUID, 0, len(placeholders))
+	for _, unused := range placeholders {
+		removed = append(removed, unused.ID)
+	}
+	if err := s.matchRepo.SaveRound(ctx, created, updated, removed); err != nil {
+		return nil, fmt.Errorf("failed to save Swiss round %d: %w", nextRound, err)
+	}
+
+	logging.Infof(ctx, "Paired Swiss round %d for tournament %s: %d matches", nextRound, tournamentID, len(pairs))
+	return s.GetMatchesByRound(ctx, tournamentID, nextRound)
+}
+
+// pairSwissPlayers pairs players in ranked order, backtracking so that each player meets the
+// highest-ranked available opponent they have not played yet. If allowRematches is false and
+// no such pairing exists, it reports failure. Each call spends one unit of budget, and the search
+// also fails once the budget is used up.
+func pairSwissPlayers(players []*swissStanding, allowRematches bool, budget *int) ([][2]*swissStanding, bool) {
+	if len(players) == 0 {
+		return nil, true
+	}
+	if *budget <= 0 {
+		return nil, false
+	}
+	*budget--
+	first := players[0]
+	for i := 1; i < len(players); i++ {
+		opponent := players[i]
+		if !allowRematches && first.opponents[opponent.participant.ID] {
+			continue
+		}
+		rest := make([]*swissStanding, 0, len(players)-2)
+		rest = append(rest, players[1:i]...)
+		rest = append(rest, players[i+1:]...)
+		if pairs, ok := pairSwissPlayers(rest, allowRematches, budget); ok {
+			return append([][2]*swissStanding{{first, opponent}}, pairs...), true
+		}
+	}
+	return nil, false
+}
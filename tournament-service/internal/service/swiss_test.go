@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// newSwissTournament stores a Swiss tournament with count players and its generated first round
+func newSwissTournament(t *testing.T, env *testEnv, count int) (*domain.Tournament, uuid.UUID) {
+	t.Helper()
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Format = domain.Swiss })
+	env.players(tournament.ID, count)
+	if err := env.service.GenerateBracket(context.Background(), tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+	return tournament, organizer
+}
+
+// finishRound completes every open match of a round with the first participant winning
+func finishRound(t *testing.T, env *testEnv, tournamentID uuid.UUID, round int) {
+	t.Helper()
+	for _, match := range env.storedMatches(tournamentID) {
+		if match.Round != round || match.Status == domain.MatchCompleted {
+			continue
+		}
+		if match.Participant1ID == nil || match.Participant2ID == nil {
+			t.Fatalf("round %d match %d is not paired", round, match.MatchNumber)
+		}
+		match.Status = domain.MatchCompleted
+		match.WinnerID, match.LoserID = match.Participant1ID, match.Participant2ID
+		env.store.putMatch(match)
+	}
+}
+
+// assertNoRematches fails if any two participants met more than once
+func assertNoRematches(t *testing.T, matches []*domain.Match) {
+	t.Helper()
+	met := map[[2]uuid.UUID]int{}
+	for _, match := range matches {
+		if match.Participant1ID == nil || match.Participant2ID == nil {
+			continue
+		}
+		a, b := *match.Participant1ID, *match.Participant2ID
+		if b.String() < a.String() {
+			a, b = b, a
+		}
+		met[[2]uuid.UUID{a, b}]++
+		if met[[2]uuid.UUID{a, b}] > 1 {
+			t.Fatalf("players %s and %s met twice (round %d)", a, b, match.Round)
+		}
+	}
+}
+
+func TestSwissRoundsPairByRecordWithoutRematches(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament, organizer := newSwissTournament(t, env, 8)
+
+	finishRound(t, env, tournament.ID, 1)
+	roundOneWinners := map[uuid.UUID]bool{}
+	for _, match := range env.storedMatches(tournament.ID) {
+		if match.Round == 1 {
+			roundOneWinners[*match.WinnerID] = true
+		}
+	}
+
+	round2, err := env.service.GenerateNextSwissRound(ctx, tournament.ID, organizer)
+	if err != nil {
+		t.Fatalf("GenerateNextSwissRound (round 2): %v", err)
+	}
+	if len(round2) != 4 {
+		t.Fatalf("expected 4 round-2 matches, got %d", len(round2))
+	}
+	for _, match := range round2 {
+		if match.Round != 2 || match.Participant1ID == nil || match.Participant2ID == nil {
+			t.Fatalf("round-2 match is not fully paired: %+v", match)
+		}
+		// 1-0 players meet 1-0 players, 0-1 players meet 0-1 players
+		if roundOneWinners[*match.Participant1ID] != roundOneWinners[*match.Participant2ID] {
+			t.Fatal("round 2 paired players from different score groups")
+		}
+	}
+
+	finishRound(t, env, tournament.ID, 2)
+	if _, err := env.service.GenerateNextSwissRound(ctx, tournament.ID, organizer); err != nil {
+		t.Fatalf("GenerateNextSwissRound (round 3): %v", err)
+	}
+	assertNoRematches(t, env.storedMatches(tournament.ID))
+}
+
+func TestSwissOddPlayerCountGivesOneBye(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament, organizer := newSwissTournament(t, env, 5)
+
+	finishRound(t, env, tournament.ID, 1)
+	round2, err := env.service.GenerateNextSwissRound(ctx, tournament.ID, organizer)
+	if err != nil {
+		t.Fatalf("GenerateNextSwissRound: %v", err)
+	}
+
+	byes := 0
+	for _, match := range round2 {
+		if match.Participant2ID == nil {
+			byes++
+			if match.Status != domain.MatchCompleted || match.WinnerID == nil {
+				t.Fatal("the round-2 bye should be completed in the player's favour")
+			}
+		}
+	}
+	if byes != 1 {
+		t.Fatalf("expected exactly one bye in round 2, got %d", byes)
+	}
+}
+
+func TestSwissNextRoundRequiresOrganizerAndFinishedRound(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament, organizer := newSwissTournament(t, env, 4)
+
+	var notAuthorized *ErrNotAuthorized
+	if _, err := env.service.GenerateNextSwissRound(ctx, tournament.ID, uuid.New()); !errors.As(err, &notAuthorized) {
+		t.Fatalf("expected ErrNotAuthorized for a non-organizer, got %v", err)
+	}
+	if _, err := env.service.GenerateNextSwissRound(ctx, tournament.ID, organizer); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("expected a conflict while round 1 is unfinished, got %v", err)
+	}
+}
+
+func TestSwissRoundIsSavedAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament, organizer := newSwissTournament(t, env, 8)
+	finishRound(t, env, tournament.ID, 1)
+
+	// The third pairing fails to save after two have been written
+	saveErr := errors.New("connection lost")
+	writes := 0
+	repo := env.service.matchRepo.(*fakeMatchRepo)
+	repo.failUpdate = func(match *domain.Match) error {
+		if writes++; writes == 3 {
+			return saveErr
+		}
+		return nil
+	}
+	if _, err := env.service.GenerateNextSwissRound(ctx, tournament.ID, organizer); !errors.Is(err, saveErr) {
+		t.Fatalf("expected the save error, got %v", err)
+	}
+	for _, match := range env.storedMatches(tournament.ID) {
+		if match.Round == 2 && (match.Participant1ID != nil || match.Participant2ID != nil) {
+			t.Fatalf("a failed round left match %d paired", match.MatchNumber)
+		}
+	}
+
+	// Nothing was half-paired, so the round can simply be paired again
+	repo.failUpdate = nil
+	round2, err := env.service.GenerateNextSwissRound(ctx, tournament.ID, organizer)
+	if err != nil {
+		t.Fatalf("GenerateNextSwissRound after the failure: %v", err)
+	}
+	if len(round2) != 4 {
+		t.Fatalf("expected 4 round-2 matches, got %d", len(round2))
+	}
+}
+
+func TestPairSwissPlayersStopsWhenBudgetIsSpent(t *testing.T) {
+	players := make([]*swissStanding, 6)
+	for i := range players {
+		players[i] = &swissStanding{
+			participant: &domain.Participant{ID: uuid.New(), Seed: i + 1},
+			opponents:   map[uuid.UUID]bool{},
+		}
+	}
+	// The last player has met everyone, so no rematch-free pairing exists, but the search only
+	// finds out after trying the pairings of everyone ranked above them
+	last := players[len(players)-1]
+	for _, other := range players[:len(players)-1] {
+		last.opponents[other.participant.ID] = true
+		other.opponents[last.participant.ID] = true
+	}
+
+	budget := 3
+	if _, ok := pairSwissPlayers(players, false, &budget); ok {
+		t.Fatal("expected pairing to fail")
+	}
+	if budget != 0 {
+		t.Fatalf("search should stop once the budget is spent, %d left", budget)
+	}
+
+	budget = swissPairingBudget
+	pairs, ok := pairSwissPlayers(players, true, &budget)
+	if !ok || len(pairs) != 3 {
+		t.Fatalf("allowing rematches should pair everyone, got %d pairs", len(pairs))
+	}
+}
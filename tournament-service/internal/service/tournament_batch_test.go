@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestGetTournamentsByIDsMixesFoundAndMissing(t *testing.T) {
+	env := newTestEnv(t)
+	viewer := uuid.New()
+	first := env.tournament(uuid.New())
+	second := env.tournament(uuid.New())
+	env.players(second.ID, 3)
+	hidden := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Visibility = domain.VisibilityPrivate })
+	missing := uuid.New()
+
+	tournaments, notFound, err := env.service.GetTournamentsByIDs(context.Background(),
+		[]uuid.UUID{second.ID, missing, first.ID, hidden.ID, second.ID}, viewer)
+	if err != nil {
+		t.Fatalf("GetTournamentsByIDs: %v", err)
+	}
+
+	// In the order asked for, once each, with participant counts
+	if len(tournaments) != 2 || tournaments[0].ID != second.ID || tournaments[1].ID != first.ID {
+		t.Fatalf("expected [second first], got %+v", tournaments)
+	}
+	if tournaments[0].CurrentParticipants != 3 || tournaments[1].CurrentParticipants != 0 {
+		t.Fatalf("wrong participant counts: %d, %d", tournaments[0].CurrentParticipants, tournaments[1].CurrentParticipants)
+	}
+	// A private tournament the viewer may not see is reported like a missing one
+	if len(notFound) != 2 || notFound[0] != missing || notFound[1] != hidden.ID {
+		t.Fatalf("expected [missing hidden] not found, got %v", notFound)
+	}
+}
+
+func TestGetTournamentsByIDsIsCapped(t *testing.T) {
+	env := newTestEnv(t)
+	ids := make([]uuid.UUID, MaxBatchTournamentIDs+1)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	_, _, err := env.service.GetTournamentsByIDs(context.Background(), ids, uuid.Nil)
+	if !errors.Is(err, ErrTooManyIDs) || !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected ErrTooManyIDs, got %v", err)
+	}
+
+	// Repeats do not count against the cap
+	repeated := append(ids[:MaxBatchTournamentIDs:MaxBatchTournamentIDs], ids[0])
+	if _, _, err := env.service.GetTournamentsByIDs(context.Background(), repeated, uuid.Nil); err != nil {
+		t.Fatalf("%d distinct IDs should be allowed: %v", MaxBatchTournamentIDs, err)
+	}
+}
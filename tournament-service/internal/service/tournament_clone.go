@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// cloneNameSuffix marks a cloned tournament's name so it is not mistaken for the original
+const cloneNameSuffix = " (copy)"
+
+// CloneTournament creates a new Draft tournament owned by userID with the settings of one they
+// can see: name, description, game, format, participant cap, rules, prize pool, custom fields
+// and visibility. Participants, matches, chat and the schedule are not copied. The clone goes
+// through CreateTournament, so the creation quota applies and it gets its own invite code.
+func (s *tournamentService) CloneTournament(
+	ctx context.Context, tournamentID, userID uuid.UUID,
+) (*domain.Tournament, error) {
+	source, err := s.viewableTournament(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.CreateTournament(ctx, &domain.CreateTournamentRequest{
+		Name:            source.Name + cloneNameSuffix,
+		Description:     source.Description,
+		Game:            source.Game,
+		Format:          source.Format,
+		MaxParticipants: source.MaxParticipants,
+		Rules:           source.Rules,
+		PrizePool:       source.PrizePool,
+		CustomFields:    source.CustomFields,
+		InitialStatus:   domain.Draft,
+		// The game was accepted when the source was created, even if it is a custom one
+		AllowCustomGame: true,
+		Visibility:      source.Visibility,
+	}, userID)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestCloneStartsAnIndependentDraft(t *testing.T) {
+	ctx := context.Background()
+	f := newCorrectionFixture(t)
+	source := f.env.store.tournaments[f.tournament.ID]
+	source.Description, source.Rules, source.PrizePool = "Weekly", "Best of one", json.RawMessage(`{"amount":100,"currency":"USD","placements":{"1":100}}`)
+	source.CustomFields = json.RawMessage(`{"best_of":3}`)
+	cloner := uuid.New()
+
+	clone, err := f.env.service.CloneTournament(ctx, source.ID, cloner)
+	if err != nil {
+		t.Fatalf("CloneTournament: %v", err)
+	}
+	if clone.ID == source.ID || clone.Status != domain.Draft || clone.CreatedBy != cloner || clone.Name != source.Name+" (copy)" {
+		t.Fatalf("expected a new draft owned by the cloner, got %+v", clone)
+	}
+	if clone.Game != source.Game || clone.Format != source.Format || clone.MaxParticipants != source.MaxParticipants ||
+		clone.Description != "Weekly" || clone.Rules != "Best of one" || string(clone.PrizePool) != `{"amount":100,"currency":"USD","placements":{"1":100}}` ||
+		string(clone.CustomFields) != `{"best_of":3}` {
+		t.Fatalf("the settings were not copied: %+v", clone)
+	}
+
+	participants, _ := f.env.service.participantRepo.ListByTournament(ctx, clone.ID)
+	if len(participants) != 0 || len(f.env.storedMatches(clone.ID)) != 0 {
+		t.Fatalf("the clone should start without participants or matches, got %d and %d", len(participants), len(f.env.storedMatches(clone.ID)))
+	}
+
+	// Changing the clone leaves the original alone
+	if _, err := f.env.service.UpdateTournament(ctx, clone.ID, cloner, &domain.UpdateTournamentRequest{Name: "Next week"}); err != nil {
+		t.Fatalf("UpdateTournament: %v", err)
+	}
+	if stored := f.env.store.tournaments[source.ID]; stored.Name == "Next week" || stored.Status != domain.InProgress || stored.CreatedBy != f.organizer {
+		t.Fatalf("cloning changed the original: %+v", stored)
+	}
+}
+
+func TestOnlyViewableTournamentsCanBeCloned(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	private := env.tournament(organizer, func(t *domain.Tournament) { t.Visibility = domain.VisibilityPrivate })
+
+	var notFound *ErrTournamentNotFound
+	if _, err := env.service.CloneTournament(ctx, private.ID, uuid.New()); !errors.As(err, &notFound) {
+		t.Fatalf("a stranger cloning a private tournament: expected ErrTournamentNotFound, got %v", err)
+	}
+	if _, err := env.service.CloneTournament(ctx, uuid.New(), organizer); !errors.As(err, &notFound) {
+		t.Fatalf("a missing tournament: expected ErrTournamentNotFound, got %v", err)
+	}
+
+	// The organizer can clone their own, within their creation quota
+	if _, err := env.service.CloneTournament(ctx, private.ID, organizer); err != nil {
+		t.Fatalf("the organizer's clone: %v", err)
+	}
+	env.service.creationQuota = CreationQuota{Limit: 2}
+	var exceeded *ErrQuotaExceeded
+	if _, err := env.service.CloneTournament(ctx, private.ID, organizer); !errors.As(err, &exceeded) {
+		t.Fatalf("expected the clone to count towards the quota, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
+)
+
+// tournamentChampion returns the participant who won the tournament's deciding match: the
+// bracket reset if it was played, otherwise the grand final or single elimination final.
+// Formats without a final (round robin, Swiss) have no champion and return nil.
+func (s *tournamentService) tournamentChampion(ctx context.Context, tournament *domain.Tournament) (*domain.Participant, error) {
+	if tournament.Format != domain.SingleElimination && tournament.Format != domain.DoubleElimination {
+		return nil, nil
+	}
+	matches, err := s.matchRepo.GetByTournamentID(ctx, tournament.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	var final *domain.Match
+	for _, match := range matches {
+		if match.NextMatchID != nil || match.BracketType == domain.LosersBracket {
+			continue
+		}
+		if match.Status != domain.MatchCompleted || match.WinnerID == nil {
+			continue
+		}
+		if final == nil || match.Round > final.Round {
+			final = match
+		}
+	}
+	if final == nil {
+		return nil, nil
+	}
+	return s.participantRepo.GetByID(ctx, *final.WinnerID)
+}
+
+// announceTournamentCompletion records a TOURNAMENT_COMPLETED activity for the creator and
+// broadcasts the result. Failures are logged; the tournament is already completed either way.
+func (s *tournamentService) announceTournamentCompletion(ctx context.Context, tournament *domain.Tournament) {
+	champion, err := s.tournamentChampion(ctx, tournament)
+	if err != nil {
+		logging.Warnf(ctx, "Failed to determine champion of tournament %s: %v", tournament.ID, err)
+	}
+
+	if s.userActivityService != nil {
+		description := fmt.Sprintf("%s tournament completed", tournament.Name)
+		if champion != nil {
+			description = fmt.Sprintf("%s tournament completed; %s won", tournament.Name, champion.ParticipantName)
+		}
+		entityType := domain.EntityTypeTournament
+		contextURL := fmt.Sprintf("/tournaments/%s", tournament.ID.String())
+		if _, err := s.userActivityService.RecordActivity(
+			ctx, tournament.CreatedBy, domain.ActivityTournamentCompleted, description, &tournament.ID, &entityType, &contextURL,
+		); err != nil {
+			logging.Warnf(ctx, "Failed to record '%s' activity for tournament %s: %v", domain.ActivityTournamentCompleted, tournament.ID, err)
+		}
+	}
+
+	if s.broadcastChan != nil {
+		payload := domain.TournamentCompletedPayload{
+			TournamentID: tournament.ID,
+			EndTime:      tournament.EndTime,
+		}
+		if champion != nil {
+			payload.Champion = &domain.ParticipantResponse{
+				ID:              champion.ID,
+				TournamentID:    champion.TournamentID,
+				UserID:          champion.UserID,
+				ParticipantName: champion.ParticipantName,
+				Seed:            champion.Seed,
+				Status:          champion.Status,
+				IsWaitlisted:    champion.IsWaitlisted,
+				CreatedAt:       champion.CreatedAt,
+			}
+		}
+		s.broadcastChan <- domain.WebSocketMessage{
+			Type:    domain.WSEventTournamentCompleted,
+			Payload: payload,
+		}
+		logging.Infof(ctx, "Broadcasted WSEventTournamentCompleted for T-%s", tournament.ID)
+	}
+}
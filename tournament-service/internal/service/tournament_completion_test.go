@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+)
+
+// completions counts the TOURNAMENT_COMPLETED activities recorded and the completion events broadcast
+func completions(f *correctionFixture) (activities []*domain.UserActivity, events []domain.TournamentCompletedPayload) {
+	for _, activity := range f.env.activities.recorded {
+		if activity.ActivityType == domain.ActivityTournamentCompleted {
+			activities = append(activities, activity)
+		}
+	}
+	for _, event := range f.env.drainEvents() {
+		if event.Type == domain.WSEventTournamentCompleted {
+			events = append(events, event.Payload.(domain.TournamentCompletedPayload))
+		}
+	}
+	return activities, events
+}
+
+func TestFinishingTheFinalAnnouncesTheChampionOnce(t *testing.T) {
+	f := newCorrectionFixture(t)
+	for _, semi := range f.semis {
+		if err := f.report(semi, 2, 0); err != nil {
+			t.Fatalf("report semi-final: %v", err)
+		}
+	}
+	if activities, events := completions(f); len(activities) != 0 || len(events) != 0 {
+		t.Fatalf("the tournament is not over yet, got %d activities and %d events", len(activities), len(events))
+	}
+
+	final := f.env.match(t, f.final.ID)
+	if err := f.report(final, 1, 2); err != nil {
+		t.Fatalf("report final: %v", err)
+	}
+
+	if got := f.env.store.tournaments[f.tournament.ID].Status; got != domain.Completed {
+		t.Fatalf("expected the tournament to be completed, got %s", got)
+	}
+	activities, events := completions(f)
+	if len(activities) != 1 || activities[0].UserID != f.organizer || !sameID(activities[0].RelatedEntityID, f.tournament.ID) {
+		t.Fatalf("expected one completion activity for the organizer, got %+v", activities)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected one completion event, got %d", len(events))
+	}
+	champion := events[0].Champion
+	if events[0].TournamentID != f.tournament.ID || champion == nil || champion.ID != *final.Participant2ID {
+		t.Fatalf("expected the final's winner as champion, got %+v", events[0])
+	}
+}
+
+func TestRoundRobinCompletionHasNoChampion(t *testing.T) {
+	f := newCorrectionFixture(t)
+	f.env.store.tournaments[f.tournament.ID].Format = domain.RoundRobin
+
+	champion, err := f.env.service.tournamentChampion(context.Background(), f.env.store.tournaments[f.tournament.ID])
+	if err != nil || champion != nil {
+		t.Fatalf("a round robin has no final to take a champion from, got %+v, %v", champion, err)
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestDeleteTournamentArchivesIt(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.Completed })
+	players := env.players(tournament.ID, 2)
+	playedMatch(env, tournament.ID, players[0], players[1], players[0])
+
+	if err := env.service.DeleteTournament(ctx, tournament.ID, organizer); err != nil {
+		t.Fatalf("DeleteTournament: %v", err)
+	}
+	stored, ok := env.store.tournaments[tournament.ID]
+	if !ok || stored.Status != domain.Archived {
+		t.Fatalf("expected the tournament to be kept as archived, got %+v", stored)
+	}
+	if len(env.storedMatches(tournament.ID)) != 1 {
+		t.Fatal("archiving must keep the tournament's matches")
+	}
+
+	// Deleting again is a no-op
+	if err := env.service.DeleteTournament(ctx, tournament.ID, organizer); err != nil {
+		t.Fatalf("deleting an archived tournament again: %v", err)
+	}
+}
+
+func TestDeleteTournamentRefusedWhileInProgress(t *testing.T) {
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.Status = domain.InProgress })
+
+	if err := env.service.DeleteTournament(context.Background(), tournament.ID, organizer); err == nil {
+		t.Fatal("expected a running tournament to stay")
+	}
+	if env.store.tournaments[tournament.ID].Status != domain.InProgress {
+		t.Fatal("a refused delete must not change the status")
+	}
+}
+
+func TestPurgeTournamentOnlyRemovesArchivedTournaments(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer)
+
+	if err := env.service.PurgeTournament(ctx, tournament.ID, organizer); !errors.Is(err, ErrNotArchived) {
+		t.Fatalf("expected ErrNotArchived, got %v", err)
+	}
+	if err := env.service.DeleteTournament(ctx, tournament.ID, organizer); err != nil {
+		t.Fatalf("DeleteTournament: %v", err)
+	}
+	var notAuthorized *ErrNotAuthorized
+	if err := env.service.PurgeTournament(ctx, tournament.ID, uuid.New()); !errors.As(err, &notAuthorized) {
+		t.Fatalf("only organizers may purge, got %v", err)
+	}
+	if err := env.service.PurgeTournament(ctx, tournament.ID, organizer); err != nil {
+		t.Fatalf("PurgeTournament: %v", err)
+	}
+	if _, ok := env.store.tournaments[tournament.ID]; ok {
+		t.Fatal("a purged tournament should be gone")
+	}
+}
+
+func TestArchivedIsNotAStatusTransition(t *testing.T) {
+	for _, status := range []domain.TournamentStatus{domain.Draft, domain.Registration, domain.Completed, domain.Cancelled} {
+		if isValidStatusTransition(status, domain.Archived) {
+			t.Errorf("%s -> ARCHIVED should only happen through a delete", status)
+		}
+		if isValidStatusTransition(domain.Archived, status) {
+			t.Errorf("ARCHIVED -> %s should not be allowed", status)
+		}
+	}
+	if isValidStatusTransition(domain.Archived, domain.InProgress) {
+		t.Error("an archived tournament must not be restarted")
+	}
+}
+
+func TestListTournamentsByStatuses(t *testing.T) {
+	env := newTestEnv(t)
+	open := env.tournament(uuid.New())
+	running := env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.InProgress })
+	env.tournament(uuid.New(), func(t *domain.Tournament) { t.Status = domain.Completed })
+
+	tournaments, total, err := env.service.ListTournaments(context.Background(), map[string]interface{}{
+		"statuses": []domain.TournamentStatus{domain.Registration, domain.InProgress},
+	}, 1, 10)
+	if err != nil {
+		t.Fatalf("ListTournaments: %v", err)
+	}
+	if total != 2 || len(tournaments) != 2 {
+		t.Fatalf("expected the open and running tournaments, got %d of %d", len(tournaments), total)
+	}
+	for _, tournament := range tournaments {
+		if tournament.ID != open.ID && tournament.ID != running.ID {
+			t.Fatalf("unexpected tournament %s (%s)", tournament.ID, tournament.Status)
+		}
+	}
+}
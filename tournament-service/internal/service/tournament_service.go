@@ -1,19 +1,22 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"math/rand"
 	"net/http"
-	"os"
+	"sort"
 	"time"
 
+	"github.com/cliffdoyle/tournament-service/internal/client"
 	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/cliffdoyle/tournament-service/internal/logging"
 	"github.com/cliffdoyle/tournament-service/internal/repository"
 	"github.com/cliffdoyle/tournament-service/internal/service/bracket"
+	"github.com/cliffdoyle/tournament-service/internal/service/bracket/seeding"
 	"github.com/google/uuid"
 )
 
@@ -22,20 +25,33 @@ type TournamentService interface {
 	CreateTournament(
 		ctx context.Context, request *domain.CreateTournamentRequest, creatorID uuid.UUID,
 	) (*domain.Tournament, error)
-	ListActiveTournaments(ctx context.Context, page, pageSize int) ([]*domain.Tournament, int, error)
+	CloneTournament(ctx context.Context, tournamentID, userID uuid.UUID) (*domain.Tournament, error)
+	ListActiveTournaments(ctx context.Context, viewerID uuid.UUID, page, pageSize int) ([]*domain.Tournament, int, error)
 	GetTournament(ctx context.Context, id uuid.UUID) (*domain.TournamentResponse, error)
+	ViewTournament(ctx context.Context, id, viewerID uuid.UUID) (*domain.TournamentResponse, error)
+	GetTournamentsByIDs(
+		ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID,
+	) ([]*domain.TournamentResponse, []uuid.UUID, error)
 	ListTournaments(
 		ctx context.Context, filters map[string]interface{}, page, pageSize int,
 	) ([]*domain.TournamentResponse, int, error)
-	UpdateTournament(ctx context.Context, id uuid.UUID, request *domain.UpdateTournamentRequest) (
+	UpdateTournament(ctx context.Context, id, userID uuid.UUID, request *domain.UpdateTournamentRequest) (
 		*domain.Tournament, error,
 	)
-	DeleteTournament(ctx context.Context, id uuid.UUID) error
-	UpdateTournamentStatus(ctx context.Context, id uuid.UUID, status domain.TournamentStatus) error
+	DeleteTournament(ctx context.Context, id, userID uuid.UUID) error
+	GetInviteCode(ctx context.Context, tournamentID, userID uuid.UUID) (string, error)
+	RegenerateInviteCode(ctx context.Context, tournamentID, userID uuid.UUID) (string, error)
+	DisableInviteCode(ctx context.Context, tournamentID, userID uuid.UUID) error
+	GetTournamentByInviteCode(ctx context.Context, code string) (*domain.TournamentResponse, error)
+	JoinByInviteCode(
+		ctx context.Context, code string, userID uuid.UUID, participantName string,
+	) (*domain.Participant, error)
+	PurgeTournament(ctx context.Context, id, userID uuid.UUID) error
+	UpdateTournamentStatus(ctx context.Context, id, userID uuid.UUID, status domain.TournamentStatus) error
 
 	// Participant operations
 	RegisterParticipant(
-		ctx context.Context, tournamentID uuid.UUID, request *domain.ParticipantRequest,
+		ctx context.Context, tournamentID, callerID uuid.UUID, request *domain.ParticipantRequest,
 	) (*domain.Participant, error)
 	UpdateParticipant(
 		ctx context.Context, tournamentID uuid.UUID, participantID uuid.UUID, request *domain.ParticipantRequest,
@@ -44,34 +60,115 @@ type TournamentService interface {
 	GetParticipants(ctx context.Context, tournamentID uuid.UUID) ([]*domain.ParticipantResponse, error)
 	CheckInParticipant(ctx context.Context, tournamentID, userID uuid.UUID) error
 	UpdateParticipantSeed(ctx context.Context, tournamentID uuid.UUID, participantID uuid.UUID, seed int) error
+	GetPlayerActiveMatches(ctx context.Context, userID uuid.UUID) ([]*domain.PlayerTournamentMatches, error)
+	GetPlayerTournaments(
+		ctx context.Context, userID uuid.UUID, statuses []domain.TournamentStatus,
+	) ([]*domain.PlayerTournament, error)
+	AssignSeeds(ctx context.Context, tournamentID uuid.UUID, strategy string) error
+	SeedByRanking(ctx context.Context, tournamentID, userID uuid.UUID) ([]*domain.ParticipantResponse, error)
 
 	// Bracket operations
-	GenerateBracket(ctx context.Context, tournamentID uuid.UUID) error
+	GenerateBracket(ctx context.Context, tournamentID, userID uuid.UUID, force bool) error
 	GetMatches(ctx context.Context, tournamentID uuid.UUID) ([]*domain.MatchResponse, error)
+	ListMatches(ctx context.Context, tournamentID uuid.UUID, filter domain.MatchFilter) ([]*domain.MatchResponse, int, error)
+	GetMatch(ctx context.Context, tournamentID, matchID uuid.UUID) (*domain.MatchResponse, error)
 	GetMatchesByRound(ctx context.Context, tournamentID uuid.UUID, round int) ([]*domain.MatchResponse, error)
 	GetMatchesByParticipant(ctx context.Context, tournamentID, participantID uuid.UUID) ([]*domain.MatchResponse, error)
+	GetBracketTree(ctx context.Context, tournamentID uuid.UUID) (*domain.BracketTree, error)
+	ExportMatchResults(ctx context.Context, tournamentID uuid.UUID) (*domain.MatchResultsExport, error)
+	PreviewBracket(
+		ctx context.Context, tournamentID, userID uuid.UUID, format domain.TournamentFormat, seeding string,
+	) (*domain.BracketPreview, error)
 	UpdateMatchScore(
 		ctx context.Context, tournamentID uuid.UUID, matchID uuid.UUID, userID uuid.UUID,
 		request *domain.ScoreUpdateRequest,
-	) error
+	) (*domain.MatchScoreUpdate, error)
+	ForfeitMatch(
+		ctx context.Context, tournamentID, matchID, userID uuid.UUID, request *domain.ForfeitRequest,
+	) (*domain.MatchResponse, error)
+	UpdateMatchSchedule(
+		ctx context.Context, tournamentID, matchID, userID uuid.UUID, scheduledTime *time.Time,
+	) (*domain.MatchResponse, error)
+	UpdateMatchNotes(
+		ctx context.Context, tournamentID, matchID, userID uuid.UUID, request *domain.MatchNotesRequest,
+	) (*domain.MatchResponse, error)
+	ResetMatch(ctx context.Context, tournamentID, matchID, userID uuid.UUID) (*domain.MatchScoreUpdate, error)
+	SubstituteParticipant(
+		ctx context.Context, tournamentID, participantID, userID uuid.UUID, request *domain.SubstitutionRequest,
+	) (*domain.Participant, error)
+	GetSchedule(ctx context.Context, tournamentID uuid.UUID) ([]*domain.MatchResponse, error)
 	DeleteMatches(ctx context.Context, tournamentID uuid.UUID) error
+	GenerateNextSwissRound(ctx context.Context, tournamentID, userID uuid.UUID) ([]*domain.MatchResponse, error)
+	GetStandings(
+		ctx context.Context, tournamentID uuid.UUID, points domain.PointsConfig,
+	) ([]*domain.StandingEntry, error)
+	ComputeResults(ctx context.Context, tournamentID uuid.UUID) (*domain.TournamentResults, error)
+	ComputePrizeDistribution(ctx context.Context, tournamentID uuid.UUID) (*domain.PrizeDistribution, error)
+	ListStaleMatches(ctx context.Context, tournamentID uuid.UUID, timeout time.Duration) ([]*domain.StaleMatch, error)
+	HandleStaleMatch(
+		ctx context.Context, tournamentID, matchID uuid.UUID, timeout time.Duration, autoForfeit bool,
+	) error
+	ListOverdueMatches(ctx context.Context, tournamentID uuid.UUID) ([]*domain.MatchResponse, error)
+	HandleOverdueMatch(ctx context.Context, tournamentID, matchID uuid.UUID) error
+	// AutoStartTournament starts an opted-in tournament whose start time has passed as of now; it reports whether it did
+	AutoStartTournament(ctx context.Context, tournamentID uuid.UUID, now time.Time) (bool, error)
 
 	// Chat operations
 	SendMessage(
 		ctx context.Context, tournamentID uuid.UUID, userID uuid.UUID, request *domain.MessageRequest,
 	) (*domain.Message, error)
 	GetMessages(ctx context.Context, tournamentID uuid.UUID, limit, offset int) ([]*domain.MessageResponse, error)
+	SendMatchMessage(
+		ctx context.Context, tournamentID, matchID, userID uuid.UUID, request *domain.MessageRequest,
+	) (*domain.Message, error)
+	GetMatchMessages(
+		ctx context.Context, tournamentID, matchID, userID uuid.UUID, limit, offset int,
+	) ([]*domain.MessageResponse, error)
+	AddReaction(
+		ctx context.Context, tournamentID, messageID, userID uuid.UUID, emoji string,
+	) (*domain.MessageResponse, error)
+	RemoveReaction(
+		ctx context.Context, tournamentID, messageID, userID uuid.UUID, emoji string,
+	) (*domain.MessageResponse, error)
+	SetMessagePinned(
+		ctx context.Context, tournamentID, messageID, userID uuid.UUID, pinned bool,
+	) (*domain.MessageResponse, error)
+	EditMessage(
+		ctx context.Context, tournamentID, messageID, userID uuid.UUID, request *domain.MessageRequest,
+	) (*domain.MessageResponse, error)
+	DeleteMessage(ctx context.Context, tournamentID, messageID, userID uuid.UUID) error
+	MarkChatRead(ctx context.Context, tournamentID, userID uuid.UUID) (*domain.ChatReadState, error)
+	CountUnreadMessages(ctx context.Context, tournamentID, userID uuid.UUID) (int, error)
+
+	// Archive operations
+	ExportTournament(ctx context.Context, tournamentID, userID uuid.UUID) (*domain.TournamentArchive, error)
+	ImportTournament(
+		ctx context.Context, archive *domain.TournamentArchive, importerID uuid.UUID,
+	) (*domain.ImportResult, error)
+}
+
+// UserDirectory resolves user IDs to their public usernames and display names
+type UserDirectory interface {
+	GetMultipleUserDetails(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]client.UserDetails, error)
+}
+
+// RankingDirectory looks up players' standing in the Ranking Service
+type RankingDirectory interface {
+	GetUserRanking(ctx context.Context, userID uuid.UUID, gameID string) (*client.UserRanking, error)
 }
 
 // tournamentService implements TournamentService
 type tournamentService struct {
-	tournamentRepo   repository.TournamentRepository
-	participantRepo  repository.ParticipantRepository
-	matchRepo        repository.MatchRepository
-	messageRepo      repository.MessageRepository
-	bracketGenerator bracket.Generator
+	tournamentRepo      repository.TournamentRepository
+	participantRepo     repository.ParticipantRepository
+	matchRepo           repository.MatchRepository
+	messageRepo         repository.MessageRepository
+	bracketGenerator    bracket.Generator
 	userActivityService UserActivityService
 	broadcastChan       chan<- domain.WebSocketMessage // Channel to send messages to the hub
+	userDirectory       UserDirectory
+	rankingDirectory    RankingDirectory
+	creationQuota       CreationQuota
 }
 
 // NewTournamentService creates a new tournament service
@@ -83,15 +180,21 @@ func NewTournamentService(
 	bracketGenerator bracket.Generator,
 	userActivityService UserActivityService,
 	broadcastChan chan<- domain.WebSocketMessage, // New parameter
+	userDirectory UserDirectory,
+	rankingDirectory RankingDirectory,
+	creationQuota CreationQuota,
 ) TournamentService {
 	return &tournamentService{
-		tournamentRepo:   tournamentRepo,
-		participantRepo:  participantRepo,
-		matchRepo:        matchRepo,
-		messageRepo:      messageRepo,
-		bracketGenerator: bracketGenerator,
+		tournamentRepo:      tournamentRepo,
+		participantRepo:     participantRepo,
+		matchRepo:           matchRepo,
+		messageRepo:         messageRepo,
+		bracketGenerator:    bracketGenerator,
 		userActivityService: userActivityService,
 		broadcastChan:       broadcastChan, // Store it
+		userDirectory:       userDirectory,
+		rankingDirectory:    rankingDirectory,
+		creationQuota:       creationQuota,
 	}
 }
 
@@ -104,30 +207,153 @@ func (e *ErrTournamentNotFound) Error() string {
 	return fmt.Sprintf("tournament not found: %v", e.ID)
 }
 
+func (e *ErrTournamentNotFound) HTTPStatus() int { return http.StatusNotFound }
+func (e *ErrTournamentNotFound) Unwrap() error   { return domain.ErrNotFound }
+
+// ErrRegistrationClosed is returned when someone other than an organizer tries to join a
+// tournament that has already started or whose registration deadline has passed
+type ErrRegistrationClosed struct {
+	TournamentID uuid.UUID
+	Reason       string
+}
+
+func (e *ErrRegistrationClosed) Error() string {
+	return fmt.Sprintf("registration for tournament %v is closed: %s", e.TournamentID, e.Reason)
+}
+
+func (e *ErrRegistrationClosed) HTTPStatus() int { return http.StatusForbidden }
+func (e *ErrRegistrationClosed) Unwrap() error   { return domain.ErrForbidden }
+
+// ErrInvalidInitialStatus is returned when a tournament is created with a status other than Draft or Registration
+var ErrInvalidInitialStatus = domain.NewError(domain.ErrValidation, "initial status must be DRAFT or REGISTRATION")
+
+// ErrNotArchived is returned when purging a tournament that has not been deleted (archived) first
+var ErrNotArchived = domain.NewError(domain.ErrConflict, "only archived tournaments can be purged; delete the tournament first")
+
+// ErrNotMatchMember is returned when someone other than the match's players or the organizers reads or posts in a match thread
+var ErrNotMatchMember = domain.NewError(domain.ErrForbidden, "only the match participants and the tournament organizers can use this match's chat")
+
+// ErrNotMessageAuthor is returned when someone other than a message's author or the organizer edits or deletes it
+var ErrNotMessageAuthor = domain.NewError(domain.ErrForbidden, "only the message author and the tournament organizer can change this message")
+
+// ErrMessageNotFound is returned when a message does not exist, belongs to another tournament or was deleted
+var ErrMessageNotFound = domain.NewError(domain.ErrNotFound, "message not found")
+
+// ErrNotRegistered is returned when a user checks in to a tournament they have not registered for
+var ErrNotRegistered = domain.NewError(domain.ErrNotFound, "you are not registered for this tournament")
+
+// ErrAlreadyCheckedIn is returned when a participant checks in a second time
+var ErrAlreadyCheckedIn = domain.NewError(domain.ErrConflict, "participant already checked in")
+
+// ErrCheckInClosed is returned when checking in outside the registration phase or after the start time
+var ErrCheckInClosed = domain.NewError(domain.ErrConflict, "check-in is closed for this tournament")
+
+// ErrTournamentFull is returned when a waitlisted participant checks in but no slot is free
+var ErrTournamentFull = domain.NewError(domain.ErrConflict, "tournament is full, cannot check in waitlisted participant")
+
+// ErrCheckInNotOpen is returned when a participant checks in before the tournament's check-in window opens
+type ErrCheckInNotOpen struct {
+	OpensAt time.Time
+}
+
+func (e *ErrCheckInNotOpen) Error() string {
+	return fmt.Sprintf("check-in opens at %s", e.OpensAt.Format(time.RFC3339))
+}
+
+func (e *ErrCheckInNotOpen) HTTPStatus() int { return http.StatusConflict }
+func (e *ErrCheckInNotOpen) Unwrap() error   { return domain.ErrConflict }
+
+// Details tells clients when to retry
+func (e *ErrCheckInNotOpen) Details() map[string]interface{} {
+	return map[string]interface{}{"opensAt": e.OpensAt}
+}
+
+// ErrBracketAlreadyStarted is returned when regenerating a bracket would discard played matches
+type ErrBracketAlreadyStarted struct {
+	TournamentID     uuid.UUID
+	CompletedMatches int
+}
+
+func (e *ErrBracketAlreadyStarted) Error() string {
+	return fmt.Sprintf("bracket for tournament %v already has %d completed match(es); use force to regenerate",
+		e.TournamentID, e.CompletedMatches)
+}
+
+func (e *ErrBracketAlreadyStarted) HTTPStatus() int { return http.StatusConflict }
+func (e *ErrBracketAlreadyStarted) Unwrap() error   { return domain.ErrConflict }
+
+// checkCreateRequest validates the settings of a new tournament and fills in the default format
+// and reporting deadline policy. Archive imports are checked the same way.
+func checkCreateRequest(request *domain.CreateTournamentRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	// Validate format
+	if request.Format == "" {
+		request.Format = domain.SingleElimination
+	}
+
+	if request.GrandFinalsAdvantage < 0 {
+		return domain.NewError(domain.ErrValidation, "grand finals advantage cannot be negative")
+	}
+
+	if request.ReportingWindowMinutes < 0 {
+		return domain.NewError(domain.ErrValidation, "reporting window cannot be negative")
+	}
+	if request.ReportingDeadlinePolicy == "" {
+		request.ReportingDeadlinePolicy = domain.DeadlineFlag
+	}
+	if !isValidDeadlinePolicy(request.ReportingDeadlinePolicy) {
+		return domain.NewError(domain.ErrValidation, fmt.Sprintf("unsupported reporting deadline policy: %s", request.ReportingDeadlinePolicy))
+	}
+	return nil
+}
+
 // CreateTournament creates a new tournament
 func (s *tournamentService) CreateTournament(
 	ctx context.Context, request *domain.CreateTournamentRequest, creatorID uuid.UUID,
 ) (*domain.Tournament, error) {
-	// Validate format
-	if request.Format == "" {
-		request.Format = domain.SingleElimination
+	if err := checkCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	// Tournaments start as drafts unless the organizer opens registration straight away
+	initialStatus := domain.Draft
+	switch request.InitialStatus {
+	case "", domain.Draft:
+	case domain.Registration:
+		initialStatus = domain.Registration
+	default:
+		return nil, ErrInvalidInitialStatus
+	}
+
+	if err := s.checkCreationQuota(ctx, creatorID); err != nil {
+		return nil, err
 	}
 
 	// Create tournament
 	tournament := &domain.Tournament{
-		ID:                   uuid.New(),
-		Name:                 request.Name,
-		Description:          request.Description,
-		Game:                 request.Game,
-		Format:               request.Format,
-		Status:               domain.Draft,
-		MaxParticipants:      request.MaxParticipants,
-		RegistrationDeadline: request.RegistrationDeadline,
-		StartTime:            request.StartTime,
-		CreatedBy:            creatorID,
-		Rules:                request.Rules,
-		PrizePool:            request.PrizePool,
-		CustomFields:         request.CustomFields,
+		ID:                      uuid.New(),
+		Name:                    request.Name,
+		Description:             request.Description,
+		Game:                    domain.NormalizeGame(request.Game),
+		Format:                  request.Format,
+		Status:                  initialStatus,
+		MaxParticipants:         request.MaxParticipants,
+		RegistrationDeadline:    request.RegistrationDeadline,
+		StartTime:               request.StartTime,
+		CreatedBy:               creatorID,
+		Rules:                   request.Rules,
+		PrizePool:               request.PrizePool,
+		CustomFields:            request.CustomFields,
+		GrandFinalsAdvantage:    request.GrandFinalsAdvantage,
+		ReportingWindowMinutes:  request.ReportingWindowMinutes,
+		ReportingDeadlinePolicy: request.ReportingDeadlinePolicy,
+		Visibility:              request.Visibility,
+	}
+	if tournament.Visibility == "" {
+		tournament.Visibility = domain.VisibilityPublic
 	}
 
 	// Save to database
@@ -136,11 +362,22 @@ func (s *tournamentService) CreateTournament(
 		return nil, fmt.Errorf("failed to create tournament: %w", err)
 	}
 
+	// Every tournament starts with a join code; the organizer can regenerate it from the tournament page
+	inviteCode, err := s.assignInviteCode(ctx, tournament.ID)
+	if err != nil {
+		logging.Warnf(ctx, "CreateTournament - Failed to assign invite code to T-%s: %v", tournament.ID, err)
+	}
+	tournament.InviteCode = inviteCode
+
 	// --- RECORD ACTIVITY ---
 	if s.userActivityService != nil { // Check if the service was injected
 		activityType := domain.ActivityTournamentCreated
 		// Description can be auto-generated by activityService or set here
 		// For auto-generation, pass "" as description
+		description := ""
+		if tournament.Status == domain.Registration {
+			description = fmt.Sprintf("Created %s tournament and opened registration", tournament.Name)
+		}
 		entityType := domain.EntityTypeTournament
 		contextURL := fmt.Sprintf("/tournaments/%s", tournament.ID.String())
 
@@ -148,45 +385,51 @@ func (s *tournamentService) CreateTournament(
 			ctx,
 			creatorID, // The user who performed the action
 			activityType,
-			"", // Let activityService try to generate "Created tournament: 'Tournament Name'"
+			description, // Empty lets activityService generate "Created 'Tournament Name' tournament"
 			&tournament.ID,
 			&entityType,
 			&contextURL,
 		)
 		if activityErr != nil {
-			log.Printf("Warning: Failed to record '%s' activity for tournament %s by user %s: %v", activityType, tournament.ID, creatorID, activityErr)
+			logging.Warnf(ctx, "Failed to record '%s' activity for tournament %s by user %s: %v", activityType, tournament.ID, creatorID, activityErr)
 		} else {
-			log.Printf("Successfully recorded '%s' activity for tournament %s by user %s", activityType, tournament.ID, creatorID)
+			logging.Infof(ctx, "Successfully recorded '%s' activity for tournament %s by user %s", activityType, tournament.ID, creatorID)
 		}
 	} else {
 		log.Println("Warning: userActivityService is nil in tournamentService. Cannot record activity.")
 	}
 	// --- END RECORD ACTIVITY ---
-	
-// --- Broadcast tournament created event via WebSocket ---
-	if s.broadcastChan != nil {
+
+	// --- Broadcast tournament created event via WebSocket ---
+	// Every connected client receives it as a listing update, so only public tournaments are
+	// announced; unlisted and private ones stay out of listings
+	if s.broadcastChan != nil && tournament.Visibility == domain.VisibilityPublic {
 		// Construct the TournamentResponse DTO for the WebSocket payload
 		participantCount, countErr := s.tournamentRepo.GetParticipantCount(ctx, tournament.ID)
 		if countErr != nil {
-			log.Printf("Warning: CreateTournament - Failed to get participant count for WebSocket payload for T-%s: %v", tournament.ID, countErr)
+			logging.Warnf(ctx, "CreateTournament - Failed to get participant count for WebSocket payload for T-%s: %v", tournament.ID, countErr)
 		}
 
 		tournamentResponseForBroadcast := domain.TournamentResponse{
-			ID:                   tournament.ID,
-			Name:                 tournament.Name,
-			Description:          tournament.Description,
-			Game:                 tournament.Game,
-			Format:               tournament.Format,
-			Status:               tournament.Status,
-			MaxParticipants:      tournament.MaxParticipants,
-			CurrentParticipants:  participantCount,
-			RegistrationDeadline: tournament.RegistrationDeadline,
-			StartTime:            tournament.StartTime,
-			EndTime:              tournament.EndTime,
-			CreatedAt:            tournament.CreatedAt,
-			Rules:                tournament.Rules,
-			PrizePool:            tournament.PrizePool,
-			CustomFields:         tournament.CustomFields,
+			ID:                      tournament.ID,
+			Name:                    tournament.Name,
+			Description:             tournament.Description,
+			Game:                    tournament.Game,
+			Format:                  tournament.Format,
+			Status:                  tournament.Status,
+			MaxParticipants:         tournament.MaxParticipants,
+			CurrentParticipants:     participantCount,
+			RegistrationDeadline:    tournament.RegistrationDeadline,
+			StartTime:               tournament.StartTime,
+			EndTime:                 tournament.EndTime,
+			CreatedAt:               tournament.CreatedAt,
+			Rules:                   tournament.Rules,
+			PrizePool:               tournament.PrizePool,
+			CustomFields:            tournament.CustomFields,
+			GrandFinalsAdvantage:    tournament.GrandFinalsAdvantage,
+			ReportingWindowMinutes:  tournament.ReportingWindowMinutes,
+			ReportingDeadlinePolicy: tournament.ReportingDeadlinePolicy,
+			Visibility:              tournament.Visibility,
 			// Add CreatedBy if it's part of your TournamentResponse and needed by clients
 			// CreatedBy: tournament.CreatedBy,
 		}
@@ -201,13 +444,12 @@ func (s *tournamentService) CreateTournament(
 
 		// Send the domain.WebSocketMessage struct to the channel; the hub will marshal it.
 		s.broadcastChan <- wsMessage
-		log.Printf("Broadcasted WSEventTournamentCreated for T-%s", tournament.ID)
-	} else {
+		logging.Infof(ctx, "Broadcasted WSEventTournamentCreated for T-%s", tournament.ID)
+	} else if s.broadcastChan == nil {
 		log.Println("Warning: CreateTournament - broadcastChan is nil. Cannot broadcast WebSocket event.")
 	}
 	// --- END Broadcast WebSocket event ---
 
-
 	return tournament, nil
 }
 
@@ -215,7 +457,7 @@ func (s *tournamentService) CreateTournament(
 func (s *tournamentService) GetTournament(ctx context.Context, id uuid.UUID) (*domain.TournamentResponse, error) {
 	tournament, err := s.tournamentRepo.GetByID(ctx, id)
 	if err != nil {
-		if err.Error() == fmt.Sprintf("tournament not found: %v", id) {
+		if errors.Is(err, domain.ErrNotFound) {
 			return nil, &ErrTournamentNotFound{ID: id}
 		}
 		return nil, fmt.Errorf("failed to get tournament: %w", err)
@@ -227,33 +469,108 @@ func (s *tournamentService) GetTournament(ctx context.Context, id uuid.UUID) (*d
 		return nil, fmt.Errorf("failed to get participant count: %w", err)
 	}
 
-	// Map to response
-	response := &domain.TournamentResponse{
-		ID:                   tournament.ID,
-		Name:                 tournament.Name,
-		Description:          tournament.Description,
-		Game:                 tournament.Game,
-		Format:               tournament.Format,
-		Status:               tournament.Status,
-		MaxParticipants:      tournament.MaxParticipants,
-		CurrentParticipants:  participantCount,
-		RegistrationDeadline: tournament.RegistrationDeadline,
-		StartTime:            tournament.StartTime,
-		EndTime:              tournament.EndTime,
-		CreatedAt:            tournament.CreatedAt,
-		Rules:                tournament.Rules,
-		PrizePool:            tournament.PrizePool,
-		CustomFields:         tournament.CustomFields,
-	}
-
-	return response, nil
+	return toTournamentResponse(tournament, participantCount), nil
+}
+
+// MaxBatchTournamentIDs caps how many tournaments GetTournamentsByIDs fetches in one call
+const MaxBatchTournamentIDs = 100
+
+// ErrTooManyIDs is returned when a batch request names more than MaxBatchTournamentIDs tournaments
+var ErrTooManyIDs = domain.NewError(domain.ErrValidation,
+	fmt.Sprintf("at most %d tournament IDs can be requested at once", MaxBatchTournamentIDs))
+
+// GetTournamentsByIDs retrieves several tournaments with their participant counts in two queries.
+// Tournaments come back in the order requested, once each; the IDs that do not exist, or are
+// private tournaments viewerID may not see, are returned separately.
+func (s *tournamentService) GetTournamentsByIDs(
+	ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID,
+) ([]*domain.TournamentResponse, []uuid.UUID, error) {
+	unique := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > MaxBatchTournamentIDs {
+		return nil, nil, ErrTooManyIDs
+	}
+
+	tournaments, err := s.tournamentRepo.GetByIDs(ctx, unique)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tournaments: %w", err)
+	}
+	counts, err := s.tournamentRepo.GetParticipantCounts(ctx, unique)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get participant counts: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*domain.Tournament, len(tournaments))
+	for _, tournament := range tournaments {
+		byID[tournament.ID] = tournament
+	}
+	responses := make([]*domain.TournamentResponse, 0, len(tournaments))
+	notFound := []uuid.UUID{}
+	for _, id := range unique {
+		tournament, ok := byID[id]
+		if ok {
+			if ok, err = s.canView(ctx, tournament, viewerID); err != nil {
+				return nil, nil, err
+			}
+		}
+		if !ok {
+			notFound = append(notFound, id)
+			continue
+		}
+		responses = append(responses, toTournamentResponse(tournament, counts[id]))
+	}
+	return responses, notFound, nil
+}
+
+// toTournamentResponse maps a tournament and its participant count to the API representation
+func toTournamentResponse(tournament *domain.Tournament, participantCount int) *domain.TournamentResponse {
+	return &domain.TournamentResponse{
+		ID:                      tournament.ID,
+		Name:                    tournament.Name,
+		Description:             tournament.Description,
+		Game:                    tournament.Game,
+		Format:                  tournament.Format,
+		Status:                  tournament.Status,
+		MaxParticipants:         tournament.MaxParticipants,
+		CurrentParticipants:     participantCount,
+		RegistrationDeadline:    tournament.RegistrationDeadline,
+		StartTime:               tournament.StartTime,
+		EndTime:                 tournament.EndTime,
+		CreatedAt:               tournament.CreatedAt,
+		Rules:                   tournament.Rules,
+		PrizePool:               tournament.PrizePool,
+		CustomFields:            tournament.CustomFields,
+		GrandFinalsAdvantage:    tournament.GrandFinalsAdvantage,
+		ReportingWindowMinutes:  tournament.ReportingWindowMinutes,
+		ReportingDeadlinePolicy: tournament.ReportingDeadlinePolicy,
+		Visibility:              tournament.Visibility,
+		CreatedBy:               tournament.CreatedBy,
+	}
 }
 
 // ListTournaments retrieves tournaments based on filters with pagination
 func (s *tournamentService) ListTournaments(
 	ctx context.Context, filters map[string]interface{}, page, pageSize int,
 ) ([]*domain.TournamentResponse, int, error) {
-	tournaments, total, err := s.tournamentRepo.List(ctx, filters, page, pageSize)
+	var tournaments []*domain.Tournament
+	var total int
+	var err error
+	// A list of statuses, e.g. REGISTRATION or IN_PROGRESS, is matched with GetByStatuses
+	if statuses, ok := filters["statuses"].([]domain.TournamentStatus); ok && len(statuses) > 0 {
+		var visibleTo *uuid.UUID
+		if viewerID, ok := filters["visibleTo"].(uuid.UUID); ok {
+			visibleTo = &viewerID
+		}
+		tournaments, total, err = s.tournamentRepo.GetByStatuses(ctx, statuses, visibleTo, pageSize, (page-1)*pageSize)
+	} else {
+		tournaments, total, err = s.tournamentRepo.List(ctx, filters, page, pageSize)
+	}
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list tournaments: %w", err)
 	}
@@ -267,29 +584,14 @@ func (s *tournamentService) ListTournaments(
 			return nil, 0, fmt.Errorf("failed to get participant count: %w", err)
 		}
 
-		responses[i] = &domain.TournamentResponse{
-			ID:                   tournament.ID,
-			Name:                 tournament.Name,
-			Description:          tournament.Description,
-			Game:                 tournament.Game,
-			Format:               tournament.Format,
-			Status:               tournament.Status,
-			MaxParticipants:      tournament.MaxParticipants,
-			CurrentParticipants:  participantCount,
-			RegistrationDeadline: tournament.RegistrationDeadline,
-			StartTime:            tournament.StartTime,
-			EndTime:              tournament.EndTime,
-			CreatedAt:            tournament.CreatedAt,
-			Rules:                tournament.Rules,
-			PrizePool:            tournament.PrizePool,
-			CustomFields:         tournament.CustomFields,
-		}
+		responses[i] = toTournamentResponse(tournament, participantCount)
 	}
 
 	return responses, total, nil
 }
 
-func (s *tournamentService) ListActiveTournaments(ctx context.Context, page, pageSize int) ([]*domain.Tournament, int, error) {
+// ListActiveTournaments lists the tournaments in registration or in progress that viewerID can see
+func (s *tournamentService) ListActiveTournaments(ctx context.Context, viewerID uuid.UUID, page, pageSize int) ([]*domain.Tournament, int, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -308,7 +610,7 @@ func (s *tournamentService) ListActiveTournaments(ctx context.Context, page, pag
 		domain.InProgress,   // Assuming you defined this
 	}
 
-	tournaments, total, err := s.tournamentRepo.GetByStatuses(ctx, activeStatuses, pageSize, offset)
+	tournaments, total, err := s.tournamentRepo.GetByStatuses(ctx, activeStatuses, &viewerID, pageSize, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list active tournaments: %w", err)
 	}
@@ -318,19 +620,23 @@ func (s *tournamentService) ListActiveTournaments(ctx context.Context, page, pag
 	return tournaments, total, nil
 }
 
-// UpdateTournament updates an existing tournament
+// UpdateTournament updates an existing tournament; only its organizers may do so
 func (s *tournamentService) UpdateTournament(
-	ctx context.Context, id uuid.UUID, request *domain.UpdateTournamentRequest,
+	ctx context.Context, id, userID uuid.UUID, request *domain.UpdateTournamentRequest,
 ) (*domain.Tournament, error) {
 	// Get current tournament
-	tournament, err := s.tournamentRepo.GetByID(ctx, id)
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	tournament, err := s.getManagedTournament(ctx, id, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tournament: %w", err)
+		return nil, err
 	}
 
 	// Only allow updates in Draft or Registration status
 	if tournament.Status != domain.Draft && tournament.Status != domain.Registration {
-		return nil, errors.New("cannot update tournament that has started or is completed")
+		return nil, domain.NewError(domain.ErrConflict, "cannot update tournament that has started or is completed")
 	}
 
 	// Update fields if provided
@@ -341,7 +647,7 @@ func (s *tournamentService) UpdateTournament(
 		tournament.Description = request.Description
 	}
 	if request.Game != "" {
-		tournament.Game = request.Game
+		tournament.Game = domain.NormalizeGame(request.Game)
 	}
 	if request.Format != "" {
 		tournament.Format = request.Format
@@ -353,7 +659,7 @@ func (s *tournamentService) UpdateTournament(
 			return nil, fmt.Errorf("failed to get participant count: %w", err)
 		}
 		if request.MaxParticipants < count {
-			return nil, errors.New("cannot reduce max participants below current count")
+			return nil, domain.NewError(domain.ErrConflict, "cannot reduce max participants below current count")
 		}
 		tournament.MaxParticipants = request.MaxParticipants
 	}
@@ -363,6 +669,10 @@ func (s *tournamentService) UpdateTournament(
 	if request.StartTime != nil {
 		tournament.StartTime = request.StartTime
 	}
+	// The request may move only one of the two dates, so check them together once merged
+	if err := domain.ValidateSchedule(tournament.RegistrationDeadline, tournament.StartTime); err != nil {
+		return nil, err
+	}
 	if request.Rules != "" {
 		tournament.Rules = request.Rules
 	}
@@ -372,6 +682,29 @@ func (s *tournamentService) UpdateTournament(
 	if request.CustomFields != nil {
 		tournament.CustomFields = request.CustomFields
 	}
+	if request.GrandFinalsAdvantage != nil {
+		if *request.GrandFinalsAdvantage < 0 {
+			return nil, domain.NewError(domain.ErrValidation, "grand finals advantage cannot be negative")
+		}
+		tournament.GrandFinalsAdvantage = *request.GrandFinalsAdvantage
+	}
+	refreshDeadlines := false
+	if request.ReportingWindowMinutes != nil {
+		if *request.ReportingWindowMinutes < 0 {
+			return nil, domain.NewError(domain.ErrValidation, "reporting window cannot be negative")
+		}
+		refreshDeadlines = tournament.ReportingWindowMinutes != *request.ReportingWindowMinutes
+		tournament.ReportingWindowMinutes = *request.ReportingWindowMinutes
+	}
+	if request.ReportingDeadlinePolicy != "" {
+		if !isValidDeadlinePolicy(request.ReportingDeadlinePolicy) {
+			return nil, domain.NewError(domain.ErrValidation, fmt.Sprintf("unsupported reporting deadline policy: %s", request.ReportingDeadlinePolicy))
+		}
+		tournament.ReportingDeadlinePolicy = request.ReportingDeadlinePolicy
+	}
+	if request.Visibility != "" {
+		tournament.Visibility = request.Visibility
+	}
 
 	// Save updates
 	err = s.tournamentRepo.Update(ctx, tournament)
@@ -379,33 +712,75 @@ func (s *tournamentService) UpdateTournament(
 		return nil, fmt.Errorf("failed to update tournament: %w", err)
 	}
 
+	if refreshDeadlines {
+		if err := s.refreshReportingDeadlines(ctx, tournament); err != nil {
+			return nil, fmt.Errorf("failed to update reporting deadlines: %w", err)
+		}
+	}
+
 	return tournament, nil
 }
 
-// DeleteTournament deletes a tournament
-func (s *tournamentService) DeleteTournament(ctx context.Context, id uuid.UUID) error {
+// DeleteTournament archives a tournament rather than removing it, so its matches, chat history
+// and the ranking records referencing it survive; only its organizers may do so
+func (s *tournamentService) DeleteTournament(ctx context.Context, id, userID uuid.UUID) error {
 	// Get current tournament
-	tournament, err := s.tournamentRepo.GetByID(ctx, id)
+	tournament, err := s.getManagedTournament(ctx, id, userID)
 	if err != nil {
-		return fmt.Errorf("failed to get tournament: %w", err)
+		return err
 	}
 
 	// Only allow deletion if not in progress
 	if tournament.Status == domain.InProgress {
-		return errors.New("cannot delete tournament that is in progress")
+		return domain.NewError(domain.ErrConflict, "cannot delete tournament that is in progress")
+	}
+	if tournament.Status == domain.Archived {
+		return nil
+	}
+
+	// Archive tournament
+	tournament.Status = domain.Archived
+	err = s.tournamentRepo.Update(ctx, tournament)
+	if err != nil {
+		return fmt.Errorf("failed to archive tournament: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeTournament permanently removes an archived tournament and everything attached to it
+func (s *tournamentService) PurgeTournament(ctx context.Context, id, userID uuid.UUID) error {
+	tournament, err := s.getManagedTournament(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if tournament.Status != domain.Archived {
+		return ErrNotArchived
 	}
 
-	// Delete tournament
 	err = s.tournamentRepo.Delete(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to delete tournament: %w", err)
+		return fmt.Errorf("failed to purge tournament: %w", err)
 	}
 
+	logging.Infof(ctx, "Tournament %s purged by user %s", id, userID)
 	return nil
 }
 
-// UpdateTournamentStatus updates the status of a tournament
+// UpdateTournamentStatus updates the status of a tournament on behalf of one of its organizers
 func (s *tournamentService) UpdateTournamentStatus(
+	ctx context.Context, id, userID uuid.UUID, status domain.TournamentStatus,
+) error {
+	if _, err := s.getManagedTournament(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.updateTournamentStatus(ctx, id, status)
+}
+
+// updateTournamentStatus validates and applies a status transition without an ownership
+// check, for transitions the service makes itself (e.g. completing a finished tournament)
+func (s *tournamentService) updateTournamentStatus(
 	ctx context.Context, id uuid.UUID, status domain.TournamentStatus,
 ) error {
 	// Get current tournament
@@ -416,7 +791,7 @@ func (s *tournamentService) UpdateTournamentStatus(
 
 	// Validate status transition
 	if !isValidStatusTransition(tournament.Status, status) {
-		return fmt.Errorf("invalid status transition from %s to %s", tournament.Status, status)
+		return domain.NewError(domain.ErrConflict, fmt.Sprintf("invalid status transition from %s to %s", tournament.Status, status))
 	}
 
 	// Additional validations based on status
@@ -427,7 +802,7 @@ func (s *tournamentService) UpdateTournamentStatus(
 			deadline := tournament.RegistrationDeadline.UTC()
 			if now.After(deadline) {
 				// Just log a warning instead of returning an error
-				log.Printf("Warning: Registration deadline has passed for tournament %s", id)
+				logging.Warnf(ctx, "Registration deadline has passed for tournament %s", id)
 			}
 		}
 	case domain.InProgress:
@@ -440,7 +815,7 @@ func (s *tournamentService) UpdateTournamentStatus(
 		}
 		if count < 2 {
 			// Just log a warning instead of returning an error
-			log.Printf("Warning: Tournament %s has less than 2 participants", id)
+			logging.Warnf(ctx, "Tournament %s has less than 2 participants", id)
 		}
 	case domain.Completed:
 		// Verify all matches are completed
@@ -449,8 +824,9 @@ func (s *tournamentService) UpdateTournamentStatus(
 			return fmt.Errorf("failed to get tournament matches: %w", err)
 		}
 		for _, match := range matches {
-			if match.Status != domain.MatchCompleted {
-				return errors.New("cannot complete tournament with unfinished matches")
+			// Cancelled matches (e.g. an unneeded bracket reset) count as finished, as in checkTournamentCompletion
+			if match.Status != domain.MatchCompleted && match.Status != domain.MatchCancelled {
+				return domain.NewError(domain.ErrConflict, "cannot complete tournament with unfinished matches")
 			}
 		}
 		now := time.Now()
@@ -464,11 +840,20 @@ func (s *tournamentService) UpdateTournamentStatus(
 		return fmt.Errorf("failed to update tournament status: %w", err)
 	}
 
+	if status == domain.Completed {
+		s.announceTournamentCompletion(ctx, tournament)
+	}
+
 	return nil
 }
 
 // isValidStatusTransition checks if a status transition is valid
 func isValidStatusTransition(from, to domain.TournamentStatus) bool {
+	// Archiving happens only through DeleteTournament, and archived tournaments stay archived
+	if from == domain.Archived || to == domain.Archived {
+		return false
+	}
+
 	// Special case: always allow transitions to IN_PROGRESS
 	if to == domain.InProgress {
 		return true
@@ -504,34 +889,85 @@ func isValidStatusTransition(from, to domain.TournamentStatus) bool {
 	return false
 }
 
-// RegisterParticipant registers a participant for a tournament
+// checkRegistrationOpen rejects registrations for tournaments that are no longer open. The
+// status check applies to everyone; organizers may override the registration deadline.
+func checkRegistrationOpen(tournament *domain.Tournament, callerID uuid.UUID, now time.Time) error {
+	if tournament.Status != domain.Draft && tournament.Status != domain.Registration {
+		return &ErrRegistrationClosed{
+			TournamentID: tournament.ID,
+			Reason:       fmt.Sprintf("tournament is %s", tournament.Status),
+		}
+	}
+	if tournament.RegistrationDeadline == nil || now.Before(*tournament.RegistrationDeadline) {
+		return nil
+	}
+	if callerID != uuid.Nil && isOrganizer(tournament, callerID) {
+		return nil
+	}
+	return &ErrRegistrationClosed{
+		TournamentID: tournament.ID,
+		Reason:       fmt.Sprintf("registration deadline passed at %s", tournament.RegistrationDeadline.Format(time.RFC3339)),
+	}
+}
+
+// RegisterParticipant registers a participant for a tournament. Registration closes once the
+// tournament leaves Draft/Registration or its deadline passes; callerID may be uuid.Nil for
+// anonymous requests, and organizers can still add participants after the deadline. Only
+// organizers can add participants to private tournaments; players join those by invite code.
 func (s *tournamentService) RegisterParticipant(
-	ctx context.Context, tournamentID uuid.UUID, request *domain.ParticipantRequest,
+	ctx context.Context, tournamentID, callerID uuid.UUID, request *domain.ParticipantRequest,
 ) (*domain.Participant, error) {
-    // --- END OF CHECK ---
-	   log.Printf("[Service.RegisterParticipant] BEFORE creating Participant struct. request.UserID is: %v", request.UserID) // Log the pointer
-    if request.UserID == nil {
-        log.Printf("[Service.RegisterParticipant] Value of *request.UserID: %s", (*request.UserID).String())
-		return nil, errors.New("participant registration requires a valid UserID to link")
-    }
-	 // --- ADD THIS CHECK ---
-    // Check if a participant with this UserID is already registered for this tournament
-    exists, err := s.participantRepo.ExistsByTournamentIDAndUserID(ctx, tournamentID, *request.UserID)
-    if err != nil {
-        // Handle potential database query errors (e.g., transient connection issues)
-        return nil, fmt.Errorf("failed to check for existing participant: %w", err)
-    }
-    if exists {
-        // Return a specific error if the user is already a participant
-        // You should define a custom error type like domain.ErrAlreadyParticipant
-        return nil, domain.ErrAlreadyParticipant // Or return a more generic error if you prefer
-    }
+	// --- END OF CHECK ---
+	logging.Debugf(ctx, "[Service.RegisterParticipant] BEFORE creating Participant struct. request.UserID is: %v", request.UserID) // Log the pointer
+	if request.UserID == nil {
+		return nil, domain.NewError(domain.ErrValidation, "participant registration requires a valid UserID to link")
+	}
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	if err := checkRegistrationOpen(tournament, callerID, time.Now()); err != nil {
+		return nil, err
+	}
+	if tournament.Visibility == domain.VisibilityPrivate && (callerID == uuid.Nil || !isOrganizer(tournament, callerID)) {
+		return nil, &ErrRegistrationClosed{
+			TournamentID: tournament.ID,
+			Reason:       "tournament is private; join with an invite code",
+		}
+	}
+
+	return s.addParticipant(ctx, tournament, request)
+}
+
+// addParticipant saves a registration the caller has been allowed to make, waitlisting it
+// when the tournament is full
+func (s *tournamentService) addParticipant(
+	ctx context.Context, tournament *domain.Tournament, request *domain.ParticipantRequest,
+) (*domain.Participant, error) {
+	tournamentID := tournament.ID
+
+	// --- ADD THIS CHECK ---
+	// Check if a participant with this UserID is already registered for this tournament
+	exists, err := s.participantRepo.ExistsByTournamentIDAndUserID(ctx, tournamentID, *request.UserID)
+	if err != nil {
+		// Handle potential database query errors (e.g., transient connection issues)
+		return nil, fmt.Errorf("failed to check for existing participant: %w", err)
+	}
+	if exists {
+		// Return a specific error if the user is already a participant
+		// You should define a custom error type like domain.ErrAlreadyParticipant
+		return nil, domain.ErrAlreadyParticipant // Or return a more generic error if you prefer
+	}
 
 	targetUserID := *request.UserID
-    // Create participant
+	// Create participant
 	// Create participant
 	participant := &domain.Participant{
-		
+
 		ID:              uuid.New(),
 		TournamentID:    tournamentID,
 		UserID:          request.UserID,
@@ -542,17 +978,31 @@ func (s *tournamentService) RegisterParticipant(
 		UpdatedAt:       time.Now(),
 	}
 
-	   log.Printf("[Service.RegisterParticipant] AFTER creating Participant struct. participant.UserID is: %v", participant.UserID) // Log the pointer again
-    if participant.UserID != nil {
-        log.Printf("[Service.RegisterParticipant] Value of *participant.UserID: %s", (*participant.UserID).String())
-    }
+	// Once the tournament is full, further registrations join the waitlist
+	if tournament.MaxParticipants > 0 {
+		confirmed, err := s.countConfirmedParticipants(ctx, tournamentID)
+		if err != nil {
+			return nil, err
+		}
+		if confirmed >= tournament.MaxParticipants {
+			participant.Status = domain.ParticipantWaitlisted
+			participant.IsWaitlisted = true
+			logging.Infof(ctx, "[Service.RegisterParticipant] Tournament %s is full (%d/%d); waitlisting %s",
+				tournamentID, confirmed, tournament.MaxParticipants, request.ParticipantName)
+		}
+	}
+
+	logging.Debugf(ctx, "[Service.RegisterParticipant] AFTER creating Participant struct. participant.UserID is: %v", participant.UserID) // Log the pointer again
+	if participant.UserID != nil {
+		logging.Debugf(ctx, "[Service.RegisterParticipant] Value of *participant.UserID: %s", (*participant.UserID).String())
+	}
 
 	// Save to database
 	err = s.participantRepo.Create(ctx, participant)
 	if err != nil {
 		return nil, fmt.Errorf("failed to register participant: %w", err)
 	}
-	
+
 	// --- RECORD ACTIVITY for TOURNAMENT_JOINED ---
 	if s.userActivityService != nil {
 		activityType := domain.ActivityTournamentJoined
@@ -564,10 +1014,10 @@ func (s *tournamentService) RegisterParticipant(
 			ctx, targetUserID, activityType, "", &tournamentID, &entityType, &contextURL,
 		)
 		if activityErr != nil {
-			log.Printf("Warning: RegisterParticipant - Failed to record '%s' activity for T-%s by U-%s: %v",
+			logging.Warnf(ctx, "RegisterParticipant - Failed to record '%s' activity for T-%s by U-%s: %v",
 				activityType, tournamentID, targetUserID, activityErr)
 		} else {
-			log.Printf("RegisterParticipant - Successfully recorded '%s' activity for T-%s by U-%s",
+			logging.Infof(ctx, "RegisterParticipant - Successfully recorded '%s' activity for T-%s by U-%s",
 				activityType, tournamentID, targetUserID)
 		}
 	} else {
@@ -575,25 +1025,24 @@ func (s *tournamentService) RegisterParticipant(
 	}
 	// --- END RECORD ACTIVITY ---
 
-	
 	if s.broadcastChan != nil && participant.UserID != nil { // Only if actual user joined
-        // Get current participant count
-        participantCount, _ := s.tournamentRepo.GetParticipantCount(ctx, tournamentID)
+		// Get current participant count
+		participantCount, _ := s.tournamentRepo.GetParticipantCount(ctx, tournamentID)
 
 		// Convert domain.Participant to domain.ParticipantResponse if needed by frontend type
-        participantResp := domain.ParticipantResponse{ /* ... map from participant ... */ }
+		participantResp := domain.ParticipantResponse{ /* ... map from participant ... */ }
 
 		wsPayload := domain.ParticipantJoinedPayload{
 			TournamentID:     tournamentID,
 			Participant:      participantResp,
-            ParticipantCount: participantCount,
+			ParticipantCount: participantCount,
 		}
 		wsMessage := domain.WebSocketMessage{
 			Type:    domain.WSEventParticipantJoined,
 			Payload: wsPayload,
 		}
 		s.broadcastChan <- wsMessage // Send struct, hub marshals
-		log.Printf("Broadcasted WSEventParticipantJoined for P-%s in T-%s", participant.ID, tournamentID)
+		logging.Infof(ctx, "Broadcasted WSEventParticipantJoined for P-%s in T-%s", participant.ID, tournamentID)
 	}
 
 	return participant, nil
@@ -609,7 +1058,7 @@ func (s *tournamentService) UnregisterParticipant(ctx context.Context, tournamen
 
 	// Check tournament status
 	if tournament.Status != domain.Draft && tournament.Status != domain.Registration {
-		return errors.New("cannot unregister after tournament has started")
+		return domain.NewError(domain.ErrConflict, "cannot unregister after tournament has started")
 	}
 
 	// Get participant
@@ -617,6 +1066,9 @@ func (s *tournamentService) UnregisterParticipant(ctx context.Context, tournamen
 	if err != nil {
 		return fmt.Errorf("failed to get participant: %w", err)
 	}
+	if participant == nil {
+		return ErrNotRegistered
+	}
 
 	// Delete participant
 	err = s.participantRepo.Delete(ctx, participant.ID)
@@ -624,6 +1076,64 @@ func (s *tournamentService) UnregisterParticipant(ctx context.Context, tournamen
 		return fmt.Errorf("failed to unregister participant: %w", err)
 	}
 
+	// A confirmed slot opened up, so the longest-waiting waitlisted participant takes it
+	if !participant.IsWaitlisted {
+		if err := s.promoteFromWaitlist(ctx, tournament); err != nil {
+			logging.Warnf(ctx, "UnregisterParticipant - Failed to promote waitlisted participant in T-%s: %v", tournamentID, err)
+		}
+	}
+
+	return nil
+}
+
+// confirmedParticipants drops waitlisted entries, leaving the participants who hold a slot
+func confirmedParticipants(participants []*domain.Participant) []*domain.Participant {
+	confirmed := make([]*domain.Participant, 0, len(participants))
+	for _, p := range participants {
+		if !p.IsWaitlisted {
+			confirmed = append(confirmed, p)
+		}
+	}
+	return confirmed
+}
+
+// countConfirmedParticipants counts the participants holding a slot, i.e. everyone not on the waitlist
+func (s *tournamentService) countConfirmedParticipants(ctx context.Context, tournamentID uuid.UUID) (int, error) {
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get participants: %w", err)
+	}
+	return len(confirmedParticipants(participants)), nil
+}
+
+// promoteFromWaitlist moves the earliest waitlisted participant into the field if a slot is free
+func (s *tournamentService) promoteFromWaitlist(ctx context.Context, tournament *domain.Tournament) error {
+	participants, err := s.participantRepo.ListByTournament(ctx, tournament.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get participants: %w", err)
+	}
+	confirmed := 0
+	var next *domain.Participant
+	for _, p := range participants {
+		if !p.IsWaitlisted {
+			confirmed++
+			continue
+		}
+		if next == nil || p.CreatedAt.Before(next.CreatedAt) {
+			next = p
+		}
+	}
+	if next == nil || (tournament.MaxParticipants > 0 && confirmed >= tournament.MaxParticipants) {
+		return nil
+	}
+
+	next.IsWaitlisted = false
+	next.Status = domain.ParticipantRegistered
+	next.UpdatedAt = time.Now()
+	if err := s.participantRepo.Update(ctx, next); err != nil {
+		return fmt.Errorf("failed to promote participant %s: %w", next.ID, err)
+	}
+	logging.Infof(ctx, "Promoted waitlisted participant %s into tournament %s", next.ID, tournament.ID)
 	return nil
 }
 
@@ -655,22 +1165,55 @@ func (s *tournamentService) GetParticipants(ctx context.Context, tournamentID uu
 	return responses, nil
 }
 
+// checkInWindow reads the optional check-in lead time an organizer configured under
+// custom_fields.check_in_window_minutes, e.g. {"check_in_window_minutes": 60} opens check-in an hour
+// before StartTime. Zero means check-in is open for the whole registration phase.
+func checkInWindow(tournament *domain.Tournament) time.Duration {
+	if len(tournament.CustomFields) == 0 {
+		return 0
+	}
+	var fields struct {
+		CheckInWindowMinutes int `json:"check_in_window_minutes"`
+	}
+	if err := json.Unmarshal(tournament.CustomFields, &fields); err != nil {
+		log.Printf("Warning: could not read check-in window of tournament %s: %v", tournament.ID, err)
+		return 0
+	}
+	if fields.CheckInWindowMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(fields.CheckInWindowMinutes) * time.Minute
+}
+
 // CheckInParticipant checks in a participant for a tournament
 func (s *tournamentService) CheckInParticipant(ctx context.Context, tournamentID, userID uuid.UUID) error {
 	// Get tournament
 	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
 	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return &ErrTournamentNotFound{ID: tournamentID}
+		}
 		return fmt.Errorf("failed to get tournament: %w", err)
 	}
 
 	// Validate tournament status
 	if tournament.Status != domain.Registration {
-		return errors.New("tournament is not in registration phase")
+		return ErrCheckInClosed
 	}
 
-	// Check if tournament has started
-	if tournament.StartTime != nil && time.Now().After(*tournament.StartTime) {
-		return errors.New("tournament has already started")
+	now := time.Now()
+	if tournament.StartTime != nil {
+		// Check if tournament has started
+		if now.After(*tournament.StartTime) {
+			return ErrCheckInClosed
+		}
+		// Check-in only opens a configured lead time before the start
+		if window := checkInWindow(tournament); window > 0 {
+			opensAt := tournament.StartTime.Add(-window)
+			if now.Before(opensAt) {
+				return &ErrCheckInNotOpen{OpensAt: opensAt}
+			}
+		}
 	}
 
 	// Get participant
@@ -679,28 +1222,30 @@ func (s *tournamentService) CheckInParticipant(ctx context.Context, tournamentID
 		return fmt.Errorf("failed to get participant: %w", err)
 	}
 	if participant == nil {
-		return errors.New("participant not found")
+		return ErrNotRegistered
 	}
 
 	// Check if already checked in
 	if participant.Status == domain.ParticipantCheckedIn {
-		return errors.New("participant already checked in")
+		return ErrAlreadyCheckedIn
 	}
 
 	// If waitlisted, check if there's space
 	if participant.IsWaitlisted {
-		count, err := s.tournamentRepo.GetParticipantCount(ctx, tournamentID)
+		// Only confirmed entries take up a slot; the waitlist itself does not
+		confirmed, err := s.countConfirmedParticipants(ctx, tournamentID)
 		if err != nil {
-			return fmt.Errorf("failed to get participant count: %w", err)
+			return err
 		}
-		if count >= tournament.MaxParticipants {
-			return errors.New("tournament is full, cannot check in waitlisted participant")
+		if confirmed >= tournament.MaxParticipants {
+			return ErrTournamentFull
 		}
 		participant.IsWaitlisted = false
 	}
 
 	// Update participant status
 	participant.Status = domain.ParticipantCheckedIn
+	participant.UpdatedAt = now
 	err = s.participantRepo.Update(ctx, participant)
 	if err != nil {
 		return fmt.Errorf("failed to update participant: %w", err)
@@ -721,7 +1266,7 @@ func (s *tournamentService) UpdateParticipantSeed(
 
 	// Check tournament status
 	if tournament.Status != domain.Draft && tournament.Status != domain.Registration {
-		return errors.New("cannot update seeds after tournament has started")
+		return domain.NewError(domain.ErrConflict, "cannot update seeds after tournament has started")
 	}
 
 	// Update seed
@@ -733,92 +1278,248 @@ func (s *tournamentService) UpdateParticipantSeed(
 	return nil
 }
 
-// GenerateBracket generates the tournament bracket based on format
-func (s *tournamentService) GenerateBracket(ctx context.Context, tournamentID uuid.UUID) error {
+// Seeding strategies supported by AssignSeeds
+const (
+	SeedByRegistrationOrder = "registration_order"
+	SeedRandom              = "random"
+)
+
+// AssignSeeds overwrites every participant's seed with 1..N according to the given strategy
+func (s *tournamentService) AssignSeeds(ctx context.Context, tournamentID uuid.UUID, strategy string) error {
+	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	ordered, err := seedOrder(participants, strategy)
+	if err != nil {
+		return err
+	}
+
+	for i, participant := range ordered {
+		if err := s.participantRepo.UpdateSeed(ctx, participant.ID, i+1); err != nil {
+			return fmt.Errorf("failed to update seed for participant %s: %w", participant.ID, err)
+		}
+		participant.Seed = i + 1
+	}
+
+	return nil
+}
+
+// ErrUnsupportedSeeding is returned for a seeding strategy other than the ones AssignSeeds supports
+var ErrUnsupportedSeeding = domain.NewError(domain.ErrValidation, "unsupported seeding strategy")
+
+// ErrUnsupportedFormat is returned for a tournament format the bracket generator cannot lay out
+var ErrUnsupportedFormat = domain.NewError(domain.ErrValidation, "unsupported tournament format")
+
+// seedOrder returns the participants in the order the given strategy seeds them, from seed 1 down
+func seedOrder(participants []*domain.Participant, strategy string) ([]*domain.Participant, error) {
+	ordered := make([]*domain.Participant, len(participants))
+	copy(ordered, participants)
+
+	switch strategy {
+	case "", SeedByRegistrationOrder:
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return ordered[i].CreatedAt.Before(ordered[j].CreatedAt)
+		})
+	case SeedRandom:
+		rand.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSeeding, strategy)
+	}
+	return ordered, nil
+}
+
+// GenerateBracket generates the tournament bracket based on format, replacing any existing matches.
+// It refuses to discard completed matches unless force is set, and only organizers may run it.
+func (s *tournamentService) GenerateBracket(ctx context.Context, tournamentID, userID uuid.UUID, force bool) error {
 	// Get tournament
-	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	tournament, err := s.getManagedTournament(ctx, tournamentID, userID)
 	if err != nil {
-		return fmt.Errorf("failed to get tournament: %w", err)
+		return err
 	}
 
-	// Get participants
+	// Guard against wiping results that have already been played
+	existingMatches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing matches: %w", err)
+	}
+	completedCount := 0
+	for _, match := range existingMatches {
+		if match.Status == domain.MatchCompleted {
+			completedCount++
+		}
+	}
+	if completedCount > 0 {
+		if !force {
+			return &ErrBracketAlreadyStarted{TournamentID: tournamentID, CompletedMatches: completedCount}
+		}
+		logging.Warnf(ctx, "Forced bracket regeneration for tournament %s discards %d completed match(es)", tournamentID, completedCount)
+	}
+
+	// Get participants; waitlisted players are not part of the bracket
 	participants, err := s.participantRepo.ListByTournament(ctx, tournamentID)
 	if err != nil {
 		return fmt.Errorf("failed to get participants: %w", err)
 	}
+	participants = confirmedParticipants(participants)
 
 	// Check if we have enough participants
 	if len(participants) < 2 {
-		return errors.New("need at least 2 participants to generate bracket")
+		return domain.NewError(domain.ErrConflict, "need at least 2 participants to generate bracket")
 	}
 
-	// Convert domain.TournamentFormat to bracket.Format
-	var bracketFormat bracket.Format
-	switch tournament.Format {
-	case domain.SingleElimination:
-		bracketFormat = bracket.SingleElimination
-	case domain.DoubleElimination:
-		bracketFormat = bracket.DoubleElimination
-	case domain.RoundRobin:
-		bracketFormat = bracket.RoundRobin
-	case domain.Swiss:
-		bracketFormat = bracket.Swiss
-	default:
-		return fmt.Errorf("unsupported tournament format: %s", tournament.Format)
+	// Nobody has been seeded yet, so seed by registration order rather than leave the order arbitrary
+	seeded := false
+	for _, participant := range participants {
+		if participant.Seed != 0 {
+			seeded = true
+			break
+		}
+	}
+	if !seeded {
+		if err := s.AssignSeeds(ctx, tournamentID, SeedByRegistrationOrder); err != nil {
+			return fmt.Errorf("failed to assign seeds: %w", err)
+		}
+		participants, err = s.participantRepo.ListByTournament(ctx, tournamentID)
+		if err != nil {
+			return fmt.Errorf("failed to get participants: %w", err)
+		}
+		participants = confirmedParticipants(participants)
+	}
+
+	bracketFormat, err := toBracketFormat(tournament.Format)
+	if err != nil {
+		return err
 	}
 
 	// Generate bracket based on tournament format
 	var matches []*domain.Match
-	options := make(map[string]interface{})
-	fmt.Println(">>> Generating brackets")
+	options := bracketOptions(tournament)
 	matches, err = s.bracketGenerator.Generate(ctx, tournamentID, bracketFormat, participants, options)
 	if err != nil {
 		return fmt.Errorf("failed to generate bracket: %w", err)
 	}
-	fmt.Println("[OK] -> Generated brackets")
-	for _, match := range matches {
-		fmt.Printf("{%#v}/n", *match)
+
+	// Byes are generated as one-sided matches; complete them before saving so the lone
+	// participant is advanced in the same transaction as the rest of the bracket
+	completeByes(ctx, matches)
+
+	// The old bracket is only cleared together with saving the new one, so a failure leaves it intact
+	if len(existingMatches) > 0 {
+		logging.Infof(ctx, "Replacing %d existing matches for tournament %s", len(existingMatches), tournamentID)
+	}
+	if err := s.matchRepo.ReplaceBracket(ctx, tournamentID, matches); err != nil {
+		return fmt.Errorf("failed to save bracket: %w", err)
 	}
-	fmt.Println("[OK] <- Generated brackets")
 
-	// First, create all matches without next_match_id or loser_next_match_id
-	matchesWithoutReferences := make([]*domain.Match, len(matches))
-	for i, match := range matches {
-		matchCopy := *match
-		matchCopy.NextMatchID = nil
-		matchCopy.LoserNextMatchID = nil
-		matchesWithoutReferences[i] = &matchCopy
+	return nil
+}
+
+// toBracketFormat converts domain.TournamentFormat to bracket.Format
+func toBracketFormat(format domain.TournamentFormat) (bracket.Format, error) {
+	switch format {
+	case domain.SingleElimination:
+		return bracket.SingleElimination, nil
+	case domain.DoubleElimination:
+		return bracket.DoubleElimination, nil
+	case domain.RoundRobin:
+		return bracket.RoundRobin, nil
+	case domain.Swiss:
+		return bracket.Swiss, nil
 	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
 
-	// Save matches without references
-	for _, match := range matchesWithoutReferences {
-		if err := s.matchRepo.Create(ctx, match); err != nil {
-			return fmt.Errorf("failed to create match: %w", err)
+// bracketOptions reads the generator options an organizer set in custom_fields:
+// {"full_bracket": true} lays out a single elimination bracket with explicit bye matches, and
+// "bye_strategy" ("challonge" or "standard") picks where byes fall in the compact layout
+func bracketOptions(tournament *domain.Tournament) map[string]interface{} {
+	options := make(map[string]interface{})
+	if len(tournament.CustomFields) == 0 {
+		return options
+	}
+	var fields struct {
+		FullBracket bool   `json:"full_bracket"`
+		ByeStrategy string `json:"bye_strategy"`
+	}
+	if err := json.Unmarshal(tournament.CustomFields, &fields); err != nil {
+		log.Printf("Warning: could not read bracket options of tournament %s: %v", tournament.ID, err)
+		return options
+	}
+	if fields.FullBracket {
+		options["full_bracket"] = true
+	}
+	if fields.ByeStrategy != "" {
+		strategy, err := seeding.ParseByeStrategy(fields.ByeStrategy)
+		if err != nil {
+			log.Printf("Warning: ignoring bye strategy of tournament %s: %v", tournament.ID, err)
+		} else {
+			options["bye_strategy"] = string(strategy)
 		}
 	}
+	return options
+}
 
-	// Now update matches with their next_match_id and loser_next_match_id
-	for i, match := range matches {
-		needsUpdate := false
-
+// completeByes auto-completes matches that have exactly one participant and no other match
+// feeding into them, recording that participant as the winner and advancing them into the
+// following match. It only changes the matches in memory, so a new bracket can be saved with its
+// byes already resolved; following matches that are not in matches are left alone.
+func completeByes(ctx context.Context, matches []*domain.Match) {
+	// A one-sided match that is still fed by an earlier match is waiting on a result, not a bye
+	fedMatches := make(map[uuid.UUID]bool)
+	byID := make(map[uuid.UUID]*domain.Match, len(matches))
+	for _, match := range matches {
+		byID[match.ID] = match
 		if match.NextMatchID != nil {
-			matchesWithoutReferences[i].NextMatchID = match.NextMatchID
-			needsUpdate = true
+			fedMatches[*match.NextMatchID] = true
 		}
-
 		if match.LoserNextMatchID != nil {
-			matchesWithoutReferences[i].LoserNextMatchID = match.LoserNextMatchID
-			needsUpdate = true
+			fedMatches[*match.LoserNextMatchID] = true
 		}
+	}
 
-		if needsUpdate {
-			if err := s.matchRepo.Update(ctx, matchesWithoutReferences[i]); err != nil {
-				return fmt.Errorf("failed to update match with references: %w", err)
-			}
+	for _, match := range matches {
+		if match.Status == domain.MatchCompleted || fedMatches[match.ID] {
+			continue
+		}
+		var byeParticipantID *uuid.UUID
+		switch {
+		case match.Participant1ID != nil && match.Participant2ID == nil:
+			byeParticipantID = match.Participant1ID
+		case match.Participant1ID == nil && match.Participant2ID != nil:
+			byeParticipantID = match.Participant2ID
+		default:
+			continue
 		}
-	}
 
-	return nil
+		now := time.Now()
+		match.Status = domain.MatchCompleted
+		match.WinnerID = byeParticipantID
+		match.CompletedTime = &now
+		if match.MatchNotes == "" {
+			match.MatchNotes = "Bye - advanced automatically"
+		}
+		logging.Infof(ctx, "Resolved bye in match %s: P-%s advances", match.ID, *byeParticipantID)
+
+		if match.NextMatchID == nil {
+			continue
+		}
+		nextMatch, ok := byID[*match.NextMatchID]
+		if !ok {
+			logging.Warnf(ctx, "completeByes - next match %s of bye %s is not part of the bracket.", *match.NextMatchID, match.ID)
+			continue
+		}
+		if nextMatch.Participant1ID == nil {
+			nextMatch.Participant1ID = byeParticipantID
+		} else if nextMatch.Participant2ID == nil {
+			nextMatch.Participant2ID = byeParticipantID
+		} else {
+			logging.Warnf(ctx, "completeByes - next match %s already has both participants assigned.", nextMatch.ID)
+		}
+	}
 }
 
 // GetMatches retrieves all matches for a tournament
@@ -831,31 +1532,76 @@ func (s *tournamentService) GetMatches(ctx context.Context, tournamentID uuid.UU
 	// Map to response
 	responses := make([]*domain.MatchResponse, len(matches))
 	for i, match := range matches {
-		responses[i] = &domain.MatchResponse{
-			ID:                match.ID,
-			TournamentID:      match.TournamentID,
-			Round:             match.Round,
-			MatchNumber:       match.MatchNumber,
-			Participant1ID:    match.Participant1ID,
-			Participant2ID:    match.Participant2ID,
-			WinnerID:          match.WinnerID,
-			LoserID:           match.LoserID,
-			ScoreParticipant1: match.ScoreParticipant1,
-			ScoreParticipant2: match.ScoreParticipant2,
-			Status:            match.Status,
-			ScheduledTime:     match.ScheduledTime,
-			CompletedTime:     match.CompletedTime,
-			NextMatchID:       match.NextMatchID,
-			LoserNextMatchID:  match.LoserNextMatchID,
-			CreatedAt:         match.CreatedAt,
-			MatchNotes:        match.MatchNotes,
-			MatchProofs:       match.MatchProofs,
-		}
+		responses[i] = toMatchResponse(match)
 	}
 
 	return responses, nil
 }
 
+// ListMatches retrieves a filtered page of a tournament's matches and the total count the
+// filter matches, in a stable bracket, round and match number order
+func (s *tournamentService) ListMatches(
+	ctx context.Context, tournamentID uuid.UUID, filter domain.MatchFilter,
+) ([]*domain.MatchResponse, int, error) {
+	matches, total, err := s.matchRepo.ListByTournament(ctx, tournamentID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list matches: %w", err)
+	}
+
+	responses := make([]*domain.MatchResponse, len(matches))
+	for i, match := range matches {
+		responses[i] = toMatchResponse(match)
+	}
+	return responses, total, nil
+}
+
+// GetMatch retrieves a single match of the tournament. Matches of other tournaments are
+// reported as not found.
+func (s *tournamentService) GetMatch(ctx context.Context, tournamentID, matchID uuid.UUID) (*domain.MatchResponse, error) {
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get match %s: %w", matchID, err)
+	}
+	if match.TournamentID != tournamentID {
+		return nil, ErrMatchNotFound
+	}
+	return toMatchResponse(match), nil
+}
+
+// toMatchResponse maps a match to its API representation
+func toMatchResponse(match *domain.Match) *domain.MatchResponse {
+	return &domain.MatchResponse{
+		ID:                match.ID,
+		TournamentID:      match.TournamentID,
+		Round:             match.Round,
+		MatchNumber:       match.MatchNumber,
+		Participant1ID:    match.Participant1ID,
+		Participant2ID:    match.Participant2ID,
+		WinnerID:          match.WinnerID,
+		LoserID:           match.LoserID,
+		ScoreParticipant1: match.ScoreParticipant1,
+		ScoreParticipant2: match.ScoreParticipant2,
+		Status:            match.Status,
+		ScheduledTime:     match.ScheduledTime,
+		CompletedTime:     match.CompletedTime,
+		ReportingDeadline: match.ReportingDeadline,
+		NextMatchID:       match.NextMatchID,
+		LoserNextMatchID:  match.LoserNextMatchID,
+		CreatedAt:         match.CreatedAt,
+		MatchNotes:        match.MatchNotes,
+		MatchProofs:       match.MatchProofs,
+		Games:             match.Games,
+		BracketType:       match.BracketType,
+		Version:           match.Version,
+		Participant1Name:  match.Participant1Name,
+		Participant2Name:  match.Participant2Name,
+		WinnerName:        match.WinnerName,
+	}
+}
+
 // GetMatchesByRound retrieves matches for a specific round
 func (s *tournamentService) GetMatchesByRound(
 	ctx context.Context, tournamentID uuid.UUID, round int,
@@ -869,26 +1615,7 @@ func (s *tournamentService) GetMatchesByRound(
 	// Map to response
 	responses := make([]*domain.MatchResponse, len(matches))
 	for i, match := range matches {
-		responses[i] = &domain.MatchResponse{
-			ID:                match.ID,
-			TournamentID:      match.TournamentID,
-			Round:             match.Round,
-			MatchNumber:       match.MatchNumber,
-			Participant1ID:    match.Participant1ID,
-			Participant2ID:    match.Participant2ID,
-			WinnerID:          match.WinnerID,
-			LoserID:           match.LoserID,
-			ScoreParticipant1: match.ScoreParticipant1,
-			ScoreParticipant2: match.ScoreParticipant2,
-			Status:            match.Status,
-			ScheduledTime:     match.ScheduledTime,
-			CompletedTime:     match.CompletedTime,
-			NextMatchID:       match.NextMatchID,
-			LoserNextMatchID:  match.LoserNextMatchID,
-			CreatedAt:         match.CreatedAt,
-			MatchNotes:        match.MatchNotes,
-			MatchProofs:       match.MatchProofs,
-		}
+		responses[i] = toMatchResponse(match)
 	}
 
 	return responses, nil
@@ -907,26 +1634,7 @@ func (s *tournamentService) GetMatchesByParticipant(
 	// Map to response
 	responses := make([]*domain.MatchResponse, len(matches))
 	for i, match := range matches {
-		responses[i] = &domain.MatchResponse{
-			ID:                match.ID,
-			TournamentID:      match.TournamentID,
-			Round:             match.Round,
-			MatchNumber:       match.MatchNumber,
-			Participant1ID:    match.Participant1ID,
-			Participant2ID:    match.Participant2ID,
-			WinnerID:          match.WinnerID,
-			LoserID:           match.LoserID,
-			ScoreParticipant1: match.ScoreParticipant1,
-			ScoreParticipant2: match.ScoreParticipant2,
-			Status:            match.Status,
-			ScheduledTime:     match.ScheduledTime,
-			CompletedTime:     match.CompletedTime,
-			NextMatchID:       match.NextMatchID,
-			LoserNextMatchID:  match.LoserNextMatchID,
-			CreatedAt:         match.CreatedAt,
-			MatchNotes:        match.MatchNotes,
-			MatchProofs:       match.MatchProofs,
-		}
+		responses[i] = toMatchResponse(match)
 	}
 
 	return responses, nil
@@ -944,71 +1652,206 @@ const (
 	RS_Loss RS_ResultType = "LOSS"
 )
 
+// RS_EventType tells the Ranking Service whether a result is new or corrects one it already applied
+type RS_EventType string
+
+const (
+	RS_Result     RS_EventType = "RESULT"
+	RS_Correction RS_EventType = "CORRECTION"
+	RS_Reversal   RS_EventType = "REVERSAL" // Takes back a result when the match is reset
+)
+
 type RS_UserMatchOutcome struct {
 	UserID  uuid.UUID     `json:"userId"` // Ensure JSON tag matches Ranking Service expected input
 	Outcome RS_ResultType `json:"outcome"`
 }
 
 type RS_MatchResultEvent struct {
-	GameID    string                `json:"gameId,omitempty"`
+	Type         RS_EventType          `json:"type,omitempty"`
+	GameID       string                `json:"gameId,omitempty"`
 	TournamentID uuid.UUID             `json:"tournamentId,omitempty"`
-	Users     []RS_UserMatchOutcome `json:"users"`
-	MatchID   uuid.UUID             `json:"matchId,omitempty"`
-	Timestamp time.Time             `json:"timestamp"`
+	Users        []RS_UserMatchOutcome `json:"users"`
+	MatchID      uuid.UUID             `json:"matchId,omitempty"`
+	Timestamp    time.Time             `json:"timestamp"`
+	PointsWin    *int                  `json:"pointsWin,omitempty"`
+	PointsDraw   *int                  `json:"pointsDraw,omitempty"`
+	PointsLoss   *int                  `json:"pointsLoss,omitempty"`
+	Elo          bool                  `json:"elo,omitempty"` // Also update the players' Elo ratings
 }
 
 // --- End DTO definitions ---
 
-//With activity recording
+// rankingPoints reads the optional ranking points an organizer configured under
+// custom_fields.ranking_points, e.g. {"ranking_points": {"win": 2, "draw": 1, "loss": 0}}.
+// Values left out are nil so the Ranking Service applies its 3/1/0 defaults.
+func rankingPoints(tournament *domain.Tournament) (win, draw, loss *int) {
+	if len(tournament.CustomFields) == 0 {
+		return nil, nil, nil
+	}
+	var fields struct {
+		RankingPoints struct {
+			Win  *int `json:"win"`
+			Draw *int `json:"draw"`
+			Loss *int `json:"loss"`
+		} `json:"ranking_points"`
+	}
+	if err := json.Unmarshal(tournament.CustomFields, &fields); err != nil {
+		log.Printf("Warning: could not read ranking points of tournament %s: %v", tournament.ID, err)
+		return nil, nil, nil
+	}
+	return fields.RankingPoints.Win, fields.RankingPoints.Draw, fields.RankingPoints.Loss
+}
+
+// rankingElo reports whether the organizer asked for the tournament's matches to move the
+// players' Elo ratings, with custom_fields.ranking_elo set to true
+func rankingElo(tournament *domain.Tournament) bool {
+	if len(tournament.CustomFields) == 0 {
+		return false
+	}
+	var fields struct {
+		RankingElo bool `json:"ranking_elo"`
+	}
+	if err := json.Unmarshal(tournament.CustomFields, &fields); err != nil {
+		log.Printf("Warning: could not read ranking_elo of tournament %s: %v", tournament.ID, err)
+		return false
+	}
+	return fields.RankingElo
+}
+
+// rankingOutboxEntry builds the outbox entry that reports a match outcome to the Ranking Service.
+// It returns nil if either participant is not linked to a platform user, since there is nobody to rank.
+func rankingOutboxEntry(
+	tournament *domain.Tournament, match *domain.Match, p1, p2 *domain.Participant,
+	p1Outcome, p2Outcome RS_ResultType, eventType RS_EventType, at time.Time,
+) (*domain.OutboxEntry, error) {
+	if p1.UserID == nil || p2.UserID == nil {
+		log.Printf("Warning: One or both participants of match %s (P1: %s - UserID: %v, P2: %s - UserID: %v) missing linked platform UserID. Ranking not notified.",
+			match.ID, p1.ParticipantName, p1.UserID, p2.ParticipantName, p2.UserID)
+		return nil, nil
+	}
+	rankingEvent := RS_MatchResultEvent{
+		Type:         eventType,
+		GameID:       tournament.Game, // GameID from the tournament
+		TournamentID: tournament.ID,
+		MatchID:      match.ID,
+		Timestamp:    at,
+		Users: []RS_UserMatchOutcome{
+			{UserID: *p1.UserID, Outcome: p1Outcome}, // Platform UserID
+			{UserID: *p2.UserID, Outcome: p2Outcome}, // Platform UserID
+		},
+	}
+	rankingEvent.PointsWin, rankingEvent.PointsDraw, rankingEvent.PointsLoss = rankingPoints(tournament)
+	rankingEvent.Elo = rankingElo(tournament)
+
+	payload, err := json.Marshal(rankingEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ranking event for match %s: %w", match.ID, err)
+	}
+	return &domain.OutboxEntry{MatchID: match.ID, Payload: payload}, nil
+}
+
+// ErrByeMatch is returned when reporting a score for a bye, which was completed automatically
+// when the bracket was generated and has no opponent to score against
+var ErrByeMatch = domain.NewError(domain.ErrConflict, "match is a bye and has no score to report")
+
+// ErrMatchAwaitingParticipants is returned when reporting a score for a match whose participants
+// are still to be decided by earlier matches
+var ErrMatchAwaitingParticipants = domain.NewError(domain.ErrConflict, "match is still waiting for its participants to be decided")
+
+// With activity recording
 // UpdateMatchScore updates the score of a match, advances winners, and notifies ranking service.
+// It returns the updated match together with the IDs of the downstream matches it modified.
 func (s *tournamentService) UpdateMatchScore(
 	ctx context.Context, tournamentID uuid.UUID, matchID uuid.UUID, reportingUserID uuid.UUID,
 	request *domain.ScoreUpdateRequest,
-) error {
+) (*domain.MatchScoreUpdate, error) {
 	// 1. Get the match
 	match, err := s.matchRepo.GetByID(ctx, matchID)
 	if err != nil {
-		return fmt.Errorf("failed to get match %s: %w", matchID, err)
+		return nil, fmt.Errorf("failed to get match %s: %w", matchID, err)
 	}
 	if match.TournamentID != tournamentID {
-		return errors.New("match does not belong to this tournament")
+		return nil, ErrMatchNotFound
+	}
+	// The client reported against an older copy of the match than the one stored
+	if request.Version != nil && *request.Version != match.Version {
+		return nil, domain.ErrConcurrentModification
 	}
 
 	// 2. Get the tournament (needed for GameID and format checks)
 	tournament, errT := s.tournamentRepo.GetByID(ctx, tournamentID)
 	if errT != nil {
-		return fmt.Errorf("failed to get tournament %s: %w", tournamentID, errT)
+		return nil, fmt.Errorf("failed to get tournament %s: %w", tournamentID, errT)
+	}
+
+	// A cancelled bracket reset cannot be played, and a played one fixes the grand finals result
+	if tournament.Format == domain.DoubleElimination {
+		if err := s.validateGrandFinalsUpdate(ctx, match); err != nil {
+			return nil, err
+		}
 	}
 
 	// 3. Ensure participants are assigned to the match
 	if match.Participant1ID == nil || match.Participant2ID == nil {
-		return errors.New("cannot update score: match participants not fully assigned")
+		// Byes are completed by completeByes with the lone participant as winner and never reach
+		// the Ranking Service, so they cannot be scored afterwards either
+		if match.Status == domain.MatchCompleted {
+			return nil, ErrByeMatch
+		}
+		return nil, ErrMatchAwaitingParticipants
 	}
 
 	// 4. Fetch the full participant entries (these contain ParticipantName and linked platform UserID)
 	p1Entry, errP1 := s.participantRepo.GetByID(ctx, *match.Participant1ID)
 	if errP1 != nil || p1Entry == nil {
-		log.Printf("Error fetching participant 1 (P_ID: %s) details for M_ID %s: %v", *match.Participant1ID, matchID, errP1)
-		return fmt.Errorf("failed to get details for participant 1 (%s): %w", *match.Participant1ID, errP1)
+		logging.Errorf(ctx, "Error fetching participant 1 (P_ID: %s) details for M_ID %s: %v", *match.Participant1ID, matchID, errP1)
+		return nil, fmt.Errorf("failed to get details for participant 1 (%s): %w", *match.Participant1ID, errP1)
 	}
 
 	p2Entry, errP2 := s.participantRepo.GetByID(ctx, *match.Participant2ID)
 	if errP2 != nil || p2Entry == nil {
-		log.Printf("Error fetching participant 2 (P_ID: %s) details for M_ID %s: %v", *match.Participant2ID, matchID, errP2)
-		return fmt.Errorf("failed to get details for participant 2 (%s): %w", *match.Participant2ID, errP2)
+		logging.Errorf(ctx, "Error fetching participant 2 (P_ID: %s) details for M_ID %s: %v", *match.Participant2ID, matchID, errP2)
+		return nil, fmt.Errorf("failed to get details for participant 2 (%s): %w", *match.Participant2ID, errP2)
 	}
 
-	// 5. Update match scores from request
-	match.ScoreParticipant1 = request.ScoreParticipant1
-	match.ScoreParticipant2 = request.ScoreParticipant2
+	// A score changed after completion must correct the outcome already sent to the Ranking Service
+	wasCompleted := match.Status == domain.MatchCompleted
+
+	// Organizers report and correct any match; the match's players may report its result once
+	if !canReportMatch(tournament, reportingUserID, wasCompleted, p1Entry, p2Entry) {
+		return nil, &ErrNotAuthorized{TournamentID: tournamentID, UserID: reportingUserID}
+	}
+
+	// A correction takes the previous winner and loser back out of the matches they advanced to
+	// before the new result is advanced, which is refused once one of those matches has started
+	var released []*domain.Match
+	if wasCompleted && match.WinnerID != nil {
+		released, err = s.resetDownstreamMatches(ctx, tournament, match, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 5. Update match scores from request; a series reported game by game scores the games won
+	if len(request.Games) > 0 {
+		wins1, wins2, err := seriesScore(request.Games, seriesLength(tournament))
+		if err != nil {
+			return nil, err
+		}
+		match.ScoreParticipant1, match.ScoreParticipant2 = wins1, wins2
+		match.Games = request.Games
+	} else {
+		match.ScoreParticipant1 = request.ScoreParticipant1
+		match.ScoreParticipant2 = request.ScoreParticipant2
+		match.Games = nil
+	}
 	if request.MatchNotes != "" {
 		match.MatchNotes = request.MatchNotes
 	}
 	if len(request.MatchProofs) > 0 {
 		match.MatchProofs = request.MatchProofs
 	}
-	log.Printf("Updating scores for Match %s: %s (%d) vs %s (%d)", matchID, p1Entry.ParticipantName, match.ScoreParticipant1, p2Entry.ParticipantName, match.ScoreParticipant2)
-
+	logging.Infof(ctx, "Updating scores for Match %s: %s (%d) vs %s (%d)", matchID, p1Entry.ParticipantName, match.ScoreParticipant1, p2Entry.ParticipantName, match.ScoreParticipant2)
 
 	// 6. Determine winner (Participant.ID), loser (Participant.ID), and outcomes for Ranking Service
 	var p1OutcomeForRanking RS_ResultType // Use your RS_ResultType
@@ -1017,20 +1860,33 @@ func (s *tournamentService) UpdateMatchScore(
 
 	if match.ScoreParticipant1 == match.ScoreParticipant2 {
 		// Since you specified "no draw"
-		return fmt.Errorf("ties are not allowed in this tournament format; scores were %d-%d for match %s",
-			match.ScoreParticipant1, match.ScoreParticipant2, matchID)
+		return nil, domain.NewError(domain.ErrValidation, fmt.Sprintf(
+			"ties are not allowed in this tournament format; scores were %d-%d for match %s",
+			match.ScoreParticipant1, match.ScoreParticipant2, matchID))
 	} else if match.ScoreParticipant1 > match.ScoreParticipant2 {
 		determinedWinnerPID = match.Participant1ID // p1Entry.ID
 		determinedLoserPID = match.Participant2ID  // p2Entry.ID
 		p1OutcomeForRanking = RS_Win
 		p2OutcomeForRanking = RS_Loss
 	} else { // ScoreParticipant2 > ScoreParticipant1
-		determinedWinnerPID = match.Participant2ID  // p2Entry.ID
-		determinedLoserPID = match.Participant1ID // p1Entry.ID
+		determinedWinnerPID = match.Participant2ID // p2Entry.ID
+		determinedLoserPID = match.Participant1ID  // p1Entry.ID
 		p1OutcomeForRanking = RS_Loss
 		p2OutcomeForRanking = RS_Win
 	}
 
+	// Grand finals scores must respect the winners finalist's starting advantage
+	var winnersFinalist *uuid.UUID
+	if tournament.Format == domain.DoubleElimination && match.BracketType == domain.GrandFinals {
+		winnersFinalist, err = s.grandFinalsWinnersFinalist(ctx, match)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve grand finals participants: %w", err)
+		}
+		if err := validateGrandFinalsScore(match, winnersFinalist, tournament.GrandFinalsAdvantage); err != nil {
+			return nil, err
+		}
+	}
+
 	// 7. Update match record in the database
 	match.Status = domain.MatchCompleted
 	now := time.Now()
@@ -1038,39 +1894,37 @@ func (s *tournamentService) UpdateMatchScore(
 	match.WinnerID = determinedWinnerPID
 	match.LoserID = determinedLoserPID
 
-	err = s.matchRepo.Update(ctx, match)
+	// 8. --- Queue Ranking Service notification ---
+	// The event goes into the ranking outbox in the same transaction as the match update;
+	// the RankingOutboxWorker delivers it and retries until the Ranking Service accepts it.
+	eventType := RS_Result
+	if wasCompleted {
+		eventType = RS_Correction
+	}
+	outboxEntry, err := rankingOutboxEntry(tournament, match, p1Entry, p2Entry, p1OutcomeForRanking, p2OutcomeForRanking, eventType, now)
 	if err != nil {
-		return fmt.Errorf("failed to update match %s in repository: %w", match.ID, err)
-	}
-	log.Printf("Match %s successfully updated in DB. WinnerPID: %v, LoserPID: %v", match.ID, match.WinnerID, match.LoserID)
-
-	// 8. --- Notify Ranking Service ---
-	if p1Entry.UserID != nil && p2Entry.UserID != nil { // Check if platform UserIDs are linked
-		rankingEvent := RS_MatchResultEvent{
-			GameID:       tournament.Game, // GameID from the tournament
-			TournamentID: tournamentID,
-			MatchID:      match.ID,
-			Timestamp:    time.Now(),
-			Users: []RS_UserMatchOutcome{
-				{UserID: *p1Entry.UserID, Outcome: p1OutcomeForRanking}, // Platform UserID
-				{UserID: *p2Entry.UserID, Outcome: p2OutcomeForRanking}, // Platform UserID
-			},
-		}
-		 go s.notifyRankingService(rankingEvent) // Assuming this is your async call
-		// For now, let's make it synchronous for easier debugging if notifyRankingService can error
-		// if errNotify := s.notifyRankingService(rankingEvent); errNotify != nil {
-		// 	log.Printf("Warning: UpdateMatchScore - Failed to notify ranking service for match %s: %v", matchID, errNotify)
-		// 	// Decide if this should be a critical error that rolls back or just a warning.
-		// 	// For now, it's just a warning and the flow continues.
-		// } else {
-		// 	log.Printf("UpdateMatchScore: Successfully notified ranking service for match %s", matchID)
-		// }
+		return nil, err
+	}
+
+	if outboxEntry != nil {
+		err = s.matchRepo.UpdateWithOutbox(ctx, match, outboxEntry)
 	} else {
-		log.Printf("Warning: UpdateMatchScore - One or both participants (P1: %s - UserID: %v, P2: %s - UserID: %v) missing linked platform UserID. Ranking not notified.",
-			p1Entry.ParticipantName, p1Entry.UserID, p2Entry.ParticipantName, p2Entry.UserID)
+		err = s.matchRepo.Update(ctx, match)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update match %s in repository: %w", match.ID, err)
 	}
-	// --- END Notify Ranking Service ---
+	logging.Infof(ctx, "Match %s successfully updated in DB. WinnerPID: %v, LoserPID: %v", match.ID, match.WinnerID, match.LoserID)
+	// --- END Ranking Service notification ---
 
+	// Downstream matches touched here are collected so the caller can refresh just those
+	updatedMatchIDs := []uuid.UUID{}
+	for _, next := range released {
+		if err := s.matchRepo.Update(ctx, next); err != nil {
+			return nil, fmt.Errorf("failed to remove the previous result from match %s: %w", next.ID, err)
+		}
+		updatedMatchIDs = append(updatedMatchIDs, next.ID)
+	}
 
 	// 9. --- RECORD ACTIVITIES for MATCH_WON and MATCH_LOST ---
 	if s.userActivityService != nil {
@@ -1105,12 +1959,12 @@ func (s *tournamentService) UpdateMatchScore(
 				ctx, *winnerPlatformUserID, domain.ActivityMatchWon, descWin, &matchID, &matchEntityType, &matchContextURL,
 			)
 			if activityErr != nil {
-				log.Printf("Warning: UpdateMatchScore - Failed to record MATCH_WON for U-%s: %v", *winnerPlatformUserID, activityErr)
+				logging.Warnf(ctx, "UpdateMatchScore - Failed to record MATCH_WON for U-%s: %v", *winnerPlatformUserID, activityErr)
 			} else {
-				log.Printf("UpdateMatchScore - Successfully recorded MATCH_WON for U-%s (P-%s, Match: %s)", *winnerPlatformUserID, *determinedWinnerPID, matchID)
+				logging.Infof(ctx, "UpdateMatchScore - Successfully recorded MATCH_WON for U-%s (P-%s, Match: %s)", *winnerPlatformUserID, *determinedWinnerPID, matchID)
 			}
 		} else {
-			log.Printf("Warning: UpdateMatchScore - Winner (P-%s) has no linked platform UserID. MATCH_WON activity not recorded.", *determinedWinnerPID)
+			logging.Warnf(ctx, "UpdateMatchScore - Winner (P-%s) has no linked platform UserID. MATCH_WON activity not recorded.", *determinedWinnerPID)
 		}
 
 		// Activity for Loser
@@ -1120,19 +1974,18 @@ func (s *tournamentService) UpdateMatchScore(
 				ctx, *loserPlatformUserID, domain.ActivityMatchLost, descLoss, &matchID, &matchEntityType, &matchContextURL,
 			)
 			if activityErr != nil {
-				log.Printf("Warning: UpdateMatchScore - Failed to record MATCH_LOST for U-%s: %v", *loserPlatformUserID, activityErr)
+				logging.Warnf(ctx, "UpdateMatchScore - Failed to record MATCH_LOST for U-%s: %v", *loserPlatformUserID, activityErr)
 			} else {
-				log.Printf("UpdateMatchScore - Successfully recorded MATCH_LOST for U-%s (P-%s, Match: %s)", *loserPlatformUserID, *determinedLoserPID, matchID)
+				logging.Infof(ctx, "UpdateMatchScore - Successfully recorded MATCH_LOST for U-%s (P-%s, Match: %s)", *loserPlatformUserID, *determinedLoserPID, matchID)
 			}
 		} else {
-			log.Printf("Warning: UpdateMatchScore - Loser (P-%s) has no linked platform UserID. MATCH_LOST activity not recorded.", *determinedLoserPID)
+			logging.Warnf(ctx, "UpdateMatchScore - Loser (P-%s) has no linked platform UserID. MATCH_LOST activity not recorded.", *determinedLoserPID)
 		}
 	} else {
 		log.Println("Warning: UpdateMatchScore - userActivityService is nil. Cannot record activities.")
 	}
 	// --- END RECORD ACTIVITIES ---
 
-
 	// 10. --- Post-Update Logic: Advancement and Tournament Completion ---
 	// This logic uses determinedWinnerPID (Participant.ID of the winner)
 	if determinedWinnerPID != nil { // This will always be true if no draws are allowed and scores differ
@@ -1140,7 +1993,7 @@ func (s *tournamentService) UpdateMatchScore(
 		if match.NextMatchID != nil {
 			nextMatch, errGetNext := s.matchRepo.GetByID(ctx, *match.NextMatchID)
 			if errGetNext != nil {
-				log.Printf("Warning: UpdateMatchScore - Error getting next match %s for winner of %s: %v", *match.NextMatchID, matchID, errGetNext)
+				logging.Warnf(ctx, "UpdateMatchScore - Error getting next match %s for winner of %s: %v", *match.NextMatchID, matchID, errGetNext)
 				// Potentially return an error here or just log if advancement isn't critical to fail the whole op
 			} else {
 				assigned := false
@@ -1151,12 +2004,18 @@ func (s *tournamentService) UpdateMatchScore(
 					nextMatch.Participant2ID = determinedWinnerPID
 					assigned = true
 				} else {
-					log.Printf("Warning: UpdateMatchScore - Winner's next match %s already has both participants assigned.", nextMatch.ID)
+					logging.Warnf(ctx, "UpdateMatchScore - Winner's next match %s already has both participants assigned.", nextMatch.ID)
+				}
+				if assigned && match.BracketType == domain.WinnersBracket && nextMatch.BracketType == domain.GrandFinals {
+					applyGrandFinalsAdvantage(nextMatch, *determinedWinnerPID, tournament.GrandFinalsAdvantage)
 				}
 				if assigned {
 					if errUpdateNext := s.matchRepo.Update(ctx, nextMatch); errUpdateNext != nil {
-						log.Printf("Warning: UpdateMatchScore - Error updating next match %s with winner %s: %v", nextMatch.ID, *determinedWinnerPID, errUpdateNext)
+						logging.Warnf(ctx, "UpdateMatchScore - Error updating next match %s with winner %s: %v", nextMatch.ID, *determinedWinnerPID, errUpdateNext)
 						// Potentially return an error
+					} else {
+						updatedMatchIDs = append(updatedMatchIDs, nextMatch.ID)
+						s.resolveWalkoverAfterAdvance(ctx, nextMatch)
 					}
 				}
 			}
@@ -1166,7 +2025,7 @@ func (s *tournamentService) UpdateMatchScore(
 		if tournament.Format == domain.DoubleElimination && determinedLoserPID != nil && match.LoserNextMatchID != nil {
 			loserNextMatch, errGetLoser := s.matchRepo.GetByID(ctx, *match.LoserNextMatchID)
 			if errGetLoser != nil {
-				log.Printf("Warning: UpdateMatchScore - Failed to get loser's next match %s: %v", *match.LoserNextMatchID, errGetLoser)
+				logging.Warnf(ctx, "UpdateMatchScore - Failed to get loser's next match %s: %v", *match.LoserNextMatchID, errGetLoser)
 			} else {
 				assigned := false
 				if loserNextMatch.Participant1ID == nil {
@@ -1178,11 +2037,24 @@ func (s *tournamentService) UpdateMatchScore(
 				}
 				if assigned {
 					if errUpdateLoser := s.matchRepo.Update(ctx, loserNextMatch); errUpdateLoser != nil {
-						log.Printf("Warning: UpdateMatchScore - Failed to update loser's next match %s with P-%s: %v", loserNextMatch.ID, *determinedLoserPID, errUpdateLoser)
+						logging.Warnf(ctx, "UpdateMatchScore - Failed to update loser's next match %s with P-%s: %v", loserNextMatch.ID, *determinedLoserPID, errUpdateLoser)
+					} else {
+						updatedMatchIDs = append(updatedMatchIDs, loserNextMatch.ID)
+						s.resolveWalkoverAfterAdvance(ctx, loserNextMatch)
 					}
 				}
 			}
 		}
+
+		// Decide whether the bracket reset is played once the grand finals are complete
+		if winnersFinalist != nil {
+			reset, errReset := s.resolveBracketReset(ctx, tournament, match, *winnersFinalist)
+			if errReset != nil {
+				logging.Warnf(ctx, "UpdateMatchScore - Failed to resolve bracket reset after grand finals %s: %v", match.ID, errReset)
+			} else if reset != nil {
+				updatedMatchIDs = append(updatedMatchIDs, reset.ID)
+			}
+		}
 	}
 	// --- End Post-Update Logic ---
 
@@ -1191,11 +2063,11 @@ func (s *tournamentService) UpdateMatchScore(
 	// For simplicity, keeping it as is, but complex tournament completion might need its own flow.
 	completed, errCheck := s.checkTournamentCompletion(ctx, tournament.ID)
 	if errCheck != nil {
-		log.Printf("Warning (TID: %s): Failed to check tournament completion after match %s update: %v", tournamentID, matchID, errCheck)
+		logging.Warnf(ctx, "Warning (TID: %s): Failed to check tournament completion after match %s update: %v", tournamentID, matchID, errCheck)
 	} else if completed {
-		log.Printf("Tournament %s is now complete. Attempting to update status.", tournamentID)
-		if errStatusUpdate := s.UpdateTournamentStatus(ctx, tournament.ID, domain.Completed); errStatusUpdate != nil {
-			log.Printf("Warning (TID: %s): Failed to update tournament status to COMPLETED: %v", tournamentID, errStatusUpdate)
+		logging.Infof(ctx, "Tournament %s is now complete. Attempting to update status.", tournamentID)
+		if errStatusUpdate := s.updateTournamentStatus(ctx, tournament.ID, domain.Completed); errStatusUpdate != nil {
+			logging.Warnf(ctx, "Warning (TID: %s): Failed to update tournament status to COMPLETED: %v", tournamentID, errStatusUpdate)
 		}
 	}
 	if s.broadcastChan != nil {
@@ -1214,53 +2086,29 @@ func (s *tournamentService) UpdateMatchScore(
 			Payload: wsPayload,
 		}
 		s.broadcastChan <- wsMessage // Send struct, hub marshals
-		log.Printf("Broadcasted WSEventMatchScoreUpdated for M-%s", match.ID)
+		logging.Infof(ctx, "Broadcasted WSEventMatchScoreUpdated for M-%s", match.ID)
 	}
 
-	return nil
+	return &domain.MatchScoreUpdate{
+		Match:           toMatchResponse(match),
+		UpdatedMatchIDs: uniqueMatchIDs(updatedMatchIDs),
+	}, nil
 }
 
-// notifyRankingService helper method (as provided before)
-func (s *tournamentService) notifyRankingService(event RS_MatchResultEvent) {
-	rankingServiceURL := os.Getenv("RANKING_SERVICE_URL")
-	if rankingServiceURL == "" {
-		log.Println("Warning: RANKING_SERVICE_URL not set. Cannot notify ranking service.")
-		return
-	}
-
-	payloadBytes, err := json.Marshal(event)
-	if err != nil {
-		log.Printf("Error marshalling ranking event for match %s: %v", event.MatchID, err)
-		return
-	}
-
-	req, err := http.NewRequest("POST", rankingServiceURL+"/rankings/match-results", bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		log.Printf("Error creating request to ranking service for match %s: %v", event.MatchID, err)
-		return
-	}
-	req.Header.Set("Content-Type", "application/json")
-	// If your ranking service requires some form of inter-service auth key:
-	// req.Header.Set("X-Internal-Service-Key", os.Getenv("INTERNAL_SERVICE_KEY"))
-
-	client := &http.Client{Timeout: 10 * time.Second} // Increased timeout slightly
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Printf("Error POSTing to ranking service for match %s: %v", event.MatchID, err)
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= http.StatusBadRequest { // Check for 4xx and 5xx errors
-		// bodyBytes, _ := io.ReadAll(resp.Body) // Requires "io" package
-		log.Printf("Ranking service returned error status %d for match %s. Body might contain details.", resp.StatusCode, event.MatchID)
-		// log.Printf("Ranking service error body: %s", string(bodyBytes))
-	} else {
-		log.Printf("Successfully notified ranking service for match %s, status %d", event.MatchID, resp.StatusCode)
+// uniqueMatchIDs drops repeated IDs, keeping the first occurrence of each. A correction can free
+// and then refill the same following match.
+func uniqueMatchIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := ids[:0]
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
 	}
+	return unique
 }
 
-
 // checkTournamentCompletion checks if all matches in a tournament are completed
 func (s *tournamentService) checkTournamentCompletion(ctx context.Context, tournamentID uuid.UUID) (bool, error) {
 	matches, err := s.matchRepo.GetByTournamentID(ctx, tournamentID)
@@ -1269,7 +2117,8 @@ func (s *tournamentService) checkTournamentCompletion(ctx context.Context, tourn
 	}
 
 	for _, match := range matches {
-		if match.Status != domain.MatchCompleted {
+		// Cancelled matches (e.g. an unneeded bracket reset) do not hold up completion
+		if match.Status != domain.MatchCompleted && match.Status != domain.MatchCancelled {
 			return false, nil
 		}
 	}
@@ -1282,7 +2131,7 @@ func (s *tournamentService) SendMessage(
 	ctx context.Context, tournamentID uuid.UUID, userID uuid.UUID, request *domain.MessageRequest,
 ) (*domain.Message, error) {
 	// Check if tournament exists
-	_, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tournament: %w", err)
 	}
@@ -1302,6 +2151,20 @@ func (s *tournamentService) SendMessage(
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
 
+	// Push the message to clients following the tournament. WebSocket subscriptions are not
+	// authenticated, so private tournaments' chat is left to GET /messages.
+	if s.broadcastChan != nil && tournament.Visibility != domain.VisibilityPrivate {
+		s.broadcastChan <- domain.WebSocketMessage{
+			Type: domain.WSEventChatMessage,
+			Payload: domain.ChatMessagePayload{
+				TournamentID: tournamentID,
+				Message:      *toMessageResponse(message, s.lookupMessageAuthors(ctx, []*domain.Message{message})),
+			},
+			TournamentID: &tournament.ID,
+		}
+		logging.Infof(ctx, "Broadcasted WSEventChatMessage for T-%s", tournamentID)
+	}
+
 	return message, nil
 }
 
@@ -1321,22 +2184,203 @@ func (s *tournamentService) GetMessages(
 		return nil, fmt.Errorf("failed to get messages: %w", err)
 	}
 
-	// Map to response
-	responses := make([]*domain.MessageResponse, len(messages))
-	for i, message := range messages {
-		// In a real implementation, you would fetch username from a user service
-		username := fmt.Sprintf("User-%s", message.UserID.String()[:8])
+	return s.messageResponses(ctx, messages)
+}
 
-		responses[i] = &domain.MessageResponse{
-			ID:        message.ID,
-			UserID:    message.UserID,
-			Username:  username,
-			Message:   message.Message,
-			CreatedAt: message.CreatedAt,
+// getMatchThread loads a match of the tournament for its chat thread, which only the organizers
+// and the users behind the two match slots may read or post in
+func (s *tournamentService) getMatchThread(
+	ctx context.Context, tournamentID, matchID, userID uuid.UUID,
+) (*domain.Match, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
 		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
 	}
 
-	return responses, nil
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrMatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get match %s: %w", matchID, err)
+	}
+	if match.TournamentID != tournamentID {
+		return nil, ErrMatchNotFound
+	}
+
+	if isOrganizer(tournament, userID) {
+		return match, nil
+	}
+	for _, participantID := range []*uuid.UUID{match.Participant1ID, match.Participant2ID} {
+		if participantID == nil {
+			continue
+		}
+		participant, err := s.participantRepo.GetByID(ctx, *participantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get participant %s: %w", *participantID, err)
+		}
+		if participant != nil && participant.UserID != nil && *participant.UserID == userID {
+			return match, nil
+		}
+	}
+	return nil, ErrNotMatchMember
+}
+
+// SendMatchMessage posts a message to a single match's thread and broadcasts it
+func (s *tournamentService) SendMatchMessage(
+	ctx context.Context, tournamentID, matchID, userID uuid.UUID, request *domain.MessageRequest,
+) (*domain.Message, error) {
+	match, err := s.getMatchThread(ctx, tournamentID, matchID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &domain.Message{
+		ID:           uuid.New(),
+		TournamentID: tournamentID,
+		MatchID:      &match.ID,
+		UserID:       userID,
+		Message:      request.Message,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.messageRepo.Create(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	if s.broadcastChan != nil {
+		s.broadcastChan <- domain.WebSocketMessage{
+			Type: domain.WSEventMatchMessagePosted,
+			Payload: domain.MatchMessagePostedPayload{
+				TournamentID: tournamentID,
+				MatchID:      match.ID,
+				Message:      *toMessageResponse(message, s.lookupMessageAuthors(ctx, []*domain.Message{message})),
+			},
+		}
+		logging.Infof(ctx, "Broadcasted WSEventMatchMessagePosted for M-%s", match.ID)
+	}
+
+	return message, nil
+}
+
+// GetMatchMessages retrieves the chat thread of a single match for one of its participants or an organizer
+func (s *tournamentService) GetMatchMessages(
+	ctx context.Context, tournamentID, matchID, userID uuid.UUID, limit, offset int,
+) ([]*domain.MessageResponse, error) {
+	if _, err := s.getMatchThread(ctx, tournamentID, matchID, userID); err != nil {
+		return nil, err
+	}
+
+	messages, err := s.messageRepo.ListByMatch(ctx, matchID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	return s.messageResponses(ctx, messages)
+}
+
+// EditMessage replaces the text of a chat message; only its author or the tournament creator may edit it
+func (s *tournamentService) EditMessage(
+	ctx context.Context, tournamentID, messageID, userID uuid.UUID, request *domain.MessageRequest,
+) (*domain.MessageResponse, error) {
+	message, err := s.getEditableMessage(ctx, tournamentID, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	message.Message = request.Message
+	if err := s.messageRepo.Update(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to edit message: %w", err)
+	}
+
+	return s.messageResponse(ctx, message)
+}
+
+// DeleteMessage soft-deletes a chat message; only its author or the tournament creator may delete it
+func (s *tournamentService) DeleteMessage(ctx context.Context, tournamentID, messageID, userID uuid.UUID) error {
+	if _, err := s.getEditableMessage(ctx, tournamentID, messageID, userID); err != nil {
+		return err
+	}
+
+	if err := s.messageRepo.Delete(ctx, messageID); err != nil {
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	return nil
+}
+
+// getEditableMessage loads a live message of the tournament and checks that userID may change it
+func (s *tournamentService) getEditableMessage(
+	ctx context.Context, tournamentID, messageID, userID uuid.UUID,
+) (*domain.Message, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", tournamentID) {
+			return nil, &ErrTournamentNotFound{ID: tournamentID}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	message, err := s.getChatMessage(ctx, tournamentID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.UserID != userID && tournament.CreatedBy != userID {
+		return nil, ErrNotMessageAuthor
+	}
+
+	return message, nil
+}
+
+// lookupMessageAuthors resolves the distinct authors of a page of messages with a single
+// user service call. A failed lookup is logged and yields an empty map so callers fall back
+// to placeholder names instead of failing the request.
+func (s *tournamentService) lookupMessageAuthors(
+	ctx context.Context, messages []*domain.Message,
+) map[uuid.UUID]client.UserDetails {
+	if s.userDirectory == nil || len(messages) == 0 {
+		return nil
+	}
+
+	seen := make(map[uuid.UUID]bool, len(messages))
+	userIDs := make([]uuid.UUID, 0, len(messages))
+	for _, message := range messages {
+		if !seen[message.UserID] {
+			seen[message.UserID] = true
+			userIDs = append(userIDs, message.UserID)
+		}
+	}
+
+	authors, err := s.userDirectory.GetMultipleUserDetails(ctx, userIDs)
+	if err != nil {
+		logging.Warnf(ctx, "failed to resolve usernames for %d message authors: %v", len(userIDs), err)
+		return nil
+	}
+	return authors
+}
+
+// toMessageResponse maps a message to its API representation, taking the author's
+// name from authors when available
+func toMessageResponse(message *domain.Message, authors map[uuid.UUID]client.UserDetails) *domain.MessageResponse {
+	response := &domain.MessageResponse{
+		ID:        message.ID,
+		MatchID:   message.MatchID,
+		UserID:    message.UserID,
+		Username:  fmt.Sprintf("User-%s", message.UserID.String()[:8]),
+		Message:   message.Message,
+		CreatedAt: message.CreatedAt,
+		EditedAt:  message.EditedAt,
+		IsPinned:  message.IsPinned,
+		Reactions: []domain.ReactionCount{},
+	}
+	if author, ok := authors[message.UserID]; ok && author.Username != "" {
+		response.Username = author.Username
+		response.DisplayName = author.DisplayName
+	}
+	return response
 }
 
 // UpdateParticipant updates a participant's details
@@ -1351,7 +2395,7 @@ func (s *tournamentService) UpdateParticipant(
 
 	// Verify participant belongs to tournament
 	if participant.TournamentID != tournamentID {
-		return nil, errors.New("participant does not belong to this tournament")
+		return nil, ErrParticipantNotFound
 	}
 
 	// Update fields
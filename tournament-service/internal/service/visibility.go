@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ErrLoginRequired is returned when an anonymous caller asks for a private tournament
+var ErrLoginRequired = domain.NewError(domain.ErrUnauthenticated, "sign in to view this tournament")
+
+// canView reports whether viewerID may see the tournament. Public and unlisted tournaments
+// are open to everyone; private ones only to their organizers and registered participants.
+// viewerID is uuid.Nil for anonymous callers.
+func (s *tournamentService) canView(ctx context.Context, tournament *domain.Tournament, viewerID uuid.UUID) (bool, error) {
+	if tournament.Visibility != domain.VisibilityPrivate {
+		return true, nil
+	}
+	if viewerID == uuid.Nil {
+		return false, nil
+	}
+	if isOrganizer(tournament, viewerID) {
+		return true, nil
+	}
+	registered, err := s.participantRepo.ExistsByTournamentIDAndUserID(ctx, tournament.ID, viewerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check participant: %w", err)
+	}
+	return registered, nil
+}
+
+// ViewTournament retrieves a tournament on behalf of viewerID. Anonymous callers asking for
+// a private tournament get ErrLoginRequired; signed-in outsiders get ErrTournamentNotFound,
+// so private tournaments do not reveal that they exist.
+func (s *tournamentService) ViewTournament(
+	ctx context.Context, id, viewerID uuid.UUID,
+) (*domain.TournamentResponse, error) {
+	tournament, err := s.tournamentRepo.GetByID(ctx, id)
+	if err != nil {
+		if err.Error() == fmt.Sprintf("tournament not found: %v", id) {
+			return nil, &ErrTournamentNotFound{ID: id}
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	visible, err := s.canView(ctx, tournament, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		if viewerID == uuid.Nil {
+			return nil, ErrLoginRequired
+		}
+		return nil, &ErrTournamentNotFound{ID: id}
+	}
+
+	participantCount, err := s.tournamentRepo.GetParticipantCount(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant count: %w", err)
+	}
+	return toTournamentResponse(tournament, participantCount), nil
+}
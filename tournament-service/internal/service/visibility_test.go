@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// visibilityFixture is one tournament at each visibility level, each with an invited player
+type visibilityFixture struct {
+	env         *testEnv
+	organizer   uuid.UUID
+	player      uuid.UUID
+	tournaments map[domain.TournamentVisibility]*domain.Tournament
+}
+
+func newVisibilityFixture(t *testing.T) *visibilityFixture {
+	f := &visibilityFixture{env: newTestEnv(t), organizer: uuid.New(), player: uuid.New(),
+		tournaments: make(map[domain.TournamentVisibility]*domain.Tournament)}
+	for _, visibility := range []domain.TournamentVisibility{domain.VisibilityPublic, domain.VisibilityUnlisted, domain.VisibilityPrivate} {
+		visibility := visibility
+		tournament := f.env.tournament(f.organizer, func(t *domain.Tournament) { t.Visibility = visibility })
+		// The organizer invites the player by user ID
+		if err := registerAs(f.env, tournament.ID, f.organizer, f.player); err != nil {
+			t.Fatalf("register in the %s tournament: %v", visibility, err)
+		}
+		f.tournaments[visibility] = tournament
+	}
+	return f
+}
+
+func TestAnonymousAccessToEachVisibility(t *testing.T) {
+	f := newVisibilityFixture(t)
+
+	for visibility, wantErr := range map[domain.TournamentVisibility]error{
+		domain.VisibilityPublic:   nil,
+		domain.VisibilityUnlisted: nil,
+		domain.VisibilityPrivate:  ErrLoginRequired,
+	} {
+		tournament := f.tournaments[visibility]
+		response, err := f.env.service.ViewTournament(context.Background(), tournament.ID, uuid.Nil)
+		if wantErr != nil {
+			if !errors.Is(err, wantErr) || !errors.Is(err, domain.ErrUnauthenticated) {
+				t.Errorf("%s: expected %v, got %v", visibility, wantErr, err)
+			}
+			continue
+		}
+		if err != nil || response.ID != tournament.ID {
+			t.Errorf("%s: anonymous callers should see the tournament, got %v", visibility, err)
+		}
+	}
+
+	// Only public tournaments are listed to anonymous callers
+	listed, total, err := f.env.service.ListTournaments(context.Background(), map[string]interface{}{"visibleTo": uuid.Nil}, 1, 10)
+	if err != nil {
+		t.Fatalf("ListTournaments: %v", err)
+	}
+	if total != 1 || len(listed) != 1 || listed[0].ID != f.tournaments[domain.VisibilityPublic].ID {
+		t.Fatalf("expected only the public tournament, got %d of %d", len(listed), total)
+	}
+	active, _, err := f.env.service.ListActiveTournaments(context.Background(), uuid.Nil, 1, 10)
+	if err != nil {
+		t.Fatalf("ListActiveTournaments: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != f.tournaments[domain.VisibilityPublic].ID {
+		t.Fatalf("expected only the public tournament to be active for anonymous callers, got %d", len(active))
+	}
+}
+
+func TestPrivateTournamentsAreOpenToTheirMembers(t *testing.T) {
+	f := newVisibilityFixture(t)
+	private := f.tournaments[domain.VisibilityPrivate]
+
+	for name, viewer := range map[string]uuid.UUID{"organizer": f.organizer, "participant": f.player} {
+		if _, err := f.env.service.ViewTournament(context.Background(), private.ID, viewer); err != nil {
+			t.Errorf("%s: expected access, got %v", name, err)
+		}
+		listed, total, err := f.env.service.ListTournaments(context.Background(), map[string]interface{}{"visibleTo": viewer}, 1, 10)
+		if err != nil {
+			t.Fatalf("ListTournaments: %v", err)
+		}
+		if total != 3 || len(listed) != 3 {
+			t.Errorf("%s: expected all three tournaments listed, got %d of %d", name, len(listed), total)
+		}
+	}
+
+	// A signed-in outsider is told the private tournament does not exist
+	var notFound *ErrTournamentNotFound
+	if _, err := f.env.service.ViewTournament(context.Background(), private.ID, uuid.New()); !errors.As(err, &notFound) {
+		t.Fatalf("outsider: expected ErrTournamentNotFound, got %v", err)
+	}
+	listed, _, err := f.env.service.ListTournaments(context.Background(), map[string]interface{}{"visibleTo": uuid.New()}, 1, 10)
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("outsider: expected only the public tournament listed, got %d (%v)", len(listed), err)
+	}
+}
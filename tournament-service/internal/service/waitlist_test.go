@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestRegisteringPastTheCapJoinsTheWaitlist(t *testing.T) {
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.MaxParticipants = 2 })
+	env.players(tournament.ID, 2)
+
+	participant, err := register(env, tournament.ID, uuid.New())
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	stored := env.store.participants[participant.ID]
+	if !stored.IsWaitlisted || stored.Status != domain.ParticipantWaitlisted {
+		t.Fatalf("expected the third registration to be waitlisted: %+v", stored)
+	}
+}
+
+func TestUnregisteringPromotesTheEarliestWaitlisted(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.MaxParticipants = 2 })
+	players := env.players(tournament.ID, 2)
+	later, err := register(env, tournament.ID, uuid.New())
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	earlier, err := register(env, tournament.ID, uuid.New())
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	env.store.participants[later.ID].CreatedAt = time.Now()
+	env.store.participants[earlier.ID].CreatedAt = time.Now().Add(-time.Hour)
+
+	// A confirmed player leaving frees a slot
+	if err := env.service.UnregisterParticipant(ctx, tournament.ID, *players[0].UserID); err != nil {
+		t.Fatalf("UnregisterParticipant: %v", err)
+	}
+	if env.store.participants[earlier.ID].IsWaitlisted == env.store.participants[later.ID].IsWaitlisted {
+		t.Fatal("exactly one waitlisted participant should be promoted")
+	}
+	promoted := env.store.participants[earlier.ID]
+	if promoted.IsWaitlisted || promoted.Status != domain.ParticipantRegistered {
+		t.Fatalf("the longest-waiting participant should take the slot: %+v", promoted)
+	}
+
+	if err := env.service.UnregisterParticipant(ctx, tournament.ID, *later.UserID); err != nil {
+		t.Fatalf("UnregisterParticipant: %v", err)
+	}
+	if err := env.service.UnregisterParticipant(ctx, tournament.ID, uuid.New()); !errors.Is(err, ErrNotRegistered) {
+		t.Fatalf("expected ErrNotRegistered, got %v", err)
+	}
+}
+
+func TestLeavingTheWaitlistPromotesNobody(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tournament := env.tournament(uuid.New(), func(t *domain.Tournament) { t.MaxParticipants = 1 })
+	env.players(tournament.ID, 1)
+	first, _ := register(env, tournament.ID, uuid.New())
+	second, _ := register(env, tournament.ID, uuid.New())
+
+	if err := env.service.UnregisterParticipant(ctx, tournament.ID, *first.UserID); err != nil {
+		t.Fatalf("UnregisterParticipant: %v", err)
+	}
+	if !env.store.participants[second.ID].IsWaitlisted {
+		t.Fatal("the tournament is still full, so nobody should be promoted")
+	}
+}
+
+func TestGenerateBracketLeavesOutTheWaitlist(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	organizer := uuid.New()
+	tournament := env.tournament(organizer, func(t *domain.Tournament) { t.MaxParticipants = 4 })
+	env.players(tournament.ID, 4)
+	waitlisted, err := register(env, tournament.ID, uuid.New())
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	if err := env.service.GenerateBracket(ctx, tournament.ID, organizer, false); err != nil {
+		t.Fatalf("GenerateBracket: %v", err)
+	}
+	matches := env.storedMatches(tournament.ID)
+	if len(matches) != 3 {
+		t.Fatalf("expected a four-player bracket of 3 matches, got %d", len(matches))
+	}
+	for _, match := range matches {
+		if sameID(match.Participant1ID, waitlisted.ID) || sameID(match.Participant2ID, waitlisted.ID) {
+			t.Fatal("a waitlisted participant was put in the bracket")
+		}
+	}
+}
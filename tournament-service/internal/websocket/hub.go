@@ -8,23 +8,65 @@ import (
 	// "github.com/cliffdoyle/tournament-service/internal/websocket"
 	"log"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
 // Client represents a single WebSocket connection.
 type Client struct {
-	Conn *websocket.Conn// The WebSocket connection.
-	Send chan []byte // Buffered channel of outbound messages.
+	Conn *websocket.Conn // The WebSocket connection.
+	Send chan []byte     // Buffered channel of outbound messages.
 	// userID uuid.UUID // Optional: to associate connection with a user
+
+	subMu         sync.Mutex
+	subscriptions map[uuid.UUID]bool // Tournaments whose scoped events this client receives
+}
+
+// NewClient wraps a connection, subscribed to the given tournaments' scoped events
+func NewClient(conn *websocket.Conn, tournamentIDs ...uuid.UUID) *Client {
+	client := &Client{Conn: conn, Send: make(chan []byte, 256), subscriptions: make(map[uuid.UUID]bool)}
+	for _, id := range tournamentIDs {
+		client.subscriptions[id] = true
+	}
+	return client
+}
+
+// Subscribe starts delivering the tournament's scoped events to the client
+func (c *Client) Subscribe(tournamentID uuid.UUID) {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+	if c.subscriptions == nil {
+		c.subscriptions = make(map[uuid.UUID]bool)
+	}
+	c.subscriptions[tournamentID] = true
+}
+
+// Unsubscribe stops delivering the tournament's scoped events to the client
+func (c *Client) Unsubscribe(tournamentID uuid.UUID) {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+	delete(c.subscriptions, tournamentID)
+}
+
+// IsSubscribed reports whether the client receives the tournament's scoped events
+func (c *Client) IsSubscribed(tournamentID uuid.UUID) bool {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+	return c.subscriptions[tournamentID]
+}
+
+// wants reports whether message should be delivered to the client
+func (c *Client) wants(message domain.WebSocketMessage) bool {
+	return message.TournamentID == nil || c.IsSubscribed(*message.TournamentID)
 }
 
 // Hub maintains the set of active clients and broadcasts messages to the clients.
 type Hub struct {
-	clients    map[*Client]bool // Registered clients.
+	clients    map[*Client]bool             // Registered clients.
 	Broadcast  chan domain.WebSocketMessage // Inbound messages from the services.
-	register   chan *Client      // Register requests from the clients.
-	unregister chan *Client      // Unregister requests from clients.
-	mu         sync.Mutex    // For safe concurrent access to clients map
+	register   chan *Client                 // Register requests from the clients.
+	unregister chan *Client                 // Unregister requests from clients.
+	mu         sync.Mutex                   // For safe concurrent access to clients map
 }
 
 func NewHub() *Hub {
@@ -36,7 +78,6 @@ func NewHub() *Hub {
 	}
 }
 
-
 // WritePump pumps messages from the hub to the websocket connection.
 func (c *Client) WritePump() {
 	defer func() {
@@ -72,7 +113,7 @@ func (c *Client) ReadPump(hub *Hub) {
 	// c.conn.SetPongHandler(func(string) error { c.conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
 
 	for {
-		_, _, err := c.Conn.ReadMessage() // Read messages (even if we don't process them from client)
+		_, data, err := c.Conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket unexpected close error: %v", err)
@@ -81,8 +122,17 @@ func (c *Client) ReadPump(hub *Hub) {
 			}
 			break // Exit loop, triggers defer to unregister and close
 		}
-		// If you wanted to process client messages, you'd do it here
-		// and potentially send them to hub.Broadcast or another channel
+		// The only messages clients send are subscription changes; anything else is ignored
+		var subscription domain.WebSocketSubscription
+		if err := json.Unmarshal(data, &subscription); err != nil || subscription.TournamentID == uuid.Nil {
+			continue
+		}
+		switch subscription.Type {
+		case "SUBSCRIBE":
+			c.Subscribe(subscription.TournamentID)
+		case "UNSUBSCRIBE":
+			c.Unsubscribe(subscription.TournamentID)
+		}
 	}
 }
 
@@ -110,6 +160,9 @@ func (h *Hub) Run() {
 			}
 			h.mu.Lock()
 			for client := range h.clients {
+				if !client.wants(message) {
+					continue
+				}
 				select {
 				case client.Send <- jsonData: // Send to client's buffered channel
 				default: // If client's send buffer is full, unregister and close (prevents hub blocking)
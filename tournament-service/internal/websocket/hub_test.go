@@ -0,0 +1,135 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/tournament-service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// runningHub starts a hub with the given clients registered
+func runningHub(clients ...*Client) *Hub {
+	hub := NewHub()
+	go hub.Run()
+	for _, client := range clients {
+		hub.Register(client)
+	}
+	return hub
+}
+
+// next returns the type of the next event queued for the client
+func next(t *testing.T, client *Client) domain.WebSocketEventType {
+	t.Helper()
+	select {
+	case data := <-client.Send:
+		var message domain.WebSocketMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			t.Fatalf("event is not JSON: %v", err)
+		}
+		return message.Type
+	case <-time.After(time.Second):
+		t.Fatal("no event was delivered")
+		return ""
+	}
+}
+
+func TestHubDeliversTournamentEventsOnlyToSubscribers(t *testing.T) {
+	tournamentID := uuid.New()
+	subscribed, other := NewClient(nil, tournamentID), NewClient(nil)
+	hub := runningHub(subscribed, other)
+
+	hub.Broadcast <- domain.WebSocketMessage{Type: domain.WSEventChatMessage, TournamentID: &tournamentID}
+	// Unscoped events still reach everyone; the hub handles broadcasts in order
+	hub.Broadcast <- domain.WebSocketMessage{Type: domain.WSEventMatchScoreUpdated}
+
+	if got := next(t, subscribed); got != domain.WSEventChatMessage {
+		t.Fatalf("the subscriber should get the chat message first, got %s", got)
+	}
+	if got := next(t, subscribed); got != domain.WSEventMatchScoreUpdated {
+		t.Fatalf("expected the unscoped event, got %s", got)
+	}
+	if got := next(t, other); got != domain.WSEventMatchScoreUpdated {
+		t.Fatalf("a client not following the tournament got %s", got)
+	}
+}
+
+func TestClientSubscriptions(t *testing.T) {
+	tournamentID := uuid.New()
+	client := &Client{}
+	if client.IsSubscribed(tournamentID) {
+		t.Fatal("a new client follows no tournaments")
+	}
+	client.Subscribe(tournamentID)
+	if !client.IsSubscribed(tournamentID) || client.IsSubscribed(uuid.New()) {
+		t.Fatal("the client should follow only the subscribed tournament")
+	}
+	client.Unsubscribe(tournamentID)
+	if client.IsSubscribed(tournamentID) {
+		t.Fatal("the client still follows the tournament")
+	}
+}
+
+func TestReadPumpHandlesSubscriptionMessages(t *testing.T) {
+	tournamentID := uuid.New()
+	hub := runningHub()
+	clients := make(chan *Client, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		client := NewClient(conn)
+		hub.Register(client)
+		clients <- client
+		client.ReadPump(hub)
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	client := <-clients
+
+	waitFor := func(subscribed bool) {
+		t.Helper()
+		for deadline := time.Now().Add(time.Second); client.IsSubscribed(tournamentID) != subscribed; {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected subscribed to be %v", subscribed)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	for _, message := range []string{"not json", `{"type": "SUBSCRIBE"}`} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+			t.Fatalf("WriteMessage: %v", err)
+		}
+	}
+	if err := conn.WriteJSON(domain.WebSocketSubscription{Type: "SUBSCRIBE", TournamentID: tournamentID}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	waitFor(true)
+	if err := conn.WriteJSON(domain.WebSocketSubscription{Type: "UNSUBSCRIBE", TournamentID: tournamentID}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	waitFor(false)
+
+	// Closing the connection unregisters the client, which closes its send channel
+	conn.Close()
+	select {
+	case _, ok := <-client.Send:
+		if ok {
+			t.Fatal("expected the send channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the client was not unregistered")
+	}
+}
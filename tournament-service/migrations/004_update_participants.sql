@@ -7,8 +7,8 @@ END $$;
 
 -- Add status and is_waitlisted columns to tournament_participants
 ALTER TABLE tournament_participants
-ADD COLUMN status participant_status NOT NULL DEFAULT 'REGISTERED',
-ADD COLUMN is_waitlisted BOOLEAN NOT NULL DEFAULT FALSE;
+ADD COLUMN IF NOT EXISTS status participant_status NOT NULL DEFAULT 'REGISTERED',
+ADD COLUMN IF NOT EXISTS is_waitlisted BOOLEAN NOT NULL DEFAULT FALSE;
 
 -- Remove old columns that are no longer used
 ALTER TABLE tournament_participants
@@ -0,0 +1,4 @@
+-- Messages may be scoped to a single match; NULL keeps them in the tournament-wide chat
+ALTER TABLE tournament_messages ADD COLUMN IF NOT EXISTS match_id UUID NULL REFERENCES matches(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_tournament_messages_match ON tournament_messages(match_id);
@@ -0,0 +1,2 @@
+-- Games the winners-bracket finalist starts the grand finals with (0 = no advantage, bracket reset applies)
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS grand_finals_advantage INTEGER NOT NULL DEFAULT 0;
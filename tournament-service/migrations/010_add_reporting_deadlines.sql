@@ -0,0 +1,6 @@
+-- Per-tournament result reporting window and what happens when it passes
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS reporting_window_minutes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS reporting_deadline_policy VARCHAR(20) NOT NULL DEFAULT 'FLAG';
+
+-- Deadline for reporting each match's result (scheduled_time + reporting window)
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS reporting_deadline TIMESTAMP WITH TIME ZONE NULL;
@@ -0,0 +1,3 @@
+-- Chat messages can be edited by their author and are soft-deleted so thread history stays consistent
+ALTER TABLE tournament_messages ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP WITH TIME ZONE NULL;
+ALTER TABLE tournament_messages ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE NULL;
@@ -0,0 +1,6 @@
+-- Optimistic locking for match updates: every write bumps the version and must match the one read
+ALTER TABLE matches
+ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+-- Add rollback
+-- ALTER TABLE matches DROP COLUMN version;
@@ -0,0 +1,6 @@
+-- Per-game scores of best-of-N series; score_participant1/2 hold the games each side won
+ALTER TABLE matches
+ADD COLUMN IF NOT EXISTS games JSONB NOT NULL DEFAULT '[]';
+
+-- Add rollback
+-- ALTER TABLE matches DROP COLUMN games;
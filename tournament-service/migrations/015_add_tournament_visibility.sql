@@ -0,0 +1,7 @@
+-- PUBLIC tournaments are listed for everyone; UNLISTED ones are only reachable by link and
+-- PRIVATE ones only by their organizers and participants
+ALTER TABLE tournaments
+ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'PUBLIC';
+
+-- Add rollback
+-- ALTER TABLE tournaments DROP COLUMN visibility;
@@ -0,0 +1,9 @@
+-- Shareable join codes; NULL when the organizer has disabled joining by code
+ALTER TABLE tournaments
+ADD COLUMN IF NOT EXISTS invite_code VARCHAR(16) NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tournaments_invite_code ON tournaments(invite_code) WHERE invite_code IS NOT NULL;
+
+-- Add rollback
+-- DROP INDEX idx_tournaments_invite_code;
+-- ALTER TABLE tournaments DROP COLUMN invite_code;
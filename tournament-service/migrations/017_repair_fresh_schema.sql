@@ -0,0 +1,34 @@
+-- Brings a database built from the files above in line with what the service uses: the
+-- participant_name rename and the user_activities table were applied by hand on existing
+-- databases. Safe to run on those too; it only adds what is missing and loosens types.
+ALTER TABLE tournament_participants ADD COLUMN IF NOT EXISTS participant_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tournament_participants ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE tournament_participants ADD COLUMN IF NOT EXISTS check_in_time TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tournament_participants ADD COLUMN IF NOT EXISTS is_checked_in BOOLEAN DEFAULT FALSE;
+
+-- The service filters statuses with text parameters (status = ANY($1)), which enum columns
+-- reject, and uses statuses the enums never got (ARCHIVED, CANCELLED)
+ALTER TABLE tournaments ALTER COLUMN format DROP DEFAULT, ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE tournaments ALTER COLUMN format TYPE VARCHAR(30), ALTER COLUMN status TYPE VARCHAR(30);
+ALTER TABLE tournaments ALTER COLUMN format SET DEFAULT 'SINGLE_ELIMINATION', ALTER COLUMN status SET DEFAULT 'DRAFT';
+ALTER TABLE matches ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE matches ALTER COLUMN status TYPE VARCHAR(50);
+ALTER TABLE matches ALTER COLUMN status SET DEFAULT 'PENDING';
+ALTER TABLE tournament_participants ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE tournament_participants ALTER COLUMN status TYPE VARCHAR(30);
+ALTER TABLE tournament_participants ALTER COLUMN status SET DEFAULT 'REGISTERED';
+
+-- Same as user_activities.sql but without the foreign key to users, which live in the user service's database
+CREATE TABLE IF NOT EXISTS user_activities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    activity_type VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL,
+    related_entity_id UUID NULL,
+    related_entity_type VARCHAR(50) NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    context_url VARCHAR(255) NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_activities_user_id_created_at ON user_activities (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_activities_activity_type ON user_activities (activity_type);
@@ -0,0 +1,25 @@
+-- Participant lists are read per tournament in seed order
+CREATE INDEX IF NOT EXISTS idx_tournament_participants_tournament_seed
+    ON tournament_participants(tournament_id, seed, created_at);
+
+-- A user can be registered in a tournament once. Guest participants have no user_id and are
+-- not limited. 006 dropped the old constraint, so look for duplicates before adding the index:
+--   SELECT tournament_id, user_id, COUNT(*) FROM tournament_participants
+--   WHERE user_id IS NOT NULL GROUP BY 1, 2 HAVING COUNT(*) > 1;
+DO $$ BEGIN
+    IF EXISTS (
+        SELECT 1 FROM tournament_participants
+        WHERE user_id IS NOT NULL
+        GROUP BY tournament_id, user_id
+        HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'tournament_participants has users registered twice in a tournament; remove the duplicates before running this migration';
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_tournament_participants_tournament_user
+    ON tournament_participants(tournament_id, user_id) WHERE user_id IS NOT NULL;
+
+-- Add rollback
+-- DROP INDEX uq_tournament_participants_tournament_user;
+-- DROP INDEX idx_tournament_participants_tournament_seed;
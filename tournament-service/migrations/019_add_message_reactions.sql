@@ -0,0 +1,15 @@
+-- Organizers pin chat messages such as announcements so they stay at the top of the chat
+ALTER TABLE tournament_messages ADD COLUMN IF NOT EXISTS is_pinned BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- One row per user and emoji on a message; the primary key stops the same reaction twice
+CREATE TABLE IF NOT EXISTS message_reactions (
+    message_id UUID NOT NULL REFERENCES tournament_messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, user_id, emoji)
+);
+
+-- Add rollback
+-- DROP TABLE message_reactions;
+-- ALTER TABLE tournament_messages DROP COLUMN is_pinned;
@@ -0,0 +1,12 @@
+-- How far each user has read a tournament's chat, for unread counts. Messages created after
+-- last_read_at are unread; last_read_message_id is the newest message when it was marked read.
+CREATE TABLE IF NOT EXISTS chat_read_state (
+    user_id UUID NOT NULL,
+    tournament_id UUID NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+    last_read_message_id UUID NULL,
+    last_read_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, tournament_id)
+);
+
+-- Add rollback
+-- DROP TABLE chat_read_state;
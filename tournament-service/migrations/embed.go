@@ -0,0 +1,10 @@
+// Package migrations embeds the tournament database's SQL migrations so the migrate command
+// carries them in its binary.
+package migrations
+
+import "embed"
+
+// FS holds every .sql file in this directory; database.RunMigrations picks the numbered ones
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,29 @@
+package migrations_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cliffdoyle/tournament-service/internal/database"
+	"github.com/cliffdoyle/tournament-service/migrations"
+)
+
+func TestEmbeddedMigrationsCreateEveryTable(t *testing.T) {
+	loaded, err := database.LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("LoadMigrations: %v", err)
+	}
+	var schema strings.Builder
+	for _, migration := range loaded {
+		schema.WriteString(migration.SQL)
+	}
+
+	for _, table := range []string{
+		"tournaments", "tournament_participants", "matches", "tournament_messages", "user_activities", "ranking_outbox",
+	} {
+		if !regexp.MustCompile(`(?i)CREATE TABLE IF NOT EXISTS ` + table + `\s*\(`).MatchString(schema.String()) {
+			t.Errorf("no numbered migration creates %s", table)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cliffdoyle/gamer_world/user-service/database"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordingDB is a database that accepts every statement, answers queries with no rows and
+// records what it ran
+type recordingDB struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+func (d *recordingDB) record(query string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.statements = append(d.statements, strings.Join(strings.Fields(query), " "))
+}
+
+func (d *recordingDB) Connect(context.Context) (driver.Conn, error) { return recordingConn{d}, nil }
+func (d *recordingDB) Driver() driver.Driver                        { return nil }
+
+type recordingConn struct{ db *recordingDB }
+
+func (recordingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (recordingConn) Close() error                        { return nil }
+func (recordingConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.record(query)
+	return driver.RowsAffected(0), nil
+}
+
+func (c recordingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.record(query)
+	return noRows{}, nil
+}
+
+type noRows struct{}
+
+func (noRows) Columns() []string              { return nil }
+func (noRows) Close() error                   { return nil }
+func (noRows) Next(dest []driver.Value) error { return io.EOF }
+
+func TestMainMigratesTheConnectedDatabase(t *testing.T) {
+	db := &recordingDB{}
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(db)}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open recording database: %v", err)
+	}
+	previous := database.DB
+	database.DB = gormDB
+	defer func() { database.DB = previous }()
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	main()
+
+	var created int
+	for _, statement := range db.statements {
+		if strings.HasPrefix(statement, "CREATE TABLE") {
+			created++
+		}
+	}
+	if created != 5 {
+		t.Fatalf("expected the 5 user service tables to be created, got %d in %q", created, db.statements)
+	}
+	if !strings.Contains(logged.String(), "Migration completed successfully") {
+		t.Fatalf("expected the migration to report success, got %q", logged.String())
+	}
+}
@@ -63,10 +63,11 @@ func Connect() {
 	DB.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\";")
 
 	// Auto migrate the schema
-	err = DB.AutoMigrate(&models.User{})
+	err = DB.AutoMigrate(&models.User{}, &models.RefreshToken{}, &models.PasswordResetToken{}, &models.EmailVerificationToken{}, &models.UserIdentity{})
 	if err != nil {
 		log.Fatal("Failed to auto-migrate schema:", err)
 	}
+	backfillUserIdentities()
 
 	log.Println("Connected to database and migrated schema successfully")
 }
@@ -83,12 +84,25 @@ func RunMigration() error {
 	DB.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\";")
 
 	// Auto migrate the schema
-	err := DB.AutoMigrate(&models.User{})
+	err := DB.AutoMigrate(&models.User{}, &models.RefreshToken{}, &models.PasswordResetToken{}, &models.EmailVerificationToken{}, &models.UserIdentity{})
 	if err != nil {
 		log.Fatal("Failed to auto-migrate schema:", err)
 		return err
 	}
+	backfillUserIdentities()
 
 	log.Println("Migrations completed successfully")
 	return nil
 }
+
+// backfillUserIdentities links users who signed up with an OAuth provider before identities
+// existed to that provider. It is safe to run repeatedly.
+func backfillUserIdentities() {
+	err := DB.Exec(`INSERT INTO user_identities (id, user_id, provider, provider_id)
+		SELECT uuid_generate_v4(), id, provider, provider_id FROM users
+		WHERE provider_id IS NOT NULL AND provider <> 'credentials' AND deleted_at IS NULL
+		ON CONFLICT DO NOTHING`).Error
+	if err != nil {
+		log.Printf("Failed to backfill user identities: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunMigrationCreatesEveryTable(t *testing.T) {
+	db := useScriptedDB(t)
+
+	if err := RunMigration(); err != nil {
+		t.Fatalf("RunMigration: %v", err)
+	}
+	if !strings.HasPrefix(db.log[0], `CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`) {
+		t.Fatalf("uuid_generate_v4 is needed before any table, got %s first", db.log[0])
+	}
+	for _, table := range []string{"users", "refresh_tokens", "password_reset_tokens", "email_verification_tokens", "user_identities"} {
+		if len(db.statements(`CREATE TABLE "`+table+`"`)) != 1 {
+			t.Errorf("expected the %s table to be created", table)
+		}
+	}
+	// A provider account belongs to one user, and a user links each provider once
+	for _, index := range []string{`"idx_user_identities_provider_account" ON "user_identities" ("provider","provider_id")`, `"idx_user_identities_user_provider" ON "user_identities" ("user_id","provider")`} {
+		if len(db.statements("CREATE UNIQUE INDEX IF NOT EXISTS "+index)) != 1 {
+			t.Errorf("expected the unique index %s", index)
+		}
+	}
+	if last := db.log[len(db.log)-1]; !strings.HasPrefix(last, "INSERT INTO user_identities") {
+		t.Fatalf("identities should be backfilled once the tables exist, got %s last", last)
+	}
+}
+
+func TestBackfillUserIdentitiesLinksOnlyLiveProviderAccounts(t *testing.T) {
+	db := useScriptedDB(t)
+
+	backfillUserIdentities()
+	backfill := db.statements("INSERT INTO user_identities")
+	if len(backfill) != 1 {
+		t.Fatalf("expected one backfill statement, got %q", db.log)
+	}
+	for _, clause := range []string{"provider_id IS NOT NULL", "provider <> 'credentials'", "deleted_at IS NULL", "ON CONFLICT DO NOTHING"} {
+		if !strings.Contains(backfill[0], clause) {
+			t.Errorf("expected %q in %s", clause, backfill[0])
+		}
+	}
+}
+
+func TestFailedBackfillDoesNotStopTheMigration(t *testing.T) {
+	db := useScriptedDB(t)
+	db.exec = func(query string, args []driver.NamedValue) (driver.Result, error) {
+		if strings.HasPrefix(query, "INSERT INTO user_identities") {
+			return nil, errors.New("permission denied")
+		}
+		return driver.RowsAffected(0), nil
+	}
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	if err := RunMigration(); err != nil {
+		t.Fatalf("RunMigration: %v", err)
+	}
+	if !strings.Contains(logged.String(), "Failed to backfill user identities: permission denied") {
+		t.Fatalf("expected the failure to be logged, got %q", logged.String())
+	}
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// scriptedDB is a database/sql connector whose statements are answered by test callbacks,
+// so handlers can be exercised through GORM without a Postgres server. Every statement and
+// transaction boundary is appended to the log.
+type scriptedDB struct {
+	mu    sync.Mutex
+	log   []string
+	args  [][]driver.NamedValue
+	exec  func(query string, args []driver.NamedValue) (driver.Result, error)
+	query func(query string, args []driver.NamedValue) (driver.Rows, error)
+}
+
+// useScriptedDB points DB at a new scriptedDB for the duration of the test
+func useScriptedDB(t *testing.T) *scriptedDB {
+	t.Helper()
+	db := &scriptedDB{}
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(db)}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open scripted database: %v", err)
+	}
+	previous := DB
+	DB = gormDB
+	t.Cleanup(func() { DB = previous })
+	return db
+}
+
+func (d *scriptedDB) record(entry string, args []driver.NamedValue) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, strings.Join(strings.Fields(entry), " "))
+	d.args = append(d.args, args)
+}
+
+// statements returns the log entries that start with prefix
+func (d *scriptedDB) statements(prefix string) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var matched []string
+	for _, entry := range d.log {
+		if strings.HasPrefix(entry, prefix) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
+
+// argsOf returns the arguments of the first statement that starts with prefix
+func (d *scriptedDB) argsOf(prefix string) []interface{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, entry := range d.log {
+		if strings.HasPrefix(entry, prefix) {
+			values := make([]interface{}, len(d.args[i]))
+			for j, arg := range d.args[i] {
+				values[j] = arg.Value
+			}
+			return values
+		}
+	}
+	return nil
+}
+
+func (d *scriptedDB) Connect(ctx context.Context) (driver.Conn, error) {
+	return &scriptedConn{db: d}, nil
+}
+func (d *scriptedDB) Driver() driver.Driver { return scriptedDriver{} }
+
+type scriptedDriver struct{}
+
+func (scriptedDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("scripted driver only opens through its connector")
+}
+
+type scriptedConn struct{ db *scriptedDB }
+
+func (c *scriptedConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("scripted driver does not prepare statements")
+}
+func (c *scriptedConn) Close() error { return nil }
+func (c *scriptedConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *scriptedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.db.record("BEGIN", nil)
+	return scriptedTx{db: c.db}, nil
+}
+
+// CheckNamedValue passes every argument through as-is; callbacks inspect them directly
+func (c *scriptedConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c *scriptedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.record(query, args)
+	if c.db.exec == nil {
+		return driver.RowsAffected(1), nil
+	}
+	return c.db.exec(strings.TrimSpace(query), args)
+}
+
+func (c *scriptedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.record(query, args)
+	if c.db.query == nil {
+		return &scriptedRows{}, nil
+	}
+	return c.db.query(strings.TrimSpace(query), args)
+}
+
+type scriptedTx struct{ db *scriptedDB }
+
+func (t scriptedTx) Commit() error   { t.db.record("COMMIT", nil); return nil }
+func (t scriptedTx) Rollback() error { t.db.record("ROLLBACK", nil); return nil }
+
+// scriptedRows is a fixed result set
+type scriptedRows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func rowsOf(columns []string, values ...[]driver.Value) *scriptedRows {
+	return &scriptedRows{columns: columns, values: values}
+}
+
+func (r *scriptedRows) Columns() []string { return r.columns }
+func (r *scriptedRows) Close() error      { return nil }
+
+func (r *scriptedRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cliffdoyle/gamer_world/user-service/database"
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeletedUserDisplayName is shown in place of an anonymized user's name
+const DeletedUserDisplayName = "Deleted user"
+
+// deleteUserCredentials removes everything that lets someone sign in as userID or reach the
+// account through a linked provider
+func deleteUserCredentials(tx *gorm.DB, userID uuid.UUID) error {
+	for _, model := range []interface{}{
+		&models.UserIdentity{},
+		&models.RefreshToken{},
+		&models.PasswordResetToken{},
+		&models.EmailVerificationToken{},
+	} {
+		if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// anonymizeUser strips the personal data from a user row and soft-deletes it. The row and its
+// ID stay so tournament participants and ranking records that reference it remain valid, and
+// the username, email and provider account are released for reuse.
+func anonymizeUser(tx *gorm.DB, user *models.User) error {
+	if err := deleteUserCredentials(tx, user.ID); err != nil {
+		return err
+	}
+	err := tx.Model(user).Updates(map[string]interface{}{
+		"username":                 "deleted-" + user.ID.String(),
+		"email":                    gorm.Expr("NULL"),
+		"email_verified":           false,
+		"password":                 "",
+		"display_name":             DeletedUserDisplayName,
+		"profile_picture_url":      "",
+		"bio":                      "",
+		"gaming_handle_psn":        "",
+		"gaming_handle_xbox":       "",
+		"gaming_handle_origin_pc":  "",
+		"preferred_fifa_version":   "",
+		"favorite_real_world_club": "",
+		"provider_id":              gorm.Expr("NULL"),
+	}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Delete(user).Error
+}
+
+// authenticatedUser loads the user the request's token was issued to. It goes by the user_id
+// claim rather than the username, which can change or, once the account is deleted, be taken
+// by someone else. A token without a user ID or for a deleted account gets a 401.
+func authenticatedUser(c *gin.Context) (*models.User, bool) {
+	userID, _ := c.Get("user_id")
+	id, _ := userID.(uuid.UUID)
+	if id == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return nil, false
+	}
+
+	var user models.User
+	if err := database.DB.Where("id = ?", id).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User account no longer exists"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error fetching user"})
+		return nil, false
+	}
+	return &user, true
+}
+
+// ExportUserData returns everything held about the authenticated user as JSON: their profile
+// and linked accounts, plus their tournaments and activities from the tournament service when
+// TOURNAMENT_SERVICE_URL is set.
+func ExportUserData(c *gin.Context) {
+	user, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	var identities []models.UserIdentity
+	if err := database.DB.Where("user_id = ?", user.ID).Order("created_at asc").Find(&identities).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error listing linked accounts"})
+		return
+	}
+
+	export := gin.H{
+		"exported_at":     time.Now(),
+		"user":            user,
+		"linked_accounts": identities,
+	}
+
+	// The tournament service authenticates the same bearer token, so it only returns this user's data
+	authHeader := c.GetHeader("Authorization")
+	tournaments, activities, err := fetchTournamentData(c.Request.Context(), authHeader)
+	if err != nil {
+		log.Printf("Error exporting tournament data for user '%s': %v", user.Username, err)
+		export["tournament_data_error"] = err.Error()
+	} else {
+		export["tournaments"] = tournaments
+		export["activities"] = activities
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%s-export.json"`, user.ID))
+	c.JSON(http.StatusOK, export)
+}
+
+// maxExportActivityPages bounds how many pages of activities an export fetches
+const maxExportActivityPages = 100
+
+// fetchTournamentData reads the caller's tournaments and every page of their activities from
+// the tournament service with their own bearer token.
+func fetchTournamentData(ctx context.Context, authHeader string) (json.RawMessage, []json.RawMessage, error) {
+	baseURL := strings.TrimSuffix(os.Getenv("TOURNAMENT_SERVICE_URL"), "/")
+	if baseURL == "" {
+		return nil, nil, errors.New("TOURNAMENT_SERVICE_URL is not set")
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	tournaments, err := getTournamentServiceJSON(ctx, client, baseURL+"/users/me/tournaments", authHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	activities := []json.RawMessage{}
+	for page := 1; page <= maxExportActivityPages; page++ {
+		body, err := getTournamentServiceJSON(ctx, client, fmt.Sprintf("%s/dashboard/activities?page=%d&pageSize=10", baseURL, page), authHeader)
+		if err != nil {
+			return nil, nil, err
+		}
+		var pageResponse struct {
+			Activities []json.RawMessage `json:"activities"`
+			Total      int               `json:"total"`
+		}
+		if err := json.Unmarshal(body, &pageResponse); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode activities: %w", err)
+		}
+		activities = append(activities, pageResponse.Activities...)
+		if len(pageResponse.Activities) == 0 || len(activities) >= pageResponse.Total {
+			break
+		}
+	}
+	return tournaments, activities, nil
+}
+
+// getTournamentServiceJSON GETs url with the caller's Authorization header and returns the body
+func getTournamentServiceJSON(ctx context.Context, client *http.Client, url, authHeader string) (json.RawMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", url, err)
+	}
+	req.Header.Set("Authorization", authHeader)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tournament service returned status %d for %s", resp.StatusCode, url)
+	}
+	return body, nil
+}
+
+// PurgeUserAccount permanently removes a user, deleted or not, with their linked accounts and
+// tokens. It is an internal route; records in other services that reference the ID are kept.
+func PurgeUserAccount(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	var user models.User
+	if err := database.DB.Unscoped().Where("id = ?", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error fetching user"})
+		return
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := deleteUserCredentials(tx, user.ID); err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&user).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error purging user account"})
+		return
+	}
+
+	if err := removeAvatar(user.ID.String()); err != nil {
+		log.Printf("Warning: could not remove avatar of purged user %s: %v", user.ID, err)
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "User account purged"})
+}
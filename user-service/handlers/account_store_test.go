@@ -0,0 +1,388 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/cliffdoyle/gamer_world/user-service/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// storedToken is a row of one of the hashed token tables (refresh, password reset, email verification)
+type storedToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+// accountStore keeps users, their linked identities and their tokens in memory and answers the
+// few statements the account handlers send through GORM: inserts, lookups, counts and deletes
+// filtered by equality or IN lists on columns, and updates, soft deletes and purges of a user.
+type accountStore struct {
+	mu         sync.Mutex
+	db         *scriptedDB
+	users      map[uuid.UUID]*models.User
+	identities []*models.UserIdentity
+	tokens     map[string]map[string]*storedToken // Table name, then token hash
+}
+
+var (
+	insertPattern     = regexp.MustCompile(`^INSERT INTO "(\w+)" \(([^)]*)\)`)
+	tablePattern      = regexp.MustCompile(`(?:FROM|UPDATE) "(\w+)"`)
+	conditionPattern  = regexp.MustCompile(`(?:"\w+"\.)?"?(\w+)"? = \$(\d+)`)
+	inPattern         = regexp.MustCompile(`(?:"\w+"\.)?"?(\w+)"? IN \(([^)]*)\)`)
+	placeholder       = regexp.MustCompile(`\$(\d+)`)
+	assignmentPattern = regexp.MustCompile(`"(\w+)"=(?:\$(\d+)|NULL)`)
+)
+
+// useAccountStore points database.DB at an empty accountStore for the duration of the test
+func useAccountStore(t *testing.T) *accountStore {
+	t.Helper()
+	t.Setenv("JWT_SECRET", "test-secret")
+	store := &accountStore{
+		db:     useScriptedDB(t),
+		users:  map[uuid.UUID]*models.User{},
+		tokens: map[string]map[string]*storedToken{},
+	}
+	store.db.query = store.query
+	store.db.exec = store.exec
+	return store
+}
+
+// addUser stores a user with a credentials account and returns it
+func (s *accountStore) addUser(t *testing.T, username, password string) *models.User {
+	t.Helper()
+	user := models.NewUser(username, "", username+"@example.com")
+	user.Provider = "credentials"
+	if password != "" {
+		hashed, err := utils.HashPassword(password)
+		if err != nil {
+			t.Fatalf("hash password: %v", err)
+		}
+		user.Password = hashed
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID] = user
+	return user
+}
+
+// user returns the stored copy of a user
+func (s *accountStore) user(id uuid.UUID) *models.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.users[id]
+}
+
+// link links a provider account to a user
+func (s *accountStore) link(userID uuid.UUID, provider, providerID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	identity := models.NewUserIdentity(userID, provider, providerID)
+	identity.CreatedAt = time.Now()
+	s.identities = append(s.identities, identity)
+}
+
+// providers returns the providers linked to a user
+func (s *accountStore) providers(userID uuid.UUID) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var providers []string
+	for _, identity := range s.identities {
+		if identity.UserID == userID {
+			providers = append(providers, identity.Provider)
+		}
+	}
+	return providers
+}
+
+// addToken stores a token for a user in table and returns the raw token
+func (s *accountStore) addToken(table string, userID uuid.UUID, expiresAt time.Time) string {
+	raw := uuid.NewString()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens[table] == nil {
+		s.tokens[table] = map[string]*storedToken{}
+	}
+	hash := utils.HashOpaqueToken(raw)
+	s.tokens[table][hash] = &storedToken{ID: uuid.New(), UserID: userID, TokenHash: hash, ExpiresAt: expiresAt}
+	return raw
+}
+
+// tokenCount returns how many tokens a user has in table
+func (s *accountStore) tokenCount(table string, userID uuid.UUID) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, token := range s.tokens[table] {
+		if token.UserID == userID {
+			count++
+		}
+	}
+	return count
+}
+
+// argument returns the value bound to a $n placeholder
+func argument(n string, args []driver.NamedValue) interface{} {
+	var i int
+	fmt.Sscan(n, &i)
+	return args[i-1].Value
+}
+
+// conditions returns the column = value and column IN (values) filters of a statement's WHERE clause
+func conditions(query string, args []driver.NamedValue) map[string]interface{} {
+	where := map[string]interface{}{}
+	if i := strings.Index(query, " WHERE "); i >= 0 {
+		for _, match := range conditionPattern.FindAllStringSubmatch(query[i:], -1) {
+			where[match[1]] = argument(match[2], args)
+		}
+		for _, match := range inPattern.FindAllStringSubmatch(query[i:], -1) {
+			var values []interface{}
+			for _, n := range placeholder.FindAllStringSubmatch(match[2], -1) {
+				values = append(values, argument(n[1], args))
+			}
+			where[match[1]] = values
+		}
+	}
+	return where
+}
+
+// matches reports whether a row with the given column values passes every filter
+func matches(row, where map[string]interface{}) bool {
+	for column, value := range where {
+		if values, ok := value.([]interface{}); ok {
+			found := false
+			for _, v := range values {
+				found = found || row[column] == v
+			}
+			if !found {
+				return false
+			}
+			continue
+		}
+		if row[column] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func userRow(user *models.User) map[string]interface{} {
+	return map[string]interface{}{"id": user.ID, "username": user.Username, "email": user.Email}
+}
+
+func identityRow(identity *models.UserIdentity) map[string]interface{} {
+	return map[string]interface{}{
+		"id": identity.ID, "user_id": identity.UserID, "provider": identity.Provider, "provider_id": identity.ProviderID,
+	}
+}
+
+func tokenRow(token *storedToken) map[string]interface{} {
+	return map[string]interface{}{"id": token.ID, "user_id": token.UserID, "token_hash": token.TokenHash}
+}
+
+func (s *accountStore) query(query string, args []driver.NamedValue) (driver.Rows, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if match := insertPattern.FindStringSubmatch(query); match != nil {
+		values := map[string]interface{}{}
+		for i, column := range strings.Split(match[2], ",") {
+			values[strings.Trim(column, `"`)] = args[i].Value
+		}
+		return s.insert(match[1], values)
+	}
+
+	table := tablePattern.FindStringSubmatch(query)
+	if table == nil || !strings.HasPrefix(query, "SELECT ") {
+		return nil, fmt.Errorf("accountStore cannot answer %q", query)
+	}
+	where := conditions(query, args)
+
+	switch table[1] {
+	case "users":
+		// Unscoped queries also see soft-deleted users
+		scoped := strings.Contains(query, `"deleted_at" IS NULL`)
+		var matched []*models.User
+		for _, user := range s.users {
+			if matches(userRow(user), where) && !(scoped && user.DeletedAt.Valid) {
+				matched = append(matched, user)
+			}
+		}
+		if strings.HasPrefix(query, "SELECT count(*)") {
+			return rowsOf([]string{"count"}, []driver.Value{int64(len(matched))}), nil
+		}
+		rows := rowsOf([]string{
+			"id", "username", "email", "email_verified", "password", "provider", "display_name", "profile_picture_url",
+			"last_username_change_at",
+		})
+		for _, user := range matched {
+			var lastUsernameChange driver.Value
+			if user.LastUsernameChangeAt != nil {
+				lastUsernameChange = *user.LastUsernameChangeAt
+			}
+			rows.values = append(rows.values, []driver.Value{
+				user.ID.String(), user.Username, user.Email, user.EmailVerified, user.Password, user.Provider,
+				user.DisplayName, user.ProfilePictureURL, lastUsernameChange,
+			})
+		}
+		return rows, nil
+	case "user_identities":
+		var linked []*models.UserIdentity
+		for _, identity := range s.identities {
+			if matches(identityRow(identity), where) {
+				linked = append(linked, identity)
+			}
+		}
+		sort.SliceStable(linked, func(i, j int) bool { return linked[i].CreatedAt.Before(linked[j].CreatedAt) })
+		switch {
+		case strings.HasPrefix(query, "SELECT count(*)"):
+			return rowsOf([]string{"count"}, []driver.Value{int64(len(linked))}), nil
+		case strings.HasPrefix(query, `SELECT "provider"`):
+			rows := rowsOf([]string{"provider"})
+			for _, identity := range linked {
+				rows.values = append(rows.values, []driver.Value{identity.Provider})
+			}
+			return rows, nil
+		}
+		rows := rowsOf([]string{"id", "user_id", "provider", "provider_id"})
+		for _, identity := range linked {
+			rows.values = append(rows.values, []driver.Value{
+				identity.ID.String(), identity.UserID.String(), identity.Provider, identity.ProviderID,
+			})
+		}
+		return rows, nil
+	}
+	rows := rowsOf([]string{"id", "user_id", "token_hash", "expires_at"})
+	for _, token := range s.tokens[table[1]] {
+		if matches(tokenRow(token), where) {
+			rows.values = append(rows.values, []driver.Value{token.ID.String(), token.UserID.String(), token.TokenHash, token.ExpiresAt})
+		}
+	}
+	return rows, nil
+}
+
+func (s *accountStore) insert(table string, values map[string]interface{}) (driver.Rows, error) {
+	id := values["id"].(uuid.UUID)
+	switch table {
+	case "users":
+		s.users[id] = &models.User{
+			ID:            id,
+			Username:      values["username"].(string),
+			Email:         values["email"].(string),
+			EmailVerified: values["email_verified"].(bool),
+			Password:      values["password"].(string),
+			Provider:      values["provider"].(string),
+		}
+	case "user_identities":
+		s.identities = append(s.identities, &models.UserIdentity{
+			ID: id, UserID: values["user_id"].(uuid.UUID), Provider: values["provider"].(string),
+			ProviderID: values["provider_id"].(string), CreatedAt: time.Now(),
+		})
+	default:
+		if s.tokens[table] == nil {
+			s.tokens[table] = map[string]*storedToken{}
+		}
+		hash := values["token_hash"].(string)
+		s.tokens[table][hash] = &storedToken{
+			ID: id, UserID: values["user_id"].(uuid.UUID), TokenHash: hash, ExpiresAt: values["expires_at"].(time.Time),
+		}
+	}
+	return rowsOf([]string{"id", "created_at"}, []driver.Value{id.String(), time.Now()}), nil
+}
+
+func (s *accountStore) exec(query string, args []driver.NamedValue) (driver.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.HasPrefix(query, "DELETE FROM ") {
+		table, where := tablePattern.FindStringSubmatch(query)[1], conditions(query, args)
+		deleted := int64(0)
+		if table == "users" {
+			for id, user := range s.users {
+				if matches(userRow(user), where) {
+					delete(s.users, id)
+					deleted++
+				}
+			}
+			return driver.RowsAffected(deleted), nil
+		}
+		if table == "user_identities" {
+			kept := s.identities[:0]
+			for _, identity := range s.identities {
+				if matches(identityRow(identity), where) {
+					deleted++
+					continue
+				}
+				kept = append(kept, identity)
+			}
+			s.identities = kept
+			return driver.RowsAffected(deleted), nil
+		}
+		for hash, token := range s.tokens[table] {
+			if matches(tokenRow(token), where) {
+				delete(s.tokens[table], hash)
+				deleted++
+			}
+		}
+		return driver.RowsAffected(deleted), nil
+	}
+	if strings.HasPrefix(query, `UPDATE "users" SET `) {
+		// The user's ID is always the last argument
+		user, ok := s.users[args[len(args)-1].Value.(uuid.UUID)]
+		if !ok {
+			return driver.RowsAffected(0), nil
+		}
+		// A single-column update or a full Save of the user; columns the store does not keep are ignored on a Save
+		assignments := assignmentPattern.FindAllStringSubmatch(query, -1)
+		for _, match := range assignments {
+			var value interface{}
+			if match[2] != "" {
+				value = argument(match[2], args)
+			}
+			switch match[1] {
+			case "username":
+				user.Username = value.(string)
+			case "email":
+				user.Email, _ = value.(string)
+			case "password":
+				user.Password = value.(string)
+			case "email_verified":
+				user.EmailVerified = value.(bool)
+			case "display_name":
+				user.DisplayName = value.(string)
+			case "profile_picture_url":
+				user.ProfilePictureURL = value.(string)
+			case "last_username_change_at":
+				switch at := value.(type) {
+				case time.Time:
+					user.LastUsernameChangeAt = &at
+				case *time.Time:
+					user.LastUsernameChangeAt = at
+				}
+			case "deleted_at":
+				switch at := value.(type) {
+				case time.Time:
+					user.DeletedAt = gorm.DeletedAt{Time: at, Valid: true}
+				case gorm.DeletedAt:
+					user.DeletedAt = at
+				}
+			default:
+				if len(assignments) == 1 {
+					return nil, fmt.Errorf("accountStore cannot update users.%s", match[1])
+				}
+			}
+		}
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("accountStore cannot run %q", query)
+}
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestDeleteAccountAnonymizesTheUser(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	user.DisplayName = "Ace"
+	store.link(user.ID, "github", "github-ace")
+	for _, table := range []string{refreshTokens, passwordResetTokens, emailVerificationTokens} {
+		store.addToken(table, user.ID, time.Now().Add(time.Hour))
+	}
+	dir := t.TempDir()
+	t.Setenv("AVATAR_DIR", dir)
+	os.WriteFile(filepath.Join(dir, user.ID.String()+".png"), []byte("avatar"), 0o644)
+
+	if rec := serve(http.MethodDelete, "/user/account", "/user/account", "", asUser(user, DeleteUserAccount)); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	stored := store.user(user.ID)
+	if stored == nil || !stored.DeletedAt.Valid {
+		t.Fatal("the user row should be kept and soft-deleted")
+	}
+	if stored.Username != "deleted-"+user.ID.String() || stored.DisplayName != DeletedUserDisplayName ||
+		stored.Email != "" || stored.Password != "" {
+		t.Fatalf("personal data was not removed: %+v", stored)
+	}
+	if providers := store.providers(user.ID); len(providers) != 0 {
+		t.Fatalf("linked providers were kept: %v", providers)
+	}
+	for _, table := range []string{refreshTokens, passwordResetTokens, emailVerificationTokens} {
+		if n := store.tokenCount(table, user.ID); n != 0 {
+			t.Errorf("%d %s left", n, table)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, user.ID.String()+".png")); !os.IsNotExist(err) {
+		t.Fatal("the avatar should have been removed")
+	}
+
+	// Other services still resolve the ID, under the anonymized name
+	body := fmt.Sprintf(`{"user_ids":[%q]}`, user.ID)
+	rec := serve(http.MethodPost, "/users/batch", "/users/batch", body, GetMultipleUserDetails)
+	var batch struct {
+		Users map[string]map[string]interface{} `json:"users"`
+	}
+	decode(t, rec, &batch)
+	if got := batch.Users[user.ID.String()]; got["display_name"] != DeletedUserDisplayName {
+		t.Fatalf("expected the deleted user under the anonymized name, got %v", batch.Users)
+	}
+	if rec := serve(http.MethodGet, "/user/:userId", "/user/"+user.ID.String(), "", GetPublicUser); rec.Code != http.StatusNotFound {
+		t.Fatalf("the public profile of a deleted user: expected 404, got %d", rec.Code)
+	}
+}
+
+func TestDeletedUserCannotLogIn(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+
+	if rec := serve(http.MethodDelete, "/user/account", "/user/account", "", asUser(user, DeleteUserAccount)); rec.Code != http.StatusOK {
+		t.Fatalf("delete: expected 200, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodPost, "/login", "/login", `{"username":"ace","password":"hunter22"}`, Login); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("login after deletion: expected 401, got %d", rec.Code)
+	}
+}
+
+func TestDeletedUsersTokenCannotActOnTheNextOwnerOfTheName(t *testing.T) {
+	store := useAccountStore(t)
+	deleted := store.addUser(t, "ace", "hunter22")
+	// The claims of the token issued before the deletion
+	token := &models.User{ID: deleted.ID, Username: "ace"}
+	t.Setenv("AVATAR_DIR", t.TempDir())
+
+	if rec := serve(http.MethodDelete, "/user/account", "/user/account", "", asUser(token, DeleteUserAccount)); rec.Code != http.StatusOK {
+		t.Fatalf("delete: expected 200, got %d", rec.Code)
+	}
+	// Someone else registers the released username
+	successor := store.addUser(t, "ace", "s3cret-pass")
+
+	if rec := serve(http.MethodGet, "/user/profile", "/user/profile", "", asUser(token, GetUserProfile)); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("profile with the deleted user's token: expected 401, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := serve(http.MethodDelete, "/user/account", "/user/account", "", asUser(token, DeleteUserAccount)); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("delete with the deleted user's token: expected 401, got %d", rec.Code)
+	}
+	if stored := store.user(successor.ID); stored.DeletedAt.Valid || stored.Username != "ace" {
+		t.Fatalf("the new owner of the name was changed: %+v", stored)
+	}
+
+	// A token without a user ID is not enough either
+	if rec := serve(http.MethodGet, "/user/profile", "/user/profile", "", asUser(&models.User{Username: "ace"}, GetUserProfile)); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("token without a user ID: expected 401, got %d", rec.Code)
+	}
+}
+
+// newTournamentServer fakes the tournament service endpoints read by an export, serving
+// totalActivities activities ten to a page, and records the Authorization headers it sees
+func newTournamentServer(t *testing.T, totalActivities int) *[]string {
+	var authHeaders []string
+	router := gin.New()
+	router.Use(func(c *gin.Context) { authHeaders = append(authHeaders, c.GetHeader("Authorization")) })
+	router.GET("/users/me/tournaments", func(c *gin.Context) {
+		c.JSON(http.StatusOK, []gin.H{{"id": "t1", "name": "Spring Cup"}})
+	})
+	router.GET("/dashboard/activities", func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.Query("page"))
+		activities := []gin.H{}
+		for i := (page - 1) * 10; i < page*10 && i < totalActivities; i++ {
+			activities = append(activities, gin.H{"id": i})
+		}
+		c.JSON(http.StatusOK, gin.H{"activities": activities, "total": totalActivities})
+	})
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	t.Setenv("TOURNAMENT_SERVICE_URL", server.URL)
+	return &authHeaders
+}
+
+// exportAs runs ExportUserData as user with a bearer token
+func exportAs(t *testing.T, user *models.User) (*httptest.ResponseRecorder, map[string]json.RawMessage) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/user/export", asUser(user, ExportUserData))
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/user/export", nil)
+	request.Header.Set("Authorization", "Bearer ace-token")
+	router.ServeHTTP(recorder, request)
+	var export map[string]json.RawMessage
+	if recorder.Code == http.StatusOK {
+		decode(t, recorder, &export)
+	}
+	return recorder, export
+}
+
+func TestExportUserData(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	store.link(user.ID, "github", "github-ace")
+	authHeaders := newTournamentServer(t, 12)
+
+	rec, export := exportAs(t, user)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if want := fmt.Sprintf(`attachment; filename="user-%s-export.json"`, user.ID); rec.Header().Get("Content-Disposition") != want {
+		t.Fatalf("unexpected Content-Disposition %q", rec.Header().Get("Content-Disposition"))
+	}
+
+	var profile struct {
+		ID       uuid.UUID `json:"id"`
+		Username string    `json:"username"`
+		Email    string    `json:"email"`
+	}
+	json.Unmarshal(export["user"], &profile)
+	if profile.ID != user.ID || profile.Username != "ace" || profile.Email != "ace@example.com" {
+		t.Fatalf("unexpected profile %s", export["user"])
+	}
+	var linked []struct {
+		Provider string `json:"provider"`
+	}
+	json.Unmarshal(export["linked_accounts"], &linked)
+	if len(linked) != 1 || linked[0].Provider != "github" {
+		t.Fatalf("unexpected linked accounts %s", export["linked_accounts"])
+	}
+	var tournaments, activities []json.RawMessage
+	json.Unmarshal(export["tournaments"], &tournaments)
+	json.Unmarshal(export["activities"], &activities)
+	if len(tournaments) != 1 || len(activities) != 12 {
+		t.Fatalf("expected 1 tournament and all 12 activities, got %d and %d", len(tournaments), len(activities))
+	}
+	for _, header := range *authHeaders {
+		if header != "Bearer ace-token" {
+			t.Fatalf("the caller's token was not forwarded, got %q", header)
+		}
+	}
+	if _, ok := export["tournament_data_error"]; ok {
+		t.Fatalf("unexpected tournament_data_error %s", export["tournament_data_error"])
+	}
+}
+
+func TestExportUserDataWithoutTheTournamentService(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	t.Setenv("TOURNAMENT_SERVICE_URL", "")
+
+	rec, export := exportAs(t, user)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if _, ok := export["user"]; !ok {
+		t.Fatal("the profile should still be exported")
+	}
+	if _, ok := export["tournament_data_error"]; !ok {
+		t.Fatalf("expected tournament_data_error, got %v", export)
+	}
+}
+
+func TestPurgeUserAccount(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	store.link(user.ID, "github", "github-ace")
+	store.addToken(refreshTokens, user.ID, time.Now().Add(time.Hour))
+	t.Setenv("AVATAR_DIR", t.TempDir())
+	// A soft-deleted account can still be purged
+	if rec := serve(http.MethodDelete, "/user/account", "/user/account", "", asUser(user, DeleteUserAccount)); rec.Code != http.StatusOK {
+		t.Fatalf("delete: expected 200, got %d", rec.Code)
+	}
+	store.link(user.ID, "github", "github-ace")
+
+	if rec := serve(http.MethodDelete, "/admin/users/:userId", "/admin/users/"+user.ID.String(), "", PurgeUserAccount); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if store.user(user.ID) != nil || len(store.providers(user.ID)) != 0 {
+		t.Fatal("the user and their linked accounts should be gone")
+	}
+
+	if rec := serve(http.MethodDelete, "/admin/users/:userId", "/admin/users/"+user.ID.String(), "", PurgeUserAccount); rec.Code != http.StatusNotFound {
+		t.Fatalf("purging again: expected 404, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodDelete, "/admin/users/:userId", "/admin/users/not-a-uuid", "", PurgeUserAccount); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid ID: expected 400, got %d", rec.Code)
+	}
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/cliffdoyle/gamer_world/user-service/database"
 	"github.com/cliffdoyle/gamer_world/user-service/models"
@@ -17,9 +19,51 @@ type UserForLinking struct{
 
 }
 
+// Page sizes for ListUsersForLinking
+const (
+	defaultLinkingPageSize = 50
+	maxLinkingPageSize     = 100
+)
+
+// likeEscaper escapes LIKE wildcards so a search term is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ListUsersForLinking returns a page of users to link to tournament participants.
+// ?q= filters by a case-insensitive substring of username or display name; ?page and ?pageSize paginate.
 func ListUsersForLinking(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+		return
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pageSize", strconv.Itoa(defaultLinkingPageSize)))
+	if err != nil || pageSize < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pageSize"})
+		return
+	}
+	if pageSize > maxLinkingPageSize {
+		pageSize = maxLinkingPageSize
+	}
+
+	// No provider or password filter: OAuth-only users can be linked like anyone else
+	query := database.DB.Model(&models.User{})
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		pattern := "%" + likeEscaper.Replace(q) + "%"
+		query = query.Where("username ILIKE ? OR display_name ILIKE ?", pattern, pattern)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count users: " + err.Error()})
+		return
+	}
+
 	var users []models.User
-	err:=database.DB.Select("id, username").Order("username asc").Find(&users).Error
+	err = query.Select("id, username, display_name").
+		Order("username asc").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Find(&users).Error
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users: " + err.Error()})
 		return
@@ -34,5 +78,5 @@ func ListUsersForLinking(c *gin.Context) {
 			DisplayName: user.DisplayName,
 		}
 	}
-	c.JSON(http.StatusOK, gin.H{"users": responseUsers})
-}
\ No newline at end of file
+	c.JSON(http.StatusOK, gin.H{"users": responseUsers, "total": total, "page": page, "pageSize": pageSize})
+}
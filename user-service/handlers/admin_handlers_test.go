@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// serve runs handler for a request to target and returns the recorded response
+func serve(method, pattern, target string, body string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, pattern, handler)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func decode(t *testing.T, recorder *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(recorder.Body.Bytes(), v); err != nil {
+		t.Fatalf("response is not JSON: %v (%s)", err, recorder.Body.String())
+	}
+}
+
+func TestListUsersForLinkingPagesAndSearches(t *testing.T) {
+	db := useScriptedDB(t)
+	alice := uuid.New()
+	db.query = func(query string, args []driver.NamedValue) (driver.Rows, error) {
+		if strings.HasPrefix(query, "SELECT count(*)") {
+			return rowsOf([]string{"count"}, []driver.Value{int64(3)}), nil
+		}
+		return rowsOf([]string{"id", "username", "display_name"}, []driver.Value{alice.String(), "alice_100%", "Alice"}), nil
+	}
+
+	rec := serve(http.MethodGet, "/list", "/list?q=100%25&page=2&pageSize=500", "", ListUsersForLinking)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var body struct {
+		Users    []UserForLinking `json:"users"`
+		Total    int              `json:"total"`
+		Page     int              `json:"page"`
+		PageSize int              `json:"pageSize"`
+	}
+	decode(t, rec, &body)
+	if body.Total != 3 || body.Page != 2 || body.PageSize != maxLinkingPageSize {
+		t.Fatalf("unexpected paging %+v", body)
+	}
+	if len(body.Users) != 1 || body.Users[0].ID != alice || body.Users[0].DisplayName != "Alice" {
+		t.Fatalf("unexpected users %+v", body.Users)
+	}
+
+	// The wildcard in the search term is escaped, and page 2 starts after the first full page
+	args := db.argsOf("SELECT id, username, display_name")
+	if len(args) != 4 || args[0] != `%100\%%` || args[2] != maxLinkingPageSize || args[3] != maxLinkingPageSize {
+		t.Fatalf("unexpected query arguments %v", args)
+	}
+	if selects := db.statements("SELECT id, username, display_name"); !strings.Contains(selects[0], "ILIKE") ||
+		!strings.Contains(selects[0], `"users"."deleted_at" IS NULL`) {
+		t.Fatalf("expected a case-insensitive search of live users, got %s", selects[0])
+	}
+}
+
+func TestListUsersForLinkingRejectsBadPaging(t *testing.T) {
+	useScriptedDB(t)
+	for _, target := range []string{"/list?page=0", "/list?page=x", "/list?pageSize=0"} {
+		if rec := serve(http.MethodGet, "/list", target, "", ListUsersForLinking); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, rec.Code)
+		}
+	}
+}
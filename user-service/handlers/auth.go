@@ -75,16 +75,27 @@ func Register(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
 		return
 	}
+	refreshToken, err := issueRefreshToken(newUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating refresh token"})
+		return
+	}
 
 	fmt.Printf("Registering user: %s with email: %s\n", input.Username, input.Email)
+	// The account works straight away; the verification link can be resent if it is lost or expires
+	if err := issueEmailVerification(newUser); err != nil {
+		log.Printf("Error issuing email verification for user '%s': %v", newUser.Username, err)
+	}
 
 
 	c.JSON(http.StatusOK, gin.H{
-		"token": token,
+		"token":         token,
+		"refresh_token": refreshToken,
 		"user": gin.H{
-			"id":       newUser.ID,
-			"username": newUser.Username,
-			"email":    newUser.Email,
+			"id":             newUser.ID,
+			"username":       newUser.Username,
+			"email":          newUser.Email,
+			"email_verified": newUser.EmailVerified,
 		},
 	})
 }
@@ -124,13 +135,20 @@ func Login(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
 		return
 	}
+	refreshToken, err := issueRefreshToken(&user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating refresh token"})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"token": token,
+		"token":         token,
+		"refresh_token": refreshToken,
 		"user": gin.H{
-			"id":       user.ID,
-			"username": user.Username,
-			"email":    user.Email,
+			"id":             user.ID,
+			"username":       user.Username,
+			"email":          user.Email,
+			"email_verified": user.EmailVerified,
 		},
 	})
 }
@@ -178,99 +196,26 @@ func GoogleSignIn(c *gin.Context) {
 		return
 	}
 
-	googleUserID := payload.Subject
-	userEmail, _ := payload.Claims["email"].(string)
-	userNameFromEmail := strings.Split(userEmail, "@")[0]
+	signInWithOAuthProfile(c, googleProfile(payload))
+}
+
+// googleProfile reads the user's details from a validated Google ID token
+func googleProfile(payload *idtoken.Payload) *oauthProfile {
+	email, _ := payload.Claims["email"].(string)
 	displayName, _ := payload.Claims["name"].(string)
 	profilePictureURL, _ := payload.Claims["picture"].(string)
-
-	var user models.User
-	err = database.DB.Where("provider = ? AND provider_id = ?", "google", googleUserID).First(&user).Error
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error checking Google user"})
-		return
-	}
-
-	if err == nil {
-		needsUpdate := false
-		if user.DisplayName != displayName && displayName != "" {
-			user.DisplayName = displayName
-			needsUpdate = true
-		}
-		if user.ProfilePictureURL != profilePictureURL && profilePictureURL != "" {
-			user.ProfilePictureURL = profilePictureURL
-			needsUpdate = true
-		}
-		if needsUpdate {
-			if err := database.DB.Save(&user).Error; err != nil {
-				fmt.Println("Error updating user details on Google Sign-In:", err)
-			}
-		}
-	} else {
-		err = database.DB.Where("email = ?", userEmail).First(&user).Error
-		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error checking email"})
-			return
-		}
-
-		if err == nil {
-			if user.Provider != "" && user.Provider != "google" {
-				c.JSON(http.StatusConflict, gin.H{
-					"error":    "Account with this email already exists. Please sign in with your original method.",
-					"provider": user.Provider,
-					"code":     "account_exists",
-				})
-				return
-			} else if user.Provider == "" {
-				user.Provider = "google"
-				user.ProviderID = &googleUserID
-				user.DisplayName = displayName
-				user.ProfilePictureURL = profilePictureURL
-				if err := database.DB.Save(&user).Error; err != nil {
-					c.JSON(http.StatusInternalServerError, gin.H{"error": "Error linking Google account to existing user"})
-					return
-				}
-			}
-		} else {
-			finalUsername := userNameFromEmail
-			count := 0
-			for {
-				var tempUser models.User
-				if err := database.DB.Where("username = ?", finalUsername).First(&tempUser).Error; errors.Is(err, gorm.ErrRecordNotFound) {
-					break
-				}
-				count++
-				finalUsername = fmt.Sprintf("%s%d", userNameFromEmail, count)
-				if count > 100 {
-					c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not generate unique username"})
-					return
-				}
-			}
-
-			newUser := models.NewOAuthUser(finalUsername, userEmail, displayName, profilePictureURL, "google", googleUserID)
-			if err := database.DB.Create(&newUser).Error; err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating new Google user"})
-				return
-			}
-			user = *newUser
-		}
+	emailVerified, ok := payload.Claims["email_verified"].(bool)
+	if !ok {
+		emailVerified = true // Tokens without the claim were always accepted; keep it that way
 	}
 
-	token, err := utils.GenerateToken(user.Username, user.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating platform token"})
-		return
+	return &oauthProfile{
+		Provider:          "google",
+		ProviderID:        payload.Subject,
+		Email:             email,
+		EmailVerified:     emailVerified,
+		Username:          strings.Split(email, "@")[0],
+		DisplayName:       displayName,
+		ProfilePictureURL: profilePictureURL,
 	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"token": token,
-		"user": gin.H{
-			"id":                  user.ID,
-			"username":            user.Username,
-			"email":               user.Email,
-			"display_name":        user.DisplayName,
-			"profile_picture_url": user.ProfilePictureURL,
-			"provider":            user.Provider,
-		},
-	})
 }
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cliffdoyle/gamer_world/user-service/database"
+	"github.com/gin-gonic/gin"
+)
+
+// maxAvatarSize is the largest avatar image accepted, in bytes
+const maxAvatarSize = 2 << 20
+
+// avatarExtensions maps the accepted image types to the extension they are stored with
+var avatarExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// AvatarDir is the directory uploaded avatars are stored in and served from,
+// AVATAR_DIR or uploads/avatars by default
+func AvatarDir() string {
+	if dir := os.Getenv("AVATAR_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join("uploads", "avatars")
+}
+
+// UploadAvatar stores a JPEG or PNG image of up to 2MB sent as the multipart "avatar" field
+// and points the user's profile picture at it. The file is named after the user ID, so a new
+// upload replaces the old one.
+func UploadAvatar(c *gin.Context) {
+	user, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	// Leave room for the multipart headers around the file itself
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxAvatarSize+64<<10)
+	file, header, err := c.Request.FormFile("avatar")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Avatar must be 2MB or smaller"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "An image file is required in the 'avatar' field"})
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxAvatarSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Avatar must be 2MB or smaller"})
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(file, maxAvatarSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Error reading uploaded file"})
+		return
+	}
+	if len(data) > maxAvatarSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Avatar must be 2MB or smaller"})
+		return
+	}
+
+	// Trust the file's contents, not the client's declared content type
+	ext, ok := avatarExtensions[http.DetectContentType(data)]
+	if !ok {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Avatar must be a JPEG or PNG image"})
+		return
+	}
+
+	filename, err := saveAvatar(user.ID.String(), ext, data)
+	if err != nil {
+		log.Printf("Error saving avatar for user '%s': %v", user.Username, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error saving avatar"})
+		return
+	}
+
+	// The version query makes clients fetch the new image even though the filename is stable
+	user.ProfilePictureURL = fmt.Sprintf("%s/avatars/%s?v=%d",
+		strings.TrimSuffix(os.Getenv("AVATAR_BASE_URL"), "/"), filename, time.Now().Unix())
+	if err := database.DB.Save(user).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating user profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":             "Avatar uploaded successfully",
+		"profile_picture_url": user.ProfilePictureURL,
+	})
+}
+
+// saveAvatar writes data to <userID><ext> in AvatarDir, replacing it atomically, and removes
+// an earlier avatar stored with the other extension. It returns the file name.
+func saveAvatar(userID, ext string, data []byte) (string, error) {
+	dir := AvatarDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(dir, userID+"-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(tmp, bytes.NewReader(data)); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	filename := userID + ext
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, filename)); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := os.Chmod(filepath.Join(dir, filename), 0o644); err != nil {
+		log.Printf("Warning: could not set permissions on avatar %s: %v", filename, err)
+	}
+
+	for _, other := range avatarExtensions {
+		if other != ext {
+			os.Remove(filepath.Join(dir, userID+other))
+		}
+	}
+	return filename, nil
+}
+
+// removeAvatar deletes any avatar stored for userID
+func removeAvatar(userID string) error {
+	for _, ext := range avatarExtensions {
+		err := os.Remove(filepath.Join(AvatarDir(), userID+ext))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// uploadAvatar posts data as the "avatar" field of a multipart form as user
+func uploadAvatar(t *testing.T, user *models.User, data []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("avatar", "avatar.png")
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	part.Write(data)
+	form.Close()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/user/avatar", asUser(user, UploadAvatar))
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/user/avatar", &body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// pngImage returns a small encoded PNG
+func pngImage(t *testing.T) []byte {
+	t.Helper()
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return encoded.Bytes()
+}
+
+func TestUploadAvatarUpdatesTheProfile(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	dir := t.TempDir()
+	t.Setenv("AVATAR_DIR", dir)
+	t.Setenv("AVATAR_BASE_URL", "https://users.example/")
+	// An earlier JPEG avatar is replaced by the PNG
+	os.WriteFile(filepath.Join(dir, user.ID.String()+".jpg"), []byte("old"), 0o644)
+
+	data := pngImage(t)
+	rec := uploadAvatar(t, user, data)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		ProfilePictureURL string `json:"profile_picture_url"`
+	}
+	decode(t, rec, &body)
+	prefix := "https://users.example/avatars/" + user.ID.String() + ".png?v="
+	if !strings.HasPrefix(body.ProfilePictureURL, prefix) {
+		t.Fatalf("expected a URL starting with %s, got %s", prefix, body.ProfilePictureURL)
+	}
+	if got := store.user(user.ID).ProfilePictureURL; got != body.ProfilePictureURL {
+		t.Fatalf("profile picture was not saved, stored %q", got)
+	}
+
+	stored, err := os.ReadFile(filepath.Join(dir, user.ID.String()+".png"))
+	if err != nil || !bytes.Equal(stored, data) {
+		t.Fatalf("avatar file was not written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, user.ID.String()+".jpg")); !os.IsNotExist(err) {
+		t.Fatal("the earlier JPEG avatar should have been removed")
+	}
+}
+
+func TestUploadAvatarRejectsOversizedAndNonImageFiles(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	t.Setenv("AVATAR_DIR", t.TempDir())
+
+	oversized := append(pngImage(t), make([]byte, maxAvatarSize)...)
+	if rec := uploadAvatar(t, user, oversized); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("oversized file: expected 413, got %d", rec.Code)
+	}
+	if rec := uploadAvatar(t, user, []byte("GIF89a not really")); rec.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("non-image: expected 415, got %d", rec.Code)
+	}
+	if got := store.user(user.ID).ProfilePictureURL; got != "" {
+		t.Fatalf("a rejected upload changed the profile picture to %q", got)
+	}
+	if rec := uploadAvatar(t, &models.User{ID: uuid.New(), Username: "nobody"}, pngImage(t)); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("unknown user: expected 401, got %d", rec.Code)
+	}
+}
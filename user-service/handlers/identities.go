@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cliffdoyle/gamer_world/user-service/database"
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"google.golang.org/api/idtoken"
+	"gorm.io/gorm"
+)
+
+// linkedProviders returns the providers linked to a user, in the order they were linked
+func linkedProviders(userID uuid.UUID) ([]string, error) {
+	var providers []string
+	err := database.DB.Model(&models.UserIdentity{}).Where("user_id = ?", userID).Order("created_at").Pluck("provider", &providers).Error
+	if providers == nil {
+		providers = []string{}
+	}
+	return providers, err
+}
+
+// LinkProvider links another sign-in provider to the authenticated user. Google takes an
+// "id_token"; Discord and GitHub take an authorization "code" (and optional "redirect_uri").
+func LinkProvider(c *gin.Context) {
+	user, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		IDToken     string `json:"id_token,omitempty"`
+		Code        string `json:"code,omitempty"`
+		RedirectURI string `json:"redirect_uri,omitempty"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	providerName := c.Param("provider")
+	title := providerTitle(providerName)
+	var profile *oauthProfile
+	if providerName == "google" {
+		if input.IDToken == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID token is required"})
+			return
+		}
+		payload, err := idtoken.Validate(c.Request.Context(), input.IDToken, getGoogleClientID())
+		if err != nil {
+			fmt.Printf("Google token validation error: %v\n", err)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Google authentication failed. Please try again."})
+			return
+		}
+		profile = googleProfile(payload)
+	} else {
+		provider, ok := oauthProviders[providerName]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unsupported provider %q", providerName)})
+			return
+		}
+		if input.Code == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Authorization code is required"})
+			return
+		}
+		var err error
+		profile, err = provider.FetchProfile(c.Request.Context(), input.Code, input.RedirectURI)
+		if err != nil {
+			fmt.Printf("%s link error: %v\n", title, err)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("%s authentication failed. Please try again.", title)})
+			return
+		}
+	}
+
+	owner, err := findUserByIdentity(profile.Provider, profile.ProviderID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking linked accounts"})
+		return
+	}
+	if owner != nil {
+		if owner.ID != user.ID {
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("This %s account is already linked to another user", title)})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%s is already linked", title)})
+		return
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.UserIdentity{}).Where("user_id = ? AND provider = ?", user.ID, profile.Provider).Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking linked accounts"})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("A different %s account is already linked; unlink it first", title)})
+		return
+	}
+
+	if err := database.DB.Create(models.NewUserIdentity(user.ID, profile.Provider, profile.ProviderID)).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Error linking %s account", title)})
+		return
+	}
+
+	providers, err := linkedProviders(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error listing linked accounts"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%s linked successfully", title), "linked_providers": providers})
+}
+
+// UnlinkProvider removes a linked sign-in provider from the authenticated user. The last
+// remaining way to sign in (a password or a linked provider) cannot be removed.
+func UnlinkProvider(c *gin.Context) {
+	user, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	providerName := c.Param("provider")
+	title := providerTitle(providerName)
+	providers, err := linkedProviders(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error listing linked accounts"})
+		return
+	}
+
+	linked := false
+	for _, p := range providers {
+		if p == providerName {
+			linked = true
+			break
+		}
+	}
+	if !linked {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("%s is not linked to this account", title)})
+		return
+	}
+
+	loginMethods := len(providers)
+	if user.Password != "" {
+		loginMethods++
+	}
+	if loginMethods <= 1 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Cannot unlink your only way to sign in. Link another provider first.",
+			"code":  "last_login_method",
+		})
+		return
+	}
+
+	if err := database.DB.Where("user_id = ? AND provider = ?", user.ID, providerName).Delete(&models.UserIdentity{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Error unlinking %s account", title)})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
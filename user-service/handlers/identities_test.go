@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+)
+
+// useProvider replaces a code-flow provider for the duration of the test
+func useProvider(t *testing.T, name string, provider oauthCodeProvider) {
+	previous := oauthProviders[name]
+	oauthProviders[name] = provider
+	t.Cleanup(func() { oauthProviders[name] = previous })
+}
+
+func TestLinkASecondProvider(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	store.link(user.ID, "github", "github-ace")
+	useProvider(t, "discord", fakeProvider{profile: discordProfile("ace", "ace@discord.example")})
+
+	rec := serve(http.MethodPost, "/link/:provider", "/link/discord", `{"code":"abc"}`, asUser(user, LinkProvider))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		LinkedProviders []string `json:"linked_providers"`
+	}
+	decode(t, rec, &body)
+	if len(body.LinkedProviders) != 2 || body.LinkedProviders[0] != "github" || body.LinkedProviders[1] != "discord" {
+		t.Fatalf("expected github then discord, got %v", body.LinkedProviders)
+	}
+
+	// Linking the same account again is a no-op
+	if rec := serve(http.MethodPost, "/link/:provider", "/link/discord", `{"code":"abc"}`, asUser(user, LinkProvider)); rec.Code != http.StatusOK {
+		t.Fatalf("relinking: expected 200, got %d", rec.Code)
+	}
+	if len(store.providers(user.ID)) != 2 {
+		t.Fatalf("relinking must not add another identity, got %v", store.providers(user.ID))
+	}
+}
+
+func TestLinkRefusesAccountsOfOtherUsers(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	other := store.addUser(t, "bo", "hunter22")
+	profile := discordProfile("bo", "bo@discord.example")
+	store.link(other.ID, "discord", profile.ProviderID)
+	useProvider(t, "discord", fakeProvider{profile: profile})
+
+	if rec := serve(http.MethodPost, "/link/:provider", "/link/discord", `{"code":"abc"}`, asUser(user, LinkProvider)); rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodPost, "/link/:provider", "/link/myspace", `{"code":"abc"}`, asUser(user, LinkProvider)); rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown provider: expected 400, got %d", rec.Code)
+	}
+}
+
+func TestUnlinkKeepsTheLastLoginMethod(t *testing.T) {
+	store := useAccountStore(t)
+	oauthOnly := store.addUser(t, "googler", "")
+	store.link(oauthOnly.ID, "google", "google-1")
+
+	rec := serve(http.MethodDelete, "/unlink/:provider", "/unlink/google", "", asUser(oauthOnly, UnlinkProvider))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for the only login method, got %d", rec.Code)
+	}
+	var body struct {
+		Code string `json:"code"`
+	}
+	decode(t, rec, &body)
+	if body.Code != "last_login_method" || len(store.providers(oauthOnly.ID)) != 1 {
+		t.Fatalf("expected the identity to be kept with code last_login_method, got %q", body.Code)
+	}
+
+	// With a second provider linked the first can go
+	store.link(oauthOnly.ID, "discord", "discord-1")
+	if rec := serve(http.MethodDelete, "/unlink/:provider", "/unlink/google", "", asUser(oauthOnly, UnlinkProvider)); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+	if providers := store.providers(oauthOnly.ID); len(providers) != 1 || providers[0] != "discord" {
+		t.Fatalf("expected only discord to remain, got %v", providers)
+	}
+}
+
+func TestUnlinkWithAPassword(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	store.link(user.ID, "google", "google-1")
+
+	if rec := serve(http.MethodDelete, "/unlink/:provider", "/unlink/google", "", asUser(user, UnlinkProvider)); rec.Code != http.StatusNoContent {
+		t.Fatalf("the password is still a login method, expected 204, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodDelete, "/unlink/:provider", "/unlink/google", "", asUser(user, UnlinkProvider)); rec.Code != http.StatusNotFound {
+		t.Fatalf("unlinking again: expected 404, got %d", rec.Code)
+	}
+}
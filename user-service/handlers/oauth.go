@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cliffdoyle/gamer_world/user-service/database"
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/cliffdoyle/gamer_world/user-service/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// oauthProfile is what a sign-in provider tells us about the user, in a provider-neutral form
+type oauthProfile struct {
+	Provider          string // e.g. "google", "discord", "github"
+	ProviderID        string // The user's stable ID at the provider
+	Email             string
+	EmailVerified     bool
+	Username          string // Preferred username; a number is appended if it is taken
+	DisplayName       string
+	ProfilePictureURL string
+}
+
+// providerTitle returns a provider name for user-facing messages, e.g. "discord" -> "Discord"
+func providerTitle(provider string) string {
+	switch provider {
+	case "github":
+		return "GitHub"
+	case "":
+		return ""
+	}
+	return strings.ToUpper(provider[:1]) + provider[1:]
+}
+
+// findUserByIdentity returns the user linked to a provider account, or gorm.ErrRecordNotFound
+func findUserByIdentity(provider, providerID string) (*models.User, error) {
+	var identity models.UserIdentity
+	if err := database.DB.Where("provider = ? AND provider_id = ?", provider, providerID).First(&identity).Error; err != nil {
+		return nil, err
+	}
+	var user models.User
+	if err := database.DB.Where("id = ?", identity.UserID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// signInWithOAuthProfile signs in the user a provider vouched for. A user already linked to the
+// provider account is signed in; otherwise a new account is created. An existing account with the
+// same email is refused with 409 so it cannot be taken over; its owner can sign in with their
+// original method and link the provider with POST /user/link/:provider.
+func signInWithOAuthProfile(c *gin.Context, profile *oauthProfile) {
+	title := providerTitle(profile.Provider)
+
+	var user models.User
+	linked, err := findUserByIdentity(profile.Provider, profile.ProviderID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Database error checking %s user", title)})
+		return
+	}
+	if linked != nil {
+		user = *linked
+	}
+
+	if err == nil {
+		needsUpdate := false
+		if user.DisplayName != profile.DisplayName && profile.DisplayName != "" {
+			user.DisplayName = profile.DisplayName
+			needsUpdate = true
+		}
+		if user.ProfilePictureURL != profile.ProfilePictureURL && profile.ProfilePictureURL != "" {
+			user.ProfilePictureURL = profile.ProfilePictureURL
+			needsUpdate = true
+		}
+		if !user.EmailVerified && profile.EmailVerified && user.Email == profile.Email {
+			user.EmailVerified = true
+			needsUpdate = true
+		}
+		if needsUpdate {
+			if err := database.DB.Save(&user).Error; err != nil {
+				fmt.Printf("Error updating user details on %s Sign-In: %v\n", title, err)
+			}
+		}
+	} else {
+		// Matching by email is only safe if the provider has confirmed the user owns it
+		if profile.Email == "" || !profile.EmailVerified {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Your %s account needs a verified email address to sign in.", title),
+				"code":  "email_not_verified",
+			})
+			return
+		}
+
+		err = database.DB.Where("email = ?", profile.Email).First(&user).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error checking email"})
+			return
+		}
+
+		if err == nil {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":    fmt.Sprintf("Account with this email already exists. Please sign in with your original method and link %s from your profile.", title),
+				"provider": user.Provider,
+				"code":     "account_exists",
+			})
+			return
+		}
+
+		baseUsername := profile.Username
+		if baseUsername == "" {
+			baseUsername = strings.Split(profile.Email, "@")[0]
+		}
+		finalUsername := baseUsername
+		count := 0
+		for {
+			var tempUser models.User
+			if err := database.DB.Where("username = ?", finalUsername).First(&tempUser).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			count++
+			finalUsername = fmt.Sprintf("%s%d", baseUsername, count)
+			if count > 100 {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not generate unique username"})
+				return
+			}
+		}
+
+		newUser := models.NewOAuthUser(finalUsername, profile.Email, profile.DisplayName, profile.ProfilePictureURL, profile.Provider, profile.ProviderID)
+		err = database.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(newUser).Error; err != nil {
+				return err
+			}
+			return tx.Create(models.NewUserIdentity(newUser.ID, profile.Provider, profile.ProviderID)).Error
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Error creating new %s user", title)})
+			return
+		}
+		user = *newUser
+	}
+
+	token, err := utils.GenerateToken(user.Username, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating platform token"})
+		return
+	}
+	refreshToken, err := issueRefreshToken(&user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":         token,
+		"refresh_token": refreshToken,
+		"user": gin.H{
+			"id":                  user.ID,
+			"username":            user.Username,
+			"email":               user.Email,
+			"email_verified":      user.EmailVerified,
+			"display_name":        user.DisplayName,
+			"profile_picture_url": user.ProfilePictureURL,
+			"provider":            user.Provider,
+		},
+	})
+}
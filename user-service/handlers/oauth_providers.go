@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oauthCodeProvider exchanges an OAuth authorization code for the signed-in user's profile
+type oauthCodeProvider interface {
+	Name() string
+	FetchProfile(ctx context.Context, code, redirectURI string) (*oauthProfile, error)
+}
+
+// errProviderNotConfigured is returned when a provider's client credentials are not set
+var errProviderNotConfigured = errors.New("oauth provider is not configured")
+
+// oauthHTTPClient is shared by the providers for token exchanges and profile lookups
+var oauthHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// oauthProviders holds the code-flow providers by name; Google signs in with an ID token instead
+var oauthProviders = map[string]oauthCodeProvider{
+	"discord": discordProvider{},
+	"github":  githubProvider{},
+}
+
+// DiscordSignIn signs in with a Discord authorization code
+func DiscordSignIn(c *gin.Context) {
+	oauthCodeSignIn(c, oauthProviders["discord"])
+}
+
+// GitHubSignIn signs in with a GitHub authorization code
+func GitHubSignIn(c *gin.Context) {
+	oauthCodeSignIn(c, oauthProviders["github"])
+}
+
+// oauthCodeSignIn exchanges the posted authorization code with the provider and signs in the user
+func oauthCodeSignIn(c *gin.Context, provider oauthCodeProvider) {
+	var input struct {
+		Code        string `json:"code" binding:"required"`
+		RedirectURI string `json:"redirect_uri,omitempty"` // Must match the one used to obtain the code
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Authorization code is required"})
+		return
+	}
+
+	title := providerTitle(provider.Name())
+	profile, err := provider.FetchProfile(c.Request.Context(), input.Code, input.RedirectURI)
+	if err != nil {
+		fmt.Printf("%s sign-in error: %v\n", title, err)
+		if errors.Is(err, errProviderNotConfigured) {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Authentication configuration error. Please contact support.",
+				"code":  "auth_config_error",
+			})
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": fmt.Sprintf("%s authentication failed. Please try again or use a different sign-in method.", title),
+			"code":  provider.Name() + "_auth_failed",
+		})
+		return
+	}
+
+	signInWithOAuthProfile(c, profile)
+}
+
+// oauthCredentials reads a provider's client ID, secret and default redirect URL from the
+// environment, e.g. DISCORD_CLIENT_ID, DISCORD_CLIENT_SECRET and DISCORD_REDIRECT_URL
+func oauthCredentials(prefix string) (clientID, clientSecret, redirectURL string, err error) {
+	clientID = os.Getenv(prefix + "_CLIENT_ID")
+	clientSecret = os.Getenv(prefix + "_CLIENT_SECRET")
+	redirectURL = os.Getenv(prefix + "_REDIRECT_URL")
+	if clientID == "" || clientSecret == "" {
+		return "", "", "", fmt.Errorf("%w: %s_CLIENT_ID and %s_CLIENT_SECRET must be set", errProviderNotConfigured, prefix, prefix)
+	}
+	return clientID, clientSecret, redirectURL, nil
+}
+
+// exchangeOAuthCode trades an authorization code for an access token at tokenURL
+func exchangeOAuthCode(ctx context.Context, tokenURL string, form url.Values) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err := doOAuthRequest(req, &token); err != nil {
+		return "", fmt.Errorf("token exchange failed: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("token exchange failed: %s", token.Error)
+	}
+	return token.AccessToken, nil
+}
+
+// getOAuthJSON fetches a provider API resource with the user's access token
+func getOAuthJSON(ctx context.Context, apiURL, accessToken string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	return doOAuthRequest(req, out)
+}
+
+// doOAuthRequest sends req and decodes a successful JSON response into out
+func doOAuthRequest(req *http.Request, out interface{}) error {
+	resp, err := oauthHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s returned status %d: %s", req.URL.Host, resp.StatusCode, string(body))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// discordProvider signs in with Discord; the app needs the "identify" and "email" scopes
+type discordProvider struct{}
+
+func (discordProvider) Name() string { return "discord" }
+
+func (discordProvider) FetchProfile(ctx context.Context, code, redirectURI string) (*oauthProfile, error) {
+	clientID, clientSecret, redirectURL, err := oauthCredentials("DISCORD")
+	if err != nil {
+		return nil, err
+	}
+	if redirectURI == "" {
+		redirectURI = redirectURL
+	}
+
+	accessToken, err := exchangeOAuthCode(ctx, "https://discord.com/api/oauth2/token", url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var me struct {
+		ID         string `json:"id"`
+		Username   string `json:"username"`
+		GlobalName string `json:"global_name"`
+		Email      string `json:"email"`
+		Verified   bool   `json:"verified"`
+		Avatar     string `json:"avatar"`
+	}
+	if err := getOAuthJSON(ctx, "https://discord.com/api/users/@me", accessToken, &me); err != nil {
+		return nil, fmt.Errorf("profile lookup failed: %w", err)
+	}
+
+	profile := &oauthProfile{
+		Provider:      "discord",
+		ProviderID:    me.ID,
+		Email:         me.Email,
+		EmailVerified: me.Verified,
+		Username:      me.Username,
+		DisplayName:   me.GlobalName,
+	}
+	if me.Avatar != "" {
+		profile.ProfilePictureURL = fmt.Sprintf("https://cdn.discordapp.com/avatars/%s/%s.png", me.ID, me.Avatar)
+	}
+	return profile, nil
+}
+
+// githubProvider signs in with GitHub; the app needs the "read:user" and "user:email" scopes
+type githubProvider struct{}
+
+func (githubProvider) Name() string { return "github" }
+
+func (githubProvider) FetchProfile(ctx context.Context, code, redirectURI string) (*oauthProfile, error) {
+	clientID, clientSecret, redirectURL, err := oauthCredentials("GITHUB")
+	if err != nil {
+		return nil, err
+	}
+	if redirectURI == "" {
+		redirectURI = redirectURL
+	}
+
+	form := url.Values{
+		"code":          {code},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+	}
+	if redirectURI != "" {
+		form.Set("redirect_uri", redirectURI)
+	}
+	accessToken, err := exchangeOAuthCode(ctx, "https://github.com/login/oauth/access_token", form)
+	if err != nil {
+		return nil, err
+	}
+
+	var me struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := getOAuthJSON(ctx, "https://api.github.com/user", accessToken, &me); err != nil {
+		return nil, fmt.Errorf("profile lookup failed: %w", err)
+	}
+
+	// The profile's public email may be missing or unverified, so use the primary address instead
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getOAuthJSON(ctx, "https://api.github.com/user/emails", accessToken, &emails); err != nil {
+		return nil, fmt.Errorf("email lookup failed: %w", err)
+	}
+
+	profile := &oauthProfile{
+		Provider:          "github",
+		ProviderID:        strconv.FormatInt(me.ID, 10),
+		Username:          me.Login,
+		DisplayName:       me.Name,
+		ProfilePictureURL: me.AvatarURL,
+	}
+	for _, e := range emails {
+		if e.Primary {
+			profile.Email = e.Email
+			profile.EmailVerified = e.Verified
+			break
+		}
+	}
+	return profile, nil
+}
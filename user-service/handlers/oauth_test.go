@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// fakeProvider hands back a fixed profile for any authorization code
+type fakeProvider struct {
+	profile *oauthProfile
+	err     error
+}
+
+func (p fakeProvider) Name() string { return "discord" }
+
+func (p fakeProvider) FetchProfile(ctx context.Context, code, redirectURI string) (*oauthProfile, error) {
+	return p.profile, p.err
+}
+
+func discordProfile(username, email string) *oauthProfile {
+	return &oauthProfile{
+		Provider: "discord", ProviderID: "discord-" + username, Email: email, EmailVerified: true,
+		Username: username, DisplayName: username,
+	}
+}
+
+// signIn posts an authorization code to a sign-in endpoint backed by provider
+func signIn(provider oauthCodeProvider) (int, uuid.UUID, string) {
+	rec := serve(http.MethodPost, "/signin", "/signin", `{"code":"abc"}`, func(c *gin.Context) {
+		oauthCodeSignIn(c, provider)
+	})
+	var body struct {
+		Code string `json:"code"`
+		User struct {
+			ID       uuid.UUID `json:"id"`
+			Username string    `json:"username"`
+		} `json:"user"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if body.Code != "" {
+		return rec.Code, uuid.Nil, body.Code
+	}
+	return rec.Code, body.User.ID, body.User.Username
+}
+
+func TestOAuthSignInCreatesAndLinksANewUser(t *testing.T) {
+	store := useAccountStore(t)
+	store.addUser(t, "ace", "hunter22") // Takes the username the provider suggests
+
+	status, userID, username := signIn(fakeProvider{profile: discordProfile("ace", "ace@discord.example")})
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if username != "ace1" {
+		t.Fatalf("expected the taken username to get a number, got %q", username)
+	}
+	user := store.user(userID)
+	if user == nil || user.Provider != "discord" || !user.EmailVerified {
+		t.Fatalf("expected a verified discord user to be created, got %+v", user)
+	}
+	if providers := store.providers(userID); len(providers) != 1 || providers[0] != "discord" {
+		t.Fatalf("expected the discord identity to be linked, got %v", providers)
+	}
+}
+
+func TestOAuthSignInWithALinkedAccount(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	profile := discordProfile("someone-else", user.Email)
+	profile.DisplayName = "" // Nothing to copy onto the account
+	store.link(user.ID, "discord", profile.ProviderID)
+
+	status, userID, _ := signIn(fakeProvider{profile: profile})
+	if status != http.StatusOK || userID != user.ID {
+		t.Fatalf("expected to sign in as the linked user, got %d as %s", status, userID)
+	}
+	if len(store.users) != 1 {
+		t.Fatal("signing in with a linked account must not create a user")
+	}
+}
+
+func TestOAuthSignInRefusesToTakeOverAnExistingEmail(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+
+	status, _, code := signIn(fakeProvider{profile: discordProfile("ace", user.Email)})
+	if status != http.StatusConflict || code != "account_exists" {
+		t.Fatalf("expected a 409 account_exists, got %d %q", status, code)
+	}
+	if len(store.providers(user.ID)) != 0 || len(store.users) != 1 {
+		t.Fatal("the existing account must be left alone")
+	}
+}
+
+func TestOAuthSignInNeedsAVerifiedEmail(t *testing.T) {
+	store := useAccountStore(t)
+	profile := discordProfile("ace", "ace@discord.example")
+	profile.EmailVerified = false
+
+	if status, _, code := signIn(fakeProvider{profile: profile}); status != http.StatusBadRequest || code != "email_not_verified" {
+		t.Fatalf("expected a 400 email_not_verified, got %d %q", status, code)
+	}
+	if len(store.users) != 0 {
+		t.Fatal("no user should be created")
+	}
+}
+
+func TestOAuthSignInProviderFailures(t *testing.T) {
+	useAccountStore(t)
+
+	if status, _, code := signIn(fakeProvider{err: errProviderNotConfigured}); status != http.StatusInternalServerError || code != "auth_config_error" {
+		t.Fatalf("unconfigured provider: expected 500 auth_config_error, got %d %q", status, code)
+	}
+	if status, _, code := signIn(fakeProvider{err: errors.New("bad code")}); status != http.StatusUnauthorized || code != "discord_auth_failed" {
+		t.Fatalf("rejected code: expected 401 discord_auth_failed, got %d %q", status, code)
+	}
+}
+
+func TestProviderTitle(t *testing.T) {
+	for provider, want := range map[string]string{"github": "GitHub", "discord": "Discord", "google": "Google", "": ""} {
+		if got := providerTitle(provider); got != want {
+			t.Errorf("providerTitle(%q) = %q, want %q", provider, got, want)
+		}
+	}
+}
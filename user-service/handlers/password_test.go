@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/cliffdoyle/gamer_world/user-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const passwordResetTokens = "password_reset_tokens"
+
+// asUser runs handler with the user ID and username set the way AuthMiddleware sets them
+func asUser(user *models.User, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("user_id", user.ID)
+		c.Set("username", user.Username)
+		handler(c)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	store.addToken(refreshTokens, user.ID, time.Now().Add(time.Hour))
+	change := asUser(user, ChangePassword)
+
+	rec := serve(http.MethodPost, "/change", "/change", `{"current_password":"wrong","new_password":"n3w-secret"}`, change)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("wrong current password: expected 401, got %d", rec.Code)
+	}
+	if !utils.CheckPasswordHash("hunter22", store.user(user.ID).Password) {
+		t.Fatal("a rejected change must keep the old password")
+	}
+
+	rec = serve(http.MethodPost, "/change", "/change", `{"current_password":"hunter22","new_password":"n3w-secret"}`, change)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !utils.CheckPasswordHash("n3w-secret", store.user(user.ID).Password) {
+		t.Fatal("the new password was not stored")
+	}
+	if store.tokenCount(refreshTokens, user.ID) != 0 {
+		t.Fatal("changing the password should sign out other sessions")
+	}
+}
+
+func TestChangePasswordRejectsOAuthAccounts(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "googler", "")
+	user.Provider = "google"
+
+	rec := serve(http.MethodPost, "/change", "/change", `{"current_password":"anything","new_password":"n3w-secret"}`, asUser(user, ChangePassword))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an account without a password, got %d", rec.Code)
+	}
+	if store.user(user.ID).Password != "" {
+		t.Fatal("an OAuth account must not get a password")
+	}
+}
+
+func TestForgotAndResetPassword(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	store.addToken(refreshTokens, user.ID, time.Now().Add(time.Hour))
+
+	for _, email := range []string{"ace@example.com", "nobody@example.com"} {
+		rec := serve(http.MethodPost, "/forgot", "/forgot", `{"email":"`+email+`"}`, ForgotPassword)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected the same 200 for known and unknown emails, got %d", email, rec.Code)
+		}
+	}
+	if store.tokenCount(passwordResetTokens, user.ID) != 1 {
+		t.Fatal("expected a reset token for the known account")
+	}
+
+	// The raw token only goes out in the email, so reset with one issued directly
+	token := store.addToken(passwordResetTokens, user.ID, time.Now().Add(time.Hour))
+	body := `{"token":"` + token + `","new_password":"n3w-secret"}`
+	if rec := serve(http.MethodPost, "/reset", "/reset", body, ResetPassword); rec.Code != http.StatusOK {
+		t.Fatalf("reset: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !utils.CheckPasswordHash("n3w-secret", store.user(user.ID).Password) {
+		t.Fatal("the reset password was not stored")
+	}
+	if store.tokenCount(passwordResetTokens, user.ID) != 0 || store.tokenCount(refreshTokens, user.ID) != 0 {
+		t.Fatal("a reset should use up the reset tokens and sign out every session")
+	}
+	if rec := serve(http.MethodPost, "/reset", "/reset", body, ResetPassword); rec.Code != http.StatusBadRequest {
+		t.Fatalf("reusing a reset token: expected 400, got %d", rec.Code)
+	}
+}
+
+func TestExpiredResetTokenIsRejected(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	token := store.addToken(passwordResetTokens, user.ID, time.Now().Add(-time.Minute))
+
+	rec := serve(http.MethodPost, "/reset", "/reset", `{"token":"`+token+`","new_password":"n3w-secret"}`, ResetPassword)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	if !utils.CheckPasswordHash("hunter22", store.user(user.ID).Password) {
+		t.Fatal("an expired token must not change the password")
+	}
+}
@@ -1,25 +1,29 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/cliffdoyle/gamer_world/user-service/database"
 	"github.com/cliffdoyle/gamer_world/user-service/models"
 	"github.com/cliffdoyle/gamer_world/user-service/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 func GetUserProfile(c *gin.Context) {
-	username := c.GetString("username")
-	if username == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+	user, ok := authenticatedUser(c)
+	if !ok {
 		return
 	}
 
-	var user models.User
-	if err := database.DB.Where("username = ?", username).First(&user).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	providers, err := linkedProviders(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error listing linked accounts"})
 		return
 	}
 
@@ -28,6 +32,7 @@ func GetUserProfile(c *gin.Context) {
 			"id":                       user.ID,
 			"username":                 user.Username,
 			"email":                    user.Email,
+			"email_verified":           user.EmailVerified,
 			"display_name":             user.DisplayName,
 			"profile_picture_url":      user.ProfilePictureURL,
 			"bio":                      user.Bio,
@@ -37,6 +42,9 @@ func GetUserProfile(c *gin.Context) {
 			"preferred_fifa_version":   user.PreferredFifaVersion,
 			"favorite_real_world_club": user.FavoriteRealWorldClub,
 			"provider":                 user.Provider,
+			"linked_providers":         providers,
+			"has_password":             user.Password != "",
+			"last_username_change_at":  user.LastUsernameChangeAt,
 			"created_at":               user.CreatedAt,
 			"updated_at":               user.UpdatedAt,
 		},
@@ -44,15 +52,8 @@ func GetUserProfile(c *gin.Context) {
 }
 
 func UpdateUserProfile(c *gin.Context) {
-	currentUsername := c.GetString("username")
-	if currentUsername == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-		return
-	}
-
-	var user models.User
-	if err := database.DB.Where("username = ?", currentUsername).First(&user).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	user, ok := authenticatedUser(c)
+	if !ok {
 		return
 	}
 
@@ -75,16 +76,35 @@ func UpdateUserProfile(c *gin.Context) {
 		return
 	}
 
-	updated := false
+	updated, renamed := false, false
 
 	if input.Username != "" && input.Username != user.Username {
+		if err := utils.ValidateUsername(input.Username); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if user.LastUsernameChangeAt != nil {
+			if nextChange := user.LastUsernameChangeAt.Add(utils.UsernameChangeCooldown()); time.Now().Before(nextChange) {
+				c.JSON(http.StatusTooManyRequests, gin.H{
+					"error":                  "Username was changed recently",
+					"next_change_allowed_at": nextChange,
+				})
+				return
+			}
+		}
 		var count int64
-		database.DB.Model(&models.User{}).Where("username = ? AND id != ?", input.Username, user.ID).Count(&count)
+		if err := database.DB.Model(&models.User{}).Where("username = ? AND id != ?", input.Username, user.ID).Count(&count).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking username"})
+			return
+		}
 		if count > 0 {
 			c.JSON(http.StatusConflict, gin.H{"error": "Username already exists"})
 			return
 		}
+		now := time.Now()
 		user.Username = input.Username
+		user.LastUsernameChangeAt = &now
+		renamed = true
 		updated = true
 	}
 
@@ -109,6 +129,7 @@ func UpdateUserProfile(c *gin.Context) {
 			return
 		}
 		user.Email = input.Email
+		user.EmailVerified = false
 		updated = true
 	}
 
@@ -150,31 +171,54 @@ func UpdateUserProfile(c *gin.Context) {
 		return
 	}
 
-	if err := database.DB.Save(&user).Error; err != nil {
+	if err := database.DB.Save(user).Error; err != nil {
+		// The unique index still catches a username or email taken since the checks above
+		if strings.Contains(err.Error(), "duplicate key") {
+			c.JSON(http.StatusConflict, gin.H{"error": "Username or email already exists"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating user profile"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "User profile updated successfully"})
-}
+	if input.Email != "" && !user.EmailVerified {
+		if err := issueEmailVerification(user); err != nil {
+			log.Printf("Error issuing email verification for user '%s': %v", user.Username, err)
+		}
+	}
 
-func DeleteUserAccount(c *gin.Context) {
-	username := c.GetString("username")
-	if username == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-		return
+	// The caller's token still carries the old username, which other services show, so a rename
+	// comes with a token for the new one
+	response := gin.H{"message": "User profile updated successfully"}
+	if renamed {
+		token, err := utils.GenerateToken(user.Username, user.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
+			return
+		}
+		response["token"] = token
 	}
+	c.JSON(http.StatusOK, response)
+}
 
-	var user models.User
-	if err := database.DB.Where("username = ?", username).First(&user).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+func DeleteUserAccount(c *gin.Context) {
+	user, ok := authenticatedUser(c)
+	if !ok {
 		return
 	}
 
-	if err := database.DB.Delete(&user).Error; err != nil {
+	// The user row is only soft-deleted and anonymized, so other services' references to its ID
+	// still resolve; linked provider accounts and sessions are released explicitly
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		return anonymizeUser(tx, user)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting user account"})
 		return
 	}
+	if err := removeAvatar(user.ID.String()); err != nil {
+		log.Printf("Warning: could not remove avatar of deleted user %s: %v", user.ID, err)
+	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "User account deleted successfully"})
 }
@@ -201,20 +245,90 @@ func GetMultipleUserDetails(c *gin.Context) {
     }
 
 	var users []models.User // Your GORM User model
-	// Use GORM's "IN" condition to fetch multiple users by their IDs
-	if err := database.DB.Where("id IN ?", req.UserIDs).Find(&users).Error; err != nil {
+	// Use GORM's "IN" condition to fetch multiple users by their IDs. Deleted users are included
+	// under their anonymized name so other services can still label their old records.
+	if err := database.DB.Unscoped().Where("id IN ?", req.UserIDs).Find(&users).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error fetching user details"})
 		return
 	}
 
 	userDetailsMap := make(map[uuid.UUID]models.UserDetailResponse)
 	for _, u := range users {
-		userDetailsMap[u.ID] = models.UserDetailResponse{
-			ID:       u.ID,
-			Username: u.Username,
-			// DisplayName: u.DisplayName, // Uncomment if you want to return this
-		}
+		userDetailsMap[u.ID] = models.NewUserDetailResponse(u)
 	}
 
 	c.JSON(http.StatusOK, gin.H{"users": userDetailsMap})
+}
+
+// maxUserLookupIDs caps how many users one GetUsersByIDs request may ask for, as for POST /users/batch
+const maxUserLookupIDs = 100
+
+// GetPublicUser returns the public profile of a single user by ID
+func GetPublicUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	var user models.User
+	if err := database.DB.Where("id = ?", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error fetching user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": models.NewUserDetailResponse(user)})
+}
+
+// GetUsersByIDs returns the public profiles of the users in ?ids=, a comma-separated list.
+// IDs with no user are listed under not_found.
+func GetUsersByIDs(c *gin.Context) {
+	var userIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, raw := range strings.Split(c.Query("ids"), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID: " + raw})
+			return
+		}
+		if !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+	if len(userIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids query parameter is required"})
+		return
+	}
+	if len(userIDs) > maxUserLookupIDs {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Too many user IDs requested, limit is 100"})
+		return
+	}
+
+	var users []models.User
+	if err := database.DB.Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error fetching user details"})
+		return
+	}
+
+	userDetailsMap := make(map[uuid.UUID]models.UserDetailResponse, len(users))
+	for _, u := range users {
+		userDetailsMap[u.ID] = models.NewUserDetailResponse(u)
+	}
+	notFound := []uuid.UUID{}
+	for _, id := range userIDs {
+		if _, ok := userDetailsMap[id]; !ok {
+			notFound = append(notFound, id)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": userDetailsMap, "not_found": notFound})
 }
\ No newline at end of file
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/cliffdoyle/gamer_world/user-service/utils"
+	"github.com/google/uuid"
+)
+
+func TestGetPublicUserShowsOnlyPublicFields(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	user.DisplayName = "Ace"
+	user.ProfilePictureURL = "https://cdn.example/ace.png"
+
+	rec := serve(http.MethodGet, "/user/:userId", "/user/"+user.ID.String(), "", GetPublicUser)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		User map[string]interface{} `json:"user"`
+	}
+	decode(t, rec, &body)
+	if body.User["id"] != user.ID.String() || body.User["username"] != "ace" || body.User["display_name"] != "Ace" ||
+		body.User["profile_picture_url"] != "https://cdn.example/ace.png" {
+		t.Fatalf("unexpected profile %v", body.User)
+	}
+	for _, private := range []string{"email", "email_verified", "password", "provider"} {
+		if _, ok := body.User[private]; ok {
+			t.Errorf("public profile exposes %s", private)
+		}
+	}
+}
+
+func TestGetPublicUserErrors(t *testing.T) {
+	useAccountStore(t)
+
+	if rec := serve(http.MethodGet, "/user/:userId", "/user/"+uuid.NewString(), "", GetPublicUser); rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown user: expected 404, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodGet, "/user/:userId", "/user/not-a-uuid", "", GetPublicUser); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid ID: expected 400, got %d", rec.Code)
+	}
+}
+
+func TestGetUsersByIDsListsMissingIDs(t *testing.T) {
+	store := useAccountStore(t)
+	ace := store.addUser(t, "ace", "hunter22")
+	bo := store.addUser(t, "bo", "hunter22")
+	store.addUser(t, "cy", "hunter22")
+	missing := uuid.New()
+
+	ids := strings.Join([]string{ace.ID.String(), missing.String(), bo.ID.String(), ace.ID.String()}, ",")
+	rec := serve(http.MethodGet, "/user/batch", "/user/batch?ids="+ids, "", GetUsersByIDs)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		Users    map[string]map[string]interface{} `json:"users"`
+		NotFound []string                          `json:"not_found"`
+	}
+	decode(t, rec, &body)
+	if len(body.Users) != 2 || body.Users[ace.ID.String()]["username"] != "ace" || body.Users[bo.ID.String()]["username"] != "bo" {
+		t.Fatalf("expected ace and bo only, got %v", body.Users)
+	}
+	if _, ok := body.Users[ace.ID.String()]["email"]; ok {
+		t.Fatal("batch lookup exposes emails")
+	}
+	if len(body.NotFound) != 1 || body.NotFound[0] != missing.String() {
+		t.Fatalf("expected %s under not_found, got %v", missing, body.NotFound)
+	}
+	if args := store.db.argsOf("SELECT"); len(args) != 3 {
+		t.Fatalf("repeated IDs should be looked up once, got %v", args)
+	}
+}
+
+func TestGetUsersByIDsRejectsBadInput(t *testing.T) {
+	useAccountStore(t)
+	tooMany := make([]string, maxUserLookupIDs+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.NewString()
+	}
+
+	for name, query := range map[string]string{
+		"missing":  "",
+		"blank":    "?ids=,,",
+		"invalid":  "?ids=" + uuid.NewString() + ",not-a-uuid",
+		"too many": "?ids=" + strings.Join(tooMany, ","),
+	} {
+		if rec := serve(http.MethodGet, "/user/batch", "/user/batch"+query, "", GetUsersByIDs); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", name, rec.Code)
+		}
+	}
+}
+
+// updateProfile sends body to UpdateUserProfile as user
+func updateProfile(user *models.User, body string) int {
+	return serve(http.MethodPut, "/user/profile", "/user/profile", body, asUser(user, UpdateUserProfile)).Code
+}
+
+func TestUpdateUsernameMustBeUniqueAndValid(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	store.addUser(t, "bobby", "hunter22")
+
+	if status := updateProfile(user, `{"username":"bobby"}`); status != http.StatusConflict {
+		t.Fatalf("taken username: expected 409, got %d", status)
+	}
+	for _, invalid := range []string{"ab", "ace spaces", "ace<script>", strings.Repeat("a", 31)} {
+		if status := updateProfile(user, `{"username":"`+invalid+`"}`); status != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", invalid, status)
+		}
+	}
+	if stored := store.user(user.ID); stored.Username != "ace" || stored.LastUsernameChangeAt != nil {
+		t.Fatalf("a rejected rename changed the user: %+v", stored)
+	}
+
+	if status := updateProfile(user, `{"username":"ace_2.0"}`); status != http.StatusOK {
+		t.Fatalf("valid rename: expected 200, got %d", status)
+	}
+	if stored := store.user(user.ID); stored.Username != "ace_2.0" || stored.LastUsernameChangeAt == nil {
+		t.Fatalf("rename was not saved with its time: %+v", stored)
+	}
+}
+
+func TestUpdateUsernameCooldown(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	t.Setenv("USERNAME_CHANGE_COOLDOWN", "1h")
+	recently := time.Now().Add(-30 * time.Minute)
+	store.user(user.ID).LastUsernameChangeAt = &recently
+
+	rec := serve(http.MethodPut, "/user/profile", "/user/profile", `{"username":"ace2"}`, asUser(user, UpdateUserProfile))
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("rename within the cooldown: expected 429, got %d", rec.Code)
+	}
+	var body struct {
+		NextChangeAllowedAt time.Time `json:"next_change_allowed_at"`
+	}
+	decode(t, rec, &body)
+	if want := recently.Add(time.Hour); !body.NextChangeAllowedAt.Equal(want) {
+		t.Fatalf("expected the next change at %v, got %v", want, body.NextChangeAllowedAt)
+	}
+
+	// The display name stays editable during the cooldown
+	if status := updateProfile(user, `{"display_name":"Ace of Spades"}`); status != http.StatusOK {
+		t.Fatalf("display name change: expected 200, got %d", status)
+	}
+	if stored := store.user(user.ID); stored.Username != "ace" || stored.DisplayName != "Ace of Spades" {
+		t.Fatalf("unexpected user after the display name change: %+v", stored)
+	}
+
+	longAgo := time.Now().Add(-2 * time.Hour)
+	store.user(user.ID).LastUsernameChangeAt = &longAgo
+	if status := updateProfile(user, `{"username":"ace2"}`); status != http.StatusOK {
+		t.Fatalf("rename after the cooldown: expected 200, got %d", status)
+	}
+	if stored := store.user(user.ID); stored.Username != "ace2" || !stored.LastUsernameChangeAt.After(longAgo) {
+		t.Fatalf("rename after the cooldown was not saved: %+v", stored)
+	}
+}
+
+func TestRenameKeepsTheSessionOnTheSameAccount(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	// The claims of the token issued before the rename
+	token := &models.User{ID: user.ID, Username: "ace"}
+
+	rec := serve(http.MethodPut, "/user/profile", "/user/profile", `{"username":"ace2"}`, asUser(token, UpdateUserProfile))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("rename: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		Token string `json:"token"`
+	}
+	decode(t, rec, &body)
+	claims, err := utils.ValidateAuthToken(body.Token)
+	if err != nil || claims.UserID != user.ID || claims.Username != "ace2" {
+		t.Fatalf("expected a token for the new username, got %+v (%v)", claims, err)
+	}
+
+	// Someone takes the old name; the old token still acts on the renamed account only
+	other := store.addUser(t, "ace", "s3cret-pass")
+	if rec := serve(http.MethodPut, "/user/profile", "/user/profile", `{"display_name":"Ace"}`, asUser(token, UpdateUserProfile)); rec.Code != http.StatusOK {
+		t.Fatalf("update with the old token: expected 200, got %d", rec.Code)
+	}
+	if store.user(user.ID).DisplayName != "Ace" || store.user(other.ID).DisplayName != "" {
+		t.Fatalf("the old token changed the wrong account: %+v and %+v", store.user(user.ID), store.user(other.ID))
+	}
+
+	// Other changes keep the current token
+	rec = serve(http.MethodPut, "/user/profile", "/user/profile", `{"display_name":"Ace of Spades"}`, asUser(user, UpdateUserProfile))
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), `"token"`) {
+		t.Fatalf("only a rename should issue a token, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
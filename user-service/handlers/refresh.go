@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/cliffdoyle/gamer_world/user-service/database"
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/cliffdoyle/gamer_world/user-service/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// issueRefreshToken creates and stores a new refresh token for the user, returning the raw token
+func issueRefreshToken(user *models.User) (string, error) {
+	token, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	record := models.NewRefreshToken(user.ID, hash, time.Now().Add(utils.RefreshTokenTTL()))
+	if err := database.DB.Create(record).Error; err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RefreshToken exchanges a valid refresh token for a new access token
+func RefreshToken(c *gin.Context) {
+	var input struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var stored models.RefreshToken
+	err := database.DB.Where("token_hash = ?", utils.HashOpaqueToken(input.RefreshToken)).First(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking refresh token"})
+		return
+	}
+
+	if !time.Now().Before(stored.ExpiresAt) {
+		if err := database.DB.Delete(&stored).Error; err != nil {
+			log.Printf("Error deleting expired refresh token %s: %v", stored.ID, err)
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token has expired"})
+		return
+	}
+
+	// Tokens of deleted accounts stop working even before they expire
+	var user models.User
+	if err := database.DB.Where("id = ?", stored.UserID).First(&user).Error; err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+
+	token, err := utils.GenerateToken(user.Username, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+// Logout revokes a refresh token. Unknown tokens are ignored so logging out twice is harmless.
+func Logout(c *gin.Context) {
+	var input struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := database.DB.Where("token_hash = ?", utils.HashOpaqueToken(input.RefreshToken)).Delete(&models.RefreshToken{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error revoking refresh token"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/gamer_world/user-service/utils"
+)
+
+const refreshTokens = "refresh_tokens"
+
+func TestLoginIssuesARefreshTokenThatCanBeExchanged(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+
+	rec := serve(http.MethodPost, "/login", "/login", `{"username":"ace","password":"hunter22"}`, Login)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var login struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	decode(t, rec, &login)
+	if login.Token == "" || login.RefreshToken == "" || store.tokenCount(refreshTokens, user.ID) != 1 {
+		t.Fatalf("expected an access token and a stored refresh token, got %+v", login)
+	}
+	if _, stored := store.tokens[refreshTokens][login.RefreshToken]; stored {
+		t.Fatal("the raw refresh token must not be stored, only its hash")
+	}
+
+	rec = serve(http.MethodPost, "/refresh", "/refresh", `{"refresh_token":"`+login.RefreshToken+`"}`, RefreshToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("refresh: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var refreshed struct {
+		Token string `json:"token"`
+	}
+	decode(t, rec, &refreshed)
+	claims, err := utils.ValidateAuthToken(refreshed.Token)
+	if err != nil || claims.UserID != user.ID || claims.Username != "ace" {
+		t.Fatalf("expected a valid access token for ace, got %+v (%v)", claims, err)
+	}
+}
+
+func TestRefreshTokenIsRejectedAfterLogout(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	token := store.addToken(refreshTokens, user.ID, time.Now().Add(time.Hour))
+	body := `{"refresh_token":"` + token + `"}`
+
+	if rec := serve(http.MethodPost, "/logout", "/logout", body, Logout); rec.Code != http.StatusNoContent {
+		t.Fatalf("logout: expected 204, got %d", rec.Code)
+	}
+	if store.tokenCount(refreshTokens, user.ID) != 0 {
+		t.Fatal("logout should delete the stored refresh token")
+	}
+	if rec := serve(http.MethodPost, "/refresh", "/refresh", body, RefreshToken); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("reuse after logout: expected 401, got %d", rec.Code)
+	}
+	// Logging out twice is harmless
+	if rec := serve(http.MethodPost, "/logout", "/logout", body, Logout); rec.Code != http.StatusNoContent {
+		t.Fatalf("second logout: expected 204, got %d", rec.Code)
+	}
+}
+
+func TestExpiredRefreshTokenIsRejectedAndDeleted(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	token := store.addToken(refreshTokens, user.ID, time.Now().Add(-time.Minute))
+
+	rec := serve(http.MethodPost, "/refresh", "/refresh", `{"refresh_token":"`+token+`"}`, RefreshToken)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+	if store.tokenCount(refreshTokens, user.ID) != 0 {
+		t.Fatal("an expired refresh token should be cleaned up")
+	}
+}
+
+func TestRefreshTokenTTL(t *testing.T) {
+	t.Setenv("REFRESH_TOKEN_TTL", "")
+	if got := utils.RefreshTokenTTL(); got != 30*24*time.Hour {
+		t.Fatalf("expected a 30 day default, got %s", got)
+	}
+	t.Setenv("REFRESH_TOKEN_TTL", "2h")
+	if got := utils.RefreshTokenTTL(); got != 2*time.Hour {
+		t.Fatalf("expected the configured 2h, got %s", got)
+	}
+}
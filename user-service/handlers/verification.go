@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cliffdoyle/gamer_world/user-service/database"
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/cliffdoyle/gamer_world/user-service/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// issueEmailVerification replaces any pending verification token for the user with a new one
+// and sends the verification link
+func issueEmailVerification(user *models.User) error {
+	token, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", user.ID).Delete(&models.EmailVerificationToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(models.NewEmailVerificationToken(user.ID, hash, time.Now().Add(utils.EmailVerificationTTL()))).Error
+	})
+	if err != nil {
+		return err
+	}
+	sendEmailVerificationLink(user, token)
+	return nil
+}
+
+// sendEmailVerificationLink delivers the verification link for a user. Like password reset links,
+// only the request is logged until there is a mail integration; see logAuthLink.
+func sendEmailVerificationLink(user *models.User, token string) {
+	baseURL := os.Getenv("EMAIL_VERIFICATION_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8081/auth/verify-email"
+	}
+	logAuthLink("Email verification", user, baseURL, token)
+}
+
+// VerifyEmail marks the account a verification token was issued for as verified
+func VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Verification token is required"})
+		return
+	}
+
+	var stored models.EmailVerificationToken
+	err := database.DB.Where("token_hash = ?", utils.HashOpaqueToken(token)).First(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired verification token"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking verification token"})
+		return
+	}
+	if !time.Now().Before(stored.ExpiresAt) {
+		if err := database.DB.Delete(&stored).Error; err != nil {
+			log.Printf("Error deleting expired email verification token %s: %v", stored.ID, err)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired verification token"})
+		return
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", stored.UserID).Update("email_verified", true).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", stored.UserID).Delete(&models.EmailVerificationToken{}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error verifying email"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+// ResendVerificationEmail sends a new verification link to the authenticated user, e.g. after
+// the previous one expired
+func ResendVerificationEmail(c *gin.Context) {
+	user, ok := authenticatedUser(c)
+	if !ok {
+		return
+	}
+	if user.EmailVerified {
+		c.JSON(http.StatusConflict, gin.H{"error": "Email is already verified"})
+		return
+	}
+	if user.Email == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Account has no email address"})
+		return
+	}
+
+	if err := issueEmailVerification(user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating verification token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Verification email sent"})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cliffdoyle/gamer_world/user-service/models"
+	"github.com/google/uuid"
+)
+
+const emailVerificationTokens = "email_verification_tokens"
+
+func TestRegisterStartsUnverifiedWithAVerificationToken(t *testing.T) {
+	store := useAccountStore(t)
+
+	rec := serve(http.MethodPost, "/register", "/register", `{"username":"ace","password":"hunter22","email":"ace@example.com"}`, Register)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		User struct {
+			ID            uuid.UUID `json:"id"`
+			EmailVerified bool      `json:"email_verified"`
+		} `json:"user"`
+	}
+	decode(t, rec, &body)
+	if body.User.EmailVerified || store.user(body.User.ID).EmailVerified {
+		t.Fatal("a credentials account should start unverified")
+	}
+	if store.tokenCount(emailVerificationTokens, body.User.ID) != 1 {
+		t.Fatal("expected a verification token to be issued on registration")
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	token := store.addToken(emailVerificationTokens, user.ID, time.Now().Add(time.Hour))
+
+	if rec := serve(http.MethodGet, "/verify", "/verify?token="+token, "", VerifyEmail); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !store.user(user.ID).EmailVerified {
+		t.Fatal("the account was not marked verified")
+	}
+	if store.tokenCount(emailVerificationTokens, user.ID) != 0 {
+		t.Fatal("the verification token should be used up")
+	}
+	if rec := serve(http.MethodGet, "/verify", "/verify?token="+token, "", VerifyEmail); rec.Code != http.StatusBadRequest {
+		t.Fatalf("reusing a verification token: expected 400, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodPost, "/resend", "/resend", "", asUser(user, ResendVerificationEmail)); rec.Code != http.StatusConflict {
+		t.Fatalf("resending for a verified account: expected 409, got %d", rec.Code)
+	}
+}
+
+func TestExpiredVerificationTokenIsRejected(t *testing.T) {
+	store := useAccountStore(t)
+	user := store.addUser(t, "ace", "hunter22")
+	token := store.addToken(emailVerificationTokens, user.ID, time.Now().Add(-time.Minute))
+
+	if rec := serve(http.MethodGet, "/verify", "/verify?token="+token, "", VerifyEmail); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	if store.user(user.ID).EmailVerified || store.tokenCount(emailVerificationTokens, user.ID) != 0 {
+		t.Fatal("an expired token should be deleted without verifying the account")
+	}
+
+	// A fresh link replaces it
+	if rec := serve(http.MethodPost, "/resend", "/resend", "", asUser(user, ResendVerificationEmail)); rec.Code != http.StatusOK {
+		t.Fatalf("resend: expected 200, got %d", rec.Code)
+	}
+	if store.tokenCount(emailVerificationTokens, user.ID) != 1 {
+		t.Fatal("expected a new verification token")
+	}
+}
+
+func TestOAuthUsersAreVerified(t *testing.T) {
+	if user := models.NewOAuthUser("googler", "g@example.com", "G", "", "google", "123"); !user.EmailVerified {
+		t.Fatal("the provider has verified the email already")
+	}
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"strings"
 
 	"github.com/cliffdoyle/gamer_world/user-service/database"
 	"github.com/cliffdoyle/gamer_world/user-service/handlers"
@@ -22,7 +25,16 @@ func main() {
 	r := gin.Default()
 
 	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{"http://localhost:3000"} // Update for production
+	origins, err := parseAllowedOrigins(os.Getenv("CORS_ALLOWED_ORIGINS"), []string{"http://localhost:3000"})
+	if err != nil {
+		log.Fatalf("Invalid CORS_ALLOWED_ORIGINS: %v", err)
+	}
+	if origins[0] == "*" {
+		config.AllowAllOrigins = true
+	} else {
+		config.AllowOrigins = origins
+	}
+	log.Printf("CORS allowed origins: %s", strings.Join(origins, ", "))
 	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
 	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
 	r.Use(cors.New(config))
@@ -32,12 +44,24 @@ func main() {
 	})
 
 	r.POST("/users/batch", handlers.GetMultipleUserDetails)
+	// Public profile lookups for the other services; only public fields are returned
+	r.GET("/user/batch", handlers.GetUsersByIDs)
+	r.GET("/user/:userId", handlers.GetPublicUser)
+	// Uploaded avatars, stored under AVATAR_DIR
+	r.Static("/avatars", handlers.AvatarDir())
 	// Public auth routes
 	authRoutes := r.Group("/auth")
 	{
 		authRoutes.POST("/register", handlers.Register)
 		authRoutes.POST("/login", handlers.Login)
 		authRoutes.POST("/google/signin", handlers.GoogleSignIn) // New route for Google Sign-In
+		authRoutes.POST("/discord/signin", handlers.DiscordSignIn)
+		authRoutes.POST("/github/signin", handlers.GitHubSignIn)
+		authRoutes.POST("/refresh", handlers.RefreshToken)
+		authRoutes.POST("/logout", handlers.Logout)
+		authRoutes.POST("/forgot-password", handlers.ForgotPassword)
+		authRoutes.POST("/reset-password", handlers.ResetPassword)
+		authRoutes.GET("/verify-email", handlers.VerifyEmail)
 	}
 
 	// Protected user routes (profile related)
@@ -46,13 +70,25 @@ func main() {
 	{
 		userRoutes.GET("/profile", handlers.GetUserProfile)
 		userRoutes.PUT("/profile", handlers.UpdateUserProfile)
+		userRoutes.POST("/avatar", handlers.UploadAvatar)
+		userRoutes.POST("/change-password", handlers.ChangePassword)
+		userRoutes.POST("/resend-verification", handlers.ResendVerificationEmail)
+		userRoutes.POST("/link/:provider", handlers.LinkProvider)
+		userRoutes.DELETE("/unlink/:provider", handlers.UnlinkProvider)
 		userRoutes.DELETE("/account", handlers.DeleteUserAccount) // Changed from /profile to /account for clarity
+		userRoutes.GET("/export", handlers.ExportUserData)
 
 		//Added new routes for linking other services to get a list of users for linking 
 		//to tournament participants
 		userRoutes.GET("/list-for-linking", handlers.ListUsersForLinking)
 	}
 
+	// Internal admin routes; need the X-Internal-Service-Key header
+	adminRoutes := r.Group("/admin", middleware.RequireInternalServiceKey())
+	{
+		adminRoutes.DELETE("/users/:userId", handlers.PurgeUserAccount)
+	}
+
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
 		port = "8081" // Default port if not set
@@ -61,3 +97,40 @@ func main() {
 	log.Printf("User service is running on port: %s", port)
 	r.Run(":" + port)
 }
+
+// parseAllowedOrigins reads a comma-separated CORS_ALLOWED_ORIGINS value such as
+// "https://app.example.com,https://admin.example.com", falling back to defaults when it is empty.
+// A lone "*" allows every origin. Each origin must be an http(s) scheme and host with no path.
+func parseAllowedOrigins(raw string, defaults []string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return defaults, nil
+	}
+	var origins []string
+	for _, origin := range strings.Split(raw, ",") {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		if origin == "" {
+			continue
+		}
+		if origin == "*" {
+			origins = append(origins, origin)
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return nil, fmt.Errorf("invalid CORS origin %q: expected scheme://host[:port]", origin)
+		}
+		origins = append(origins, origin)
+	}
+	if len(origins) == 0 {
+		return defaults, nil
+	}
+	if len(origins) > 1 {
+		for _, origin := range origins {
+			if origin == "*" {
+				return nil, fmt.Errorf("CORS origin \"*\" cannot be combined with other origins")
+			}
+		}
+	}
+	return origins, nil
+}
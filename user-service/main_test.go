@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestParseAllowedOrigins(t *testing.T) {
+	defaults := []string{"http://localhost:3000"}
+	for raw, want := range map[string][]string{
+		"":    defaults,
+		" , ": defaults,
+		"https://app.example.com/, http://localhost:8080": {"https://app.example.com", "http://localhost:8080"},
+		" * ": {"*"},
+	} {
+		origins, err := parseAllowedOrigins(raw, defaults)
+		if err != nil {
+			t.Fatalf("parseAllowedOrigins(%q): %v", raw, err)
+		}
+		if len(origins) != len(want) {
+			t.Fatalf("parseAllowedOrigins(%q) = %v, want %v", raw, origins, want)
+		}
+		for i := range want {
+			if origins[i] != want[i] {
+				t.Fatalf("parseAllowedOrigins(%q) = %v, want %v", raw, origins, want)
+			}
+		}
+	}
+
+	for _, raw := range []string{
+		"app.example.com",
+		"ftp://files.example.com",
+		"https://app.example.com/path",
+		"https://user@app.example.com",
+		"*,https://app.example.com",
+	} {
+		if _, err := parseAllowedOrigins(raw, defaults); err == nil {
+			t.Errorf("parseAllowedOrigins(%q) should be rejected", raw)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"os"
 	"strings"
 
 	"github.com/cliffdoyle/gamer_world/user-service/utils"
@@ -42,3 +43,16 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequireInternalServiceKey rejects requests whose X-Internal-Service-Key header does not match
+// INTERNAL_SERVICE_KEY; with no key configured every request is rejected
+func RequireInternalServiceKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := os.Getenv("INTERNAL_SERVICE_KEY")
+		if key == "" || c.GetHeader("X-Internal-Service-Key") != key {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "A valid X-Internal-Service-Key header is required"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// guarded sends a request with key as X-Internal-Service-Key through RequireInternalServiceKey
+func guarded(key string) int {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.DELETE("/admin/users/:userId", RequireInternalServiceKey(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodDelete, "/admin/users/42", nil)
+	if key != "" {
+		request.Header.Set("X-Internal-Service-Key", key)
+	}
+	router.ServeHTTP(recorder, request)
+	return recorder.Code
+}
+
+func TestRequireInternalServiceKey(t *testing.T) {
+	t.Setenv("INTERNAL_SERVICE_KEY", "s3cret")
+	if status := guarded("s3cret"); status != http.StatusOK {
+		t.Fatalf("matching key: expected 200, got %d", status)
+	}
+	for _, key := range []string{"", "wrong"} {
+		if status := guarded(key); status != http.StatusForbidden {
+			t.Errorf("key %q: expected 403, got %d", key, status)
+		}
+	}
+
+	// With no key configured nothing gets through
+	t.Setenv("INTERNAL_SERVICE_KEY", "")
+	if status := guarded(""); status != http.StatusForbidden {
+		t.Fatalf("unconfigured key: expected 403, got %d", status)
+	}
+}
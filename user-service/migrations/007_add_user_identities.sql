@@ -0,0 +1,17 @@
+-- Linked OAuth accounts; a user may link several providers but only one account per provider
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    provider_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_user_provider ON user_identities(user_id, provider);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_provider_account ON user_identities(provider, provider_id);
+
+-- Carry over the single provider each user signed up with
+INSERT INTO user_identities (user_id, provider, provider_id)
+SELECT id, provider, provider_id FROM users
+WHERE provider_id IS NOT NULL AND provider <> 'credentials' AND deleted_at IS NULL
+ON CONFLICT DO NOTHING;
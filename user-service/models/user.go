@@ -17,12 +17,24 @@ type UserDetailResponse struct {
     ID       uuid.UUID `json:"id"`
     Username string    `json:"username"`
     DisplayName string `json:"display_name,omitempty"` // Optional
+    ProfilePictureURL string `json:"profile_picture_url,omitempty"`
+}
+
+// NewUserDetailResponse keeps only the public fields of a user
+func NewUserDetailResponse(u User) UserDetailResponse {
+    return UserDetailResponse{
+        ID:                u.ID,
+        Username:          u.Username,
+        DisplayName:       u.DisplayName,
+        ProfilePictureURL: u.ProfilePictureURL,
+    }
 }
 
 type User struct {
 	ID                    uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
 	Username              string         `gorm:"type:varchar(255);unique;not null" json:"username"`
 	Email                 string         `gorm:"type:varchar(255);unique" json:"email,omitempty"`
+	EmailVerified         bool           `gorm:"not null;default:false" json:"email_verified"`
 	Password              string         `gorm:"type:varchar(255);" json:"-"` // Password can be null for OAuth users
 	DisplayName           string         `gorm:"type:varchar(255)" json:"display_name,omitempty"`
 	ProfilePictureURL     string         `gorm:"type:text" json:"profile_picture_url,omitempty"`
@@ -34,6 +46,7 @@ type User struct {
 	FavoriteRealWorldClub string         `gorm:"type:varchar(100)" json:"favorite_real_world_club,omitempty"`
 	Provider              string         `gorm:"type:varchar(50);not null;default:'credentials'" json:"provider,omitempty"`            // e.g., "google", "credentials"
 	ProviderID            *string         `gorm:"type:varchar(255);" json:"provider_id,omitempty"` // Unique ID from the provider
+	LastUsernameChangeAt  *time.Time     `json:"last_username_change_at,omitempty"` // Nil until the user first renames themselves
 	CreatedAt             time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt             time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
 	DeletedAt             gorm.DeletedAt `gorm:"index" json:"-"`
@@ -74,5 +87,6 @@ func NewOAuthUser(username, email, displayName, profilePictureURL, provider, pro
 		ProfilePictureURL: profilePictureURL,
 		Provider:          provider,
 		ProviderID:        &providerID,
+		EmailVerified:     true, // The provider has already verified the email
 	}
 }
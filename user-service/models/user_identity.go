@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity links a user to an account at an OAuth provider. A user can link several
+// providers, but only one account per provider, and a provider account belongs to one user.
+type UserIdentity struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_user_identities_user_provider" json:"user_id"`
+	Provider   string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_identities_user_provider;uniqueIndex:idx_user_identities_provider_account" json:"provider"`
+	ProviderID string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_provider_account" json:"provider_id"`
+	CreatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// NewUserIdentity creates a link between a user and a provider account
+func NewUserIdentity(userID uuid.UUID, provider, providerID string) *UserIdentity {
+	return &UserIdentity{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Provider:   provider,
+		ProviderID: providerID,
+	}
+}
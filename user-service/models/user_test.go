@@ -0,0 +1,24 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUserDetailResponseLeavesOutPrivateFields(t *testing.T) {
+	user := NewOAuthUser("ace", "ace@example.com", "Ace", "https://cdn.example/ace.png", "github", "42")
+	user.Bio = "hi"
+
+	encoded, err := json.Marshal(NewUserDetailResponse(*user))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(fields) != 4 || fields["id"] != user.ID.String() || fields["username"] != "ace" ||
+		fields["display_name"] != "Ace" || fields["profile_picture_url"] != "https://cdn.example/ace.png" {
+		t.Fatalf("expected only id, username, display name and picture, got %v", fields)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// runChecker runs the checker with GOOGLE_CLIENT_ID set to clientID and returns what it printed
+func runChecker(t *testing.T, clientID string) string {
+	t.Helper()
+	t.Setenv("GOOGLE_CLIENT_ID", clientID)
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var out bytes.Buffer
+		io.Copy(&out, r)
+		done <- out.String()
+	}()
+
+	main()
+	w.Close()
+	os.Stdout = stdout
+	return <-done
+}
+
+func TestCheckerMasksTheClientID(t *testing.T) {
+	clientID := "123456789012-abcdefghijklmnop.apps.googleusercontent.com"
+
+	out := runChecker(t, clientID)
+	if strings.Contains(out, clientID) {
+		t.Fatalf("the full client ID should not be printed:\n%s", out)
+	}
+	if !strings.Contains(out, "GOOGLE_CLIENT_ID is set to: 123456789012...tent.com") {
+		t.Fatalf("expected the masked client ID:\n%s", out)
+	}
+}
+
+func TestCheckerReportsAMissingClientID(t *testing.T) {
+	if out := runChecker(t, ""); !strings.Contains(out, "GOOGLE_CLIENT_ID environment variable is not set") {
+		t.Fatalf("expected the missing variable to be reported:\n%s", out)
+	}
+}
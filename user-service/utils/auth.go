@@ -1,6 +1,10 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"os"
 	"time"
 
@@ -70,3 +74,56 @@ func ValidateAuthToken(tokenString string) (*Claims, error) {
 
 	return nil, jwt.ErrTokenUnverifiable
 }
+
+// RefreshTokenTTL returns how long refresh tokens stay valid, from REFRESH_TOKEN_TTL
+// (a Go duration such as "720h"); it defaults to 30 days
+func RefreshTokenTTL() time.Duration {
+	if ttl, err := time.ParseDuration(os.Getenv("REFRESH_TOKEN_TTL")); err == nil && ttl > 0 {
+		return ttl
+	}
+	return 30 * 24 * time.Hour
+}
+
+// PasswordResetTTL returns how long password reset tokens stay valid, from PASSWORD_RESET_TTL;
+// it defaults to one hour
+func PasswordResetTTL() time.Duration {
+	if ttl, err := time.ParseDuration(os.Getenv("PASSWORD_RESET_TTL")); err == nil && ttl > 0 {
+		return ttl
+	}
+	return time.Hour
+}
+
+// EmailVerificationTTL returns how long email verification tokens stay valid, from
+// EMAIL_VERIFICATION_TTL; it defaults to 24 hours
+func EmailVerificationTTL() time.Duration {
+	if ttl, err := time.ParseDuration(os.Getenv("EMAIL_VERIFICATION_TTL")); err == nil && ttl > 0 {
+		return ttl
+	}
+	return 24 * time.Hour
+}
+
+// UsernameChangeCooldown returns how long a user must wait between username changes, from
+// USERNAME_CHANGE_COOLDOWN; it defaults to 30 days
+func UsernameChangeCooldown() time.Duration {
+	if cooldown, err := time.ParseDuration(os.Getenv("USERNAME_CHANGE_COOLDOWN")); err == nil && cooldown >= 0 {
+		return cooldown
+	}
+	return 30 * 24 * time.Hour
+}
+
+// GenerateOpaqueToken creates a random token (e.g. a refresh or password reset token) and
+// returns it with the hash to store
+func GenerateOpaqueToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashOpaqueToken(token), nil
+}
+
+// HashOpaqueToken returns the hex-encoded SHA-256 hash under which an opaque token is stored
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
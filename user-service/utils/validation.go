@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// usernamePattern allows 3 to 30 letters, digits, underscores, dots and hyphens
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{3,30}$`)
+
+// ValidateUsername reports why a username chosen by a user is not acceptable, or nil if it is
+func ValidateUsername(username string) error {
+	if !usernamePattern.MatchString(username) {
+		return fmt.Errorf("username must be 3 to 30 characters of letters, digits, '_', '.' or '-'")
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateUsername(t *testing.T) {
+	for _, valid := range []string{"ace", "Ace_of.Spades-99", strings.Repeat("a", 30)} {
+		if err := ValidateUsername(valid); err != nil {
+			t.Errorf("%q should be valid: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "ab", strings.Repeat("a", 31), "two words", "émile", "ace@home"} {
+		if ValidateUsername(invalid) == nil {
+			t.Errorf("%q should be rejected", invalid)
+		}
+	}
+}
+
+func TestUsernameChangeCooldown(t *testing.T) {
+	t.Setenv("USERNAME_CHANGE_COOLDOWN", "")
+	if got := UsernameChangeCooldown(); got != 30*24*time.Hour {
+		t.Fatalf("expected a 30 day default, got %v", got)
+	}
+	t.Setenv("USERNAME_CHANGE_COOLDOWN", "72h")
+	if got := UsernameChangeCooldown(); got != 72*time.Hour {
+		t.Fatalf("expected 72h, got %v", got)
+	}
+	for _, bad := range []string{"soon", "-1h"} {
+		t.Setenv("USERNAME_CHANGE_COOLDOWN", bad)
+		if got := UsernameChangeCooldown(); got != 30*24*time.Hour {
+			t.Errorf("%q should fall back to the default, got %v", bad, got)
+		}
+	}
+}